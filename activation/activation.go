@@ -3,12 +3,16 @@
 package activation
 
 import (
+	"bytes"
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/spacemeshos/ed25519"
 	"github.com/spacemeshos/post/shared"
 	"go.uber.org/atomic"
 
@@ -20,6 +24,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/datastore"
 	"github.com/spacemeshos/go-spacemesh/log"
 	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
+	"github.com/spacemeshos/go-spacemesh/signing"
 	"github.com/spacemeshos/go-spacemesh/sql"
 	"github.com/spacemeshos/go-spacemesh/sql/atxs"
 	"github.com/spacemeshos/go-spacemesh/sql/kvstore"
@@ -30,6 +35,25 @@ type PoetConfig struct {
 	PhaseShift  time.Duration `mapstructure:"phase-shift"`
 	CycleGap    time.Duration `mapstructure:"cycle-gap"`
 	GracePeriod time.Duration `mapstructure:"grace-period"`
+	// ServiceIDs are hex encoded public keys of the trusted poet services.
+	// If not empty, poet servers that report other ids are rejected on update.
+	ServiceIDs []string `mapstructure:"poet-service-ids"`
+}
+
+// ParseServiceIDs decodes public keys of the trusted poet services.
+func (c PoetConfig) ParseServiceIDs() ([][]byte, error) {
+	ids := make([][]byte, 0, len(c.ServiceIDs))
+	for _, encoded := range c.ServiceIDs {
+		id, err := hex.DecodeString(strings.TrimPrefix(encoded, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("decode poet service id %s: %w", encoded, err)
+		}
+		if len(id) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("poet service id %s: expected %d bytes, got %d", encoded, ed25519.PublicKeySize, len(id))
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
 }
 
 func DefaultPoetConfig() PoetConfig {
@@ -40,7 +64,8 @@ const defaultPoetRetryInterval = 5 * time.Second
 
 type nipostBuilder interface {
 	updatePoETProvers([]PoetProvingServiceClient)
-	BuildNIPost(ctx context.Context, challenge *types.Hash32, commitmentAtx types.ATXID, poetProofDeadline time.Time) (*types.NIPost, time.Duration, error)
+	setDeadlineMonitor(*deadlineMonitor)
+	BuildNIPost(ctx context.Context, challenge *types.Hash32, commitmentAtx types.ATXID, poetRoundStart, poetProofDeadline time.Time) (*types.NIPost, time.Duration, error)
 }
 
 type atxHandler interface {
@@ -50,7 +75,7 @@ type atxHandler interface {
 }
 
 type signer interface {
-	Sign(m []byte) []byte
+	SignRequest(signing.Request) ([]byte, error)
 }
 
 type syncer interface {
@@ -118,6 +143,9 @@ type Builder struct {
 	// when we need to collect the PoET proofs and start building
 	// PoST to complete building NiPoST.
 	lastPostGenDuration time.Duration
+	// deadlines tracks the phases of the ATX construction.
+	deadlines      *deadlineMonitor
+	deadlineMargin time.Duration
 }
 
 // BuilderOption ...
@@ -131,6 +159,14 @@ func WithPoetRetryInterval(interval time.Duration) BuilderOption {
 	}
 }
 
+// WithDeadlineMargin sets how long before the deadline of a phase of the ATX construction
+// the phase is reported to be at risk.
+func WithDeadlineMargin(margin time.Duration) BuilderOption {
+	return func(b *Builder) {
+		b.deadlineMargin = margin
+	}
+}
+
 // PoETClientInitializer interfaces for creating PoetProvingServiceClient.
 type PoETClientInitializer func(string) PoetProvingServiceClient
 
@@ -179,10 +215,13 @@ func NewBuilder(conf Config, nodeID types.NodeID, signer signer, cdb *datastore.
 		poetRetryInterval:     defaultPoetRetryInterval,
 		poetClientInitializer: defaultPoetClientFunc,
 		lastPostGenDuration:   0,
+		deadlineMargin:        defaultDeadlineMargin,
 	}
 	for _, opt := range opts {
 		opt(b)
 	}
+	b.deadlines = newDeadlineMonitor(nodeID, b.deadlineMargin, log)
+	nipostBuilder.setDeadlineMonitor(b.deadlines)
 	return b
 }
 
@@ -395,6 +434,7 @@ func (b *Builder) loop(ctx context.Context) {
 		}
 	}()
 	defer b.log.Info("atx builder is stopped")
+	defer b.deadlines.stop()
 	for {
 		if poetClients := b.receivePendingPoetClients(); poetClients != nil {
 			b.nipostBuilder.updatePoETProvers(*poetClients)
@@ -466,7 +506,7 @@ func (b *Builder) buildNIPostChallenge(ctx context.Context) error {
 		challenge.Sequence = prevAtx.Sequence + 1
 	}
 	b.challenge = challenge
-	if err := kvstore.AddNIPostChallenge(b.cdb, b.challenge); err != nil {
+	if err := kvstore.AddNIPostChallenge(b.cdb, b.nodeID, b.challenge); err != nil {
 		return fmt.Errorf("failed to store nipost challenge: %w", err)
 	}
 	return nil
@@ -482,15 +522,21 @@ func (b *Builder) UpdatePoETServers(ctx context.Context, endpoints []string) err
 			return nil
 		})))
 
+	trusted, err := b.poetCfg.ParseServiceIDs()
+	if err != nil {
+		return err
+	}
 	clients := make([]PoetProvingServiceClient, 0, len(endpoints))
 	for _, endpoint := range endpoints {
 		client := b.poetClientInitializer(endpoint)
-		// TODO(dshulyak) not enough information to verify that PoetServiceID matches with an expected one.
-		// Maybe it should be provided during update.
 		sid, err := client.PoetServiceID(ctx)
 		if err != nil {
 			return &PoetSvcUnstableError{source: fmt.Errorf("failed to query Poet '%s' for ID (%w)", endpoint, err)}
 		}
+		if len(trusted) > 0 && !containsServiceID(trusted, sid) {
+			metrics.PoetServersRejected.Inc()
+			return fmt.Errorf("%w: poet '%s' reported id %s", ErrUnknownPoetService, endpoint, util.Bytes2Hex(sid))
+		}
 		b.log.WithContext(ctx).With().Debug("preparing to update poet service", log.String("poet_id", util.Bytes2Hex(sid)))
 		clients = append(clients, client)
 	}
@@ -515,7 +561,7 @@ func (b *Builder) Coinbase() types.Address {
 }
 
 func (b *Builder) loadChallenge() error {
-	nipost, err := kvstore.GetNIPostChallenge(b.cdb)
+	nipost, err := kvstore.GetNIPostChallenge(b.cdb, b.nodeID)
 	if err != nil {
 		return err
 	}
@@ -610,6 +656,7 @@ func (b *Builder) PublishActivationTx(ctx context.Context) error {
 	}
 
 	logger.Event().Info("atx published", log.Inline(atx), log.Int("size", size))
+	b.deadlines.complete(atypes.AtxPhasePublished)
 
 	select {
 	case <-atxReceived:
@@ -661,6 +708,11 @@ func (b *Builder) createAtx(ctx context.Context) (*types.ActivationTx, error) {
 	nextPoetRoundStart := b.layerClock.LayerToTime(pubEpoch.FirstLayer()).Add(b.poetCfg.PhaseShift)
 	poetRoundEnd := nextPoetRoundStart.Add(-b.poetCfg.CycleGap)
 	postDurationWithMargin := time.Duration(float64(b.lastPostGenDuration.Nanoseconds()) * 1.1)
+	// Challenges are accepted until the round that ends at poetRoundEnd starts.
+	var poetRoundStart time.Time
+	if pubEpoch > 0 {
+		poetRoundStart = b.layerClock.LayerToTime((pubEpoch - 1).FirstLayer()).Add(b.poetCfg.PhaseShift)
+	}
 
 	poetProofDeadline := nextPoetRoundStart.Add(-postDurationWithMargin).Add(-b.poetCfg.GracePeriod)
 	// Safety check
@@ -676,6 +728,17 @@ func (b *Builder) createAtx(ctx context.Context) (*types.ActivationTx, error) {
 		poetProofDeadline = poetRoundEnd.Add(b.poetCfg.GracePeriod)
 	}
 
+	b.deadlines.start(pubEpoch, map[atypes.AtxPhase]time.Time{
+		atypes.AtxPhaseChallengeBuilt: poetRoundStart,
+		atypes.AtxPhasePoetSubmitted:  poetRoundStart,
+		// PoET proof is needed in time to generate PoST before the next poet round.
+		atypes.AtxPhasePoetProofReceived:  nextPoetRoundStart.Add(-postDurationWithMargin),
+		atypes.AtxPhasePostProofGenerated: nextPoetRoundStart,
+		// ATX must be received by the network before the target epoch starts.
+		atypes.AtxPhasePublished: b.layerClock.LayerToTime((pubEpoch + 1).FirstLayer()),
+	})
+	b.deadlines.complete(atypes.AtxPhaseChallengeBuilt)
+
 	b.log.With().Info("building NIPost",
 		log.Stringer("pub_epoch", pubEpoch),
 		log.Time("deadline time", poetProofDeadline),
@@ -687,7 +750,7 @@ func (b *Builder) createAtx(ctx context.Context) (*types.ActivationTx, error) {
 		return nil, fmt.Errorf("getting commitment atx failed: %w", err)
 	}
 
-	nipost, postDuration, err := b.nipostBuilder.BuildNIPost(ctx, hash, *commitmentAtx, poetProofDeadline)
+	nipost, postDuration, err := b.nipostBuilder.BuildNIPost(ctx, hash, *commitmentAtx, poetRoundStart, poetProofDeadline)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build NIPost: %w", err)
 	}
@@ -741,7 +804,7 @@ func (b *Builder) currentEpoch() types.EpochID {
 func (b *Builder) discardChallenge() {
 	b.challenge = nil
 	b.pendingATX = nil
-	if err := kvstore.ClearNIPostChallenge(b.cdb); err != nil {
+	if err := kvstore.ClearNIPostChallenge(b.cdb, b.nodeID); err != nil {
 		b.log.Error("failed to discard NIPost challenge: %w", err)
 	}
 }
@@ -757,6 +820,12 @@ func (b *Builder) broadcast(ctx context.Context, atx *types.ActivationTx) (int,
 	return len(buf), nil
 }
 
+// AtxDeadlines returns the phases of the ATX that is being built with their deadlines,
+// or nil if no ATX challenge was built since the node started.
+func (b *Builder) AtxDeadlines() *atypes.AtxDeadlineStatus {
+	return b.deadlines.status()
+}
+
 // GetPositioningAtxInfo returns id and publication layer from the best observed atx.
 func (b *Builder) GetPositioningAtxInfo() (types.ATXID, types.LayerID, error) {
 	id, err := b.atxHandler.GetPosAtxID()
@@ -793,6 +862,22 @@ func SignAtx(signer signer, atx *types.ActivationTx) error {
 	if err != nil {
 		return fmt.Errorf("inner bytes of ATX: %w", err)
 	}
-	atx.Sig = signer.Sign(bts)
+	atx.Sig, err = signer.SignRequest(signing.Request{
+		Domain:  signing.ATX,
+		Epoch:   uint32(atx.PubLayerID.GetEpoch()),
+		Message: bts,
+	})
+	if err != nil {
+		return fmt.Errorf("sign ATX: %w", err)
+	}
 	return nil
 }
+
+func containsServiceID(ids [][]byte, id []byte) bool {
+	for _, expected := range ids {
+		if bytes.Equal(expected, id) {
+			return true
+		}
+	}
+	return false
+}
@@ -14,6 +14,8 @@ var (
 	ErrPoetServiceUnstable = &PoetSvcUnstableError{}
 	// ErrPoetProofNotReceived is returned when no poet proof was received.
 	ErrPoetProofNotReceived = errors.New("builder: didn't receive any poet proof")
+	// ErrUnknownPoetService is returned when the poet service is not one of the configured services.
+	ErrUnknownPoetService = errors.New("unknown poet service")
 )
 
 // PoetSvcUnstableError means there was a problem communicating
@@ -1,6 +1,7 @@
 package activation
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -9,14 +10,18 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/spacemeshos/ed25519"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/spacemeshos/go-spacemesh/activation/metrics"
 	"github.com/spacemeshos/go-spacemesh/activation/mocks"
 	atypes "github.com/spacemeshos/go-spacemesh/activation/types"
 	"github.com/spacemeshos/go-spacemesh/codec"
 	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/common/util"
 	"github.com/spacemeshos/go-spacemesh/datastore"
 	"github.com/spacemeshos/go-spacemesh/log/logtest"
 	"github.com/spacemeshos/go-spacemesh/signing"
@@ -116,6 +121,10 @@ func (ms *MockSigning) Sign(m []byte) []byte {
 	return ms.signer.Sign(m)
 }
 
+func (ms *MockSigning) SignRequest(req signing.Request) ([]byte, error) {
+	return ms.signer.SignRequest(req)
+}
+
 type NIPostBuilderMock struct {
 	poetRef         []byte
 	buildNIPostFunc func(challenge *types.Hash32, commitmentAtx types.ATXID) (*types.NIPost, time.Duration, error)
@@ -124,7 +133,9 @@ type NIPostBuilderMock struct {
 
 func (np NIPostBuilderMock) updatePoETProvers([]PoetProvingServiceClient) {}
 
-func (np *NIPostBuilderMock) BuildNIPost(_ context.Context, challenge *types.Hash32, commitmentAtx types.ATXID, _ time.Time) (*types.NIPost, time.Duration, error) {
+func (np NIPostBuilderMock) setDeadlineMonitor(*deadlineMonitor) {}
+
+func (np *NIPostBuilderMock) BuildNIPost(_ context.Context, challenge *types.Hash32, commitmentAtx types.ATXID, _, _ time.Time) (*types.NIPost, time.Duration, error) {
 	if np.buildNIPostFunc != nil {
 		return np.buildNIPostFunc(challenge, commitmentAtx)
 	}
@@ -136,7 +147,9 @@ type NIPostErrBuilderMock struct{}
 
 func (np *NIPostErrBuilderMock) updatePoETProvers([]PoetProvingServiceClient) {}
 
-func (np *NIPostErrBuilderMock) BuildNIPost(context.Context, *types.Hash32, types.ATXID, time.Time) (*types.NIPost, time.Duration, error) {
+func (np *NIPostErrBuilderMock) setDeadlineMonitor(*deadlineMonitor) {}
+
+func (np *NIPostErrBuilderMock) BuildNIPost(context.Context, *types.Hash32, types.ATXID, time.Time, time.Time) (*types.NIPost, time.Duration, error) {
 	return nil, 0, fmt.Errorf("NIPost builder error")
 }
 
@@ -174,7 +187,7 @@ func newCachedDB(tb testing.TB) *datastore.CachedDB {
 
 func newAtxHandler(tb testing.TB, cdb *datastore.CachedDB) *Handler {
 	receiver := mocks.NewMockatxReceiver(gomock.NewController(tb))
-	return NewHandler(cdb, nil, layersPerEpoch, testTickSize, goldenATXID, &ValidatorMock{}, receiver, logtest.New(tb).WithName("atxHandler"))
+	return NewHandler(cdb, nil, nil, layersPerEpoch, testTickSize, goldenATXID, &ValidatorMock{}, receiver, logtest.New(tb).WithName("atxHandler"))
 }
 
 func newChallenge(sequence uint64, prevAtxID, posAtxID types.ATXID, pubLayerID types.LayerID, cATX *types.ATXID) types.NIPostChallenge {
@@ -950,7 +963,7 @@ func TestBuilder_SignAtx(t *testing.T) {
 	sig := NewMockSigner()
 	cdb := newCachedDB(t)
 	receiver := mocks.NewMockatxReceiver(gomock.NewController(t))
-	atxHdlr := NewHandler(cdb, nil, layersPerEpoch, testTickSize, goldenATXID, &ValidatorMock{}, receiver, logtest.New(t).WithName("atxDB1"))
+	atxHdlr := NewHandler(cdb, nil, nil, layersPerEpoch, testTickSize, goldenATXID, &ValidatorMock{}, receiver, logtest.New(t).WithName("atxDB1"))
 	b := NewBuilder(cfg, sig.NodeID(), sig, cdb, atxHdlr, net, nipostBuilderMock, &postSetupProviderMock{}, layerClockMock, &mockSyncer{}, logtest.New(t).WithName("atxBuilder"))
 
 	prevAtx := types.ATXID(types.HexToHash32("0x111"))
@@ -974,7 +987,7 @@ func TestBuilder_NIPostPublishRecovery(t *testing.T) {
 	sig := NewMockSigner()
 	cdb := newCachedDB(t)
 	receiver := mocks.NewMockatxReceiver(gomock.NewController(t))
-	atxHdlr := NewHandler(cdb, nil, layersPerEpoch, testTickSize, goldenATXID, &ValidatorMock{}, receiver, logtest.New(t).WithName("atxDB1"))
+	atxHdlr := NewHandler(cdb, nil, nil, layersPerEpoch, testTickSize, goldenATXID, &ValidatorMock{}, receiver, logtest.New(t).WithName("atxDB1"))
 	net.atxHdlr = atxHdlr
 
 	cfg := Config{
@@ -1024,7 +1037,7 @@ func TestBuilder_NIPostPublishRecovery(t *testing.T) {
 	b = NewBuilder(cfg, sig.NodeID(), sig, cdb, atxHdlr, &FaultyNetMock{}, nipostBuilder, &postSetupProviderMock{}, layerClockMock, &mockSyncer{}, logtest.New(t).WithName("atxBuilder"))
 	err = b.buildNIPostChallenge(context.TODO())
 	assert.NoError(t, err)
-	got, err := kvstore.GetNIPostChallenge(cdb)
+	got, err := kvstore.GetNIPostChallenge(cdb, sig.NodeID())
 	require.NoError(t, err)
 	require.NotEmpty(t, got)
 
@@ -1037,7 +1050,7 @@ func TestBuilder_NIPostPublishRecovery(t *testing.T) {
 	err = b.PublishActivationTx(context.TODO())
 	// This 👇 ensures that handing of the challenge succeeded and the code moved on to the next part
 	assert.ErrorIs(t, err, ErrATXChallengeExpired)
-	got, err = kvstore.GetNIPostChallenge(cdb)
+	got, err = kvstore.GetNIPostChallenge(cdb, sig.NodeID())
 	require.ErrorIs(t, err, sql.ErrNotFound)
 	require.Empty(t, got)
 }
@@ -1157,6 +1170,34 @@ func TestBuilder_UpdatePoets(t *testing.T) {
 	r.Nil(b.receivePendingPoetClients())
 }
 
+func TestBuilder_UpdatePoetsUnknownService(t *testing.T) {
+	r := require.New(t)
+
+	trusted := bytes.Repeat([]byte{1}, ed25519.PublicKeySize)
+	cdb := newCachedDB(t)
+	atxHdlr := newAtxHandler(t, cdb)
+	b := newBuilder(t, cdb, atxHdlr,
+		WithPoetConfig(PoetConfig{ServiceIDs: []string{util.Bytes2Hex(trusted)}}),
+		WithPoETClientInitializer(func(endpoint string) PoetProvingServiceClient {
+			poet := mocks.NewMockPoetProvingServiceClient(gomock.NewController(t))
+			sid := trusted
+			if endpoint == "poet1" {
+				sid = []byte("poetid")
+			}
+			poet.EXPECT().PoetServiceID(gomock.Any()).Times(1).Return(sid, nil)
+			return poet
+		}))
+
+	r.NoError(b.UpdatePoETServers(context.TODO(), []string{"poet0"}))
+	r.NotNil(b.receivePendingPoetClients())
+
+	before := testutil.ToFloat64(metrics.PoetServersRejected)
+	err := b.UpdatePoETServers(context.TODO(), []string{"poet0", "poet1"})
+	r.ErrorIs(err, ErrUnknownPoetService)
+	r.Equal(before+1, testutil.ToFloat64(metrics.PoetServersRejected))
+	r.Nil(b.receivePendingPoetClients())
+}
+
 func TestBuilder_UpdatePoetsUnstable(t *testing.T) {
 	r := require.New(t)
 
@@ -0,0 +1,233 @@
+package activation
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	atypes "github.com/spacemeshos/go-spacemesh/activation/types"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/events"
+	"github.com/spacemeshos/go-spacemesh/log"
+)
+
+// defaultDeadlineMargin is the time before the deadline of a pending phase when the phase is considered at risk.
+const defaultDeadlineMargin = 10 * time.Minute
+
+type deadlineAlert uint8
+
+const (
+	alertNone deadlineAlert = iota
+	alertAtRisk
+	alertMissed
+)
+
+// deadlineMonitor tracks the phases of the ATX construction against their deadlines.
+// Completed phases are reported as events. Pending phases that get close to their deadline
+// or miss it are reported as node errors. A nil monitor ignores all reports.
+type deadlineMonitor struct {
+	nodeID types.NodeID
+	logger log.Log
+	margin time.Duration
+
+	mu           sync.Mutex
+	publishEpoch types.EpochID
+	// phases is nil until the first ATX challenge is built.
+	phases  []atypes.AtxPhaseStatus
+	alerted map[atypes.AtxPhase]deadlineAlert
+	timer   *time.Timer
+	stopped bool
+}
+
+func newDeadlineMonitor(nodeID types.NodeID, margin time.Duration, logger log.Log) *deadlineMonitor {
+	return &deadlineMonitor{
+		nodeID: nodeID,
+		logger: logger,
+		margin: margin,
+	}
+}
+
+// start tracks the phases of the ATX that is published in pubEpoch.
+// Phases that were completed for the same publication epoch are kept, so that the ATX construction can be resumed.
+// Phases without a deadline are not monitored.
+func (m *deadlineMonitor) start(pubEpoch types.EpochID, deadlines map[atypes.AtxPhase]time.Time) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stopped = false
+	if m.phases == nil || m.publishEpoch != pubEpoch {
+		m.publishEpoch = pubEpoch
+		m.phases = make([]atypes.AtxPhaseStatus, 0, len(atypes.AtxPhases))
+		for _, phase := range atypes.AtxPhases {
+			m.phases = append(m.phases, atypes.AtxPhaseStatus{Phase: phase})
+		}
+		m.alerted = map[atypes.AtxPhase]deadlineAlert{}
+	}
+	for i := range m.phases {
+		if m.phases[i].Completed.IsZero() {
+			m.phases[i].Deadline = deadlines[m.phases[i].Phase]
+		}
+	}
+	m.schedule()
+}
+
+// complete records completion of the phase of the ATX that is currently tracked.
+func (m *deadlineMonitor) complete(phase atypes.AtxPhase) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	status := m.phase(phase)
+	if status == nil || !status.Completed.IsZero() {
+		return
+	}
+	status.Completed = time.Now()
+	if !status.Deadline.IsZero() {
+		status.Slack = status.Deadline.Sub(status.Completed)
+	}
+	events.ReportAtxPhase(events.EventAtxPhase{
+		Smesher:      m.nodeID,
+		PublishEpoch: m.publishEpoch,
+		Phase:        phase,
+		Deadline:     status.Deadline,
+		Completed:    status.Completed,
+		Slack:        status.Slack,
+	})
+	if !status.Deadline.IsZero() && status.Slack < 0 {
+		m.logger.With().Warning("atx phase completed after deadline",
+			log.Stringer("phase", phase),
+			log.Stringer("publish_epoch", m.publishEpoch),
+			log.Duration("late", -status.Slack),
+		)
+		if m.alerted[phase] < alertMissed {
+			m.alerted[phase] = alertMissed
+			m.alert(fmt.Sprintf("atx phase %s for publish epoch %d completed %v after the deadline",
+				phase, m.publishEpoch, -status.Slack.Round(time.Second)))
+		}
+	} else {
+		m.logger.With().Info("atx phase completed",
+			log.Stringer("phase", phase),
+			log.Stringer("publish_epoch", m.publishEpoch),
+			log.Duration("slack", status.Slack),
+		)
+	}
+	m.schedule()
+}
+
+// status returns a snapshot of the tracked phases, or nil if no ATX challenge was built yet.
+func (m *deadlineMonitor) status() *atypes.AtxDeadlineStatus {
+	if m == nil {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.phases == nil {
+		return nil
+	}
+	now := time.Now()
+	rst := &atypes.AtxDeadlineStatus{
+		PublishEpoch: uint32(m.publishEpoch),
+		Phases:       make([]atypes.AtxPhaseStatus, 0, len(m.phases)),
+	}
+	for _, status := range m.phases {
+		if status.Completed.IsZero() && !status.Deadline.IsZero() {
+			status.Slack = status.Deadline.Sub(now)
+			if status.Slack < m.margin {
+				rst.AtRisk = true
+			}
+		}
+		rst.Phases = append(rst.Phases, status)
+	}
+	return rst
+}
+
+// stop stops monitoring the pending phases.
+func (m *deadlineMonitor) stop() {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stopped = true
+	if m.timer != nil {
+		m.timer.Stop()
+		m.timer = nil
+	}
+}
+
+func (m *deadlineMonitor) phase(phase atypes.AtxPhase) *atypes.AtxPhaseStatus {
+	for i := range m.phases {
+		if m.phases[i].Phase == phase {
+			return &m.phases[i]
+		}
+	}
+	return nil
+}
+
+// pending returns the first phase that is not completed and has a deadline.
+func (m *deadlineMonitor) pending() *atypes.AtxPhaseStatus {
+	for i := range m.phases {
+		if m.phases[i].Completed.IsZero() && !m.phases[i].Deadline.IsZero() {
+			return &m.phases[i]
+		}
+	}
+	return nil
+}
+
+// schedule sets up the timer for the next alert of the pending phase. Must be called with the lock held.
+func (m *deadlineMonitor) schedule() {
+	if m.timer != nil {
+		m.timer.Stop()
+		m.timer = nil
+	}
+	pending := m.pending()
+	if pending == nil || m.stopped {
+		return
+	}
+	var at time.Time
+	switch m.alerted[pending.Phase] {
+	case alertNone:
+		at = pending.Deadline.Add(-m.margin)
+	case alertAtRisk:
+		at = pending.Deadline
+	default:
+		return
+	}
+	m.timer = time.AfterFunc(time.Until(at), m.check)
+}
+
+func (m *deadlineMonitor) check() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	pending := m.pending()
+	if pending == nil || m.stopped {
+		return
+	}
+	left := time.Until(pending.Deadline)
+	switch {
+	case left <= 0 && m.alerted[pending.Phase] < alertMissed:
+		m.alerted[pending.Phase] = alertMissed
+		m.alert(fmt.Sprintf("atx phase %s for publish epoch %d missed the deadline",
+			pending.Phase, m.publishEpoch))
+	case left < m.margin && m.alerted[pending.Phase] < alertAtRisk:
+		m.alerted[pending.Phase] = alertAtRisk
+		m.alert(fmt.Sprintf("atx phase %s for publish epoch %d is at risk, %v left until the deadline",
+			pending.Phase, m.publishEpoch, left.Round(time.Second)))
+	}
+	m.schedule()
+}
+
+// alert reports the message to the error stream of the node.
+// The message is logged as a warning, errors in the log are already reported by the log hook.
+func (m *deadlineMonitor) alert(msg string) {
+	m.logger.With().Warning(msg, m.nodeID)
+	events.ReportError(events.NodeError{
+		Msg:   fmt.Sprintf("%s: %s", m.nodeID.ShortString(), msg),
+		Level: zapcore.ErrorLevel,
+	})
+}
@@ -0,0 +1,97 @@
+package activation
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	atypes "github.com/spacemeshos/go-spacemesh/activation/types"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/events"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+)
+
+func TestDeadlineMonitor(t *testing.T) {
+	events.InitializeReporter()
+	t.Cleanup(events.CloseEventReporter)
+	phases := events.SubscribeAtxPhases()
+	t.Cleanup(func() { phases.Close() })
+	errs := events.SubscribeErrors()
+	t.Cleanup(func() { errs.Close() })
+
+	nodeID := types.NodeID{1}
+	m := newDeadlineMonitor(nodeID, 300*time.Millisecond, logtest.New(t))
+	require.Nil(t, m.status())
+
+	now := time.Now()
+	m.start(3, map[atypes.AtxPhase]time.Time{
+		atypes.AtxPhaseChallengeBuilt:     now.Add(time.Hour),
+		atypes.AtxPhasePoetSubmitted:      now.Add(800 * time.Millisecond),
+		atypes.AtxPhasePoetProofReceived:  now.Add(time.Hour),
+		atypes.AtxPhasePostProofGenerated: now.Add(time.Hour),
+		atypes.AtxPhasePublished:          now.Add(time.Hour),
+	})
+	t.Cleanup(m.stop)
+	m.complete(atypes.AtxPhaseChallengeBuilt)
+
+	select {
+	case ev := <-phases.Out():
+		phase := ev.(events.EventAtxPhase)
+		require.Equal(t, nodeID, phase.Smesher)
+		require.Equal(t, types.EpochID(3), phase.PublishEpoch)
+		require.Equal(t, atypes.AtxPhaseChallengeBuilt, phase.Phase)
+		require.Greater(t, phase.Slack, 50*time.Minute)
+	case <-time.After(time.Second):
+		require.FailNow(t, "timed out waiting for phase event")
+	}
+
+	status := m.status()
+	require.NotNil(t, status)
+	require.EqualValues(t, 3, status.PublishEpoch)
+	require.Len(t, status.Phases, len(atypes.AtxPhases))
+	require.False(t, status.Phases[0].Completed.IsZero())
+	require.True(t, status.Phases[1].Completed.IsZero())
+	require.False(t, status.AtRisk)
+
+	for _, expected := range []string{"is at risk", "missed the deadline"} {
+		select {
+		case ev := <-errs.Out():
+			nodeErr := ev.(events.NodeError)
+			require.True(t, strings.Contains(nodeErr.Msg, atypes.AtxPhasePoetSubmitted.String()), nodeErr.Msg)
+			require.True(t, strings.Contains(nodeErr.Msg, expected), nodeErr.Msg)
+		case <-time.After(time.Second):
+			require.FailNow(t, "timed out waiting for error", expected)
+		}
+	}
+
+	require.True(t, m.status().AtRisk)
+
+	m.complete(atypes.AtxPhasePoetSubmitted)
+	status = m.status()
+	require.Less(t, status.Phases[1].Slack, time.Duration(0))
+	require.False(t, status.AtRisk)
+
+	// resuming the same epoch keeps completed phases
+	m.start(3, map[atypes.AtxPhase]time.Time{atypes.AtxPhasePublished: now.Add(time.Hour)})
+	status = m.status()
+	require.False(t, status.Phases[1].Completed.IsZero())
+	require.True(t, status.Phases[2].Deadline.IsZero())
+
+	// next epoch starts over
+	m.start(4, nil)
+	status = m.status()
+	require.EqualValues(t, 4, status.PublishEpoch)
+	for _, phase := range status.Phases {
+		require.True(t, phase.Completed.IsZero())
+	}
+}
+
+func TestDeadlineMonitor_Nil(t *testing.T) {
+	var m *deadlineMonitor
+	m.start(1, nil)
+	m.complete(atypes.AtxPhasePublished)
+	m.stop()
+	require.Nil(t, m.status())
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/spacemeshos/post/shared"
 
+	"github.com/spacemeshos/go-spacemesh/codec"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/datastore"
 	"github.com/spacemeshos/go-spacemesh/events"
@@ -18,12 +19,16 @@ import (
 	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
 	"github.com/spacemeshos/go-spacemesh/sql"
 	"github.com/spacemeshos/go-spacemesh/sql/atxs"
+	"github.com/spacemeshos/go-spacemesh/sql/identities"
 	"github.com/spacemeshos/go-spacemesh/system"
 )
 
 var (
 	errKnownAtx      = errors.New("known atx")
 	errMalformedData = errors.New("malformed data")
+	// errLookup is returned when an atx can't be validated because something it references
+	// is missing locally or can't be read from the database. It says nothing about the atx itself.
+	errLookup = errors.New("lookup failed")
 )
 
 type atxChan struct {
@@ -45,10 +50,11 @@ type Handler struct {
 	processAtxMutex sync.Mutex
 	atxChannels     map[types.ATXID]*atxChan
 	fetcher         system.Fetcher
+	publisher       pubsub.Publisher
 }
 
 // NewHandler returns a data handler for ATX.
-func NewHandler(cdb *datastore.CachedDB, fetcher system.Fetcher, layersPerEpoch uint32, tickSize uint64, goldenATXID types.ATXID, nipostValidator nipostValidator, atxReceiver atxReceiver, log log.Log) *Handler {
+func NewHandler(cdb *datastore.CachedDB, fetcher system.Fetcher, publisher pubsub.Publisher, layersPerEpoch uint32, tickSize uint64, goldenATXID types.ATXID, nipostValidator nipostValidator, atxReceiver atxReceiver, log log.Log) *Handler {
 	return &Handler{
 		cdb:             cdb,
 		layersPerEpoch:  layersPerEpoch,
@@ -59,6 +65,7 @@ func NewHandler(cdb *datastore.CachedDB, fetcher system.Fetcher, layersPerEpoch
 		log:             log,
 		atxChannels:     make(map[types.ATXID]*atxChan),
 		fetcher:         fetcher,
+		publisher:       publisher,
 	}
 }
 
@@ -130,12 +137,75 @@ func (h *Handler) ProcessAtx(ctx context.Context, atx *types.VerifiedActivationT
 	} else {
 		h.log.WithContext(ctx).With().Info("atx is valid", atx.ID())
 	}
+	proof, err := h.checkDoublePublish(ctx, atx)
+	if err != nil {
+		return err
+	}
 	if err := h.StoreAtx(ctx, atx); err != nil {
 		return fmt.Errorf("cannot store atx %s: %w", atx.ShortString(), err)
 	}
+	if proof != nil {
+		encoded, err := codec.Encode(proof)
+		if err != nil {
+			h.log.With().Panic("failed to encode malfeasance proof", log.Err(err))
+		}
+		if err := h.publisher.Publish(ctx, pubsub.MalfeasanceProtocol, encoded); err != nil {
+			h.log.WithContext(ctx).With().Error("failed to broadcast malfeasance proof", log.Err(err))
+		}
+	}
 	return nil
 }
 
+// checkDoublePublish returns proof of malfeasance if the smesher already published another atx
+// in the same epoch. The proof is stored before it is returned.
+func (h *Handler) checkDoublePublish(ctx context.Context, atx *types.VerifiedActivationTx) (*types.MalfeasanceProof, error) {
+	prevID, err := atxs.GetIDByEpochAndNodeID(h.cdb, atx.PublishEpoch(), atx.NodeID())
+	if errors.Is(err, sql.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	// identity may be recorded as malicious without the proof, then the proof is still stored
+	if _, err := identities.GetProof(h.cdb, atx.NodeID().ToBytes()); err == nil {
+		return nil, nil
+	} else if !errors.Is(err, sql.ErrNotFound) {
+		return nil, err
+	}
+	prev, err := atxs.Get(h.cdb, prevID)
+	if err != nil {
+		return nil, fmt.Errorf("get atx %s: %w", prevID, err)
+	}
+	prevBytes, err := prev.InnerBytes()
+	if err != nil {
+		return nil, err
+	}
+	atxBytes, err := atx.InnerBytes()
+	if err != nil {
+		return nil, err
+	}
+	proof := types.NewMalfeasanceProof(types.MultipleATXs,
+		types.NewSignedMessage(prevBytes, prev.Sig),
+		types.NewSignedMessage(atxBytes, atx.Sig),
+	)
+	encoded, err := codec.Encode(proof)
+	if err != nil {
+		h.log.With().Panic("failed to encode malfeasance proof", log.Err(err))
+	}
+	if err := h.cdb.WithTx(ctx, func(tx *sql.Tx) error {
+		return identities.AddProof(tx, atx.NodeID().ToBytes(), encoded, time.Now())
+	}); err != nil {
+		return nil, err
+	}
+	h.log.WithContext(ctx).With().Warning("smesher published more than one atx in the same epoch",
+		log.FieldNamed("atx_node_id", atx.NodeID()),
+		atx.PublishEpoch(),
+		atx.ID(),
+		log.Stringer("prev_atx_id", prevID),
+	)
+	events.ReportMalfeasance(atx.NodeID(), proof)
+	return proof, nil
+}
+
 // SyntacticallyValidateAtx ensures the following conditions apply, otherwise it returns an error.
 //
 //   - If the sequence number is non-zero: PrevATX points to a syntactically valid ATX whose sequence number is one less
@@ -170,7 +240,7 @@ func (h *Handler) SyntacticallyValidateAtx(ctx context.Context, atx *types.Activ
 	if atx.PositioningATX != h.goldenATXID {
 		posAtx, err := h.cdb.GetAtxHeader(atx.PositioningATX)
 		if err != nil {
-			return nil, fmt.Errorf("positioning atx not found")
+			return nil, fmt.Errorf("%w: positioning atx not found: %v", errLookup, err)
 		}
 		if !atx.PubLayerID.After(posAtx.PubLayerID) {
 			return nil, fmt.Errorf("atx layer (%v) must be after positioning atx layer (%v)", atx.PubLayerID, posAtx.PubLayerID)
@@ -190,7 +260,7 @@ func (h *Handler) SyntacticallyValidateAtx(ctx context.Context, atx *types.Activ
 
 	commitment, err := h.getCommitmentFromAtx(atx)
 	if err != nil {
-		return nil, fmt.Errorf("validation failed: initial atx not found: %w", err)
+		return nil, fmt.Errorf("%w: initial atx not found: %v", errLookup, err)
 	}
 
 	leaves, err := h.nipostValidator.Validate(commitment, atx.NIPost, *expectedChallengeHash, atx.NumUnits)
@@ -229,7 +299,7 @@ func (h *Handler) validateInitialAtx(ctx context.Context, atx *types.ActivationT
 	if *atx.CommitmentATX != h.goldenATXID {
 		commitmentAtx, err := h.cdb.GetAtxHeader(*atx.CommitmentATX)
 		if err != nil {
-			return fmt.Errorf("commitment atx not found")
+			return fmt.Errorf("%w: commitment atx not found: %v", errLookup, err)
 		}
 		if !atx.PubLayerID.After(commitmentAtx.PubLayerID) {
 			return fmt.Errorf("atx layer (%v) must be after commitment atx layer (%v)", atx.PubLayerID, commitmentAtx.PubLayerID)
@@ -252,7 +322,7 @@ func (h *Handler) validateInitialAtx(ctx context.Context, atx *types.ActivationT
 func (h *Handler) validateNonInitialAtx(ctx context.Context, atx *types.ActivationTx) error {
 	prevATX, err := h.cdb.GetAtxHeader(atx.PrevATXID)
 	if err != nil {
-		return fmt.Errorf("validation failed: prevATX not found: %w", err)
+		return fmt.Errorf("%w: prevATX not found: %v", errLookup, err)
 	}
 
 	if prevATX.NodeID != atx.NodeID() {
@@ -437,13 +507,14 @@ func (h *Handler) handleAtxData(ctx context.Context, data []byte) error {
 	}
 
 	vAtx, err := h.SyntacticallyValidateAtx(ctx, atx)
-	if err != nil {
-		return fmt.Errorf("received syntactically invalid atx %v: %v", atx.ShortString(), err)
+	if errors.Is(err, errLookup) {
+		return fmt.Errorf("validate atx %v: %w", atx.ShortString(), err)
+	} else if err != nil {
+		return fmt.Errorf("%w: received syntactically invalid atx %v: %v", errMalformedData, atx.ShortString(), err)
 	}
 	err = h.ProcessAtx(ctx, vAtx)
 	if err != nil {
 		return fmt.Errorf("cannot process atx %v: %v", atx.ShortString(), err)
-		// TODO: blacklist peer
 	}
 	header, err := h.cdb.GetAtxHeader(vAtx.ID())
 	if err != nil {
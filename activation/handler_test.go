@@ -2,6 +2,7 @@ package activation
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"runtime"
 	"sync"
@@ -19,8 +20,10 @@ import (
 	"github.com/spacemeshos/go-spacemesh/datastore"
 	"github.com/spacemeshos/go-spacemesh/log/logtest"
 	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
+	pubsubmocks "github.com/spacemeshos/go-spacemesh/p2p/pubsub/mocks"
 	"github.com/spacemeshos/go-spacemesh/sql"
 	"github.com/spacemeshos/go-spacemesh/sql/atxs"
+	"github.com/spacemeshos/go-spacemesh/sql/identities"
 	"github.com/spacemeshos/go-spacemesh/system/mocks"
 )
 
@@ -47,7 +50,7 @@ func TestHandler_StoreAtx(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	validator := amocks.NewMocknipostValidator(ctrl)
 	receiver := amocks.NewMockatxReceiver(ctrl)
-	atxHdlr := NewHandler(cdb, nil, layersPerEpoch, testTickSize, goldenATXID, validator, receiver, log)
+	atxHdlr := NewHandler(cdb, nil, nil, layersPerEpoch, testTickSize, goldenATXID, validator, receiver, log)
 
 	coinbase1 := types.GenerateAddress([]byte("aaaa"))
 
@@ -79,7 +82,10 @@ func TestHandler_processBlockATXs(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	validator := amocks.NewMocknipostValidator(ctrl)
 	receiver := amocks.NewMockatxReceiver(ctrl)
-	atxHdlr := NewHandler(cdb, nil, layersPerEpoch, testTickSize, goldenATXID, validator, receiver, log)
+	// all atxs are published by the same identity, so it is proven malicious
+	publisher := pubsubmocks.NewMockPublisher(ctrl)
+	publisher.EXPECT().Publish(gomock.Any(), pubsub.MalfeasanceProtocol, gomock.Any()).Return(nil).Times(1)
+	atxHdlr := NewHandler(cdb, nil, publisher, layersPerEpoch, testTickSize, goldenATXID, validator, receiver, log)
 
 	coinbase1 := types.GenerateAddress([]byte("aaaa"))
 	coinbase2 := types.GenerateAddress([]byte("bbbb"))
@@ -144,7 +150,7 @@ func TestHandler_SyntacticallyValidateAtx(t *testing.T) {
 	validator := amocks.NewMocknipostValidator(gomock.NewController(t))
 	validator.EXPECT().ValidatePost(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 	validator.EXPECT().Validate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(uint64(1), nil).AnyTimes()
-	atxHdlr := NewHandler(cdb, nil, layersPerEpochBig, testTickSize, goldenATXID, validator, nil, log)
+	atxHdlr := NewHandler(cdb, nil, nil, layersPerEpochBig, testTickSize, goldenATXID, validator, nil, log)
 
 	otherSig := NewMockSigner()
 	coinbase := types.GenerateAddress([]byte("aaaa"))
@@ -279,7 +285,7 @@ func TestHandler_SyntacticallyValidateAtx(t *testing.T) {
 	t.Run("prevAtx not declared but validation of initial post fails", func(t *testing.T) {
 		validator := amocks.NewMocknipostValidator(gomock.NewController(t))
 		validator.EXPECT().ValidatePost(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("failed validation")).Times(1)
-		atxHdlr := NewHandler(cdb, nil, layersPerEpochBig, testTickSize, goldenATXID, validator, nil, log)
+		atxHdlr := NewHandler(cdb, nil, nil, layersPerEpochBig, testTickSize, goldenATXID, validator, nil, log)
 
 		cATX := prevAtx.ID()
 		challenge := newChallenge(0, *types.EmptyATXID, posAtx.ID(), types.NewLayerID(1012), &cATX)
@@ -316,13 +322,15 @@ func TestHandler_SyntacticallyValidateAtx(t *testing.T) {
 		atx.InitialPost = initialPost
 		atx.InitialPostIndices = initialPost.Indices
 		_, err := atxHdlr.SyntacticallyValidateAtx(context.TODO(), atx)
-		require.EqualError(t, err, "commitment atx not found")
+		require.ErrorIs(t, err, errLookup)
+		require.ErrorContains(t, err, "commitment atx not found")
 	})
 
 	t.Run("prevAtx declared but not found", func(t *testing.T) {
 		challenge := newChallenge(1, types.RandomATXID(), posAtx.ID(), types.NewLayerID(1012), nil)
 		atx := newAtx(t, challenge, sig, &types.NIPost{}, 100, coinbase)
 		_, err := atxHdlr.SyntacticallyValidateAtx(context.TODO(), atx)
+		require.ErrorIs(t, err, errLookup)
 		require.ErrorContains(t, err, "prevATX not found")
 	})
 
@@ -330,7 +338,8 @@ func TestHandler_SyntacticallyValidateAtx(t *testing.T) {
 		challenge := newChallenge(1, prevAtx.ID(), types.RandomATXID(), types.NewLayerID(1012), nil)
 		atx := newAtx(t, challenge, sig, &types.NIPost{}, 100, coinbase)
 		_, err := atxHdlr.SyntacticallyValidateAtx(context.TODO(), atx)
-		require.EqualError(t, err, "positioning atx not found")
+		require.ErrorIs(t, err, errLookup)
+		require.ErrorContains(t, err, "positioning atx not found")
 	})
 
 	t.Run("prevAtx declared but initial Post is included", func(t *testing.T) {
@@ -375,7 +384,7 @@ func TestHandler_ContextuallyValidateAtx(t *testing.T) {
 	cdb := datastore.NewCachedDB(sql.InMemory(), log)
 	validator := amocks.NewMocknipostValidator(gomock.NewController(t))
 	receiver := amocks.NewMockatxReceiver(gomock.NewController(t))
-	atxHdlr := NewHandler(cdb, nil, layersPerEpochBig, testTickSize, goldenATXID, validator, receiver, log)
+	atxHdlr := NewHandler(cdb, nil, nil, layersPerEpochBig, testTickSize, goldenATXID, validator, receiver, log)
 
 	sig1 := NewMockSigner()
 	otherSig := NewMockSigner()
@@ -464,7 +473,7 @@ func TestHandler_ProcessAtx(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	validator := amocks.NewMocknipostValidator(ctrl)
 	receiver := amocks.NewMockatxReceiver(ctrl)
-	atxHdlr := NewHandler(cdb, nil, layersPerEpoch, testTickSize, goldenATXID, validator, receiver, log)
+	atxHdlr := NewHandler(cdb, nil, nil, layersPerEpoch, testTickSize, goldenATXID, validator, receiver, log)
 
 	coinbase := types.GenerateAddress([]byte("aaaa"))
 
@@ -478,6 +487,48 @@ func TestHandler_ProcessAtx(t *testing.T) {
 	require.NoError(t, atxHdlr.ProcessAtx(context.TODO(), atx2))
 }
 
+func TestHandler_ProcessAtx_MultipleInEpoch(t *testing.T) {
+	log := logtest.New(t)
+	cdb := datastore.NewCachedDB(sql.InMemory(), log)
+	ctrl := gomock.NewController(t)
+	validator := amocks.NewMocknipostValidator(ctrl)
+	receiver := amocks.NewMockatxReceiver(ctrl)
+	publisher := pubsubmocks.NewMockPublisher(ctrl)
+	atxHdlr := NewHandler(cdb, nil, publisher, layersPerEpoch, testTickSize, goldenATXID, validator, receiver, log)
+
+	coinbase := types.GenerateAddress([]byte("aaaa"))
+	atx1 := newActivationTx(t, sig, 0, *types.EmptyATXID, *types.EmptyATXID, nil, types.NewLayerID(100), 0, 100, coinbase, 100, &types.NIPost{})
+	require.NoError(t, atxHdlr.ProcessAtx(context.TODO(), atx1))
+
+	atx2 := newActivationTx(t, sig, 1, atx1.ID(), atx1.ID(), nil, types.NewLayerID(101), 0, 100, coinbase, 100, &types.NIPost{})
+	require.Equal(t, atx1.PublishEpoch(), atx2.PublishEpoch())
+	var published []byte
+	publisher.EXPECT().Publish(gomock.Any(), pubsub.MalfeasanceProtocol, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, data []byte) error {
+			published = data
+			return nil
+		})
+	require.NoError(t, atxHdlr.ProcessAtx(context.TODO(), atx2))
+
+	stored, err := identities.GetProof(cdb, sig.NodeID().ToBytes())
+	require.NoError(t, err)
+	require.Equal(t, stored, published)
+	var proof types.MalfeasanceProof
+	require.NoError(t, codec.Decode(published, &proof))
+	require.Equal(t, types.MultipleATXs, proof.MalfeasanceType())
+	require.Len(t, proof.Messages, 2)
+	atx1Bytes, err := atx1.InnerBytes()
+	require.NoError(t, err)
+	require.Equal(t, types.NewSignedMessage(atx1Bytes, atx1.Sig), proof.Messages[0])
+	atx2Bytes, err := atx2.InnerBytes()
+	require.NoError(t, err)
+	require.Equal(t, types.NewSignedMessage(atx2Bytes, atx2.Sig), proof.Messages[1])
+
+	// proof is not published again for the identity that is already known to be malicious
+	atx3 := newActivationTx(t, sig, 2, atx2.ID(), atx2.ID(), nil, types.NewLayerID(102), 0, 100, coinbase, 100, &types.NIPost{})
+	require.NoError(t, atxHdlr.ProcessAtx(context.TODO(), atx3))
+}
+
 func BenchmarkActivationDb_SyntacticallyValidateAtx(b *testing.B) {
 	r := require.New(b)
 	log := logtest.New(b)
@@ -485,7 +536,7 @@ func BenchmarkActivationDb_SyntacticallyValidateAtx(b *testing.B) {
 	validator := amocks.NewMocknipostValidator(gomock.NewController(b))
 	validator.EXPECT().Validate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(uint64(1), nil).AnyTimes()
 	receiver := amocks.NewMockatxReceiver(gomock.NewController(b))
-	atxHdlr := NewHandler(cdb, nil, layersPerEpochBig, testTickSize, goldenATXID, validator, receiver, log)
+	atxHdlr := NewHandler(cdb, nil, nil, layersPerEpochBig, testTickSize, goldenATXID, validator, receiver, log)
 
 	const (
 		activesetSize         = 300
@@ -542,7 +593,7 @@ func BenchmarkNewActivationDb(b *testing.B) {
 	cdb := datastore.NewCachedDB(sql.InMemory(), log)
 	validator := amocks.NewMocknipostValidator(gomock.NewController(b))
 	receiver := amocks.NewMockatxReceiver(gomock.NewController(b))
-	atxHdlr := NewHandler(cdb, nil, layersPerEpochBig, testTickSize, goldenATXID, validator, receiver, log)
+	atxHdlr := NewHandler(cdb, nil, nil, layersPerEpochBig, testTickSize, goldenATXID, validator, receiver, log)
 
 	const (
 		numOfMiners = 300
@@ -599,7 +650,7 @@ func TestHandler_GetPosAtx(t *testing.T) {
 	cdb := datastore.NewCachedDB(sql.InMemory(), log)
 	validator := amocks.NewMocknipostValidator(gomock.NewController(t))
 	receiver := amocks.NewMockatxReceiver(gomock.NewController(t))
-	atxHdlr := NewHandler(cdb, nil, layersPerEpochBig, testTickSize, goldenATXID, validator, receiver, log)
+	atxHdlr := NewHandler(cdb, nil, nil, layersPerEpochBig, testTickSize, goldenATXID, validator, receiver, log)
 
 	// Act & Assert
 
@@ -636,7 +687,7 @@ func TestHandler_AwaitAtx(t *testing.T) {
 	cdb := datastore.NewCachedDB(sql.InMemory(), log)
 	validator := amocks.NewMocknipostValidator(gomock.NewController(t))
 	receiver := amocks.NewMockatxReceiver(gomock.NewController(t))
-	atxHdlr := NewHandler(cdb, nil, layersPerEpochBig, testTickSize, goldenATXID, validator, receiver, log)
+	atxHdlr := NewHandler(cdb, nil, nil, layersPerEpochBig, testTickSize, goldenATXID, validator, receiver, log)
 	// Act & Assert
 
 	atx := newActivationTx(t, sig, 0, *types.EmptyATXID, *types.EmptyATXID, nil, types.NewLayerID(1), 0, 100, coinbase, 100, &types.NIPost{})
@@ -685,7 +736,7 @@ func TestHandler_HandleAtxData(t *testing.T) {
 	cdb := datastore.NewCachedDB(sql.InMemory(), log)
 	validator := amocks.NewMocknipostValidator(gomock.NewController(t))
 	receiver := amocks.NewMockatxReceiver(gomock.NewController(t))
-	atxHdlr := NewHandler(cdb, nil, layersPerEpochBig, testTickSize, goldenATXID, validator, receiver, log)
+	atxHdlr := NewHandler(cdb, nil, nil, layersPerEpochBig, testTickSize, goldenATXID, validator, receiver, log)
 
 	// Act & Assert
 
@@ -713,7 +764,7 @@ func BenchmarkGetAtxHeaderWithConcurrentStoreAtx(b *testing.B) {
 	cdb := datastore.NewCachedDB(sql.InMemory(), log)
 	validator := amocks.NewMocknipostValidator(gomock.NewController(b))
 	receiver := amocks.NewMockatxReceiver(gomock.NewController(b))
-	atxHdlr := NewHandler(cdb, nil, layersPerEpochBig, testTickSize, goldenATXID, validator, receiver, log)
+	atxHdlr := NewHandler(cdb, nil, nil, layersPerEpochBig, testTickSize, goldenATXID, validator, receiver, log)
 
 	var (
 		stop uint64
@@ -758,7 +809,7 @@ func TestHandler_FetchAtxReferences(t *testing.T) {
 	log := logtest.New(t)
 	cdb := datastore.NewCachedDB(sql.InMemory(), log)
 
-	atxHdlr := NewHandler(cdb, mockFetch, layersPerEpochBig, testTickSize, goldenATXID, validator, receiver, log)
+	atxHdlr := NewHandler(cdb, mockFetch, nil, layersPerEpochBig, testTickSize, goldenATXID, validator, receiver, log)
 	challenge := newChallenge(1, prevAtxID, prevAtxID, postGenesisEpochLayer, nil)
 
 	atx1 := newAtx(t, challenge, sig, nipost, 2, coinbase)
@@ -812,7 +863,7 @@ func TestHandler_AtxWeight(t *testing.T) {
 
 	log := logtest.New(t)
 	cdb := datastore.NewCachedDB(sql.InMemory(), log)
-	handler := NewHandler(cdb, mfetch, layersPerEpoch, tickSize, goldenATXID, mvalidator, receiver, log)
+	handler := NewHandler(cdb, mfetch, nil, layersPerEpoch, tickSize, goldenATXID, mvalidator, receiver, log)
 
 	atx1 := &types.ActivationTx{
 		InnerActivationTx: types.InnerActivationTx{
@@ -884,3 +935,85 @@ func TestHandler_AtxWeight(t *testing.T) {
 	require.Equal(t, stored1.TickHeight()+leaves/tickSize, stored2.TickHeight())
 	require.Equal(t, int(leaves/tickSize)*units, int(stored2.GetWeight()))
 }
+
+func TestHandler_HandleGossipAtx_ValidationResult(t *testing.T) {
+	for _, tc := range []struct {
+		desc       string
+		sequence   uint64
+		commitment types.ATXID
+		validate   error
+		expect     pubsub.ValidationResult
+	}{
+		{
+			desc:       "valid",
+			commitment: goldenATXID,
+			expect:     pubsub.ValidationAccept,
+		},
+		{
+			desc:       "invalid sequence",
+			sequence:   1,
+			commitment: goldenATXID,
+			expect:     pubsub.ValidationReject,
+		},
+		{
+			desc:       "invalid nipost",
+			commitment: goldenATXID,
+			validate:   errors.New("invalid"),
+			expect:     pubsub.ValidationReject,
+		},
+		{
+			desc:       "missing commitment atx",
+			commitment: types.RandomATXID(),
+			expect:     pubsub.ValidationIgnore,
+		},
+		{
+			desc:       "missing poet proof",
+			commitment: goldenATXID,
+			validate:   fmt.Errorf("%w: poet proof is not available: %v", errLookup, sql.ErrNotFound),
+			expect:     pubsub.ValidationIgnore,
+		},
+	} {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mfetch := mocks.NewMockFetcher(ctrl)
+			mvalidator := amocks.NewMocknipostValidator(ctrl)
+			receiver := amocks.NewMockatxReceiver(ctrl)
+			log := logtest.New(t)
+			cdb := datastore.NewCachedDB(sql.InMemory(), log)
+			handler := NewHandler(cdb, mfetch, nil, layersPerEpoch, testTickSize, goldenATXID, mvalidator, receiver, log)
+
+			commitment := tc.commitment
+			atx := &types.ActivationTx{
+				InnerActivationTx: types.InnerActivationTx{
+					NIPostChallenge: types.NIPostChallenge{
+						Sequence:           tc.sequence,
+						PositioningATX:     goldenATXID,
+						InitialPostIndices: []byte{1},
+						PubLayerID:         types.NewLayerID(1).Add(layersPerEpoch),
+						CommitmentATX:      &commitment,
+					},
+					NumUnits: 1,
+					NIPost: &types.NIPost{
+						Post:         &types.Post{},
+						PostMetadata: &types.PostMetadata{},
+					},
+					InitialPost: &types.Post{Indices: []byte{1}},
+				},
+			}
+			require.NoError(t, SignAtx(sig, atx))
+			buf, err := codec.Encode(atx)
+			require.NoError(t, err)
+
+			mfetch.EXPECT().GetPoetProof(gomock.Any(), gomock.Any())
+			if tc.sequence == 0 && tc.commitment == goldenATXID {
+				mvalidator.EXPECT().ValidatePost(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+				mvalidator.EXPECT().Validate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(uint64(1), tc.validate)
+			}
+			if tc.expect == pubsub.ValidationAccept {
+				receiver.EXPECT().OnAtx(gomock.Any())
+			}
+			require.Equal(t, tc.expect, handler.HandleGossipAtx(context.TODO(), "", buf))
+		})
+	}
+}
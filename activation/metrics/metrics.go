@@ -14,3 +14,11 @@ var PostDuration = metrics.NewGauge(
 	"duration of last PoST in nanoseconds",
 	[]string{},
 ).WithLabelValues()
+
+// PoetServersRejected is the number of poet servers that were rejected on update because of the unknown service.
+var PoetServersRejected = metrics.NewCounter(
+	"poet_servers_rejected",
+	namespace,
+	"number of poet servers rejected on update because of the unknown service id",
+	[]string{},
+).WithLabelValues()
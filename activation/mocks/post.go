@@ -12,6 +12,59 @@ import (
 	types0 "github.com/spacemeshos/go-spacemesh/common/types"
 )
 
+// MockPostProver is a mock of PostProver interface.
+type MockPostProver struct {
+	ctrl     *gomock.Controller
+	recorder *MockPostProverMockRecorder
+}
+
+// MockPostProverMockRecorder is the mock recorder for MockPostProver.
+type MockPostProverMockRecorder struct {
+	mock *MockPostProver
+}
+
+// NewMockPostProver creates a new mock instance.
+func NewMockPostProver(ctrl *gomock.Controller) *MockPostProver {
+	mock := &MockPostProver{ctrl: ctrl}
+	mock.recorder = &MockPostProverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPostProver) EXPECT() *MockPostProverMockRecorder {
+	return m.recorder
+}
+
+// GenerateProof mocks base method.
+func (m *MockPostProver) GenerateProof(challenge []byte, commitmentAtx types0.ATXID) (*types0.Post, *types0.PostMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateProof", challenge, commitmentAtx)
+	ret0, _ := ret[0].(*types0.Post)
+	ret1, _ := ret[1].(*types0.PostMetadata)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GenerateProof indicates an expected call of GenerateProof.
+func (mr *MockPostProverMockRecorder) GenerateProof(challenge, commitmentAtx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateProof", reflect.TypeOf((*MockPostProver)(nil).GenerateProof), challenge, commitmentAtx)
+}
+
+// Status mocks base method.
+func (m *MockPostProver) Status() *types.PostSetupStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status")
+	ret0, _ := ret[0].(*types.PostSetupStatus)
+	return ret0
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockPostProverMockRecorder) Status() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockPostProver)(nil).Status))
+}
+
 // MockPostSetupProvider is a mock of PostSetupProvider interface.
 type MockPostSetupProvider struct {
 	ctrl     *gomock.Controller
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	"golang.org/x/sync/errgroup"
@@ -14,6 +15,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/log"
 	"github.com/spacemeshos/go-spacemesh/sql"
 	"github.com/spacemeshos/go-spacemesh/sql/kvstore"
+	"github.com/spacemeshos/go-spacemesh/sql/poets"
 )
 
 //go:generate mockgen -package=mocks -destination=./mocks/nipost.go -source=./nipost.go PoetProvingServiceClient
@@ -27,10 +29,54 @@ type PoetProvingServiceClient interface {
 
 	// PoetServiceID returns the public key of the PoET proving service.
 	PoetServiceID(context.Context) ([]byte, error)
+
+	// Address returns the address of the PoET proving service.
+	Address() string
+}
+
+// PoetRetryConfig configures retries of a challenge submission to a PoET service.
+type PoetRetryConfig struct {
+	// Interval is the delay before the first retry. It doubles after every failed attempt.
+	Interval time.Duration
+	// MaxInterval caps the delay between retries.
+	MaxInterval time.Duration
+	// MaxAttempts is the maximum number of submissions to a single PoET service.
+	MaxAttempts int
+	// MaxConsecutiveFailures is the number of consecutive failed submissions
+	// after which a PoET service is considered unhealthy.
+	MaxConsecutiveFailures uint64
+}
+
+// DefaultPoetRetryConfig returns the default PoetRetryConfig.
+func DefaultPoetRetryConfig() PoetRetryConfig {
+	return PoetRetryConfig{
+		Interval:               time.Second,
+		MaxInterval:            time.Minute,
+		MaxAttempts:            10,
+		MaxConsecutiveFailures: 5,
+	}
+}
+
+// NIPostBuilderOption configures a NIPostBuilder.
+type NIPostBuilderOption func(*NIPostBuilder)
+
+// WithPoetRetry sets the retry policy of challenge submissions.
+func WithPoetRetry(cfg PoetRetryConfig) NIPostBuilderOption {
+	return func(nb *NIPostBuilder) {
+		nb.retry = cfg
+	}
+}
+
+// WithBackupPoets sets PoET services that are used when the registered ones fail
+// to accept the challenge.
+func WithBackupPoets(backups []PoetProvingServiceClient) NIPostBuilderOption {
+	return func(nb *NIPostBuilder) {
+		nb.backupPoets = backups
+	}
 }
 
 func (nb *NIPostBuilder) load(challenge types.Hash32) {
-	state, err := kvstore.GetNIPostBuilderState(nb.db)
+	state, err := kvstore.GetNIPostBuilderState(nb.db, types.BytesToNodeID(nb.minerID))
 	if err != nil {
 		nb.log.With().Warning("cannot load nipost state", log.Err(err))
 		return
@@ -43,7 +89,7 @@ func (nb *NIPostBuilder) load(challenge types.Hash32) {
 }
 
 func (nb *NIPostBuilder) persist() {
-	if err := kvstore.AddNIPostBuilderState(nb.db, nb.state); err != nil {
+	if err := kvstore.AddNIPostBuilderState(nb.db, types.BytesToNodeID(nb.minerID), nb.state); err != nil {
 		nb.log.With().Warning("cannot store nipost state", log.Err(err))
 	}
 }
@@ -52,10 +98,13 @@ func (nb *NIPostBuilder) persist() {
 type NIPostBuilder struct {
 	minerID           []byte
 	db                *sql.Database
-	postSetupProvider PostSetupProvider
+	postSetupProvider PostProver
 	poetProvers       []PoetProvingServiceClient
+	backupPoets       []PoetProvingServiceClient
+	retry             PoetRetryConfig
 	poetDB            poetDbAPI
 	state             *types.NIPostBuilderState
+	deadlines         *deadlineMonitor
 	log               log.Log
 }
 
@@ -68,21 +117,27 @@ type poetDbAPI interface {
 // NewNIPostBuilder returns a NIPostBuilder.
 func NewNIPostBuilder(
 	minerID types.NodeID,
-	postSetupProvider PostSetupProvider,
+	postSetupProvider PostProver,
 	poetProvers []PoetProvingServiceClient,
 	poetDB poetDbAPI,
 	db *sql.Database,
 	log log.Log,
+	opts ...NIPostBuilderOption,
 ) *NIPostBuilder {
-	return &NIPostBuilder{
+	nb := &NIPostBuilder{
 		minerID:           minerID.ToBytes(),
 		postSetupProvider: postSetupProvider,
 		poetProvers:       poetProvers,
+		retry:             DefaultPoetRetryConfig(),
 		poetDB:            poetDB,
 		state:             &types.NIPostBuilderState{NIPost: &types.NIPost{}},
 		db:                db,
 		log:               log,
 	}
+	for _, opt := range opts {
+		opt(nb)
+	}
+	return nb
 }
 
 // updatePoETProver updates poetProver reference. It should not be executed concurrently with BuildNIPoST.
@@ -95,10 +150,16 @@ func (nb *NIPostBuilder) updatePoETProvers(poetProvers []PoetProvingServiceClien
 	nb.log.With().Info("updated poet proof service clients", log.Int("count", len(nb.poetProvers)))
 }
 
+// setDeadlineMonitor sets the monitor that is notified when phases of the NIPost construction are completed.
+func (nb *NIPostBuilder) setDeadlineMonitor(deadlines *deadlineMonitor) {
+	nb.deadlines = deadlines
+}
+
 // BuildNIPost uses the given challenge to build a NIPost.
 // The process can take considerable time, because it includes waiting for the poet service to
 // publish a proof - a process that takes about an epoch.
-func (nb *NIPostBuilder) BuildNIPost(ctx context.Context, challenge *types.Hash32, commitmentAtx types.ATXID, poetProofDeadline time.Time) (*types.NIPost, time.Duration, error) {
+// Failed submissions are retried until poetRoundStart, when the PoET round stops accepting challenges.
+func (nb *NIPostBuilder) BuildNIPost(ctx context.Context, challenge *types.Hash32, commitmentAtx types.ATXID, poetRoundStart, poetProofDeadline time.Time) (*types.NIPost, time.Duration, error) {
 	nb.load(*challenge)
 
 	if s := nb.postSetupProvider.Status(); s.State != atypes.PostSetupStateComplete {
@@ -109,7 +170,7 @@ func (nb *NIPostBuilder) BuildNIPost(ctx context.Context, challenge *types.Hash3
 
 	// Phase 0: Submit challenge to PoET services.
 	if nb.state.PoetRequests == nil {
-		poetRequests := nb.submitPoetChallenges(ctx, challenge)
+		poetRequests := nb.submitPoetChallenges(ctx, challenge, poetRoundStart)
 		if ctx.Err() != nil {
 			return nil, 0, ctx.Err()
 		}
@@ -121,6 +182,7 @@ func (nb *NIPostBuilder) BuildNIPost(ctx context.Context, challenge *types.Hash3
 		nb.state.PoetRequests = poetRequests
 		nb.persist()
 	}
+	nb.deadlines.complete(atypes.AtxPhasePoetSubmitted)
 
 	// Phase 1: receive proofs from PoET services
 	if nb.state.PoetProofRef == nil {
@@ -137,6 +199,7 @@ func (nb *NIPostBuilder) BuildNIPost(ctx context.Context, challenge *types.Hash3
 		nb.state.PoetProofRef = poetProofRef
 		nb.persist()
 	}
+	nb.deadlines.complete(atypes.AtxPhasePoetProofReceived)
 
 	// Phase 2: Post execution.
 	var postGenDuration time.Duration = 0
@@ -157,6 +220,7 @@ func (nb *NIPostBuilder) BuildNIPost(ctx context.Context, challenge *types.Hash3
 
 		nb.persist()
 	}
+	nb.deadlines.complete(atypes.AtxPhasePostProofGenerated)
 
 	nb.log.Info("finished nipost construction")
 
@@ -198,17 +262,101 @@ func submitPoetChallenge(ctx context.Context, logger log.Log, poet PoetProvingSe
 	}, nil
 }
 
-// Submit the challenge to all registered PoETs.
-func (nb *NIPostBuilder) submitPoetChallenges(ctx context.Context, challenge *types.Hash32) []types.PoetRequest {
+// Submit the challenge to a single PoET, retrying with backoff until the attempts are exhausted
+// or the next attempt would not start before the deadline.
+func (nb *NIPostBuilder) submitWithRetry(ctx context.Context, poet PoetProvingServiceClient, challenge *types.Hash32, deadline time.Time) (*types.PoetRequest, error) {
+	interval := nb.retry.Interval
+	for attempt := 1; ; attempt++ {
+		request, err := submitPoetChallenge(ctx, nb.log, poet, challenge)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		nb.recordSubmission(poet, err == nil)
+		if err == nil {
+			return request, nil
+		}
+		if attempt >= nb.retry.MaxAttempts || !time.Now().Add(interval).Before(deadline) {
+			return nil, err
+		}
+		nb.log.With().Debug("retrying challenge submission",
+			log.String("poet", poet.Address()),
+			log.Int("attempt", attempt),
+			log.Duration("backoff", interval),
+			log.Err(err))
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if interval *= 2; nb.retry.MaxInterval > 0 && interval > nb.retry.MaxInterval {
+			interval = nb.retry.MaxInterval
+		}
+	}
+}
+
+func (nb *NIPostBuilder) recordSubmission(poet PoetProvingServiceClient, success bool) {
+	if err := poets.RecordSubmission(nb.db, poet.Address(), success); err != nil {
+		nb.log.With().Warning("failed to record poet submission", log.String("poet", poet.Address()), log.Err(err))
+	}
+}
+
+// healthyBackups returns the backup PoETs ordered by their health score. Unhealthy services
+// are placed after all healthy ones.
+func (nb *NIPostBuilder) healthyBackups() []PoetProvingServiceClient {
+	type scored struct {
+		poet    PoetProvingServiceClient
+		health  poets.Health
+		healthy bool
+	}
+	candidates := make([]scored, 0, len(nb.backupPoets))
+	for _, poet := range nb.backupPoets {
+		health, err := poets.GetHealth(nb.db, poet.Address())
+		if err != nil {
+			nb.log.With().Warning("failed to load poet health", log.String("poet", poet.Address()), log.Err(err))
+		}
+		candidates = append(candidates, scored{
+			poet:    poet,
+			health:  health,
+			healthy: nb.retry.MaxConsecutiveFailures == 0 || health.ConsecutiveFailures < nb.retry.MaxConsecutiveFailures,
+		})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].healthy != candidates[j].healthy {
+			return candidates[i].healthy
+		}
+		return candidates[i].health.Score() > candidates[j].health.Score()
+	})
+	rst := make([]PoetProvingServiceClient, 0, len(candidates))
+	for _, c := range candidates {
+		rst = append(rst, c.poet)
+	}
+	return rst
+}
+
+// Submit the challenge to all registered PoETs. PoETs that fail to accept the challenge
+// are replaced by the healthiest backup PoETs.
+func (nb *NIPostBuilder) submitPoetChallenges(ctx context.Context, challenge *types.Hash32, deadline time.Time) []types.PoetRequest {
+	poetRequests := nb.submitToPoets(ctx, nb.poetProvers, challenge, deadline)
+	for _, backup := range nb.healthyBackups() {
+		if len(poetRequests) >= len(nb.poetProvers) || ctx.Err() != nil {
+			break
+		}
+		nb.log.With().Info("submitting challenge to backup poet", log.String("poet", backup.Address()))
+		poetRequests = append(poetRequests, nb.submitToPoets(ctx, []PoetProvingServiceClient{backup}, challenge, deadline)...)
+	}
+	return poetRequests
+}
+
+func (nb *NIPostBuilder) submitToPoets(ctx context.Context, provers []PoetProvingServiceClient, challenge *types.Hash32, deadline time.Time) []types.PoetRequest {
 	g, ctx := errgroup.WithContext(ctx)
-	poetRequestsChannel := make(chan types.PoetRequest, len(nb.poetProvers))
-	for _, poetProver := range nb.poetProvers {
+	poetRequestsChannel := make(chan types.PoetRequest, len(provers))
+	for _, poetProver := range provers {
 		poet := poetProver
 		g.Go(func() error {
-			if poetRequest, err := submitPoetChallenge(ctx, nb.log, poet, challenge); err == nil {
+			if poetRequest, err := nb.submitWithRetry(ctx, poet, challenge, deadline); err == nil {
 				poetRequestsChannel <- *poetRequest
 			} else {
-				nb.log.With().Warning("failed to submit challenge to PoET", log.Err(err))
+				nb.log.With().Warning("failed to submit challenge to PoET", log.String("poet", poet.Address()), log.Err(err))
 			}
 			return nil
 		})
@@ -216,7 +364,7 @@ func (nb *NIPostBuilder) submitPoetChallenges(ctx context.Context, challenge *ty
 	g.Wait()
 	close(poetRequestsChannel)
 
-	poetRequests := make([]types.PoetRequest, 0, len(nb.poetProvers))
+	poetRequests := make([]types.PoetRequest, 0, len(provers))
 	for request := range poetRequestsChannel {
 		poetRequests = append(poetRequests, request)
 	}
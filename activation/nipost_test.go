@@ -15,10 +15,12 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/spacemeshos/go-spacemesh/activation/mocks"
+	"github.com/spacemeshos/go-spacemesh/activation/poettest"
 	atypes "github.com/spacemeshos/go-spacemesh/activation/types"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/log/logtest"
 	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/poets"
 )
 
 var (
@@ -103,6 +105,7 @@ func newPoetServiceMock(tb testing.TB) (*mocks.MockPoetProvingServiceClient, *go
 	tb.Helper()
 	controller := gomock.NewController(tb)
 	poetClient := mocks.NewMockPoetProvingServiceClient(controller)
+	poetClient.EXPECT().Address().AnyTimes().Return("http://localhost:9999")
 	return poetClient, controller
 }
 
@@ -145,7 +148,7 @@ func TestNIPostBuilderWithMocks(t *testing.T) {
 	nb := NewNIPostBuilder(minerID, postProvider, []PoetProvingServiceClient{poetProvider},
 		poetDb, sql.InMemory(), logtest.New(t))
 	hash := types.BytesToHash([]byte("anton"))
-	nipost, _, err := nb.BuildNIPost(context.TODO(), &hash, goldenATXID, time.Time{})
+	nipost, _, err := nb.BuildNIPost(context.TODO(), &hash, goldenATXID, time.Time{}, time.Time{})
 	assert.NoError(err)
 	assert.NotNil(nipost)
 }
@@ -175,7 +178,7 @@ func TestPostSetup(t *testing.T) {
 	}()
 
 	hash := types.BytesToHash([]byte("anton"))
-	nipost, _, err := nb.BuildNIPost(context.TODO(), &hash, goldenATXID, time.Time{})
+	nipost, _, err := nb.BuildNIPost(context.TODO(), &hash, goldenATXID, time.Time{}, time.Time{})
 	r.NoError(err)
 	r.NotNil(nipost)
 }
@@ -220,7 +223,7 @@ func buildNIPost(tb testing.TB, r *require.Assertions, postCfg atypes.PostConfig
 	nb := NewNIPostBuilder(minerID, postProvider, []PoetProvingServiceClient{poetProver},
 		poetDb, sql.InMemory(), logtest.New(tb))
 
-	nipost, _, err := nb.BuildNIPost(context.TODO(), &nipostChallenge, goldenATXID, time.Time{})
+	nipost, _, err := nb.BuildNIPost(context.TODO(), &nipostChallenge, goldenATXID, time.Time{}, time.Time{})
 	r.NoError(err)
 	return nipost
 }
@@ -255,7 +258,7 @@ func TestNewNIPostBuilderNotInitialized(t *testing.T) {
 	nb := NewNIPostBuilder(minerIDNotInitialized, postProvider, []PoetProvingServiceClient{poetProver},
 		poetDb, sql.InMemory(), logtest.New(t))
 
-	nipost, _, err := nb.BuildNIPost(context.TODO(), &nipostChallenge, goldenATXID, time.Time{})
+	nipost, _, err := nb.BuildNIPost(context.TODO(), &nipostChallenge, goldenATXID, time.Time{}, time.Time{})
 	r.EqualError(err, "post setup not complete")
 	r.Nil(nipost)
 
@@ -263,7 +266,7 @@ func TestNewNIPostBuilderNotInitialized(t *testing.T) {
 	r.NoError(err)
 	<-done
 
-	nipost, _, err = nb.BuildNIPost(context.TODO(), &nipostChallenge, goldenATXID, time.Time{})
+	nipost, _, err = nb.BuildNIPost(context.TODO(), &nipostChallenge, goldenATXID, time.Time{}, time.Time{})
 	r.NoError(err)
 	r.NotNil(nipost)
 
@@ -285,7 +288,7 @@ func TestNIPostBuilder_BuildNIPost(t *testing.T) {
 	nb := NewNIPostBuilder(minerID, postProvider, []PoetProvingServiceClient{poetProver},
 		poetDb, sql.InMemory(), logtest.New(t))
 	hash := types.BytesToHash([]byte("anton"))
-	nipost, _, err := nb.BuildNIPost(context.TODO(), &hash, goldenATXID, time.Time{})
+	nipost, _, err := nb.BuildNIPost(context.TODO(), &hash, goldenATXID, time.Time{}, time.Time{})
 	assert.NoError(err)
 	assert.NotNil(nipost)
 	db := sql.InMemory()
@@ -294,13 +297,13 @@ func TestNIPostBuilder_BuildNIPost(t *testing.T) {
 	// fail after getting proof ref
 	nb = NewNIPostBuilder(minerID, postProvider, []PoetProvingServiceClient{poetProver}, poetDb, db, logtest.New(t))
 	poetDb.errOn = true
-	nipost, _, err = nb.BuildNIPost(context.TODO(), &hash, goldenATXID, time.Time{})
+	nipost, _, err = nb.BuildNIPost(context.TODO(), &hash, goldenATXID, time.Time{}, time.Time{})
 	assert.Nil(nipost)
 	assert.Error(err)
 
 	// check that proof ref is not called again
 	nb = NewNIPostBuilder(minerID, postProvider, []PoetProvingServiceClient{poetProver}, poetDb, db, logtest.New(t))
-	nipost, _, err = nb.BuildNIPost(context.TODO(), &hash, goldenATXID, time.Time{})
+	nipost, _, err = nb.BuildNIPost(context.TODO(), &hash, goldenATXID, time.Time{}, time.Time{})
 	assert.Nil(nipost)
 	assert.Error(err)
 
@@ -309,7 +312,7 @@ func TestNIPostBuilder_BuildNIPost(t *testing.T) {
 	poetDb.errOn = false
 	postProvider.setError = true
 	// check that proof ref is not called again
-	nipost, _, err = nb.BuildNIPost(context.TODO(), &hash, goldenATXID, time.Time{})
+	nipost, _, err = nb.BuildNIPost(context.TODO(), &hash, goldenATXID, time.Time{}, time.Time{})
 	assert.Nil(nipost)
 	assert.Error(err)
 
@@ -318,7 +321,7 @@ func TestNIPostBuilder_BuildNIPost(t *testing.T) {
 	poetDb.errOn = false
 	postProvider.setError = false
 	// check that proof ref is not called again
-	nipost, _, err = nb.BuildNIPost(context.TODO(), &hash, goldenATXID, time.Time{})
+	nipost, _, err = nb.BuildNIPost(context.TODO(), &hash, goldenATXID, time.Time{}, time.Time{})
 	assert.NotNil(nipost)
 	assert.NoError(err)
 
@@ -327,7 +330,7 @@ func TestNIPostBuilder_BuildNIPost(t *testing.T) {
 	poetProver.EXPECT().PoetServiceID(gomock.Any()).Return([]byte{}, nil)
 	poetProver.EXPECT().Submit(gomock.Any(), gomock.Any()).Return(&types.PoetRound{}, nil)
 	hash2 := types.BytesToHash([]byte("anton1"))
-	nipost, _, err = nb.BuildNIPost(context.TODO(), &hash2, goldenATXID, time.Time{})
+	nipost, _, err = nb.BuildNIPost(context.TODO(), &hash2, goldenATXID, time.Time{}, time.Time{})
 	assert.Equal(4, postProvider.called)
 
 	assert.NotNil(nipost)
@@ -339,6 +342,7 @@ func createMockPoetService(t *testing.T, id []byte) PoetProvingServiceClient {
 	poet := mocks.NewMockPoetProvingServiceClient(gomock.NewController(t))
 	poet.EXPECT().PoetServiceID(gomock.Any()).Times(1).Return(id, nil)
 	poet.EXPECT().Submit(gomock.Any(), gomock.Any()).Times(1).Return(&types.PoetRound{}, nil)
+	poet.EXPECT().Address().AnyTimes().Return(fmt.Sprintf("http://poet-%x", id))
 	return poet
 }
 
@@ -357,7 +361,7 @@ func TestNIPostBuilder_ManyPoETs_DeadlineReached(t *testing.T) {
 
 	challenge := types.BytesToHash([]byte("challenge"))
 	go func() error {
-		nipost, _, err := nb.BuildNIPost(context.TODO(), &challenge, goldenATXID, deadline)
+		nipost, _, err := nb.BuildNIPost(context.TODO(), &challenge, goldenATXID, time.Time{}, deadline)
 		assert.NoError(t, err)
 		resultChan <- nipost
 		return nil
@@ -401,7 +405,7 @@ func TestNIPostBuilder_ManyPoETs_AllFinished(t *testing.T) {
 	deadline := time.Now().Add(time.Millisecond * 10)
 
 	go func() error {
-		nipost, _, err := nb.BuildNIPost(context.TODO(), &challenge, goldenATXID, deadline)
+		nipost, _, err := nb.BuildNIPost(context.TODO(), &challenge, goldenATXID, time.Time{}, deadline)
 		assert.NoError(err)
 		resultChan <- nipost
 		return nil
@@ -512,7 +516,7 @@ func TestNIPostBuilder_Close(t *testing.T) {
 	hash := types.BytesToHash([]byte("anton"))
 	ctx, close := context.WithCancel(context.Background())
 	close()
-	nipost, _, err := nb.BuildNIPost(ctx, &hash, goldenATXID, time.Time{})
+	nipost, _, err := nb.BuildNIPost(ctx, &hash, goldenATXID, time.Time{}, time.Time{})
 	r.ErrorIs(err, context.Canceled)
 	r.Nil(nipost)
 }
@@ -531,7 +535,7 @@ func TestNIPSTBuilder_PoetUnstable(t *testing.T) {
 	t.Run("PoetServiceID", func(t *testing.T) {
 		poetProver.EXPECT().PoetServiceID(gomock.Any()).Return(nil, errors.New("test"))
 		hash := types.BytesToHash([]byte("test"))
-		nipst, _, err := nb.BuildNIPost(context.TODO(), &hash, goldenATXID, time.Time{})
+		nipst, _, err := nb.BuildNIPost(context.TODO(), &hash, goldenATXID, time.Time{}, time.Time{})
 		require.ErrorIs(t, err, ErrPoetServiceUnstable)
 		require.Nil(t, nipst)
 	})
@@ -540,7 +544,7 @@ func TestNIPSTBuilder_PoetUnstable(t *testing.T) {
 		poetProver.EXPECT().PoetServiceID(gomock.Any()).Return([]byte{}, nil)
 		poetProver.EXPECT().Submit(gomock.Any(), gomock.Any()).Return(nil, errors.New("test"))
 		hash := types.BytesToHash([]byte("test"))
-		nipst, _, err := nb.BuildNIPost(context.TODO(), &hash, goldenATXID, time.Time{})
+		nipst, _, err := nb.BuildNIPost(context.TODO(), &hash, goldenATXID, time.Time{}, time.Time{})
 		require.ErrorIs(t, err, ErrPoetServiceUnstable)
 		require.Nil(t, nipst)
 	})
@@ -549,12 +553,96 @@ func TestNIPSTBuilder_PoetUnstable(t *testing.T) {
 		poetProver.EXPECT().PoetServiceID(gomock.Any()).Return([]byte{}, nil)
 		poetProver.EXPECT().Submit(gomock.Any(), gomock.Any()).Return(&types.PoetRound{}, nil)
 		hash := types.BytesToHash([]byte("test")) // see poetDbMock for included challenges
-		nipst, _, err := nb.BuildNIPost(context.TODO(), &hash, goldenATXID, time.Time{})
+		nipst, _, err := nb.BuildNIPost(context.TODO(), &hash, goldenATXID, time.Time{}, time.Time{})
 		require.ErrorIs(t, err, ErrPoetProofNotReceived)
 		require.Nil(t, nipst)
 	})
 }
 
+func TestNIPostBuilder_SubmitRetry(t *testing.T) {
+	srv, err := poettest.NewServer([]byte("poet"))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, srv.Close()) })
+	poet := NewPoetClient(srv.GRPCAddress())
+
+	db := sql.InMemory()
+	nb := NewNIPostBuilder(minerID, &postSetupProviderMock{}, []PoetProvingServiceClient{poet},
+		newPoetDbMock(), db, logtest.New(t),
+		WithPoetRetry(PoetRetryConfig{Interval: time.Millisecond, MaxInterval: 5 * time.Millisecond, MaxAttempts: 5}),
+	)
+	challenge := types.BytesToHash([]byte("challenge"))
+
+	t.Run("succeeds after retries", func(t *testing.T) {
+		srv.FailSubmissions(3)
+		requests := nb.submitPoetChallenges(context.Background(), &challenge, time.Now().Add(time.Minute))
+		require.Len(t, requests, 1)
+		require.Equal(t, [][]byte{challenge[:]}, srv.Challenges())
+
+		health, err := poets.GetHealth(db, poet.Address())
+		require.NoError(t, err)
+		require.EqualValues(t, 1, health.Successes)
+		require.EqualValues(t, 3, health.Failures)
+		require.Zero(t, health.ConsecutiveFailures)
+	})
+
+	t.Run("attempts exhausted", func(t *testing.T) {
+		srv.NextRound()
+		srv.FailSubmissions(5)
+		requests := nb.submitPoetChallenges(context.Background(), &challenge, time.Now().Add(time.Minute))
+		require.Empty(t, requests)
+		require.Empty(t, srv.Challenges())
+	})
+
+	t.Run("no retries past deadline", func(t *testing.T) {
+		srv.FailSubmissions(1)
+		requests := nb.submitPoetChallenges(context.Background(), &challenge, time.Now())
+		require.Empty(t, requests)
+
+		health, err := poets.GetHealth(db, poet.Address())
+		require.NoError(t, err)
+		require.EqualValues(t, 6, health.ConsecutiveFailures)
+	})
+}
+
+func TestNIPostBuilder_BackupPoets(t *testing.T) {
+	newServer := func() (*poettest.Server, PoetProvingServiceClient) {
+		srv, err := poettest.NewServer([]byte("poet"))
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, srv.Close()) })
+		return srv, NewPoetClient(srv.HTTPAddress())
+	}
+	primary, primaryClient := newServer()
+	unhealthy, unhealthyClient := newServer()
+	healthy, healthyClient := newServer()
+
+	db := sql.InMemory()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, poets.RecordSubmission(db, unhealthyClient.Address(), false))
+	}
+	require.NoError(t, poets.RecordSubmission(db, healthyClient.Address(), true))
+
+	nb := NewNIPostBuilder(minerID, &postSetupProviderMock{}, []PoetProvingServiceClient{primaryClient},
+		newPoetDbMock(), db, logtest.New(t),
+		WithPoetRetry(PoetRetryConfig{Interval: time.Millisecond, MaxAttempts: 2, MaxConsecutiveFailures: 3}),
+		WithBackupPoets([]PoetProvingServiceClient{unhealthyClient, healthyClient}),
+	)
+	challenge := types.BytesToHash([]byte("challenge"))
+
+	primary.FailSubmissions(2)
+	requests := nb.submitPoetChallenges(context.Background(), &challenge, time.Now().Add(time.Minute))
+	require.Len(t, requests, 1)
+	require.Empty(t, primary.Challenges())
+	require.Empty(t, unhealthy.Challenges())
+	require.Equal(t, [][]byte{challenge[:]}, healthy.Challenges())
+
+	// backups are not used while the registered poets accept challenges
+	healthy.NextRound()
+	requests = nb.submitPoetChallenges(context.Background(), &challenge, time.Now().Add(time.Minute))
+	require.Len(t, requests, 1)
+	require.Equal(t, [][]byte{challenge[:]}, primary.Challenges())
+	require.Empty(t, healthy.Challenges())
+}
+
 func FuzzBuilderStateConsistency(f *testing.F) {
 	tester.FuzzConsistency[types.NIPostBuilderState](f)
 }
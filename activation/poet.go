@@ -7,10 +7,14 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/spacemeshos/poet/integration"
 	"github.com/spacemeshos/poet/release/proto/go/rpc/api"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 
 	"github.com/spacemeshos/go-spacemesh/common/types"
 )
@@ -55,26 +59,89 @@ func NewHTTPPoetHarness(disableBroadcast bool) (*HTTPPoetHarness, error) {
 	}, nil
 }
 
+const (
+	grpcPoetScheme = "grpc://"
+	httpPoetScheme = "http://"
+
+	defaultPoetRequestTimeout = 10 * time.Second
+)
+
+type poetClientConfig struct {
+	requestTimeout time.Duration
+	httpClient     *http.Client
+}
+
+// PoetClientOpt configures a PoET client.
+type PoetClientOpt func(*poetClientConfig)
+
+// WithRequestTimeout sets the timeout of a single request to the PoET service.
+func WithRequestTimeout(timeout time.Duration) PoetClientOpt {
+	return func(cfg *poetClientConfig) {
+		cfg.requestTimeout = timeout
+	}
+}
+
+// WithHTTPClient sets the http client used by the HTTP/JSON transport.
+func WithHTTPClient(client *http.Client) PoetClientOpt {
+	return func(cfg *poetClientConfig) {
+		cfg.httpClient = client
+	}
+}
+
+func newPoetClientConfig(opts ...PoetClientOpt) *poetClientConfig {
+	cfg := &poetClientConfig{
+		requestTimeout: defaultPoetRequestTimeout,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.httpClient == nil {
+		cfg.httpClient = &http.Client{
+			Transport: http.DefaultTransport.(*http.Transport).Clone(),
+		}
+	}
+	return cfg
+}
+
+func defaultPoetClientFunc(target string) PoetProvingServiceClient {
+	return NewPoetClient(target)
+}
+
+// NewPoetClient returns a client for the PoET service at target. The transport is selected by
+// the scheme of the target: "grpc://" selects gRPC, anything else the HTTP/JSON gateway.
+func NewPoetClient(target string, opts ...PoetClientOpt) PoetProvingServiceClient {
+	if strings.HasPrefix(target, grpcPoetScheme) {
+		return NewGRPCPoetClient(strings.TrimPrefix(target, grpcPoetScheme), opts...)
+	}
+	return NewHTTPPoetClient(target, opts...)
+}
+
 // HTTPPoetClient implements PoetProvingServiceClient interface.
 type HTTPPoetClient struct {
+	target     string
 	baseURL    string
+	client     *http.Client
 	ctxFactory func(ctx context.Context) (context.Context, context.CancelFunc)
 }
 
-func defaultPoetClientFunc(target string) PoetProvingServiceClient {
-	return NewHTTPPoetClient(target)
-}
-
 // NewHTTPPoetClient returns new instance of HTTPPoetClient for the specified target.
-func NewHTTPPoetClient(target string) *HTTPPoetClient {
+func NewHTTPPoetClient(target string, opts ...PoetClientOpt) *HTTPPoetClient {
+	cfg := newPoetClientConfig(opts...)
 	return &HTTPPoetClient{
-		baseURL: fmt.Sprintf("http://%s/v1", target),
+		target:  target,
+		baseURL: fmt.Sprintf("http://%s/v1", strings.TrimPrefix(target, httpPoetScheme)),
+		client:  cfg.httpClient,
 		ctxFactory: func(ctx context.Context) (context.Context, context.CancelFunc) {
-			return context.WithTimeout(ctx, 10*time.Second)
+			return context.WithTimeout(ctx, cfg.requestTimeout)
 		},
 	}
 }
 
+// Address returns the address of the PoET service.
+func (c *HTTPPoetClient) Address() string {
+	return c.target
+}
+
 // Start is an administrative endpoint of the proving service that tells it to start. This is mostly done in tests,
 // since it requires administrative permissions to the proving service.
 func (c *HTTPPoetClient) Start(ctx context.Context, gatewayAddresses []string) error {
@@ -125,7 +192,7 @@ func (c *HTTPPoetClient) req(ctx context.Context, method string, endURL string,
 	defer cancel()
 	req = req.WithContext(ctx)
 
-	res, err := http.DefaultClient.Do(req)
+	res, err := c.client.Do(req)
 	if err != nil {
 		return fmt.Errorf("perform request: %w", err)
 	}
@@ -144,3 +211,80 @@ func (c *HTTPPoetClient) req(ctx context.Context, method string, endURL string,
 
 	return nil
 }
+
+// GRPCPoetClient implements PoetProvingServiceClient interface on top of the gRPC api of the PoET service.
+// The connection is established lazily on the first request.
+type GRPCPoetClient struct {
+	target  string
+	timeout time.Duration
+
+	once   sync.Once
+	conn   *grpc.ClientConn
+	client api.PoetClient
+	err    error
+}
+
+// NewGRPCPoetClient returns new instance of GRPCPoetClient for the specified target.
+func NewGRPCPoetClient(target string, opts ...PoetClientOpt) *GRPCPoetClient {
+	cfg := newPoetClientConfig(opts...)
+	return &GRPCPoetClient{
+		target:  target,
+		timeout: cfg.requestTimeout,
+	}
+}
+
+func (c *GRPCPoetClient) dial() (api.PoetClient, error) {
+	c.once.Do(func() {
+		c.conn, c.err = grpc.Dial(c.target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if c.err == nil {
+			c.client = api.NewPoetClient(c.conn)
+		}
+	})
+	if c.err != nil {
+		return nil, fmt.Errorf("dial %s: %w", c.target, c.err)
+	}
+	return c.client, nil
+}
+
+// Address returns the address of the PoET service.
+func (c *GRPCPoetClient) Address() string {
+	return grpcPoetScheme + c.target
+}
+
+// Submit registers a challenge in the proving service current open round.
+func (c *GRPCPoetClient) Submit(ctx context.Context, challenge types.Hash32) (*types.PoetRound, error) {
+	client, err := c.dial()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	resp, err := client.Submit(ctx, &api.SubmitRequest{Challenge: challenge[:]})
+	if err != nil {
+		return nil, fmt.Errorf("submit: %w", err)
+	}
+	return &types.PoetRound{ID: resp.RoundId}, nil
+}
+
+// PoetServiceID returns the public key of the PoET proving service.
+func (c *GRPCPoetClient) PoetServiceID(ctx context.Context) ([]byte, error) {
+	client, err := c.dial()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	resp, err := client.GetInfo(ctx, &api.GetInfoRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("get info: %w", err)
+	}
+	return resp.ServicePubKey, nil
+}
+
+// Close closes the connection to the PoET service.
+func (c *GRPCPoetClient) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.Close()
+}
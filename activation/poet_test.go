@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/spacemeshos/go-spacemesh/activation/poettest"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 )
 
@@ -57,3 +58,40 @@ func testHTTPPoetClient(c *HTTPPoetHarness, assert *require.Assertions) {
 	assert.NoError(err)
 	assert.NotNil(poetRound)
 }
+
+func TestPoetClient_Transports(t *testing.T) {
+	pubKey := []byte("poet service public key")
+	srv, err := poettest.NewServer(pubKey)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, srv.Close()) })
+
+	for _, tc := range []struct {
+		desc   string
+		target string
+	}{
+		{desc: "grpc", target: srv.GRPCAddress()},
+		{desc: "http", target: srv.HTTPAddress()},
+	} {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			client := NewPoetClient(tc.target)
+			require.Equal(t, tc.target, client.Address())
+
+			id, err := client.PoetServiceID(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, pubKey, id)
+
+			var challenge types.Hash32
+			_, err = rand.Read(challenge[:])
+			require.NoError(t, err)
+			round, err := client.Submit(context.Background(), challenge)
+			require.NoError(t, err)
+			require.Equal(t, "0", round.ID)
+			require.Contains(t, srv.Challenges(), challenge[:])
+
+			srv.FailSubmissions(1)
+			_, err = client.Submit(context.Background(), challenge)
+			require.Error(t, err)
+		})
+	}
+}
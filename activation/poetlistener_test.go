@@ -47,6 +47,10 @@ func TestNewPoetListener(t *testing.T) {
 	poetDb.EXPECT().Validate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("bad poet message"))
 	require.Equal(t, pubsub.ValidationIgnore, listener.HandlePoetProofMessage(context.TODO(), "test", data))
 
+	poetDb.EXPECT().HasProof(ref).Return(false)
+	poetDb.EXPECT().Validate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(ErrUnknownPoetService)
+	require.Equal(t, pubsub.ValidationIgnore, listener.HandlePoetProofMessage(context.TODO(), "test", data))
+
 	poetDb.EXPECT().HasProof(ref).Return(true)
 	require.Equal(t, pubsub.ValidationIgnore, listener.HandlePoetProofMessage(context.TODO(), "test", data))
 }
@@ -0,0 +1,146 @@
+// Package poettest provides an in-process PoET service for tests.
+package poettest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	gonet "net"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/spacemeshos/poet/release/proto/go/rpc/api"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server is an in-process PoET service that serves both the gRPC and the HTTP/JSON api.
+// It accepts every challenge into a single open round and can be instructed to reject submissions,
+// which makes it suitable for exercising PoET clients in tests.
+type Server struct {
+	api.UnimplementedPoetServer
+
+	pubKey []byte
+
+	mu         sync.Mutex
+	round      int
+	failures   int
+	challenges [][]byte
+
+	grpcServer   *grpc.Server
+	grpcListener gonet.Listener
+	httpServer   *http.Server
+	httpListener gonet.Listener
+}
+
+// NewServer starts a Server with the given service public key on localhost.
+func NewServer(pubKey []byte) (*Server, error) {
+	grpcListener, err := gonet.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("listen grpc: %w", err)
+	}
+	httpListener, err := gonet.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		grpcListener.Close()
+		return nil, fmt.Errorf("listen http: %w", err)
+	}
+
+	srv := &Server{
+		pubKey:       pubKey,
+		grpcServer:   grpc.NewServer(),
+		grpcListener: grpcListener,
+		httpListener: httpListener,
+	}
+	api.RegisterPoetServer(srv.grpcServer, srv)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/submit", func(w http.ResponseWriter, r *http.Request) {
+		var req api.SubmitRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		resp, err := srv.Submit(r.Context(), &req)
+		writeJSON(w, resp, err)
+	})
+	mux.HandleFunc("/v1/info", func(w http.ResponseWriter, r *http.Request) {
+		resp, err := srv.GetInfo(r.Context(), &api.GetInfoRequest{})
+		writeJSON(w, resp, err)
+	})
+	srv.httpServer = &http.Server{Handler: mux}
+
+	go srv.grpcServer.Serve(grpcListener)
+	go srv.httpServer.Serve(httpListener)
+	return srv, nil
+}
+
+func writeJSON(w http.ResponseWriter, resp any, err error) {
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// GRPCAddress returns the target of the gRPC api, suitable for activation.NewPoetClient.
+func (s *Server) GRPCAddress() string {
+	return "grpc://" + s.grpcListener.Addr().String()
+}
+
+// HTTPAddress returns the target of the HTTP/JSON api, suitable for activation.NewPoetClient.
+func (s *Server) HTTPAddress() string {
+	return s.httpListener.Addr().String()
+}
+
+// FailSubmissions makes the next n submissions fail.
+func (s *Server) FailSubmissions(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = n
+}
+
+// NextRound closes the open round. Subsequent challenges are accepted into a new round.
+func (s *Server) NextRound() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.round++
+	s.challenges = nil
+}
+
+// Challenges returns the challenges accepted into the open round.
+func (s *Server) Challenges() [][]byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([][]byte(nil), s.challenges...)
+}
+
+// Close stops both apis.
+func (s *Server) Close() error {
+	s.grpcServer.Stop()
+	return s.httpServer.Close()
+}
+
+// Submit implements api.PoetServer.
+func (s *Server) Submit(_ context.Context, req *api.SubmitRequest) (*api.SubmitResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failures > 0 {
+		s.failures--
+		return nil, status.Error(codes.Unavailable, "submission rejected")
+	}
+	s.challenges = append(s.challenges, req.Challenge)
+	return &api.SubmitResponse{RoundId: strconv.Itoa(s.round)}, nil
+}
+
+// GetInfo implements api.PoetServer.
+func (s *Server) GetInfo(context.Context, *api.GetInfoRequest) (*api.GetInfoResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &api.GetInfoResponse{
+		OpenRoundId:   strconv.Itoa(s.round),
+		ServicePubKey: s.pubKey,
+	}, nil
+}
@@ -1,6 +1,7 @@
 package activation
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sync"
@@ -29,6 +30,12 @@ func DefaultPostSetupOpts() atypes.PostSetupOpts {
 	return (atypes.PostSetupOpts)(config.DefaultInitOpts())
 }
 
+// PostProver generates proofs over initialized Post data.
+type PostProver interface {
+	Status() *atypes.PostSetupStatus
+	GenerateProof(challenge []byte, commitmentAtx types.ATXID) (*types.Post, *types.PostMetadata, error)
+}
+
 // PostSetupProvider defines the functionality required for Post setup.
 type PostSetupProvider interface {
 	Status() *atypes.PostSetupStatus
@@ -63,6 +70,9 @@ type PostSetupManager struct {
 	lastOpts *atypes.PostSetupOpts
 	lastErr  error
 
+	// commitment is the commitment of the last data creation session.
+	commitment []byte
+
 	// startedChan indicates whether a data creation session has started.
 	// The channel instance is replaced in the end of the session.
 	startedChan chan struct{}
@@ -70,6 +80,10 @@ type PostSetupManager struct {
 	// doneChan indicates whether the current data creation session has finished.
 	// The channel instance is replaced in the beginning of the session.
 	doneChan chan struct{}
+
+	// dataMu is held for writing while the Post data is repaired, and for reading
+	// while proofs are generated or the data is verified without repair.
+	dataMu sync.RWMutex
 }
 
 // NewPostSetupManager creates a new instance of PostSetupManager.
@@ -88,7 +102,10 @@ func NewPostSetupManager(id types.NodeID, cfg atypes.PostConfig, logger log.Log,
 	return mgr, nil
 }
 
-var errNotComplete = errors.New("not complete")
+var (
+	errNotComplete = errors.New("not complete")
+	errDataInUse   = errors.New("post data is in use")
+)
 
 // Status returns the setup current status.
 func (mgr *PostSetupManager) Status() *atypes.PostSetupStatus {
@@ -237,6 +254,7 @@ func (mgr *PostSetupManager) StartSession(opts atypes.PostSetupOpts, commitmentA
 	mgr.mu.Lock()
 	mgr.init = newInit
 	mgr.lastOpts = &opts
+	mgr.commitment = commitment
 	mgr.lastErr = nil
 	close(mgr.startedChan)
 	mgr.doneChan = make(chan struct{})
@@ -329,6 +347,9 @@ func (mgr *PostSetupManager) GenerateProof(challenge []byte, commitmentAtx types
 		return nil, nil, errNotComplete
 	}
 
+	mgr.dataMu.RLock()
+	defer mgr.dataMu.RUnlock()
+
 	// TODO(mafa): id field in post package should be renamed to commitment otherwise error messages are confusing
 	commitment := GetCommitmentBytes(mgr.id, commitmentAtx)
 	prover, err := proving.NewProver(config.Config(mgr.cfg), mgr.LastOpts().DataDir, commitment)
@@ -354,6 +375,48 @@ func (mgr *PostSetupManager) GenerateProof(challenge []byte, commitmentAtx types
 	return p, m, nil
 }
 
+// VerifyData verifies the labels of the data created in the last session against its commitment,
+// and regenerates the corrupted labels if opts.Repair is set.
+// Repair fails if proofs are being generated or the data is being verified, and proofs wait for the repair to finish.
+func (mgr *PostSetupManager) VerifyData(ctx context.Context, opts atypes.PostVerifyOpts) (<-chan *atypes.PostVerifyStatus, error) {
+	if mgr.getState() != atypes.PostSetupStateComplete {
+		return nil, errNotComplete
+	}
+
+	lock, unlock := mgr.dataMu.TryRLock, mgr.dataMu.RUnlock
+	if opts.Repair {
+		lock, unlock = mgr.dataMu.TryLock, mgr.dataMu.Unlock
+	}
+	if !lock() {
+		return nil, errDataInUse
+	}
+
+	mgr.mu.Lock()
+	lastOpts := mgr.lastOpts
+	commitment := mgr.commitment
+	mgr.mu.Unlock()
+
+	verifier, err := NewPostDataVerifier(lastOpts.DataDir, commitment, lastOpts.ComputeProviderID, mgr.logger)
+	if err != nil {
+		unlock()
+		return nil, fmt.Errorf("new verifier: %w", err)
+	}
+	statusChan := verifier.Verify(ctx, opts)
+	rst := make(chan *atypes.PostVerifyStatus, 1)
+	go func() {
+		defer close(rst)
+		// data is released before the channel is closed
+		defer unlock()
+		for status := range statusChan {
+			select {
+			case rst <- status:
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return rst, nil
+}
+
 // LastError returns the Post setup last error.
 func (mgr *PostSetupManager) LastError() error {
 	mgr.mu.Lock()
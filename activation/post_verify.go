@@ -0,0 +1,330 @@
+package activation
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spacemeshos/post/initialization"
+	"github.com/spacemeshos/post/oracle"
+	"github.com/spacemeshos/post/shared"
+
+	atypes "github.com/spacemeshos/go-spacemesh/activation/types"
+	"github.com/spacemeshos/go-spacemesh/log"
+)
+
+// verifyChunkLabels is the number of labels that are verified at once.
+// It is a multiple of 8, so that chunks are aligned to bytes for every label size.
+const verifyChunkLabels = 1 << 14
+
+// verifyProgressInterval is the minimal interval between progress updates of a verification.
+const verifyProgressInterval = time.Second
+
+// ErrCommitmentMismatch is returned when the Post data was created for a different commitment.
+var ErrCommitmentMismatch = errors.New("post data was created for a different commitment")
+
+// labelOracle computes the labels in the range [start, end].
+type labelOracle func(providerID uint, commitment []byte, start, end uint64, bitsPerLabel uint32) ([]byte, error)
+
+// PostDataVerifier verifies the labels of Post data against the commitment they were created for
+// and regenerates the labels that are corrupted.
+type PostDataVerifier struct {
+	logger     log.Log
+	dataDir    string
+	commitment []byte
+	providerID uint
+	metadata   *initialization.Metadata
+	fileLabels uint64
+	oracle     labelOracle
+	// seed returns the seed of the sample of chunks that are verified.
+	seed func() int64
+}
+
+// NewPostDataVerifier creates a verifier for the Post data in dataDir. The layout of the data is loaded from its metadata.
+// If commitment is nil, the data is verified against the commitment recorded in the metadata.
+// Labels are computed with the compute provider with providerID, or on the cpu if it is negative.
+func NewPostDataVerifier(dataDir string, commitment []byte, providerID int, logger log.Log) (*PostDataVerifier, error) {
+	metadata, err := initialization.LoadMetadata(dataDir)
+	if err != nil {
+		return nil, fmt.Errorf("load post metadata: %w", err)
+	}
+	if commitment == nil {
+		commitment = metadata.ID
+	} else if !bytes.Equal(commitment, metadata.ID) {
+		return nil, ErrCommitmentMismatch
+	}
+	numLabels := metadata.LabelsPerUnit * uint64(metadata.NumUnits)
+	if metadata.NumFiles == 0 || metadata.BitsPerLabel == 0 || numLabels%uint64(metadata.NumFiles) != 0 {
+		return nil, fmt.Errorf("invalid post metadata: %d labels, %d files, %d bits per label",
+			numLabels, metadata.NumFiles, metadata.BitsPerLabel)
+	}
+	fileLabels := numLabels / uint64(metadata.NumFiles)
+	if fileLabels*uint64(metadata.BitsPerLabel)%8 != 0 {
+		return nil, fmt.Errorf("invalid post metadata: files are not aligned to bytes")
+	}
+	if providerID < 0 {
+		providerID = initialization.CPUProviderID()
+	}
+	return &PostDataVerifier{
+		logger:     logger,
+		dataDir:    dataDir,
+		commitment: commitment,
+		providerID: uint(providerID),
+		metadata:   metadata,
+		fileLabels: fileLabels,
+		oracle:     oracle.WorkOracle,
+		seed:       func() int64 { return time.Now().UnixNano() },
+	}, nil
+}
+
+// Verify verifies the Post data and, if requested, repairs it.
+// The returned channel streams progress updates and is closed after the final status, which is marked as Done.
+func (v *PostDataVerifier) Verify(ctx context.Context, opts atypes.PostVerifyOpts) <-chan *atypes.PostVerifyStatus {
+	statusChan := make(chan *atypes.PostVerifyStatus, 1)
+	go func() {
+		defer close(statusChan)
+		status := &atypes.PostVerifyStatus{}
+		send := func() {
+			snapshot := *status
+			snapshot.Corrupted = append([]atypes.LabelRange(nil), status.Corrupted...)
+			select {
+			case statusChan <- &snapshot:
+			case <-ctx.Done():
+			}
+		}
+		if err := v.verify(ctx, opts, status, send); err != nil {
+			status.LastError = err
+		}
+		status.Done = true
+		send()
+		v.logger.With().Info("post data verification finished",
+			log.String("data_dir", v.dataDir),
+			log.Uint64("labels_verified", status.LabelsVerified),
+			log.Uint64("labels_corrupted", status.LabelsCorrupted),
+			log.Uint64("labels_repaired", status.LabelsRepaired),
+			log.Err(status.LastError),
+		)
+	}()
+	return statusChan
+}
+
+func (v *PostDataVerifier) verify(ctx context.Context, opts atypes.PostVerifyOpts, status *atypes.PostVerifyStatus, send func()) error {
+	if opts.Fraction <= 0 || opts.Fraction > 1 {
+		return fmt.Errorf("invalid fraction %v, expected (0, 1]", opts.Fraction)
+	}
+	chunks := (v.fileLabels + verifyChunkLabels - 1) / verifyChunkLabels
+	selected := sampleChunks(rand.New(rand.NewSource(v.seed())), chunks*uint64(v.metadata.NumFiles), opts.Fraction)
+	for file := 0; file < int(v.metadata.NumFiles); file++ {
+		for chunk := uint64(0); chunk < chunks; chunk++ {
+			if selected(uint64(file)*chunks + chunk) {
+				start, end := v.chunkRange(chunk)
+				status.LabelsToVerify += end - start
+			}
+		}
+	}
+	v.logger.With().Info("verifying post data",
+		log.String("data_dir", v.dataDir),
+		log.Uint64("labels_to_verify", status.LabelsToVerify),
+		log.Bool("repair", opts.Repair),
+	)
+
+	lastProgress := time.Now()
+	for file := 0; file < int(v.metadata.NumFiles); file++ {
+		corrupted, err := v.verifyFile(ctx, file, chunks, selected, func(labels uint64) {
+			status.LabelsVerified += labels
+			if time.Since(lastProgress) >= verifyProgressInterval {
+				lastProgress = time.Now()
+				send()
+			}
+		})
+		if err != nil {
+			return err
+		}
+		for _, r := range corrupted {
+			status.LabelsCorrupted += r.End - r.Start
+		}
+		status.Corrupted = append(status.Corrupted, corrupted...)
+	}
+	if !opts.Repair || len(status.Corrupted) == 0 {
+		return nil
+	}
+	send()
+	for _, r := range status.Corrupted {
+		repaired, err := v.repair(ctx, r)
+		status.LabelsRepaired += repaired
+		if err != nil {
+			return err
+		}
+		send()
+	}
+	return nil
+}
+
+// sampleChunks selects max(1, ceil(fraction*total)) distinct chunks out of total and returns
+// a function that reports whether a chunk is selected.
+func sampleChunks(rng *rand.Rand, total uint64, fraction float64) func(chunk uint64) bool {
+	if fraction >= 1 {
+		return func(uint64) bool { return true }
+	}
+	k := uint64(math.Ceil(fraction * float64(total)))
+	if k == 0 {
+		k = 1
+	}
+	if k >= total {
+		return func(uint64) bool { return true }
+	}
+	// Floyd's algorithm, memory is bounded by the size of the sample
+	selected := make(map[uint64]struct{}, k)
+	for j := total - k; j < total; j++ {
+		t := uint64(rng.Int63n(int64(j) + 1))
+		if _, ok := selected[t]; ok {
+			t = j
+		}
+		selected[t] = struct{}{}
+	}
+	return func(chunk uint64) bool {
+		_, ok := selected[chunk]
+		return ok
+	}
+}
+
+// chunkRange returns the range of labels of the chunk, relative to the beginning of the file.
+func (v *PostDataVerifier) chunkRange(chunk uint64) (uint64, uint64) {
+	start := chunk * verifyChunkLabels
+	end := start + verifyChunkLabels
+	if end > v.fileLabels {
+		end = v.fileLabels
+	}
+	return start, end
+}
+
+func (v *PostDataVerifier) filename(file int) string {
+	return filepath.Join(v.dataDir, shared.InitFileName(file))
+}
+
+func (v *PostDataVerifier) byteOffset(label uint64) int64 {
+	return int64(label * uint64(v.metadata.BitsPerLabel) / 8)
+}
+
+// verifyFile verifies the selected chunks of the file and returns the corrupted ranges of labels.
+// A missing or truncated file is corrupted.
+// Chunks are numbered across all files.
+func (v *PostDataVerifier) verifyFile(ctx context.Context, file int, chunks uint64, selected func(uint64) bool, verified func(uint64)) ([]atypes.LabelRange, error) {
+	f, err := os.Open(v.filename(file))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("open post data: %w", err)
+	}
+	if f != nil {
+		defer f.Close()
+	}
+	fileOffset := uint64(file) * v.fileLabels
+	var corrupted []atypes.LabelRange
+	for chunk := uint64(0); chunk < chunks; chunk++ {
+		if !selected(uint64(file)*chunks + chunk) {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		start, end := v.chunkRange(chunk)
+		expected, err := v.oracle(v.providerID, v.commitment, fileOffset+start, fileOffset+end-1, uint32(v.metadata.BitsPerLabel))
+		if err != nil {
+			return nil, fmt.Errorf("compute labels: %w", err)
+		}
+		actual := make([]byte, len(expected))
+		n := 0
+		if f != nil {
+			n, err = f.ReadAt(actual, v.byteOffset(start))
+			if err != nil && !errors.Is(err, io.EOF) {
+				return nil, fmt.Errorf("read post data: %w", err)
+			}
+		}
+		for _, r := range v.diff(expected, actual[:n]) {
+			r.Start += fileOffset + start
+			r.End += fileOffset + start
+			if last := len(corrupted) - 1; last >= 0 && corrupted[last].End >= r.Start {
+				corrupted[last].End = r.End
+			} else {
+				corrupted = append(corrupted, r)
+			}
+		}
+		verified(end - start)
+	}
+	return corrupted, nil
+}
+
+// diff returns the ranges of labels that differ between expected and actual.
+// Labels that are missing from actual are different.
+func (v *PostDataVerifier) diff(expected, actual []byte) []atypes.LabelRange {
+	bits := uint64(v.metadata.BitsPerLabel)
+	var ranges []atypes.LabelRange
+	add := func(start, end uint64) {
+		if last := len(ranges) - 1; last >= 0 && ranges[last].End >= start {
+			if end > ranges[last].End {
+				ranges[last].End = end
+			}
+			return
+		}
+		ranges = append(ranges, atypes.LabelRange{Start: start, End: end})
+	}
+	for i := range expected {
+		if i < len(actual) && expected[i] == actual[i] {
+			continue
+		}
+		// byte i holds bits [8i, 8i+8) which belong to labels [8i/bits, (8i+7)/bits]
+		add(uint64(i)*8/bits, (uint64(i)*8+7)/bits+1)
+	}
+	return ranges
+}
+
+// repair regenerates the labels of the range and returns the number of labels that were written.
+func (v *PostDataVerifier) repair(ctx context.Context, r atypes.LabelRange) (uint64, error) {
+	file := int(r.Start / v.fileLabels)
+	fileOffset := uint64(file) * v.fileLabels
+	// align the range to bytes
+	start := (r.Start - fileOffset) / 8 * 8
+	end := (r.End - fileOffset + 7) / 8 * 8
+	if end > v.fileLabels {
+		end = v.fileLabels
+	}
+	f, err := os.OpenFile(v.filename(file), os.O_RDWR|os.O_CREATE, shared.OwnerReadWrite)
+	if err != nil {
+		return 0, fmt.Errorf("open post data: %w", err)
+	}
+	defer f.Close()
+
+	var repaired uint64
+	for pos := start; pos < end; pos += verifyChunkLabels {
+		if err := ctx.Err(); err != nil {
+			return repaired, err
+		}
+		last := pos + verifyChunkLabels
+		if last > end {
+			last = end
+		}
+		labels, err := v.oracle(v.providerID, v.commitment, fileOffset+pos, fileOffset+last-1, uint32(v.metadata.BitsPerLabel))
+		if err != nil {
+			return repaired, fmt.Errorf("compute labels: %w", err)
+		}
+		if _, err := f.WriteAt(labels, v.byteOffset(pos)); err != nil {
+			return repaired, fmt.Errorf("write post data: %w", err)
+		}
+		repaired += last - pos
+	}
+	if err := f.Sync(); err != nil {
+		return repaired, fmt.Errorf("sync post data: %w", err)
+	}
+	v.logger.With().Info("repaired post data",
+		log.Int("file", file),
+		log.Uint64("start", fileOffset+start),
+		log.Uint64("end", fileOffset+end),
+	)
+	return repaired, nil
+}
@@ -0,0 +1,270 @@
+package activation
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spacemeshos/post/initialization"
+	"github.com/spacemeshos/post/shared"
+	"github.com/stretchr/testify/require"
+
+	atypes "github.com/spacemeshos/go-spacemesh/activation/types"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+)
+
+// testLabelOracle derives every byte of the labels from its offset, starts of ranges are always aligned to bytes.
+func testLabelOracle(_ uint, commitment []byte, start, end uint64, bitsPerLabel uint32) ([]byte, error) {
+	offset := start * uint64(bitsPerLabel) / 8
+	labels := make([]byte, (end-start+1)*uint64(bitsPerLabel)/8)
+	for i := range labels {
+		pos := offset + uint64(i)
+		labels[i] = byte(pos*31) ^ byte(pos>>8) ^ commitment[0]
+	}
+	return labels, nil
+}
+
+func writeTestPostData(t *testing.T, metadata *initialization.Metadata) string {
+	dir := t.TempDir()
+	require.NoError(t, initialization.SaveMetadata(dir, metadata))
+	fileLabels := metadata.LabelsPerUnit * uint64(metadata.NumUnits) / uint64(metadata.NumFiles)
+	for file := 0; file < int(metadata.NumFiles); file++ {
+		start := uint64(file) * fileLabels
+		labels, err := testLabelOracle(0, metadata.ID, start, start+fileLabels-1, uint32(metadata.BitsPerLabel))
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, shared.InitFileName(file)), labels, shared.OwnerReadWrite))
+	}
+	return dir
+}
+
+func newTestVerifier(t *testing.T, dir string, commitment []byte) *PostDataVerifier {
+	verifier, err := NewPostDataVerifier(dir, commitment, 0, logtest.New(t))
+	require.NoError(t, err)
+	verifier.oracle = testLabelOracle
+	return verifier
+}
+
+func finalVerifyStatus(t *testing.T, statusChan <-chan *atypes.PostVerifyStatus) *atypes.PostVerifyStatus {
+	var last *atypes.PostVerifyStatus
+	for status := range statusChan {
+		require.False(t, last != nil && last.Done, "status after done")
+		last = status
+	}
+	require.NotNil(t, last)
+	require.True(t, last.Done)
+	return last
+}
+
+func TestPostDataVerifier(t *testing.T) {
+	for _, bits := range []uint8{4, 8} {
+		bits := bits
+		t.Run(fmt.Sprintf("bits %d", bits), func(t *testing.T) {
+			t.Parallel()
+			metadata := &initialization.Metadata{
+				ID:            []byte{1, 2, 3},
+				BitsPerLabel:  bits,
+				LabelsPerUnit: verifyChunkLabels + 4096,
+				NumUnits:      2,
+				NumFiles:      2,
+			}
+			fileLabels := metadata.LabelsPerUnit
+			labelsPerByte := uint64(8 / bits)
+			dir := writeTestPostData(t, metadata)
+			verifier := newTestVerifier(t, dir, metadata.ID)
+
+			status := finalVerifyStatus(t, verifier.Verify(context.Background(), atypes.PostVerifyOpts{Fraction: 1}))
+			require.NoError(t, status.LastError)
+			require.Equal(t, 2*fileLabels, status.LabelsToVerify)
+			require.Equal(t, 2*fileLabels, status.LabelsVerified)
+			require.Zero(t, status.LabelsCorrupted)
+			require.Empty(t, status.Corrupted)
+
+			// corrupt a byte in the first file and truncate the second one
+			first := filepath.Join(dir, shared.InitFileName(0))
+			data, err := os.ReadFile(first)
+			require.NoError(t, err)
+			data[100] ^= 0xff
+			require.NoError(t, os.WriteFile(first, data, shared.OwnerReadWrite))
+			second := filepath.Join(dir, shared.InitFileName(1))
+			require.NoError(t, os.Truncate(second, int64(fileLabels*uint64(bits)/8-10)))
+
+			expected := []atypes.LabelRange{
+				{Start: 100 * labelsPerByte, End: 101 * labelsPerByte},
+				{Start: 2*fileLabels - 10*labelsPerByte, End: 2 * fileLabels},
+			}
+			status = finalVerifyStatus(t, verifier.Verify(context.Background(), atypes.PostVerifyOpts{Fraction: 1}))
+			require.NoError(t, status.LastError)
+			require.Equal(t, expected, status.Corrupted)
+			require.Equal(t, 11*labelsPerByte, status.LabelsCorrupted)
+			require.Zero(t, status.LabelsRepaired)
+
+			status = finalVerifyStatus(t, verifier.Verify(context.Background(), atypes.PostVerifyOpts{Fraction: 1, Repair: true}))
+			require.NoError(t, status.LastError)
+			require.Equal(t, expected, status.Corrupted)
+			require.GreaterOrEqual(t, status.LabelsRepaired, status.LabelsCorrupted)
+
+			status = finalVerifyStatus(t, verifier.Verify(context.Background(), atypes.PostVerifyOpts{Fraction: 1}))
+			require.NoError(t, status.LastError)
+			require.Empty(t, status.Corrupted)
+			info, err := os.Stat(second)
+			require.NoError(t, err)
+			require.EqualValues(t, fileLabels*uint64(bits)/8, info.Size())
+		})
+	}
+}
+
+func TestPostDataVerifier_Sampling(t *testing.T) {
+	metadata := &initialization.Metadata{
+		ID:            []byte{1},
+		BitsPerLabel:  8,
+		LabelsPerUnit: 8 * verifyChunkLabels,
+		NumUnits:      4,
+		NumFiles:      4,
+	}
+	numLabels := metadata.LabelsPerUnit * uint64(metadata.NumUnits)
+	verifier := newTestVerifier(t, writeTestPostData(t, metadata), nil)
+	verifier.seed = func() int64 { return 1 }
+
+	for _, tc := range []struct {
+		fraction float64
+		chunks   uint64
+	}{
+		{fraction: 0.01, chunks: 1},
+		{fraction: 0.1, chunks: 4},
+		{fraction: 0.5, chunks: 16},
+		{fraction: 1, chunks: 32},
+	} {
+		tc := tc
+		t.Run(fmt.Sprint(tc.fraction), func(t *testing.T) {
+			status := finalVerifyStatus(t, verifier.Verify(context.Background(), atypes.PostVerifyOpts{Fraction: tc.fraction}))
+			require.NoError(t, status.LastError)
+			require.Equal(t, tc.chunks*verifyChunkLabels, status.LabelsToVerify)
+			require.Equal(t, status.LabelsToVerify, status.LabelsVerified)
+			require.LessOrEqual(t, status.LabelsToVerify, numLabels)
+			require.Empty(t, status.Corrupted)
+		})
+	}
+
+	status := finalVerifyStatus(t, verifier.Verify(context.Background(), atypes.PostVerifyOpts{Fraction: 0}))
+	require.Error(t, status.LastError)
+}
+
+func TestSampleChunks(t *testing.T) {
+	for _, total := range []uint64{1, 7, 100, 1000} {
+		for _, fraction := range []float64{0.001, 0.01, 0.3, 0.99, 1} {
+			expected := uint64(math.Ceil(fraction * float64(total)))
+			if expected == 0 {
+				expected = 1
+			}
+			selected := sampleChunks(rand.New(rand.NewSource(int64(total))), total, fraction)
+			var count uint64
+			for chunk := uint64(0); chunk < total; chunk++ {
+				if selected(chunk) {
+					count++
+				}
+			}
+			require.Equal(t, expected, count, "total %d fraction %v", total, fraction)
+		}
+	}
+}
+
+func TestPostSetupManager_VerifyDataLocking(t *testing.T) {
+	metadata := &initialization.Metadata{
+		ID:            []byte{1},
+		BitsPerLabel:  8,
+		LabelsPerUnit: 1024,
+		NumUnits:      1,
+		NumFiles:      1,
+	}
+	dir := writeTestPostData(t, metadata)
+	mgr, err := NewPostSetupManager(types.NodeID{1}, DefaultPostConfig(), logtest.New(t), nil, types.ATXID{})
+	require.NoError(t, err)
+	mgr.state = atypes.PostSetupStateComplete
+	mgr.lastOpts = &atypes.PostSetupOpts{DataDir: dir}
+	mgr.commitment = metadata.ID
+
+	drain := func(statusChan <-chan *atypes.PostVerifyStatus) {
+		for range statusChan {
+		}
+	}
+
+	t.Run("repair while data is read", func(t *testing.T) {
+		mgr.dataMu.RLock()
+		_, err := mgr.VerifyData(context.Background(), atypes.PostVerifyOpts{Fraction: 1, Repair: true})
+		require.ErrorIs(t, err, errDataInUse)
+
+		statusChan, err := mgr.VerifyData(context.Background(), atypes.PostVerifyOpts{Fraction: 1})
+		require.NoError(t, err)
+		drain(statusChan)
+		mgr.dataMu.RUnlock()
+	})
+
+	t.Run("verify while data is repaired", func(t *testing.T) {
+		mgr.dataMu.Lock()
+		_, err := mgr.VerifyData(context.Background(), atypes.PostVerifyOpts{Fraction: 1})
+		require.ErrorIs(t, err, errDataInUse)
+		_, err = mgr.VerifyData(context.Background(), atypes.PostVerifyOpts{Fraction: 1, Repair: true})
+		require.ErrorIs(t, err, errDataInUse)
+
+		proved := make(chan struct{})
+		go func() {
+			defer close(proved)
+			mgr.GenerateProof([]byte("challenge"), types.ATXID{})
+		}()
+		select {
+		case <-proved:
+			require.FailNow(t, "proof generated while data is repaired")
+		case <-time.After(100 * time.Millisecond):
+		}
+		mgr.dataMu.Unlock()
+		<-proved
+	})
+
+	t.Run("released after repair", func(t *testing.T) {
+		statusChan, err := mgr.VerifyData(context.Background(), atypes.PostVerifyOpts{Fraction: 1, Repair: true})
+		require.NoError(t, err)
+		drain(statusChan)
+		require.True(t, mgr.dataMu.TryLock())
+		mgr.dataMu.Unlock()
+	})
+}
+
+func TestPostDataVerifier_MissingFile(t *testing.T) {
+	metadata := &initialization.Metadata{
+		ID:            []byte{1},
+		BitsPerLabel:  8,
+		LabelsPerUnit: 1024,
+		NumUnits:      2,
+		NumFiles:      2,
+	}
+	dir := writeTestPostData(t, metadata)
+	require.NoError(t, os.Remove(filepath.Join(dir, shared.InitFileName(0))))
+	verifier := newTestVerifier(t, dir, nil)
+
+	status := finalVerifyStatus(t, verifier.Verify(context.Background(), atypes.PostVerifyOpts{Fraction: 1, Repair: true}))
+	require.NoError(t, status.LastError)
+	require.Equal(t, []atypes.LabelRange{{Start: 0, End: 1024}}, status.Corrupted)
+	require.EqualValues(t, 1024, status.LabelsRepaired)
+
+	status = finalVerifyStatus(t, verifier.Verify(context.Background(), atypes.PostVerifyOpts{Fraction: 1}))
+	require.NoError(t, status.LastError)
+	require.Empty(t, status.Corrupted)
+}
+
+func TestPostDataVerifier_CommitmentMismatch(t *testing.T) {
+	dir := writeTestPostData(t, &initialization.Metadata{
+		ID:            []byte{1},
+		BitsPerLabel:  8,
+		LabelsPerUnit: 1024,
+		NumUnits:      1,
+		NumFiles:      1,
+	})
+	_, err := NewPostDataVerifier(dir, []byte{2}, 0, logtest.New(t))
+	require.ErrorIs(t, err, ErrCommitmentMismatch)
+}
@@ -0,0 +1,234 @@
+package postservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/spacemeshos/go-spacemesh/activation"
+	atypes "github.com/spacemeshos/go-spacemesh/activation/types"
+	pb "github.com/spacemeshos/go-spacemesh/api/nodepb"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+)
+
+var (
+	// ErrNotSupported is returned for Post setup operations that must be performed on the post-service.
+	ErrNotSupported = errors.New("not supported by the remote post service")
+	// ErrNotInitialized is returned when the Post data of the post-service is not initialized.
+	ErrNotInitialized = errors.New("post data of the remote post service is not initialized")
+	// ErrIdentityMismatch is returned when the post-service serves Post data of another identity
+	// or Post data initialized with another commitment atx.
+	ErrIdentityMismatch = errors.New("remote post service serves other post data")
+)
+
+// ClientConfig configures the connection to the post-service.
+type ClientConfig struct {
+	// Address of the post-service. Remote proving is disabled when empty.
+	Address string `mapstructure:"address"`
+	// StatusTimeout bounds status requests. Proof requests are not bounded,
+	// as proving may take a considerable time.
+	StatusTimeout time.Duration `mapstructure:"status-timeout"`
+	TLS           TLSConfig     `mapstructure:"tls"`
+}
+
+// DefaultClientConfig returns the default ClientConfig.
+func DefaultClientConfig() ClientConfig {
+	return ClientConfig{
+		StatusTimeout: 10 * time.Second,
+	}
+}
+
+// Client generates proofs on the post-service. It implements activation.PostSetupProvider,
+// so it can be used instead of activation.PostSetupManager when the Post data is stored on another machine.
+// Post setup is performed on the post-service, data creation sessions are therefore not supported by the client.
+type Client struct {
+	cfg     ClientConfig
+	postCfg atypes.PostConfig
+	nodeID  types.NodeID
+	conn    *grpc.ClientConn
+	client  pb.PostServiceClient
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu       sync.Mutex
+	lastErr  error
+	lastOpts *atypes.PostSetupOpts
+}
+
+var _ activation.PostSetupProvider = (*Client)(nil)
+
+// NewClient creates a client for the post-service that serves the Post data of nodeID.
+// The connection is established lazily, the identity of the Post data is verified
+// when a session is started and before every proof.
+func NewClient(cfg ClientConfig, postCfg atypes.PostConfig, nodeID types.NodeID) (*Client, error) {
+	creds, err := cfg.TLS.ClientCredentials()
+	if err != nil {
+		return nil, fmt.Errorf("client credentials: %w", err)
+	}
+	conn, err := grpc.Dial(cfg.Address, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("dial %s: %w", cfg.Address, err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Client{
+		cfg:     cfg,
+		postCfg: postCfg,
+		nodeID:  nodeID,
+		conn:    conn,
+		client:  pb.NewPostServiceClient(conn),
+		ctx:     ctx,
+		cancel:  cancel,
+	}, nil
+}
+
+// Close closes the connection to the post-service.
+func (c *Client) Close() error {
+	c.cancel()
+	return c.conn.Close()
+}
+
+// Status returns the status of the Post data on the post-service.
+func (c *Client) Status() *atypes.PostSetupStatus {
+	ctx, cancel := context.WithTimeout(c.ctx, c.cfg.StatusTimeout)
+	defer cancel()
+	resp, err := c.client.Status(ctx, &emptypb.Empty{})
+	if err != nil {
+		err = fmt.Errorf("remote status: %w", err)
+		c.mu.Lock()
+		c.lastErr = err
+		opts := c.lastOpts
+		c.mu.Unlock()
+		return &atypes.PostSetupStatus{
+			State:     atypes.PostSetupStateError,
+			LastOpts:  opts,
+			LastError: err,
+		}
+	}
+	status := statusFromPb(resp)
+	c.mu.Lock()
+	c.lastErr = status.LastError
+	if status.LastOpts != nil {
+		c.lastOpts = status.LastOpts
+	}
+	c.mu.Unlock()
+	return status
+}
+
+// StatusChan returns a channel with the current status of the Post data on the post-service.
+func (c *Client) StatusChan() <-chan *atypes.PostSetupStatus {
+	statusChan := make(chan *atypes.PostSetupStatus, 1)
+	statusChan <- c.Status()
+	close(statusChan)
+	return statusChan
+}
+
+// ComputeProviders returns no providers, Post setup is performed on the post-service.
+func (c *Client) ComputeProviders() []atypes.PostSetupComputeProvider {
+	return nil
+}
+
+// Benchmark is not supported by the client.
+func (c *Client) Benchmark(atypes.PostSetupComputeProvider) (int, error) {
+	return 0, ErrNotSupported
+}
+
+// StartSession verifies that the Post data on the post-service belongs to the node, was initialized
+// with commitmentAtx and is complete. The returned channel is closed immediately.
+func (c *Client) StartSession(_ atypes.PostSetupOpts, commitmentAtx types.ATXID) (chan struct{}, error) {
+	if err := c.verify(commitmentAtx); err != nil {
+		c.mu.Lock()
+		c.lastErr = err
+		c.mu.Unlock()
+		return nil, err
+	}
+	status := c.Status()
+	switch status.State {
+	case atypes.PostSetupStateComplete:
+		done := make(chan struct{})
+		close(done)
+		return done, nil
+	case atypes.PostSetupStateError:
+		return nil, status.LastError
+	default:
+		return nil, ErrNotInitialized
+	}
+}
+
+// StopSession does nothing, deleting the Post data is not supported by the client.
+func (c *Client) StopSession(deleteFiles bool) error {
+	if deleteFiles {
+		return ErrNotSupported
+	}
+	return nil
+}
+
+// verify checks that the post-service serves the Post data of the node that was initialized with commitmentAtx.
+func (c *Client) verify(commitmentAtx types.ATXID) error {
+	ctx, cancel := context.WithTimeout(c.ctx, c.cfg.StatusTimeout)
+	defer cancel()
+	info, err := c.client.Info(ctx, &emptypb.Empty{})
+	if err != nil {
+		return fmt.Errorf("remote info: %w", err)
+	}
+	if nodeID := types.BytesToNodeID(info.NodeId); len(info.NodeId) != len(types.NodeID{}) || nodeID != c.nodeID {
+		return fmt.Errorf("%w: node id %x, expected %s", ErrIdentityMismatch, info.NodeId, c.nodeID)
+	}
+	if atx := types.ATXID(types.BytesToHash(info.CommitmentAtx)); len(info.CommitmentAtx) != types.Hash32Length || atx != commitmentAtx {
+		return fmt.Errorf("%w: commitment atx %x, expected %s", ErrIdentityMismatch, info.CommitmentAtx, commitmentAtx)
+	}
+	return nil
+}
+
+// GenerateProof generates a proof on the post-service.
+func (c *Client) GenerateProof(challenge []byte, commitmentAtx types.ATXID) (*types.Post, *types.PostMetadata, error) {
+	if err := c.verify(commitmentAtx); err != nil {
+		return nil, nil, err
+	}
+	resp, err := c.client.GenerateProof(c.ctx, &pb.GenerateProofRequest{
+		Challenge:     challenge,
+		CommitmentAtx: commitmentAtx.Bytes(),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("remote proof: %w", err)
+	}
+	if resp.Proof == nil || resp.Metadata == nil {
+		return nil, nil, errors.New("remote proof: empty response")
+	}
+	post := &types.Post{
+		Nonce:   resp.Proof.Nonce,
+		Indices: resp.Proof.Indices,
+	}
+	metadata := &types.PostMetadata{
+		Challenge:     resp.Metadata.Challenge,
+		BitsPerLabel:  uint8(resp.Metadata.BitsPerLabel),
+		LabelsPerUnit: resp.Metadata.LabelsPerUnit,
+		K1:            resp.Metadata.K1,
+		K2:            resp.Metadata.K2,
+	}
+	return post, metadata, nil
+}
+
+// LastError returns the last error reported by the post-service.
+func (c *Client) LastError() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lastErr
+}
+
+// LastOpts returns the options the Post data on the post-service was initialized with.
+func (c *Client) LastOpts() *atypes.PostSetupOpts {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lastOpts
+}
+
+// Config returns the Post protocol config.
+func (c *Client) Config() atypes.PostConfig {
+	return c.postCfg
+}
@@ -0,0 +1,178 @@
+// Package postservice serves proofs over Post data from a process that runs next to the data,
+// so that the node does not have to be located on the same machine as its Post data.
+package postservice
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/spacemeshos/go-spacemesh/activation"
+	atypes "github.com/spacemeshos/go-spacemesh/activation/types"
+	pb "github.com/spacemeshos/go-spacemesh/api/nodepb"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log"
+)
+
+// TLSConfig configures mutual TLS between the node and the post-service.
+type TLSConfig struct {
+	// Cert and Key are paths to the PEM encoded certificate and key of this side of the connection.
+	Cert string `mapstructure:"cert"`
+	Key  string `mapstructure:"key"`
+	// CA is a path to the PEM encoded certificate authority that signed the certificate of the other side.
+	CA string `mapstructure:"ca"`
+}
+
+func (c TLSConfig) load() (tls.Certificate, *x509.CertPool, error) {
+	cert, err := tls.LoadX509KeyPair(c.Cert, c.Key)
+	if err != nil {
+		return tls.Certificate{}, nil, fmt.Errorf("load key pair: %w", err)
+	}
+	ca, err := os.ReadFile(c.CA)
+	if err != nil {
+		return tls.Certificate{}, nil, fmt.Errorf("read ca: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return tls.Certificate{}, nil, fmt.Errorf("no certificates in %s", c.CA)
+	}
+	return cert, pool, nil
+}
+
+// ServerCredentials returns credentials that require clients to present a certificate signed by the CA.
+func (c TLSConfig) ServerCredentials() (credentials.TransportCredentials, error) {
+	cert, pool, err := c.load()
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}), nil
+}
+
+// ClientCredentials returns credentials that present the certificate to the server and
+// verify the server certificate against the CA.
+func (c TLSConfig) ClientCredentials() (credentials.TransportCredentials, error) {
+	cert, pool, err := c.load()
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		MinVersion:   tls.VersionTLS12,
+	}), nil
+}
+
+// Server answers proof requests using the Post data of the prover.
+type Server struct {
+	prover        activation.PostProver
+	nodeID        types.NodeID
+	commitmentAtx types.ATXID
+	logger        log.Log
+}
+
+// NewServer returns a Server that generates proofs with prover over the Post data
+// that was initialized for nodeID with commitmentAtx.
+func NewServer(prover activation.PostProver, nodeID types.NodeID, commitmentAtx types.ATXID, logger log.Log) *Server {
+	return &Server{prover: prover, nodeID: nodeID, commitmentAtx: commitmentAtx, logger: logger}
+}
+
+// Register registers the service on the grpc server.
+func (s *Server) Register(server *grpc.Server) {
+	pb.RegisterPostServiceServer(server, s)
+}
+
+// Info returns the identity and the commitment atx of the Post data.
+func (s *Server) Info(context.Context, *emptypb.Empty) (*pb.PostInfo, error) {
+	return &pb.PostInfo{
+		NodeId:        s.nodeID.ToBytes(),
+		CommitmentAtx: s.commitmentAtx.Bytes(),
+	}, nil
+}
+
+// Status returns the status of the Post data.
+func (s *Server) Status(context.Context, *emptypb.Empty) (*pb.PostStatus, error) {
+	return statusToPb(s.prover.Status()), nil
+}
+
+// GenerateProof generates a proof for the challenge over the Post data.
+func (s *Server) GenerateProof(ctx context.Context, in *pb.GenerateProofRequest) (*pb.GenerateProofResponse, error) {
+	commitmentAtx := types.ATXID(types.BytesToHash(in.CommitmentAtx))
+	if len(in.CommitmentAtx) != types.Hash32Length || commitmentAtx != s.commitmentAtx {
+		return nil, status.Errorf(codes.InvalidArgument, "commitment atx %x doesn't match the post data", in.CommitmentAtx)
+	}
+	s.logger.WithContext(ctx).With().Info("generating proof",
+		log.Binary("challenge", in.Challenge),
+		commitmentAtx,
+	)
+	post, metadata, err := s.prover.GenerateProof(in.Challenge, commitmentAtx)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "generate proof: %v", err)
+	}
+	return &pb.GenerateProofResponse{
+		Proof: &pb.Proof{
+			Nonce:   post.Nonce,
+			Indices: post.Indices,
+		},
+		Metadata: &pb.ProofMetadata{
+			Challenge:     metadata.Challenge,
+			BitsPerLabel:  uint32(metadata.BitsPerLabel),
+			LabelsPerUnit: metadata.LabelsPerUnit,
+			K1:            metadata.K1,
+			K2:            metadata.K2,
+		},
+	}, nil
+}
+
+func statusToPb(status *atypes.PostSetupStatus) *pb.PostStatus {
+	rst := &pb.PostStatus{
+		State:            pb.PostSetupState(status.State),
+		NumLabelsWritten: status.NumLabelsWritten,
+	}
+	if status.LastError != nil {
+		rst.ErrorMessage = status.LastError.Error()
+	}
+	if status.LastOpts != nil {
+		rst.Opts = &pb.PostSetupOpts{
+			DataDir:           status.LastOpts.DataDir,
+			NumUnits:          status.LastOpts.NumUnits,
+			NumFiles:          status.LastOpts.NumFiles,
+			ComputeProviderId: int32(status.LastOpts.ComputeProviderID),
+			Throttle:          status.LastOpts.Throttle,
+		}
+	}
+	return rst
+}
+
+func statusFromPb(status *pb.PostStatus) *atypes.PostSetupStatus {
+	rst := &atypes.PostSetupStatus{
+		State:            atypes.PostSetupState(status.State),
+		NumLabelsWritten: status.NumLabelsWritten,
+	}
+	if status.ErrorMessage != "" {
+		rst.LastError = errors.New(status.ErrorMessage)
+	}
+	if status.Opts != nil {
+		rst.LastOpts = &atypes.PostSetupOpts{
+			DataDir:           status.Opts.DataDir,
+			NumUnits:          status.Opts.NumUnits,
+			NumFiles:          status.Opts.NumFiles,
+			ComputeProviderID: int(status.Opts.ComputeProviderId),
+			Throttle:          status.Opts.Throttle,
+		}
+	}
+	return rst
+}
@@ -0,0 +1,288 @@
+package postservice
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/spacemeshos/post/initialization"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/spacemeshos/go-spacemesh/activation"
+	"github.com/spacemeshos/go-spacemesh/activation/mocks"
+	"github.com/spacemeshos/go-spacemesh/activation/poettest"
+	atypes "github.com/spacemeshos/go-spacemesh/activation/types"
+	pb "github.com/spacemeshos/go-spacemesh/api/nodepb"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+type certificate struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func writePEM(tb testing.TB, path, typ string, der []byte) {
+	tb.Helper()
+	require.NoError(tb, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0o600))
+}
+
+// issue creates a certificate signed by parent and writes it with its key to dir.
+// The certificate is self-signed if parent is nil.
+func issue(tb testing.TB, dir, name string, parent *certificate, isCA bool) *certificate {
+	tb.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(tb, err)
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(tb, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+	}
+	signer := &certificate{cert: tmpl, key: key}
+	if parent != nil {
+		signer = parent
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer.cert, &key.PublicKey, signer.key)
+	require.NoError(tb, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(tb, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(tb, err)
+	writePEM(tb, filepath.Join(dir, name+".crt"), "CERTIFICATE", der)
+	writePEM(tb, filepath.Join(dir, name+".key"), "EC PRIVATE KEY", keyDer)
+	return &certificate{cert: cert, key: key}
+}
+
+func tlsConfig(dir, name, ca string) TLSConfig {
+	return TLSConfig{
+		Cert: filepath.Join(dir, name+".crt"),
+		Key:  filepath.Join(dir, name+".key"),
+		CA:   filepath.Join(dir, ca+".crt"),
+	}
+}
+
+// serve starts the post service for the Post data of nodeID and returns a client connected to it
+// and the config of the client.
+func serve(tb testing.TB, prover activation.PostProver, nodeID types.NodeID, commitmentAtx types.ATXID, opts ...grpc.ServerOption) (*Client, ClientConfig) {
+	tb.Helper()
+	dir := tb.TempDir()
+	ca := issue(tb, dir, "ca", nil, true)
+	issue(tb, dir, "server", ca, false)
+	issue(tb, dir, "client", ca, false)
+
+	creds, err := tlsConfig(dir, "server", "ca").ServerCredentials()
+	require.NoError(tb, err)
+	server := grpc.NewServer(append(opts, grpc.Creds(creds))...)
+	NewServer(prover, nodeID, commitmentAtx, logtest.New(tb)).Register(server)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(tb, err)
+	go server.Serve(lis)
+	tb.Cleanup(server.Stop)
+
+	cfg := DefaultClientConfig()
+	cfg.Address = lis.Addr().String()
+	cfg.TLS = tlsConfig(dir, "client", "ca")
+	client, err := NewClient(cfg, activation.DefaultPostConfig(), nodeID)
+	require.NoError(tb, err)
+	tb.Cleanup(func() { require.NoError(tb, client.Close()) })
+	clientCfg := cfg
+
+	// a client with a certificate from another authority is rejected
+	other := tb.TempDir()
+	issue(tb, other, "ca", nil, true)
+	issue(tb, other, "client", nil, false)
+	cfg.TLS = TLSConfig{
+		Cert: filepath.Join(other, "client.crt"),
+		Key:  filepath.Join(other, "client.key"),
+		CA:   filepath.Join(dir, "ca.crt"),
+	}
+	untrusted, err := NewClient(cfg, activation.DefaultPostConfig(), nodeID)
+	require.NoError(tb, err)
+	defer untrusted.Close()
+	require.Equal(tb, atypes.PostSetupStateError, untrusted.Status().State)
+
+	return client, clientCfg
+}
+
+func TestClientServer(t *testing.T) {
+	prover := mocks.NewMockPostProver(gomock.NewController(t))
+	nodeID := types.NodeID{1, 2, 3}
+	atx := types.ATXID{1, 2, 3}
+	var (
+		mu          sync.Mutex
+		intercepted []string
+	)
+	client, cfg := serve(t, prover, nodeID, atx, grpc.UnaryInterceptor(
+		func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			mu.Lock()
+			intercepted = append(intercepted, info.FullMethod)
+			mu.Unlock()
+			return handler(ctx, req)
+		}))
+
+	opts := &atypes.PostSetupOpts{DataDir: "/data", NumUnits: 4, NumFiles: 2, ComputeProviderID: 1}
+
+	t.Run("status", func(t *testing.T) {
+		prover.EXPECT().Status().Return(&atypes.PostSetupStatus{
+			State:            atypes.PostSetupStateComplete,
+			NumLabelsWritten: 100,
+			LastOpts:         opts,
+		})
+		status := client.Status()
+		require.Equal(t, atypes.PostSetupStateComplete, status.State)
+		require.EqualValues(t, 100, status.NumLabelsWritten)
+		require.Equal(t, opts, status.LastOpts)
+		require.Equal(t, opts, client.LastOpts())
+		require.NoError(t, client.LastError())
+	})
+
+	t.Run("start session", func(t *testing.T) {
+		prover.EXPECT().Status().Return(&atypes.PostSetupStatus{State: atypes.PostSetupStateComplete, LastOpts: opts})
+		done, err := client.StartSession(*opts, atx)
+		require.NoError(t, err)
+		require.NotNil(t, done)
+		<-done
+
+		prover.EXPECT().Status().Return(&atypes.PostSetupStatus{State: atypes.PostSetupStateInProgress, LastOpts: opts})
+		_, err = client.StartSession(*opts, atx)
+		require.ErrorIs(t, err, ErrNotInitialized)
+
+		require.ErrorIs(t, client.StopSession(true), ErrNotSupported)
+		require.NoError(t, client.StopSession(false))
+	})
+
+	t.Run("generate proof", func(t *testing.T) {
+		challenge := []byte("challenge")
+		post := &types.Post{Nonce: 7, Indices: []byte{1, 2, 3}}
+		meta := &types.PostMetadata{Challenge: challenge, BitsPerLabel: 8, LabelsPerUnit: 1024, K1: 10, K2: 20}
+		prover.EXPECT().GenerateProof(challenge, atx).Return(post, meta, nil)
+
+		gotPost, gotMeta, err := client.GenerateProof(challenge, atx)
+		require.NoError(t, err)
+		require.Equal(t, post, gotPost)
+		require.Equal(t, meta, gotMeta)
+	})
+
+	t.Run("proof failure", func(t *testing.T) {
+		prover.EXPECT().GenerateProof(gomock.Any(), gomock.Any()).Return(nil, nil, activation.ErrPoetProofNotReceived)
+		_, _, err := client.GenerateProof([]byte("challenge"), atx)
+		require.Error(t, err)
+	})
+
+	t.Run("other commitment atx", func(t *testing.T) {
+		_, err := client.StartSession(*opts, types.ATXID{4, 5, 6})
+		require.ErrorIs(t, err, ErrIdentityMismatch)
+		require.ErrorIs(t, client.LastError(), ErrIdentityMismatch)
+		_, _, err = client.GenerateProof([]byte("challenge"), types.ATXID{4, 5, 6})
+		require.ErrorIs(t, err, ErrIdentityMismatch)
+	})
+
+	t.Run("other identity", func(t *testing.T) {
+		other, err := NewClient(cfg, activation.DefaultPostConfig(), types.NodeID{4, 5, 6})
+		require.NoError(t, err)
+		defer other.Close()
+		_, err = other.StartSession(*opts, atx)
+		require.ErrorIs(t, err, ErrIdentityMismatch)
+		_, _, err = other.GenerateProof([]byte("challenge"), atx)
+		require.ErrorIs(t, err, ErrIdentityMismatch)
+	})
+
+	t.Run("interceptor", func(t *testing.T) {
+		mu.Lock()
+		defer mu.Unlock()
+		require.Contains(t, intercepted, "/spacemesh.node.v1.PostService/Info")
+		require.Contains(t, intercepted, "/spacemesh.node.v1.PostService/Status")
+		require.Contains(t, intercepted, "/spacemesh.node.v1.PostService/GenerateProof")
+	})
+}
+
+func TestServerRejectsOtherCommitment(t *testing.T) {
+	prover := mocks.NewMockPostProver(gomock.NewController(t))
+	server := NewServer(prover, types.NodeID{1}, types.ATXID{1}, logtest.New(t))
+	_, err := server.GenerateProof(context.Background(), &pb.GenerateProofRequest{
+		Challenge:     []byte("challenge"),
+		CommitmentAtx: types.ATXID{2}.Bytes(),
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+type poetDb struct {
+	challenge types.Hash32
+}
+
+func (p *poetDb) GetProofRef([]byte, string) (types.PoetProofRef, error) {
+	return []byte("ref"), nil
+}
+
+func (p *poetDb) GetMembershipMap(types.PoetProofRef) (map[types.Hash32]bool, error) {
+	return map[types.Hash32]bool{p.challenge: true}, nil
+}
+
+func (p *poetDb) GetProof(types.PoetProofRef) (*types.PoetProof, error) {
+	return &types.PoetProof{Members: [][]byte{p.challenge[:]}}, nil
+}
+
+func TestNIPostLoopback(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	nodeID := types.NodeID{1, 2, 3}
+	commitmentAtx := types.ATXID{4, 5, 6}
+	postCfg := activation.DefaultPostConfig()
+	opts := activation.DefaultPostSetupOpts()
+	opts.DataDir = t.TempDir()
+	opts.NumUnits = postCfg.MinNumUnits
+	opts.ComputeProviderID = initialization.CPUProviderID()
+
+	mgr, err := activation.NewPostSetupManager(nodeID, postCfg, logtest.New(t), nil, commitmentAtx)
+	require.NoError(t, err)
+	done, err := mgr.StartSession(opts, commitmentAtx)
+	require.NoError(t, err)
+	<-done
+	require.NoError(t, mgr.LastError())
+
+	client, _ := serve(t, mgr, nodeID, commitmentAtx)
+	require.Equal(t, mgr.Status().State, client.Status().State)
+	require.Equal(t, mgr.Status().LastOpts, client.Status().LastOpts)
+
+	poet, err := poettest.NewServer([]byte("poet"))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, poet.Close()) })
+
+	challenge := types.BytesToHash([]byte("challenge"))
+	db := &poetDb{challenge: challenge}
+	nb := activation.NewNIPostBuilder(nodeID, client,
+		[]activation.PoetProvingServiceClient{activation.NewPoetClient(poet.GRPCAddress())},
+		db, sql.InMemory(), logtest.New(t))
+	nipost, _, err := nb.BuildNIPost(context.Background(), &challenge, commitmentAtx, time.Time{}, time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{challenge[:]}, poet.Challenges())
+
+	v := activation.NewValidator(db, postCfg)
+	require.NoError(t, v.ValidatePost(activation.GetCommitmentBytes(nodeID, commitmentAtx), nipost.Post, nipost.PostMetadata, opts.NumUnits))
+}
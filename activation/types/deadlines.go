@@ -0,0 +1,64 @@
+package types
+
+import "time"
+
+// AtxPhase is a phase of the ATX construction.
+type AtxPhase uint8
+
+const (
+	// AtxPhaseChallengeBuilt is completed when the NIPost challenge is ready.
+	AtxPhaseChallengeBuilt AtxPhase = iota + 1
+	// AtxPhasePoetSubmitted is completed when the challenge is registered with a PoET service.
+	AtxPhasePoetSubmitted
+	// AtxPhasePoetProofReceived is completed when a PoET proof that includes the challenge is received.
+	AtxPhasePoetProofReceived
+	// AtxPhasePostProofGenerated is completed when the Post proof for the PoET proof is generated.
+	AtxPhasePostProofGenerated
+	// AtxPhasePublished is completed when the ATX is published.
+	AtxPhasePublished
+)
+
+// AtxPhases lists the phases of the ATX construction in order.
+var AtxPhases = []AtxPhase{
+	AtxPhaseChallengeBuilt,
+	AtxPhasePoetSubmitted,
+	AtxPhasePoetProofReceived,
+	AtxPhasePostProofGenerated,
+	AtxPhasePublished,
+}
+
+func (p AtxPhase) String() string {
+	switch p {
+	case AtxPhaseChallengeBuilt:
+		return "challenge_built"
+	case AtxPhasePoetSubmitted:
+		return "poet_submitted"
+	case AtxPhasePoetProofReceived:
+		return "poet_proof_received"
+	case AtxPhasePostProofGenerated:
+		return "post_proof_generated"
+	case AtxPhasePublished:
+		return "atx_published"
+	default:
+		return "unknown"
+	}
+}
+
+// AtxPhaseStatus is the status of a phase of the ATX construction.
+type AtxPhaseStatus struct {
+	Phase    AtxPhase
+	Deadline time.Time
+	// Completed is zero if the phase is not completed.
+	Completed time.Time
+	// Slack is the time left until the deadline, or the time that was left when the phase was completed.
+	// It is negative if the deadline was missed.
+	Slack time.Duration
+}
+
+// AtxDeadlineStatus is a snapshot of the phases of the ATX that is being constructed.
+type AtxDeadlineStatus struct {
+	PublishEpoch uint32
+	Phases       []AtxPhaseStatus
+	// AtRisk is set if a pending phase is close to its deadline, or missed it.
+	AtRisk bool
+}
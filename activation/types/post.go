@@ -41,3 +41,29 @@ const (
 	PostSetupStateComplete
 	PostSetupStateError
 )
+
+// PostVerifyOpts are the options of a Post data verification.
+type PostVerifyOpts struct {
+	// Fraction of the labels that are verified, in (0, 1]. Labels are sampled in chunks,
+	// all labels are verified if the fraction is 1.
+	Fraction float64
+	// Repair regenerates the labels that were found to be corrupted.
+	Repair bool
+}
+
+// LabelRange is a range of labels of the Post data, End is exclusive.
+type LabelRange struct {
+	Start uint64
+	End   uint64
+}
+
+// PostVerifyStatus represents a status snapshot of a Post data verification.
+type PostVerifyStatus struct {
+	LabelsToVerify  uint64
+	LabelsVerified  uint64
+	LabelsCorrupted uint64
+	LabelsRepaired  uint64
+	Corrupted       []LabelRange
+	Done            bool
+	LastError       error
+}
@@ -58,7 +58,7 @@ func (v *Validator) Validate(commitment []byte, nipost *types.NIPost, expectedCh
 
 	proof, err := v.poetDb.GetProof(nipost.PostMetadata.Challenge)
 	if err != nil {
-		return 0, fmt.Errorf("poet proof is not available %x: %w", nipost.PostMetadata.Challenge, err)
+		return 0, fmt.Errorf("%w: poet proof is not available %x: %v", errLookup, nipost.PostMetadata.Challenge, err)
 	}
 	if !isIncluded(proof, nipost.Challenge.Bytes()) {
 		return 0, fmt.Errorf("challenge is not included in the proof %x", nipost.PostMetadata.Challenge)
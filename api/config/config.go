@@ -18,6 +18,7 @@ const (
 	defaultStartNodeService        = false
 	defaultStartSmesherService     = false
 	defaultStartTransactionService = false
+	defaultStartBeaconService      = false
 )
 
 // Config defines the api config params.
@@ -27,6 +28,8 @@ type Config struct {
 	GrpcServerInterface string   `mapstructure:"grpc-interface"`
 	StartJSONServer     bool     `mapstructure:"json-server"`
 	JSONServerPort      int      `mapstructure:"json-port"`
+	// AdminToken authenticates admin calls. Admin calls are disabled if it is empty.
+	AdminToken string `mapstructure:"grpc-admin-token"`
 	// no direct command line flags for these
 	StartDebugService       bool
 	StartGatewayService     bool
@@ -35,6 +38,7 @@ type Config struct {
 	StartNodeService        bool
 	StartSmesherService     bool
 	StartTransactionService bool
+	StartBeaconService      bool
 }
 
 func init() {
@@ -57,6 +61,7 @@ func DefaultConfig() Config {
 		StartNodeService:        defaultStartNodeService,
 		StartSmesherService:     defaultStartSmesherService,
 		StartTransactionService: defaultStartTransactionService,
+		StartBeaconService:      defaultStartBeaconService,
 	}
 }
 
@@ -88,6 +93,8 @@ func (s *Config) ParseServicesList() error {
 			s.StartSmesherService = true
 		case "transaction":
 			s.StartTransactionService = true
+		case "beacon":
+			s.StartBeaconService = true
 		default:
 			return fmt.Errorf("unrecognized GRPC service requested: %s", svc)
 		}
@@ -103,6 +110,7 @@ func (s *Config) ParseServicesList() error {
 		!s.StartNodeService &&
 		!s.StartSmesherService &&
 		!s.StartTransactionService &&
+		!s.StartBeaconService &&
 		// 'true' keeps the above clean
 		true {
 		return errors.New("must enable at least one GRPC service along with JSON gateway service")
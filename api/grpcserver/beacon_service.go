@@ -0,0 +1,164 @@
+package grpcserver
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/spacemeshos/go-spacemesh/api"
+	"github.com/spacemeshos/go-spacemesh/api/nodepb"
+	"github.com/spacemeshos/go-spacemesh/beacon"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/events"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+// BeaconService exposes progress of the beacon protocol and allows to set beacons manually.
+type BeaconService struct {
+	beacon api.BeaconAPI
+	// adminToken authenticates SetBeacon calls. SetBeacon is disabled if empty.
+	adminToken string
+}
+
+// RegisterService registers this service with a grpc server instance.
+func (s BeaconService) RegisterService(server *Server) {
+	nodepb.RegisterBeaconServiceServer(server.GrpcServer, s)
+}
+
+// NewBeaconService creates a new grpc service using config data.
+func NewBeaconService(protocol api.BeaconAPI, adminToken string) *BeaconService {
+	return &BeaconService{
+		beacon:     protocol,
+		adminToken: adminToken,
+	}
+}
+
+// BeaconStream streams progress of the beacon protocol: number of proposals, votes margins and weak coins
+// for every round, and beacons that were calculated, adopted from ballots or set manually.
+func (s BeaconService) BeaconStream(_ *emptypb.Empty, stream nodepb.BeaconService_BeaconStreamServer) error {
+	sub := events.SubscribeBeacon()
+	if sub == nil {
+		return status.Errorf(codes.FailedPrecondition, "event reporting is not enabled")
+	}
+	eventch, fullch := consumeEvents(stream.Context(), sub)
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return status.Errorf(codes.Unavailable, "can't send header")
+	}
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-fullch:
+			return status.Errorf(codes.Canceled, "buffer is full")
+		case ev := <-eventch:
+			bev := ev.(events.EventBeacon)
+			if err := stream.Send(castBeaconEvent(&bev)); err != nil {
+				return fmt.Errorf("send to stream: %w", err)
+			}
+		}
+	}
+}
+
+func castBeaconEvent(ev *events.EventBeacon) *nodepb.BeaconEvent {
+	rst := &nodepb.BeaconEvent{
+		Epoch: uint32(ev.Epoch),
+		Stage: nodepb.BeaconStage(ev.Stage),
+	}
+	switch ev.Stage {
+	case events.BeaconProposals:
+		rst.ValidProposals = uint32(ev.ValidProposals)
+		rst.PotentiallyValidProposals = uint32(ev.PotentiallyValidProposals)
+	case events.BeaconRound:
+		rst.Round = uint32(ev.Round)
+		if ev.WeakCoin != nil {
+			rst.WeakCoinUsed = true
+			rst.WeakCoin = *ev.WeakCoin
+		}
+		for _, margin := range ev.Margins {
+			rst.Margins = append(rst.Margins, &nodepb.ProposalMargin{
+				Proposal: margin.Proposal,
+				Margin:   margin.Margin.String(),
+			})
+		}
+	case events.BeaconFromBallots:
+		rst.Beacon = ev.Beacon.Bytes()
+		rst.Weight = ev.Weight
+	default:
+		rst.Beacon = ev.Beacon.Bytes()
+	}
+	return rst
+}
+
+// BallotBeacons returns the beacon for the epoch and beacons reported by ballots with their weights.
+func (s BeaconService) BallotBeacons(_ context.Context, in *nodepb.BallotBeaconsRequest) (*nodepb.BallotBeaconsResponse, error) {
+	epoch := types.EpochID(in.GetEpoch())
+	rst := &nodepb.BallotBeaconsResponse{Epoch: uint32(epoch)}
+	if current, err := s.beacon.GetBeacon(epoch); err == nil {
+		rst.Beacon = current.Bytes()
+		manual, err := s.beacon.IsManualBeacon(epoch)
+		if err != nil && !errors.Is(err, sql.ErrNotFound) {
+			return nil, status.Errorf(codes.Internal, "get beacon: %v", err)
+		}
+		rst.Manual = manual
+	}
+	for _, bb := range s.beacon.BallotBeacons(epoch) {
+		rst.Ballots = append(rst.Ballots, &nodepb.BallotBeacon{
+			Beacon:  bb.Beacon.Bytes(),
+			Weight:  bb.Weight,
+			Ballots: uint32(bb.Ballots),
+		})
+	}
+	return rst, nil
+}
+
+// SetBeacon sets the beacon for the future epoch.
+// Call must be authenticated with the admin token in the "authorization" metadata, as "Bearer <token>".
+func (s BeaconService) SetBeacon(ctx context.Context, in *nodepb.SetBeaconRequest) (*emptypb.Empty, error) {
+	if err := s.authenticate(ctx); err != nil {
+		return nil, err
+	}
+	if in.GetEpoch() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "epoch is required")
+	}
+	if len(in.GetBeacon()) != types.BeaconSize {
+		return nil, status.Errorf(codes.InvalidArgument, "beacon should be %d bytes", types.BeaconSize)
+	}
+	epoch := types.EpochID(in.GetEpoch())
+	value := types.BytesToBeacon(in.GetBeacon())
+	if err := s.beacon.SetManualBeacon(epoch, value); err != nil {
+		if errors.Is(err, beacon.ErrNotFutureEpoch) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "set beacon: %v", err)
+	}
+	log.With().Warning("GRPC BeaconService.SetBeacon", epoch, value)
+	return &emptypb.Empty{}, nil
+}
+
+func (s BeaconService) authenticate(ctx context.Context) error {
+	return authenticateAdmin(ctx, s.adminToken)
+}
+
+// authenticateAdmin checks that the call is authenticated with the admin token in the "authorization" metadata.
+// Admin calls are disabled if the token is empty.
+func authenticateAdmin(ctx context.Context, adminToken string) error {
+	if adminToken == "" {
+		return status.Error(codes.PermissionDenied, "admin calls are disabled")
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("authorization") {
+		token := strings.TrimPrefix(value, "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) == 1 {
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "invalid admin token")
+}
@@ -2,7 +2,9 @@ package grpcserver
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	pb "github.com/spacemeshos/api/release/go/spacemesh/v1"
@@ -12,26 +14,41 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/spacemeshos/go-spacemesh/api"
+	"github.com/spacemeshos/go-spacemesh/api/nodepb"
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/events"
 	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/p2p"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/identities"
+	"github.com/spacemeshos/go-spacemesh/tortoise"
 )
 
 // DebugService exposes global state data, output from the STF.
 type DebugService struct {
+	db       *sql.Database
 	conState api.ConservativeState
 	identity api.NetworkIdentity
+	tortoise api.TortoiseAPI
+	// adminToken authenticates calls that change the peer access lists. They are disabled if empty.
+	adminToken string
 }
 
 // RegisterService registers this service with a grpc server instance.
 func (d DebugService) RegisterService(server *Server) {
 	pb.RegisterDebugServiceServer(server.GrpcServer, d)
+	nodepb.RegisterDebugServiceServer(server.GrpcServer, d)
 }
 
 // NewDebugService creates a new grpc service using config data.
-func NewDebugService(conState api.ConservativeState, host api.NetworkIdentity) *DebugService {
+func NewDebugService(db *sql.Database, conState api.ConservativeState, host api.NetworkIdentity, trtl api.TortoiseAPI, adminToken string) *DebugService {
 	return &DebugService{
-		conState: conState,
-		identity: host,
+		db:         db,
+		conState:   conState,
+		identity:   host,
+		tortoise:   trtl,
+		adminToken: adminToken,
 	}
 }
 
@@ -121,3 +138,262 @@ func castEventProposal(ev *events.EventProposal) *pb.Proposal {
 	}
 	return proposal
 }
+
+// MalfeasanceStream streams proofs of malfeasance. Proofs that are already stored are sent first,
+// followed by the proofs that are received while the stream is open. A proof that is received
+// while stored proofs are being sent may be streamed twice.
+func (d DebugService) MalfeasanceStream(_ *emptypb.Empty, stream nodepb.DebugService_MalfeasanceStreamServer) error {
+	sub := events.SubscribeMalfeasance()
+	if sub == nil {
+		return status.Errorf(codes.FailedPrecondition, "event reporting is not enabled")
+	}
+	eventch, fullch := consumeEvents(stream.Context(), sub)
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return status.Errorf(codes.Unavailable, "can't send header")
+	}
+	var serr error
+	if err := identities.IterateProofs(d.db, time.Time{}, func(pubkey, encoded []byte, received time.Time) bool {
+		var proof types.MalfeasanceProof
+		if err := codec.Decode(encoded, &proof); err != nil {
+			serr = status.Errorf(codes.Internal, "decode proof for 0x%x: %v", pubkey, err)
+			return false
+		}
+		rst := castMalfeasanceProof(types.BytesToNodeID(pubkey), &proof, encoded)
+		rst.Received = received.Unix()
+		if err := stream.Send(rst); err != nil {
+			serr = fmt.Errorf("send to stream: %w", err)
+			return false
+		}
+		return true
+	}); err != nil {
+		return status.Errorf(codes.Internal, "iterate proofs: %v", err)
+	}
+	if serr != nil {
+		return serr
+	}
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-fullch:
+			return status.Errorf(codes.Canceled, "buffer is full")
+		case ev := <-eventch:
+			mev := ev.(events.EventMalfeasance)
+			encoded, err := codec.Encode(mev.Proof)
+			if err != nil {
+				return status.Errorf(codes.Internal, "encode proof: %v", err)
+			}
+			if err := stream.Send(castMalfeasanceProof(mev.Smesher, mev.Proof, encoded)); err != nil {
+				return fmt.Errorf("send to stream: %w", err)
+			}
+		}
+	}
+}
+
+func castMalfeasanceProof(smesher types.NodeID, proof *types.MalfeasanceProof, encoded []byte) *nodepb.MalfeasanceProof {
+	return &nodepb.MalfeasanceProof{
+		Smesher: smesher.ToBytes(),
+		Type:    proof.MalfeasanceType().String(),
+		Proof:   encoded,
+	}
+}
+
+// ExplainLayer returns inputs that were used by tortoise to decide validity of the layer.
+func (d DebugService) ExplainLayer(_ context.Context, in *nodepb.ExplainLayerRequest) (*nodepb.LayerExplanation, error) {
+	explanation, err := d.tortoise.ExplainLayer(types.NewLayerID(in.GetLayer()))
+	if errors.Is(err, tortoise.ErrNotInWindow) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return castLayerExplanation(explanation), nil
+}
+
+// ExplainBlock returns votes for the block, grouped by ballots and atxs.
+func (d DebugService) ExplainBlock(_ context.Context, in *nodepb.ExplainBlockRequest) (*nodepb.BlockExplanation, error) {
+	var id types.BlockID
+	if len(in.GetId()) != len(id) {
+		return nil, status.Errorf(codes.InvalidArgument, "block id should be %d bytes", len(id))
+	}
+	copy(id[:], in.GetId())
+	explanation, err := d.tortoise.ExplainBlock(id)
+	if errors.Is(err, tortoise.ErrNotInWindow) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return castBlockExplanation(explanation), nil
+}
+
+// PeerAccessProvider is implemented by network identities that gate connections with access lists.
+type PeerAccessProvider interface {
+	AllowlistOnly() bool
+	AccessEntries(p2p.AccessList) ([]string, error)
+	AddAccessEntry(p2p.AccessList, string) error
+	RemoveAccessEntry(p2p.AccessList, string) error
+}
+
+func (d DebugService) peerAccess() (PeerAccessProvider, error) {
+	provider, ok := d.identity.(PeerAccessProvider)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "peer access lists are not supported")
+	}
+	return provider, nil
+}
+
+// PeerAccessLists returns the allowlist and denylist of peer IDs and CIDR ranges.
+func (d DebugService) PeerAccessLists(context.Context, *emptypb.Empty) (*nodepb.PeerAccessListsResponse, error) {
+	provider, err := d.peerAccess()
+	if err != nil {
+		return nil, err
+	}
+	allowlist, err := provider.AccessEntries(p2p.Allowlist)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	denylist, err := provider.AccessEntries(p2p.Denylist)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return &nodepb.PeerAccessListsResponse{
+		AllowlistOnly: provider.AllowlistOnly(),
+		Allowlist:     allowlist,
+		Denylist:      denylist,
+	}, nil
+}
+
+// AddPeerAccessEntry adds the entry to the access list.
+// Connections that are not allowed after the change are closed.
+// Call must be authenticated with the admin token.
+func (d DebugService) AddPeerAccessEntry(ctx context.Context, in *nodepb.PeerAccessEntryRequest) (*emptypb.Empty, error) {
+	return d.updatePeerAccess(ctx, in, PeerAccessProvider.AddAccessEntry)
+}
+
+// RemovePeerAccessEntry removes the entry from the access list.
+// Call must be authenticated with the admin token.
+func (d DebugService) RemovePeerAccessEntry(ctx context.Context, in *nodepb.PeerAccessEntryRequest) (*emptypb.Empty, error) {
+	return d.updatePeerAccess(ctx, in, PeerAccessProvider.RemoveAccessEntry)
+}
+
+func (d DebugService) updatePeerAccess(
+	ctx context.Context,
+	in *nodepb.PeerAccessEntryRequest,
+	update func(PeerAccessProvider, p2p.AccessList, string) error,
+) (*emptypb.Empty, error) {
+	if err := authenticateAdmin(ctx, d.adminToken); err != nil {
+		return nil, err
+	}
+	provider, err := d.peerAccess()
+	if err != nil {
+		return nil, err
+	}
+	var list p2p.AccessList
+	switch in.GetList() {
+	case nodepb.AccessList_ACCESS_LIST_ALLOW:
+		list = p2p.Allowlist
+	case nodepb.AccessList_ACCESS_LIST_DENY:
+		list = p2p.Denylist
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown access list %v", in.GetList())
+	}
+	if len(in.GetEntry()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "entry is required")
+	}
+	err = update(provider, list, in.GetEntry())
+	switch {
+	case errors.Is(err, p2p.ErrInvalidAccessEntry):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, p2p.ErrAccessEntryNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, p2p.ErrAccessListsDisabled):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case err != nil:
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func castLayerExplanation(explanation *tortoise.LayerExplanation) *nodepb.LayerExplanation {
+	rst := &nodepb.LayerExplanation{
+		Layer:           explanation.Layer.Uint32(),
+		Verified:        explanation.Verified,
+		HareTerminated:  explanation.HareTerminated,
+		Opinion:         explanation.Opinion.Bytes(),
+		Mode:            explanation.Mode,
+		Last:            explanation.Last.Uint32(),
+		Processed:       explanation.Processed.Uint32(),
+		LatestVerified:  explanation.Latest.Uint32(),
+		GlobalThreshold: explanation.GlobalThreshold,
+		LocalThreshold:  explanation.LocalThreshold,
+		ExpectedWeight:  explanation.ExpectedWeight,
+	}
+	if decision := explanation.Decision; decision != nil {
+		rst.Decision = &nodepb.LayerDecision{
+			Mode:            decision.Mode,
+			Verified:        decision.Verified,
+			Reason:          decision.Reason,
+			GlobalThreshold: decision.GlobalThreshold,
+			Margin:          decision.Margin,
+			Uncounted:       decision.Uncounted,
+			TotalGoodWeight: decision.TotalGoodWeight,
+			GoodUncounted:   decision.GoodUncounted,
+		}
+		for _, block := range decision.Blocks {
+			rst.Decision.Blocks = append(rst.Decision.Blocks, &nodepb.BlockDecision{
+				Id:       block.Block[:],
+				Decision: block.Decision,
+			})
+		}
+	}
+	for i := range explanation.Blocks {
+		rst.Blocks = append(rst.Blocks, castBlockExplanation(&explanation.Blocks[i]))
+	}
+	for _, switched := range explanation.ModeSwitches {
+		rst.ModeSwitches = append(rst.ModeSwitches, &nodepb.ModeSwitch{
+			Target:    switched.Target.Uint32(),
+			Processed: switched.Processed.Uint32(),
+			Verified:  switched.Verified.Uint32(),
+			Mode:      switched.Mode,
+		})
+	}
+	return rst
+}
+
+func castBlockExplanation(explanation *tortoise.BlockExplanation) *nodepb.BlockExplanation {
+	rst := &nodepb.BlockExplanation{
+		Id:              explanation.ID[:],
+		Layer:           explanation.Layer.Uint32(),
+		Height:          explanation.Height,
+		Hare:            explanation.Hare,
+		Validity:        explanation.Validity,
+		Persisted:       explanation.Persisted,
+		LocalVote:       explanation.LocalVote,
+		LocalVoteReason: explanation.LocalVoteReason,
+		Margin:          explanation.Margin,
+		Support:         explanation.Support,
+		Against:         explanation.Against,
+		Abstain:         explanation.Abstain,
+	}
+	for _, ballot := range explanation.Ballots {
+		rst.Ballots = append(rst.Ballots, &nodepb.BallotVote{
+			Id:      ballot.Ballot[:],
+			Atx:     ballot.ATX.Bytes(),
+			Layer:   ballot.Layer.Uint32(),
+			Weight:  ballot.Weight,
+			Vote:    ballot.Vote,
+			Good:    ballot.Good,
+			Counted: ballot.Counted,
+		})
+	}
+	for _, atx := range explanation.Atxs {
+		rst.Atxs = append(rst.Atxs, &nodepb.AtxVote{
+			Id:      atx.ATX.Bytes(),
+			Support: atx.Support,
+			Against: atx.Against,
+			Abstain: atx.Abstain,
+		})
+	}
+	return rst
+}
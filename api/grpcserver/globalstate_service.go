@@ -143,6 +143,7 @@ func (s GlobalStateService) AccountDataQuery(_ context.Context, in *pb.AccountDa
 					// See https://github.com/spacemeshos/go-spacemesh/issues/2275
 					// LayerComputed: 0,
 					Coinbase: &pb.AccountId{Address: addr.String()},
+					Smesher:  &pb.SmesherId{Id: r.SmesherID.ToBytes()},
 				},
 			}})
 		}
@@ -315,9 +316,72 @@ func (s GlobalStateService) AccountDataStream(in *pb.AccountDataStreamRequest, s
 }
 
 // SmesherRewardStream exposes a stream of smesher rewards.
+// Rewards that were already recorded for the smesher are sent first,
+// followed by the rewards as they are applied to the state.
 func (s GlobalStateService) SmesherRewardStream(in *pb.SmesherRewardStreamRequest, stream pb.GlobalStateService_SmesherRewardStreamServer) error {
-	log.Info("DEPRECATED GRPC GlobalStateService.SmesherRewardStream")
-	return status.Errorf(codes.Unimplemented, "DEPRECATED")
+	log.Info("GRPC GlobalStateService.SmesherRewardStream")
+
+	if in.Id == nil {
+		return status.Errorf(codes.InvalidArgument, "`Id` must be provided")
+	}
+	var smesherID types.NodeID
+	if len(in.Id.Id) != len(smesherID) {
+		return status.Errorf(codes.InvalidArgument, "`Id` must be %d bytes", len(smesherID))
+	}
+	copy(smesherID[:], in.Id.Id)
+
+	// subscribe before reading the database so that rewards applied in between are not lost.
+	// such rewards may be sent twice.
+	var (
+		rewardsCh      <-chan interface{}
+		rewardsBufFull <-chan struct{}
+	)
+	if rewardsSubscription := events.SubscribeRewards(); rewardsSubscription != nil {
+		rewardsCh, rewardsBufFull = consumeEvents(stream.Context(), rewardsSubscription)
+	}
+
+	dbRewards, err := s.mesh.GetRewardsBySmesher(smesherID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "error getting rewards data")
+	}
+	for _, r := range dbRewards {
+		resp := &pb.SmesherRewardStreamResponse{Reward: &pb.Reward{
+			Layer:       &pb.LayerNumber{Number: r.Layer.Uint32()},
+			Total:       &pb.Amount{Value: r.TotalReward},
+			LayerReward: &pb.Amount{Value: r.LayerReward},
+			Coinbase:    &pb.AccountId{Address: r.Coinbase.String()},
+			Smesher:     &pb.SmesherId{Id: smesherID.ToBytes()},
+		}}
+		if err := stream.Send(resp); err != nil {
+			return fmt.Errorf("send to stream: %w", err)
+		}
+	}
+
+	for {
+		select {
+		case <-rewardsBufFull:
+			log.Info("rewards buffer is full, shutting down")
+			return status.Error(codes.Canceled, errRewardsBufferFull)
+		case rewardEvent := <-rewardsCh:
+			reward := rewardEvent.(events.Reward)
+			if reward.Smesher != smesherID {
+				continue
+			}
+			resp := &pb.SmesherRewardStreamResponse{Reward: &pb.Reward{
+				Layer:       &pb.LayerNumber{Number: reward.Layer.Uint32()},
+				Total:       &pb.Amount{Value: reward.Total},
+				LayerReward: &pb.Amount{Value: reward.LayerReward},
+				Coinbase:    &pb.AccountId{Address: reward.Coinbase.String()},
+				Smesher:     &pb.SmesherId{Id: smesherID.ToBytes()},
+			}}
+			if err := stream.Send(resp); err != nil {
+				return fmt.Errorf("send to stream: %w", err)
+			}
+		case <-stream.Context().Done():
+			log.Info("SmesherRewardStream closing stream, client disconnected")
+			return nil
+		}
+	}
 }
 
 // AppEventStream exposes a stream of emitted app events.
@@ -30,12 +30,15 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	"github.com/spacemeshos/go-spacemesh/activation"
 	atypes "github.com/spacemeshos/go-spacemesh/activation/types"
 	"github.com/spacemeshos/go-spacemesh/api/config"
 	"github.com/spacemeshos/go-spacemesh/api/mocks"
+	"github.com/spacemeshos/go-spacemesh/api/nodepb"
+	"github.com/spacemeshos/go-spacemesh/beacon"
 	"github.com/spacemeshos/go-spacemesh/cmd"
 	"github.com/spacemeshos/go-spacemesh/codec"
 	"github.com/spacemeshos/go-spacemesh/common/types"
@@ -50,6 +53,9 @@ import (
 	"github.com/spacemeshos/go-spacemesh/rand"
 	"github.com/spacemeshos/go-spacemesh/signing"
 	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/atxs"
+	"github.com/spacemeshos/go-spacemesh/sql/identities"
+	"github.com/spacemeshos/go-spacemesh/tortoise"
 	"github.com/spacemeshos/go-spacemesh/txs"
 )
 
@@ -84,6 +90,7 @@ var (
 	genTime     = GenesisTimeMock{time.Unix(genTimeUnix, 0)}
 	addr1       = wallet.Address(signer1.PublicKey().Bytes())
 	addr2       = wallet.Address(signer2.PublicKey().Bytes())
+	smesher1    = types.BytesToNodeID(signer1.PublicKey().Bytes())
 	prevAtxID   = types.ATXID(types.HexToHash32("44444"))
 	chlng       = types.HexToHash32("55555")
 	poetRef     = []byte("66666")
@@ -132,6 +139,7 @@ func dialGrpc(ctx context.Context, tb testing.TB, cfg config.Config) *grpc.Clien
 func TestMain(m *testing.M) {
 	// run on a random port
 	cfg.GrpcServerPort = 1024 + rand.Intn(9999)
+	types.SetLayersPerEpoch(layersPerEpoch)
 
 	atx := types.NewActivationTx(challenge, addr1, nipost, numUnits, nil)
 	if err := activation.SignAtx(signer, atx); err != nil {
@@ -163,7 +171,6 @@ func TestMain(m *testing.M) {
 	block1.TxIDs = []types.TransactionID{globalTx.ID, globalTx2.ID}
 	conStateAPI.returnTx[globalTx.ID] = globalTx
 	conStateAPI.returnTx[globalTx2.ID] = globalTx2
-	types.SetLayersPerEpoch(layersPerEpoch)
 
 	res := m.Run()
 	os.Exit(res)
@@ -218,10 +225,18 @@ func (m *MeshAPIMock) GetRewards(types.Address) (rewards []*types.Reward, err er
 			TotalReward: rewardAmount,
 			LayerReward: rewardAmount,
 			Coinbase:    addr1,
+			SmesherID:   smesher1,
 		},
 	}, nil
 }
 
+func (m *MeshAPIMock) GetRewardsBySmesher(id types.NodeID) (rewards []*types.Reward, err error) {
+	if id != smesher1 {
+		return nil, nil
+	}
+	return m.GetRewards(addr1)
+}
+
 func (m *MeshAPIMock) GetLayer(tid types.LayerID) (*types.Layer, error) {
 	if tid.After(genTime.GetCurrentLayer()) {
 		return nil, errors.New("requested layer later than current layer")
@@ -383,6 +398,10 @@ func (ms *MockSigning) Sign(m []byte) []byte {
 	return ms.signer.Sign(m)
 }
 
+func (ms *MockSigning) SignRequest(req signing.Request) ([]byte, error) {
+	return ms.signer.SignRequest(req)
+}
+
 // PostAPIMock is a mock for Post API.
 // TODO(mafa): replace this mock with the generated mock.
 type PostAPIMock struct{}
@@ -431,6 +450,24 @@ func (p *PostAPIMock) Config() atypes.PostConfig {
 	return atypes.PostConfig{}
 }
 
+func (p *PostAPIMock) VerifyData(_ context.Context, opts atypes.PostVerifyOpts) (<-chan *atypes.PostVerifyStatus, error) {
+	ch := make(chan *atypes.PostVerifyStatus, 2)
+	ch <- &atypes.PostVerifyStatus{LabelsToVerify: 100, LabelsVerified: 50}
+	final := &atypes.PostVerifyStatus{
+		LabelsToVerify:  100,
+		LabelsVerified:  100,
+		LabelsCorrupted: 8,
+		Corrupted:       []atypes.LabelRange{{Start: 16, End: 24}},
+		Done:            true,
+	}
+	if opts.Repair {
+		final.LabelsRepaired = 8
+	}
+	ch <- final
+	close(ch)
+	return ch, nil
+}
+
 // SmeshingAPIMock is a mock for Smeshing API.
 type SmeshingAPIMock struct{}
 
@@ -457,6 +494,26 @@ func (*SmeshingAPIMock) Coinbase() types.Address {
 func (*SmeshingAPIMock) SetCoinbase(coinbase types.Address) {
 }
 
+func (*SmeshingAPIMock) AtxDeadlines() *atypes.AtxDeadlineStatus {
+	return &atypes.AtxDeadlineStatus{
+		PublishEpoch: 3,
+		Phases: []atypes.AtxPhaseStatus{
+			{
+				Phase:     atypes.AtxPhaseChallengeBuilt,
+				Deadline:  time.Unix(1000, 0),
+				Completed: time.Unix(900, 0),
+				Slack:     100 * time.Second,
+			},
+			{
+				Phase:    atypes.AtxPhasePoetSubmitted,
+				Deadline: time.Unix(1000, 0),
+				Slack:    -time.Minute,
+			},
+		},
+		AtRisk: true,
+	}
+}
+
 type GenesisTimeMock struct {
 	t time.Time
 }
@@ -1092,6 +1149,100 @@ func TestSmesherService(t *testing.T) {
 	}
 }
 
+func TestSmesherService_Identities(t *testing.T) {
+	logtest.SetupGlobal(t)
+	identity := SmesherIdentity{
+		ID:       signer.NodeID(),
+		Post:     &PostAPIMock{},
+		Smeshing: &SmeshingAPIMock{},
+		Opts:     atypes.PostSetupOpts{DataDir: t.TempDir()},
+	}
+	svc := NewSmesherService(&PostAPIMock{}, &SmeshingAPIMock{}, identity)
+	shutDown := launchServer(t, svc)
+	defer shutDown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	conn := dialGrpc(ctx, t, cfg)
+
+	client := nodepb.NewSmesherIdentityServiceClient(conn)
+	id := identity.ID.ToBytes()
+
+	t.Run("Identities", func(t *testing.T) {
+		rst, err := client.Identities(ctx, &empty.Empty{})
+		require.NoError(t, err)
+		require.Len(t, rst.Identities, 1)
+		require.Equal(t, id, rst.Identities[0].Id)
+		require.False(t, rst.Identities[0].Smeshing)
+		require.Equal(t, identity.Opts.DataDir, rst.Identities[0].DataDir)
+	})
+	t.Run("IdentityPostSetupStatus", func(t *testing.T) {
+		rst, err := client.IdentityPostSetupStatus(ctx, &nodepb.IdentityRequest{Id: id})
+		require.NoError(t, err)
+		require.Equal(t, id, rst.Id)
+	})
+	t.Run("UnknownIdentity", func(t *testing.T) {
+		_, err := client.IdentityPostSetupStatus(ctx, &nodepb.IdentityRequest{Id: types.NodeID{1}.ToBytes()})
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+	t.Run("StartIdentitySmeshingMissingCoinbase", func(t *testing.T) {
+		_, err := client.StartIdentitySmeshing(ctx, &nodepb.StartIdentitySmeshingRequest{Id: id})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("StartIdentitySmeshing", func(t *testing.T) {
+		_, err := client.StartIdentitySmeshing(ctx, &nodepb.StartIdentitySmeshingRequest{Id: id, Coinbase: addr1.String()})
+		require.NoError(t, err)
+	})
+	t.Run("StopIdentitySmeshing", func(t *testing.T) {
+		_, err := client.StopIdentitySmeshing(ctx, &nodepb.StopIdentitySmeshingRequest{Id: id, DeleteFiles: true})
+		require.NoError(t, err)
+	})
+	verify := func(t *testing.T, req *nodepb.VerifyPostDataRequest) ([]*nodepb.PostVerifyStatus, error) {
+		stream, err := client.VerifyPostData(ctx, req)
+		require.NoError(t, err)
+		var statuses []*nodepb.PostVerifyStatus
+		for {
+			rst, err := stream.Recv()
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					return statuses, nil
+				}
+				return statuses, err
+			}
+			statuses = append(statuses, rst)
+		}
+	}
+	t.Run("VerifyPostData", func(t *testing.T) {
+		statuses, err := verify(t, &nodepb.VerifyPostDataRequest{Id: id, Repair: true})
+		require.NoError(t, err)
+		require.Len(t, statuses, 2)
+		require.EqualValues(t, 50, statuses[0].LabelsVerified)
+		final := statuses[1]
+		require.True(t, final.Done)
+		require.EqualValues(t, 8, final.LabelsCorrupted)
+		require.EqualValues(t, 8, final.LabelsRepaired)
+		require.Len(t, final.CorruptedRanges, 1)
+		require.EqualValues(t, 16, final.CorruptedRanges[0].Start)
+		require.EqualValues(t, 24, final.CorruptedRanges[0].End)
+	})
+	t.Run("AtxDeadlines", func(t *testing.T) {
+		rst, err := client.AtxDeadlines(ctx, &nodepb.IdentityRequest{Id: id})
+		require.NoError(t, err)
+		require.EqualValues(t, 3, rst.PublishEpoch)
+		require.True(t, rst.AtRisk)
+		require.Len(t, rst.Phases, 2)
+		require.Equal(t, nodepb.AtxPhase_ATX_PHASE_CHALLENGE_BUILT, rst.Phases[0].Phase)
+		require.Equal(t, time.Unix(1000, 0).UTC(), rst.Phases[0].Deadline.AsTime())
+		require.Equal(t, time.Unix(900, 0).UTC(), rst.Phases[0].Completed.AsTime())
+		require.Equal(t, 100*time.Second, rst.Phases[0].Slack.AsDuration())
+		require.Equal(t, -60*time.Second, rst.Phases[1].Slack.AsDuration())
+	})
+	t.Run("VerifyPostDataInvalidFraction", func(t *testing.T) {
+		_, err := verify(t, &nodepb.VerifyPostDataRequest{Fraction: 1.5})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
 func TestMeshService(t *testing.T) {
 	logtest.SetupGlobal(t)
 	grpcService := NewMeshService(meshAPI, conStateAPI, &genTime, layersPerEpoch, types.Hash20{}, layerDurationSec, layerAvgSize, txsPerProposal)
@@ -2254,6 +2405,67 @@ func TestAccountDataStream_comprehensive(t *testing.T) {
 	wg.Wait()
 }
 
+func TestSmesherRewardStream_comprehensive(t *testing.T) {
+	logtest.SetupGlobal(t)
+	svc := NewGlobalStateService(meshAPI, conStateAPI)
+	shutDown := launchServer(t, svc)
+	defer shutDown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	conn := dialGrpc(ctx, t, cfg)
+	c := pb.NewGlobalStateServiceClient(conn)
+
+	// initialize the streamer
+	events.CloseEventReporter()
+	events.InitializeReporter()
+	defer events.CloseEventReporter()
+
+	for _, req := range []*pb.SmesherRewardStreamRequest{
+		{},
+		{Id: &pb.SmesherId{Id: []byte{1, 2, 3}}},
+	} {
+		stream, err := c.SmesherRewardStream(ctx, req)
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+
+	stream, err := c.SmesherRewardStream(ctx, &pb.SmesherRewardStreamRequest{
+		Id: &pb.SmesherId{Id: smesher1.ToBytes()},
+	})
+	require.NoError(t, err)
+
+	// reward from the database
+	res, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, layerFirst.Uint32(), res.Reward.Layer.Number)
+	require.Equal(t, uint64(rewardAmount), res.Reward.Total.Value)
+	require.Equal(t, addr1.String(), res.Reward.Coinbase.Address)
+	require.Equal(t, smesher1.ToBytes(), res.Reward.Smesher.Id)
+
+	// reward for another smesher is filtered out
+	events.ReportRewardReceived(events.Reward{
+		Layer:    layerFirst.Add(1),
+		Coinbase: addr1,
+		Smesher:  types.BytesToNodeID(signer2.PublicKey().Bytes()),
+	})
+	events.ReportRewardReceived(events.Reward{
+		Layer:       layerFirst.Add(2),
+		Total:       rewardAmount,
+		LayerReward: rewardAmount * 2,
+		Coinbase:    addr2,
+		Smesher:     smesher1,
+	})
+	res, err = stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, layerFirst.Add(2).Uint32(), res.Reward.Layer.Number)
+	require.Equal(t, uint64(rewardAmount), res.Reward.Total.Value)
+	require.Equal(t, uint64(rewardAmount*2), res.Reward.LayerReward.Value)
+	require.Equal(t, addr2.String(), res.Reward.Coinbase.Address)
+	require.Equal(t, smesher1.ToBytes(), res.Reward.Smesher.Id)
+}
+
 func TestGlobalStateStream_comprehensive(t *testing.T) {
 	logtest.SetupGlobal(t)
 	svc := NewGlobalStateService(meshAPI, conStateAPI)
@@ -2446,7 +2658,7 @@ func checkAccountDataQueryItemReward(t *testing.T, dataItem interface{}) {
 	require.Equal(t, uint64(rewardAmount), x.Reward.Total.Value)
 	require.Equal(t, uint64(rewardAmount), x.Reward.LayerReward.Value)
 	require.Equal(t, addr1.String(), x.Reward.Coinbase.Address)
-	require.Nil(t, x.Reward.Smesher)
+	require.Equal(t, smesher1.ToBytes(), x.Reward.Smesher.Id)
 }
 
 func checkAccountMeshDataItemTx(t *testing.T, dataItem interface{}) {
@@ -2604,7 +2816,9 @@ func TestDebugService(t *testing.T) {
 	logtest.SetupGlobal(t)
 	ctrl := gomock.NewController(t)
 	identity := mocks.NewMockNetworkIdentity(ctrl)
-	svc := NewDebugService(conStateAPI, identity)
+	trtl := mocks.NewMockTortoiseAPI(ctrl)
+	db := sql.InMemory()
+	svc := NewDebugService(db, conStateAPI, identity, trtl, "")
 	shutDown := launchServer(t, svc)
 	defer shutDown()
 
@@ -2612,6 +2826,7 @@ func TestDebugService(t *testing.T) {
 	defer cancel()
 	conn := dialGrpc(ctx, t, cfg)
 	c := pb.NewDebugServiceClient(conn)
+	debug := nodepb.NewDebugServiceClient(conn)
 
 	t.Run("Accounts", func(t *testing.T) {
 		res, err := c.Accounts(context.Background(), &empty.Empty{})
@@ -2657,6 +2872,263 @@ func TestDebugService(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, pb.Proposal_Included, msg.Status)
 	})
+	t.Run("ExplainLayer", func(t *testing.T) {
+		lid := types.NewLayerID(10)
+		block := types.BlockID{1, 2, 3}
+		trtl.EXPECT().ExplainLayer(lid).Return(&tortoise.LayerExplanation{
+			Layer:    lid,
+			Mode:     "verifying",
+			Decision: &tortoise.Decision{Mode: "verifying", Reason: "margin doesn't cross global threshold", Margin: 10},
+			Blocks: []tortoise.BlockExplanation{{
+				ID:      block,
+				Layer:   lid,
+				Support: 10,
+				Ballots: []tortoise.BallotVote{{Ballot: types.BallotID{1}, Weight: 10, Vote: "support", Good: true}},
+				Atxs:    []tortoise.AtxVote{{ATX: types.ATXID{1}, Support: 10}},
+			}},
+		}, nil)
+
+		rst, err := debug.ExplainLayer(ctx, &nodepb.ExplainLayerRequest{Layer: lid.Uint32()})
+		require.NoError(t, err)
+		require.Equal(t, lid.Uint32(), rst.Layer)
+		require.Equal(t, "verifying", rst.Mode)
+		require.Equal(t, "margin doesn't cross global threshold", rst.Decision.Reason)
+		require.Equal(t, float64(10), rst.Decision.Margin)
+		require.Len(t, rst.Blocks, 1)
+		explained := rst.Blocks[0]
+		require.Equal(t, block[:], explained.Id)
+		require.Equal(t, float64(10), explained.Support)
+		require.Len(t, explained.Ballots, 1)
+		require.Len(t, explained.Atxs, 1)
+
+		trtl.EXPECT().ExplainLayer(lid).Return(nil, tortoise.ErrNotInWindow)
+		_, err = debug.ExplainLayer(ctx, &nodepb.ExplainLayerRequest{Layer: lid.Uint32()})
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+	t.Run("ExplainBlock", func(t *testing.T) {
+		block := types.BlockID{1, 2, 3}
+		trtl.EXPECT().ExplainBlock(block).Return(&tortoise.BlockExplanation{
+			ID:        block,
+			Validity:  "support",
+			LocalVote: "support",
+			Against:   5,
+		}, nil)
+
+		rst, err := debug.ExplainBlock(ctx, &nodepb.ExplainBlockRequest{Id: block[:]})
+		require.NoError(t, err)
+		require.Equal(t, block[:], rst.Id)
+		require.Equal(t, "support", rst.Validity)
+		require.Equal(t, float64(5), rst.Against)
+
+		_, err = debug.ExplainBlock(ctx, &nodepb.ExplainBlockRequest{Id: []byte{1}})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("MalfeasanceStream", func(t *testing.T) {
+		events.InitializeReporter()
+		t.Cleanup(events.CloseEventReporter)
+
+		stored := types.NewMalfeasanceProof(types.MultipleATXs,
+			types.NewSignedMessage([]byte{1}, []byte{1}),
+			types.NewSignedMessage([]byte{2}, []byte{2}),
+		)
+		encoded, err := codec.Encode(stored)
+		require.NoError(t, err)
+		storedID := types.NodeID{1}
+		require.NoError(t, db.WithTx(context.Background(), func(tx *sql.Tx) error {
+			return identities.AddProof(tx, storedID.ToBytes(), encoded, time.Now())
+		}))
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		stream, err := debug.MalfeasanceStream(ctx, &empty.Empty{})
+		require.NoError(t, err)
+		_, err = stream.Header()
+		require.NoError(t, err)
+
+		rst, err := stream.Recv()
+		require.NoError(t, err)
+		require.Equal(t, storedID.ToBytes(), rst.Smesher)
+		require.Equal(t, types.MultipleATXs.String(), rst.Type)
+		require.Equal(t, encoded, rst.Proof)
+		require.NotZero(t, rst.Received)
+
+		reported := types.NewMalfeasanceProof(types.MultipleBallots,
+			types.NewSignedMessage([]byte{3}, []byte{3}),
+			types.NewSignedMessage([]byte{4}, []byte{4}),
+		)
+		reportedID := types.NodeID{2}
+		events.ReportMalfeasance(reportedID, reported)
+		rst, err = stream.Recv()
+		require.NoError(t, err)
+		require.Equal(t, reportedID.ToBytes(), rst.Smesher)
+		require.Equal(t, types.MultipleBallots.String(), rst.Type)
+	})
+}
+
+var _ PeerAccessProvider = (*p2p.Host)(nil)
+
+type peerAccessMock struct {
+	*p2p.Gater
+}
+
+func (peerAccessMock) ID() p2p.Peer {
+	return "test"
+}
+
+func (m peerAccessMock) AccessEntries(list p2p.AccessList) ([]string, error) {
+	return m.Entries(list), nil
+}
+
+func (m peerAccessMock) AddAccessEntry(list p2p.AccessList, entry string) error {
+	return m.Add(list, entry)
+}
+
+func (m peerAccessMock) RemoveAccessEntry(list p2p.AccessList, entry string) error {
+	return m.Remove(list, entry)
+}
+
+func TestDebugService_PeerAccess(t *testing.T) {
+	logtest.SetupGlobal(t)
+	gater, err := p2p.NewGater(logtest.New(t), t.TempDir(), true)
+	require.NoError(t, err)
+	const token = "admin"
+	svc := NewDebugService(sql.InMemory(), conStateAPI, peerAccessMock{gater}, nil, token)
+	shutDown := launchServer(t, svc)
+	defer shutDown()
+
+	unauthorized, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	conn := dialGrpc(unauthorized, t, cfg)
+
+	client := nodepb.NewDebugServiceClient(conn)
+	entry := func(list nodepb.AccessList, entry string) *nodepb.PeerAccessEntryRequest {
+		return &nodepb.PeerAccessEntryRequest{List: list, Entry: entry}
+	}
+	_, err = client.AddPeerAccessEntry(unauthorized, entry(nodepb.AccessList_ACCESS_LIST_DENY, "10.3.0.0/16"))
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = client.RemovePeerAccessEntry(unauthorized, entry(nodepb.AccessList_ACCESS_LIST_DENY, "10.3.0.0/16"))
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	wrong := metadata.AppendToOutgoingContext(unauthorized, "authorization", "Bearer other")
+	_, err = client.AddPeerAccessEntry(wrong, entry(nodepb.AccessList_ACCESS_LIST_DENY, "10.3.0.0/16"))
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	require.Empty(t, gater.Entries(p2p.Denylist))
+
+	ctx := metadata.AppendToOutgoingContext(unauthorized, "authorization", "Bearer "+token)
+	_, err = client.AddPeerAccessEntry(ctx, entry(nodepb.AccessList_ACCESS_LIST_ALLOW, "10.0.0.1"))
+	require.NoError(t, err)
+	_, err = client.AddPeerAccessEntry(ctx, entry(nodepb.AccessList_ACCESS_LIST_DENY, "10.1.0.0/16"))
+	require.NoError(t, err)
+	_, err = client.AddPeerAccessEntry(ctx, entry(nodepb.AccessList_ACCESS_LIST_DENY, "10.2.0.0/16"))
+	require.NoError(t, err)
+	_, err = client.RemovePeerAccessEntry(ctx, entry(nodepb.AccessList_ACCESS_LIST_DENY, "10.2.0.0/16"))
+	require.NoError(t, err)
+
+	rst, err := client.PeerAccessLists(ctx, &empty.Empty{})
+	require.NoError(t, err)
+	require.True(t, rst.AllowlistOnly)
+	require.Equal(t, []string{"10.0.0.1/32"}, rst.Allowlist)
+	require.Equal(t, []string{"10.1.0.0/16"}, rst.Denylist)
+
+	_, err = client.AddPeerAccessEntry(ctx, entry(nodepb.AccessList_ACCESS_LIST_DENY, "peer"))
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.AddPeerAccessEntry(ctx, entry(nodepb.AccessList_ACCESS_LIST_UNSPECIFIED, "10.0.0.1"))
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.RemovePeerAccessEntry(ctx, entry(nodepb.AccessList_ACCESS_LIST_DENY, "10.2.0.0/16"))
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestBeaconService(t *testing.T) {
+	logtest.SetupGlobal(t)
+	ctrl := gomock.NewController(t)
+	protocol := mocks.NewMockBeaconAPI(ctrl)
+	const token = "secret"
+	shutDown := launchServer(t, NewBeaconService(protocol, token))
+	defer shutDown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	conn := dialGrpc(ctx, t, cfg)
+	client := nodepb.NewBeaconServiceClient(conn)
+
+	t.Run("BallotBeacons", func(t *testing.T) {
+		epoch := types.EpochID(5)
+		chosen := types.Beacon{1, 2, 3, 4}
+		protocol.EXPECT().GetBeacon(epoch).Return(chosen, nil)
+		protocol.EXPECT().IsManualBeacon(epoch).Return(false, nil)
+		protocol.EXPECT().BallotBeacons(epoch).Return([]beacon.BallotBeacon{
+			{Beacon: chosen, Weight: 20, Ballots: 2},
+			{Beacon: types.Beacon{5}, Weight: 10, Ballots: 1},
+		})
+
+		rst, err := client.BallotBeacons(ctx, &nodepb.BallotBeaconsRequest{Epoch: uint32(epoch)})
+		require.NoError(t, err)
+		require.Equal(t, chosen.Bytes(), rst.Beacon)
+		require.False(t, rst.Manual)
+		require.Len(t, rst.Ballots, 2)
+		require.Equal(t, chosen.Bytes(), rst.Ballots[0].Beacon)
+		require.EqualValues(t, 20, rst.Ballots[0].Weight)
+		require.EqualValues(t, 2, rst.Ballots[0].Ballots)
+	})
+	t.Run("BeaconStream", func(t *testing.T) {
+		events.InitializeReporter()
+		t.Cleanup(events.CloseEventReporter)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		stream, err := client.BeaconStream(ctx, &empty.Empty{})
+		require.NoError(t, err)
+		_, err = stream.Header()
+		require.NoError(t, err)
+
+		coin := true
+		events.ReportBeacon(events.EventBeacon{Epoch: 4, Stage: events.BeaconProposals, ValidProposals: 3})
+		events.ReportBeacon(events.EventBeacon{
+			Epoch:    4,
+			Stage:    events.BeaconRound,
+			Round:    2,
+			WeakCoin: &coin,
+			Margins:  []events.BeaconMargin{{Proposal: []byte{1}, Margin: big.NewInt(-5)}},
+		})
+
+		rst, err := stream.Recv()
+		require.NoError(t, err)
+		require.EqualValues(t, 4, rst.Epoch)
+		require.Equal(t, nodepb.BeaconStage_BEACON_STAGE_PROPOSALS, rst.Stage)
+		require.EqualValues(t, 3, rst.ValidProposals)
+
+		rst, err = stream.Recv()
+		require.NoError(t, err)
+		require.Equal(t, nodepb.BeaconStage_BEACON_STAGE_ROUND, rst.Stage)
+		require.EqualValues(t, 2, rst.Round)
+		require.True(t, rst.WeakCoinUsed)
+		require.True(t, rst.WeakCoin)
+		require.Len(t, rst.Margins, 1)
+		require.Equal(t, "-5", rst.Margins[0].Margin)
+	})
+	t.Run("SetBeacon", func(t *testing.T) {
+		value := types.Beacon{1, 2, 3, 4}
+		request := &nodepb.SetBeaconRequest{Epoch: 10, Beacon: value.Bytes()}
+
+		_, err := client.SetBeacon(ctx, request)
+		require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+		authorized := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+		protocol.EXPECT().SetManualBeacon(types.EpochID(10), value).Return(nil)
+		_, err = client.SetBeacon(authorized, request)
+		require.NoError(t, err)
+
+		protocol.EXPECT().SetManualBeacon(types.EpochID(10), value).Return(beacon.ErrNotFutureEpoch)
+		_, err = client.SetBeacon(authorized, request)
+		require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+		_, err = client.SetBeacon(authorized, &nodepb.SetBeaconRequest{Epoch: 10, Beacon: []byte{1}})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("SetBeacon disabled", func(t *testing.T) {
+		svc := NewBeaconService(protocol, "")
+		_, err := svc.SetBeacon(metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer ")), &nodepb.SetBeaconRequest{})
+		require.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
 }
 
 func TestGatewayService(t *testing.T) {
@@ -2775,13 +3247,15 @@ func TestEventsReceived(t *testing.T) {
 	// without sleep execution in the test goroutine completes
 	// before streams can subscribe to the internal events.
 	time.Sleep(50 * time.Millisecond)
-	svm := vm.New(sql.InMemory(), vm.WithLogger(logtest.New(t)))
+	db := sql.InMemory()
+	require.NoError(t, atxs.Add(db, globalAtx, time.Now()))
+	svm := vm.New(db, vm.WithLogger(logtest.New(t)))
 	conState := txs.NewConservativeState(svm, sql.InMemory(), txs.WithLogger(logtest.New(t).WithName("conState")))
 	conState.AddToCache(context.TODO(), globalTx)
 
 	weight := util.WeightFromFloat64(18.7)
 	require.NoError(t, err)
-	rewards := []types.AnyReward{{Coinbase: addr2, Weight: types.RatNum{Num: weight.Num().Uint64(), Denom: weight.Denom().Uint64()}}}
+	rewards := []types.AnyReward{{Coinbase: addr2, AtxID: globalAtx.ID(), Weight: types.RatNum{Num: weight.Num().Uint64(), Denom: weight.Denom().Uint64()}}}
 	svm.Apply(vm.ApplyContext{Layer: types.GetEffectiveGenesis()},
 		[]types.Transaction{*globalTx}, rewards)
 
@@ -9,6 +9,7 @@ import (
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	gw "github.com/spacemeshos/api/release/go/spacemesh/v1"
 
+	"github.com/spacemeshos/go-spacemesh/api/nodepb"
 	cmdp "github.com/spacemeshos/go-spacemesh/cmd"
 	"github.com/spacemeshos/go-spacemesh/log"
 )
@@ -84,10 +85,18 @@ func (s *JSONHTTPServer) startInternal(
 			err = gw.RegisterNodeServiceHandlerServer(ctx, mux, typed)
 		case *SmesherService:
 			err = gw.RegisterSmesherServiceHandlerServer(ctx, mux, typed)
+			if err == nil {
+				err = nodepb.RegisterSmesherIdentityServiceHandlerServer(ctx, mux, typed)
+			}
 		case *TransactionService:
 			err = gw.RegisterTransactionServiceHandlerServer(ctx, mux, typed)
 		case *DebugService:
 			err = gw.RegisterDebugServiceHandlerServer(ctx, mux, typed)
+			if err == nil {
+				err = nodepb.RegisterDebugServiceHandlerServer(ctx, mux, typed)
+			}
+		case *BeaconService:
+			err = nodepb.RegisterBeaconServiceHandlerServer(ctx, mux, typed)
 		}
 		if err != nil {
 			log.Error("registering %T with grpc gateway failed with %v", svc, err)
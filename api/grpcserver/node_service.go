@@ -138,6 +138,8 @@ func (s NodeService) UpdatePoetServers(ctx context.Context, req *pb.UpdatePoetSe
 	switch {
 	case errors.Is(err, activation.ErrPoetServiceUnstable):
 		return nil, status.Errorf(codes.Unavailable, "can't reach poet service (%v). retry later", err)
+	case errors.Is(err, activation.ErrUnknownPoetService):
+		return nil, status.Errorf(codes.InvalidArgument, "poet service is not trusted (%v)", err)
 	}
 	return nil, status.Errorf(codes.Internal, "failed to update poet server")
 }
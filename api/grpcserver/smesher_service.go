@@ -9,11 +9,17 @@ import (
 	"google.golang.org/genproto/googleapis/rpc/code"
 	rpcstatus "google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	atypes "github.com/spacemeshos/go-spacemesh/activation/types"
 	"github.com/spacemeshos/go-spacemesh/api"
+	"github.com/spacemeshos/go-spacemesh/api/nodepb"
 	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/events"
 	"github.com/spacemeshos/go-spacemesh/log"
 )
 
@@ -25,20 +31,42 @@ type PostSetupProvider interface {
 	Config() atypes.PostConfig
 }
 
+// PostDataVerifier is implemented by PostSetupProviders that can verify and repair their Post data.
+type PostDataVerifier interface {
+	VerifyData(ctx context.Context, opts atypes.PostVerifyOpts) (<-chan *atypes.PostVerifyStatus, error)
+}
+
+// AtxDeadlineProvider is implemented by SmeshingAPIs that track the phases of the ATX construction.
+type AtxDeadlineProvider interface {
+	AtxDeadlines() *atypes.AtxDeadlineStatus
+}
+
+// SmesherIdentity is one of the identities that smesh on the node.
+type SmesherIdentity struct {
+	ID       types.NodeID
+	Post     PostSetupProvider
+	Smeshing api.SmeshingAPI
+	// Opts are used to start smeshing for the identity.
+	Opts atypes.PostSetupOpts
+}
+
 // SmesherService exposes endpoints to manage smeshing.
 type SmesherService struct {
 	postSetupProvider PostSetupProvider
 	smeshingProvider  api.SmeshingAPI
+	// identities can be managed separately with the identity methods.
+	identities []SmesherIdentity
 }
 
 // RegisterService registers this service with a grpc server instance.
 func (s SmesherService) RegisterService(server *Server) {
 	pb.RegisterSmesherServiceServer(server.GrpcServer, s)
+	nodepb.RegisterSmesherIdentityServiceServer(server.GrpcServer, s)
 }
 
 // NewSmesherService creates a new grpc service using config data.
-func NewSmesherService(post PostSetupProvider, smeshing api.SmeshingAPI) *SmesherService {
-	return &SmesherService{post, smeshing}
+func NewSmesherService(post PostSetupProvider, smeshing api.SmeshingAPI, identities ...SmesherIdentity) *SmesherService {
+	return &SmesherService{post, smeshing, identities}
 }
 
 // IsSmeshing reports whether the node is smeshing.
@@ -265,3 +293,241 @@ func statusToPbStatus(status *atypes.PostSetupStatus) *pb.PostSetupStatus {
 
 	return pbStatus
 }
+
+// Identities lists identities that smesh on the node with their smeshing and post setup status.
+func (s SmesherService) Identities(context.Context, *emptypb.Empty) (*nodepb.IdentitiesResponse, error) {
+	log.Info("GRPC SmesherService.Identities")
+
+	rst := &nodepb.IdentitiesResponse{}
+	for _, identity := range s.identities {
+		rst.Identities = append(rst.Identities, castSmesherIdentity(identity))
+	}
+	return rst, nil
+}
+
+// IdentityPostSetupStatus returns smeshing and post setup status of the identity.
+func (s SmesherService) IdentityPostSetupStatus(_ context.Context, in *nodepb.IdentityRequest) (*nodepb.SmesherIdentity, error) {
+	log.Info("GRPC SmesherService.IdentityPostSetupStatus")
+
+	identity, err := s.identity(in.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return castSmesherIdentity(*identity), nil
+}
+
+// StartIdentitySmeshing starts smeshing for the identity, rewards are accumulated in the coinbase account.
+func (s SmesherService) StartIdentitySmeshing(_ context.Context, in *nodepb.StartIdentitySmeshingRequest) (*emptypb.Empty, error) {
+	log.Info("GRPC SmesherService.StartIdentitySmeshing")
+
+	identity, err := s.identity(in.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if in.GetCoinbase() == "" {
+		return nil, status.Error(codes.InvalidArgument, "`coinbase` must be provided")
+	}
+	coinbaseAddr, err := types.StringToAddress(in.GetCoinbase())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse coinbase `%s`: %v", in.GetCoinbase(), err)
+	}
+	if err := identity.Smeshing.StartSmeshing(coinbaseAddr, identity.Opts); err != nil {
+		err := fmt.Sprintf("failed to start smeshing: %v", err)
+		log.With().Error(err, identity.ID)
+		return nil, status.Error(codes.Internal, err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// StopIdentitySmeshing stops smeshing for the identity. PoST data of the identity is deleted if requested.
+func (s SmesherService) StopIdentitySmeshing(ctx context.Context, in *nodepb.StopIdentitySmeshingRequest) (*emptypb.Empty, error) {
+	log.Info("GRPC SmesherService.StopIdentitySmeshing")
+
+	identity, err := s.identity(in.GetId())
+	if err != nil {
+		return nil, err
+	}
+	errchan := make(chan error, 1)
+	go func() {
+		errchan <- identity.Smeshing.StopSmeshing(in.GetDeleteFiles())
+	}()
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("context done: %w", ctx.Err())
+	case err := <-errchan:
+		if err != nil {
+			err := fmt.Sprintf("failed to stop smeshing: %v", err)
+			log.With().Error(err, identity.ID)
+			return nil, status.Error(codes.Internal, err)
+		}
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// VerifyPostData verifies Post data of the node, or of the identity, against its commitment and streams the progress.
+// A fraction of the labels in (0, 1] is sampled, all labels are verified if fraction is not set.
+// Corrupted labels are regenerated if repair is requested.
+func (s SmesherService) VerifyPostData(in *nodepb.VerifyPostDataRequest, stream nodepb.SmesherIdentityService_VerifyPostDataServer) error {
+	log.Info("GRPC SmesherService.VerifyPostData")
+
+	post := s.postSetupProvider
+	if len(in.GetId()) > 0 {
+		identity, err := s.identity(in.GetId())
+		if err != nil {
+			return err
+		}
+		post = identity.Post
+	}
+	verifier, ok := post.(PostDataVerifier)
+	if !ok {
+		return status.Error(codes.Unimplemented, "post data verification is not supported")
+	}
+	opts := atypes.PostVerifyOpts{
+		Fraction: 1,
+		Repair:   in.GetRepair(),
+	}
+	if in.GetFraction() != 0 {
+		opts.Fraction = in.GetFraction()
+	}
+	if opts.Fraction <= 0 || opts.Fraction > 1 {
+		return status.Errorf(codes.InvalidArgument, "`fraction` must be in (0, 1], got %v", opts.Fraction)
+	}
+	statusChan, err := verifier.VerifyData(stream.Context(), opts)
+	if err != nil {
+		return status.Errorf(codes.FailedPrecondition, "verify post data: %v", err)
+	}
+	for verifyStatus := range statusChan {
+		if err := stream.Send(castPostVerifyStatus(verifyStatus)); err != nil {
+			return fmt.Errorf("send to stream: %w", err)
+		}
+	}
+	return nil
+}
+
+// AtxDeadlines returns the phases of the ATX that is built by the node, or by the identity,
+// with their deadlines and the remaining slack.
+func (s SmesherService) AtxDeadlines(_ context.Context, in *nodepb.IdentityRequest) (*nodepb.AtxDeadlinesResponse, error) {
+	log.Info("GRPC SmesherService.AtxDeadlines")
+
+	smeshing := s.smeshingProvider
+	if len(in.GetId()) > 0 {
+		identity, err := s.identity(in.GetId())
+		if err != nil {
+			return nil, err
+		}
+		smeshing = identity.Smeshing
+	}
+	provider, ok := smeshing.(AtxDeadlineProvider)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "atx deadlines are not tracked")
+	}
+	return castAtxDeadlines(provider.AtxDeadlines()), nil
+}
+
+// AtxPhaseStream streams phases of the ATX construction as they are completed by the node and its identities.
+func (s SmesherService) AtxPhaseStream(_ *emptypb.Empty, stream nodepb.SmesherIdentityService_AtxPhaseStreamServer) error {
+	log.Info("GRPC SmesherService.AtxPhaseStream")
+
+	sub := events.SubscribeAtxPhases()
+	if sub == nil {
+		return status.Errorf(codes.FailedPrecondition, "event reporting is not enabled")
+	}
+	eventch, fullch := consumeEvents(stream.Context(), sub)
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return status.Errorf(codes.Unavailable, "can't send header")
+	}
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-fullch:
+			return status.Errorf(codes.Canceled, "buffer is full")
+		case ev := <-eventch:
+			phase := ev.(events.EventAtxPhase)
+			if err := stream.Send(castAtxPhaseEvent(&phase)); err != nil {
+				return fmt.Errorf("send to stream: %w", err)
+			}
+		}
+	}
+}
+
+func (s SmesherService) identity(id []byte) (*SmesherIdentity, error) {
+	if len(id) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "`id` must be provided")
+	}
+	nodeID := types.BytesToNodeID(id)
+	for i := range s.identities {
+		if s.identities[i].ID == nodeID {
+			return &s.identities[i], nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "identity %s not found", nodeID)
+}
+
+func castSmesherIdentity(identity SmesherIdentity) *nodepb.SmesherIdentity {
+	post := identity.Post.Status()
+	rst := &nodepb.SmesherIdentity{
+		Id:               identity.ID.ToBytes(),
+		Smeshing:         identity.Smeshing.Smeshing(),
+		Coinbase:         identity.Smeshing.Coinbase().String(),
+		DataDir:          identity.Opts.DataDir,
+		PostState:        nodepb.PostSetupState(post.State),
+		NumLabelsWritten: post.NumLabelsWritten,
+	}
+	if post.LastError != nil {
+		rst.ErrorMessage = post.LastError.Error()
+	}
+	return rst
+}
+
+func castAtxDeadlines(deadlines *atypes.AtxDeadlineStatus) *nodepb.AtxDeadlinesResponse {
+	rst := &nodepb.AtxDeadlinesResponse{}
+	if deadlines == nil {
+		return rst
+	}
+	rst.PublishEpoch = deadlines.PublishEpoch
+	rst.AtRisk = deadlines.AtRisk
+	for _, phase := range deadlines.Phases {
+		encoded := &nodepb.AtxPhaseStatus{Phase: nodepb.AtxPhase(phase.Phase)}
+		if !phase.Deadline.IsZero() {
+			encoded.Deadline = timestamppb.New(phase.Deadline)
+			encoded.Slack = durationpb.New(phase.Slack)
+		}
+		if !phase.Completed.IsZero() {
+			encoded.Completed = timestamppb.New(phase.Completed)
+		}
+		rst.Phases = append(rst.Phases, encoded)
+	}
+	return rst
+}
+
+func castAtxPhaseEvent(ev *events.EventAtxPhase) *nodepb.AtxPhaseEvent {
+	rst := &nodepb.AtxPhaseEvent{
+		Smesher:      ev.Smesher.ToBytes(),
+		PublishEpoch: uint32(ev.PublishEpoch),
+		Phase:        nodepb.AtxPhase(ev.Phase),
+		Completed:    timestamppb.New(ev.Completed),
+	}
+	if !ev.Deadline.IsZero() {
+		rst.Deadline = timestamppb.New(ev.Deadline)
+		rst.Slack = durationpb.New(ev.Slack)
+	}
+	return rst
+}
+
+func castPostVerifyStatus(verifyStatus *atypes.PostVerifyStatus) *nodepb.PostVerifyStatus {
+	rst := &nodepb.PostVerifyStatus{
+		LabelsToVerify:  verifyStatus.LabelsToVerify,
+		LabelsVerified:  verifyStatus.LabelsVerified,
+		LabelsCorrupted: verifyStatus.LabelsCorrupted,
+		LabelsRepaired:  verifyStatus.LabelsRepaired,
+		Done:            verifyStatus.Done,
+	}
+	for _, r := range verifyStatus.Corrupted {
+		rst.CorruptedRanges = append(rst.CorruptedRanges, &nodepb.LabelRange{Start: r.Start, End: r.End})
+	}
+	if verifyStatus.LastError != nil {
+		rst.ErrorMessage = verifyStatus.LastError.Error()
+	}
+	return rst
+}
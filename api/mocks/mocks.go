@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/spacemeshos/go-spacemesh/api (interfaces: NetworkIdentity)
+// Source: github.com/spacemeshos/go-spacemesh/api (interfaces: NetworkIdentity,TortoiseAPI,BeaconAPI)
 
 // Package mocks is a generated GoMock package.
 package mocks
@@ -9,6 +9,9 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	peer "github.com/libp2p/go-libp2p/core/peer"
+	beacon "github.com/spacemeshos/go-spacemesh/beacon"
+	types "github.com/spacemeshos/go-spacemesh/common/types"
+	tortoise "github.com/spacemeshos/go-spacemesh/tortoise"
 )
 
 // MockNetworkIdentity is a mock of NetworkIdentity interface.
@@ -47,3 +50,137 @@ func (mr *MockNetworkIdentityMockRecorder) ID() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ID", reflect.TypeOf((*MockNetworkIdentity)(nil).ID))
 }
+
+// MockTortoiseAPI is a mock of TortoiseAPI interface.
+type MockTortoiseAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockTortoiseAPIMockRecorder
+}
+
+// MockTortoiseAPIMockRecorder is the mock recorder for MockTortoiseAPI.
+type MockTortoiseAPIMockRecorder struct {
+	mock *MockTortoiseAPI
+}
+
+// NewMockTortoiseAPI creates a new mock instance.
+func NewMockTortoiseAPI(ctrl *gomock.Controller) *MockTortoiseAPI {
+	mock := &MockTortoiseAPI{ctrl: ctrl}
+	mock.recorder = &MockTortoiseAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTortoiseAPI) EXPECT() *MockTortoiseAPIMockRecorder {
+	return m.recorder
+}
+
+// ExplainBlock mocks base method.
+func (m *MockTortoiseAPI) ExplainBlock(arg0 types.BlockID) (*tortoise.BlockExplanation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExplainBlock", arg0)
+	ret0, _ := ret[0].(*tortoise.BlockExplanation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExplainBlock indicates an expected call of ExplainBlock.
+func (mr *MockTortoiseAPIMockRecorder) ExplainBlock(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExplainBlock", reflect.TypeOf((*MockTortoiseAPI)(nil).ExplainBlock), arg0)
+}
+
+// ExplainLayer mocks base method.
+func (m *MockTortoiseAPI) ExplainLayer(arg0 types.LayerID) (*tortoise.LayerExplanation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExplainLayer", arg0)
+	ret0, _ := ret[0].(*tortoise.LayerExplanation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExplainLayer indicates an expected call of ExplainLayer.
+func (mr *MockTortoiseAPIMockRecorder) ExplainLayer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExplainLayer", reflect.TypeOf((*MockTortoiseAPI)(nil).ExplainLayer), arg0)
+}
+
+// MockBeaconAPI is a mock of BeaconAPI interface.
+type MockBeaconAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockBeaconAPIMockRecorder
+}
+
+// MockBeaconAPIMockRecorder is the mock recorder for MockBeaconAPI.
+type MockBeaconAPIMockRecorder struct {
+	mock *MockBeaconAPI
+}
+
+// NewMockBeaconAPI creates a new mock instance.
+func NewMockBeaconAPI(ctrl *gomock.Controller) *MockBeaconAPI {
+	mock := &MockBeaconAPI{ctrl: ctrl}
+	mock.recorder = &MockBeaconAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBeaconAPI) EXPECT() *MockBeaconAPIMockRecorder {
+	return m.recorder
+}
+
+// BallotBeacons mocks base method.
+func (m *MockBeaconAPI) BallotBeacons(arg0 types.EpochID) []beacon.BallotBeacon {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BallotBeacons", arg0)
+	ret0, _ := ret[0].([]beacon.BallotBeacon)
+	return ret0
+}
+
+// BallotBeacons indicates an expected call of BallotBeacons.
+func (mr *MockBeaconAPIMockRecorder) BallotBeacons(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BallotBeacons", reflect.TypeOf((*MockBeaconAPI)(nil).BallotBeacons), arg0)
+}
+
+// GetBeacon mocks base method.
+func (m *MockBeaconAPI) GetBeacon(arg0 types.EpochID) (types.Beacon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBeacon", arg0)
+	ret0, _ := ret[0].(types.Beacon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBeacon indicates an expected call of GetBeacon.
+func (mr *MockBeaconAPIMockRecorder) GetBeacon(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBeacon", reflect.TypeOf((*MockBeaconAPI)(nil).GetBeacon), arg0)
+}
+
+// IsManualBeacon mocks base method.
+func (m *MockBeaconAPI) IsManualBeacon(arg0 types.EpochID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsManualBeacon", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsManualBeacon indicates an expected call of IsManualBeacon.
+func (mr *MockBeaconAPIMockRecorder) IsManualBeacon(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsManualBeacon", reflect.TypeOf((*MockBeaconAPI)(nil).IsManualBeacon), arg0)
+}
+
+// SetManualBeacon mocks base method.
+func (m *MockBeaconAPI) SetManualBeacon(arg0 types.EpochID, arg1 types.Beacon) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetManualBeacon", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetManualBeacon indicates an expected call of SetManualBeacon.
+func (mr *MockBeaconAPIMockRecorder) SetManualBeacon(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetManualBeacon", reflect.TypeOf((*MockBeaconAPI)(nil).SetManualBeacon), arg0, arg1)
+}
@@ -5,9 +5,11 @@ import (
 	"time"
 
 	"github.com/spacemeshos/go-spacemesh/activation"
+	"github.com/spacemeshos/go-spacemesh/beacon"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/p2p"
 	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
+	"github.com/spacemeshos/go-spacemesh/tortoise"
 )
 
 // Publisher interface for publishing messages.
@@ -52,13 +54,28 @@ type MeshAPI interface {
 	GetATXs(context.Context, []types.ATXID) (map[types.ATXID]*types.VerifiedActivationTx, []types.ATXID)
 	GetLayer(types.LayerID) (*types.Layer, error)
 	GetRewards(types.Address) ([]*types.Reward, error)
+	GetRewardsBySmesher(types.NodeID) ([]*types.Reward, error)
 	LatestLayer() types.LayerID
 	LatestLayerInState() types.LayerID
 	ProcessedLayer() types.LayerID
 }
 
+// TortoiseAPI is an api for explaining tortoise decisions.
+type TortoiseAPI interface {
+	ExplainLayer(types.LayerID) (*tortoise.LayerExplanation, error)
+	ExplainBlock(types.BlockID) (*tortoise.BlockExplanation, error)
+}
+
+// BeaconAPI is an api for observing the beacon protocol and setting beacons manually.
+type BeaconAPI interface {
+	GetBeacon(types.EpochID) (types.Beacon, error)
+	IsManualBeacon(types.EpochID) (bool, error)
+	BallotBeacons(types.EpochID) []beacon.BallotBeacon
+	SetManualBeacon(types.EpochID, types.Beacon) error
+}
+
 // NOTE that mockgen doesn't use source-mode to avoid generating mocks for all interfaces in this file.
-//go:generate mockgen -package=mocks -destination=./mocks/mocks.go github.com/spacemeshos/go-spacemesh/api NetworkIdentity
+//go:generate mockgen -package=mocks -destination=./mocks/mocks.go github.com/spacemeshos/go-spacemesh/api NetworkIdentity,TortoiseAPI,BeaconAPI
 
 // NetworkIdentity interface.
 type NetworkIdentity interface {
@@ -0,0 +1,749 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.5
+// source: nodepb/beacon.proto
+
+package nodepb
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type BeaconStage int32
+
+const (
+	BeaconStage_BEACON_STAGE_UNSPECIFIED BeaconStage = 0
+	// BEACON_STAGE_PROPOSALS is reported when the proposal phase is finished.
+	BeaconStage_BEACON_STAGE_PROPOSALS BeaconStage = 1
+	// BEACON_STAGE_ROUND is reported when the voting round is finished.
+	BeaconStage_BEACON_STAGE_ROUND BeaconStage = 2
+	// BEACON_STAGE_CALCULATED is reported when the beacon is calculated by the protocol.
+	BeaconStage_BEACON_STAGE_CALCULATED BeaconStage = 3
+	// BEACON_STAGE_FROM_BALLOTS is reported when the beacon is adopted from the ballots.
+	BeaconStage_BEACON_STAGE_FROM_BALLOTS BeaconStage = 4
+	// BEACON_STAGE_MANUAL is reported when the beacon is set by the operator.
+	BeaconStage_BEACON_STAGE_MANUAL BeaconStage = 5
+)
+
+// Enum value maps for BeaconStage.
+var (
+	BeaconStage_name = map[int32]string{
+		0: "BEACON_STAGE_UNSPECIFIED",
+		1: "BEACON_STAGE_PROPOSALS",
+		2: "BEACON_STAGE_ROUND",
+		3: "BEACON_STAGE_CALCULATED",
+		4: "BEACON_STAGE_FROM_BALLOTS",
+		5: "BEACON_STAGE_MANUAL",
+	}
+	BeaconStage_value = map[string]int32{
+		"BEACON_STAGE_UNSPECIFIED":  0,
+		"BEACON_STAGE_PROPOSALS":    1,
+		"BEACON_STAGE_ROUND":        2,
+		"BEACON_STAGE_CALCULATED":   3,
+		"BEACON_STAGE_FROM_BALLOTS": 4,
+		"BEACON_STAGE_MANUAL":       5,
+	}
+)
+
+func (x BeaconStage) Enum() *BeaconStage {
+	p := new(BeaconStage)
+	*p = x
+	return p
+}
+
+func (x BeaconStage) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BeaconStage) Descriptor() protoreflect.EnumDescriptor {
+	return file_nodepb_beacon_proto_enumTypes[0].Descriptor()
+}
+
+func (BeaconStage) Type() protoreflect.EnumType {
+	return &file_nodepb_beacon_proto_enumTypes[0]
+}
+
+func (x BeaconStage) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BeaconStage.Descriptor instead.
+func (BeaconStage) EnumDescriptor() ([]byte, []int) {
+	return file_nodepb_beacon_proto_rawDescGZIP(), []int{0}
+}
+
+type BeaconEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// epoch is the epoch the beacon is used in.
+	Epoch uint32      `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Stage BeaconStage `protobuf:"varint,2,opt,name=stage,proto3,enum=spacemesh.node.v1.BeaconStage" json:"stage,omitempty"`
+	// valid_proposals and potentially_valid_proposals are set for the proposals stage.
+	ValidProposals            uint32 `protobuf:"varint,3,opt,name=valid_proposals,json=validProposals,proto3" json:"valid_proposals,omitempty"`
+	PotentiallyValidProposals uint32 `protobuf:"varint,4,opt,name=potentially_valid_proposals,json=potentiallyValidProposals,proto3" json:"potentially_valid_proposals,omitempty"`
+	// round, margins and weak coin are set for the round stage.
+	Round   uint32            `protobuf:"varint,5,opt,name=round,proto3" json:"round,omitempty"`
+	Margins []*ProposalMargin `protobuf:"bytes,6,rep,name=margins,proto3" json:"margins,omitempty"`
+	// weak_coin_used is set if the weak coin was used in the round.
+	WeakCoinUsed bool `protobuf:"varint,7,opt,name=weak_coin_used,json=weakCoinUsed,proto3" json:"weak_coin_used,omitempty"`
+	WeakCoin     bool `protobuf:"varint,8,opt,name=weak_coin,json=weakCoin,proto3" json:"weak_coin,omitempty"`
+	// beacon is set for the calculated, from ballots and manual stages.
+	Beacon []byte `protobuf:"bytes,9,opt,name=beacon,proto3" json:"beacon,omitempty"`
+	// weight is the weight of ballots that reported the beacon, set for the from ballots stage.
+	Weight uint64 `protobuf:"varint,10,opt,name=weight,proto3" json:"weight,omitempty"`
+}
+
+func (x *BeaconEvent) Reset() {
+	*x = BeaconEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nodepb_beacon_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BeaconEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeaconEvent) ProtoMessage() {}
+
+func (x *BeaconEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_nodepb_beacon_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeaconEvent.ProtoReflect.Descriptor instead.
+func (*BeaconEvent) Descriptor() ([]byte, []int) {
+	return file_nodepb_beacon_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BeaconEvent) GetEpoch() uint32 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *BeaconEvent) GetStage() BeaconStage {
+	if x != nil {
+		return x.Stage
+	}
+	return BeaconStage_BEACON_STAGE_UNSPECIFIED
+}
+
+func (x *BeaconEvent) GetValidProposals() uint32 {
+	if x != nil {
+		return x.ValidProposals
+	}
+	return 0
+}
+
+func (x *BeaconEvent) GetPotentiallyValidProposals() uint32 {
+	if x != nil {
+		return x.PotentiallyValidProposals
+	}
+	return 0
+}
+
+func (x *BeaconEvent) GetRound() uint32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *BeaconEvent) GetMargins() []*ProposalMargin {
+	if x != nil {
+		return x.Margins
+	}
+	return nil
+}
+
+func (x *BeaconEvent) GetWeakCoinUsed() bool {
+	if x != nil {
+		return x.WeakCoinUsed
+	}
+	return false
+}
+
+func (x *BeaconEvent) GetWeakCoin() bool {
+	if x != nil {
+		return x.WeakCoin
+	}
+	return false
+}
+
+func (x *BeaconEvent) GetBeacon() []byte {
+	if x != nil {
+		return x.Beacon
+	}
+	return nil
+}
+
+func (x *BeaconEvent) GetWeight() uint64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+// ProposalMargin is the votes margin of the beacon proposal.
+type ProposalMargin struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Proposal []byte `protobuf:"bytes,1,opt,name=proposal,proto3" json:"proposal,omitempty"`
+	// margin is a decimal integer.
+	Margin string `protobuf:"bytes,2,opt,name=margin,proto3" json:"margin,omitempty"`
+}
+
+func (x *ProposalMargin) Reset() {
+	*x = ProposalMargin{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nodepb_beacon_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProposalMargin) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProposalMargin) ProtoMessage() {}
+
+func (x *ProposalMargin) ProtoReflect() protoreflect.Message {
+	mi := &file_nodepb_beacon_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProposalMargin.ProtoReflect.Descriptor instead.
+func (*ProposalMargin) Descriptor() ([]byte, []int) {
+	return file_nodepb_beacon_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ProposalMargin) GetProposal() []byte {
+	if x != nil {
+		return x.Proposal
+	}
+	return nil
+}
+
+func (x *ProposalMargin) GetMargin() string {
+	if x != nil {
+		return x.Margin
+	}
+	return ""
+}
+
+type BallotBeaconsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Epoch uint32 `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+}
+
+func (x *BallotBeaconsRequest) Reset() {
+	*x = BallotBeaconsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nodepb_beacon_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BallotBeaconsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BallotBeaconsRequest) ProtoMessage() {}
+
+func (x *BallotBeaconsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nodepb_beacon_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BallotBeaconsRequest.ProtoReflect.Descriptor instead.
+func (*BallotBeaconsRequest) Descriptor() ([]byte, []int) {
+	return file_nodepb_beacon_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BallotBeaconsRequest) GetEpoch() uint32 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+type BallotBeaconsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Epoch uint32 `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	// beacon is empty if the beacon for the epoch is not known yet.
+	Beacon []byte `protobuf:"bytes,2,opt,name=beacon,proto3" json:"beacon,omitempty"`
+	Manual bool   `protobuf:"varint,3,opt,name=manual,proto3" json:"manual,omitempty"`
+	// ballots are beacons reported by ballots, ordered by weight.
+	Ballots []*BallotBeacon `protobuf:"bytes,4,rep,name=ballots,proto3" json:"ballots,omitempty"`
+}
+
+func (x *BallotBeaconsResponse) Reset() {
+	*x = BallotBeaconsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nodepb_beacon_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BallotBeaconsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BallotBeaconsResponse) ProtoMessage() {}
+
+func (x *BallotBeaconsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nodepb_beacon_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BallotBeaconsResponse.ProtoReflect.Descriptor instead.
+func (*BallotBeaconsResponse) Descriptor() ([]byte, []int) {
+	return file_nodepb_beacon_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BallotBeaconsResponse) GetEpoch() uint32 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *BallotBeaconsResponse) GetBeacon() []byte {
+	if x != nil {
+		return x.Beacon
+	}
+	return nil
+}
+
+func (x *BallotBeaconsResponse) GetManual() bool {
+	if x != nil {
+		return x.Manual
+	}
+	return false
+}
+
+func (x *BallotBeaconsResponse) GetBallots() []*BallotBeacon {
+	if x != nil {
+		return x.Ballots
+	}
+	return nil
+}
+
+type BallotBeacon struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Beacon  []byte `protobuf:"bytes,1,opt,name=beacon,proto3" json:"beacon,omitempty"`
+	Weight  uint64 `protobuf:"varint,2,opt,name=weight,proto3" json:"weight,omitempty"`
+	Ballots uint32 `protobuf:"varint,3,opt,name=ballots,proto3" json:"ballots,omitempty"`
+}
+
+func (x *BallotBeacon) Reset() {
+	*x = BallotBeacon{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nodepb_beacon_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BallotBeacon) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BallotBeacon) ProtoMessage() {}
+
+func (x *BallotBeacon) ProtoReflect() protoreflect.Message {
+	mi := &file_nodepb_beacon_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BallotBeacon.ProtoReflect.Descriptor instead.
+func (*BallotBeacon) Descriptor() ([]byte, []int) {
+	return file_nodepb_beacon_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BallotBeacon) GetBeacon() []byte {
+	if x != nil {
+		return x.Beacon
+	}
+	return nil
+}
+
+func (x *BallotBeacon) GetWeight() uint64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *BallotBeacon) GetBallots() uint32 {
+	if x != nil {
+		return x.Ballots
+	}
+	return 0
+}
+
+type SetBeaconRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Epoch  uint32 `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Beacon []byte `protobuf:"bytes,2,opt,name=beacon,proto3" json:"beacon,omitempty"`
+}
+
+func (x *SetBeaconRequest) Reset() {
+	*x = SetBeaconRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nodepb_beacon_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetBeaconRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBeaconRequest) ProtoMessage() {}
+
+func (x *SetBeaconRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nodepb_beacon_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetBeaconRequest.ProtoReflect.Descriptor instead.
+func (*SetBeaconRequest) Descriptor() ([]byte, []int) {
+	return file_nodepb_beacon_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SetBeaconRequest) GetEpoch() uint32 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *SetBeaconRequest) GetBeacon() []byte {
+	if x != nil {
+		return x.Beacon
+	}
+	return nil
+}
+
+var File_nodepb_beacon_proto protoreflect.FileDescriptor
+
+var file_nodepb_beacon_proto_rawDesc = []byte{
+	0x0a, 0x13, 0x6e, 0x6f, 0x64, 0x65, 0x70, 0x62, 0x2f, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x11, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68,
+	0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x22, 0x88, 0x03, 0x0a, 0x0b, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x34, 0x0a, 0x05, 0x73, 0x74, 0x61,
+	0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x6d, 0x65, 0x73, 0x68, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x65, 0x61,
+	0x63, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x67, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x12,
+	0x27, 0x0a, 0x0f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x5f, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61,
+	0x6c, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x50,
+	0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x73, 0x12, 0x3e, 0x0a, 0x1b, 0x70, 0x6f, 0x74, 0x65,
+	0x6e, 0x74, 0x69, 0x61, 0x6c, 0x6c, 0x79, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x5f, 0x70, 0x72,
+	0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x19, 0x70,
+	0x6f, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x6c, 0x79, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x50,
+	0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e,
+	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x3b,
+	0x0a, 0x07, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x21, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x6e, 0x6f, 0x64, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x4d, 0x61, 0x72, 0x67,
+	0x69, 0x6e, 0x52, 0x07, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x77,
+	0x65, 0x61, 0x6b, 0x5f, 0x63, 0x6f, 0x69, 0x6e, 0x5f, 0x75, 0x73, 0x65, 0x64, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0c, 0x77, 0x65, 0x61, 0x6b, 0x43, 0x6f, 0x69, 0x6e, 0x55, 0x73, 0x65,
+	0x64, 0x12, 0x1b, 0x0a, 0x09, 0x77, 0x65, 0x61, 0x6b, 0x5f, 0x63, 0x6f, 0x69, 0x6e, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x77, 0x65, 0x61, 0x6b, 0x43, 0x6f, 0x69, 0x6e, 0x12, 0x16,
+	0x0a, 0x06, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06,
+	0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x44,
+	0x0a, 0x0e, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x4d, 0x61, 0x72, 0x67, 0x69, 0x6e,
+	0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x12, 0x16, 0x0a, 0x06,
+	0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x61,
+	0x72, 0x67, 0x69, 0x6e, 0x22, 0x2c, 0x0a, 0x14, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x74, 0x42, 0x65,
+	0x61, 0x63, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x65, 0x70, 0x6f,
+	0x63, 0x68, 0x22, 0x98, 0x01, 0x0a, 0x15, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x74, 0x42, 0x65, 0x61,
+	0x63, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x65, 0x70, 0x6f,
+	0x63, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x06, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61,
+	0x6e, 0x75, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6d, 0x61, 0x6e, 0x75,
+	0x61, 0x6c, 0x12, 0x39, 0x0a, 0x07, 0x62, 0x61, 0x6c, 0x6c, 0x6f, 0x74, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x2e,
+	0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x74, 0x42, 0x65,
+	0x61, 0x63, 0x6f, 0x6e, 0x52, 0x07, 0x62, 0x61, 0x6c, 0x6c, 0x6f, 0x74, 0x73, 0x22, 0x58, 0x0a,
+	0x0c, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x74, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x12, 0x16, 0x0a,
+	0x06, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x62,
+	0x65, 0x61, 0x63, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x18, 0x0a,
+	0x07, 0x62, 0x61, 0x6c, 0x6c, 0x6f, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07,
+	0x62, 0x61, 0x6c, 0x6c, 0x6f, 0x74, 0x73, 0x22, 0x40, 0x0a, 0x10, 0x53, 0x65, 0x74, 0x42, 0x65,
+	0x61, 0x63, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65,
+	0x70, 0x6f, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63,
+	0x68, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x06, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x2a, 0xb4, 0x01, 0x0a, 0x0b, 0x42, 0x65,
+	0x61, 0x63, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x18, 0x42, 0x45, 0x41,
+	0x43, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x47, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1a, 0x0a, 0x16, 0x42, 0x45, 0x41, 0x43, 0x4f,
+	0x4e, 0x5f, 0x53, 0x54, 0x41, 0x47, 0x45, 0x5f, 0x50, 0x52, 0x4f, 0x50, 0x4f, 0x53, 0x41, 0x4c,
+	0x53, 0x10, 0x01, 0x12, 0x16, 0x0a, 0x12, 0x42, 0x45, 0x41, 0x43, 0x4f, 0x4e, 0x5f, 0x53, 0x54,
+	0x41, 0x47, 0x45, 0x5f, 0x52, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x02, 0x12, 0x1b, 0x0a, 0x17, 0x42,
+	0x45, 0x41, 0x43, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x47, 0x45, 0x5f, 0x43, 0x41, 0x4c, 0x43,
+	0x55, 0x4c, 0x41, 0x54, 0x45, 0x44, 0x10, 0x03, 0x12, 0x1d, 0x0a, 0x19, 0x42, 0x45, 0x41, 0x43,
+	0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x47, 0x45, 0x5f, 0x46, 0x52, 0x4f, 0x4d, 0x5f, 0x42, 0x41,
+	0x4c, 0x4c, 0x4f, 0x54, 0x53, 0x10, 0x04, 0x12, 0x17, 0x0a, 0x13, 0x42, 0x45, 0x41, 0x43, 0x4f,
+	0x4e, 0x5f, 0x53, 0x54, 0x41, 0x47, 0x45, 0x5f, 0x4d, 0x41, 0x4e, 0x55, 0x41, 0x4c, 0x10, 0x05,
+	0x32, 0xf2, 0x02, 0x0a, 0x0d, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x12, 0x6c, 0x0a, 0x0c, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1e, 0x2e, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42,
+	0x65, 0x61, 0x63, 0x6f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x22, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x1c, 0x3a, 0x01, 0x2a, 0x22, 0x17, 0x2f, 0x76, 0x31, 0x2f, 0x62, 0x65, 0x61, 0x63, 0x6f,
+	0x6e, 0x2f, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x30, 0x01,
+	0x12, 0x87, 0x01, 0x0a, 0x0d, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x74, 0x42, 0x65, 0x61, 0x63, 0x6f,
+	0x6e, 0x73, 0x12, 0x27, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x6e,
+	0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x74, 0x42, 0x65, 0x61,
+	0x63, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x74, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x23, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1d, 0x3a, 0x01, 0x2a,
+	0x22, 0x18, 0x2f, 0x76, 0x31, 0x2f, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x2f, 0x62, 0x61, 0x6c,
+	0x6c, 0x6f, 0x74, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x73, 0x12, 0x69, 0x0a, 0x09, 0x53, 0x65,
+	0x74, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x12, 0x23, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d,
+	0x65, 0x73, 0x68, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x42,
+	0x65, 0x61, 0x63, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x22, 0x1f, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x19, 0x3a, 0x01, 0x2a, 0x22,
+	0x14, 0x2f, 0x76, 0x31, 0x2f, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x2f, 0x73, 0x65, 0x74, 0x62,
+	0x65, 0x61, 0x63, 0x6f, 0x6e, 0x42, 0x37, 0x5a, 0x35, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x6f, 0x73, 0x2f,
+	0x67, 0x6f, 0x2d, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x2f, 0x61, 0x70, 0x69,
+	0x2f, 0x6e, 0x6f, 0x64, 0x65, 0x70, 0x62, 0x3b, 0x6e, 0x6f, 0x64, 0x65, 0x70, 0x62, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_nodepb_beacon_proto_rawDescOnce sync.Once
+	file_nodepb_beacon_proto_rawDescData = file_nodepb_beacon_proto_rawDesc
+)
+
+func file_nodepb_beacon_proto_rawDescGZIP() []byte {
+	file_nodepb_beacon_proto_rawDescOnce.Do(func() {
+		file_nodepb_beacon_proto_rawDescData = protoimpl.X.CompressGZIP(file_nodepb_beacon_proto_rawDescData)
+	})
+	return file_nodepb_beacon_proto_rawDescData
+}
+
+var file_nodepb_beacon_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_nodepb_beacon_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_nodepb_beacon_proto_goTypes = []interface{}{
+	(BeaconStage)(0),              // 0: spacemesh.node.v1.BeaconStage
+	(*BeaconEvent)(nil),           // 1: spacemesh.node.v1.BeaconEvent
+	(*ProposalMargin)(nil),        // 2: spacemesh.node.v1.ProposalMargin
+	(*BallotBeaconsRequest)(nil),  // 3: spacemesh.node.v1.BallotBeaconsRequest
+	(*BallotBeaconsResponse)(nil), // 4: spacemesh.node.v1.BallotBeaconsResponse
+	(*BallotBeacon)(nil),          // 5: spacemesh.node.v1.BallotBeacon
+	(*SetBeaconRequest)(nil),      // 6: spacemesh.node.v1.SetBeaconRequest
+	(*emptypb.Empty)(nil),         // 7: google.protobuf.Empty
+}
+var file_nodepb_beacon_proto_depIdxs = []int32{
+	0, // 0: spacemesh.node.v1.BeaconEvent.stage:type_name -> spacemesh.node.v1.BeaconStage
+	2, // 1: spacemesh.node.v1.BeaconEvent.margins:type_name -> spacemesh.node.v1.ProposalMargin
+	5, // 2: spacemesh.node.v1.BallotBeaconsResponse.ballots:type_name -> spacemesh.node.v1.BallotBeacon
+	7, // 3: spacemesh.node.v1.BeaconService.BeaconStream:input_type -> google.protobuf.Empty
+	3, // 4: spacemesh.node.v1.BeaconService.BallotBeacons:input_type -> spacemesh.node.v1.BallotBeaconsRequest
+	6, // 5: spacemesh.node.v1.BeaconService.SetBeacon:input_type -> spacemesh.node.v1.SetBeaconRequest
+	1, // 6: spacemesh.node.v1.BeaconService.BeaconStream:output_type -> spacemesh.node.v1.BeaconEvent
+	4, // 7: spacemesh.node.v1.BeaconService.BallotBeacons:output_type -> spacemesh.node.v1.BallotBeaconsResponse
+	7, // 8: spacemesh.node.v1.BeaconService.SetBeacon:output_type -> google.protobuf.Empty
+	6, // [6:9] is the sub-list for method output_type
+	3, // [3:6] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_nodepb_beacon_proto_init() }
+func file_nodepb_beacon_proto_init() {
+	if File_nodepb_beacon_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_nodepb_beacon_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BeaconEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nodepb_beacon_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProposalMargin); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nodepb_beacon_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BallotBeaconsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nodepb_beacon_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BallotBeaconsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nodepb_beacon_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BallotBeacon); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nodepb_beacon_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetBeaconRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_nodepb_beacon_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_nodepb_beacon_proto_goTypes,
+		DependencyIndexes: file_nodepb_beacon_proto_depIdxs,
+		EnumInfos:         file_nodepb_beacon_proto_enumTypes,
+		MessageInfos:      file_nodepb_beacon_proto_msgTypes,
+	}.Build()
+	File_nodepb_beacon_proto = out.File
+	file_nodepb_beacon_proto_rawDesc = nil
+	file_nodepb_beacon_proto_goTypes = nil
+	file_nodepb_beacon_proto_depIdxs = nil
+}
@@ -0,0 +1,214 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.5
+// source: nodepb/beacon.proto
+
+package nodepb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// BeaconServiceClient is the client API for BeaconService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BeaconServiceClient interface {
+	// BeaconStream streams progress of the beacon protocol: number of proposals, votes margins and weak coins
+	// for every round, and beacons that were calculated, adopted from ballots or set manually.
+	BeaconStream(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (BeaconService_BeaconStreamClient, error)
+	// BallotBeacons returns the beacon for the epoch and beacons reported by ballots with their weights.
+	BallotBeacons(ctx context.Context, in *BallotBeaconsRequest, opts ...grpc.CallOption) (*BallotBeaconsResponse, error)
+	// SetBeacon sets the beacon for the future epoch.
+	// Call must be authenticated with the admin token in the "authorization" metadata, as "Bearer <token>".
+	SetBeacon(ctx context.Context, in *SetBeaconRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type beaconServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBeaconServiceClient(cc grpc.ClientConnInterface) BeaconServiceClient {
+	return &beaconServiceClient{cc}
+}
+
+func (c *beaconServiceClient) BeaconStream(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (BeaconService_BeaconStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &BeaconService_ServiceDesc.Streams[0], "/spacemesh.node.v1.BeaconService/BeaconStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &beaconServiceBeaconStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BeaconService_BeaconStreamClient interface {
+	Recv() (*BeaconEvent, error)
+	grpc.ClientStream
+}
+
+type beaconServiceBeaconStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *beaconServiceBeaconStreamClient) Recv() (*BeaconEvent, error) {
+	m := new(BeaconEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *beaconServiceClient) BallotBeacons(ctx context.Context, in *BallotBeaconsRequest, opts ...grpc.CallOption) (*BallotBeaconsResponse, error) {
+	out := new(BallotBeaconsResponse)
+	err := c.cc.Invoke(ctx, "/spacemesh.node.v1.BeaconService/BallotBeacons", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *beaconServiceClient) SetBeacon(ctx context.Context, in *SetBeaconRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/spacemesh.node.v1.BeaconService/SetBeacon", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BeaconServiceServer is the server API for BeaconService service.
+// All implementations should embed UnimplementedBeaconServiceServer
+// for forward compatibility
+type BeaconServiceServer interface {
+	// BeaconStream streams progress of the beacon protocol: number of proposals, votes margins and weak coins
+	// for every round, and beacons that were calculated, adopted from ballots or set manually.
+	BeaconStream(*emptypb.Empty, BeaconService_BeaconStreamServer) error
+	// BallotBeacons returns the beacon for the epoch and beacons reported by ballots with their weights.
+	BallotBeacons(context.Context, *BallotBeaconsRequest) (*BallotBeaconsResponse, error)
+	// SetBeacon sets the beacon for the future epoch.
+	// Call must be authenticated with the admin token in the "authorization" metadata, as "Bearer <token>".
+	SetBeacon(context.Context, *SetBeaconRequest) (*emptypb.Empty, error)
+}
+
+// UnimplementedBeaconServiceServer should be embedded to have forward compatible implementations.
+type UnimplementedBeaconServiceServer struct {
+}
+
+func (UnimplementedBeaconServiceServer) BeaconStream(*emptypb.Empty, BeaconService_BeaconStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method BeaconStream not implemented")
+}
+func (UnimplementedBeaconServiceServer) BallotBeacons(context.Context, *BallotBeaconsRequest) (*BallotBeaconsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BallotBeacons not implemented")
+}
+func (UnimplementedBeaconServiceServer) SetBeacon(context.Context, *SetBeaconRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBeacon not implemented")
+}
+
+// UnsafeBeaconServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BeaconServiceServer will
+// result in compilation errors.
+type UnsafeBeaconServiceServer interface {
+	mustEmbedUnimplementedBeaconServiceServer()
+}
+
+func RegisterBeaconServiceServer(s grpc.ServiceRegistrar, srv BeaconServiceServer) {
+	s.RegisterService(&BeaconService_ServiceDesc, srv)
+}
+
+func _BeaconService_BeaconStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(emptypb.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BeaconServiceServer).BeaconStream(m, &beaconServiceBeaconStreamServer{stream})
+}
+
+type BeaconService_BeaconStreamServer interface {
+	Send(*BeaconEvent) error
+	grpc.ServerStream
+}
+
+type beaconServiceBeaconStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *beaconServiceBeaconStreamServer) Send(m *BeaconEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BeaconService_BallotBeacons_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BallotBeaconsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeaconServiceServer).BallotBeacons(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spacemesh.node.v1.BeaconService/BallotBeacons",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeaconServiceServer).BallotBeacons(ctx, req.(*BallotBeaconsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BeaconService_SetBeacon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetBeaconRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BeaconServiceServer).SetBeacon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spacemesh.node.v1.BeaconService/SetBeacon",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BeaconServiceServer).SetBeacon(ctx, req.(*SetBeaconRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BeaconService_ServiceDesc is the grpc.ServiceDesc for BeaconService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BeaconService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "spacemesh.node.v1.BeaconService",
+	HandlerType: (*BeaconServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "BallotBeacons",
+			Handler:    _BeaconService_BallotBeacons_Handler,
+		},
+		{
+			MethodName: "SetBeacon",
+			Handler:    _BeaconService_SetBeacon_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "BeaconStream",
+			Handler:       _BeaconService_BeaconStream_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "nodepb/beacon.proto",
+}
@@ -0,0 +1,1520 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.5
+// source: nodepb/debug.proto
+
+package nodepb
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AccessList int32
+
+const (
+	AccessList_ACCESS_LIST_UNSPECIFIED AccessList = 0
+	// ACCESS_LIST_ALLOW is a list of peers that are always allowed to connect,
+	// and in the allowlist-only mode the only peers that are allowed to connect.
+	AccessList_ACCESS_LIST_ALLOW AccessList = 1
+	// ACCESS_LIST_DENY is a list of peers that are never allowed to connect.
+	AccessList_ACCESS_LIST_DENY AccessList = 2
+)
+
+// Enum value maps for AccessList.
+var (
+	AccessList_name = map[int32]string{
+		0: "ACCESS_LIST_UNSPECIFIED",
+		1: "ACCESS_LIST_ALLOW",
+		2: "ACCESS_LIST_DENY",
+	}
+	AccessList_value = map[string]int32{
+		"ACCESS_LIST_UNSPECIFIED": 0,
+		"ACCESS_LIST_ALLOW":       1,
+		"ACCESS_LIST_DENY":        2,
+	}
+)
+
+func (x AccessList) Enum() *AccessList {
+	p := new(AccessList)
+	*p = x
+	return p
+}
+
+func (x AccessList) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AccessList) Descriptor() protoreflect.EnumDescriptor {
+	return file_nodepb_debug_proto_enumTypes[0].Descriptor()
+}
+
+func (AccessList) Type() protoreflect.EnumType {
+	return &file_nodepb_debug_proto_enumTypes[0]
+}
+
+func (x AccessList) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AccessList.Descriptor instead.
+func (AccessList) EnumDescriptor() ([]byte, []int) {
+	return file_nodepb_debug_proto_rawDescGZIP(), []int{0}
+}
+
+type ExplainLayerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Layer uint32 `protobuf:"varint,1,opt,name=layer,proto3" json:"layer,omitempty"`
+}
+
+func (x *ExplainLayerRequest) Reset() {
+	*x = ExplainLayerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nodepb_debug_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExplainLayerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExplainLayerRequest) ProtoMessage() {}
+
+func (x *ExplainLayerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nodepb_debug_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExplainLayerRequest.ProtoReflect.Descriptor instead.
+func (*ExplainLayerRequest) Descriptor() ([]byte, []int) {
+	return file_nodepb_debug_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ExplainLayerRequest) GetLayer() uint32 {
+	if x != nil {
+		return x.Layer
+	}
+	return 0
+}
+
+type ExplainBlockRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *ExplainBlockRequest) Reset() {
+	*x = ExplainBlockRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nodepb_debug_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExplainBlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExplainBlockRequest) ProtoMessage() {}
+
+func (x *ExplainBlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nodepb_debug_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExplainBlockRequest.ProtoReflect.Descriptor instead.
+func (*ExplainBlockRequest) Descriptor() ([]byte, []int) {
+	return file_nodepb_debug_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ExplainBlockRequest) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+// LayerExplanation describes the state of the layer in tortoise.
+type LayerExplanation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Layer          uint32 `protobuf:"varint,1,opt,name=layer,proto3" json:"layer,omitempty"`
+	Verified       bool   `protobuf:"varint,2,opt,name=verified,proto3" json:"verified,omitempty"`
+	HareTerminated bool   `protobuf:"varint,3,opt,name=hare_terminated,json=hareTerminated,proto3" json:"hare_terminated,omitempty"`
+	Opinion        []byte `protobuf:"bytes,4,opt,name=opinion,proto3" json:"opinion,omitempty"`
+	// mode, last, processed and latest_verified describe the state of the tortoise when explanation was created.
+	Mode            string  `protobuf:"bytes,5,opt,name=mode,proto3" json:"mode,omitempty"`
+	Last            uint32  `protobuf:"varint,6,opt,name=last,proto3" json:"last,omitempty"`
+	Processed       uint32  `protobuf:"varint,7,opt,name=processed,proto3" json:"processed,omitempty"`
+	LatestVerified  uint32  `protobuf:"varint,8,opt,name=latest_verified,json=latestVerified,proto3" json:"latest_verified,omitempty"`
+	GlobalThreshold float64 `protobuf:"fixed64,9,opt,name=global_threshold,json=globalThreshold,proto3" json:"global_threshold,omitempty"`
+	LocalThreshold  float64 `protobuf:"fixed64,10,opt,name=local_threshold,json=localThreshold,proto3" json:"local_threshold,omitempty"`
+	ExpectedWeight  float64 `protobuf:"fixed64,11,opt,name=expected_weight,json=expectedWeight,proto3" json:"expected_weight,omitempty"`
+	// decision made on the last attempt to verify the layer. not set if layer wasn't a candidate yet.
+	Decision *LayerDecision      `protobuf:"bytes,12,opt,name=decision,proto3" json:"decision,omitempty"`
+	Blocks   []*BlockExplanation `protobuf:"bytes,13,rep,name=blocks,proto3" json:"blocks,omitempty"`
+	// mode_switches that happened when the layer was a candidate for verification.
+	ModeSwitches []*ModeSwitch `protobuf:"bytes,14,rep,name=mode_switches,json=modeSwitches,proto3" json:"mode_switches,omitempty"`
+}
+
+func (x *LayerExplanation) Reset() {
+	*x = LayerExplanation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nodepb_debug_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LayerExplanation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LayerExplanation) ProtoMessage() {}
+
+func (x *LayerExplanation) ProtoReflect() protoreflect.Message {
+	mi := &file_nodepb_debug_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LayerExplanation.ProtoReflect.Descriptor instead.
+func (*LayerExplanation) Descriptor() ([]byte, []int) {
+	return file_nodepb_debug_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LayerExplanation) GetLayer() uint32 {
+	if x != nil {
+		return x.Layer
+	}
+	return 0
+}
+
+func (x *LayerExplanation) GetVerified() bool {
+	if x != nil {
+		return x.Verified
+	}
+	return false
+}
+
+func (x *LayerExplanation) GetHareTerminated() bool {
+	if x != nil {
+		return x.HareTerminated
+	}
+	return false
+}
+
+func (x *LayerExplanation) GetOpinion() []byte {
+	if x != nil {
+		return x.Opinion
+	}
+	return nil
+}
+
+func (x *LayerExplanation) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *LayerExplanation) GetLast() uint32 {
+	if x != nil {
+		return x.Last
+	}
+	return 0
+}
+
+func (x *LayerExplanation) GetProcessed() uint32 {
+	if x != nil {
+		return x.Processed
+	}
+	return 0
+}
+
+func (x *LayerExplanation) GetLatestVerified() uint32 {
+	if x != nil {
+		return x.LatestVerified
+	}
+	return 0
+}
+
+func (x *LayerExplanation) GetGlobalThreshold() float64 {
+	if x != nil {
+		return x.GlobalThreshold
+	}
+	return 0
+}
+
+func (x *LayerExplanation) GetLocalThreshold() float64 {
+	if x != nil {
+		return x.LocalThreshold
+	}
+	return 0
+}
+
+func (x *LayerExplanation) GetExpectedWeight() float64 {
+	if x != nil {
+		return x.ExpectedWeight
+	}
+	return 0
+}
+
+func (x *LayerExplanation) GetDecision() *LayerDecision {
+	if x != nil {
+		return x.Decision
+	}
+	return nil
+}
+
+func (x *LayerExplanation) GetBlocks() []*BlockExplanation {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
+func (x *LayerExplanation) GetModeSwitches() []*ModeSwitch {
+	if x != nil {
+		return x.ModeSwitches
+	}
+	return nil
+}
+
+// LayerDecision contains inputs that were used on the last attempt to verify the layer.
+type LayerDecision struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mode     string `protobuf:"bytes,1,opt,name=mode,proto3" json:"mode,omitempty"`
+	Verified bool   `protobuf:"varint,2,opt,name=verified,proto3" json:"verified,omitempty"`
+	// reason why layer wasn't verified.
+	Reason          string  `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	GlobalThreshold float64 `protobuf:"fixed64,4,opt,name=global_threshold,json=globalThreshold,proto3" json:"global_threshold,omitempty"`
+	// margin is the good weight in verifying mode, and the weight of votes for empty layer in full mode.
+	Margin float64 `protobuf:"fixed64,5,opt,name=margin,proto3" json:"margin,omitempty"`
+	// uncounted, total_good_weight and good_uncounted are set only in verifying mode.
+	Uncounted       float64          `protobuf:"fixed64,6,opt,name=uncounted,proto3" json:"uncounted,omitempty"`
+	TotalGoodWeight float64          `protobuf:"fixed64,7,opt,name=total_good_weight,json=totalGoodWeight,proto3" json:"total_good_weight,omitempty"`
+	GoodUncounted   float64          `protobuf:"fixed64,8,opt,name=good_uncounted,json=goodUncounted,proto3" json:"good_uncounted,omitempty"`
+	Blocks          []*BlockDecision `protobuf:"bytes,9,rep,name=blocks,proto3" json:"blocks,omitempty"`
+}
+
+func (x *LayerDecision) Reset() {
+	*x = LayerDecision{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nodepb_debug_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LayerDecision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LayerDecision) ProtoMessage() {}
+
+func (x *LayerDecision) ProtoReflect() protoreflect.Message {
+	mi := &file_nodepb_debug_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LayerDecision.ProtoReflect.Descriptor instead.
+func (*LayerDecision) Descriptor() ([]byte, []int) {
+	return file_nodepb_debug_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LayerDecision) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *LayerDecision) GetVerified() bool {
+	if x != nil {
+		return x.Verified
+	}
+	return false
+}
+
+func (x *LayerDecision) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *LayerDecision) GetGlobalThreshold() float64 {
+	if x != nil {
+		return x.GlobalThreshold
+	}
+	return 0
+}
+
+func (x *LayerDecision) GetMargin() float64 {
+	if x != nil {
+		return x.Margin
+	}
+	return 0
+}
+
+func (x *LayerDecision) GetUncounted() float64 {
+	if x != nil {
+		return x.Uncounted
+	}
+	return 0
+}
+
+func (x *LayerDecision) GetTotalGoodWeight() float64 {
+	if x != nil {
+		return x.TotalGoodWeight
+	}
+	return 0
+}
+
+func (x *LayerDecision) GetGoodUncounted() float64 {
+	if x != nil {
+		return x.GoodUncounted
+	}
+	return 0
+}
+
+func (x *LayerDecision) GetBlocks() []*BlockDecision {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
+type BlockDecision struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id       []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Decision string `protobuf:"bytes,2,opt,name=decision,proto3" json:"decision,omitempty"`
+}
+
+func (x *BlockDecision) Reset() {
+	*x = BlockDecision{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nodepb_debug_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockDecision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockDecision) ProtoMessage() {}
+
+func (x *BlockDecision) ProtoReflect() protoreflect.Message {
+	mi := &file_nodepb_debug_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockDecision.ProtoReflect.Descriptor instead.
+func (*BlockDecision) Descriptor() ([]byte, []int) {
+	return file_nodepb_debug_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BlockDecision) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *BlockDecision) GetDecision() string {
+	if x != nil {
+		return x.Decision
+	}
+	return ""
+}
+
+// ModeSwitch is recorded every time tortoise switches between verifying and full modes.
+type ModeSwitch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Target    uint32 `protobuf:"varint,1,opt,name=target,proto3" json:"target,omitempty"`
+	Processed uint32 `protobuf:"varint,2,opt,name=processed,proto3" json:"processed,omitempty"`
+	Verified  uint32 `protobuf:"varint,3,opt,name=verified,proto3" json:"verified,omitempty"`
+	Mode      string `protobuf:"bytes,4,opt,name=mode,proto3" json:"mode,omitempty"`
+}
+
+func (x *ModeSwitch) Reset() {
+	*x = ModeSwitch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nodepb_debug_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ModeSwitch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModeSwitch) ProtoMessage() {}
+
+func (x *ModeSwitch) ProtoReflect() protoreflect.Message {
+	mi := &file_nodepb_debug_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModeSwitch.ProtoReflect.Descriptor instead.
+func (*ModeSwitch) Descriptor() ([]byte, []int) {
+	return file_nodepb_debug_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ModeSwitch) GetTarget() uint32 {
+	if x != nil {
+		return x.Target
+	}
+	return 0
+}
+
+func (x *ModeSwitch) GetProcessed() uint32 {
+	if x != nil {
+		return x.Processed
+	}
+	return 0
+}
+
+func (x *ModeSwitch) GetVerified() uint32 {
+	if x != nil {
+		return x.Verified
+	}
+	return 0
+}
+
+func (x *ModeSwitch) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+// BlockExplanation describes votes for the block.
+type BlockExplanation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Layer     uint32 `protobuf:"varint,2,opt,name=layer,proto3" json:"layer,omitempty"`
+	Height    uint64 `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	Hare      string `protobuf:"bytes,4,opt,name=hare,proto3" json:"hare,omitempty"`
+	Validity  string `protobuf:"bytes,5,opt,name=validity,proto3" json:"validity,omitempty"`
+	Persisted string `protobuf:"bytes,6,opt,name=persisted,proto3" json:"persisted,omitempty"`
+	// local_vote and local_vote_reason are used when the block is encoded in ballots created by this node.
+	LocalVote       string `protobuf:"bytes,7,opt,name=local_vote,json=localVote,proto3" json:"local_vote,omitempty"`
+	LocalVoteReason string `protobuf:"bytes,8,opt,name=local_vote_reason,json=localVoteReason,proto3" json:"local_vote_reason,omitempty"`
+	// margin is counted by full tortoise.
+	Margin float64 `protobuf:"fixed64,9,opt,name=margin,proto3" json:"margin,omitempty"`
+	// support, against and abstain sum weight of ballots in the window.
+	Support float64       `protobuf:"fixed64,10,opt,name=support,proto3" json:"support,omitempty"`
+	Against float64       `protobuf:"fixed64,11,opt,name=against,proto3" json:"against,omitempty"`
+	Abstain float64       `protobuf:"fixed64,12,opt,name=abstain,proto3" json:"abstain,omitempty"`
+	Ballots []*BallotVote `protobuf:"bytes,13,rep,name=ballots,proto3" json:"ballots,omitempty"`
+	Atxs    []*AtxVote    `protobuf:"bytes,14,rep,name=atxs,proto3" json:"atxs,omitempty"`
+}
+
+func (x *BlockExplanation) Reset() {
+	*x = BlockExplanation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nodepb_debug_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockExplanation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockExplanation) ProtoMessage() {}
+
+func (x *BlockExplanation) ProtoReflect() protoreflect.Message {
+	mi := &file_nodepb_debug_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockExplanation.ProtoReflect.Descriptor instead.
+func (*BlockExplanation) Descriptor() ([]byte, []int) {
+	return file_nodepb_debug_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BlockExplanation) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *BlockExplanation) GetLayer() uint32 {
+	if x != nil {
+		return x.Layer
+	}
+	return 0
+}
+
+func (x *BlockExplanation) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *BlockExplanation) GetHare() string {
+	if x != nil {
+		return x.Hare
+	}
+	return ""
+}
+
+func (x *BlockExplanation) GetValidity() string {
+	if x != nil {
+		return x.Validity
+	}
+	return ""
+}
+
+func (x *BlockExplanation) GetPersisted() string {
+	if x != nil {
+		return x.Persisted
+	}
+	return ""
+}
+
+func (x *BlockExplanation) GetLocalVote() string {
+	if x != nil {
+		return x.LocalVote
+	}
+	return ""
+}
+
+func (x *BlockExplanation) GetLocalVoteReason() string {
+	if x != nil {
+		return x.LocalVoteReason
+	}
+	return ""
+}
+
+func (x *BlockExplanation) GetMargin() float64 {
+	if x != nil {
+		return x.Margin
+	}
+	return 0
+}
+
+func (x *BlockExplanation) GetSupport() float64 {
+	if x != nil {
+		return x.Support
+	}
+	return 0
+}
+
+func (x *BlockExplanation) GetAgainst() float64 {
+	if x != nil {
+		return x.Against
+	}
+	return 0
+}
+
+func (x *BlockExplanation) GetAbstain() float64 {
+	if x != nil {
+		return x.Abstain
+	}
+	return 0
+}
+
+func (x *BlockExplanation) GetBallots() []*BallotVote {
+	if x != nil {
+		return x.Ballots
+	}
+	return nil
+}
+
+func (x *BlockExplanation) GetAtxs() []*AtxVote {
+	if x != nil {
+		return x.Atxs
+	}
+	return nil
+}
+
+// BallotVote is a vote of a single ballot for the block.
+type BallotVote struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     []byte  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Atx    []byte  `protobuf:"bytes,2,opt,name=atx,proto3" json:"atx,omitempty"`
+	Layer  uint32  `protobuf:"varint,3,opt,name=layer,proto3" json:"layer,omitempty"`
+	Weight float64 `protobuf:"fixed64,4,opt,name=weight,proto3" json:"weight,omitempty"`
+	Vote   string  `protobuf:"bytes,5,opt,name=vote,proto3" json:"vote,omitempty"`
+	// good is true if ballot is consistent with local opinion and is counted by verifying tortoise.
+	Good bool `protobuf:"varint,6,opt,name=good,proto3" json:"good,omitempty"`
+	// counted is true if ballot vote for the block is counted by full tortoise.
+	Counted bool `protobuf:"varint,7,opt,name=counted,proto3" json:"counted,omitempty"`
+}
+
+func (x *BallotVote) Reset() {
+	*x = BallotVote{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nodepb_debug_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BallotVote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BallotVote) ProtoMessage() {}
+
+func (x *BallotVote) ProtoReflect() protoreflect.Message {
+	mi := &file_nodepb_debug_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BallotVote.ProtoReflect.Descriptor instead.
+func (*BallotVote) Descriptor() ([]byte, []int) {
+	return file_nodepb_debug_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BallotVote) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *BallotVote) GetAtx() []byte {
+	if x != nil {
+		return x.Atx
+	}
+	return nil
+}
+
+func (x *BallotVote) GetLayer() uint32 {
+	if x != nil {
+		return x.Layer
+	}
+	return 0
+}
+
+func (x *BallotVote) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *BallotVote) GetVote() string {
+	if x != nil {
+		return x.Vote
+	}
+	return ""
+}
+
+func (x *BallotVote) GetGood() bool {
+	if x != nil {
+		return x.Good
+	}
+	return false
+}
+
+func (x *BallotVote) GetCounted() bool {
+	if x != nil {
+		return x.Counted
+	}
+	return false
+}
+
+// AtxVote sums votes from all ballots of the atx.
+type AtxVote struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id      []byte  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Support float64 `protobuf:"fixed64,2,opt,name=support,proto3" json:"support,omitempty"`
+	Against float64 `protobuf:"fixed64,3,opt,name=against,proto3" json:"against,omitempty"`
+	Abstain float64 `protobuf:"fixed64,4,opt,name=abstain,proto3" json:"abstain,omitempty"`
+}
+
+func (x *AtxVote) Reset() {
+	*x = AtxVote{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nodepb_debug_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AtxVote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AtxVote) ProtoMessage() {}
+
+func (x *AtxVote) ProtoReflect() protoreflect.Message {
+	mi := &file_nodepb_debug_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AtxVote.ProtoReflect.Descriptor instead.
+func (*AtxVote) Descriptor() ([]byte, []int) {
+	return file_nodepb_debug_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AtxVote) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *AtxVote) GetSupport() float64 {
+	if x != nil {
+		return x.Support
+	}
+	return 0
+}
+
+func (x *AtxVote) GetAgainst() float64 {
+	if x != nil {
+		return x.Against
+	}
+	return 0
+}
+
+func (x *AtxVote) GetAbstain() float64 {
+	if x != nil {
+		return x.Abstain
+	}
+	return 0
+}
+
+type MalfeasanceProof struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Smesher []byte `protobuf:"bytes,1,opt,name=smesher,proto3" json:"smesher,omitempty"`
+	Type    string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// proof is scale encoded proof of malfeasance.
+	Proof []byte `protobuf:"bytes,3,opt,name=proof,proto3" json:"proof,omitempty"`
+	// received is unix time when the proof was received, set only for stored proofs.
+	Received int64 `protobuf:"varint,4,opt,name=received,proto3" json:"received,omitempty"`
+}
+
+func (x *MalfeasanceProof) Reset() {
+	*x = MalfeasanceProof{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nodepb_debug_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MalfeasanceProof) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MalfeasanceProof) ProtoMessage() {}
+
+func (x *MalfeasanceProof) ProtoReflect() protoreflect.Message {
+	mi := &file_nodepb_debug_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MalfeasanceProof.ProtoReflect.Descriptor instead.
+func (*MalfeasanceProof) Descriptor() ([]byte, []int) {
+	return file_nodepb_debug_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *MalfeasanceProof) GetSmesher() []byte {
+	if x != nil {
+		return x.Smesher
+	}
+	return nil
+}
+
+func (x *MalfeasanceProof) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *MalfeasanceProof) GetProof() []byte {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+func (x *MalfeasanceProof) GetReceived() int64 {
+	if x != nil {
+		return x.Received
+	}
+	return 0
+}
+
+type PeerAccessListsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AllowlistOnly bool     `protobuf:"varint,1,opt,name=allowlist_only,json=allowlistOnly,proto3" json:"allowlist_only,omitempty"`
+	Allowlist     []string `protobuf:"bytes,2,rep,name=allowlist,proto3" json:"allowlist,omitempty"`
+	Denylist      []string `protobuf:"bytes,3,rep,name=denylist,proto3" json:"denylist,omitempty"`
+}
+
+func (x *PeerAccessListsResponse) Reset() {
+	*x = PeerAccessListsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nodepb_debug_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeerAccessListsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerAccessListsResponse) ProtoMessage() {}
+
+func (x *PeerAccessListsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nodepb_debug_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerAccessListsResponse.ProtoReflect.Descriptor instead.
+func (*PeerAccessListsResponse) Descriptor() ([]byte, []int) {
+	return file_nodepb_debug_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PeerAccessListsResponse) GetAllowlistOnly() bool {
+	if x != nil {
+		return x.AllowlistOnly
+	}
+	return false
+}
+
+func (x *PeerAccessListsResponse) GetAllowlist() []string {
+	if x != nil {
+		return x.Allowlist
+	}
+	return nil
+}
+
+func (x *PeerAccessListsResponse) GetDenylist() []string {
+	if x != nil {
+		return x.Denylist
+	}
+	return nil
+}
+
+type PeerAccessEntryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	List AccessList `protobuf:"varint,1,opt,name=list,proto3,enum=spacemesh.node.v1.AccessList" json:"list,omitempty"`
+	// entry is a peer ID, IP address or CIDR range.
+	Entry string `protobuf:"bytes,2,opt,name=entry,proto3" json:"entry,omitempty"`
+}
+
+func (x *PeerAccessEntryRequest) Reset() {
+	*x = PeerAccessEntryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nodepb_debug_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeerAccessEntryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerAccessEntryRequest) ProtoMessage() {}
+
+func (x *PeerAccessEntryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nodepb_debug_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerAccessEntryRequest.ProtoReflect.Descriptor instead.
+func (*PeerAccessEntryRequest) Descriptor() ([]byte, []int) {
+	return file_nodepb_debug_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PeerAccessEntryRequest) GetList() AccessList {
+	if x != nil {
+		return x.List
+	}
+	return AccessList_ACCESS_LIST_UNSPECIFIED
+}
+
+func (x *PeerAccessEntryRequest) GetEntry() string {
+	if x != nil {
+		return x.Entry
+	}
+	return ""
+}
+
+var File_nodepb_debug_proto protoreflect.FileDescriptor
+
+var file_nodepb_debug_proto_rawDesc = []byte{
+	0x0a, 0x12, 0x6e, 0x6f, 0x64, 0x65, 0x70, 0x62, 0x2f, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x11, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x2e,
+	0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x22, 0x2b, 0x0a, 0x13, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x4c, 0x61, 0x79,
+	0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x22,
+	0x25, 0x0a, 0x13, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x22, 0xb2, 0x04, 0x0a, 0x10, 0x4c, 0x61, 0x79, 0x65, 0x72,
+	0x45, 0x78, 0x70, 0x6c, 0x61, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x6c,
+	0x61, 0x79, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x12, 0x1a, 0x0a, 0x08, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x08, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x12, 0x27, 0x0a,
+	0x0f, 0x68, 0x61, 0x72, 0x65, 0x5f, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x65, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x68, 0x61, 0x72, 0x65, 0x54, 0x65, 0x72, 0x6d,
+	0x69, 0x6e, 0x61, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x70, 0x69, 0x6e, 0x69, 0x6f,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x6f, 0x70, 0x69, 0x6e, 0x69, 0x6f, 0x6e,
+	0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6d, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x61, 0x73, 0x74, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x04, 0x6c, 0x61, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x63,
+	0x65, 0x73, 0x73, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x70, 0x72, 0x6f,
+	0x63, 0x65, 0x73, 0x73, 0x65, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74,
+	0x5f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0e, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x12,
+	0x29, 0x0a, 0x10, 0x67, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68,
+	0x6f, 0x6c, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x67, 0x6c, 0x6f, 0x62, 0x61,
+	0x6c, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x6c, 0x6f,
+	0x63, 0x61, 0x6c, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x0e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68,
+	0x6f, 0x6c, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f,
+	0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x65, 0x78,
+	0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x3c, 0x0a, 0x08,
+	0x64, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20,
+	0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x4c, 0x61, 0x79, 0x65, 0x72, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x52, 0x08, 0x64, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x3b, 0x0a, 0x06, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x42, 0x0a, 0x0d, 0x6d, 0x6f, 0x64, 0x65, 0x5f,
+	0x73, 0x77, 0x69, 0x74, 0x63, 0x68, 0x65, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d,
+	0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x53, 0x77, 0x69, 0x74, 0x63, 0x68, 0x52, 0x0c, 0x6d,
+	0x6f, 0x64, 0x65, 0x53, 0x77, 0x69, 0x74, 0x63, 0x68, 0x65, 0x73, 0x22, 0xc5, 0x02, 0x0a, 0x0d,
+	0x4c, 0x61, 0x79, 0x65, 0x72, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a,
+	0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x6f, 0x64,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x08, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x12, 0x16, 0x0a,
+	0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x29, 0x0a, 0x10, 0x67, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x5f,
+	0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x0f, 0x67, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x06, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x75, 0x6e, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x75, 0x6e, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x65, 0x64, 0x12, 0x2a, 0x0a, 0x11, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f,
+	0x67, 0x6f, 0x6f, 0x64, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x47, 0x6f, 0x6f, 0x64, 0x57, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x67, 0x6f, 0x6f, 0x64, 0x5f, 0x75, 0x6e, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x67, 0x6f, 0x6f, 0x64,
+	0x55, 0x6e, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x64, 0x12, 0x38, 0x0a, 0x06, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x73, 0x22, 0x3b, 0x0a, 0x0d, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x44, 0x65, 0x63, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x22, 0x72, 0x0a, 0x0a, 0x4d, 0x6f, 0x64, 0x65, 0x53, 0x77, 0x69, 0x74, 0x63, 0x68, 0x12, 0x16,
+	0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73,
+	0x73, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x63, 0x65,
+	0x73, 0x73, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6d, 0x6f, 0x64, 0x65, 0x22, 0xb8, 0x03, 0x0a, 0x10, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x45, 0x78,
+	0x70, 0x6c, 0x61, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12,
+	0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x72, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x72, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x76,
+	0x61, 0x6c, 0x69, 0x64, 0x69, 0x74, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x76,
+	0x61, 0x6c, 0x69, 0x64, 0x69, 0x74, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x65, 0x72, 0x73, 0x69,
+	0x73, 0x74, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x65, 0x72, 0x73,
+	0x69, 0x73, 0x74, 0x65, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x76,
+	0x6f, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x6f, 0x63, 0x61, 0x6c,
+	0x56, 0x6f, 0x74, 0x65, 0x12, 0x2a, 0x0a, 0x11, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x76, 0x6f,
+	0x74, 0x65, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0f, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x56, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x06, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x70, 0x70,
+	0x6f, 0x72, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x73, 0x75, 0x70, 0x70, 0x6f,
+	0x72, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x67, 0x61, 0x69, 0x6e, 0x73, 0x74, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x07, 0x61, 0x67, 0x61, 0x69, 0x6e, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07,
+	0x61, 0x62, 0x73, 0x74, 0x61, 0x69, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x61,
+	0x62, 0x73, 0x74, 0x61, 0x69, 0x6e, 0x12, 0x37, 0x0a, 0x07, 0x62, 0x61, 0x6c, 0x6c, 0x6f, 0x74,
+	0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d,
+	0x65, 0x73, 0x68, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x6c, 0x6c,
+	0x6f, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x52, 0x07, 0x62, 0x61, 0x6c, 0x6c, 0x6f, 0x74, 0x73, 0x12,
+	0x2e, 0x0a, 0x04, 0x61, 0x74, 0x78, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x41, 0x74, 0x78, 0x56, 0x6f, 0x74, 0x65, 0x52, 0x04, 0x61, 0x74, 0x78, 0x73, 0x22,
+	0x9e, 0x01, 0x0a, 0x0a, 0x42, 0x61, 0x6c, 0x6c, 0x6f, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x10,
+	0x0a, 0x03, 0x61, 0x74, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x61, 0x74, 0x78,
+	0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x05, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x76, 0x6f, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x76, 0x6f,
+	0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x67, 0x6f, 0x6f, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x04, 0x67, 0x6f, 0x6f, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65,
+	0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x64,
+	0x22, 0x67, 0x0a, 0x07, 0x41, 0x74, 0x78, 0x56, 0x6f, 0x74, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73,
+	0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x73, 0x75,
+	0x70, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x67, 0x61, 0x69, 0x6e, 0x73, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x61, 0x67, 0x61, 0x69, 0x6e, 0x73, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x61, 0x62, 0x73, 0x74, 0x61, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x07, 0x61, 0x62, 0x73, 0x74, 0x61, 0x69, 0x6e, 0x22, 0x72, 0x0a, 0x10, 0x4d, 0x61, 0x6c,
+	0x66, 0x65, 0x61, 0x73, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x18, 0x0a,
+	0x07, 0x73, 0x6d, 0x65, 0x73, 0x68, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07,
+	0x73, 0x6d, 0x65, 0x73, 0x68, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x70,
+	0x72, 0x6f, 0x6f, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x6f,
+	0x66, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x22, 0x7a, 0x0a,
+	0x17, 0x50, 0x65, 0x65, 0x72, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x61, 0x6c, 0x6c, 0x6f,
+	0x77, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0d, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x6c, 0x69, 0x73, 0x74, 0x4f, 0x6e, 0x6c, 0x79, 0x12,
+	0x1c, 0x0a, 0x09, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x09, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x1a, 0x0a,
+	0x08, 0x64, 0x65, 0x6e, 0x79, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x08, 0x64, 0x65, 0x6e, 0x79, 0x6c, 0x69, 0x73, 0x74, 0x22, 0x61, 0x0a, 0x16, 0x50, 0x65, 0x65,
+	0x72, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x31, 0x0a, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x1d, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x6e, 0x6f,
+	0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x4c, 0x69, 0x73, 0x74,
+	0x52, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x2a, 0x56, 0x0a, 0x0a,
+	0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x17, 0x41, 0x43,
+	0x43, 0x45, 0x53, 0x53, 0x5f, 0x4c, 0x49, 0x53, 0x54, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x41, 0x43, 0x43, 0x45, 0x53,
+	0x53, 0x5f, 0x4c, 0x49, 0x53, 0x54, 0x5f, 0x41, 0x4c, 0x4c, 0x4f, 0x57, 0x10, 0x01, 0x12, 0x14,
+	0x0a, 0x10, 0x41, 0x43, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x4c, 0x49, 0x53, 0x54, 0x5f, 0x44, 0x45,
+	0x4e, 0x59, 0x10, 0x02, 0x32, 0x93, 0x06, 0x0a, 0x0c, 0x44, 0x65, 0x62, 0x75, 0x67, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x7e, 0x0a, 0x0c, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e,
+	0x4c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x26, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73,
+	0x68, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x69,
+	0x6e, 0x4c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x4c, 0x61, 0x79, 0x65, 0x72, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x6e, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x22, 0x21, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1b, 0x3a, 0x01, 0x2a, 0x22, 0x16, 0x2f,
+	0x76, 0x31, 0x2f, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2f, 0x65, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x7e, 0x0a, 0x0c, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x26, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73,
+	0x68, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x69,
+	0x6e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x6e, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x22, 0x21, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1b, 0x3a, 0x01, 0x2a, 0x22, 0x16, 0x2f,
+	0x76, 0x31, 0x2f, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2f, 0x65, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x7a, 0x0a, 0x11, 0x4d, 0x61, 0x6c, 0x66, 0x65, 0x61, 0x73,
+	0x61, 0x6e, 0x63, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x1a, 0x23, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x6e,
+	0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x6c, 0x66, 0x65, 0x61, 0x73, 0x61, 0x6e,
+	0x63, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x26, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x20, 0x3a,
+	0x01, 0x2a, 0x22, 0x1b, 0x2f, 0x76, 0x31, 0x2f, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2f, 0x6d, 0x61,
+	0x6c, 0x66, 0x65, 0x61, 0x73, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x30,
+	0x01, 0x12, 0x7b, 0x0a, 0x0f, 0x50, 0x65, 0x65, 0x72, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x4c,
+	0x69, 0x73, 0x74, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x2a, 0x2e, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x50, 0x65, 0x65, 0x72, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x24, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1e,
+	0x3a, 0x01, 0x2a, 0x22, 0x19, 0x2f, 0x76, 0x31, 0x2f, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2f, 0x70,
+	0x65, 0x65, 0x72, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x6c, 0x69, 0x73, 0x74, 0x73, 0x12, 0x80,
+	0x01, 0x0a, 0x12, 0x41, 0x64, 0x64, 0x50, 0x65, 0x65, 0x72, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x29, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73,
+	0x68, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x41, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x27, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x21,
+	0x3a, 0x01, 0x2a, 0x22, 0x1c, 0x2f, 0x76, 0x31, 0x2f, 0x64, 0x65, 0x62, 0x75, 0x67, 0x2f, 0x61,
+	0x64, 0x64, 0x70, 0x65, 0x65, 0x72, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x65, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x86, 0x01, 0x0a, 0x15, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x50, 0x65, 0x65, 0x72,
+	0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x29, 0x2e, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x50, 0x65, 0x65, 0x72, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x2a,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x24, 0x3a, 0x01, 0x2a, 0x22, 0x1f, 0x2f, 0x76, 0x31, 0x2f, 0x64,
+	0x65, 0x62, 0x75, 0x67, 0x2f, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x70, 0x65, 0x65, 0x72, 0x61,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x42, 0x37, 0x5a, 0x35, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65,
+	0x73, 0x68, 0x6f, 0x73, 0x2f, 0x67, 0x6f, 0x2d, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73,
+	0x68, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6e, 0x6f, 0x64, 0x65, 0x70, 0x62, 0x3b, 0x6e, 0x6f, 0x64,
+	0x65, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_nodepb_debug_proto_rawDescOnce sync.Once
+	file_nodepb_debug_proto_rawDescData = file_nodepb_debug_proto_rawDesc
+)
+
+func file_nodepb_debug_proto_rawDescGZIP() []byte {
+	file_nodepb_debug_proto_rawDescOnce.Do(func() {
+		file_nodepb_debug_proto_rawDescData = protoimpl.X.CompressGZIP(file_nodepb_debug_proto_rawDescData)
+	})
+	return file_nodepb_debug_proto_rawDescData
+}
+
+var file_nodepb_debug_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_nodepb_debug_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_nodepb_debug_proto_goTypes = []interface{}{
+	(AccessList)(0),                 // 0: spacemesh.node.v1.AccessList
+	(*ExplainLayerRequest)(nil),     // 1: spacemesh.node.v1.ExplainLayerRequest
+	(*ExplainBlockRequest)(nil),     // 2: spacemesh.node.v1.ExplainBlockRequest
+	(*LayerExplanation)(nil),        // 3: spacemesh.node.v1.LayerExplanation
+	(*LayerDecision)(nil),           // 4: spacemesh.node.v1.LayerDecision
+	(*BlockDecision)(nil),           // 5: spacemesh.node.v1.BlockDecision
+	(*ModeSwitch)(nil),              // 6: spacemesh.node.v1.ModeSwitch
+	(*BlockExplanation)(nil),        // 7: spacemesh.node.v1.BlockExplanation
+	(*BallotVote)(nil),              // 8: spacemesh.node.v1.BallotVote
+	(*AtxVote)(nil),                 // 9: spacemesh.node.v1.AtxVote
+	(*MalfeasanceProof)(nil),        // 10: spacemesh.node.v1.MalfeasanceProof
+	(*PeerAccessListsResponse)(nil), // 11: spacemesh.node.v1.PeerAccessListsResponse
+	(*PeerAccessEntryRequest)(nil),  // 12: spacemesh.node.v1.PeerAccessEntryRequest
+	(*emptypb.Empty)(nil),           // 13: google.protobuf.Empty
+}
+var file_nodepb_debug_proto_depIdxs = []int32{
+	4,  // 0: spacemesh.node.v1.LayerExplanation.decision:type_name -> spacemesh.node.v1.LayerDecision
+	7,  // 1: spacemesh.node.v1.LayerExplanation.blocks:type_name -> spacemesh.node.v1.BlockExplanation
+	6,  // 2: spacemesh.node.v1.LayerExplanation.mode_switches:type_name -> spacemesh.node.v1.ModeSwitch
+	5,  // 3: spacemesh.node.v1.LayerDecision.blocks:type_name -> spacemesh.node.v1.BlockDecision
+	8,  // 4: spacemesh.node.v1.BlockExplanation.ballots:type_name -> spacemesh.node.v1.BallotVote
+	9,  // 5: spacemesh.node.v1.BlockExplanation.atxs:type_name -> spacemesh.node.v1.AtxVote
+	0,  // 6: spacemesh.node.v1.PeerAccessEntryRequest.list:type_name -> spacemesh.node.v1.AccessList
+	1,  // 7: spacemesh.node.v1.DebugService.ExplainLayer:input_type -> spacemesh.node.v1.ExplainLayerRequest
+	2,  // 8: spacemesh.node.v1.DebugService.ExplainBlock:input_type -> spacemesh.node.v1.ExplainBlockRequest
+	13, // 9: spacemesh.node.v1.DebugService.MalfeasanceStream:input_type -> google.protobuf.Empty
+	13, // 10: spacemesh.node.v1.DebugService.PeerAccessLists:input_type -> google.protobuf.Empty
+	12, // 11: spacemesh.node.v1.DebugService.AddPeerAccessEntry:input_type -> spacemesh.node.v1.PeerAccessEntryRequest
+	12, // 12: spacemesh.node.v1.DebugService.RemovePeerAccessEntry:input_type -> spacemesh.node.v1.PeerAccessEntryRequest
+	3,  // 13: spacemesh.node.v1.DebugService.ExplainLayer:output_type -> spacemesh.node.v1.LayerExplanation
+	7,  // 14: spacemesh.node.v1.DebugService.ExplainBlock:output_type -> spacemesh.node.v1.BlockExplanation
+	10, // 15: spacemesh.node.v1.DebugService.MalfeasanceStream:output_type -> spacemesh.node.v1.MalfeasanceProof
+	11, // 16: spacemesh.node.v1.DebugService.PeerAccessLists:output_type -> spacemesh.node.v1.PeerAccessListsResponse
+	13, // 17: spacemesh.node.v1.DebugService.AddPeerAccessEntry:output_type -> google.protobuf.Empty
+	13, // 18: spacemesh.node.v1.DebugService.RemovePeerAccessEntry:output_type -> google.protobuf.Empty
+	13, // [13:19] is the sub-list for method output_type
+	7,  // [7:13] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_nodepb_debug_proto_init() }
+func file_nodepb_debug_proto_init() {
+	if File_nodepb_debug_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_nodepb_debug_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExplainLayerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nodepb_debug_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExplainBlockRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nodepb_debug_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LayerExplanation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nodepb_debug_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LayerDecision); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nodepb_debug_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlockDecision); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nodepb_debug_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ModeSwitch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nodepb_debug_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlockExplanation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nodepb_debug_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BallotVote); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nodepb_debug_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AtxVote); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nodepb_debug_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MalfeasanceProof); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nodepb_debug_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerAccessListsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nodepb_debug_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerAccessEntryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_nodepb_debug_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_nodepb_debug_proto_goTypes,
+		DependencyIndexes: file_nodepb_debug_proto_depIdxs,
+		EnumInfos:         file_nodepb_debug_proto_enumTypes,
+		MessageInfos:      file_nodepb_debug_proto_msgTypes,
+	}.Build()
+	File_nodepb_debug_proto = out.File
+	file_nodepb_debug_proto_rawDesc = nil
+	file_nodepb_debug_proto_goTypes = nil
+	file_nodepb_debug_proto_depIdxs = nil
+}
@@ -0,0 +1,3 @@
+// Package nodepb contains api definitions of the node that extend the public spacemesh api.
+// Code is generated from the proto definitions in this directory with `make proto`.
+package nodepb
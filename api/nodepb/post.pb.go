@@ -0,0 +1,712 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.5
+// source: nodepb/post.proto
+
+package nodepb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PostInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NodeId        []byte `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	CommitmentAtx []byte `protobuf:"bytes,2,opt,name=commitment_atx,json=commitmentAtx,proto3" json:"commitment_atx,omitempty"`
+}
+
+func (x *PostInfo) Reset() {
+	*x = PostInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nodepb_post_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PostInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostInfo) ProtoMessage() {}
+
+func (x *PostInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_nodepb_post_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostInfo.ProtoReflect.Descriptor instead.
+func (*PostInfo) Descriptor() ([]byte, []int) {
+	return file_nodepb_post_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PostInfo) GetNodeId() []byte {
+	if x != nil {
+		return x.NodeId
+	}
+	return nil
+}
+
+func (x *PostInfo) GetCommitmentAtx() []byte {
+	if x != nil {
+		return x.CommitmentAtx
+	}
+	return nil
+}
+
+type PostStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	State            PostSetupState `protobuf:"varint,1,opt,name=state,proto3,enum=spacemesh.node.v1.PostSetupState" json:"state,omitempty"`
+	NumLabelsWritten uint64         `protobuf:"varint,2,opt,name=num_labels_written,json=numLabelsWritten,proto3" json:"num_labels_written,omitempty"`
+	Opts             *PostSetupOpts `protobuf:"bytes,3,opt,name=opts,proto3" json:"opts,omitempty"`
+	ErrorMessage     string         `protobuf:"bytes,4,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+}
+
+func (x *PostStatus) Reset() {
+	*x = PostStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nodepb_post_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PostStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostStatus) ProtoMessage() {}
+
+func (x *PostStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_nodepb_post_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostStatus.ProtoReflect.Descriptor instead.
+func (*PostStatus) Descriptor() ([]byte, []int) {
+	return file_nodepb_post_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PostStatus) GetState() PostSetupState {
+	if x != nil {
+		return x.State
+	}
+	return PostSetupState_POST_SETUP_STATE_UNSPECIFIED
+}
+
+func (x *PostStatus) GetNumLabelsWritten() uint64 {
+	if x != nil {
+		return x.NumLabelsWritten
+	}
+	return 0
+}
+
+func (x *PostStatus) GetOpts() *PostSetupOpts {
+	if x != nil {
+		return x.Opts
+	}
+	return nil
+}
+
+func (x *PostStatus) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type PostSetupOpts struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DataDir           string `protobuf:"bytes,1,opt,name=data_dir,json=dataDir,proto3" json:"data_dir,omitempty"`
+	NumUnits          uint32 `protobuf:"varint,2,opt,name=num_units,json=numUnits,proto3" json:"num_units,omitempty"`
+	NumFiles          uint32 `protobuf:"varint,3,opt,name=num_files,json=numFiles,proto3" json:"num_files,omitempty"`
+	ComputeProviderId int32  `protobuf:"varint,4,opt,name=compute_provider_id,json=computeProviderId,proto3" json:"compute_provider_id,omitempty"`
+	Throttle          bool   `protobuf:"varint,5,opt,name=throttle,proto3" json:"throttle,omitempty"`
+}
+
+func (x *PostSetupOpts) Reset() {
+	*x = PostSetupOpts{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nodepb_post_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PostSetupOpts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostSetupOpts) ProtoMessage() {}
+
+func (x *PostSetupOpts) ProtoReflect() protoreflect.Message {
+	mi := &file_nodepb_post_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostSetupOpts.ProtoReflect.Descriptor instead.
+func (*PostSetupOpts) Descriptor() ([]byte, []int) {
+	return file_nodepb_post_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PostSetupOpts) GetDataDir() string {
+	if x != nil {
+		return x.DataDir
+	}
+	return ""
+}
+
+func (x *PostSetupOpts) GetNumUnits() uint32 {
+	if x != nil {
+		return x.NumUnits
+	}
+	return 0
+}
+
+func (x *PostSetupOpts) GetNumFiles() uint32 {
+	if x != nil {
+		return x.NumFiles
+	}
+	return 0
+}
+
+func (x *PostSetupOpts) GetComputeProviderId() int32 {
+	if x != nil {
+		return x.ComputeProviderId
+	}
+	return 0
+}
+
+func (x *PostSetupOpts) GetThrottle() bool {
+	if x != nil {
+		return x.Throttle
+	}
+	return false
+}
+
+type GenerateProofRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Challenge     []byte `protobuf:"bytes,1,opt,name=challenge,proto3" json:"challenge,omitempty"`
+	CommitmentAtx []byte `protobuf:"bytes,2,opt,name=commitment_atx,json=commitmentAtx,proto3" json:"commitment_atx,omitempty"`
+}
+
+func (x *GenerateProofRequest) Reset() {
+	*x = GenerateProofRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nodepb_post_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GenerateProofRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateProofRequest) ProtoMessage() {}
+
+func (x *GenerateProofRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nodepb_post_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateProofRequest.ProtoReflect.Descriptor instead.
+func (*GenerateProofRequest) Descriptor() ([]byte, []int) {
+	return file_nodepb_post_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GenerateProofRequest) GetChallenge() []byte {
+	if x != nil {
+		return x.Challenge
+	}
+	return nil
+}
+
+func (x *GenerateProofRequest) GetCommitmentAtx() []byte {
+	if x != nil {
+		return x.CommitmentAtx
+	}
+	return nil
+}
+
+type GenerateProofResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Proof    *Proof         `protobuf:"bytes,1,opt,name=proof,proto3" json:"proof,omitempty"`
+	Metadata *ProofMetadata `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+}
+
+func (x *GenerateProofResponse) Reset() {
+	*x = GenerateProofResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nodepb_post_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GenerateProofResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateProofResponse) ProtoMessage() {}
+
+func (x *GenerateProofResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nodepb_post_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateProofResponse.ProtoReflect.Descriptor instead.
+func (*GenerateProofResponse) Descriptor() ([]byte, []int) {
+	return file_nodepb_post_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GenerateProofResponse) GetProof() *Proof {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+func (x *GenerateProofResponse) GetMetadata() *ProofMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type Proof struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Nonce   uint32 `protobuf:"varint,1,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Indices []byte `protobuf:"bytes,2,opt,name=indices,proto3" json:"indices,omitempty"`
+}
+
+func (x *Proof) Reset() {
+	*x = Proof{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nodepb_post_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Proof) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Proof) ProtoMessage() {}
+
+func (x *Proof) ProtoReflect() protoreflect.Message {
+	mi := &file_nodepb_post_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Proof.ProtoReflect.Descriptor instead.
+func (*Proof) Descriptor() ([]byte, []int) {
+	return file_nodepb_post_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Proof) GetNonce() uint32 {
+	if x != nil {
+		return x.Nonce
+	}
+	return 0
+}
+
+func (x *Proof) GetIndices() []byte {
+	if x != nil {
+		return x.Indices
+	}
+	return nil
+}
+
+type ProofMetadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Challenge     []byte `protobuf:"bytes,1,opt,name=challenge,proto3" json:"challenge,omitempty"`
+	BitsPerLabel  uint32 `protobuf:"varint,2,opt,name=bits_per_label,json=bitsPerLabel,proto3" json:"bits_per_label,omitempty"`
+	LabelsPerUnit uint64 `protobuf:"varint,3,opt,name=labels_per_unit,json=labelsPerUnit,proto3" json:"labels_per_unit,omitempty"`
+	K1            uint32 `protobuf:"varint,4,opt,name=k1,proto3" json:"k1,omitempty"`
+	K2            uint32 `protobuf:"varint,5,opt,name=k2,proto3" json:"k2,omitempty"`
+}
+
+func (x *ProofMetadata) Reset() {
+	*x = ProofMetadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nodepb_post_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProofMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProofMetadata) ProtoMessage() {}
+
+func (x *ProofMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_nodepb_post_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProofMetadata.ProtoReflect.Descriptor instead.
+func (*ProofMetadata) Descriptor() ([]byte, []int) {
+	return file_nodepb_post_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ProofMetadata) GetChallenge() []byte {
+	if x != nil {
+		return x.Challenge
+	}
+	return nil
+}
+
+func (x *ProofMetadata) GetBitsPerLabel() uint32 {
+	if x != nil {
+		return x.BitsPerLabel
+	}
+	return 0
+}
+
+func (x *ProofMetadata) GetLabelsPerUnit() uint64 {
+	if x != nil {
+		return x.LabelsPerUnit
+	}
+	return 0
+}
+
+func (x *ProofMetadata) GetK1() uint32 {
+	if x != nil {
+		return x.K1
+	}
+	return 0
+}
+
+func (x *ProofMetadata) GetK2() uint32 {
+	if x != nil {
+		return x.K2
+	}
+	return 0
+}
+
+var File_nodepb_post_proto protoreflect.FileDescriptor
+
+var file_nodepb_post_proto_rawDesc = []byte{
+	0x0a, 0x11, 0x6e, 0x6f, 0x64, 0x65, 0x70, 0x62, 0x2f, 0x70, 0x6f, 0x73, 0x74, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x11, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x6e,
+	0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x1a, 0x14, 0x6e, 0x6f, 0x64, 0x65, 0x70, 0x62, 0x2f, 0x73, 0x6d, 0x65, 0x73,
+	0x68, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x4a, 0x0a, 0x08, 0x50, 0x6f, 0x73,
+	0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x12, 0x25,
+	0x0a, 0x0e, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x74, 0x78,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65,
+	0x6e, 0x74, 0x41, 0x74, 0x78, 0x22, 0xce, 0x01, 0x0a, 0x0a, 0x50, 0x6f, 0x73, 0x74, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x37, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x21, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x2e,
+	0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x53, 0x65, 0x74, 0x75,
+	0x70, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x2c, 0x0a,
+	0x12, 0x6e, 0x75, 0x6d, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x5f, 0x77, 0x72, 0x69, 0x74,
+	0x74, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x6e, 0x75, 0x6d, 0x4c, 0x61,
+	0x62, 0x65, 0x6c, 0x73, 0x57, 0x72, 0x69, 0x74, 0x74, 0x65, 0x6e, 0x12, 0x34, 0x0a, 0x04, 0x6f,
+	0x70, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f,
+	0x73, 0x74, 0x53, 0x65, 0x74, 0x75, 0x70, 0x4f, 0x70, 0x74, 0x73, 0x52, 0x04, 0x6f, 0x70, 0x74,
+	0x73, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xb0, 0x01, 0x0a, 0x0d, 0x50, 0x6f, 0x73, 0x74, 0x53,
+	0x65, 0x74, 0x75, 0x70, 0x4f, 0x70, 0x74, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x61,
+	0x5f, 0x64, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x61, 0x74, 0x61,
+	0x44, 0x69, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x75, 0x6d, 0x5f, 0x75, 0x6e, 0x69, 0x74, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6e, 0x75, 0x6d, 0x55, 0x6e, 0x69, 0x74, 0x73,
+	0x12, 0x1b, 0x0a, 0x09, 0x6e, 0x75, 0x6d, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x08, 0x6e, 0x75, 0x6d, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x2e, 0x0a,
+	0x13, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x63, 0x6f, 0x6d, 0x70,
+	0x75, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a,
+	0x08, 0x74, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x74, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c, 0x65, 0x22, 0x5b, 0x0a, 0x14, 0x47, 0x65, 0x6e,
+	0x65, 0x72, 0x61, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x12,
+	0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x74,
+	0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d,
+	0x65, 0x6e, 0x74, 0x41, 0x74, 0x78, 0x22, 0x85, 0x01, 0x0a, 0x15, 0x47, 0x65, 0x6e, 0x65, 0x72,
+	0x61, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x2e, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x18, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x6e, 0x6f, 0x64, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66,
+	0x12, 0x3c, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x20, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x6e,
+	0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x37,
+	0x0a, 0x05, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07,
+	0x69, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x22, 0x9b, 0x01, 0x0a, 0x0d, 0x50, 0x72, 0x6f, 0x6f,
+	0x66, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x68, 0x61,
+	0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x63, 0x68,
+	0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x62, 0x69, 0x74, 0x73, 0x5f,
+	0x70, 0x65, 0x72, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0c, 0x62, 0x69, 0x74, 0x73, 0x50, 0x65, 0x72, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x26, 0x0a,
+	0x0f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x75, 0x6e, 0x69, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x50, 0x65,
+	0x72, 0x55, 0x6e, 0x69, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x6b, 0x31, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x02, 0x6b, 0x31, 0x12, 0x0e, 0x0a, 0x02, 0x6b, 0x32, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x02, 0x6b, 0x32, 0x32, 0xef, 0x01, 0x0a, 0x0b, 0x50, 0x6f, 0x73, 0x74, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3b, 0x0a, 0x04, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1b, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73,
+	0x68, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x49, 0x6e,
+	0x66, 0x6f, 0x12, 0x3f, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1d, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68,
+	0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x62, 0x0a, 0x0d, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x50,
+	0x72, 0x6f, 0x6f, 0x66, 0x12, 0x27, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68,
+	0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74,
+	0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x37, 0x5a, 0x35, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x6f,
+	0x73, 0x2f, 0x67, 0x6f, 0x2d, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x2f, 0x61,
+	0x70, 0x69, 0x2f, 0x6e, 0x6f, 0x64, 0x65, 0x70, 0x62, 0x3b, 0x6e, 0x6f, 0x64, 0x65, 0x70, 0x62,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_nodepb_post_proto_rawDescOnce sync.Once
+	file_nodepb_post_proto_rawDescData = file_nodepb_post_proto_rawDesc
+)
+
+func file_nodepb_post_proto_rawDescGZIP() []byte {
+	file_nodepb_post_proto_rawDescOnce.Do(func() {
+		file_nodepb_post_proto_rawDescData = protoimpl.X.CompressGZIP(file_nodepb_post_proto_rawDescData)
+	})
+	return file_nodepb_post_proto_rawDescData
+}
+
+var file_nodepb_post_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_nodepb_post_proto_goTypes = []interface{}{
+	(*PostInfo)(nil),              // 0: spacemesh.node.v1.PostInfo
+	(*PostStatus)(nil),            // 1: spacemesh.node.v1.PostStatus
+	(*PostSetupOpts)(nil),         // 2: spacemesh.node.v1.PostSetupOpts
+	(*GenerateProofRequest)(nil),  // 3: spacemesh.node.v1.GenerateProofRequest
+	(*GenerateProofResponse)(nil), // 4: spacemesh.node.v1.GenerateProofResponse
+	(*Proof)(nil),                 // 5: spacemesh.node.v1.Proof
+	(*ProofMetadata)(nil),         // 6: spacemesh.node.v1.ProofMetadata
+	(PostSetupState)(0),           // 7: spacemesh.node.v1.PostSetupState
+	(*emptypb.Empty)(nil),         // 8: google.protobuf.Empty
+}
+var file_nodepb_post_proto_depIdxs = []int32{
+	7, // 0: spacemesh.node.v1.PostStatus.state:type_name -> spacemesh.node.v1.PostSetupState
+	2, // 1: spacemesh.node.v1.PostStatus.opts:type_name -> spacemesh.node.v1.PostSetupOpts
+	5, // 2: spacemesh.node.v1.GenerateProofResponse.proof:type_name -> spacemesh.node.v1.Proof
+	6, // 3: spacemesh.node.v1.GenerateProofResponse.metadata:type_name -> spacemesh.node.v1.ProofMetadata
+	8, // 4: spacemesh.node.v1.PostService.Info:input_type -> google.protobuf.Empty
+	8, // 5: spacemesh.node.v1.PostService.Status:input_type -> google.protobuf.Empty
+	3, // 6: spacemesh.node.v1.PostService.GenerateProof:input_type -> spacemesh.node.v1.GenerateProofRequest
+	0, // 7: spacemesh.node.v1.PostService.Info:output_type -> spacemesh.node.v1.PostInfo
+	1, // 8: spacemesh.node.v1.PostService.Status:output_type -> spacemesh.node.v1.PostStatus
+	4, // 9: spacemesh.node.v1.PostService.GenerateProof:output_type -> spacemesh.node.v1.GenerateProofResponse
+	7, // [7:10] is the sub-list for method output_type
+	4, // [4:7] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_nodepb_post_proto_init() }
+func file_nodepb_post_proto_init() {
+	if File_nodepb_post_proto != nil {
+		return
+	}
+	file_nodepb_smesher_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_nodepb_post_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PostInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nodepb_post_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PostStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nodepb_post_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PostSetupOpts); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nodepb_post_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GenerateProofRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nodepb_post_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GenerateProofResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nodepb_post_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Proof); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nodepb_post_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProofMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_nodepb_post_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_nodepb_post_proto_goTypes,
+		DependencyIndexes: file_nodepb_post_proto_depIdxs,
+		MessageInfos:      file_nodepb_post_proto_msgTypes,
+	}.Build()
+	File_nodepb_post_proto = out.File
+	file_nodepb_post_proto_rawDesc = nil
+	file_nodepb_post_proto_goTypes = nil
+	file_nodepb_post_proto_depIdxs = nil
+}
@@ -0,0 +1,1324 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.5
+// source: nodepb/smesher.proto
+
+package nodepb
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PostSetupState int32
+
+const (
+	PostSetupState_POST_SETUP_STATE_UNSPECIFIED PostSetupState = 0
+	PostSetupState_POST_SETUP_STATE_NOT_STARTED PostSetupState = 1
+	PostSetupState_POST_SETUP_STATE_IN_PROGRESS PostSetupState = 2
+	PostSetupState_POST_SETUP_STATE_COMPLETE    PostSetupState = 3
+	PostSetupState_POST_SETUP_STATE_ERROR       PostSetupState = 4
+)
+
+// Enum value maps for PostSetupState.
+var (
+	PostSetupState_name = map[int32]string{
+		0: "POST_SETUP_STATE_UNSPECIFIED",
+		1: "POST_SETUP_STATE_NOT_STARTED",
+		2: "POST_SETUP_STATE_IN_PROGRESS",
+		3: "POST_SETUP_STATE_COMPLETE",
+		4: "POST_SETUP_STATE_ERROR",
+	}
+	PostSetupState_value = map[string]int32{
+		"POST_SETUP_STATE_UNSPECIFIED": 0,
+		"POST_SETUP_STATE_NOT_STARTED": 1,
+		"POST_SETUP_STATE_IN_PROGRESS": 2,
+		"POST_SETUP_STATE_COMPLETE":    3,
+		"POST_SETUP_STATE_ERROR":       4,
+	}
+)
+
+func (x PostSetupState) Enum() *PostSetupState {
+	p := new(PostSetupState)
+	*p = x
+	return p
+}
+
+func (x PostSetupState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PostSetupState) Descriptor() protoreflect.EnumDescriptor {
+	return file_nodepb_smesher_proto_enumTypes[0].Descriptor()
+}
+
+func (PostSetupState) Type() protoreflect.EnumType {
+	return &file_nodepb_smesher_proto_enumTypes[0]
+}
+
+func (x PostSetupState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PostSetupState.Descriptor instead.
+func (PostSetupState) EnumDescriptor() ([]byte, []int) {
+	return file_nodepb_smesher_proto_rawDescGZIP(), []int{0}
+}
+
+type AtxPhase int32
+
+const (
+	AtxPhase_ATX_PHASE_UNSPECIFIED AtxPhase = 0
+	// ATX_PHASE_CHALLENGE_BUILT is completed when the nipost challenge is ready.
+	AtxPhase_ATX_PHASE_CHALLENGE_BUILT AtxPhase = 1
+	// ATX_PHASE_POET_SUBMITTED is completed when the challenge is registered with a poet service.
+	AtxPhase_ATX_PHASE_POET_SUBMITTED AtxPhase = 2
+	// ATX_PHASE_POET_PROOF_RECEIVED is completed when a poet proof that includes the challenge is received.
+	AtxPhase_ATX_PHASE_POET_PROOF_RECEIVED AtxPhase = 3
+	// ATX_PHASE_POST_PROOF_GENERATED is completed when the post proof for the poet proof is generated.
+	AtxPhase_ATX_PHASE_POST_PROOF_GENERATED AtxPhase = 4
+	// ATX_PHASE_PUBLISHED is completed when the atx is published.
+	AtxPhase_ATX_PHASE_PUBLISHED AtxPhase = 5
+)
+
+// Enum value maps for AtxPhase.
+var (
+	AtxPhase_name = map[int32]string{
+		0: "ATX_PHASE_UNSPECIFIED",
+		1: "ATX_PHASE_CHALLENGE_BUILT",
+		2: "ATX_PHASE_POET_SUBMITTED",
+		3: "ATX_PHASE_POET_PROOF_RECEIVED",
+		4: "ATX_PHASE_POST_PROOF_GENERATED",
+		5: "ATX_PHASE_PUBLISHED",
+	}
+	AtxPhase_value = map[string]int32{
+		"ATX_PHASE_UNSPECIFIED":          0,
+		"ATX_PHASE_CHALLENGE_BUILT":      1,
+		"ATX_PHASE_POET_SUBMITTED":       2,
+		"ATX_PHASE_POET_PROOF_RECEIVED":  3,
+		"ATX_PHASE_POST_PROOF_GENERATED": 4,
+		"ATX_PHASE_PUBLISHED":            5,
+	}
+)
+
+func (x AtxPhase) Enum() *AtxPhase {
+	p := new(AtxPhase)
+	*p = x
+	return p
+}
+
+func (x AtxPhase) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AtxPhase) Descriptor() protoreflect.EnumDescriptor {
+	return file_nodepb_smesher_proto_enumTypes[1].Descriptor()
+}
+
+func (AtxPhase) Type() protoreflect.EnumType {
+	return &file_nodepb_smesher_proto_enumTypes[1]
+}
+
+func (x AtxPhase) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AtxPhase.Descriptor instead.
+func (AtxPhase) EnumDescriptor() ([]byte, []int) {
+	return file_nodepb_smesher_proto_rawDescGZIP(), []int{1}
+}
+
+type IdentityRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// id of the identity. methods that support it use the node identity if id is empty.
+	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *IdentityRequest) Reset() {
+	*x = IdentityRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nodepb_smesher_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IdentityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IdentityRequest) ProtoMessage() {}
+
+func (x *IdentityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nodepb_smesher_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IdentityRequest.ProtoReflect.Descriptor instead.
+func (*IdentityRequest) Descriptor() ([]byte, []int) {
+	return file_nodepb_smesher_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *IdentityRequest) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+type IdentitiesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Identities []*SmesherIdentity `protobuf:"bytes,1,rep,name=identities,proto3" json:"identities,omitempty"`
+}
+
+func (x *IdentitiesResponse) Reset() {
+	*x = IdentitiesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nodepb_smesher_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IdentitiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IdentitiesResponse) ProtoMessage() {}
+
+func (x *IdentitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nodepb_smesher_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IdentitiesResponse.ProtoReflect.Descriptor instead.
+func (*IdentitiesResponse) Descriptor() ([]byte, []int) {
+	return file_nodepb_smesher_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *IdentitiesResponse) GetIdentities() []*SmesherIdentity {
+	if x != nil {
+		return x.Identities
+	}
+	return nil
+}
+
+type SmesherIdentity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id       []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Smeshing bool   `protobuf:"varint,2,opt,name=smeshing,proto3" json:"smeshing,omitempty"`
+	// coinbase is the bech32 encoded account that accumulates rewards of the identity.
+	Coinbase         string         `protobuf:"bytes,3,opt,name=coinbase,proto3" json:"coinbase,omitempty"`
+	DataDir          string         `protobuf:"bytes,4,opt,name=data_dir,json=dataDir,proto3" json:"data_dir,omitempty"`
+	PostState        PostSetupState `protobuf:"varint,5,opt,name=post_state,json=postState,proto3,enum=spacemesh.node.v1.PostSetupState" json:"post_state,omitempty"`
+	NumLabelsWritten uint64         `protobuf:"varint,6,opt,name=num_labels_written,json=numLabelsWritten,proto3" json:"num_labels_written,omitempty"`
+	ErrorMessage     string         `protobuf:"bytes,7,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+}
+
+func (x *SmesherIdentity) Reset() {
+	*x = SmesherIdentity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nodepb_smesher_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SmesherIdentity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SmesherIdentity) ProtoMessage() {}
+
+func (x *SmesherIdentity) ProtoReflect() protoreflect.Message {
+	mi := &file_nodepb_smesher_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SmesherIdentity.ProtoReflect.Descriptor instead.
+func (*SmesherIdentity) Descriptor() ([]byte, []int) {
+	return file_nodepb_smesher_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SmesherIdentity) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *SmesherIdentity) GetSmeshing() bool {
+	if x != nil {
+		return x.Smeshing
+	}
+	return false
+}
+
+func (x *SmesherIdentity) GetCoinbase() string {
+	if x != nil {
+		return x.Coinbase
+	}
+	return ""
+}
+
+func (x *SmesherIdentity) GetDataDir() string {
+	if x != nil {
+		return x.DataDir
+	}
+	return ""
+}
+
+func (x *SmesherIdentity) GetPostState() PostSetupState {
+	if x != nil {
+		return x.PostState
+	}
+	return PostSetupState_POST_SETUP_STATE_UNSPECIFIED
+}
+
+func (x *SmesherIdentity) GetNumLabelsWritten() uint64 {
+	if x != nil {
+		return x.NumLabelsWritten
+	}
+	return 0
+}
+
+func (x *SmesherIdentity) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type StartIdentitySmeshingRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// coinbase is the bech32 encoded account that accumulates rewards of the identity.
+	Coinbase string `protobuf:"bytes,2,opt,name=coinbase,proto3" json:"coinbase,omitempty"`
+}
+
+func (x *StartIdentitySmeshingRequest) Reset() {
+	*x = StartIdentitySmeshingRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nodepb_smesher_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StartIdentitySmeshingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartIdentitySmeshingRequest) ProtoMessage() {}
+
+func (x *StartIdentitySmeshingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nodepb_smesher_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartIdentitySmeshingRequest.ProtoReflect.Descriptor instead.
+func (*StartIdentitySmeshingRequest) Descriptor() ([]byte, []int) {
+	return file_nodepb_smesher_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StartIdentitySmeshingRequest) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *StartIdentitySmeshingRequest) GetCoinbase() string {
+	if x != nil {
+		return x.Coinbase
+	}
+	return ""
+}
+
+type StopIdentitySmeshingRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// delete_files deletes post data of the identity.
+	DeleteFiles bool `protobuf:"varint,2,opt,name=delete_files,json=deleteFiles,proto3" json:"delete_files,omitempty"`
+}
+
+func (x *StopIdentitySmeshingRequest) Reset() {
+	*x = StopIdentitySmeshingRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nodepb_smesher_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StopIdentitySmeshingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopIdentitySmeshingRequest) ProtoMessage() {}
+
+func (x *StopIdentitySmeshingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nodepb_smesher_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopIdentitySmeshingRequest.ProtoReflect.Descriptor instead.
+func (*StopIdentitySmeshingRequest) Descriptor() ([]byte, []int) {
+	return file_nodepb_smesher_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *StopIdentitySmeshingRequest) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *StopIdentitySmeshingRequest) GetDeleteFiles() bool {
+	if x != nil {
+		return x.DeleteFiles
+	}
+	return false
+}
+
+type VerifyPostDataRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// fraction of the labels in (0, 1] that is sampled. all labels are verified if not set.
+	Fraction float64 `protobuf:"fixed64,2,opt,name=fraction,proto3" json:"fraction,omitempty"`
+	// repair regenerates labels that were found to be corrupted.
+	Repair bool `protobuf:"varint,3,opt,name=repair,proto3" json:"repair,omitempty"`
+}
+
+func (x *VerifyPostDataRequest) Reset() {
+	*x = VerifyPostDataRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nodepb_smesher_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyPostDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyPostDataRequest) ProtoMessage() {}
+
+func (x *VerifyPostDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nodepb_smesher_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyPostDataRequest.ProtoReflect.Descriptor instead.
+func (*VerifyPostDataRequest) Descriptor() ([]byte, []int) {
+	return file_nodepb_smesher_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *VerifyPostDataRequest) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *VerifyPostDataRequest) GetFraction() float64 {
+	if x != nil {
+		return x.Fraction
+	}
+	return 0
+}
+
+func (x *VerifyPostDataRequest) GetRepair() bool {
+	if x != nil {
+		return x.Repair
+	}
+	return false
+}
+
+// LabelRange is a range of labels of the post data, end is exclusive.
+type LabelRange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Start uint64 `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	End   uint64 `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+}
+
+func (x *LabelRange) Reset() {
+	*x = LabelRange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nodepb_smesher_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LabelRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LabelRange) ProtoMessage() {}
+
+func (x *LabelRange) ProtoReflect() protoreflect.Message {
+	mi := &file_nodepb_smesher_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LabelRange.ProtoReflect.Descriptor instead.
+func (*LabelRange) Descriptor() ([]byte, []int) {
+	return file_nodepb_smesher_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *LabelRange) GetStart() uint64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *LabelRange) GetEnd() uint64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+type PostVerifyStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LabelsToVerify  uint64        `protobuf:"varint,1,opt,name=labels_to_verify,json=labelsToVerify,proto3" json:"labels_to_verify,omitempty"`
+	LabelsVerified  uint64        `protobuf:"varint,2,opt,name=labels_verified,json=labelsVerified,proto3" json:"labels_verified,omitempty"`
+	LabelsCorrupted uint64        `protobuf:"varint,3,opt,name=labels_corrupted,json=labelsCorrupted,proto3" json:"labels_corrupted,omitempty"`
+	LabelsRepaired  uint64        `protobuf:"varint,4,opt,name=labels_repaired,json=labelsRepaired,proto3" json:"labels_repaired,omitempty"`
+	CorruptedRanges []*LabelRange `protobuf:"bytes,5,rep,name=corrupted_ranges,json=corruptedRanges,proto3" json:"corrupted_ranges,omitempty"`
+	Done            bool          `protobuf:"varint,6,opt,name=done,proto3" json:"done,omitempty"`
+	ErrorMessage    string        `protobuf:"bytes,7,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+}
+
+func (x *PostVerifyStatus) Reset() {
+	*x = PostVerifyStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nodepb_smesher_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PostVerifyStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostVerifyStatus) ProtoMessage() {}
+
+func (x *PostVerifyStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_nodepb_smesher_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostVerifyStatus.ProtoReflect.Descriptor instead.
+func (*PostVerifyStatus) Descriptor() ([]byte, []int) {
+	return file_nodepb_smesher_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PostVerifyStatus) GetLabelsToVerify() uint64 {
+	if x != nil {
+		return x.LabelsToVerify
+	}
+	return 0
+}
+
+func (x *PostVerifyStatus) GetLabelsVerified() uint64 {
+	if x != nil {
+		return x.LabelsVerified
+	}
+	return 0
+}
+
+func (x *PostVerifyStatus) GetLabelsCorrupted() uint64 {
+	if x != nil {
+		return x.LabelsCorrupted
+	}
+	return 0
+}
+
+func (x *PostVerifyStatus) GetLabelsRepaired() uint64 {
+	if x != nil {
+		return x.LabelsRepaired
+	}
+	return 0
+}
+
+func (x *PostVerifyStatus) GetCorruptedRanges() []*LabelRange {
+	if x != nil {
+		return x.CorruptedRanges
+	}
+	return nil
+}
+
+func (x *PostVerifyStatus) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+func (x *PostVerifyStatus) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type AtxPhaseStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Phase AtxPhase `protobuf:"varint,1,opt,name=phase,proto3,enum=spacemesh.node.v1.AtxPhase" json:"phase,omitempty"`
+	// deadline is not set if the phase doesn't have one.
+	Deadline *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	// completed is not set if the phase is not completed.
+	Completed *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=completed,proto3" json:"completed,omitempty"`
+	// slack is the time left until the deadline, or the time that was left when the phase was completed.
+	// it is negative if the deadline was missed.
+	Slack *durationpb.Duration `protobuf:"bytes,4,opt,name=slack,proto3" json:"slack,omitempty"`
+}
+
+func (x *AtxPhaseStatus) Reset() {
+	*x = AtxPhaseStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nodepb_smesher_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AtxPhaseStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AtxPhaseStatus) ProtoMessage() {}
+
+func (x *AtxPhaseStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_nodepb_smesher_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AtxPhaseStatus.ProtoReflect.Descriptor instead.
+func (*AtxPhaseStatus) Descriptor() ([]byte, []int) {
+	return file_nodepb_smesher_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AtxPhaseStatus) GetPhase() AtxPhase {
+	if x != nil {
+		return x.Phase
+	}
+	return AtxPhase_ATX_PHASE_UNSPECIFIED
+}
+
+func (x *AtxPhaseStatus) GetDeadline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Deadline
+	}
+	return nil
+}
+
+func (x *AtxPhaseStatus) GetCompleted() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Completed
+	}
+	return nil
+}
+
+func (x *AtxPhaseStatus) GetSlack() *durationpb.Duration {
+	if x != nil {
+		return x.Slack
+	}
+	return nil
+}
+
+type AtxDeadlinesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PublishEpoch uint32            `protobuf:"varint,1,opt,name=publish_epoch,json=publishEpoch,proto3" json:"publish_epoch,omitempty"`
+	Phases       []*AtxPhaseStatus `protobuf:"bytes,2,rep,name=phases,proto3" json:"phases,omitempty"`
+	// at_risk is set if a pending phase is close to its deadline, or missed it.
+	AtRisk bool `protobuf:"varint,3,opt,name=at_risk,json=atRisk,proto3" json:"at_risk,omitempty"`
+}
+
+func (x *AtxDeadlinesResponse) Reset() {
+	*x = AtxDeadlinesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nodepb_smesher_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AtxDeadlinesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AtxDeadlinesResponse) ProtoMessage() {}
+
+func (x *AtxDeadlinesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nodepb_smesher_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AtxDeadlinesResponse.ProtoReflect.Descriptor instead.
+func (*AtxDeadlinesResponse) Descriptor() ([]byte, []int) {
+	return file_nodepb_smesher_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *AtxDeadlinesResponse) GetPublishEpoch() uint32 {
+	if x != nil {
+		return x.PublishEpoch
+	}
+	return 0
+}
+
+func (x *AtxDeadlinesResponse) GetPhases() []*AtxPhaseStatus {
+	if x != nil {
+		return x.Phases
+	}
+	return nil
+}
+
+func (x *AtxDeadlinesResponse) GetAtRisk() bool {
+	if x != nil {
+		return x.AtRisk
+	}
+	return false
+}
+
+type AtxPhaseEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Smesher      []byte                 `protobuf:"bytes,1,opt,name=smesher,proto3" json:"smesher,omitempty"`
+	PublishEpoch uint32                 `protobuf:"varint,2,opt,name=publish_epoch,json=publishEpoch,proto3" json:"publish_epoch,omitempty"`
+	Phase        AtxPhase               `protobuf:"varint,3,opt,name=phase,proto3,enum=spacemesh.node.v1.AtxPhase" json:"phase,omitempty"`
+	Deadline     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	Completed    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=completed,proto3" json:"completed,omitempty"`
+	// slack is the time that was left until the deadline, negative if the deadline was missed.
+	Slack *durationpb.Duration `protobuf:"bytes,6,opt,name=slack,proto3" json:"slack,omitempty"`
+}
+
+func (x *AtxPhaseEvent) Reset() {
+	*x = AtxPhaseEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nodepb_smesher_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AtxPhaseEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AtxPhaseEvent) ProtoMessage() {}
+
+func (x *AtxPhaseEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_nodepb_smesher_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AtxPhaseEvent.ProtoReflect.Descriptor instead.
+func (*AtxPhaseEvent) Descriptor() ([]byte, []int) {
+	return file_nodepb_smesher_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AtxPhaseEvent) GetSmesher() []byte {
+	if x != nil {
+		return x.Smesher
+	}
+	return nil
+}
+
+func (x *AtxPhaseEvent) GetPublishEpoch() uint32 {
+	if x != nil {
+		return x.PublishEpoch
+	}
+	return 0
+}
+
+func (x *AtxPhaseEvent) GetPhase() AtxPhase {
+	if x != nil {
+		return x.Phase
+	}
+	return AtxPhase_ATX_PHASE_UNSPECIFIED
+}
+
+func (x *AtxPhaseEvent) GetDeadline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Deadline
+	}
+	return nil
+}
+
+func (x *AtxPhaseEvent) GetCompleted() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Completed
+	}
+	return nil
+}
+
+func (x *AtxPhaseEvent) GetSlack() *durationpb.Duration {
+	if x != nil {
+		return x.Slack
+	}
+	return nil
+}
+
+var File_nodepb_smesher_proto protoreflect.FileDescriptor
+
+var file_nodepb_smesher_proto_rawDesc = []byte{
+	0x0a, 0x14, 0x6e, 0x6f, 0x64, 0x65, 0x70, 0x62, 0x2f, 0x73, 0x6d, 0x65, 0x73, 0x68, 0x65, 0x72,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x11, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73,
+	0x68, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x21, 0x0a, 0x0f, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x22, 0x58, 0x0a, 0x12, 0x49, 0x64, 0x65, 0x6e,
+	0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42,
+	0x0a, 0x0a, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x22, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x6e,
+	0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6d, 0x65, 0x73, 0x68, 0x65, 0x72, 0x49, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x0a, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69,
+	0x65, 0x73, 0x22, 0x89, 0x02, 0x0a, 0x0f, 0x53, 0x6d, 0x65, 0x73, 0x68, 0x65, 0x72, 0x49, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x6d, 0x65, 0x73, 0x68, 0x69,
+	0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x73, 0x6d, 0x65, 0x73, 0x68, 0x69,
+	0x6e, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x69, 0x6e, 0x62, 0x61, 0x73, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6f, 0x69, 0x6e, 0x62, 0x61, 0x73, 0x65, 0x12, 0x19,
+	0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x64, 0x69, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x64, 0x61, 0x74, 0x61, 0x44, 0x69, 0x72, 0x12, 0x40, 0x0a, 0x0a, 0x70, 0x6f, 0x73,
+	0x74, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x21, 0x2e,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x53, 0x65, 0x74, 0x75, 0x70, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x52, 0x09, 0x70, 0x6f, 0x73, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x6e,
+	0x75, 0x6d, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x5f, 0x77, 0x72, 0x69, 0x74, 0x74, 0x65,
+	0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x6e, 0x75, 0x6d, 0x4c, 0x61, 0x62, 0x65,
+	0x6c, 0x73, 0x57, 0x72, 0x69, 0x74, 0x74, 0x65, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x4a,
+	0x0a, 0x1c, 0x53, 0x74, 0x61, 0x72, 0x74, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x53,
+	0x6d, 0x65, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1a,
+	0x0a, 0x08, 0x63, 0x6f, 0x69, 0x6e, 0x62, 0x61, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x63, 0x6f, 0x69, 0x6e, 0x62, 0x61, 0x73, 0x65, 0x22, 0x50, 0x0a, 0x1b, 0x53, 0x74,
+	0x6f, 0x70, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x53, 0x6d, 0x65, 0x73, 0x68, 0x69,
+	0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0b, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x22, 0x5b, 0x0a, 0x15,
+	0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x50, 0x6f, 0x73, 0x74, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x66, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x70, 0x61, 0x69, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x06, 0x72, 0x65, 0x70, 0x61, 0x69, 0x72, 0x22, 0x34, 0x0a, 0x0a, 0x4c, 0x61, 0x62,
+	0x65, 0x6c, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a,
+	0x03, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x22,
+	0xbc, 0x02, 0x0a, 0x10, 0x50, 0x6f, 0x73, 0x74, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x5f, 0x74,
+	0x6f, 0x5f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e,
+	0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x54, 0x6f, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x12, 0x27,
+	0x0a, 0x0f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x5f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x56,
+	0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x6c, 0x61, 0x62, 0x65, 0x6c,
+	0x73, 0x5f, 0x63, 0x6f, 0x72, 0x72, 0x75, 0x70, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x43, 0x6f, 0x72, 0x72, 0x75, 0x70, 0x74,
+	0x65, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x5f, 0x72, 0x65, 0x70,
+	0x61, 0x69, 0x72, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x6c, 0x61, 0x62,
+	0x65, 0x6c, 0x73, 0x52, 0x65, 0x70, 0x61, 0x69, 0x72, 0x65, 0x64, 0x12, 0x48, 0x0a, 0x10, 0x63,
+	0x6f, 0x72, 0x72, 0x75, 0x70, 0x74, 0x65, 0x64, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73,
+	0x68, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x52,
+	0x61, 0x6e, 0x67, 0x65, 0x52, 0x0f, 0x63, 0x6f, 0x72, 0x72, 0x75, 0x70, 0x74, 0x65, 0x64, 0x52,
+	0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x6f, 0x6e, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x04, 0x64, 0x6f, 0x6e, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xe6,
+	0x01, 0x0a, 0x0e, 0x41, 0x74, 0x78, 0x50, 0x68, 0x61, 0x73, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x31, 0x0a, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x1b, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x6e, 0x6f, 0x64,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x74, 0x78, 0x50, 0x68, 0x61, 0x73, 0x65, 0x52, 0x05, 0x70,
+	0x68, 0x61, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x38, 0x0a, 0x09,
+	0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x6f, 0x6d,
+	0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x12, 0x2f, 0x0a, 0x05, 0x73, 0x6c, 0x61, 0x63, 0x6b, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x05, 0x73, 0x6c, 0x61, 0x63, 0x6b, 0x22, 0x8f, 0x01, 0x0a, 0x14, 0x41, 0x74, 0x78, 0x44,
+	0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x23, 0x0a, 0x0d, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x5f, 0x65, 0x70, 0x6f, 0x63,
+	0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68,
+	0x45, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x39, 0x0a, 0x06, 0x70, 0x68, 0x61, 0x73, 0x65, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73,
+	0x68, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x74, 0x78, 0x50, 0x68, 0x61,
+	0x73, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x70, 0x68, 0x61, 0x73, 0x65, 0x73,
+	0x12, 0x17, 0x0a, 0x07, 0x61, 0x74, 0x5f, 0x72, 0x69, 0x73, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x06, 0x61, 0x74, 0x52, 0x69, 0x73, 0x6b, 0x22, 0xa4, 0x02, 0x0a, 0x0d, 0x41, 0x74,
+	0x78, 0x50, 0x68, 0x61, 0x73, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x73,
+	0x6d, 0x65, 0x73, 0x68, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x73, 0x6d,
+	0x65, 0x73, 0x68, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68,
+	0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x73, 0x68, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x31, 0x0a, 0x05, 0x70, 0x68,
+	0x61, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x74,
+	0x78, 0x50, 0x68, 0x61, 0x73, 0x65, 0x52, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65, 0x12, 0x36, 0x0a,
+	0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x64, 0x65, 0x61,
+	0x64, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74,
+	0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x12,
+	0x2f, 0x0a, 0x05, 0x73, 0x6c, 0x61, 0x63, 0x6b, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x73, 0x6c, 0x61, 0x63, 0x6b,
+	0x2a, 0xb1, 0x01, 0x0a, 0x0e, 0x50, 0x6f, 0x73, 0x74, 0x53, 0x65, 0x74, 0x75, 0x70, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x12, 0x20, 0x0a, 0x1c, 0x50, 0x4f, 0x53, 0x54, 0x5f, 0x53, 0x45, 0x54, 0x55,
+	0x50, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
+	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x20, 0x0a, 0x1c, 0x50, 0x4f, 0x53, 0x54, 0x5f, 0x53, 0x45,
+	0x54, 0x55, 0x50, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x53, 0x54,
+	0x41, 0x52, 0x54, 0x45, 0x44, 0x10, 0x01, 0x12, 0x20, 0x0a, 0x1c, 0x50, 0x4f, 0x53, 0x54, 0x5f,
+	0x53, 0x45, 0x54, 0x55, 0x50, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x49, 0x4e, 0x5f, 0x50,
+	0x52, 0x4f, 0x47, 0x52, 0x45, 0x53, 0x53, 0x10, 0x02, 0x12, 0x1d, 0x0a, 0x19, 0x50, 0x4f, 0x53,
+	0x54, 0x5f, 0x53, 0x45, 0x54, 0x55, 0x50, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x43, 0x4f,
+	0x4d, 0x50, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x03, 0x12, 0x1a, 0x0a, 0x16, 0x50, 0x4f, 0x53, 0x54,
+	0x5f, 0x53, 0x45, 0x54, 0x55, 0x50, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x45, 0x52, 0x52,
+	0x4f, 0x52, 0x10, 0x04, 0x2a, 0xc2, 0x01, 0x0a, 0x08, 0x41, 0x74, 0x78, 0x50, 0x68, 0x61, 0x73,
+	0x65, 0x12, 0x19, 0x0a, 0x15, 0x41, 0x54, 0x58, 0x5f, 0x50, 0x48, 0x41, 0x53, 0x45, 0x5f, 0x55,
+	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1d, 0x0a, 0x19,
+	0x41, 0x54, 0x58, 0x5f, 0x50, 0x48, 0x41, 0x53, 0x45, 0x5f, 0x43, 0x48, 0x41, 0x4c, 0x4c, 0x45,
+	0x4e, 0x47, 0x45, 0x5f, 0x42, 0x55, 0x49, 0x4c, 0x54, 0x10, 0x01, 0x12, 0x1c, 0x0a, 0x18, 0x41,
+	0x54, 0x58, 0x5f, 0x50, 0x48, 0x41, 0x53, 0x45, 0x5f, 0x50, 0x4f, 0x45, 0x54, 0x5f, 0x53, 0x55,
+	0x42, 0x4d, 0x49, 0x54, 0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x21, 0x0a, 0x1d, 0x41, 0x54, 0x58,
+	0x5f, 0x50, 0x48, 0x41, 0x53, 0x45, 0x5f, 0x50, 0x4f, 0x45, 0x54, 0x5f, 0x50, 0x52, 0x4f, 0x4f,
+	0x46, 0x5f, 0x52, 0x45, 0x43, 0x45, 0x49, 0x56, 0x45, 0x44, 0x10, 0x03, 0x12, 0x22, 0x0a, 0x1e,
+	0x41, 0x54, 0x58, 0x5f, 0x50, 0x48, 0x41, 0x53, 0x45, 0x5f, 0x50, 0x4f, 0x53, 0x54, 0x5f, 0x50,
+	0x52, 0x4f, 0x4f, 0x46, 0x5f, 0x47, 0x45, 0x4e, 0x45, 0x52, 0x41, 0x54, 0x45, 0x44, 0x10, 0x04,
+	0x12, 0x17, 0x0a, 0x13, 0x41, 0x54, 0x58, 0x5f, 0x50, 0x48, 0x41, 0x53, 0x45, 0x5f, 0x50, 0x55,
+	0x42, 0x4c, 0x49, 0x53, 0x48, 0x45, 0x44, 0x10, 0x05, 0x32, 0xbe, 0x07, 0x0a, 0x16, 0x53, 0x6d,
+	0x65, 0x73, 0x68, 0x65, 0x72, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x6e, 0x0a, 0x0a, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69,
+	0x65, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x25, 0x2e, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x49,
+	0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x21, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1b, 0x3a, 0x01, 0x2a, 0x22, 0x16, 0x2f, 0x76,
+	0x31, 0x2f, 0x73, 0x6d, 0x65, 0x73, 0x68, 0x65, 0x72, 0x2f, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69,
+	0x74, 0x69, 0x65, 0x73, 0x12, 0x91, 0x01, 0x0a, 0x17, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x50, 0x6f, 0x73, 0x74, 0x53, 0x65, 0x74, 0x75, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x22, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x6e, 0x6f, 0x64,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68,
+	0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6d, 0x65, 0x73, 0x68, 0x65, 0x72,
+	0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x22, 0x2e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x28,
+	0x3a, 0x01, 0x2a, 0x22, 0x23, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x6d, 0x65, 0x73, 0x68, 0x65, 0x72,
+	0x2f, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x70, 0x6f, 0x73, 0x74, 0x73, 0x65, 0x74,
+	0x75, 0x70, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x8e, 0x01, 0x0a, 0x15, 0x53, 0x74, 0x61,
+	0x72, 0x74, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x53, 0x6d, 0x65, 0x73, 0x68, 0x69,
+	0x6e, 0x67, 0x12, 0x2f, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x6e,
+	0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x49, 0x64, 0x65, 0x6e,
+	0x74, 0x69, 0x74, 0x79, 0x53, 0x6d, 0x65, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x2c, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x26, 0x3a, 0x01, 0x2a, 0x22, 0x21, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x6d, 0x65, 0x73,
+	0x68, 0x65, 0x72, 0x2f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x73, 0x6d, 0x65, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x12, 0x8b, 0x01, 0x0a, 0x14, 0x53, 0x74,
+	0x6f, 0x70, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x53, 0x6d, 0x65, 0x73, 0x68, 0x69,
+	0x6e, 0x67, 0x12, 0x2e, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x6e,
+	0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x49, 0x64, 0x65, 0x6e, 0x74,
+	0x69, 0x74, 0x79, 0x53, 0x6d, 0x65, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x2b, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x25, 0x3a, 0x01, 0x2a, 0x22, 0x20, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x6d, 0x65, 0x73, 0x68,
+	0x65, 0x72, 0x2f, 0x73, 0x74, 0x6f, 0x70, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x73,
+	0x6d, 0x65, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x12, 0x88, 0x01, 0x0a, 0x0e, 0x56, 0x65, 0x72, 0x69,
+	0x66, 0x79, 0x50, 0x6f, 0x73, 0x74, 0x44, 0x61, 0x74, 0x61, 0x12, 0x28, 0x2e, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56,
+	0x65, 0x72, 0x69, 0x66, 0x79, 0x50, 0x6f, 0x73, 0x74, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68,
+	0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x56, 0x65, 0x72,
+	0x69, 0x66, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x25, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x1f, 0x3a, 0x01, 0x2a, 0x22, 0x1a, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x6d, 0x65, 0x73, 0x68, 0x65,
+	0x72, 0x2f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x70, 0x6f, 0x73, 0x74, 0x64, 0x61, 0x74, 0x61,
+	0x30, 0x01, 0x12, 0x80, 0x01, 0x0a, 0x0c, 0x41, 0x74, 0x78, 0x44, 0x65, 0x61, 0x64, 0x6c, 0x69,
+	0x6e, 0x65, 0x73, 0x12, 0x22, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x2e,
+	0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d,
+	0x65, 0x73, 0x68, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x74, 0x78, 0x44,
+	0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x23, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1d, 0x3a, 0x01, 0x2a, 0x22, 0x18, 0x2f, 0x76, 0x31,
+	0x2f, 0x73, 0x6d, 0x65, 0x73, 0x68, 0x65, 0x72, 0x2f, 0x61, 0x74, 0x78, 0x64, 0x65, 0x61, 0x64,
+	0x6c, 0x69, 0x6e, 0x65, 0x73, 0x12, 0x73, 0x0a, 0x0e, 0x41, 0x74, 0x78, 0x50, 0x68, 0x61, 0x73,
+	0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
+	0x20, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x6e, 0x6f, 0x64, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x41, 0x74, 0x78, 0x50, 0x68, 0x61, 0x73, 0x65, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x22, 0x25, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1f, 0x3a, 0x01, 0x2a, 0x22, 0x1a, 0x2f, 0x76,
+	0x31, 0x2f, 0x73, 0x6d, 0x65, 0x73, 0x68, 0x65, 0x72, 0x2f, 0x61, 0x74, 0x78, 0x70, 0x68, 0x61,
+	0x73, 0x65, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x30, 0x01, 0x42, 0x37, 0x5a, 0x35, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65,
+	0x73, 0x68, 0x6f, 0x73, 0x2f, 0x67, 0x6f, 0x2d, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73,
+	0x68, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6e, 0x6f, 0x64, 0x65, 0x70, 0x62, 0x3b, 0x6e, 0x6f, 0x64,
+	0x65, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_nodepb_smesher_proto_rawDescOnce sync.Once
+	file_nodepb_smesher_proto_rawDescData = file_nodepb_smesher_proto_rawDesc
+)
+
+func file_nodepb_smesher_proto_rawDescGZIP() []byte {
+	file_nodepb_smesher_proto_rawDescOnce.Do(func() {
+		file_nodepb_smesher_proto_rawDescData = protoimpl.X.CompressGZIP(file_nodepb_smesher_proto_rawDescData)
+	})
+	return file_nodepb_smesher_proto_rawDescData
+}
+
+var file_nodepb_smesher_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_nodepb_smesher_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_nodepb_smesher_proto_goTypes = []interface{}{
+	(PostSetupState)(0),                  // 0: spacemesh.node.v1.PostSetupState
+	(AtxPhase)(0),                        // 1: spacemesh.node.v1.AtxPhase
+	(*IdentityRequest)(nil),              // 2: spacemesh.node.v1.IdentityRequest
+	(*IdentitiesResponse)(nil),           // 3: spacemesh.node.v1.IdentitiesResponse
+	(*SmesherIdentity)(nil),              // 4: spacemesh.node.v1.SmesherIdentity
+	(*StartIdentitySmeshingRequest)(nil), // 5: spacemesh.node.v1.StartIdentitySmeshingRequest
+	(*StopIdentitySmeshingRequest)(nil),  // 6: spacemesh.node.v1.StopIdentitySmeshingRequest
+	(*VerifyPostDataRequest)(nil),        // 7: spacemesh.node.v1.VerifyPostDataRequest
+	(*LabelRange)(nil),                   // 8: spacemesh.node.v1.LabelRange
+	(*PostVerifyStatus)(nil),             // 9: spacemesh.node.v1.PostVerifyStatus
+	(*AtxPhaseStatus)(nil),               // 10: spacemesh.node.v1.AtxPhaseStatus
+	(*AtxDeadlinesResponse)(nil),         // 11: spacemesh.node.v1.AtxDeadlinesResponse
+	(*AtxPhaseEvent)(nil),                // 12: spacemesh.node.v1.AtxPhaseEvent
+	(*timestamppb.Timestamp)(nil),        // 13: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),          // 14: google.protobuf.Duration
+	(*emptypb.Empty)(nil),                // 15: google.protobuf.Empty
+}
+var file_nodepb_smesher_proto_depIdxs = []int32{
+	4,  // 0: spacemesh.node.v1.IdentitiesResponse.identities:type_name -> spacemesh.node.v1.SmesherIdentity
+	0,  // 1: spacemesh.node.v1.SmesherIdentity.post_state:type_name -> spacemesh.node.v1.PostSetupState
+	8,  // 2: spacemesh.node.v1.PostVerifyStatus.corrupted_ranges:type_name -> spacemesh.node.v1.LabelRange
+	1,  // 3: spacemesh.node.v1.AtxPhaseStatus.phase:type_name -> spacemesh.node.v1.AtxPhase
+	13, // 4: spacemesh.node.v1.AtxPhaseStatus.deadline:type_name -> google.protobuf.Timestamp
+	13, // 5: spacemesh.node.v1.AtxPhaseStatus.completed:type_name -> google.protobuf.Timestamp
+	14, // 6: spacemesh.node.v1.AtxPhaseStatus.slack:type_name -> google.protobuf.Duration
+	10, // 7: spacemesh.node.v1.AtxDeadlinesResponse.phases:type_name -> spacemesh.node.v1.AtxPhaseStatus
+	1,  // 8: spacemesh.node.v1.AtxPhaseEvent.phase:type_name -> spacemesh.node.v1.AtxPhase
+	13, // 9: spacemesh.node.v1.AtxPhaseEvent.deadline:type_name -> google.protobuf.Timestamp
+	13, // 10: spacemesh.node.v1.AtxPhaseEvent.completed:type_name -> google.protobuf.Timestamp
+	14, // 11: spacemesh.node.v1.AtxPhaseEvent.slack:type_name -> google.protobuf.Duration
+	15, // 12: spacemesh.node.v1.SmesherIdentityService.Identities:input_type -> google.protobuf.Empty
+	2,  // 13: spacemesh.node.v1.SmesherIdentityService.IdentityPostSetupStatus:input_type -> spacemesh.node.v1.IdentityRequest
+	5,  // 14: spacemesh.node.v1.SmesherIdentityService.StartIdentitySmeshing:input_type -> spacemesh.node.v1.StartIdentitySmeshingRequest
+	6,  // 15: spacemesh.node.v1.SmesherIdentityService.StopIdentitySmeshing:input_type -> spacemesh.node.v1.StopIdentitySmeshingRequest
+	7,  // 16: spacemesh.node.v1.SmesherIdentityService.VerifyPostData:input_type -> spacemesh.node.v1.VerifyPostDataRequest
+	2,  // 17: spacemesh.node.v1.SmesherIdentityService.AtxDeadlines:input_type -> spacemesh.node.v1.IdentityRequest
+	15, // 18: spacemesh.node.v1.SmesherIdentityService.AtxPhaseStream:input_type -> google.protobuf.Empty
+	3,  // 19: spacemesh.node.v1.SmesherIdentityService.Identities:output_type -> spacemesh.node.v1.IdentitiesResponse
+	4,  // 20: spacemesh.node.v1.SmesherIdentityService.IdentityPostSetupStatus:output_type -> spacemesh.node.v1.SmesherIdentity
+	15, // 21: spacemesh.node.v1.SmesherIdentityService.StartIdentitySmeshing:output_type -> google.protobuf.Empty
+	15, // 22: spacemesh.node.v1.SmesherIdentityService.StopIdentitySmeshing:output_type -> google.protobuf.Empty
+	9,  // 23: spacemesh.node.v1.SmesherIdentityService.VerifyPostData:output_type -> spacemesh.node.v1.PostVerifyStatus
+	11, // 24: spacemesh.node.v1.SmesherIdentityService.AtxDeadlines:output_type -> spacemesh.node.v1.AtxDeadlinesResponse
+	12, // 25: spacemesh.node.v1.SmesherIdentityService.AtxPhaseStream:output_type -> spacemesh.node.v1.AtxPhaseEvent
+	19, // [19:26] is the sub-list for method output_type
+	12, // [12:19] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_nodepb_smesher_proto_init() }
+func file_nodepb_smesher_proto_init() {
+	if File_nodepb_smesher_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_nodepb_smesher_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IdentityRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nodepb_smesher_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IdentitiesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nodepb_smesher_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SmesherIdentity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nodepb_smesher_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StartIdentitySmeshingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nodepb_smesher_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StopIdentitySmeshingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nodepb_smesher_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyPostDataRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nodepb_smesher_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LabelRange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nodepb_smesher_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PostVerifyStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nodepb_smesher_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AtxPhaseStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nodepb_smesher_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AtxDeadlinesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nodepb_smesher_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AtxPhaseEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_nodepb_smesher_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_nodepb_smesher_proto_goTypes,
+		DependencyIndexes: file_nodepb_smesher_proto_depIdxs,
+		EnumInfos:         file_nodepb_smesher_proto_enumTypes,
+		MessageInfos:      file_nodepb_smesher_proto_msgTypes,
+	}.Build()
+	File_nodepb_smesher_proto = out.File
+	file_nodepb_smesher_proto_rawDesc = nil
+	file_nodepb_smesher_proto_goTypes = nil
+	file_nodepb_smesher_proto_depIdxs = nil
+}
@@ -0,0 +1,393 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.5
+// source: nodepb/smesher.proto
+
+package nodepb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// SmesherIdentityServiceClient is the client API for SmesherIdentityService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SmesherIdentityServiceClient interface {
+	// Identities lists identities that smesh on the node with their smeshing and post setup status.
+	Identities(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*IdentitiesResponse, error)
+	// IdentityPostSetupStatus returns smeshing and post setup status of the identity.
+	IdentityPostSetupStatus(ctx context.Context, in *IdentityRequest, opts ...grpc.CallOption) (*SmesherIdentity, error)
+	// StartIdentitySmeshing starts smeshing for the identity.
+	StartIdentitySmeshing(ctx context.Context, in *StartIdentitySmeshingRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// StopIdentitySmeshing stops smeshing for the identity.
+	StopIdentitySmeshing(ctx context.Context, in *StopIdentitySmeshingRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// VerifyPostData verifies post data of the node, or of the identity, against its commitment
+	// and streams the progress.
+	VerifyPostData(ctx context.Context, in *VerifyPostDataRequest, opts ...grpc.CallOption) (SmesherIdentityService_VerifyPostDataClient, error)
+	// AtxDeadlines returns the phases of the atx that is built by the node, or by the identity,
+	// with their deadlines and the remaining slack.
+	AtxDeadlines(ctx context.Context, in *IdentityRequest, opts ...grpc.CallOption) (*AtxDeadlinesResponse, error)
+	// AtxPhaseStream streams phases of the atx construction as they are completed by the node and its identities.
+	AtxPhaseStream(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (SmesherIdentityService_AtxPhaseStreamClient, error)
+}
+
+type smesherIdentityServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSmesherIdentityServiceClient(cc grpc.ClientConnInterface) SmesherIdentityServiceClient {
+	return &smesherIdentityServiceClient{cc}
+}
+
+func (c *smesherIdentityServiceClient) Identities(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*IdentitiesResponse, error) {
+	out := new(IdentitiesResponse)
+	err := c.cc.Invoke(ctx, "/spacemesh.node.v1.SmesherIdentityService/Identities", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *smesherIdentityServiceClient) IdentityPostSetupStatus(ctx context.Context, in *IdentityRequest, opts ...grpc.CallOption) (*SmesherIdentity, error) {
+	out := new(SmesherIdentity)
+	err := c.cc.Invoke(ctx, "/spacemesh.node.v1.SmesherIdentityService/IdentityPostSetupStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *smesherIdentityServiceClient) StartIdentitySmeshing(ctx context.Context, in *StartIdentitySmeshingRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/spacemesh.node.v1.SmesherIdentityService/StartIdentitySmeshing", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *smesherIdentityServiceClient) StopIdentitySmeshing(ctx context.Context, in *StopIdentitySmeshingRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/spacemesh.node.v1.SmesherIdentityService/StopIdentitySmeshing", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *smesherIdentityServiceClient) VerifyPostData(ctx context.Context, in *VerifyPostDataRequest, opts ...grpc.CallOption) (SmesherIdentityService_VerifyPostDataClient, error) {
+	stream, err := c.cc.NewStream(ctx, &SmesherIdentityService_ServiceDesc.Streams[0], "/spacemesh.node.v1.SmesherIdentityService/VerifyPostData", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &smesherIdentityServiceVerifyPostDataClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SmesherIdentityService_VerifyPostDataClient interface {
+	Recv() (*PostVerifyStatus, error)
+	grpc.ClientStream
+}
+
+type smesherIdentityServiceVerifyPostDataClient struct {
+	grpc.ClientStream
+}
+
+func (x *smesherIdentityServiceVerifyPostDataClient) Recv() (*PostVerifyStatus, error) {
+	m := new(PostVerifyStatus)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *smesherIdentityServiceClient) AtxDeadlines(ctx context.Context, in *IdentityRequest, opts ...grpc.CallOption) (*AtxDeadlinesResponse, error) {
+	out := new(AtxDeadlinesResponse)
+	err := c.cc.Invoke(ctx, "/spacemesh.node.v1.SmesherIdentityService/AtxDeadlines", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *smesherIdentityServiceClient) AtxPhaseStream(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (SmesherIdentityService_AtxPhaseStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &SmesherIdentityService_ServiceDesc.Streams[1], "/spacemesh.node.v1.SmesherIdentityService/AtxPhaseStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &smesherIdentityServiceAtxPhaseStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SmesherIdentityService_AtxPhaseStreamClient interface {
+	Recv() (*AtxPhaseEvent, error)
+	grpc.ClientStream
+}
+
+type smesherIdentityServiceAtxPhaseStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *smesherIdentityServiceAtxPhaseStreamClient) Recv() (*AtxPhaseEvent, error) {
+	m := new(AtxPhaseEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SmesherIdentityServiceServer is the server API for SmesherIdentityService service.
+// All implementations should embed UnimplementedSmesherIdentityServiceServer
+// for forward compatibility
+type SmesherIdentityServiceServer interface {
+	// Identities lists identities that smesh on the node with their smeshing and post setup status.
+	Identities(context.Context, *emptypb.Empty) (*IdentitiesResponse, error)
+	// IdentityPostSetupStatus returns smeshing and post setup status of the identity.
+	IdentityPostSetupStatus(context.Context, *IdentityRequest) (*SmesherIdentity, error)
+	// StartIdentitySmeshing starts smeshing for the identity.
+	StartIdentitySmeshing(context.Context, *StartIdentitySmeshingRequest) (*emptypb.Empty, error)
+	// StopIdentitySmeshing stops smeshing for the identity.
+	StopIdentitySmeshing(context.Context, *StopIdentitySmeshingRequest) (*emptypb.Empty, error)
+	// VerifyPostData verifies post data of the node, or of the identity, against its commitment
+	// and streams the progress.
+	VerifyPostData(*VerifyPostDataRequest, SmesherIdentityService_VerifyPostDataServer) error
+	// AtxDeadlines returns the phases of the atx that is built by the node, or by the identity,
+	// with their deadlines and the remaining slack.
+	AtxDeadlines(context.Context, *IdentityRequest) (*AtxDeadlinesResponse, error)
+	// AtxPhaseStream streams phases of the atx construction as they are completed by the node and its identities.
+	AtxPhaseStream(*emptypb.Empty, SmesherIdentityService_AtxPhaseStreamServer) error
+}
+
+// UnimplementedSmesherIdentityServiceServer should be embedded to have forward compatible implementations.
+type UnimplementedSmesherIdentityServiceServer struct {
+}
+
+func (UnimplementedSmesherIdentityServiceServer) Identities(context.Context, *emptypb.Empty) (*IdentitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Identities not implemented")
+}
+func (UnimplementedSmesherIdentityServiceServer) IdentityPostSetupStatus(context.Context, *IdentityRequest) (*SmesherIdentity, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IdentityPostSetupStatus not implemented")
+}
+func (UnimplementedSmesherIdentityServiceServer) StartIdentitySmeshing(context.Context, *StartIdentitySmeshingRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartIdentitySmeshing not implemented")
+}
+func (UnimplementedSmesherIdentityServiceServer) StopIdentitySmeshing(context.Context, *StopIdentitySmeshingRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopIdentitySmeshing not implemented")
+}
+func (UnimplementedSmesherIdentityServiceServer) VerifyPostData(*VerifyPostDataRequest, SmesherIdentityService_VerifyPostDataServer) error {
+	return status.Errorf(codes.Unimplemented, "method VerifyPostData not implemented")
+}
+func (UnimplementedSmesherIdentityServiceServer) AtxDeadlines(context.Context, *IdentityRequest) (*AtxDeadlinesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AtxDeadlines not implemented")
+}
+func (UnimplementedSmesherIdentityServiceServer) AtxPhaseStream(*emptypb.Empty, SmesherIdentityService_AtxPhaseStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method AtxPhaseStream not implemented")
+}
+
+// UnsafeSmesherIdentityServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SmesherIdentityServiceServer will
+// result in compilation errors.
+type UnsafeSmesherIdentityServiceServer interface {
+	mustEmbedUnimplementedSmesherIdentityServiceServer()
+}
+
+func RegisterSmesherIdentityServiceServer(s grpc.ServiceRegistrar, srv SmesherIdentityServiceServer) {
+	s.RegisterService(&SmesherIdentityService_ServiceDesc, srv)
+}
+
+func _SmesherIdentityService_Identities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SmesherIdentityServiceServer).Identities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spacemesh.node.v1.SmesherIdentityService/Identities",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SmesherIdentityServiceServer).Identities(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SmesherIdentityService_IdentityPostSetupStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdentityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SmesherIdentityServiceServer).IdentityPostSetupStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spacemesh.node.v1.SmesherIdentityService/IdentityPostSetupStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SmesherIdentityServiceServer).IdentityPostSetupStatus(ctx, req.(*IdentityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SmesherIdentityService_StartIdentitySmeshing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartIdentitySmeshingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SmesherIdentityServiceServer).StartIdentitySmeshing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spacemesh.node.v1.SmesherIdentityService/StartIdentitySmeshing",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SmesherIdentityServiceServer).StartIdentitySmeshing(ctx, req.(*StartIdentitySmeshingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SmesherIdentityService_StopIdentitySmeshing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopIdentitySmeshingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SmesherIdentityServiceServer).StopIdentitySmeshing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spacemesh.node.v1.SmesherIdentityService/StopIdentitySmeshing",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SmesherIdentityServiceServer).StopIdentitySmeshing(ctx, req.(*StopIdentitySmeshingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SmesherIdentityService_VerifyPostData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(VerifyPostDataRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SmesherIdentityServiceServer).VerifyPostData(m, &smesherIdentityServiceVerifyPostDataServer{stream})
+}
+
+type SmesherIdentityService_VerifyPostDataServer interface {
+	Send(*PostVerifyStatus) error
+	grpc.ServerStream
+}
+
+type smesherIdentityServiceVerifyPostDataServer struct {
+	grpc.ServerStream
+}
+
+func (x *smesherIdentityServiceVerifyPostDataServer) Send(m *PostVerifyStatus) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SmesherIdentityService_AtxDeadlines_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IdentityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SmesherIdentityServiceServer).AtxDeadlines(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spacemesh.node.v1.SmesherIdentityService/AtxDeadlines",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SmesherIdentityServiceServer).AtxDeadlines(ctx, req.(*IdentityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SmesherIdentityService_AtxPhaseStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(emptypb.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SmesherIdentityServiceServer).AtxPhaseStream(m, &smesherIdentityServiceAtxPhaseStreamServer{stream})
+}
+
+type SmesherIdentityService_AtxPhaseStreamServer interface {
+	Send(*AtxPhaseEvent) error
+	grpc.ServerStream
+}
+
+type smesherIdentityServiceAtxPhaseStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *smesherIdentityServiceAtxPhaseStreamServer) Send(m *AtxPhaseEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// SmesherIdentityService_ServiceDesc is the grpc.ServiceDesc for SmesherIdentityService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SmesherIdentityService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "spacemesh.node.v1.SmesherIdentityService",
+	HandlerType: (*SmesherIdentityServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Identities",
+			Handler:    _SmesherIdentityService_Identities_Handler,
+		},
+		{
+			MethodName: "IdentityPostSetupStatus",
+			Handler:    _SmesherIdentityService_IdentityPostSetupStatus_Handler,
+		},
+		{
+			MethodName: "StartIdentitySmeshing",
+			Handler:    _SmesherIdentityService_StartIdentitySmeshing_Handler,
+		},
+		{
+			MethodName: "StopIdentitySmeshing",
+			Handler:    _SmesherIdentityService_StopIdentitySmeshing_Handler,
+		},
+		{
+			MethodName: "AtxDeadlines",
+			Handler:    _SmesherIdentityService_AtxDeadlines_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "VerifyPostData",
+			Handler:       _SmesherIdentityService_VerifyPostData_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "AtxPhaseStream",
+			Handler:       _SmesherIdentityService_AtxPhaseStream_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "nodepb/smesher.proto",
+}
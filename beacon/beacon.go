@@ -20,6 +20,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/common/util"
 	"github.com/spacemeshos/go-spacemesh/datastore"
+	"github.com/spacemeshos/go-spacemesh/events"
 	"github.com/spacemeshos/go-spacemesh/log"
 	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
 	"github.com/spacemeshos/go-spacemesh/signing"
@@ -83,8 +84,8 @@ func withWeakCoin(wc coin) Opt {
 func New(
 	nodeID types.NodeID,
 	publisher pubsub.Publisher,
-	edSigner *signing.EdSigner,
-	vrfSigner *signing.VRFSigner,
+	edSigner signing.RequestSigner,
+	vrfSigner signing.RequestSigner,
 	cdb *datastore.CachedDB,
 	clock layerClock,
 	opts ...Opt,
@@ -133,8 +134,8 @@ type ProtocolDriver struct {
 	nodeID      types.NodeID
 	sync        system.SyncStateProvider
 	publisher   pubsub.Publisher
-	edSigner    *signing.EdSigner
-	vrfSigner   *signing.VRFSigner
+	edSigner    signing.RequestSigner
+	vrfSigner   signing.RequestSigner
 	vrfVerifier signing.VRFVerifier
 	weakCoin    coin
 	theta       *big.Float
@@ -199,6 +200,9 @@ func (pd *ProtocolDriver) Start(ctx context.Context) {
 		pd.metricsCollector.Start(nil)
 
 		pd.setProposalTimeForNextEpoch()
+		if err := pd.resume(ctx); err != nil {
+			pd.logger.With().Error("failed to resume beacon protocol", log.Err(err))
+		}
 		pd.eg.Go(func() error {
 			pd.listenLayers(ctx)
 			return nil
@@ -241,7 +245,9 @@ func (pd *ProtocolDriver) ReportBeaconFromBallot(epoch types.EpochID, bid types.
 	if eBeacon := pd.findMostWeightedBeaconForEpoch(epoch); eBeacon != types.EmptyBeacon {
 		if err := pd.setBeacon(epoch, eBeacon); err != nil {
 			pd.logger.With().Error("beacon sync: failed to set beacon", log.Err(err))
+			return
 		}
+		pd.reportFromBallots(epoch, eBeacon)
 	}
 }
 
@@ -545,10 +551,10 @@ func (pd *ProtocolDriver) onNewEpoch(ctx context.Context, epoch types.EpochID) {
 	}
 
 	logger.With().Info("participating beacon protocol with ATX", atxID, log.Uint64("epoch_weight", epochWeight))
-	pd.runProtocol(ctx, epoch, atxs)
+	pd.runProtocol(ctx, epoch, atxs, resumePoint{})
 }
 
-func (pd *ProtocolDriver) runProtocol(ctx context.Context, epoch types.EpochID, atxs []types.ATXID) {
+func (pd *ProtocolDriver) runProtocol(ctx context.Context, epoch types.EpochID, atxs []types.ATXID, point resumePoint) {
 	ctx = log.WithNewSessionID(ctx)
 	targetEpoch := epoch + 1
 	logger := pd.logger.WithContext(ctx).WithFields(epoch, log.Uint32("target_epoch", uint32(targetEpoch)))
@@ -559,11 +565,39 @@ func (pd *ProtocolDriver) runProtocol(ctx context.Context, epoch types.EpochID,
 	pd.startWeakCoinEpoch(ctx, epoch, atxs)
 	defer pd.weakCoin.FinishEpoch(ctx, epoch)
 
-	if err := pd.runProposalPhase(ctx, epoch); err != nil {
-		logger.With().Warning("proposal phase failed", log.Err(err))
-		return
+	var ownVotes allVotes
+	if point.checkpoint == nil || point.proposal > 0 {
+		duration := pd.config.ProposalDuration
+		if point.checkpoint != nil {
+			duration = point.proposal
+		}
+		pd.persistState(logger, epoch, 0, ownVotes)
+		if err := pd.runProposalPhase(ctx, epoch, duration); err != nil {
+			logger.With().Warning("proposal phase failed", log.Err(err))
+			return
+		}
+		pd.persistState(logger, epoch, 0, ownVotes)
+	} else {
+		if point.wait > 0 {
+			timer := time.NewTimer(point.wait)
+			select {
+			case <-timer.C:
+			case <-pd.ctx.Done():
+				timer.Stop()
+				return
+			}
+		}
+		if uint32(point.round) == point.checkpoint.Rounds {
+			ownVotes = point.checkpoint.ownVotes()
+		} else {
+			var err error
+			if ownVotes, err = pd.catchUpVotes(logger, epoch, point.checkpoint); err != nil {
+				logger.With().Warning("failed to catch up votes", log.Err(err))
+				return
+			}
+		}
 	}
-	lastRoundOwnVotes, err := pd.runConsensusPhase(ctx, epoch)
+	lastRoundOwnVotes, err := pd.runConsensusPhase(ctx, epoch, point.round, ownVotes)
 	if err != nil {
 		logger.With().Warning("consensus phase failed", log.Err(err))
 		return
@@ -577,6 +611,10 @@ func (pd *ProtocolDriver) runProtocol(ctx context.Context, epoch types.EpochID,
 		logger.With().Error("failed to set beacon", log.Err(err))
 		return
 	}
+	if err := beacons.DeleteStatesBefore(pd.cdb, targetEpoch); err != nil {
+		logger.With().Error("failed to delete beacon states", log.Err(err))
+	}
+	events.ReportBeacon(events.EventBeacon{Epoch: targetEpoch, Stage: events.BeaconCalculated, Beacon: beacon})
 
 	logger.With().Info("beacon set for epoch", beacon)
 }
@@ -600,12 +638,12 @@ func calcBeacon(logger log.Log, lastRoundVotes allVotes) types.Beacon {
 	return beacon
 }
 
-func (pd *ProtocolDriver) runProposalPhase(ctx context.Context, epoch types.EpochID) error {
+func (pd *ProtocolDriver) runProposalPhase(ctx context.Context, epoch types.EpochID, duration time.Duration) error {
 	logger := pd.logger.WithContext(ctx).WithFields(epoch)
 	logger.Info("starting beacon proposal phase")
 
 	var cancel func()
-	ctx, cancel = context.WithTimeout(ctx, pd.config.ProposalDuration)
+	ctx, cancel = context.WithTimeout(ctx, duration)
 	defer cancel()
 
 	pd.eg.Go(func() error {
@@ -624,6 +662,7 @@ func (pd *ProtocolDriver) runProposalPhase(ctx context.Context, epoch types.Epoc
 	}
 
 	logger.Debug("beacon proposal phase finished")
+	pd.reportProposals(epoch)
 	return nil
 }
 
@@ -633,7 +672,11 @@ func (pd *ProtocolDriver) sendProposal(ctx context.Context, epoch types.EpochID)
 	}
 
 	logger := pd.logger.WithContext(ctx).WithFields(epoch)
-	proposedSignature := buildSignedProposal(ctx, pd.vrfSigner, epoch, pd.logger)
+	proposedSignature, err := buildSignedProposal(ctx, pd.vrfSigner, epoch, pd.logger)
+	if err != nil {
+		logger.With().Error("failed to sign beacon proposal", log.Err(err))
+		return
+	}
 	if !pd.checkProposalEligibility(logger, epoch, proposedSignature) {
 		logger.With().Debug("own proposal doesn't pass threshold",
 			log.String("proposal", string(proposedSignature)))
@@ -660,8 +703,9 @@ func (pd *ProtocolDriver) sendProposal(ctx context.Context, epoch types.EpochID)
 	logger.With().Info("beacon proposal sent", log.String("message", m.String()))
 }
 
-// runConsensusPhase runs K voting rounds and returns result from last weak coin round.
-func (pd *ProtocolDriver) runConsensusPhase(ctx context.Context, epoch types.EpochID) (allVotes, error) {
+// runConsensusPhase runs voting rounds starting from the specified round and returns result from last weak coin round.
+// ownVotes are the votes from the round before the first one.
+func (pd *ProtocolDriver) runConsensusPhase(ctx context.Context, epoch types.EpochID, from types.RoundID, ownVotes allVotes) (allVotes, error) {
 	logger := pd.logger.WithContext(ctx).WithFields(epoch)
 	logger.Info("starting consensus phase")
 
@@ -669,15 +713,18 @@ func (pd *ProtocolDriver) runConsensusPhase(ctx context.Context, epoch types.Epo
 	// For next rounds,
 	// wait for δ time, and construct a message that points to all messages from previous round received by δ.
 	// rounds 1 to K
-	timer := time.NewTimer(pd.config.FirstVotingRoundDuration)
+	duration := pd.config.FirstVotingRoundDuration
+	if from != types.FirstRound {
+		duration = pd.config.VotingRoundDuration
+	}
+	timer := time.NewTimer(duration)
 	defer timer.Stop()
 
 	var (
-		ownVotes  allVotes
 		undecided []string
 		err       error
 	)
-	for round := types.FirstRound; round < pd.config.RoundsNumber; round++ {
+	for round := from; round < pd.config.RoundsNumber; round++ {
 		round := round
 		pd.setRoundInProgress(round)
 		rLogger := logger.WithFields(round)
@@ -723,8 +770,14 @@ func (pd *ProtocolDriver) runConsensusPhase(ctx context.Context, epoch types.Epo
 				return allVotes{}, fmt.Errorf("context done: %w", ctx.Err())
 			}
 			pd.weakCoin.FinishRound(ctx)
-			tallyUndecided(&ownVotes, undecided, pd.weakCoin.Get(ctx, epoch, round))
+			coin := pd.weakCoin.Get(ctx, epoch, round)
+			pd.setRoundCoin(epoch, round, coin)
+			tallyUndecided(&ownVotes, undecided, coin)
+			pd.reportRound(epoch, round, &coin)
+		} else {
+			pd.reportRound(epoch, round, nil)
 		}
+		pd.persistState(rLogger, epoch, uint32(round)+1, ownVotes)
 		timer.Reset(pd.config.VotingRoundDuration)
 	}
 
@@ -757,6 +810,14 @@ func (pd *ProtocolDriver) markProposalPhaseFinished(epoch types.EpochID, finishe
 	return nil
 }
 
+func (pd *ProtocolDriver) setRoundCoin(epoch types.EpochID, round types.RoundID, coin bool) {
+	pd.mu.Lock()
+	defer pd.mu.Unlock()
+	if s, ok := pd.states[epoch]; ok {
+		s.coins[round] = coin
+	}
+}
+
 func (pd *ProtocolDriver) calcVotesBeforeWeakCoin(logger log.Log, epoch types.EpochID) (allVotes, []string, error) {
 	pd.mu.RLock()
 	defer pd.mu.RUnlock()
@@ -792,7 +853,15 @@ func (pd *ProtocolDriver) sendFirstRoundVote(ctx context.Context, epoch types.Ep
 	if err != nil {
 		pd.logger.With().Panic("failed to serialize message for signing", log.Err(err))
 	}
-	sig := pd.edSigner.Sign(encoded)
+	sig, err := pd.edSigner.SignRequest(signing.Request{
+		Domain:  signing.BEACON,
+		Epoch:   uint32(epoch),
+		Round:   uint32(types.FirstRound),
+		Message: encoded,
+	})
+	if err != nil {
+		return fmt.Errorf("sign first round vote: %w", err)
+	}
 
 	m := FirstVotingMessage{
 		FirstVotingMessageBody: mb,
@@ -839,7 +908,15 @@ func (pd *ProtocolDriver) sendFollowingVote(ctx context.Context, epoch types.Epo
 	if err != nil {
 		pd.logger.With().Panic("failed to serialize message for signing", log.Err(err))
 	}
-	sig := pd.edSigner.Sign(encoded)
+	sig, err := pd.edSigner.SignRequest(signing.Request{
+		Domain:  signing.BEACON,
+		Epoch:   uint32(epoch),
+		Round:   uint32(round),
+		Message: encoded,
+	})
+	if err != nil {
+		return fmt.Errorf("sign following round vote: %w", err)
+	}
 
 	m := FollowingVotingMessage{
 		FollowingVotingMessageBody: mb,
@@ -934,15 +1011,22 @@ func atxThreshold(kappa uint64, q *big.Rat, epochWeight uint64) *big.Int {
 	return threshold
 }
 
-func buildSignedProposal(ctx context.Context, signer signing.Signer, epoch types.EpochID, logger log.Log) []byte {
+func buildSignedProposal(ctx context.Context, signer signing.RequestSigner, epoch types.EpochID, logger log.Log) ([]byte, error) {
 	p := buildProposal(epoch, logger)
-	signature := signer.Sign(p)
+	signature, err := signer.SignRequest(signing.Request{
+		Domain:  signing.BEACON_PROPOSAL,
+		Epoch:   uint32(epoch),
+		Message: p,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sign beacon proposal: %w", err)
+	}
 	logger.WithContext(ctx).With().Debug("calculated signature",
 		epoch,
 		log.String("proposal", util.Bytes2Hex(p)),
 		log.String("signature", string(signature)))
 
-	return signature
+	return signature, nil
 }
 
 //go:generate scalegen -types BuildProposalMessage
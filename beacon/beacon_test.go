@@ -91,7 +91,7 @@ func setUpProtocolDriver(t *testing.T) *testProtocolDriver {
 	return tpd
 }
 
-func createATX(t *testing.T, db *datastore.CachedDB, lid types.LayerID, sig *signing.EdSigner, numUnits uint32) {
+func createATX(t *testing.T, db *datastore.CachedDB, lid types.LayerID, sig signing.RequestSigner, numUnits uint32) {
 	atx := types.NewActivationTx(
 		types.NIPostChallenge{PubLayerID: lid},
 		types.Address{},
@@ -762,7 +762,8 @@ func TestBeacon_getSignedProposal(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			result := buildSignedProposal(context.TODO(), vrfSigner, tc.epoch, logtest.New(t))
+			result, err := buildSignedProposal(context.TODO(), vrfSigner, tc.epoch, logtest.New(t))
+			r.NoError(err)
 			r.Equal(string(tc.result), string(result))
 		})
 	}
@@ -814,3 +815,43 @@ func TestBeacon_calcBeacon(t *testing.T) {
 	expected := types.HexToBeacon("0x6d148de54cc5ac334cdf4537018209b0e9f5ea94c049417103065eac777ddb5c")
 	require.EqualValues(t, expected, beacon)
 }
+
+func TestBeacon_SetManualBeacon(t *testing.T) {
+	tpd := setUpProtocolDriver(t)
+	current := types.EpochID(5)
+	tpd.mClock.EXPECT().GetCurrentLayer().Return(current.FirstLayer()).AnyTimes()
+
+	manual := types.RandomBeacon()
+	require.ErrorIs(t, tpd.SetManualBeacon(current, manual), ErrNotFutureEpoch)
+
+	require.NoError(t, tpd.setBeacon(current+1, types.RandomBeacon()))
+	require.NoError(t, tpd.SetManualBeacon(current+1, manual))
+	got, err := tpd.GetBeacon(current + 1)
+	require.NoError(t, err)
+	require.Equal(t, manual, got)
+	isManual, err := tpd.IsManualBeacon(current + 1)
+	require.NoError(t, err)
+	require.True(t, isManual)
+
+	// beacon calculated by the protocol doesn't replace manual beacon
+	require.ErrorIs(t, tpd.setBeacon(current+1, types.RandomBeacon()), errDifferentBeacon)
+	got, err = tpd.GetBeacon(current + 1)
+	require.NoError(t, err)
+	require.Equal(t, manual, got)
+}
+
+func TestBeacon_BallotBeacons(t *testing.T) {
+	tpd := setUpProtocolDriver(t)
+	epoch := types.EpochID(5)
+	heavy := types.Beacon{1}
+	light := types.Beacon{2}
+	tpd.recordBeacon(epoch, types.RandomBallotID(), light, 5)
+	tpd.recordBeacon(epoch, types.RandomBallotID(), heavy, 10)
+	tpd.recordBeacon(epoch, types.RandomBallotID(), heavy, 10)
+
+	require.Equal(t, []BallotBeacon{
+		{Beacon: heavy, Weight: 20, Ballots: 2},
+		{Beacon: light, Weight: 5, Ballots: 1},
+	}, tpd.BallotBeacons(epoch))
+	require.Empty(t, tpd.BallotBeacons(epoch+1))
+}
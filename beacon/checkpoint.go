@@ -0,0 +1,300 @@
+package beacon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/atxs"
+	"github.com/spacemeshos/go-spacemesh/sql/beacons"
+)
+
+//go:generate scalegen -types EpochState,MinerVotes,RoundVoters,ProposalMargin,RoundCoin
+
+// MinerVotes are the first round votes of the miner.
+type MinerVotes struct {
+	Miner []byte
+	Votes [][]byte
+}
+
+// RoundVoters are the miners that voted in the round.
+type RoundVoters struct {
+	Miners [][]byte
+}
+
+// ProposalMargin is the votes margin of the proposal.
+type ProposalMargin struct {
+	Proposal []byte
+	// Margin is the gob encoding of the big.Int margin, as it can be negative.
+	Margin []byte
+}
+
+// RoundCoin is the weak coin of the completed round.
+type RoundCoin struct {
+	Round uint32
+	Coin  bool
+}
+
+// EpochState is a checkpoint of the beacon protocol state for the epoch. It is saved after the proposal phase
+// and after every voting round, so that the node can continue voting after restart.
+type EpochState struct {
+	Epoch types.EpochID
+	// Rounds is the number of voting rounds completed by the node.
+	Rounds uint32
+	// ProposalPhaseFinished is the time when the proposal phase finished in unix nanoseconds,
+	// zero if it wasn't finished.
+	ProposalPhaseFinished uint64
+
+	ValidProposals            [][]byte
+	PotentiallyValidProposals [][]byte
+	Proposed                  [][]byte
+	FirstRoundVotes           []MinerVotes
+	Voted                     []RoundVoters
+	Margins                   []ProposalMargin
+	Coins                     []RoundCoin
+
+	// Support and Against are own votes after the last completed round, including the weak coin.
+	Support [][]byte
+	Against [][]byte
+}
+
+func (s *state) checkpoint(epoch types.EpochID, rounds uint32, ownVotes allVotes) (*EpochState, error) {
+	cp := &EpochState{
+		Epoch:                     epoch,
+		Rounds:                    rounds,
+		ValidProposals:            s.incomingProposals.valid.sort(),
+		PotentiallyValidProposals: s.incomingProposals.potentiallyValid.sort(),
+		Support:                   ownVotes.support.sort(),
+		Against:                   ownVotes.against.sort(),
+	}
+	if !s.proposalPhaseFinishedTime.IsZero() {
+		cp.ProposalPhaseFinished = uint64(s.proposalPhaseFinishedTime.UnixNano())
+	}
+	for miner := range s.hasProposed {
+		cp.Proposed = append(cp.Proposed, []byte(miner))
+	}
+	for miner, votes := range s.firstRoundIncomingVotes {
+		cp.FirstRoundVotes = append(cp.FirstRoundVotes, MinerVotes{Miner: []byte(miner), Votes: votes})
+	}
+	for _, voted := range s.hasVoted {
+		var rv RoundVoters
+		for miner := range voted {
+			rv.Miners = append(rv.Miners, []byte(miner))
+		}
+		cp.Voted = append(cp.Voted, rv)
+	}
+	for proposal, margin := range s.votesMargin {
+		encoded, err := margin.GobEncode()
+		if err != nil {
+			return nil, fmt.Errorf("encode margin: %w", err)
+		}
+		cp.Margins = append(cp.Margins, ProposalMargin{Proposal: []byte(proposal), Margin: encoded})
+	}
+	for round, coin := range s.coins {
+		cp.Coins = append(cp.Coins, RoundCoin{Round: uint32(round), Coin: coin})
+	}
+	sort.Slice(cp.Coins, func(i, j int) bool { return cp.Coins[i].Round < cp.Coins[j].Round })
+	return cp, nil
+}
+
+func (s *state) restore(cp *EpochState) error {
+	if len(cp.Voted) > len(s.hasVoted) {
+		return fmt.Errorf("checkpoint has %d rounds, configured %d", len(cp.Voted), len(s.hasVoted))
+	}
+	if cp.ProposalPhaseFinished != 0 {
+		s.proposalPhaseFinishedTime = time.Unix(0, int64(cp.ProposalPhaseFinished))
+	}
+	for _, proposal := range cp.ValidProposals {
+		s.addValidProposal(proposal)
+	}
+	for _, proposal := range cp.PotentiallyValidProposals {
+		s.addPotentiallyValidProposal(proposal)
+	}
+	for _, miner := range cp.Proposed {
+		s.hasProposed[string(miner)] = struct{}{}
+	}
+	for _, mv := range cp.FirstRoundVotes {
+		s.firstRoundIncomingVotes[string(mv.Miner)] = mv.Votes
+	}
+	for round, rv := range cp.Voted {
+		if len(rv.Miners) == 0 {
+			continue
+		}
+		s.hasVoted[round] = make(map[string]struct{}, len(rv.Miners))
+		for _, miner := range rv.Miners {
+			s.hasVoted[round][string(miner)] = struct{}{}
+		}
+	}
+	for _, pm := range cp.Margins {
+		margin := new(big.Int)
+		if err := margin.GobDecode(pm.Margin); err != nil {
+			return fmt.Errorf("decode margin: %w", err)
+		}
+		s.votesMargin[string(pm.Proposal)] = margin
+	}
+	for _, rc := range cp.Coins {
+		s.coins[types.RoundID(rc.Round)] = rc.Coin
+	}
+	return nil
+}
+
+func (cp *EpochState) ownVotes() allVotes {
+	votes := allVotes{support: make(proposalSet), against: make(proposalSet)}
+	for _, proposal := range cp.Support {
+		votes.support[string(proposal)] = struct{}{}
+	}
+	for _, proposal := range cp.Against {
+		votes.against[string(proposal)] = struct{}{}
+	}
+	return votes
+}
+
+// persistState saves the checkpoint of the protocol state for the epoch.
+func (pd *ProtocolDriver) persistState(logger log.Log, epoch types.EpochID, rounds uint32, ownVotes allVotes) {
+	pd.mu.RLock()
+	s, ok := pd.states[epoch]
+	if !ok {
+		pd.mu.RUnlock()
+		return
+	}
+	cp, err := s.checkpoint(epoch, rounds, ownVotes)
+	pd.mu.RUnlock()
+	if err != nil {
+		logger.With().Error("failed to checkpoint beacon state", log.Err(err))
+		return
+	}
+	encoded, err := codec.Encode(cp)
+	if err != nil {
+		logger.With().Panic("failed to encode beacon state", log.Err(err))
+	}
+	if err := beacons.SetState(pd.cdb, epoch, encoded); err != nil {
+		logger.With().Error("failed to persist beacon state", log.Err(err))
+	}
+}
+
+// resumePoint is where the protocol continues after restart.
+type resumePoint struct {
+	// proposal is the remaining duration of the proposal phase, zero if it already finished.
+	proposal time.Duration
+	// wait is the duration until the round starts.
+	wait  time.Duration
+	round types.RoundID
+	// checkpoint is nil if the protocol wasn't resumed.
+	checkpoint *EpochState
+}
+
+// roundStart returns the offset of the round start from the end of the proposal phase.
+func (pd *ProtocolDriver) roundStart(round types.RoundID) time.Duration {
+	if round == types.FirstRound {
+		return 0
+	}
+	return pd.config.FirstVotingRoundDuration +
+		time.Duration(round-1)*(pd.config.VotingRoundDuration+pd.config.WeakCoinRoundDuration)
+}
+
+// findResumePoint returns the earliest point in the protocol for the epoch that the node can still fully participate
+// in, or false if the protocol can't be resumed.
+func (pd *ProtocolDriver) findResumePoint(epoch types.EpochID, cp *EpochState, now time.Time) (resumePoint, bool) {
+	proposalEnd := pd.clock.LayerToTime(epoch.FirstLayer()).Add(pd.config.ProposalDuration)
+	if now.Before(proposalEnd) {
+		return resumePoint{proposal: proposalEnd.Sub(now), checkpoint: cp}, true
+	}
+	for round := types.RoundID(cp.Rounds); round < pd.config.RoundsNumber; round++ {
+		start := proposalEnd.Add(pd.roundStart(round))
+		if !now.After(start) {
+			return resumePoint{wait: start.Sub(now), round: round, checkpoint: cp}, true
+		}
+	}
+	return resumePoint{}, false
+}
+
+// resume continues the protocol for the current epoch from the checkpoint saved before restart.
+func (pd *ProtocolDriver) resume(ctx context.Context) error {
+	epoch, encoded, err := beacons.LatestState(pd.cdb)
+	if errors.Is(err, sql.ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	logger := pd.logger.WithContext(ctx).WithFields(epoch)
+	if current := pd.currentEpoch(); current != epoch {
+		logger.With().Info("not resuming beacon protocol: checkpoint is not for the current epoch", log.Named("current", current))
+		return nil
+	}
+	if _, err := pd.GetBeacon(epoch + 1); err == nil {
+		return nil
+	}
+	var cp EpochState
+	if err := codec.Decode(encoded, &cp); err != nil {
+		return fmt.Errorf("decode beacon state %v: %w", epoch, err)
+	}
+	point, ok := pd.findResumePoint(epoch, &cp, time.Now())
+	if !ok {
+		logger.Info("not resuming beacon protocol: too late to participate")
+		return nil
+	}
+	if _, err := atxs.GetIDByEpochAndNodeID(pd.cdb, epoch-1, pd.nodeID); err != nil {
+		return fmt.Errorf("own atx in epoch %v: %w", epoch-1, err)
+	}
+	s, err := pd.initEpochStateIfNotPresent(logger, epoch)
+	if err != nil {
+		return err
+	}
+	pd.mu.Lock()
+	err = s.restore(&cp)
+	pd.roundInProgress = point.round
+	pd.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	logger.With().Info("resuming beacon protocol",
+		log.Uint32("completed_rounds", cp.Rounds),
+		log.Uint32("round", uint32(point.round)),
+		log.Duration("wait", point.wait))
+	pd.eg.Go(func() error {
+		defer pd.cleanupEpoch(epoch)
+		pd.runProtocol(ctx, epoch, s.atxs, point)
+		return nil
+	})
+	return nil
+}
+
+// lastCoin returns the weak coin of the latest round completed before the checkpoint,
+// or false if no weak coin round was completed.
+func (cp *EpochState) lastCoin() bool {
+	if len(cp.Coins) == 0 {
+		return false
+	}
+	return cp.Coins[len(cp.Coins)-1].Coin
+}
+
+// catchUpVotes calculates own votes when the node missed rounds before the round it resumed in.
+// Weak coins for missed rounds are not known, so undecided proposals keep the opinion from the checkpoint.
+// Proposals without an opinion are tallied with the weak coin of the last checkpointed round,
+// and are voted against if no weak coin round was completed.
+func (pd *ProtocolDriver) catchUpVotes(logger log.Log, epoch types.EpochID, cp *EpochState) (allVotes, error) {
+	votes, undecided, err := pd.calcVotesBeforeWeakCoin(logger, epoch)
+	if err != nil {
+		return allVotes{}, err
+	}
+	previous := cp.ownVotes()
+	coin := cp.lastCoin()
+	for _, proposal := range undecided {
+		if _, ok := previous.support[proposal]; ok {
+			votes.support[proposal] = struct{}{}
+		} else if _, ok := previous.against[proposal]; ok {
+			votes.against[proposal] = struct{}{}
+		} else {
+			tallyUndecided(&votes, []string{proposal}, coin)
+		}
+	}
+	return votes, nil
+}
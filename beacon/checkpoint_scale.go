@@ -0,0 +1,334 @@
+// Code generated by github.com/spacemeshos/go-scale/scalegen. DO NOT EDIT.
+
+// nolint
+package beacon
+
+import (
+	"github.com/spacemeshos/go-scale"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+)
+
+func (t *EpochState) EncodeScale(enc *scale.Encoder) (total int, err error) {
+	{
+		n, err := scale.EncodeCompact32(enc, uint32(t.Epoch))
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.EncodeCompact32(enc, uint32(t.Rounds))
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.EncodeCompact64(enc, uint64(t.ProposalPhaseFinished))
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.EncodeSliceOfByteSlice(enc, t.ValidProposals)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.EncodeSliceOfByteSlice(enc, t.PotentiallyValidProposals)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.EncodeSliceOfByteSlice(enc, t.Proposed)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.EncodeStructSlice(enc, t.FirstRoundVotes)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.EncodeStructSlice(enc, t.Voted)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.EncodeStructSlice(enc, t.Margins)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.EncodeStructSlice(enc, t.Coins)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.EncodeSliceOfByteSlice(enc, t.Support)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.EncodeSliceOfByteSlice(enc, t.Against)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+func (t *EpochState) DecodeScale(dec *scale.Decoder) (total int, err error) {
+	{
+		field, n, err := scale.DecodeCompact32(dec)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.Epoch = types.EpochID(field)
+	}
+	{
+		field, n, err := scale.DecodeCompact32(dec)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.Rounds = uint32(field)
+	}
+	{
+		field, n, err := scale.DecodeCompact64(dec)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.ProposalPhaseFinished = uint64(field)
+	}
+	{
+		field, n, err := scale.DecodeSliceOfByteSlice(dec)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.ValidProposals = field
+	}
+	{
+		field, n, err := scale.DecodeSliceOfByteSlice(dec)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.PotentiallyValidProposals = field
+	}
+	{
+		field, n, err := scale.DecodeSliceOfByteSlice(dec)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.Proposed = field
+	}
+	{
+		field, n, err := scale.DecodeStructSlice[MinerVotes](dec)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.FirstRoundVotes = field
+	}
+	{
+		field, n, err := scale.DecodeStructSlice[RoundVoters](dec)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.Voted = field
+	}
+	{
+		field, n, err := scale.DecodeStructSlice[ProposalMargin](dec)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.Margins = field
+	}
+	{
+		field, n, err := scale.DecodeStructSlice[RoundCoin](dec)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.Coins = field
+	}
+	{
+		field, n, err := scale.DecodeSliceOfByteSlice(dec)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.Support = field
+	}
+	{
+		field, n, err := scale.DecodeSliceOfByteSlice(dec)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.Against = field
+	}
+	return total, nil
+}
+
+func (t *MinerVotes) EncodeScale(enc *scale.Encoder) (total int, err error) {
+	{
+		n, err := scale.EncodeByteSlice(enc, t.Miner)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.EncodeSliceOfByteSlice(enc, t.Votes)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+func (t *MinerVotes) DecodeScale(dec *scale.Decoder) (total int, err error) {
+	{
+		field, n, err := scale.DecodeByteSlice(dec)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.Miner = field
+	}
+	{
+		field, n, err := scale.DecodeSliceOfByteSlice(dec)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.Votes = field
+	}
+	return total, nil
+}
+
+func (t *RoundVoters) EncodeScale(enc *scale.Encoder) (total int, err error) {
+	{
+		n, err := scale.EncodeSliceOfByteSlice(enc, t.Miners)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+func (t *RoundVoters) DecodeScale(dec *scale.Decoder) (total int, err error) {
+	{
+		field, n, err := scale.DecodeSliceOfByteSlice(dec)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.Miners = field
+	}
+	return total, nil
+}
+
+func (t *ProposalMargin) EncodeScale(enc *scale.Encoder) (total int, err error) {
+	{
+		n, err := scale.EncodeByteSlice(enc, t.Proposal)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.EncodeByteSlice(enc, t.Margin)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+func (t *ProposalMargin) DecodeScale(dec *scale.Decoder) (total int, err error) {
+	{
+		field, n, err := scale.DecodeByteSlice(dec)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.Proposal = field
+	}
+	{
+		field, n, err := scale.DecodeByteSlice(dec)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.Margin = field
+	}
+	return total, nil
+}
+
+func (t *RoundCoin) EncodeScale(enc *scale.Encoder) (total int, err error) {
+	{
+		n, err := scale.EncodeCompact32(enc, uint32(t.Round))
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.EncodeBool(enc, t.Coin)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+func (t *RoundCoin) DecodeScale(dec *scale.Decoder) (total int, err error) {
+	{
+		field, n, err := scale.DecodeCompact32(dec)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.Round = uint32(field)
+	}
+	{
+		field, n, err := scale.DecodeBool(dec)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.Coin = field
+	}
+	return total, nil
+}
@@ -0,0 +1,204 @@
+package beacon
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/sql/beacons"
+)
+
+func TestState_CheckpointRestore(t *testing.T) {
+	cfg := UnitTestConfig()
+	epoch := types.EpochID(5)
+	valid := types.RandomHash().Bytes()
+	potentiallyValid := types.RandomHash().Bytes()
+	miner := types.RandomHash().Bytes()
+
+	s := newState(logtest.New(t), cfg, 10, nil)
+	s.addValidProposal(valid)
+	s.addPotentiallyValidProposal(potentiallyValid)
+	s.hasProposed[string(miner)] = struct{}{}
+	s.firstRoundIncomingVotes[string(miner)] = proposalList{valid, potentiallyValid}
+	s.hasVoted[types.FirstRound] = map[string]struct{}{string(miner): {}}
+	s.hasVoted[types.FirstRound+1] = map[string]struct{}{string(miner): {}}
+	s.votesMargin[string(valid)] = big.NewInt(7)
+	s.votesMargin[string(potentiallyValid)] = big.NewInt(-3)
+	s.proposalPhaseFinishedTime = time.Unix(0, time.Now().UnixNano())
+	s.coins[types.FirstRound+1] = true
+	s.coins[types.FirstRound+2] = false
+
+	ownVotes := allVotes{
+		support: proposalSet{string(valid): {}},
+		against: proposalSet{string(potentiallyValid): {}},
+	}
+	cp, err := s.checkpoint(epoch, 2, ownVotes)
+	require.NoError(t, err)
+	encoded, err := codec.Encode(cp)
+	require.NoError(t, err)
+	var decoded EpochState
+	require.NoError(t, codec.Decode(encoded, &decoded))
+	require.Equal(t, epoch, decoded.Epoch)
+	require.EqualValues(t, 2, decoded.Rounds)
+	require.Equal(t, ownVotes, decoded.ownVotes())
+
+	restored := newState(logtest.New(t), cfg, 10, nil)
+	require.NoError(t, restored.restore(&decoded))
+	require.Equal(t, s.incomingProposals, restored.incomingProposals)
+	require.Equal(t, s.firstRoundIncomingVotes, restored.firstRoundIncomingVotes)
+	require.Equal(t, s.votesMargin, restored.votesMargin)
+	require.Equal(t, s.hasProposed, restored.hasProposed)
+	require.Equal(t, s.hasVoted, restored.hasVoted)
+	require.True(t, s.proposalPhaseFinishedTime.Equal(restored.proposalPhaseFinishedTime))
+	require.Equal(t, s.coins, restored.coins)
+	require.False(t, decoded.lastCoin())
+
+	t.Run("too many rounds", func(t *testing.T) {
+		cfg := cfg
+		cfg.RoundsNumber = 1
+		require.Error(t, newState(logtest.New(t), cfg, 10, nil).restore(&decoded))
+	})
+}
+
+func TestBeacon_findResumePoint(t *testing.T) {
+	tpd := setUpProtocolDriver(t)
+	cfg := tpd.config
+	epoch := types.EpochID(3)
+	start := time.Now()
+	tpd.mClock.EXPECT().LayerToTime(epoch.FirstLayer()).Return(start).AnyTimes()
+	proposalEnd := start.Add(cfg.ProposalDuration)
+	firstRoundEnd := proposalEnd.Add(cfg.FirstVotingRoundDuration)
+	roundDuration := cfg.VotingRoundDuration + cfg.WeakCoinRoundDuration
+
+	for _, tc := range []struct {
+		desc     string
+		rounds   uint32
+		now      time.Time
+		expected resumePoint
+		ok       bool
+	}{
+		{
+			desc:     "proposal phase",
+			now:      start.Add(5 * time.Millisecond),
+			expected: resumePoint{proposal: cfg.ProposalDuration - 5*time.Millisecond},
+			ok:       true,
+		},
+		{
+			desc:     "first round start",
+			now:      proposalEnd,
+			expected: resumePoint{round: types.FirstRound},
+			ok:       true,
+		},
+		{
+			desc:     "during first round",
+			now:      proposalEnd.Add(time.Millisecond),
+			expected: resumePoint{wait: cfg.FirstVotingRoundDuration - time.Millisecond, round: types.FirstRound + 1},
+			ok:       true,
+		},
+		{
+			desc:     "next round after completed",
+			rounds:   4,
+			now:      proposalEnd.Add(time.Millisecond),
+			expected: resumePoint{wait: firstRoundEnd.Add(3 * roundDuration).Sub(proposalEnd.Add(time.Millisecond)), round: 4},
+			ok:       true,
+		},
+		{
+			desc:     "missed rounds",
+			rounds:   1,
+			now:      firstRoundEnd.Add(2*roundDuration + time.Millisecond),
+			expected: resumePoint{wait: roundDuration - time.Millisecond, round: types.FirstRound + 4},
+			ok:       true,
+		},
+		{
+			desc: "last round started",
+			now:  firstRoundEnd.Add(time.Duration(cfg.RoundsNumber-2)*roundDuration + time.Millisecond),
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			cp := &EpochState{Epoch: epoch, Rounds: tc.rounds}
+			point, ok := tpd.findResumePoint(epoch, cp, tc.now)
+			require.Equal(t, tc.ok, ok)
+			if tc.ok {
+				tc.expected.checkpoint = cp
+			}
+			require.Equal(t, tc.expected, point)
+		})
+	}
+}
+
+func TestBeacon_ResumeNoCheckpoint(t *testing.T) {
+	tpd := setUpProtocolDriver(t)
+	require.NoError(t, tpd.resume(context.Background()))
+}
+
+func TestBeacon_ResumeOtherEpoch(t *testing.T) {
+	tpd := setUpProtocolDriver(t)
+	encoded, err := codec.Encode(&EpochState{Epoch: 2})
+	require.NoError(t, err)
+	require.NoError(t, beacons.SetState(tpd.cdb, 2, encoded))
+	tpd.mClock.EXPECT().GetCurrentLayer().Return(types.EpochID(3).FirstLayer())
+
+	require.NoError(t, tpd.resume(context.Background()))
+	tpd.mu.RLock()
+	defer tpd.mu.RUnlock()
+	require.Empty(t, tpd.states)
+}
+
+func TestBeacon_catchUpVotes(t *testing.T) {
+	epoch := types.EpochID(3)
+	supported := string(types.RandomHash().Bytes())
+	opposed := string(types.RandomHash().Bytes())
+	unknown := string(types.RandomHash().Bytes())
+	decided := string(types.RandomHash().Bytes())
+	cp := &EpochState{
+		Epoch:   epoch,
+		Rounds:  3,
+		Support: [][]byte{[]byte(supported)},
+		Against: [][]byte{[]byte(opposed)},
+	}
+
+	for _, tc := range []struct {
+		desc     string
+		coins    []RoundCoin
+		expected allVotes
+	}{
+		{
+			desc: "no weak coin",
+			expected: allVotes{
+				support: proposalSet{supported: {}, decided: {}},
+				against: proposalSet{opposed: {}, unknown: {}},
+			},
+		},
+		{
+			desc:  "last weak coin",
+			coins: []RoundCoin{{Round: 1, Coin: false}, {Round: 2, Coin: true}},
+			expected: allVotes{
+				support: proposalSet{supported: {}, decided: {}, unknown: {}},
+				against: proposalSet{opposed: {}},
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			tpd := setUpProtocolDriver(t)
+			s := newState(logtest.New(t), tpd.config, 1_000_000, nil)
+			s.votesMargin[supported] = big.NewInt(0)
+			s.votesMargin[opposed] = big.NewInt(0)
+			s.votesMargin[unknown] = big.NewInt(0)
+			s.votesMargin[decided] = big.NewInt(1000)
+			tpd.states[epoch] = s
+
+			cp := *cp
+			cp.Coins = tc.coins
+			votes, err := tpd.catchUpVotes(logtest.New(t), epoch, &cp)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, votes)
+		})
+	}
+}
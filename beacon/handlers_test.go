@@ -75,9 +75,10 @@ func mockAlwaysFalseProposalChecker(t *testing.T, pd *ProtocolDriver, epoch type
 	pd.states[epoch].proposalChecker = mockChecker
 }
 
-func createProposal(t *testing.T, signer, vrfSigner signing.Signer, epoch types.EpochID, corruptSignature bool) *ProposalMessage {
+func createProposal(t *testing.T, signer signing.Signer, vrfSigner signing.RequestSigner, epoch types.EpochID, corruptSignature bool) *ProposalMessage {
 	nodeID := types.BytesToNodeID(signer.PublicKey().Bytes())
-	sig := buildSignedProposal(context.TODO(), vrfSigner, epoch, logtest.New(t))
+	sig, err := buildSignedProposal(context.TODO(), vrfSigner, epoch, logtest.New(t))
+	require.NoError(t, err)
 	msg := &ProposalMessage{
 		NodeID:       nodeID,
 		EpochID:      epoch,
@@ -0,0 +1,122 @@
+package beacon
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/events"
+	"github.com/spacemeshos/go-spacemesh/sql/beacons"
+)
+
+// ErrNotFutureEpoch is returned if the manual beacon is set for the epoch that already started.
+var ErrNotFutureEpoch = errors.New("manual beacon can be set only for a future epoch")
+
+// BallotBeacon is a beacon reported by ballots in the epoch.
+type BallotBeacon struct {
+	Beacon  types.Beacon
+	Weight  uint64
+	Ballots int
+}
+
+// BallotBeacons returns beacons reported by ballots for the epoch, ordered by weight.
+func (pd *ProtocolDriver) BallotBeacons(epoch types.EpochID) []BallotBeacon {
+	pd.mu.RLock()
+	defer pd.mu.RUnlock()
+	rst := make([]BallotBeacon, 0, len(pd.beaconsFromBallots[epoch]))
+	for beacon, entry := range pd.beaconsFromBallots[epoch] {
+		rst = append(rst, BallotBeacon{Beacon: beacon, Weight: entry.weight, Ballots: len(entry.ballots)})
+	}
+	sort.Slice(rst, func(i, j int) bool {
+		if rst[i].Weight != rst[j].Weight {
+			return rst[i].Weight > rst[j].Weight
+		}
+		return rst[i].Beacon.String() < rst[j].Beacon.String()
+	})
+	return rst
+}
+
+// SetManualBeacon sets the beacon for the future epoch, replacing the beacon that was computed or adopted
+// for it. Manual beacon is persisted and can't be changed by the protocol.
+// Meant to be used on devnets, when the protocol can't produce the beacon.
+func (pd *ProtocolDriver) SetManualBeacon(epoch types.EpochID, beacon types.Beacon) error {
+	if current := pd.currentEpoch(); epoch <= current {
+		return fmt.Errorf("%w: current epoch %v, requested %v", ErrNotFutureEpoch, current, epoch)
+	}
+	if beacon == types.EmptyBeacon {
+		return errors.New("empty beacon")
+	}
+	if err := beacons.SetManual(pd.cdb, epoch, beacon); err != nil {
+		return err
+	}
+	pd.mu.Lock()
+	pd.beacons[epoch] = beacon
+	pd.mu.Unlock()
+	pd.logger.With().Warning("manual beacon set for epoch", epoch, beacon)
+	events.ReportBeacon(events.EventBeacon{Epoch: epoch, Stage: events.BeaconManual, Beacon: beacon})
+	return nil
+}
+
+// IsManualBeacon returns true if the beacon for the epoch was set by the operator.
+func (pd *ProtocolDriver) IsManualBeacon(epoch types.EpochID) (bool, error) {
+	return beacons.IsManual(pd.cdb, epoch)
+}
+
+// reportProposals reports the number of proposals received in the proposal phase.
+func (pd *ProtocolDriver) reportProposals(epoch types.EpochID) {
+	pd.mu.RLock()
+	s, ok := pd.states[epoch]
+	if !ok {
+		pd.mu.RUnlock()
+		return
+	}
+	ev := events.EventBeacon{
+		Epoch:                     epoch + 1,
+		Stage:                     events.BeaconProposals,
+		ValidProposals:            len(s.incomingProposals.valid),
+		PotentiallyValidProposals: len(s.incomingProposals.potentiallyValid),
+	}
+	pd.mu.RUnlock()
+	events.ReportBeacon(ev)
+}
+
+// reportRound reports votes margins after the round and the weak coin if it was used in the round.
+func (pd *ProtocolDriver) reportRound(epoch types.EpochID, round types.RoundID, coin *bool) {
+	pd.mu.RLock()
+	s, ok := pd.states[epoch]
+	if !ok {
+		pd.mu.RUnlock()
+		return
+	}
+	ev := events.EventBeacon{
+		Epoch:    epoch + 1,
+		Stage:    events.BeaconRound,
+		Round:    round,
+		WeakCoin: coin,
+		Margins:  make([]events.BeaconMargin, 0, len(s.votesMargin)),
+	}
+	for proposal, margin := range s.votesMargin {
+		ev.Margins = append(ev.Margins, events.BeaconMargin{
+			Proposal: []byte(proposal),
+			Margin:   new(big.Int).Set(margin),
+		})
+	}
+	pd.mu.RUnlock()
+	sort.Slice(ev.Margins, func(i, j int) bool {
+		return string(ev.Margins[i].Proposal) < string(ev.Margins[j].Proposal)
+	})
+	events.ReportBeacon(ev)
+}
+
+// reportFromBallots reports the beacon that was adopted from ballots.
+func (pd *ProtocolDriver) reportFromBallots(epoch types.EpochID, beacon types.Beacon) {
+	var weight uint64
+	pd.mu.RLock()
+	if entry, ok := pd.beaconsFromBallots[epoch][beacon]; ok {
+		weight = entry.weight
+	}
+	pd.mu.RUnlock()
+	events.ReportBeacon(events.EventBeacon{Epoch: epoch, Stage: events.BeaconFromBallots, Beacon: beacon, Weight: weight})
+}
@@ -27,6 +27,8 @@ type state struct {
 	hasVoted                  []map[string]struct{}
 	proposalPhaseFinishedTime time.Time
 	proposalChecker           eligibilityChecker
+	// coins are the weak coins of the completed rounds.
+	coins map[types.RoundID]bool
 }
 
 func newState(logger log.Log, cfg Config, epochWeight uint64, atxs []types.ATXID) *state {
@@ -39,6 +41,7 @@ func newState(logger log.Log, cfg Config, epochWeight uint64, atxs []types.ATXID
 		hasProposed:             make(map[string]struct{}),
 		hasVoted:                make([]map[string]struct{}, cfg.RoundsNumber),
 		proposalChecker:         createProposalChecker(logger, cfg.Kappa, cfg.Q, epochWeight),
+		coins:                   make(map[types.RoundID]bool),
 	}
 }
 
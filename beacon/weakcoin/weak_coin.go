@@ -178,7 +178,7 @@ func WithVerifier(v signing.Verifier) Option {
 // New creates an instance of weak coin protocol.
 func New(
 	publisher pubsub.Publisher,
-	signer signing.Signer,
+	signer signing.RequestSigner,
 	opts ...Option,
 ) *WeakCoin {
 	wc := &WeakCoin{
@@ -204,7 +204,7 @@ type WeakCoin struct {
 	logger    log.Log
 	config    config
 	verifier  signing.Verifier
-	signer    signing.Signer
+	signer    signing.RequestSigner
 	publisher pubsub.Publisher
 
 	mu                         sync.RWMutex
@@ -300,7 +300,7 @@ func (wc *WeakCoin) updateProposal(ctx context.Context, message Message) error {
 	return nil
 }
 
-func (wc *WeakCoin) prepareProposal(epoch types.EpochID, round types.RoundID) (broadcast, smallest []byte) {
+func (wc *WeakCoin) prepareProposal(epoch types.EpochID, round types.RoundID) (broadcast, smallest []byte, err error) {
 	// TODO(dshulyak) double check that 10 means that 10 units are allowed
 	allowed, exists := wc.allowances[string(wc.signer.PublicKey().Bytes())]
 	if !exists {
@@ -308,7 +308,15 @@ func (wc *WeakCoin) prepareProposal(epoch types.EpochID, round types.RoundID) (b
 	}
 	for unit := uint64(0); unit < allowed; unit++ {
 		proposal := wc.encodeProposal(epoch, round, unit)
-		signature := wc.signer.Sign(proposal)
+		signature, err := wc.signer.SignRequest(signing.Request{
+			Domain:  signing.WEAK_COIN,
+			Epoch:   uint32(epoch),
+			Round:   uint32(round),
+			Message: proposal,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("sign weak coin proposal: %w", err)
+		}
 		if wc.aboveThreshold(signature) {
 			continue
 		}
@@ -334,8 +342,11 @@ func (wc *WeakCoin) prepareProposal(epoch types.EpochID, round types.RoundID) (b
 
 func (wc *WeakCoin) publishProposal(ctx context.Context, epoch types.EpochID, round types.RoundID) error {
 	wc.mu.Lock()
-	msg, smallest := wc.prepareProposal(epoch, round)
+	msg, smallest, err := wc.prepareProposal(epoch, round)
 	wc.mu.Unlock()
+	if err != nil {
+		return err
+	}
 
 	// nothing to send is valid if all proposals are exceeding threshold
 	if msg == nil {
@@ -34,10 +34,10 @@ func broadcastedMessage(tb testing.TB, msg weakcoin.Message) []byte {
 	return buf
 }
 
-func staticSigner(tb testing.TB, ctrl *gomock.Controller, sig []byte) *smocks.MockSigner {
+func staticSigner(tb testing.TB, ctrl *gomock.Controller, sig []byte) *smocks.MockRequestSigner {
 	tb.Helper()
-	signer := smocks.NewMockSigner(ctrl)
-	signer.EXPECT().Sign(gomock.Any()).Return(sig).AnyTimes()
+	signer := smocks.NewMockRequestSigner(ctrl)
+	signer.EXPECT().SignRequest(gomock.Any()).Return(sig, nil).AnyTimes()
 	signer.EXPECT().PublicKey().Return(signing.NewPublicKey(sig)).AnyTimes()
 	signer.EXPECT().LittleEndian().Return(true).AnyTimes()
 	return signer
@@ -90,7 +90,7 @@ type Certifier struct {
 	db         *sql.Database
 	oracle     hare.Rolacle
 	nodeID     types.NodeID
-	signer     *signing.EdSigner
+	signer     signing.RequestSigner
 	publisher  pubsub.Publisher
 	layerClock layerClock
 	beacon     system.BeaconGetter
@@ -102,7 +102,7 @@ type Certifier struct {
 
 // NewCertifier creates new block certifier.
 func NewCertifier(
-	db *sql.Database, o hare.Rolacle, n types.NodeID, s *signing.EdSigner, p pubsub.Publisher, lc layerClock, b system.BeaconGetter, tortoise system.Tortoise,
+	db *sql.Database, o hare.Rolacle, n types.NodeID, s signing.RequestSigner, p pubsub.Publisher, lc layerClock, b system.BeaconGetter, tortoise system.Tortoise,
 	opts ...CertifierOpt,
 ) *Certifier {
 	c := &Certifier{
@@ -234,7 +234,15 @@ func (c *Certifier) CertifyIfEligible(ctx context.Context, logger log.Log, lid t
 			Proof:          proof,
 		},
 	}
-	msg.Signature = c.signer.Sign(msg.Bytes())
+	msg.Signature, err = c.signer.SignRequest(signing.Request{
+		Domain:  signing.CERTIFICATE,
+		Layer:   lid.Value,
+		Message: msg.Bytes(),
+	})
+	if err != nil {
+		logger.With().Error("failed to sign certify message", log.Err(err))
+		return err
+	}
 	data, err := codec.Encode(&msg)
 	if err != nil {
 		logger.With().Panic("failed to serialize certify message", log.Err(err))
@@ -212,7 +212,7 @@ func (g *Generator) generateBlock(ctx context.Context, layerID types.LayerID, pr
 		logger.With().Error("failed to select block txs", layerID, log.Err(err))
 		return nil, fmt.Errorf("select block txs: %w", err)
 	}
-	tickHeight, rewards, err := g.extractCoinbasesAndHeight(logger, proposals)
+	tickHeight, rewards, err := g.extractCoinbasesAndHeight(logger, layerID, proposals)
 	if err != nil {
 		return nil, err
 	}
@@ -229,9 +229,10 @@ func (g *Generator) generateBlock(ctx context.Context, layerID types.LayerID, pr
 	return b, nil
 }
 
-func (g *Generator) extractCoinbasesAndHeight(logger log.Log, props []*types.Proposal) (uint64, []types.AnyReward, error) {
-	weights := make(map[types.Address]util.Weight)
-	coinbases := make([]types.Address, 0, len(props))
+func (g *Generator) extractCoinbasesAndHeight(logger log.Log, lid types.LayerID, props []*types.Proposal) (uint64, []types.AnyReward, error) {
+	weights := make(map[types.ATXID]util.Weight)
+	coinbases := make(map[types.ATXID]types.Address)
+	atxids := make([]types.ATXID, 0, len(props))
 	max := uint64(0)
 	for _, p := range props {
 		if p.AtxID == *types.EmptyATXID {
@@ -240,13 +241,13 @@ func (g *Generator) extractCoinbasesAndHeight(logger log.Log, props []*types.Pro
 			return 0, nil, errInvalidATXID
 		}
 		atx, err := g.cdb.GetAtxHeader(p.AtxID)
-		if atx.BaseTickHeight > max {
-			max = atx.BaseTickHeight
-		}
 		if err != nil {
 			logger.With().Warning("proposal ATX not found", p.ID(), p.AtxID, log.Err(err))
 			return 0, nil, fmt.Errorf("block gen get ATX: %w", err)
 		}
+		if atx.BaseTickHeight > max {
+			max = atx.BaseTickHeight
+		}
 		ballot := &p.Ballot
 		weightPer, err := proposals.ComputeWeightPerEligibility(g.cdb, ballot, g.cfg.LayerSize, g.cfg.LayersPerEpoch)
 		if err != nil {
@@ -255,24 +256,65 @@ func (g *Generator) extractCoinbasesAndHeight(logger log.Log, props []*types.Pro
 		}
 		logger.With().Debug("weight per eligibility", p.ID(), log.Stringer("weight_per", weightPer))
 		actual := weightPer.Mul(util.WeightFromUint64(uint64(len(ballot.EligibilityProofs))))
-		if _, ok := weights[atx.Coinbase]; !ok {
-			weights[atx.Coinbase] = actual
-			coinbases = append(coinbases, atx.Coinbase)
+		if _, ok := weights[p.AtxID]; !ok {
+			weights[p.AtxID] = actual
+			coinbases[p.AtxID] = atx.Coinbase
+			atxids = append(atxids, p.AtxID)
 		} else {
-			weights[atx.Coinbase].Add(actual)
+			weights[p.AtxID].Add(actual)
 		}
 		events.ReportProposal(events.ProposalIncluded, p)
 	}
-	// make sure we output coinbase in a stable order.
-	sort.Slice(coinbases, func(i, j int) bool {
-		return bytes.Compare(coinbases[i].Bytes(), coinbases[j].Bytes()) < 0
+	if lid.Before(types.GetRewardAtxLayer()) {
+		return max, legacyRewards(logger, atxids, coinbases, weights), nil
+	}
+	// make sure we output rewards in a stable order.
+	sort.Slice(atxids, func(i, j int) bool {
+		return bytes.Compare(atxids[i].Bytes(), atxids[j].Bytes()) < 0
 	})
 	rewards := make([]types.AnyReward, 0, len(weights))
-	for _, coinbase := range coinbases {
-		weight, ok := weights[coinbase]
+	for _, atxid := range atxids {
+		weight, ok := weights[atxid]
 		if !ok {
-			g.logger.With().Fatal("coinbase missing", coinbase)
+			g.logger.With().Fatal("atx missing", atxid)
+		}
+		rewards = append(rewards, types.AnyReward{
+			Coinbase: coinbases[atxid],
+			AtxID:    atxid,
+			Weight: types.RatNum{
+				Num:   weight.Num().Uint64(),
+				Denom: weight.Denom().Uint64(),
+			},
+		})
+		logger.With().Debug("adding coinbase weight",
+			atxid,
+			log.Stringer("coinbase", coinbases[atxid]),
+			log.Stringer("weight", weight))
+	}
+	return max, rewards, nil
+}
+
+// legacyRewards merges rewards of the atxs with the same coinbase, as the blocks in layers
+// before the reward atx layer contain at most one reward per coinbase.
+func legacyRewards(logger log.Log, atxids []types.ATXID, coinbases map[types.ATXID]types.Address, weights map[types.ATXID]util.Weight) []types.AnyReward {
+	merged := make(map[types.Address]util.Weight, len(atxids))
+	order := make([]types.Address, 0, len(atxids))
+	for _, atxid := range atxids {
+		coinbase := coinbases[atxid]
+		if weight, ok := merged[coinbase]; ok {
+			weight.Add(weights[atxid])
+		} else {
+			merged[coinbase] = weights[atxid].Copy()
+			order = append(order, coinbase)
 		}
+	}
+	// make sure we output coinbase in a stable order.
+	sort.Slice(order, func(i, j int) bool {
+		return bytes.Compare(order[i].Bytes(), order[j].Bytes()) < 0
+	})
+	rewards := make([]types.AnyReward, 0, len(order))
+	for _, coinbase := range order {
+		weight := merged[coinbase]
 		rewards = append(rewards, types.AnyReward{
 			Coinbase: coinbase,
 			Weight: types.RatNum{
@@ -284,5 +326,5 @@ func (g *Generator) extractCoinbasesAndHeight(logger log.Log, props []*types.Pro
 			log.Stringer("coinbase", coinbase),
 			log.Stringer("weight", weight))
 	}
-	return max, rewards, nil
+	return rewards
 }
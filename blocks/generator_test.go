@@ -173,10 +173,11 @@ func createProposal(t *testing.T, epochData *types.EpochData, lid types.LayerID,
 func checkRewards(t *testing.T, atxs []*types.ActivationTx, expWeightPer util.Weight, rewards []types.AnyReward) {
 	t.Helper()
 	sort.Slice(atxs, func(i, j int) bool {
-		return bytes.Compare(atxs[i].Coinbase.Bytes(), atxs[j].Coinbase.Bytes()) < 0
+		return bytes.Compare(atxs[i].ID().Bytes(), atxs[j].ID().Bytes()) < 0
 	})
 	for i, r := range rewards {
 		require.Equal(t, atxs[i].Coinbase, r.Coinbase)
+		require.Equal(t, atxs[i].ID(), r.AtxID)
 		got := util.WeightFromNumDenom(r.Weight.Num, r.Weight.Denom)
 		require.Equal(t, expWeightPer, got)
 	}
@@ -440,11 +441,47 @@ func Test_generateBlock_SameCoinbase(t *testing.T) {
 
 	// numUint is the ATX weight. eligible slots per epoch is 3 for each atx, each proposal has 1 eligibility
 	// the expected weight for each eligibility is `numUnit` * 1/3
-	// since there are two proposals for the same coinbase, the final weight is `numUnit` * 1/3 * 2
+	// since there are two proposals for the same atx, the final weight is `numUnit` * 1/3 * 2
 	expWeight := util.WeightFromInt64(numUint * 1 / 3 * 2)
 	checkRewards(t, atxes[0:1], expWeight, block.Rewards)
 }
 
+func Test_generateBlock_LegacyRewards(t *testing.T) {
+	tg := createTestGenerator(t)
+	layerID := types.GetEffectiveGenesis().Add(100)
+	t.Cleanup(func() { types.SetRewardAtxLayer(types.LayerID{}) })
+
+	coinbase := types.Address{1}
+	numProposals := 2
+	txIDs := createTransactions(t, 10)
+	signers, atxes := createModifiedATXs(t, tg.cdb, (layerID.GetEpoch() - 1).FirstLayer(), numProposals,
+		func(atx *types.ActivationTx) (*types.VerifiedActivationTx, error) {
+			atx.Coinbase = coinbase
+			return atx.Verify(0, 1)
+		})
+	plist := createProposals(t, tg.cdb, layerID, signers, types.ToATXIDs(atxes), txIDs)
+	tg.mockCState.EXPECT().SelectBlockTXs(layerID, plist).Return(txIDs, nil).Times(2)
+
+	block, err := tg.generateBlock(context.TODO(), layerID, plist)
+	require.NoError(t, err)
+	require.Len(t, block.Rewards, numProposals)
+	expWeight := util.WeightFromUint64(0)
+	for _, reward := range block.Rewards {
+		require.Equal(t, coinbase, reward.Coinbase)
+		require.NotEqual(t, *types.EmptyATXID, reward.AtxID)
+		expWeight.Add(util.WeightFromNumDenom(reward.Weight.Num, reward.Weight.Denom))
+	}
+
+	// rewards of the atxs with the same coinbase are merged and don't reference atxs
+	types.SetRewardAtxLayer(layerID.Add(1))
+	block, err = tg.generateBlock(context.TODO(), layerID, plist)
+	require.NoError(t, err)
+	require.Len(t, block.Rewards, 1)
+	require.Equal(t, coinbase, block.Rewards[0].Coinbase)
+	require.Equal(t, *types.EmptyATXID, block.Rewards[0].AtxID)
+	require.Equal(t, expWeight, util.WeightFromNumDenom(block.Rewards[0].Weight.Num, block.Rewards[0].Weight.Denom))
+}
+
 func Test_generateBlock_EmptyATXID(t *testing.T) {
 	tg := createTestGenerator(t)
 	layerID := types.GetEffectiveGenesis().Add(100)
@@ -486,13 +523,12 @@ func Test_generateBlock_MultipleEligibilities(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, block.Rewards, len(plist))
 	sort.Slice(plist, func(i, j int) bool {
-		cbi := types.GenerateAddress(plist[i].SmesherID().Bytes())
-		cbj := types.GenerateAddress(plist[j].SmesherID().Bytes())
-		return bytes.Compare(cbi.Bytes(), cbj.Bytes()) < 0
+		return bytes.Compare(plist[i].AtxID.Bytes(), plist[j].AtxID.Bytes()) < 0
 	})
 	totalWeight := util.WeightFromUint64(0)
 	for i, r := range block.Rewards {
 		require.Equal(t, types.GenerateAddress(plist[i].SmesherID().Bytes()), r.Coinbase)
+		require.Equal(t, plist[i].AtxID, r.AtxID)
 		got := util.WeightFromNumDenom(r.Weight.Num, r.Weight.Denom)
 		// numUint is the ATX weight. eligible slots per epoch is 3 for each atx
 		// the expected weight for each eligibility is `numUnit` * 1/3
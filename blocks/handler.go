@@ -65,7 +65,7 @@ func (h *Handler) HandleSyncedBlock(ctx context.Context, data []byte) error {
 	// set the block ID when received
 	b.Initialize()
 
-	if err := vm.ValidateRewards(b.Rewards); err != nil {
+	if err := vm.ValidateRewards(b.LayerIndex, b.Rewards); err != nil {
 		return fmt.Errorf("%w: %s", errInvalidRewards, err.Error())
 	}
 
@@ -80,6 +80,10 @@ func (h *Handler) HandleSyncedBlock(ctx context.Context, data []byte) error {
 	logger.With().Info("new block")
 
 	h.fetcher.AddPeersFromHash(b.ID().AsHash32(), types.TransactionIDsToHashes(b.TxIDs))
+	if err := h.checkRewards(ctx, &b); err != nil {
+		logger.With().Warning("failed to fetch block rewards ATXs", log.Err(err))
+		return err
+	}
 	if err := h.checkTransactions(ctx, &b); err != nil {
 		logger.With().Warning("failed to fetch block TXs", log.Err(err))
 		return err
@@ -93,6 +97,25 @@ func (h *Handler) HandleSyncedBlock(ctx context.Context, data []byte) error {
 	return nil
 }
 
+// checkRewards makes sure that ATXs referenced by rewards are available,
+// as they are needed to attribute rewards to smeshers when the block is applied.
+func (h *Handler) checkRewards(ctx context.Context, b *types.Block) error {
+	atxids := make([]types.ATXID, 0, len(b.Rewards))
+	for _, reward := range b.Rewards {
+		// rewards in layers before the reward atx layer don't reference atxs
+		if reward.AtxID != *types.EmptyATXID {
+			atxids = append(atxids, reward.AtxID)
+		}
+	}
+	if len(atxids) == 0 {
+		return nil
+	}
+	if err := h.fetcher.GetAtxs(ctx, atxids); err != nil {
+		return fmt.Errorf("block get ATXs: %w", err)
+	}
+	return nil
+}
+
 func (h *Handler) checkTransactions(ctx context.Context, b *types.Block) error {
 	if len(b.TxIDs) == 0 {
 		return nil
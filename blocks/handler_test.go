@@ -42,7 +42,7 @@ func createBlockData(t *testing.T, layerID types.LayerID, txIDs []types.Transact
 			LayerIndex: layerID,
 			TxIDs:      txIDs,
 			Rewards: []types.AnyReward{
-				{Weight: types.RatNum{Num: 1, Denom: 1}},
+				{AtxID: types.RandomATXID(), Weight: types.RatNum{Num: 1, Denom: 1}},
 			},
 		},
 	}
@@ -74,6 +74,18 @@ func Test_HandleBlockData_AlreadyHasBlock(t *testing.T) {
 	assert.NoError(t, th.HandleSyncedBlock(context.TODO(), data))
 }
 
+func Test_HandleBlockData_FailedToFetchATXs(t *testing.T) {
+	th := createTestHandler(t)
+	layerID := types.NewLayerID(99)
+	txIDs := createTransactions(t, max(10, rand.Intn(100)))
+
+	block, data := createBlockData(t, layerID, txIDs)
+	errUnknown := errors.New("unknown")
+	th.mockFetcher.EXPECT().GetAtxs(gomock.Any(), []types.ATXID{block.Rewards[0].AtxID}).Return(errUnknown).Times(1)
+	th.mockFetcher.EXPECT().AddPeersFromHash(block.ID().AsHash32(), types.TransactionIDsToHashes(block.TxIDs))
+	assert.ErrorIs(t, th.HandleSyncedBlock(context.TODO(), data), errUnknown)
+}
+
 func Test_HandleBlockData_FailedToFetchTXs(t *testing.T) {
 	th := createTestHandler(t)
 	layerID := types.NewLayerID(99)
@@ -81,6 +93,7 @@ func Test_HandleBlockData_FailedToFetchTXs(t *testing.T) {
 
 	block, data := createBlockData(t, layerID, txIDs)
 	errUnknown := errors.New("unknown")
+	th.mockFetcher.EXPECT().GetAtxs(gomock.Any(), []types.ATXID{block.Rewards[0].AtxID}).Return(nil).Times(1)
 	th.mockFetcher.EXPECT().GetBlockTxs(gomock.Any(), txIDs).Return(errUnknown).Times(1)
 	th.mockFetcher.EXPECT().AddPeersFromHash(block.ID().AsHash32(), types.TransactionIDsToHashes(block.TxIDs))
 	assert.ErrorIs(t, th.HandleSyncedBlock(context.TODO(), data), errUnknown)
@@ -92,6 +105,7 @@ func Test_HandleBlockData_FailedToAddBlock(t *testing.T) {
 	txIDs := createTransactions(t, max(10, rand.Intn(100)))
 
 	block, data := createBlockData(t, layerID, txIDs)
+	th.mockFetcher.EXPECT().GetAtxs(gomock.Any(), []types.ATXID{block.Rewards[0].AtxID}).Return(nil).Times(1)
 	th.mockFetcher.EXPECT().GetBlockTxs(gomock.Any(), txIDs).Return(nil).Times(1)
 	errUnknown := errors.New("unknown")
 	th.mockMesh.EXPECT().AddBlockWithTXs(gomock.Any(), block).Return(errUnknown).Times(1)
@@ -105,6 +119,23 @@ func Test_HandleBlockData(t *testing.T) {
 	txIDs := createTransactions(t, max(10, rand.Intn(100)))
 
 	block, data := createBlockData(t, layerID, txIDs)
+	th.mockFetcher.EXPECT().GetAtxs(gomock.Any(), []types.ATXID{block.Rewards[0].AtxID}).Return(nil).Times(1)
+	th.mockFetcher.EXPECT().GetBlockTxs(gomock.Any(), txIDs).Return(nil).Times(1)
+	th.mockMesh.EXPECT().AddBlockWithTXs(gomock.Any(), block).Return(nil).Times(1)
+	th.mockFetcher.EXPECT().AddPeersFromHash(block.ID().AsHash32(), types.TransactionIDsToHashes(block.TxIDs))
+	assert.NoError(t, th.HandleSyncedBlock(context.TODO(), data))
+}
+
+func Test_HandleBlockData_Legacy(t *testing.T) {
+	th := createTestHandler(t)
+	layerID := types.NewLayerID(99)
+	types.SetRewardAtxLayer(layerID.Add(1))
+	t.Cleanup(func() { types.SetRewardAtxLayer(types.LayerID{}) })
+	txIDs := createTransactions(t, max(10, rand.Intn(100)))
+
+	block, data := createBlockData(t, layerID, txIDs)
+	// rewards in layers before the reward atx layer are decoded without atxs
+	block.Rewards[0].AtxID = *types.EmptyATXID
 	th.mockFetcher.EXPECT().GetBlockTxs(gomock.Any(), txIDs).Return(nil).Times(1)
 	th.mockMesh.EXPECT().AddBlockWithTXs(gomock.Any(), block).Return(nil).Times(1)
 	th.mockFetcher.EXPECT().AddPeersFromHash(block.ID().AsHash32(), types.TransactionIDsToHashes(block.TxIDs))
@@ -188,6 +188,8 @@ func EnsureCLIFlags(cmd *cobra.Command, appCFG *bc.Config) error {
 					val = viper.GetDuration(name)
 				case "map[string]uint64":
 					val = flags.CastStringToMapStringUint64(viper.GetString(name))
+				case "map[string]server.Limits":
+					val = flags.CastStringToMapStringLimits(viper.GetString(name))
 				case "*big.Rat":
 					v, ok := new(big.Rat).SetString(viper.GetString(name))
 					if !ok {
@@ -263,6 +265,10 @@ func EnsureCLIFlags(cmd *cobra.Command, appCFG *bc.Config) error {
 			elem = reflect.ValueOf(&appCFG.SMESHING.Opts).Elem()
 			assignFields(ff, elem, name)
 
+			ff = reflect.TypeOf(appCFG.FETCH)
+			elem = reflect.ValueOf(&appCFG.FETCH).Elem()
+			assignFields(ff, elem, name)
+
 			ff = reflect.TypeOf(appCFG.LOGGING)
 			elem = reflect.ValueOf(&appCFG.LOGGING).Elem()
 			assignFields(ff, elem, name)
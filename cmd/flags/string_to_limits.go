@@ -0,0 +1,120 @@
+package flags
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spacemeshos/go-spacemesh/p2p/server"
+)
+
+const (
+	limitConcurrent     = "concurrent"
+	limitRequests       = "requests"
+	limitBytes          = "bytes"
+	limitPeerConcurrent = "peer-concurrent"
+	limitPeerRequests   = "peer-requests"
+	limitPeerBytes      = "peer-bytes"
+)
+
+// StringToLimitsValue is a flag type for limits of the served protocols.
+type StringToLimitsValue struct {
+	value map[string]server.Limits
+}
+
+// NewStringToLimitsValue creates instance.
+func NewStringToLimitsValue(p map[string]server.Limits) *StringToLimitsValue {
+	return &StringToLimitsValue{value: p}
+}
+
+// Set expects value as "ax/1=concurrent:10;peer-requests:5,hs/1=bytes:1000000".
+// Supported limits are concurrent, requests, bytes, peer-concurrent, peer-requests and peer-bytes.
+func (s *StringToLimitsValue) Set(val string) error {
+	for _, pair := range strings.Split(val, ",") {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("%s must be formatted as protocol=limit:value;limit:value", pair)
+		}
+		var limits server.Limits
+		for _, limit := range strings.Split(parts[1], ";") {
+			if len(strings.TrimSpace(limit)) == 0 {
+				continue
+			}
+			if err := setLimit(&limits, limit); err != nil {
+				return err
+			}
+		}
+		s.value[strings.TrimSpace(parts[0])] = limits
+	}
+	return nil
+}
+
+func setLimit(limits *server.Limits, limit string) error {
+	parts := strings.SplitN(limit, ":", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("%s must be formatted as limit:value", limit)
+	}
+	name, value := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+	var err error
+	switch name {
+	case limitConcurrent:
+		limits.Protocol.Concurrent, err = strconv.Atoi(value)
+	case limitRequests:
+		limits.Protocol.Requests, err = strconv.ParseFloat(value, 64)
+	case limitBytes:
+		limits.Protocol.Bytes, err = strconv.Atoi(value)
+	case limitPeerConcurrent:
+		limits.Peer.Concurrent, err = strconv.Atoi(value)
+	case limitPeerRequests:
+		limits.Peer.Requests, err = strconv.ParseFloat(value, 64)
+	case limitPeerBytes:
+		limits.Peer.Bytes, err = strconv.Atoi(value)
+	default:
+		return fmt.Errorf("unknown limit %s", name)
+	}
+	return err
+}
+
+// Type returns stringToLimits type.
+func (s *StringToLimitsValue) Type() string {
+	return "string=limits"
+}
+
+// String marshals value of the StringToLimitsValue instance.
+func (s *StringToLimitsValue) String() string {
+	protocols := make([]string, 0, len(s.value))
+	for protocol := range s.value {
+		protocols = append(protocols, protocol)
+	}
+	sort.Strings(protocols)
+	pairs := make([]string, 0, len(protocols))
+	for _, protocol := range protocols {
+		limits := s.value[protocol]
+		var fields []string
+		add := func(name, value string, set bool) {
+			if set {
+				fields = append(fields, name+":"+value)
+			}
+		}
+		add(limitConcurrent, strconv.Itoa(limits.Protocol.Concurrent), limits.Protocol.Concurrent != 0)
+		add(limitRequests, strconv.FormatFloat(limits.Protocol.Requests, 'g', -1, 64), limits.Protocol.Requests != 0)
+		add(limitBytes, strconv.Itoa(limits.Protocol.Bytes), limits.Protocol.Bytes != 0)
+		add(limitPeerConcurrent, strconv.Itoa(limits.Peer.Concurrent), limits.Peer.Concurrent != 0)
+		add(limitPeerRequests, strconv.FormatFloat(limits.Peer.Requests, 'g', -1, 64), limits.Peer.Requests != 0)
+		add(limitPeerBytes, strconv.Itoa(limits.Peer.Bytes), limits.Peer.Bytes != 0)
+		pairs = append(pairs, protocol+"="+strings.Join(fields, ";"))
+	}
+	return strings.Join(pairs, ",")
+}
+
+// CastStringToMapStringLimits casts string with comma separated values to map[string]server.Limits.
+// Nil is returned if value fails format validation (consistent with viper behavior).
+func CastStringToMapStringLimits(value string) map[string]server.Limits {
+	val := map[string]server.Limits{}
+	parser := NewStringToLimitsValue(val)
+	if err := parser.Set(value); err != nil {
+		return nil
+	}
+	return val
+}
@@ -0,0 +1,89 @@
+package flags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/p2p/server"
+)
+
+func TestStringToLimitsValue(t *testing.T) {
+	for _, tc := range []struct {
+		desc     string
+		input    []string
+		err      string
+		expected map[string]server.Limits
+	}{
+		{
+			desc:  "Joined",
+			input: []string{"ax/1=concurrent:10;peer-requests:0.5,hs/1=bytes:1000;peer-concurrent:2"},
+			expected: map[string]server.Limits{
+				"ax/1": {
+					Protocol: server.Limit{Concurrent: 10},
+					Peer:     server.Limit{Requests: 0.5},
+				},
+				"hs/1": {
+					Protocol: server.Limit{Bytes: 1000},
+					Peer:     server.Limit{Concurrent: 2},
+				},
+			},
+		},
+		{
+			desc:  "Separate",
+			input: []string{"ax/1=requests:20;peer-bytes:100", "hs/1="},
+			expected: map[string]server.Limits{
+				"ax/1": {
+					Protocol: server.Limit{Requests: 20},
+					Peer:     server.Limit{Bytes: 100},
+				},
+				"hs/1": {},
+			},
+		},
+		{
+			desc:  "NoSeparator",
+			input: []string{"ax/1"},
+			err:   "ax/1 must be formatted as protocol=limit:value;limit:value",
+		},
+		{
+			desc:  "InvalidLimit",
+			input: []string{"ax/1=concurrent"},
+			err:   "concurrent must be formatted as limit:value",
+		},
+		{
+			desc:  "UnknownLimit",
+			input: []string{"ax/1=streams:1"},
+			err:   "unknown limit streams",
+		},
+		{
+			desc:  "InvalidInteger",
+			input: []string{"ax/1=bytes:abc"},
+			err:   "strconv.Atoi: parsing \"abc\": invalid syntax",
+		},
+	} {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			value := map[string]server.Limits{}
+			parser := NewStringToLimitsValue(value)
+			full := ""
+			for _, arg := range tc.input {
+				full += arg + ","
+				err := parser.Set(arg)
+				if len(tc.err) > 0 {
+					require.NotNil(t, err)
+					require.Contains(t, err.Error(), tc.err)
+				} else {
+					require.NoError(t, err)
+				}
+			}
+			full = full[:len(full)-1]
+			if len(tc.err) == 0 {
+				require.Equal(t, tc.expected, value)
+				require.Equal(t, tc.expected, CastStringToMapStringLimits(full))
+				require.Equal(t, tc.expected, CastStringToMapStringLimits(parser.String()))
+			} else {
+				require.Nil(t, CastStringToMapStringLimits(full))
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+// hare-replay replays traces recorded by the hare consensus process (see --hare-trace-dir),
+// and prints report in JSON format that compares recorded and replayed decisions.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/common/util"
+	"github.com/spacemeshos/go-spacemesh/hare"
+	"github.com/spacemeshos/go-spacemesh/log"
+)
+
+var (
+	output   = flag.String("output", "", "path to the file for the report. by default report is printed to stdout")
+	logLevel = flag.String("log-level", "warn", "log level. logs are written to stderr")
+	events   = flag.Bool("events", false, "include recorded events in the report")
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [options] <layer.trace>...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := run(flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+type decision struct {
+	Terminated bool     `json:"terminated"`
+	Completed  bool     `json:"completed"`
+	K          uint32   `json:"k"`
+	Set        []string `json:"set"`
+	Reason     string   `json:"reason,omitempty"`
+	Coinflip   *bool    `json:"coinflip,omitempty"`
+}
+
+type event struct {
+	Type             string        `json:"type"`
+	Elapsed          time.Duration `json:"elapsed"`
+	K                uint32        `json:"k"`
+	Sender           string        `json:"sender,omitempty"`
+	EligibilityCount uint16        `json:"eligibility_count,omitempty"`
+	Set              []string      `json:"set,omitempty"`
+}
+
+type report struct {
+	Path     string   `json:"path"`
+	Layer    uint32   `json:"layer"`
+	Recorded decision `json:"recorded"`
+	Replayed decision `json:"replayed"`
+	Match    bool     `json:"match"`
+	Events   []event  `json:"events,omitempty"`
+}
+
+func run(paths []string) error {
+	level, err := zap.ParseAtomicLevel(*logLevel)
+	if err != nil {
+		return fmt.Errorf("parse log level: %w", err)
+	}
+	logger := log.NewFromLog(zap.New(zapcore.NewCore(
+		zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig()),
+		zapcore.Lock(os.Stderr),
+		level,
+	)))
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	var reports []*report
+	for _, path := range paths {
+		trace, err := hare.ReadTrace(path)
+		if err != nil {
+			return err
+		}
+		rst, err := hare.Replay(ctx, logger.Named(path), trace)
+		if err != nil {
+			return fmt.Errorf("replay %s: %w", path, err)
+		}
+		reports = append(reports, newReport(path, trace, rst))
+	}
+
+	var out io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return fmt.Errorf("create report file: %w", err)
+		}
+		defer f.Close()
+		out = f
+	}
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	if len(reports) == 1 {
+		return enc.Encode(reports[0])
+	}
+	return enc.Encode(reports)
+}
+
+func newReport(path string, trace *hare.Trace, rst *hare.ReplayResult) *report {
+	r := &report{
+		Path:  path,
+		Layer: trace.Layer.Uint32(),
+		Replayed: decision{
+			Terminated: rst.Terminated,
+			Completed:  rst.Completed,
+			K:          rst.K,
+			Set:        setToStrings(rst.Set),
+			Coinflip:   &rst.Coinflip,
+		},
+	}
+	if ev := trace.Termination(); ev != nil {
+		r.Recorded = decision{
+			Terminated: true,
+			Completed:  ev.Completed,
+			K:          ev.K,
+			Set:        setToStrings(ev.Values),
+			Reason:     ev.Reason,
+		}
+	}
+	r.Match = r.Recorded.Completed == r.Replayed.Completed &&
+		(!r.Recorded.Completed || equal(r.Recorded.Set, r.Replayed.Set))
+	if *events {
+		for _, ev := range trace.Events {
+			e := event{
+				Type:             ev.Type.String(),
+				Elapsed:          time.Duration(ev.Elapsed),
+				K:                ev.K,
+				EligibilityCount: ev.EligibilityCount,
+				Set:              setToStrings(ev.Values),
+			}
+			if len(ev.Sender) > 0 {
+				e.Sender = util.Bytes2Hex(ev.Sender)
+			}
+			r.Events = append(r.Events, e)
+		}
+	}
+	return r
+}
+
+func setToStrings(ids []types.ProposalID) []string {
+	rst := make([]string, 0, len(ids))
+	for _, id := range ids {
+		rst = append(rst, id.String())
+	}
+	return rst
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
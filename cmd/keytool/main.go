@@ -0,0 +1,169 @@
+// keytool manages identity keys of the node (key.bin) and of the p2p host (p2p.key).
+//
+//	keytool passwd [options] <key file>  encrypts the key or changes its passphrase
+//	keytool public [options] <key file>  prints the public key of the node or the id of the p2p host
+package main
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/spacemeshos/go-spacemesh/keystore"
+)
+
+const usage = `Usage: %s <command> [options] <key file>
+
+Commands:
+  passwd  encrypt the key with a new passphrase. the current passphrase is required for encrypted keys
+  public  print the public key of the node identity or the id of the p2p identity
+`
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), usage, os.Args[0])
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	var err error
+	switch cmd, args := flag.Arg(0), flag.Args()[1:]; cmd {
+	case "passwd":
+		err = passwd(args)
+	case "public":
+		err = public(args)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func passphraseFlags(fs *flag.FlagSet, prefix string, cfg *keystore.Config) {
+	fs.StringVar(&cfg.PassphraseFile, prefix+"passphrase-file", "", "file with the "+prefix+"passphrase")
+	fs.StringVar(&cfg.PassphraseEnv, prefix+"passphrase-env", "", "environment variable with the "+prefix+"passphrase")
+}
+
+func parse(fs *flag.FlagSet, args []string) (string, error) {
+	if err := fs.Parse(args); err != nil {
+		return "", err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return "", errors.New("key file is required")
+	}
+	return fs.Arg(0), nil
+}
+
+func passwd(args []string) error {
+	var current, next keystore.Config
+	fs := flag.NewFlagSet("passwd", flag.ExitOnError)
+	passphraseFlags(fs, "", &current)
+	passphraseFlags(fs, "new-", &next)
+	scryptN := fs.Int("scrypt-n", keystore.DefaultScryptParams().N, "scrypt cost parameter")
+	path, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	secret := data
+	if keystore.IsEncrypted(data) {
+		current.Prompt = true
+		passphrase, err := current.Passphrase("Current passphrase: ")
+		if err != nil {
+			return err
+		}
+		if secret, err = keystore.Decrypt(data, passphrase); err != nil {
+			return err
+		}
+	}
+	pub, err := publicKey(secret)
+	if err != nil {
+		return err
+	}
+
+	passphrase, err := next.Passphrase("")
+	if err != nil {
+		return err
+	}
+	if passphrase == nil {
+		if passphrase, err = keystore.Prompt("New passphrase: "); err != nil {
+			return err
+		}
+		confirm, err := keystore.Prompt("Repeat new passphrase: ")
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(passphrase, confirm) {
+			return errors.New("passphrases do not match")
+		}
+	}
+	params := keystore.DefaultScryptParams()
+	if err := keystore.WriteFile(path, secret, pub, passphrase, keystore.WithScryptParams(*scryptN, params.R, params.P)); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "%s is encrypted with the new passphrase\n", path)
+	return nil
+}
+
+func public(args []string) error {
+	fs := flag.NewFlagSet("public", flag.ExitOnError)
+	path, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	pub := data
+	if keystore.IsEncrypted(data) {
+		if pub, err = keystore.PublicKey(data); err != nil {
+			return err
+		}
+	} else if pub, err = publicKey(data); err != nil {
+		return err
+	}
+	if len(pub) == ed25519.PublicKeySize {
+		fmt.Println(hex.EncodeToString(pub))
+		return nil
+	}
+	id, err := peer.IDFromBytes(pub)
+	if err != nil {
+		return fmt.Errorf("unknown public key format: %w", err)
+	}
+	fmt.Println(id.Pretty())
+	return nil
+}
+
+// publicKey returns the public part of the node identity (ed25519 private key)
+// or of the p2p identity (json encoded key and id).
+func publicKey(secret []byte) ([]byte, error) {
+	if len(secret) == ed25519.PrivateKeySize {
+		return ed25519.PrivateKey(secret).Public().(ed25519.PublicKey), nil
+	}
+	var info struct {
+		Key []byte
+		ID  peer.ID
+	}
+	if err := json.Unmarshal(secret, &info); err != nil || info.ID == "" {
+		return nil, errors.New("unknown key format")
+	}
+	return []byte(info.ID), nil
+}
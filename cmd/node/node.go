@@ -23,6 +23,8 @@ import (
 	"go.uber.org/zap/zapcore"
 
 	"github.com/spacemeshos/go-spacemesh/activation"
+	"github.com/spacemeshos/go-spacemesh/activation/postservice"
+	atypes "github.com/spacemeshos/go-spacemesh/activation/types"
 	"github.com/spacemeshos/go-spacemesh/api/grpcserver"
 	"github.com/spacemeshos/go-spacemesh/beacon"
 	"github.com/spacemeshos/go-spacemesh/blocks"
@@ -37,8 +39,10 @@ import (
 	vm "github.com/spacemeshos/go-spacemesh/genvm"
 	"github.com/spacemeshos/go-spacemesh/hare"
 	"github.com/spacemeshos/go-spacemesh/hare/eligibility"
+	"github.com/spacemeshos/go-spacemesh/keystore"
 	"github.com/spacemeshos/go-spacemesh/layerpatrol"
 	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/malfeasance"
 	"github.com/spacemeshos/go-spacemesh/mesh"
 	"github.com/spacemeshos/go-spacemesh/metrics"
 	"github.com/spacemeshos/go-spacemesh/miner"
@@ -46,7 +50,9 @@ import (
 	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
 	"github.com/spacemeshos/go-spacemesh/proposals"
 	"github.com/spacemeshos/go-spacemesh/signing"
+	"github.com/spacemeshos/go-spacemesh/signing/remote"
 	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/kvstore"
 	dbmetrics "github.com/spacemeshos/go-spacemesh/sql/metrics"
 	"github.com/spacemeshos/go-spacemesh/syncer"
 	"github.com/spacemeshos/go-spacemesh/system"
@@ -91,6 +97,7 @@ const (
 	VMLogger               = "vm"
 	GRPCLogger             = "grpc"
 	ConStateLogger         = "conState"
+	MalfeasanceLogger      = "malfeasance"
 )
 
 // Cmd is the cobra wrapper for the node, that allows adding parameters to it.
@@ -271,11 +278,15 @@ type App struct {
 	hare             HareService
 	blockGen         *blocks.Generator
 	certifier        *blocks.Certifier
-	postSetupMgr     *activation.PostSetupManager
+	postSetupMgr     activation.PostSetupProvider
+	remotePost       *postservice.Client
+	remoteSigner     *remote.Client
+	keyPassphrase    []byte
 	atxBuilder       *activation.Builder
 	atxHandler       *activation.Handler
 	poetListener     *activation.PoetListener
 	edSgn            *signing.EdSigner
+	identities       []*smesherIdentity // identities that smesh on the node, the primary identity is the first
 	beaconProtocol   *beacon.ProtocolDriver
 	closers          []interface{ Close() }
 	log              log.Log
@@ -292,6 +303,18 @@ type App struct {
 	started chan struct{} // this channel is closed once the app has finished starting
 }
 
+// smesherIdentity is an identity that smeshes on the node. It has its own PoST data,
+// publishes its own ATXs and proposals and participates in hare, while the rest of the node is shared.
+type smesherIdentity struct {
+	name            string // empty for the primary identity
+	signer          signing.RequestSigner
+	vrfSigner       signing.RequestSigner
+	opts            atypes.PostSetupOpts
+	postSetupMgr    activation.PostSetupProvider
+	atxBuilder      *activation.Builder
+	proposalBuilder *miner.ProposalBuilder
+}
+
 func (app *App) introduction() {
 	log.Info("Welcome to Spacemesh. Spacemesh full node is starting...")
 }
@@ -433,20 +456,24 @@ func (app *App) SetLogLevel(name, loglevel string) error {
 func (app *App) initServices(ctx context.Context,
 	nodeID types.NodeID,
 	dbStorepath string,
-	sgn *signing.EdSigner,
+	sgn signing.RequestSigner,
 	layerSize uint32,
 	poetClients []activation.PoetProvingServiceClient,
-	vrfSigner *signing.VRFSigner,
+	vrfSigner signing.RequestSigner,
 	layersPerEpoch uint32, clock TickProvider,
 ) error {
 	app.nodeID = nodeID
 
 	lg := app.log.Named(nodeID.ShortString()).WithFields(nodeID)
 	types.SetLayersPerEpoch(app.Config.LayersPerEpoch)
+	types.SetRewardAtxLayer(types.NewLayerID(app.Config.RewardAtxLayer))
 
 	app.log = app.addLogger(AppLogger, lg)
 
-	sqlDB, err := sql.Open("file:" + filepath.Join(dbStorepath, "state.sql"))
+	sqlDB, err := sql.Open("file:"+filepath.Join(dbStorepath, "state.sql"),
+		sql.WithLogger(app.addLogger(StateDbLogger, lg)),
+		sql.WithSlowQueryThreshold(app.Config.DatabaseSlowQueryThreshold),
+	)
 	if err != nil {
 		return fmt.Errorf("open sqlite db %w", err)
 	}
@@ -518,6 +545,9 @@ func (app *App) initServices(ctx context.Context,
 	trtlCfg.LayerSize = layerSize
 	trtlCfg.BadBeaconVoteDelayLayers = app.Config.LayersPerEpoch
 	trtlCfg.MeshProcessed = processed
+	if err := trtlCfg.Validate(); err != nil {
+		return fmt.Errorf("invalid tortoise config: %w", err)
+	}
 	trtl := tortoise.New(cdb, beaconProtocol, msh,
 		tortoise.WithContext(ctx),
 		tortoise.WithLogger(app.addLogger(TrtlLogger, lg)),
@@ -526,7 +556,7 @@ func (app *App) initServices(ctx context.Context,
 	verifier.Tortoise = trtl
 
 	fetcherWrapped := &layerFetcher{}
-	atxHandler := activation.NewHandler(cdb, fetcherWrapped, layersPerEpoch, app.Config.TickSize, goldenATXID, validator, trtl, app.addLogger(ATXHandlerLogger, lg))
+	atxHandler := activation.NewHandler(cdb, fetcherWrapped, app.host, layersPerEpoch, app.Config.TickSize, goldenATXID, validator, trtl, app.addLogger(ATXHandlerLogger, lg))
 
 	// we can't have an epoch offset which is greater/equal than the number of layers in an epoch
 
@@ -535,7 +565,7 @@ func (app *App) initServices(ctx context.Context,
 			app.Config.HareEligibility.EpochOffset, app.Config.BaseConfig.LayersPerEpoch)
 	}
 
-	proposalListener := proposals.NewHandler(cdb, fetcherWrapped, beaconProtocol, msh, trtl,
+	proposalListener := proposals.NewHandler(cdb, fetcherWrapped, app.host, beaconProtocol, msh, trtl,
 		proposals.WithLogger(app.addLogger(ProposalListenerLogger, lg)),
 		proposals.WithConfig(proposals.Config{
 			LayerSize:      layerSize,
@@ -545,6 +575,8 @@ func (app *App) initServices(ctx context.Context,
 			Hdist:          trtlCfg.Hdist,
 		}))
 
+	malfeasanceHandler := malfeasance.NewHandler(sqlDB, app.addLogger(MalfeasanceLogger, lg), app.host.ID())
+
 	blockHandler := blocks.NewHandler(fetcherWrapped, sqlDB, msh,
 		blocks.WithLogger(app.addLogger(BlockHandlerLogger, lg)))
 
@@ -573,6 +605,8 @@ func (app *App) initServices(ctx context.Context,
 		fetch.WithProposalHandler(proposalListener),
 		fetch.WithTXHandler(txHandler),
 		fetch.WithPoetHandler(poetDb),
+		fetch.WithMalfeasanceHandler(malfeasanceHandler),
+		fetch.WithReputation(app.host.Reputation()),
 	)
 	fetcherWrapped.Fetcher = fetcher
 
@@ -602,31 +636,32 @@ func (app *App) initServices(ctx context.Context,
 		blocks.WithGeneratorLogger(app.addLogger(BlockGenLogger, lg)))
 	rabbit := app.HareFactory(sqlDB, sgn, hareOutputCh, nodeID, patrol, newSyncer, beaconProtocol, hOracle, clock, lg)
 
-	proposalBuilder := miner.NewProposalBuilder(
-		ctx,
-		clock.Subscribe(),
-		sgn,
-		vrfSigner,
-		cdb,
-		app.host,
-		trtl,
-		beaconProtocol,
-		newSyncer,
-		app.conState,
-		miner.WithMinerID(nodeID),
-		miner.WithTxsPerProposal(app.Config.TxsPerProposal),
-		miner.WithLayerSize(layerSize),
-		miner.WithLayerPerEpoch(layersPerEpoch),
-		miner.WithLogger(app.addLogger(ProposalBuilderLogger, lg)))
-
 	poetListener := activation.NewPoetListener(poetDb, app.addLogger(PoetListenerLogger, lg))
 
-	postSetupMgr, err := activation.NewPostSetupManager(nodeID, app.Config.POST, app.addLogger(PostLogger, lg), cdb, goldenATXID)
-	if err != nil {
-		app.log.Panic("failed to create post setup manager: %v", err)
+	primary := &smesherIdentity{signer: sgn, vrfSigner: vrfSigner, opts: app.Config.SMESHING.Opts}
+	if app.Config.SMESHING.RemotePost.Address != "" {
+		// proofs are generated by the post-service that runs next to the post data
+		remotePost, err := postservice.NewClient(app.Config.SMESHING.RemotePost, app.Config.POST, nodeID)
+		if err != nil {
+			return fmt.Errorf("create remote post client: %w", err)
+		}
+		app.remotePost = remotePost
+		primary.postSetupMgr = remotePost
 	}
+	// values stored before identities were added to the keys belong to the primary identity
+	if err := kvstore.MigrateLegacyKeys(sqlDB, nodeID); err != nil {
+		return fmt.Errorf("migrate kvstore keys: %w", err)
+	}
+	app.identities = append([]*smesherIdentity{primary}, app.identities...)
 
-	nipostBuilder := activation.NewNIPostBuilder(nodeID, postSetupMgr, poetClients, poetDb, sqlDB, app.addLogger(NipostBuilderLogger, lg))
+	backupPoets := make([]activation.PoetProvingServiceClient, 0, len(app.Config.BackupPoETServers))
+	for _, address := range app.Config.BackupPoETServers {
+		backupPoets = append(backupPoets, activation.NewPoetClient(address))
+	}
+	nipostOpts := []activation.NIPostBuilderOption{
+		activation.WithPoetRetry(activation.DefaultPoetRetryConfig()),
+		activation.WithBackupPoets(backupPoets),
+	}
 
 	var coinbaseAddr types.Address
 	if app.Config.SMESHING.Start {
@@ -644,14 +679,49 @@ func (app *App) initServices(ctx context.Context,
 		GoldenATXID:     goldenATXID,
 		LayersPerEpoch:  layersPerEpoch,
 	}
-	atxBuilder := activation.NewBuilder(builderConfig, nodeID, sgn, cdb, atxHandler, app.host, nipostBuilder,
-		postSetupMgr, clock, newSyncer, app.addLogger("atxBuilder", lg),
-		activation.WithContext(ctx),
-		activation.WithPoetConfig(activation.PoetConfig{
-			PhaseShift:  app.Config.POET.PhaseShift,
-			CycleGap:    app.Config.POET.CycleGap,
-			GracePeriod: app.Config.POET.GracePeriod,
-		}))
+	for _, identity := range app.identities {
+		id := types.BytesToNodeID(identity.signer.PublicKey().Bytes())
+		ilg := lg
+		if identity != primary {
+			ilg = lg.WithName(identity.name).WithFields(log.Stringer("identity", id))
+		}
+		if identity.postSetupMgr == nil {
+			mgr, err := activation.NewPostSetupManager(id, app.Config.POST, app.addLogger(PostLogger, ilg), cdb, goldenATXID)
+			if err != nil {
+				return fmt.Errorf("create post setup manager for identity %s: %w", id, err)
+			}
+			identity.postSetupMgr = mgr
+		}
+		nipostBuilder := activation.NewNIPostBuilder(id, identity.postSetupMgr, poetClients, poetDb, sqlDB, app.addLogger(NipostBuilderLogger, ilg), nipostOpts...)
+		identity.atxBuilder = activation.NewBuilder(builderConfig, id, identity.signer, cdb, atxHandler, app.host, nipostBuilder,
+			identity.postSetupMgr, clock, newSyncer, app.addLogger("atxBuilder", ilg),
+			activation.WithContext(ctx),
+			activation.WithPoetConfig(activation.PoetConfig{
+				PhaseShift:  app.Config.POET.PhaseShift,
+				CycleGap:    app.Config.POET.CycleGap,
+				GracePeriod: app.Config.POET.GracePeriod,
+			}))
+		identity.proposalBuilder = miner.NewProposalBuilder(
+			ctx,
+			clock.Subscribe(),
+			identity.signer,
+			identity.vrfSigner,
+			cdb,
+			app.host,
+			trtl,
+			beaconProtocol,
+			newSyncer,
+			app.conState,
+			miner.WithMinerID(id),
+			miner.WithTxsPerProposal(app.Config.TxsPerProposal),
+			miner.WithLayerSize(layerSize),
+			miner.WithLayerPerEpoch(layersPerEpoch),
+			miner.WithLogger(app.addLogger(ProposalBuilderLogger, ilg)))
+		// hare instance is created for the primary identity
+		if ha, ok := rabbit.(*hare.Hare); ok && identity != primary {
+			ha.AddIdentity(identity.signer, hOracle.ForIdentity(identity.vrfSigner))
+		}
+	}
 
 	syncHandler := func(_ context.Context, _ p2p.Peer, _ []byte) pubsub.ValidationResult {
 		if newSyncer.ListenToGossip() {
@@ -668,14 +738,13 @@ func (app *App) initServices(ctx context.Context,
 	app.host.Register(pubsub.BeaconFollowingVotesProtocol,
 		pubsub.ChainGossipHandler(syncHandler, beaconProtocol.HandleFollowingVotes))
 	app.host.Register(pubsub.ProposalProtocol, pubsub.ChainGossipHandler(syncHandler, proposalListener.HandleProposal))
-	app.host.Register(pubsub.AtxProtocol, pubsub.ChainGossipHandler(
-		func(_ context.Context, _ p2p.Peer, _ []byte) pubsub.ValidationResult {
-			if newSyncer.ListenToATXGossip() {
-				return pubsub.ValidationAccept
-			}
-			return pubsub.ValidationIgnore
-		},
-		atxHandler.HandleGossipAtx))
+	atxSyncHandler := func(_ context.Context, _ p2p.Peer, _ []byte) pubsub.ValidationResult {
+		if newSyncer.ListenToATXGossip() {
+			return pubsub.ValidationAccept
+		}
+		return pubsub.ValidationIgnore
+	}
+	app.host.Register(pubsub.AtxProtocol, pubsub.ChainGossipHandler(atxSyncHandler, atxHandler.HandleGossipAtx))
 	app.host.Register(pubsub.TxProtocol, pubsub.ChainGossipHandler(syncHandler, txHandler.HandleGossipTransaction))
 	app.host.Register(pubsub.PoetProofProtocol, poetListener.HandlePoetProofMessage)
 	hareGossipHandler := rabbit.GetHareMsgHandler()
@@ -684,8 +753,10 @@ func (app *App) initServices(ctx context.Context,
 	}
 	app.host.Register(pubsub.HareProtocol, pubsub.ChainGossipHandler(syncHandler, hareGossipHandler))
 	app.host.Register(pubsub.BlockCertify, pubsub.ChainGossipHandler(syncHandler, app.certifier.HandleCertifyMessage))
+	// proofs are produced by atx handler as well, so they are accepted as long as atxs are accepted
+	app.host.Register(pubsub.MalfeasanceProtocol, pubsub.ChainGossipHandler(atxSyncHandler, malfeasanceHandler.HandleMalfeasanceProof))
 
-	app.proposalBuilder = proposalBuilder
+	app.proposalBuilder = primary.proposalBuilder
 	app.proposalListener = proposalListener
 	app.mesh = msh
 	app.syncer = newSyncer
@@ -693,8 +764,8 @@ func (app *App) initServices(ctx context.Context,
 	app.svm = state
 	app.hare = rabbit
 	app.poetListener = poetListener
-	app.atxBuilder = atxBuilder
-	app.postSetupMgr = postSetupMgr
+	app.atxBuilder = primary.atxBuilder
+	app.postSetupMgr = primary.postSetupMgr
 	app.atxHandler = atxHandler
 	app.fetcher = fetcher
 	app.beaconProtocol = beaconProtocol
@@ -758,8 +829,10 @@ func (app *App) startServices(ctx context.Context) error {
 	if err := app.hare.Start(ctx); err != nil {
 		return fmt.Errorf("cannot start hare: %w", err)
 	}
-	if err := app.proposalBuilder.Start(ctx); err != nil {
-		return fmt.Errorf("cannot start block producer: %w", err)
+	for _, identity := range app.identities {
+		if err := identity.proposalBuilder.Start(ctx); err != nil {
+			return fmt.Errorf("cannot start block producer for identity %s: %w", identity.signer.PublicKey().ShortString(), err)
+		}
 	}
 
 	if app.Config.SMESHING.Start {
@@ -767,11 +840,14 @@ func (app *App) startServices(ctx context.Context) error {
 		if err != nil {
 			app.log.Panic("failed to parse CoinbaseAccount address on start `%s`: %v", app.Config.SMESHING.CoinbaseAccount, err)
 		}
-		go func() {
-			if err := app.atxBuilder.StartSmeshing(coinbaseAddr, app.Config.SMESHING.Opts); err != nil {
-				log.Panic("failed to start smeshing: %v", err)
-			}
-		}()
+		for _, identity := range app.identities {
+			identity := identity
+			go func() {
+				if err := identity.atxBuilder.StartSmeshing(coinbaseAddr, identity.opts); err != nil {
+					log.Panic("failed to start smeshing for identity %s: %v", identity.signer.PublicKey().ShortString(), err)
+				}
+			}()
+		}
 	} else {
 		log.Info("smeshing not started, waiting to be triggered via smesher api")
 	}
@@ -814,7 +890,7 @@ func (app *App) startAPIServices(ctx context.Context) {
 
 	// Register the requested services one by one
 	if apiConf.StartDebugService {
-		registerService(grpcserver.NewDebugService(app.conState, app.host))
+		registerService(grpcserver.NewDebugService(app.db, app.conState, app.host, app.tortoise, apiConf.AdminToken))
 	}
 	if apiConf.StartGatewayService {
 		registerService(grpcserver.NewGatewayService(app.host))
@@ -831,11 +907,23 @@ func (app *App) startAPIServices(ctx context.Context) {
 		app.closers = append(app.closers, nodeService)
 	}
 	if apiConf.StartSmesherService {
-		registerService(grpcserver.NewSmesherService(app.postSetupMgr, app.atxBuilder))
+		identities := make([]grpcserver.SmesherIdentity, 0, len(app.identities))
+		for _, identity := range app.identities {
+			identities = append(identities, grpcserver.SmesherIdentity{
+				ID:       identity.atxBuilder.SmesherID(),
+				Post:     identity.postSetupMgr,
+				Smeshing: identity.atxBuilder,
+				Opts:     identity.opts,
+			})
+		}
+		registerService(grpcserver.NewSmesherService(app.postSetupMgr, app.atxBuilder, identities...))
 	}
 	if apiConf.StartTransactionService {
 		registerService(grpcserver.NewTransactionService(app.db, app.host, app.mesh, app.conState, app.syncer))
 	}
+	if apiConf.StartBeaconService {
+		registerService(grpcserver.NewBeaconService(app.beaconProtocol, apiConf.AdminToken))
+	}
 
 	// Now that the services are registered, start the server.
 	if app.grpcAPIService != nil {
@@ -871,9 +959,11 @@ func (app *App) stopServices() {
 		_ = app.grpcAPIService.Close()
 	}
 
-	if app.proposalBuilder != nil {
-		app.log.Info("closing proposal builder")
-		app.proposalBuilder.Close()
+	app.log.Info("closing proposal builders")
+	for _, identity := range app.identities {
+		if identity.proposalBuilder != nil {
+			identity.proposalBuilder.Close()
+		}
 	}
 
 	if app.clock != nil {
@@ -886,9 +976,14 @@ func (app *App) stopServices() {
 		app.beaconProtocol.Close()
 	}
 
-	if app.atxBuilder != nil {
-		app.log.Info("closing atx builder")
-		_ = app.atxBuilder.StopSmeshing(false)
+	app.log.Info("closing atx builders")
+	for _, identity := range app.identities {
+		if identity.atxBuilder != nil {
+			_ = identity.atxBuilder.StopSmeshing(false)
+		}
+	}
+	if app.remotePost != nil {
+		_ = app.remotePost.Close()
 	}
 
 	if app.hare != nil {
@@ -935,6 +1030,9 @@ func (app *App) stopServices() {
 			app.log.With().Warning("db exited with error", log.Err(err))
 		}
 	}
+	if app.remoteSigner != nil {
+		_ = app.remoteSigner.Close()
+	}
 
 	events.CloseEventReporter()
 
@@ -948,10 +1046,37 @@ func (app *App) stopServices() {
 
 // LoadOrCreateEdSigner either loads a previously created ed identity for the node or creates a new one if not exists.
 func (app *App) LoadOrCreateEdSigner() (*signing.EdSigner, error) {
-	filename := filepath.Join(app.Config.SMESHING.Opts.DataDir, edKeyFileName)
+	return app.loadOrCreateEdSigner(app.Config.SMESHING.Opts.DataDir)
+}
+
+// loadIdentities loads or creates additional smeshing identities from the subdirectories of the smeshing data dir.
+func (app *App) loadIdentities() ([]*smesherIdentity, error) {
+	identities := make([]*smesherIdentity, 0, len(app.Config.SMESHING.Identities))
+	seen := make(map[string]struct{}, len(app.Config.SMESHING.Identities))
+	for _, name := range app.Config.SMESHING.Identities {
+		if name == "" || name == "." || name == ".." || filepath.Base(name) != name {
+			return nil, fmt.Errorf("invalid identity name %q", name)
+		}
+		if _, exist := seen[name]; exist {
+			return nil, fmt.Errorf("duplicate identity name %q", name)
+		}
+		seen[name] = struct{}{}
+		opts := app.Config.SMESHING.Opts
+		opts.DataDir = filepath.Join(opts.DataDir, name)
+		signer, err := app.loadOrCreateEdSigner(opts.DataDir)
+		if err != nil {
+			return nil, fmt.Errorf("identity %s: %w", name, err)
+		}
+		identities = append(identities, &smesherIdentity{name: name, signer: signer, vrfSigner: signer.VRFSigner(), opts: opts})
+	}
+	return identities, nil
+}
+
+func (app *App) loadOrCreateEdSigner(dir string) (*signing.EdSigner, error) {
+	filename := filepath.Join(dir, edKeyFileName)
 	log.Info("Looking for identity file at `%v`", filename)
 
-	data, err := os.ReadFile(filename)
+	data, encrypted, err := keystore.ReadFile(filename, app.keyPassphrase)
 	if err != nil {
 		if !os.IsNotExist(err) {
 			return nil, fmt.Errorf("failed to read identity file: %w", err)
@@ -964,18 +1089,24 @@ func (app *App) LoadOrCreateEdSigner() (*signing.EdSigner, error) {
 		if err != nil {
 			return nil, fmt.Errorf("failed to create directory for identity file: %w", err)
 		}
-		err = os.WriteFile(filename, edSgn.ToBuffer(), 0o600)
+		err = keystore.WriteFile(filename, edSgn.ToBuffer(), edSgn.PublicKey().Bytes(), app.keyPassphrase)
 		if err != nil {
 			return nil, fmt.Errorf("failed to write identity file: %w", err)
 		}
 
-		log.With().Info("created new identity", edSgn.PublicKey())
+		log.With().Info("created new identity", edSgn.PublicKey(), log.Bool("encrypted", len(app.keyPassphrase) > 0))
 		return edSgn, nil
 	}
 	edSgn, err := signing.NewEdSignerFromBuffer(data, signing.WithSignerPrefix(app.Config.Genesis.GenesisID().Bytes()))
 	if err != nil {
 		return nil, fmt.Errorf("failed to construct identity from data file: %w", err)
 	}
+	if !encrypted && len(app.keyPassphrase) > 0 {
+		if err := keystore.WriteFile(filename, data, edSgn.PublicKey().Bytes(), app.keyPassphrase); err != nil {
+			return nil, fmt.Errorf("failed to encrypt identity file: %w", err)
+		}
+		log.With().Info("encrypted plaintext identity file", log.String("path", filename))
+	}
 
 	log.Info("Loaded existing identity; public key: %v", edSgn.PublicKey())
 
@@ -1048,20 +1179,40 @@ func (app *App) Start() error {
 
 	/* Create or load miner identity */
 
-	app.edSgn, err = app.LoadOrCreateEdSigner()
+	app.keyPassphrase, err = app.Config.KEYSTORE.Passphrase("Enter passphrase for the identity keys: ")
 	if err != nil {
-		return fmt.Errorf("could not retrieve identity: %w", err)
+		return fmt.Errorf("could not read keystore passphrase: %w", err)
+	}
+	var sgn, vrfSigner signing.RequestSigner
+	if app.Config.SIGNER.Socket != "" {
+		// the identity key is stored by the signer, which also protects from signing equivocating messages
+		if len(app.Config.SMESHING.Identities) > 0 {
+			return errors.New("additional smeshing identities are not supported with a remote signer")
+		}
+		app.remoteSigner, err = remote.NewClient(ctx, app.Config.SIGNER)
+		if err != nil {
+			return fmt.Errorf("could not connect to signer: %w", err)
+		}
+		log.Info("using remote signer; public key: %v", app.remoteSigner.PublicKey())
+		sgn, vrfSigner = app.remoteSigner, app.remoteSigner
+	} else {
+		app.edSgn, err = app.LoadOrCreateEdSigner()
+		if err != nil {
+			return fmt.Errorf("could not retrieve identity: %w", err)
+		}
+		app.identities, err = app.loadIdentities()
+		if err != nil {
+			return fmt.Errorf("could not retrieve smeshing identities: %w", err)
+		}
+		sgn, vrfSigner = app.edSgn, app.edSgn.VRFSigner()
 	}
 
 	poetClients := make([]activation.PoetProvingServiceClient, 0, len(app.Config.PoETServers))
 	for _, address := range app.Config.PoETServers {
-		poetClients = append(poetClients, activation.NewHTTPPoetClient(address))
+		poetClients = append(poetClients, activation.NewPoetClient(address))
 	}
 
-	edPubkey := app.edSgn.PublicKey()
-	vrfSigner := app.edSgn.VRFSigner()
-
-	nodeID := types.BytesToNodeID(edPubkey.Bytes())
+	nodeID := types.BytesToNodeID(sgn.PublicKey().Bytes())
 
 	lg := logger.Named(nodeID.ShortString()).WithFields(nodeID)
 
@@ -1084,6 +1235,7 @@ func (app *App) Start() error {
 	cfg.LogLevel = app.getLevel(P2PLogger)
 	app.host, err = p2p.New(ctx, p2plog, cfg, app.Config.Genesis.GenesisID(),
 		p2p.WithNodeReporter(events.ReportNodeStatusUpdate),
+		p2p.WithIdentityOpts(p2p.WithPassphrase(app.keyPassphrase)),
 	)
 	if err != nil {
 		return fmt.Errorf("failed to initialize p2p host: %w", err)
@@ -1092,7 +1244,7 @@ func (app *App) Start() error {
 	if err = app.initServices(ctx,
 		nodeID,
 		dbStorepath,
-		app.edSgn,
+		sgn,
 		uint32(app.Config.LayerAvgSize),
 		poetClients,
 		vrfSigner,
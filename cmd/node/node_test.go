@@ -45,6 +45,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/events"
 	"github.com/spacemeshos/go-spacemesh/genvm/sdk"
 	"github.com/spacemeshos/go-spacemesh/genvm/sdk/wallet"
+	"github.com/spacemeshos/go-spacemesh/keystore"
 	"github.com/spacemeshos/go-spacemesh/log"
 	"github.com/spacemeshos/go-spacemesh/log/logtest"
 	"github.com/spacemeshos/go-spacemesh/p2p"
@@ -97,6 +98,69 @@ func TestSpacemeshApp_getEdIdentity(t *testing.T) {
 	r.NotEqual(signer1.PublicKey(), signer3.PublicKey())
 }
 
+func TestSpacemeshApp_EncryptedEdSigner(t *testing.T) {
+	tempdir := t.TempDir()
+	app := New(WithLog(logtest.New(t)))
+	app.Config.SMESHING.Opts.DataDir = tempdir
+
+	plain, err := app.LoadOrCreateEdSigner()
+	require.NoError(t, err)
+
+	// plaintext key is encrypted when loaded with a passphrase
+	app.keyPassphrase = []byte("passphrase")
+	encrypted, err := app.LoadOrCreateEdSigner()
+	require.NoError(t, err)
+	require.Equal(t, plain.PublicKey(), encrypted.PublicKey())
+	data, err := os.ReadFile(filepath.Join(tempdir, edKeyFileName))
+	require.NoError(t, err)
+	require.True(t, keystore.IsEncrypted(data))
+	public, err := keystore.PublicKey(data)
+	require.NoError(t, err)
+	require.Equal(t, plain.PublicKey().Bytes(), public)
+
+	loaded, err := app.LoadOrCreateEdSigner()
+	require.NoError(t, err)
+	require.Equal(t, plain.PublicKey(), loaded.PublicKey())
+
+	app.keyPassphrase = []byte("wrong")
+	_, err = app.LoadOrCreateEdSigner()
+	require.ErrorIs(t, err, keystore.ErrWrongPassphrase)
+	app.keyPassphrase = nil
+	_, err = app.LoadOrCreateEdSigner()
+	require.ErrorIs(t, err, keystore.ErrPassphraseRequired)
+}
+
+func TestSpacemeshApp_LoadIdentities(t *testing.T) {
+	tempdir := t.TempDir()
+	app := New(WithLog(logtest.New(t)))
+	app.Config.SMESHING.Opts.DataDir = tempdir
+	app.Config.SMESHING.Identities = []string{"first", "second"}
+
+	primary, err := app.LoadOrCreateEdSigner()
+	require.NoError(t, err)
+	identities, err := app.loadIdentities()
+	require.NoError(t, err)
+	require.Len(t, identities, 2)
+	for i, identity := range identities {
+		require.Equal(t, filepath.Join(tempdir, app.Config.SMESHING.Identities[i]), identity.opts.DataDir)
+		require.FileExists(t, filepath.Join(identity.opts.DataDir, edKeyFileName))
+		require.NotEqual(t, primary.PublicKey(), identity.signer.PublicKey())
+	}
+	require.NotEqual(t, identities[0].signer.PublicKey(), identities[1].signer.PublicKey())
+
+	loaded, err := app.loadIdentities()
+	require.NoError(t, err)
+	for i := range loaded {
+		require.Equal(t, identities[i].signer.PublicKey(), loaded[i].signer.PublicKey())
+	}
+
+	for _, names := range [][]string{{"first", "first"}, {"../first"}, {""}} {
+		app.Config.SMESHING.Identities = names
+		_, err := app.loadIdentities()
+		require.Error(t, err, names)
+	}
+}
+
 func newLogger(buf *bytes.Buffer) log.Log {
 	lvl := zap.NewAtomicLevelAt(zapcore.InfoLevel)
 	syncer := zapcore.AddSync(buf)
@@ -0,0 +1,133 @@
+// post-service runs next to the Post data of a node and generates proofs for it over gRPC.
+// The node connects to it with mutual TLS (see --smeshing-remote-post-address).
+// Missing Post data is initialized on start.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+
+	"github.com/spacemeshos/go-spacemesh/activation"
+	"github.com/spacemeshos/go-spacemesh/activation/postservice"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/common/util"
+	"github.com/spacemeshos/go-spacemesh/log"
+)
+
+var (
+	postCfg = activation.DefaultPostConfig()
+	opts    = activation.DefaultPostSetupOpts()
+	tlsCfg  postservice.TLSConfig
+
+	listen        = flag.String("listen", "0.0.0.0:9094", "address to serve the post service on")
+	nodeID        = flag.String("node-id", "", "hex encoded id of the node the post data belongs to")
+	commitmentAtx = flag.String("commitment-atx", "", "hex encoded id of the commitment atx of the post data")
+	logLevel      = flag.String("log-level", "info", "log level. logs are written to stderr")
+)
+
+func init() {
+	flag.StringVar(&opts.DataDir, "datadir", opts.DataDir, "directory of the post data")
+	flag.Func("numunits", "number of post data units", uint32Flag(&opts.NumUnits))
+	flag.Func("numfiles", "number of files the post data is split into", uint32Flag(&opts.NumFiles))
+	flag.IntVar(&opts.ComputeProviderID, "provider", opts.ComputeProviderID, "compute provider used to initialize the post data")
+
+	flag.Func("post-k1", "", uint32Flag(&postCfg.K1))
+	flag.Func("post-k2", "", uint32Flag(&postCfg.K2))
+	flag.Uint64Var(&postCfg.LabelsPerUnit, "post-labels-per-unit", postCfg.LabelsPerUnit, "")
+	flag.Func("post-min-numunits", "", uint32Flag(&postCfg.MinNumUnits))
+	flag.Func("post-max-numunits", "", uint32Flag(&postCfg.MaxNumUnits))
+
+	flag.StringVar(&tlsCfg.Cert, "tls-cert", "", "PEM encoded certificate of the service")
+	flag.StringVar(&tlsCfg.Key, "tls-key", "", "PEM encoded key of the service")
+	flag.StringVar(&tlsCfg.CA, "tls-ca", "", "PEM encoded certificate authority of the node certificates")
+}
+
+func uint32Flag(v *uint32) func(string) error {
+	return func(s string) error {
+		parsed, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			return err
+		}
+		*v = uint32(parsed)
+		return nil
+	}
+}
+
+func main() {
+	flag.Parse()
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	level, err := zap.ParseAtomicLevel(*logLevel)
+	if err != nil {
+		return fmt.Errorf("parse log level: %w", err)
+	}
+	logger := log.NewFromLog(zap.New(zapcore.NewCore(
+		zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig()),
+		zapcore.Lock(os.Stderr),
+		level,
+	)))
+
+	id, err := util.Decode(*nodeID)
+	if err != nil || len(id) != len(types.NodeID{}) {
+		return fmt.Errorf("invalid node id %q", *nodeID)
+	}
+	atx, err := util.Decode(*commitmentAtx)
+	if err != nil || len(atx) != types.Hash32Length {
+		return fmt.Errorf("invalid commitment atx %q", *commitmentAtx)
+	}
+	creds, err := tlsCfg.ServerCredentials()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	mgr, err := activation.NewPostSetupManager(types.BytesToNodeID(id), postCfg, logger.WithName("post"), nil, types.ATXID{})
+	if err != nil {
+		return fmt.Errorf("post setup manager: %w", err)
+	}
+	done, err := mgr.StartSession(opts, types.ATXID(types.BytesToHash(atx)))
+	if err != nil {
+		return fmt.Errorf("start post setup: %w", err)
+	}
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return mgr.StopSession(false)
+	}
+	if err := mgr.LastError(); err != nil {
+		return fmt.Errorf("post setup: %w", err)
+	}
+
+	lis, err := net.Listen("tcp", *listen)
+	if err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+	server := grpc.NewServer(grpc.Creds(creds))
+	postservice.NewServer(mgr, types.BytesToNodeID(id), types.ATXID(types.BytesToHash(atx)), logger.WithName("postservice")).Register(server)
+	go func() {
+		<-ctx.Done()
+		server.GracefulStop()
+	}()
+	logger.With().Info("serving post service", log.String("address", lis.Addr().String()))
+	if err := server.Serve(lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return fmt.Errorf("serve: %w", err)
+	}
+	return nil
+}
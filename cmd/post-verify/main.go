@@ -0,0 +1,95 @@
+// post-verify verifies Post data against the commitment it was created for and reports the corrupted labels.
+// With --repair the corrupted labels are regenerated. The node should not be generating proofs from the data
+// while it is repaired, use the VerifyPostData method of the smesher service to verify the data of a running node.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/spacemeshos/go-spacemesh/activation"
+	atypes "github.com/spacemeshos/go-spacemesh/activation/types"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/common/util"
+	"github.com/spacemeshos/go-spacemesh/log"
+)
+
+var (
+	dataDir       = flag.String("datadir", activation.DefaultPostSetupOpts().DataDir, "directory of the post data")
+	nodeID        = flag.String("node-id", "", "hex encoded id of the node the post data belongs to. the commitment recorded in the post metadata is trusted if empty")
+	commitmentAtx = flag.String("commitment-atx", "", "hex encoded id of the commitment atx of the post data, required with --node-id")
+	fraction      = flag.Float64("fraction", 1, "fraction of the labels that are verified, in (0, 1]")
+	repair        = flag.Bool("repair", false, "regenerate corrupted labels")
+	provider      = flag.Int("provider", -1, "compute provider used to generate labels, cpu if negative")
+	logLevel      = flag.String("log-level", "info", "log level. logs are written to stderr")
+)
+
+func main() {
+	flag.Parse()
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	level, err := zap.ParseAtomicLevel(*logLevel)
+	if err != nil {
+		return fmt.Errorf("parse log level: %w", err)
+	}
+	logger := log.NewFromLog(zap.New(zapcore.NewCore(
+		zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig()),
+		zapcore.Lock(os.Stderr),
+		level,
+	)))
+
+	var commitment []byte
+	if *nodeID != "" {
+		id, err := util.Decode(*nodeID)
+		if err != nil || len(id) != len(types.NodeID{}) {
+			return fmt.Errorf("invalid node id %q", *nodeID)
+		}
+		atx, err := util.Decode(*commitmentAtx)
+		if err != nil || len(atx) != types.Hash32Length {
+			return fmt.Errorf("invalid commitment atx %q", *commitmentAtx)
+		}
+		commitment = activation.GetCommitmentBytes(types.BytesToNodeID(id), types.ATXID(types.BytesToHash(atx)))
+	}
+	verifier, err := activation.NewPostDataVerifier(*dataDir, commitment, *provider, logger.WithName("post"))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	start := time.Now()
+	var last *atypes.PostVerifyStatus
+	for status := range verifier.Verify(ctx, atypes.PostVerifyOpts{Fraction: *fraction, Repair: *repair}) {
+		last = status
+		if !status.Done {
+			fmt.Printf("verified %d/%d labels, %d corrupted, %d repaired\n",
+				status.LabelsVerified, status.LabelsToVerify, status.LabelsCorrupted, status.LabelsRepaired)
+		}
+	}
+	if last.LastError != nil {
+		return fmt.Errorf("verify post data: %w", last.LastError)
+	}
+	for _, r := range last.Corrupted {
+		fmt.Printf("corrupted labels [%d, %d)\n", r.Start, r.End)
+	}
+	fmt.Printf("verified %d labels in %v, %d corrupted, %d repaired\n",
+		last.LabelsVerified, time.Since(start).Round(time.Second), last.LabelsCorrupted, last.LabelsRepaired)
+	if last.LabelsCorrupted > 0 && !*repair {
+		return errors.New("post data is corrupted, run with --repair to regenerate corrupted labels")
+	}
+	return nil
+}
@@ -40,6 +40,9 @@ func AddCommands(cmd *cobra.Command) {
 		config.OracleServerWorldID, "The worldid to use with the oracle server (temporary) ")
 	cmd.PersistentFlags().StringArrayVar(&config.PoETServers, "poet-server",
 		config.PoETServers, "The poet server url. (temporary) Can be passed multiple times")
+	cmd.PersistentFlags().StringArrayVar(&config.BackupPoETServers, "poet-backup-server",
+		config.BackupPoETServers, "The poet server url used when a poet server fails to accept a challenge. "+
+			"Prefix the url with grpc:// to use the grpc api. Can be passed multiple times")
 	cmd.PersistentFlags().StringVar(&config.Genesis.GenesisTime, "genesis-time",
 		config.Genesis.GenesisTime, "Time of the genesis layer in 2019-13-02T17:02:00+00:00 format")
 	cmd.PersistentFlags().StringVar(&config.Genesis.ExtraData, "genesis-extra-data",
@@ -57,6 +60,8 @@ func AddCommands(cmd *cobra.Command) {
 		config.ProfilerURL, "send profiler data to certain url, if no url no profiling will be sent, format: http://<IP>:<PORT>")
 	cmd.PersistentFlags().StringVar(&config.ProfilerName, "profiler-name",
 		config.ProfilerName, "the name to use when sending profiles")
+	cmd.PersistentFlags().DurationVar(&config.DatabaseSlowQueryThreshold, "db-slow-query-threshold",
+		config.DatabaseSlowQueryThreshold, "log database queries that took longer than this threshold, 0 disables the log")
 
 	cmd.PersistentFlags().IntVar(&config.SyncRequestTimeout, "sync-request-timeout",
 		config.SyncRequestTimeout, "the timeout in ms for direct requests in the sync")
@@ -87,6 +92,8 @@ func AddCommands(cmd *cobra.Command) {
 		config.P2P.TargetOutbound, "target outbound connections")
 	cmd.PersistentFlags().StringSliceVar(&config.P2P.Bootnodes, "bootnodes",
 		config.P2P.Bootnodes, "entrypoints into the network")
+	cmd.PersistentFlags().BoolVar(&config.P2P.AllowlistOnly, "allowlist-only",
+		config.P2P.AllowlistOnly, "allow connections only with peers from the allowlist (for nodes behind sentries)")
 
 	/** ======================== TIME Flags ========================== **/
 
@@ -117,13 +124,16 @@ func AddCommands(cmd *cobra.Command) {
 	// StartGrpcServices determines which (if any) GRPC API services should be started
 	cmd.PersistentFlags().StringSliceVar(&config.API.StartGrpcServices, "grpc",
 		config.API.StartGrpcServices, "Comma-separated list of individual grpc services to enable "+
-			"(gateway,globalstate,mesh,node,smesher,transaction)")
+			"(beacon,debug,gateway,globalstate,mesh,node,smesher,transaction)")
 	// GrpcServerPort determines the grpc server local listening port
 	cmd.PersistentFlags().IntVar(&config.API.GrpcServerPort, "grpc-port",
 		config.API.GrpcServerPort, "GRPC api server port")
 	// GrpcServerInterface determines the interface the GRPC server listens on
 	cmd.PersistentFlags().StringVar(&config.API.GrpcServerInterface, "grpc-interface",
 		config.API.GrpcServerInterface, "GRPC api server interface")
+	// AdminToken authenticates admin calls, such as setting a manual beacon
+	cmd.PersistentFlags().StringVar(&config.API.AdminToken, "grpc-admin-token",
+		config.API.AdminToken, "Token that authenticates GRPC admin calls. Admin calls are disabled if not set")
 
 	/**======================== Hare Flags ========================== **/
 
@@ -144,6 +154,8 @@ func AddCommands(cmd *cobra.Command) {
 		config.HARE.LimitIterations, "The limit of the number of iteration per consensus process")
 	cmd.PersistentFlags().IntVar(&config.HARE.LimitConcurrent, "hare-limit-concurrent",
 		config.HARE.LimitConcurrent, "The number of consensus processes running concurrently")
+	cmd.PersistentFlags().StringVar(&config.HARE.TraceDir, "hare-trace-dir",
+		config.HARE.TraceDir, "Directory for per-layer traces of consensus processes. Tracing is disabled if empty")
 
 	/**======================== Hare Eligibility Oracle Flags ========================== **/
 
@@ -180,6 +192,10 @@ func AddCommands(cmd *cobra.Command) {
 	/**======================== Tortoise Flags ========================== **/
 	cmd.PersistentFlags().Uint32Var(&config.Tortoise.Hdist, "tortoise-hdist",
 		config.Tortoise.Hdist, "hdist")
+	cmd.PersistentFlags().Uint32Var(&config.Tortoise.CheckpointInterval, "tortoise-checkpoint-interval",
+		config.Tortoise.CheckpointInterval, "number of processed layers between tortoise state checkpoints, 0 disables checkpoints")
+	cmd.PersistentFlags().Uint32Var(&config.Tortoise.MaxResidentLayers, "tortoise-max-resident-layers",
+		config.Tortoise.MaxResidentLayers, "number of recent layers kept in memory, older layers are moved to the database. must be >= tortoise-window-size. 0 disables it")
 
 	// TODO(moshababo): add usage desc
 
@@ -214,11 +230,51 @@ func AddCommands(cmd *cobra.Command) {
 		config.SMESHING.Opts.ComputeProviderID, "")
 	cmd.PersistentFlags().BoolVar(&config.SMESHING.Opts.Throttle, "smeshing-opts-throttle",
 		config.SMESHING.Opts.Throttle, "")
+	cmd.PersistentFlags().StringSliceVar(&config.SMESHING.Identities, "smeshing-identities",
+		config.SMESHING.Identities, "names of additional identities that smesh on this node, "+
+			"each identity uses a subdirectory of smeshing-opts-datadir with its name")
+	cmd.PersistentFlags().StringVar(&config.SMESHING.RemotePost.Address, "smeshing-remote-post-address",
+		config.SMESHING.RemotePost.Address, "address of the post-service that generates proofs over post data "+
+			"stored on another machine. post setup is performed by the post-service")
+	cmd.PersistentFlags().DurationVar(&config.SMESHING.RemotePost.StatusTimeout, "smeshing-remote-post-status-timeout",
+		config.SMESHING.RemotePost.StatusTimeout, "timeout of status requests to the post-service")
+	cmd.PersistentFlags().StringVar(&config.SMESHING.RemotePost.TLS.Cert, "smeshing-remote-post-tls-cert",
+		config.SMESHING.RemotePost.TLS.Cert, "PEM encoded client certificate presented to the post-service")
+	cmd.PersistentFlags().StringVar(&config.SMESHING.RemotePost.TLS.Key, "smeshing-remote-post-tls-key",
+		config.SMESHING.RemotePost.TLS.Key, "PEM encoded key of the client certificate")
+	cmd.PersistentFlags().StringVar(&config.SMESHING.RemotePost.TLS.CA, "smeshing-remote-post-tls-ca",
+		config.SMESHING.RemotePost.TLS.CA, "PEM encoded certificate authority of the post-service certificate")
+
+	/**======================== Keystore Flags ========================== **/
+
+	cmd.PersistentFlags().StringVar(&config.KEYSTORE.PassphraseFile, "keystore-passphrase-file",
+		config.KEYSTORE.PassphraseFile, "file with the passphrase that encrypts the identity keys")
+	cmd.PersistentFlags().StringVar(&config.KEYSTORE.PassphraseEnv, "keystore-passphrase-env",
+		config.KEYSTORE.PassphraseEnv, "environment variable with the passphrase that encrypts the identity keys")
+	cmd.PersistentFlags().BoolVar(&config.KEYSTORE.Prompt, "keystore-prompt",
+		config.KEYSTORE.Prompt, "prompt for the passphrase that encrypts the identity keys")
+
+	/**======================== Signer Flags ========================== **/
+
+	cmd.PersistentFlags().StringVar(&config.SIGNER.Socket, "signer-socket",
+		config.SIGNER.Socket, "unix socket of the signer that stores the identity key. the key is stored by the node if not set")
+	cmd.PersistentFlags().DurationVar(&config.SIGNER.Timeout, "signer-timeout",
+		config.SIGNER.Timeout, "timeout of the requests to the signer")
+
+	/**======================== Fetch Flags ========================== **/
+
+	cmd.PersistentFlags().Var(flags.NewStringToLimitsValue(config.FETCH.ServerLimits), "fetch-server-limits",
+		"limits of the served protocols, e.g. ax/1=concurrent:10;peer-requests:5,hs/1=bytes:1000000. "+
+			"supported limits are concurrent, requests, bytes, peer-concurrent, peer-requests and peer-bytes")
+	cmd.PersistentFlags().IntVar(&config.FETCH.EgressBytesPerSecond, "fetch-egress-bytes-per-second",
+		config.FETCH.EgressBytesPerSecond, "bytes per second in responses of all served protocols, 0 disables the limit")
 
 	/**======================== Consensus Flags ========================== **/
 
 	cmd.PersistentFlags().Uint32Var(&config.LayersPerEpoch, "layers-per-epoch",
 		config.LayersPerEpoch, "number of layers in epoch")
+	cmd.PersistentFlags().Uint32Var(&config.RewardAtxLayer, "reward-atx-layer",
+		config.RewardAtxLayer, "first layer in which rewards of the block reference atxs")
 
 	/**======================== PoET Flags ========================== **/
 
@@ -228,6 +284,8 @@ func AddCommands(cmd *cobra.Command) {
 		config.POET.CycleGap, "cycle gap of poet server")
 	cmd.PersistentFlags().DurationVar(&config.POET.GracePeriod, "grace-period",
 		config.POET.GracePeriod, "propagation time for ATXs in the network")
+	cmd.PersistentFlags().StringSliceVar(&config.POET.ServiceIDs, "poet-service-ids",
+		config.POET.ServiceIDs, "hex encoded public keys of the trusted poet services, other poet servers are rejected on update")
 
 	// Bind Flags to config
 	err := viper.BindPFlags(cmd.PersistentFlags())
@@ -0,0 +1,107 @@
+// signer stores the identity key of a node and signs messages for it over a local unix socket
+// (see --signer-socket). Messages that equivocate with messages signed before are refused,
+// signed messages are recorded in the slashing protection database.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/common/util"
+	"github.com/spacemeshos/go-spacemesh/keystore"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/signing"
+	"github.com/spacemeshos/go-spacemesh/signing/remote"
+)
+
+var (
+	keystoreCfg = keystore.DefaultConfig()
+
+	socket     = flag.String("socket", "signer/signer.sock", "unix socket to serve the signer on. directory of the socket must be accessible only by the owner")
+	key        = flag.String("key", "key.bin", "identity key of the node")
+	genesisID  = flag.String("genesis-id", "", "hex encoded genesis id of the network")
+	protection = flag.String("protection", "protection.log", "slashing protection database")
+	window     = flag.Uint("window", remote.DefaultWindow, "number of layers below the high-water mark for which signed messages are retained")
+	layers     = flag.Uint("layers-per-epoch", 0, "number of layers per epoch of the network")
+	logLevel   = flag.String("log-level", "info", "log level. logs are written to stderr")
+)
+
+func init() {
+	flag.StringVar(&keystoreCfg.PassphraseFile, "passphrase-file", "", "file with the passphrase of the identity key")
+	flag.StringVar(&keystoreCfg.PassphraseEnv, "passphrase-env", keystoreCfg.PassphraseEnv, "environment variable with the passphrase of the identity key")
+	flag.BoolVar(&keystoreCfg.Prompt, "prompt", false, "prompt for the passphrase of the identity key")
+}
+
+func main() {
+	flag.Parse()
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	level, err := zap.ParseAtomicLevel(*logLevel)
+	if err != nil {
+		return fmt.Errorf("parse log level: %w", err)
+	}
+	logger := log.NewFromLog(zap.New(zapcore.NewCore(
+		zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig()),
+		zapcore.Lock(os.Stderr),
+		level,
+	)))
+
+	genesis, err := util.Decode(*genesisID)
+	if err != nil || len(genesis) != len(types.Hash20{}) {
+		return fmt.Errorf("invalid genesis id %q", *genesisID)
+	}
+	if *layers == 0 {
+		return errors.New("layers per epoch must be set")
+	}
+	types.SetLayersPerEpoch(uint32(*layers))
+	passphrase, err := keystoreCfg.Passphrase("Enter passphrase for the identity key: ")
+	if err != nil {
+		return fmt.Errorf("read passphrase: %w", err)
+	}
+	secret, _, err := keystore.ReadFile(*key, passphrase)
+	if err != nil {
+		return fmt.Errorf("read identity key: %w", err)
+	}
+	signer, err := signing.NewEdSignerFromBuffer(secret, signing.WithSignerPrefix(genesis))
+	if err != nil {
+		return fmt.Errorf("identity key: %w", err)
+	}
+	db, err := remote.NewProtection(*protection, remote.WithWindow(uint32(*window)))
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	lis, err := remote.Listen(*socket)
+	if err != nil {
+		return err
+	}
+	server := grpc.NewServer()
+	remote.NewServer(signer, db, logger.WithName("signer")).Register(server)
+	go func() {
+		<-ctx.Done()
+		server.GracefulStop()
+	}()
+	logger.With().Info("serving signer", signer.PublicKey(), log.String("socket", *socket))
+	if err := server.Serve(lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return fmt.Errorf("serve: %w", err)
+	}
+	return nil
+}
@@ -130,6 +130,26 @@ type Votes struct {
 	Against []Vote
 	// Abstain on layers until they are terminated.
 	Abstain []LayerID
+	// Checkpoint is used instead of the base ballot when none of the base ballots
+	// can be used without exceeding the limit on the number of exceptions.
+	// If set Base must be empty.
+	Checkpoint *OpinionCheckpoint
+}
+
+// OpinionCheckpoint is an opinion about all layers up to and including Layer.
+// Votes after the Layer are encoded as exceptions.
+type OpinionCheckpoint struct {
+	Layer LayerID
+	// Opinion is an opinion hash computed over the local opinion for all layers
+	// up to and including Layer.
+	Opinion Hash32
+}
+
+// MarshalLogObject implements logging interface.
+func (c *OpinionCheckpoint) MarshalLogObject(encoder log.ObjectEncoder) error {
+	encoder.AddUint32("layer", c.Layer.Value)
+	encoder.AddString("opinion", c.Opinion.ShortString())
+	return nil
 }
 
 // MarshalLogObject implements logging interface.
@@ -153,6 +173,9 @@ func (v *Votes) MarshalLogObject(encoder log.ObjectEncoder) error {
 		}
 		return nil
 	}))
+	if v.Checkpoint != nil {
+		encoder.AddObject("checkpoint", v.Checkpoint)
+	}
 	return nil
 }
 
@@ -181,6 +181,13 @@ func (t *Votes) EncodeScale(enc *scale.Encoder) (total int, err error) {
 		}
 		total += n
 	}
+	{
+		n, err := scale.EncodeOption(enc, t.Checkpoint)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
 	return total, nil
 }
 
@@ -216,6 +223,50 @@ func (t *Votes) DecodeScale(dec *scale.Decoder) (total int, err error) {
 		total += n
 		t.Abstain = field
 	}
+	{
+		field, n, err := scale.DecodeOption[OpinionCheckpoint](dec)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.Checkpoint = field
+	}
+	return total, nil
+}
+
+func (t *OpinionCheckpoint) EncodeScale(enc *scale.Encoder) (total int, err error) {
+	{
+		n, err := t.Layer.EncodeScale(enc)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.EncodeByteArray(enc, t.Opinion[:])
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+func (t *OpinionCheckpoint) DecodeScale(dec *scale.Decoder) (total int, err error) {
+	{
+		n, err := t.Layer.DecodeScale(dec)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.DecodeByteArray(dec, t.Opinion[:])
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
 	return total, nil
 }
 
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"sort"
+	"sync/atomic"
 
 	"github.com/spacemeshos/go-scale"
 
@@ -18,7 +19,7 @@ const (
 	BlockIDSize = Hash32Length
 )
 
-//go:generate scalegen
+//go:generate scalegen -types Block,RatNum,AnyReward,BlockContextualValidity,Certificate,CertifyMessage,CertifyContent,AnyRewardV0
 
 // BlockID is a 20-byte sha256 sum of the serialized block used to identify a Block.
 type BlockID Hash20
@@ -26,6 +27,21 @@ type BlockID Hash20
 // EmptyBlockID is a canonical empty BlockID.
 var EmptyBlockID = BlockID{}
 
+// rewardAtxLayer is the first layer in which rewards of the block reference ATXs.
+var rewardAtxLayer uint32
+
+// SetRewardAtxLayer sets the first layer in which rewards of the block reference ATXs.
+// Blocks of the earlier layers are encoded without ATXs, so that they have the same ids
+// and can be exchanged with nodes that don't know about ATXs in rewards.
+func SetRewardAtxLayer(lid LayerID) {
+	atomic.StoreUint32(&rewardAtxLayer, lid.Value)
+}
+
+// GetRewardAtxLayer returns the first layer in which rewards of the block reference ATXs.
+func GetRewardAtxLayer() LayerID {
+	return NewLayerID(atomic.LoadUint32(&rewardAtxLayer))
+}
+
 // NewExistingBlock creates a block from existing data.
 func NewExistingBlock(id BlockID, inner InnerBlock) *Block {
 	return &Block{blockID: id, InnerBlock: inner}
@@ -70,11 +86,119 @@ func (r *RatNum) String() string {
 }
 
 // AnyReward contains the reward information.
+// Block contains at most one reward per ATX.
 type AnyReward struct {
+	Coinbase Address
+	// AtxID is the ATX that was used by the smesher to earn the reward.
+	AtxID  ATXID
+	Weight RatNum
+}
+
+// AnyRewardV0 is the encoding of the AnyReward in layers before the reward atx layer.
+type AnyRewardV0 struct {
 	Coinbase Address
 	Weight   RatNum
 }
 
+// EncodeScale implements scale codec interface.
+// Rewards are encoded without ATXs in layers before the reward atx layer.
+func (b *InnerBlock) EncodeScale(enc *scale.Encoder) (total int, err error) {
+	{
+		n, err := b.LayerIndex.EncodeScale(enc)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.EncodeCompact64(enc, b.TickHeight)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		var (
+			n   int
+			err error
+		)
+		if b.LayerIndex.Before(GetRewardAtxLayer()) {
+			var legacy []AnyRewardV0
+			if b.Rewards != nil {
+				legacy = make([]AnyRewardV0, 0, len(b.Rewards))
+			}
+			for _, reward := range b.Rewards {
+				legacy = append(legacy, AnyRewardV0{Coinbase: reward.Coinbase, Weight: reward.Weight})
+			}
+			n, err = scale.EncodeStructSlice(enc, legacy)
+		} else {
+			n, err = scale.EncodeStructSlice(enc, b.Rewards)
+		}
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.EncodeStructSlice(enc, b.TxIDs)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+// DecodeScale implements scale codec interface.
+// Rewards are decoded without ATXs in layers before the reward atx layer.
+func (b *InnerBlock) DecodeScale(dec *scale.Decoder) (total int, err error) {
+	{
+		n, err := b.LayerIndex.DecodeScale(dec)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		field, n, err := scale.DecodeCompact64(dec)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		b.TickHeight = field
+	}
+	if b.LayerIndex.Before(GetRewardAtxLayer()) {
+		legacy, n, err := scale.DecodeStructSlice[AnyRewardV0](dec)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		b.Rewards = nil
+		if legacy != nil {
+			b.Rewards = make([]AnyReward, 0, len(legacy))
+		}
+		for _, reward := range legacy {
+			b.Rewards = append(b.Rewards, AnyReward{Coinbase: reward.Coinbase, Weight: reward.Weight})
+		}
+	} else {
+		field, n, err := scale.DecodeStructSlice[AnyReward](dec)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		b.Rewards = field
+	}
+	{
+		field, n, err := scale.DecodeStructSlice[TransactionID](dec)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		b.TxIDs = field
+	}
+	return total, nil
+}
+
 // Initialize calculates and sets the Block's cached blockID.
 func (b *Block) Initialize() {
 	b.blockID = BlockID(CalcHash32(b.Bytes()).ToHash20())
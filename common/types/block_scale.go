@@ -29,73 +29,6 @@ func (t *Block) DecodeScale(dec *scale.Decoder) (total int, err error) {
 	return total, nil
 }
 
-func (t *InnerBlock) EncodeScale(enc *scale.Encoder) (total int, err error) {
-	{
-		n, err := t.LayerIndex.EncodeScale(enc)
-		if err != nil {
-			return total, err
-		}
-		total += n
-	}
-	{
-		n, err := scale.EncodeCompact64(enc, uint64(t.TickHeight))
-		if err != nil {
-			return total, err
-		}
-		total += n
-	}
-	{
-		n, err := scale.EncodeStructSlice(enc, t.Rewards)
-		if err != nil {
-			return total, err
-		}
-		total += n
-	}
-	{
-		n, err := scale.EncodeStructSlice(enc, t.TxIDs)
-		if err != nil {
-			return total, err
-		}
-		total += n
-	}
-	return total, nil
-}
-
-func (t *InnerBlock) DecodeScale(dec *scale.Decoder) (total int, err error) {
-	{
-		n, err := t.LayerIndex.DecodeScale(dec)
-		if err != nil {
-			return total, err
-		}
-		total += n
-	}
-	{
-		field, n, err := scale.DecodeCompact64(dec)
-		if err != nil {
-			return total, err
-		}
-		total += n
-		t.TickHeight = uint64(field)
-	}
-	{
-		field, n, err := scale.DecodeStructSlice[AnyReward](dec)
-		if err != nil {
-			return total, err
-		}
-		total += n
-		t.Rewards = field
-	}
-	{
-		field, n, err := scale.DecodeStructSlice[TransactionID](dec)
-		if err != nil {
-			return total, err
-		}
-		total += n
-		t.TxIDs = field
-	}
-	return total, nil
-}
-
 func (t *RatNum) EncodeScale(enc *scale.Encoder) (total int, err error) {
 	{
 		n, err := scale.EncodeCompact64(enc, uint64(t.Num))
@@ -142,6 +75,13 @@ func (t *AnyReward) EncodeScale(enc *scale.Encoder) (total int, err error) {
 		}
 		total += n
 	}
+	{
+		n, err := scale.EncodeByteArray(enc, t.AtxID[:])
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
 	{
 		n, err := t.Weight.EncodeScale(enc)
 		if err != nil {
@@ -160,6 +100,13 @@ func (t *AnyReward) DecodeScale(dec *scale.Decoder) (total int, err error) {
 		}
 		total += n
 	}
+	{
+		n, err := scale.DecodeByteArray(dec, t.AtxID[:])
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
 	{
 		n, err := t.Weight.DecodeScale(dec)
 		if err != nil {
@@ -346,3 +293,39 @@ func (t *CertifyContent) DecodeScale(dec *scale.Decoder) (total int, err error)
 	}
 	return total, nil
 }
+
+func (t *AnyRewardV0) EncodeScale(enc *scale.Encoder) (total int, err error) {
+	{
+		n, err := scale.EncodeByteArray(enc, t.Coinbase[:])
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := t.Weight.EncodeScale(enc)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+func (t *AnyRewardV0) DecodeScale(dec *scale.Decoder) (total int, err error) {
+	{
+		n, err := scale.DecodeByteArray(dec, t.Coinbase[:])
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := t.Weight.DecodeScale(dec)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
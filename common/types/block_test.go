@@ -54,6 +54,47 @@ func TestRewardCodec(t *testing.T) {
 	require.Equal(t, r, &got)
 }
 
+func TestBlockCodec_RewardAtxLayer(t *testing.T) {
+	SetRewardAtxLayer(NewLayerID(10))
+	t.Cleanup(func() { SetRewardAtxLayer(LayerID{}) })
+
+	rewards := []AnyReward{{Coinbase: Address{1}, AtxID: ATXID{1}, Weight: RatNum{Num: 1, Denom: 2}}}
+	for _, tc := range []struct {
+		desc     string
+		lid      LayerID
+		expected []AnyReward
+	}{
+		{
+			desc:     "before",
+			lid:      NewLayerID(9),
+			expected: []AnyReward{{Coinbase: Address{1}, Weight: RatNum{Num: 1, Denom: 2}}},
+		},
+		{
+			desc:     "after",
+			lid:      NewLayerID(10),
+			expected: rewards,
+		},
+	} {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			block := Block{InnerBlock: InnerBlock{LayerIndex: tc.lid, TickHeight: 5, Rewards: rewards, TxIDs: []TransactionID{{1}}}}
+			data, err := codec.Encode(&block)
+			require.NoError(t, err)
+
+			var got Block
+			require.NoError(t, codec.Decode(data, &got))
+			require.Equal(t, tc.lid, got.LayerIndex)
+			require.Equal(t, block.TickHeight, got.TickHeight)
+			require.Equal(t, block.TxIDs, got.TxIDs)
+			require.Equal(t, tc.expected, got.Rewards)
+
+			block.Initialize()
+			got.Initialize()
+			require.Equal(t, block.ID(), got.ID())
+		})
+	}
+}
+
 func FuzzAnyRewardConsistency(f *testing.F) {
 	tester.FuzzConsistency[AnyReward](f)
 }
@@ -170,6 +170,15 @@ func (id NodeID) ShortString() string {
 // Field returns a log field. Implements the LoggableField interface.
 func (id NodeID) Field() log.Field { return log.Stringer("node_id", id) }
 
+// NodeIDsToHashes turns a list of NodeID into their Hash32 representation.
+func NodeIDsToHashes(ids []NodeID) []Hash32 {
+	hashes := make([]Hash32, 0, len(ids))
+	for _, id := range ids {
+		hashes = append(hashes, Hash32(id))
+	}
+	return hashes
+}
+
 // Layer contains a list of proposals and their corresponding LayerID.
 type Layer struct {
 	index   LayerID
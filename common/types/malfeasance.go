@@ -0,0 +1,82 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/spacemeshos/go-spacemesh/common/util"
+	"github.com/spacemeshos/go-spacemesh/log"
+)
+
+//go:generate scalegen -types MalfeasanceProof,SignedMessage
+
+// MalfeasanceType is a kind of misbehavior that is proven by MalfeasanceProof.
+type MalfeasanceType uint8
+
+const (
+	// MultipleATXs is a proof that identity published more than one atx in the same epoch.
+	MultipleATXs MalfeasanceType = iota + 1
+	// MultipleBallots is a proof that identity published more than one ballot in the same layer.
+	MultipleBallots
+	// HareEquivocation is a proof that identity sent conflicting hare messages in the same round.
+	HareEquivocation
+	// CertifyEquivocation is a proof that identity certified different blocks in the same layer.
+	CertifyEquivocation
+)
+
+// String returns human-readable name of the malfeasance type.
+func (t MalfeasanceType) String() string {
+	switch t {
+	case MultipleATXs:
+		return "multiple_atxs"
+	case MultipleBallots:
+		return "multiple_ballots"
+	case HareEquivocation:
+		return "hare_equivocation"
+	case CertifyEquivocation:
+		return "certify_equivocation"
+	}
+	return fmt.Sprintf("unknown(%d)", uint8(t))
+}
+
+// SignedMessage is a message in the same encoding that was used for signing, together with the signature.
+type SignedMessage struct {
+	Data      []byte
+	Signature []byte
+}
+
+// NewSignedMessage creates SignedMessage from signed data and signature.
+func NewSignedMessage(data, sig []byte) SignedMessage {
+	return SignedMessage{Data: data, Signature: sig}
+}
+
+// MalfeasanceProof is a proof that identity is malicious. It consists of two conflicting messages
+// signed by the same identity, messages are interpreted according to the Type.
+type MalfeasanceProof struct {
+	Type     uint8
+	Messages []SignedMessage
+}
+
+// NewMalfeasanceProof creates proof from two conflicting messages.
+func NewMalfeasanceProof(typ MalfeasanceType, first, second SignedMessage) *MalfeasanceProof {
+	return &MalfeasanceProof{
+		Type:     uint8(typ),
+		Messages: []SignedMessage{first, second},
+	}
+}
+
+// MalfeasanceType returns type of the proof.
+func (p *MalfeasanceProof) MalfeasanceType() MalfeasanceType {
+	return MalfeasanceType(p.Type)
+}
+
+// MarshalLogObject implements logging interface.
+func (p *MalfeasanceProof) MarshalLogObject(encoder log.ObjectEncoder) error {
+	encoder.AddString("type", p.MalfeasanceType().String())
+	encoder.AddArray("messages", log.ArrayMarshalerFunc(func(encoder log.ArrayEncoder) error {
+		for _, msg := range p.Messages {
+			encoder.AppendString(util.Bytes2Hex(msg.Signature))
+		}
+		return nil
+	}))
+	return nil
+}
@@ -6,10 +6,12 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/spf13/viper"
 
 	"github.com/spacemeshos/go-spacemesh/activation"
+	"github.com/spacemeshos/go-spacemesh/activation/postservice"
 	atypes "github.com/spacemeshos/go-spacemesh/activation/types"
 	apiConfig "github.com/spacemeshos/go-spacemesh/api/config"
 	"github.com/spacemeshos/go-spacemesh/beacon"
@@ -18,8 +20,10 @@ import (
 	vm "github.com/spacemeshos/go-spacemesh/genvm"
 	hareConfig "github.com/spacemeshos/go-spacemesh/hare/config"
 	eligConfig "github.com/spacemeshos/go-spacemesh/hare/eligibility/config"
+	"github.com/spacemeshos/go-spacemesh/keystore"
 	"github.com/spacemeshos/go-spacemesh/log"
 	"github.com/spacemeshos/go-spacemesh/p2p"
+	"github.com/spacemeshos/go-spacemesh/signing/remote"
 	timeConfig "github.com/spacemeshos/go-spacemesh/timesync/config"
 	"github.com/spacemeshos/go-spacemesh/tortoise"
 )
@@ -56,6 +60,8 @@ type Config struct {
 	POST            atypes.PostConfig     `mapstructure:"post"`
 	POET            activation.PoetConfig `mapstructure:"poet"`
 	SMESHING        SmeshingConfig        `mapstructure:"smeshing"`
+	KEYSTORE        keystore.Config       `mapstructure:"keystore"`
+	SIGNER          remote.Config         `mapstructure:"signer"`
 	LOGGING         LoggerConfig          `mapstructure:"logging"`
 	FETCH           fetch.Config          `mapstructure:"fetch"`
 }
@@ -87,8 +93,13 @@ type BaseConfig struct {
 	LayerDurationSec int    `mapstructure:"layer-duration-sec"`
 	LayerAvgSize     int    `mapstructure:"layer-average-size"`
 	LayersPerEpoch   uint32 `mapstructure:"layers-per-epoch"`
+	// RewardAtxLayer is the first layer in which rewards of the block reference ATXs.
+	// Blocks of the earlier layers are exchanged in the encoding without ATXs.
+	RewardAtxLayer uint32 `mapstructure:"reward-atx-layer"`
 
 	PoETServers []string `mapstructure:"poet-server"`
+	// BackupPoETServers are used when the PoET servers fail to accept a challenge.
+	BackupPoETServers []string `mapstructure:"poet-backup-server"`
 
 	PprofHTTPServer bool `mapstructure:"pprof-server"`
 
@@ -106,6 +117,10 @@ type BaseConfig struct {
 	// then we optimistically filter out infeasible transactions before constructing the block.
 	OptFilterThreshold int    `mapstructure:"optimistic-filtering-threshold"`
 	TickSize           uint64 `mapstructure:"tick-size"`
+
+	// queries that took longer than this threshold are logged together with the caller package.
+	// zero disables slow query log.
+	DatabaseSlowQueryThreshold time.Duration `mapstructure:"db-slow-query-threshold"`
 }
 
 // SmeshingConfig defines configuration for the node's smeshing (mining).
@@ -113,6 +128,12 @@ type SmeshingConfig struct {
 	Start           bool                 `mapstructure:"smeshing-start"`
 	CoinbaseAccount string               `mapstructure:"smeshing-coinbase"`
 	Opts            atypes.PostSetupOpts `mapstructure:"smeshing-opts"`
+	// Identities are names of additional identities that smesh on the same node.
+	// Key and PoST data of every identity are stored in the subdirectory of Opts.DataDir with the identity name.
+	Identities []string `mapstructure:"smeshing-identities"`
+	// RemotePost configures the post-service that generates proofs when the Post data
+	// is stored on another machine.
+	RemotePost postservice.ClientConfig `mapstructure:"smeshing-remote-post"`
 }
 
 // DefaultConfig returns the default configuration for a spacemesh node.
@@ -132,6 +153,8 @@ func DefaultConfig() Config {
 		POST:            activation.DefaultPostConfig(),
 		POET:            activation.DefaultPoetConfig(),
 		SMESHING:        DefaultSmeshingConfig(),
+		KEYSTORE:        keystore.DefaultConfig(),
+		SIGNER:          remote.DefaultConfig(),
 		FETCH:           fetch.DefaultConfig(),
 		LOGGING:         defaultLoggingConfig(),
 	}
@@ -178,6 +201,7 @@ func DefaultSmeshingConfig() SmeshingConfig {
 		Start:           false,
 		CoinbaseAccount: "",
 		Opts:            activation.DefaultPostSetupOpts(),
+		RemotePost:      postservice.DefaultClientConfig(),
 	}
 }
 
@@ -11,6 +11,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/sql/atxs"
 	"github.com/spacemeshos/go-spacemesh/sql/ballots"
 	"github.com/spacemeshos/go-spacemesh/sql/blocks"
+	"github.com/spacemeshos/go-spacemesh/sql/identities"
 	"github.com/spacemeshos/go-spacemesh/sql/poets"
 	"github.com/spacemeshos/go-spacemesh/sql/proposals"
 	"github.com/spacemeshos/go-spacemesh/sql/transactions"
@@ -132,12 +133,13 @@ type Hint string
 
 // DB hints per DB.
 const (
-	BallotDB   Hint = "ballotDB"
-	BlockDB    Hint = "blocksDB"
-	ProposalDB Hint = "proposalDB"
-	ATXDB      Hint = "ATXDB"
-	TXDB       Hint = "TXDB"
-	POETDB     Hint = "POETDB"
+	BallotDB      Hint = "ballotDB"
+	BlockDB       Hint = "blocksDB"
+	ProposalDB    Hint = "proposalDB"
+	ATXDB         Hint = "ATXDB"
+	TXDB          Hint = "TXDB"
+	POETDB        Hint = "POETDB"
+	MalfeasanceDB Hint = "malfeasanceDB"
 )
 
 // NewBlobStore returns a BlobStore.
@@ -169,20 +171,13 @@ func (bs *BlobStore) Get(hint Hint, key []byte) ([]byte, error) {
 		}
 		return data, nil
 	case BlockDB:
-		id := types.BlockID(types.BytesToHash(key).ToHash20())
-		blk, err := blocks.Get(bs.DB, id)
-		if err != nil {
-			return nil, fmt.Errorf("get block: %w", err)
-		}
-		data, err := codec.Encode(blk)
-		if err != nil {
-			return data, fmt.Errorf("serialize: %w", err)
-		}
-		return data, nil
+		return blocks.GetBlob(bs.DB, key)
 	case TXDB:
 		return transactions.GetBlob(bs.DB, key)
 	case POETDB:
 		return poets.Get(bs.DB, key)
+	case MalfeasanceDB:
+		return identities.GetProof(bs.DB, key)
 	}
 	return nil, fmt.Errorf("blob store not found %s", hint)
 }
@@ -2,6 +2,7 @@ package datastore
 
 import (
 	"bytes"
+	"context"
 	"testing"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/sql/atxs"
 	"github.com/spacemeshos/go-spacemesh/sql/ballots"
 	"github.com/spacemeshos/go-spacemesh/sql/blocks"
+	"github.com/spacemeshos/go-spacemesh/sql/identities"
 	"github.com/spacemeshos/go-spacemesh/sql/poets"
 	"github.com/spacemeshos/go-spacemesh/sql/proposals"
 	"github.com/spacemeshos/go-spacemesh/sql/transactions"
@@ -125,6 +127,23 @@ func TestBlobStore_GetPoetBlob(t *testing.T) {
 	require.ErrorIs(t, err, sql.ErrNotFound)
 }
 
+func TestBlobStore_GetMalfeasanceBlob(t *testing.T) {
+	db := sql.InMemory()
+	bs := NewBlobStore(db)
+
+	nodeID := types.BytesToNodeID(signing.NewEdSigner().PublicKey().Bytes())
+	proof := []byte("proof0")
+
+	_, err := bs.Get(MalfeasanceDB, nodeID.ToBytes())
+	require.ErrorIs(t, err, sql.ErrNotFound)
+	require.NoError(t, db.WithTx(context.Background(), func(tx *sql.Tx) error {
+		return identities.AddProof(tx, nodeID.ToBytes(), proof, time.Now())
+	}))
+	got, err := bs.Get(MalfeasanceDB, nodeID.ToBytes())
+	require.NoError(t, err)
+	require.Equal(t, proof, got)
+}
+
 func TestBlobStore_GetProposalBlob(t *testing.T) {
 	db := sql.InMemory()
 	bs := NewBlobStore(db)
@@ -0,0 +1,45 @@
+package events
+
+import (
+	"time"
+
+	atypes "github.com/spacemeshos/go-spacemesh/activation/types"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log"
+)
+
+// EventAtxPhase reports completion of a phase of the ATX construction.
+type EventAtxPhase struct {
+	Smesher      types.NodeID
+	PublishEpoch types.EpochID
+	Phase        atypes.AtxPhase
+	Deadline     time.Time
+	Completed    time.Time
+	// Slack is the time that was left until the deadline, negative if the deadline was missed.
+	Slack time.Duration
+}
+
+// ReportAtxPhase reports completion of a phase of the ATX construction.
+func ReportAtxPhase(ev EventAtxPhase) {
+	mu.RLock()
+	defer mu.RUnlock()
+	if reporter != nil {
+		if err := reporter.atxPhaseEmitter.Emit(ev); err != nil {
+			log.With().Error("failed to emit atx phase event", log.Err(err))
+		}
+	}
+}
+
+// SubscribeAtxPhases subscribes to the progress of the ATX construction.
+func SubscribeAtxPhases() Subscription {
+	mu.RLock()
+	defer mu.RUnlock()
+	if reporter != nil {
+		sub, err := reporter.bus.Subscribe(new(EventAtxPhase))
+		if err != nil {
+			log.With().Panic("failed to subscribe to atx phase events")
+		}
+		return sub
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package events
+
+import (
+	"math/big"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log"
+)
+
+// BeaconStage is the stage of the beacon protocol that is reported in the event.
+type BeaconStage uint8
+
+const (
+	// BeaconProposals is reported when the proposal phase is finished.
+	BeaconProposals BeaconStage = iota + 1
+	// BeaconRound is reported when the voting round is finished.
+	BeaconRound
+	// BeaconCalculated is reported when the beacon is calculated by the protocol.
+	BeaconCalculated
+	// BeaconFromBallots is reported when the beacon is adopted from the ballots.
+	BeaconFromBallots
+	// BeaconManual is reported when the beacon is set by the operator.
+	BeaconManual
+)
+
+func (s BeaconStage) String() string {
+	switch s {
+	case BeaconProposals:
+		return "proposals"
+	case BeaconRound:
+		return "round"
+	case BeaconCalculated:
+		return "calculated"
+	case BeaconFromBallots:
+		return "ballots"
+	case BeaconManual:
+		return "manual"
+	default:
+		return "unknown"
+	}
+}
+
+// BeaconMargin is the votes margin of the beacon proposal.
+type BeaconMargin struct {
+	Proposal []byte
+	Margin   *big.Int
+}
+
+// EventBeacon reports progress of the beacon protocol.
+type EventBeacon struct {
+	// Epoch is the epoch the beacon is used in.
+	Epoch types.EpochID
+	Stage BeaconStage
+
+	// set for BeaconProposals stage.
+	ValidProposals            int
+	PotentiallyValidProposals int
+
+	// set for BeaconRound stage.
+	Round types.RoundID
+	// Margins are votes margins of proposals after the round.
+	Margins []BeaconMargin
+	// WeakCoin is set if the weak coin was used in the round.
+	WeakCoin *bool
+
+	// set for BeaconCalculated, BeaconFromBallots and BeaconManual stages.
+	Beacon types.Beacon
+	// Weight is the weight of ballots that reported the beacon, set for BeaconFromBallots stage.
+	Weight uint64
+}
+
+// ReportBeacon reports progress of the beacon protocol.
+func ReportBeacon(ev EventBeacon) {
+	mu.RLock()
+	defer mu.RUnlock()
+	if reporter != nil {
+		if err := reporter.beaconEmitter.Emit(ev); err != nil {
+			log.With().Error("failed to emit beacon event", log.Err(err))
+		}
+	}
+}
+
+// SubscribeBeacon subscribes to the progress of the beacon protocol.
+func SubscribeBeacon() Subscription {
+	mu.RLock()
+	defer mu.RUnlock()
+	if reporter != nil {
+		sub, err := reporter.bus.Subscribe(new(EventBeacon))
+		if err != nil {
+			log.With().Panic("failed to subscribe to beacon events")
+		}
+		return sub
+	}
+	return nil
+}
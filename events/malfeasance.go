@@ -0,0 +1,37 @@
+package events
+
+import (
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log"
+)
+
+// EventMalfeasance includes the malfeasance proof and the identity that it proves to be malicious.
+type EventMalfeasance struct {
+	Smesher types.NodeID
+	Proof   *types.MalfeasanceProof
+}
+
+// ReportMalfeasance reports a new malfeasance proof.
+func ReportMalfeasance(smesher types.NodeID, proof *types.MalfeasanceProof) {
+	mu.RLock()
+	defer mu.RUnlock()
+	if reporter != nil {
+		if err := reporter.malfeasanceEmitter.Emit(EventMalfeasance{Smesher: smesher, Proof: proof}); err != nil {
+			log.With().Error("failed to emit malfeasance proof", log.Err(err))
+		}
+	}
+}
+
+// SubscribeMalfeasance subscribes to the malfeasance proofs.
+func SubscribeMalfeasance() Subscription {
+	mu.RLock()
+	defer mu.RUnlock()
+	if reporter != nil {
+		sub, err := reporter.bus.Subscribe(new(EventMalfeasance))
+		if err != nil {
+			log.With().Panic("failed to subscribe to malfeasance proofs")
+		}
+		return sub
+	}
+	return nil
+}
@@ -369,6 +369,7 @@ type Reward struct {
 	Total       uint64
 	LayerReward uint64
 	Coinbase    types.Address
+	Smesher     types.NodeID
 }
 
 // Transaction wraps a tx with its layer ID and validity info.
@@ -403,6 +404,9 @@ type EventReporter struct {
 	rewardEmitter      event.Emitter
 	resultsEmitter     event.Emitter
 	proposalsEmitter   event.Emitter
+	malfeasanceEmitter event.Emitter
+	beaconEmitter      event.Emitter
+	atxPhaseEmitter    event.Emitter
 	stopChan           chan struct{}
 }
 
@@ -454,6 +458,21 @@ func newEventReporter() *EventReporter {
 		log.With().Panic("failed to to create proposal emitter", log.Err(err))
 	}
 
+	malfeasanceEmitter, err := bus.Emitter(new(EventMalfeasance))
+	if err != nil {
+		log.With().Panic("failed to create malfeasance emitter", log.Err(err))
+	}
+
+	beaconEmitter, err := bus.Emitter(new(EventBeacon))
+	if err != nil {
+		log.With().Panic("failed to create beacon emitter", log.Err(err))
+	}
+
+	atxPhaseEmitter, err := bus.Emitter(new(EventAtxPhase))
+	if err != nil {
+		log.With().Panic("failed to create atx phase emitter", log.Err(err))
+	}
+
 	return &EventReporter{
 		bus:                bus,
 		transactionEmitter: transactionEmitter,
@@ -465,6 +484,9 @@ func newEventReporter() *EventReporter {
 		resultsEmitter:     resultsEmitter,
 		errorEmitter:       errorEmitter,
 		proposalsEmitter:   proposalsEmitter,
+		malfeasanceEmitter: malfeasanceEmitter,
+		beaconEmitter:      beaconEmitter,
+		atxPhaseEmitter:    atxPhaseEmitter,
 		stopChan:           make(chan struct{}),
 	}
 }
@@ -501,6 +523,15 @@ func CloseEventReporter() {
 		if err := reporter.proposalsEmitter.Close(); err != nil {
 			log.With().Panic("failed to close propoposalsEmitter", log.Err(err))
 		}
+		if err := reporter.malfeasanceEmitter.Close(); err != nil {
+			log.With().Panic("failed to close malfeasanceEmitter", log.Err(err))
+		}
+		if err := reporter.beaconEmitter.Close(); err != nil {
+			log.With().Panic("failed to close beaconEmitter", log.Err(err))
+		}
+		if err := reporter.atxPhaseEmitter.Close(); err != nil {
+			log.With().Panic("failed to close atxPhaseEmitter", log.Err(err))
+		}
 
 		close(reporter.stopChan)
 		reporter = nil
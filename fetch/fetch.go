@@ -16,6 +16,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/datastore"
 	"github.com/spacemeshos/go-spacemesh/log"
 	"github.com/spacemeshos/go-spacemesh/p2p"
+	"github.com/spacemeshos/go-spacemesh/p2p/reputation"
 	"github.com/spacemeshos/go-spacemesh/p2p/server"
 )
 
@@ -85,6 +86,10 @@ type Config struct {
 	BatchSize            int
 	RequestTimeout       time.Duration // in seconds
 	MaxRetriesForRequest int
+	// ServerLimits are limits of the served protocols, keyed by protocol. Protocols without limits are not limited.
+	ServerLimits map[string]server.Limits `mapstructure:"fetch-server-limits"`
+	// EgressBytesPerSecond limits bytes per second in responses of all protocols. Zero disables the limit.
+	EgressBytesPerSecond int `mapstructure:"fetch-egress-bytes-per-second"`
 }
 
 // DefaultConfig is the default config for the fetch component.
@@ -95,6 +100,8 @@ func DefaultConfig() Config {
 		BatchSize:            20,
 		RequestTimeout:       time.Second * time.Duration(10),
 		MaxRetriesForRequest: 100,
+		// served protocols are not limited by default
+		ServerLimits: map[string]server.Limits{},
 	}
 }
 
@@ -172,6 +179,20 @@ func WithPoetHandler(h poetHandler) Option {
 	}
 }
 
+// WithMalfeasanceHandler configures the malfeasance handler of the fetcher.
+func WithMalfeasanceHandler(h malfeasanceHandler) Option {
+	return func(f *Fetch) {
+		f.malHandler = h
+	}
+}
+
+// WithReputation configures the reputation of peers that is updated with the quality of their responses.
+func WithReputation(r *reputation.Reputation) Option {
+	return func(f *Fetch) {
+		f.reputation = r
+	}
+}
+
 func withServers(s map[string]requester) Option {
 	return func(f *Fetch) {
 		f.servers = s
@@ -198,6 +219,8 @@ type Fetch struct {
 	blockHandler    blockHandler
 	proposalHandler proposalHandler
 	txHandler       txHandler
+	malHandler      malfeasanceHandler
+	reputation      *reputation.Reputation
 
 	// activeRequests contains requests that are not processed
 	activeRequests map[types.Hash32][]*request
@@ -237,17 +260,22 @@ func NewFetch(cdb *datastore.CachedDB, msh meshProvider, host *p2p.Host, opts ..
 	}
 
 	f.batchTimeout = time.NewTicker(f.cfg.BatchTimeout)
-	srvOpts := []server.Opt{
-		server.WithTimeout(f.cfg.RequestTimeout),
-		server.WithLog(f.logger),
+	egress := server.NewBytesLimiter(f.cfg.EgressBytesPerSecond)
+	srvOpts := func(proto string) []server.Opt {
+		return []server.Opt{
+			server.WithTimeout(f.cfg.RequestTimeout),
+			server.WithLog(f.logger),
+			server.WithLimits(f.cfg.ServerLimits[proto]),
+			server.WithEgress(egress),
+		}
 	}
 	if len(f.servers) == 0 {
 		h := newHandler(cdb, bs, msh, f.logger)
-		f.servers[atxProtocol] = server.New(host, atxProtocol, h.handleEpochATXIDsReq, srvOpts...)
-		f.servers[lyrDataProtocol] = server.New(host, lyrDataProtocol, h.handleLayerDataReq, srvOpts...)
-		f.servers[lyrOpnsProtocol] = server.New(host, lyrOpnsProtocol, h.handleLayerOpinionsReq, srvOpts...)
-		f.servers[hashProtocol] = server.New(host, hashProtocol, h.handleHashReq, srvOpts...)
-		f.servers[meshHashProtocol] = server.New(host, meshHashProtocol, h.handleMeshHashReq, srvOpts...)
+		f.servers[atxProtocol] = server.New(host, atxProtocol, h.handleEpochATXIDsReq, srvOpts(atxProtocol)...)
+		f.servers[lyrDataProtocol] = server.New(host, lyrDataProtocol, h.handleLayerDataReq, srvOpts(lyrDataProtocol)...)
+		f.servers[lyrOpnsProtocol] = server.New(host, lyrOpnsProtocol, h.handleLayerOpinionsReq, srvOpts(lyrOpnsProtocol)...)
+		f.servers[hashProtocol] = server.New(host, hashProtocol, h.handleHashReq, srvOpts(hashProtocol)...)
+		f.servers[meshHashProtocol] = server.New(host, meshHashProtocol, h.handleMeshHashReq, srvOpts(meshHashProtocol)...)
 	}
 	return f
 }
@@ -342,7 +370,7 @@ func (f *Fetch) loop(handler func(*request)) {
 }
 
 // receive Data from message server and call response handlers accordingly.
-func (f *Fetch) receiveResponse(data []byte) {
+func (f *Fetch) receiveResponse(p p2p.Peer, data []byte) {
 	if f.stopped() {
 		return
 	}
@@ -350,7 +378,11 @@ func (f *Fetch) receiveResponse(data []byte) {
 	var response ResponseBatch
 	err := codec.Decode(data, &response)
 	if err != nil {
-		f.logger.With().Error("response was unclear, maybe leaking", log.Err(err))
+		f.logger.With().Warning("failed to decode response",
+			log.String("peer", p.String()),
+			log.Err(err),
+		)
+		f.reputation.Report(p, reputation.Malformed)
 		return
 	}
 
@@ -364,6 +396,7 @@ func (f *Fetch) receiveResponse(data []byte) {
 
 	// convert requests to map so it can be invalidated when reading Responses
 	batchMap := batch.ToMap()
+	behaviour := reputation.Valid
 	// iterate all hash Responses
 	for _, resID := range response.Responses {
 		// take lock here to make handling of a single hash atomic
@@ -375,10 +408,11 @@ func (f *Fetch) receiveResponse(data []byte) {
 			var err error
 			if req.validateResponseHash {
 				if actualHash == (types.Hash32{}) {
-					actualHash = types.CalcHash32(data)
+					actualHash = types.CalcHash32(resID.Data)
 				}
 				if actualHash != resID.Hash {
 					err = fmt.Errorf("%w: %v, actual %v", errWrongHash, resID.Hash.ShortString(), actualHash.ShortString())
+					behaviour = reputation.WrongHash
 				}
 			}
 			req.returnChan <- HashDataPromiseResult{
@@ -387,7 +421,6 @@ func (f *Fetch) receiveResponse(data []byte) {
 				Data:    resID.Data,
 				IsLocal: false,
 			}
-			// todo: mark peer as malicious
 		}
 		// remove from map
 		delete(batchMap, resID.Hash)
@@ -396,6 +429,7 @@ func (f *Fetch) receiveResponse(data []byte) {
 		delete(f.pendingRequests, resID.Hash)
 		f.activeReqM.Unlock()
 	}
+	f.reputation.Report(p, behaviour)
 
 	// iterate all requests that didn't return value from peer and notify
 	// they will be retried for MaxRetriesForRequest
@@ -550,7 +584,9 @@ func (f *Fetch) sendBatch(p p2p.Peer, requests []RequestMessage) error {
 			log.Int("num_requests", len(batch.Requests)),
 			log.String("peer", p.String()))
 
-		err = f.servers[hashProtocol].Request(f.shutdownCtx, p, bytes, f.receiveResponse, errorFunc)
+		err = f.servers[hashProtocol].Request(f.shutdownCtx, p, bytes, func(data []byte) {
+			f.receiveResponse(p, data)
+		}, errorFunc)
 		if err == nil {
 			break
 		}
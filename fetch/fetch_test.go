@@ -16,6 +16,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/fetch/mocks"
 	"github.com/spacemeshos/go-spacemesh/log/logtest"
 	"github.com/spacemeshos/go-spacemesh/p2p"
+	"github.com/spacemeshos/go-spacemesh/p2p/reputation"
 	"github.com/spacemeshos/go-spacemesh/sql"
 )
 
@@ -36,6 +37,7 @@ type testFetch struct {
 	method     int
 	mTxH       *mocks.MocktxHandler
 	mPoetH     *mocks.MockpoetHandler
+	mMalH      *mocks.MockmalfeasanceHandler
 }
 
 func createFetch(tb testing.TB) *testFetch {
@@ -53,13 +55,14 @@ func createFetch(tb testing.TB) *testFetch {
 		mProposalH: mocks.NewMockproposalHandler(ctrl),
 		mTxH:       mocks.NewMocktxHandler(ctrl),
 		mPoetH:     mocks.NewMockpoetHandler(ctrl),
+		mMalH:      mocks.NewMockmalfeasanceHandler(ctrl),
 	}
 	cfg := Config{
-		time.Millisecond * time.Duration(2000), // make sure we never hit the batch timeout
-		3,
-		3,
-		time.Second * time.Duration(3),
-		3,
+		BatchTimeout:         time.Millisecond * time.Duration(2000), // make sure we never hit the batch timeout
+		MaxRetriesForPeer:    3,
+		BatchSize:            3,
+		RequestTimeout:       time.Second * time.Duration(3),
+		MaxRetriesForRequest: 3,
 	}
 	lg := logtest.New(tb)
 	tf.Fetch = NewFetch(datastore.NewCachedDB(sql.InMemory(), lg), tf.mMesh, nil,
@@ -72,6 +75,7 @@ func createFetch(tb testing.TB) *testFetch {
 		WithProposalHandler(tf.mProposalH),
 		WithTXHandler(tf.mTxH),
 		WithPoetHandler(tf.mPoetH),
+		WithMalfeasanceHandler(tf.mMalH),
 		withServers(map[string]requester{
 			atxProtocol:      tf.mAtxS,
 			lyrDataProtocol:  tf.mLyrS,
@@ -108,17 +112,27 @@ func TestFetch_GetHash(t *testing.T) {
 
 func TestFetch_RequestHashBatchFromPeers(t *testing.T) {
 	tt := []struct {
-		name     string
-		validate bool
-		err      error
+		name      string
+		validate  bool
+		validHash bool
+		err       error
+		score     float64
 	}{
 		{
 			name:     "request batch hash aggregated",
 			validate: false,
+			score:    1,
 		},
 		{
 			name:     "request batch hash aggregated and validated",
 			validate: true,
+			score:    -50,
+		},
+		{
+			name:      "request batch hash aggregated and validated with matching hash",
+			validate:  true,
+			validHash: true,
+			score:     1,
 		},
 		{
 			name: "request batch hash aggregated network failure",
@@ -134,10 +148,16 @@ func TestFetch_RequestHashBatchFromPeers(t *testing.T) {
 			f := createFetch(t)
 			f.cfg.MaxRetriesForRequest = 0
 			f.cfg.MaxRetriesForPeer = 0
+			cfg := reputation.DefaultConfig()
+			cfg.HalfLife = 0
+			f.reputation = reputation.New(cfg)
 			peer := p2p.Peer("buddy")
 			f.mh.EXPECT().GetPeers().Return([]p2p.Peer{peer})
 
 			hsh := types.RandomHash()
+			if tc.validHash {
+				hsh = types.CalcHash32([]byte("a"))
+			}
 			res := ResponseMessage{
 				Hash: hsh,
 				Data: []byte("a"),
@@ -175,12 +195,13 @@ func TestFetch_RequestHashBatchFromPeers(t *testing.T) {
 			for x := range req.returnChan {
 				if tc.err != nil {
 					require.ErrorIs(t, x.Err, tc.err)
-				} else if tc.validate {
+				} else if tc.validate && !tc.validHash {
 					require.ErrorIs(t, x.Err, errWrongHash)
 				} else {
 					require.NoError(t, x.Err)
 				}
 			}
+			require.Equal(t, tc.score, f.reputation.Score(peer))
 		})
 	}
 }
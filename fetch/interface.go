@@ -38,6 +38,10 @@ type poetHandler interface {
 	ValidateAndStoreMsg(data []byte) error
 }
 
+type malfeasanceHandler interface {
+	HandleSyncedMalfeasanceProof(context.Context, []byte) error
+}
+
 type meshProvider interface {
 	LastVerified() types.LayerID
 }
@@ -66,6 +66,19 @@ func (f *Fetch) getHashes(ctx context.Context, hashes []types.Hash32, hint datas
 	return errs
 }
 
+// GetMalfeasanceProofs gets malfeasance proofs for the specified NodeIDs and validates them.
+func (f *Fetch) GetMalfeasanceProofs(ctx context.Context, ids []types.NodeID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	f.logger.WithContext(ctx).With().Debug("requesting malfeasance proofs from peer", log.Int("num_proofs", len(ids)))
+	hashes := types.NodeIDsToHashes(ids)
+	if errs := f.getHashes(ctx, hashes, datastore.MalfeasanceDB, f.malHandler.HandleSyncedMalfeasanceProof); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
 // GetBallots gets data for the specified BallotIDs and validates them.
 func (f *Fetch) GetBallots(ctx context.Context, ids []types.BallotID) error {
 	if len(ids) == 0 {
@@ -511,6 +511,71 @@ func TestGetATXs(t *testing.T) {
 	}
 }
 
+func TestGetMalfeasanceProofs(t *testing.T) {
+	nodeIDs := []types.NodeID{{1}, {2}}
+	hashes := types.NodeIDsToHashes(nodeIDs)
+	errUnknown := errors.New("unknown")
+	tt := []struct {
+		name         string
+		fetchErrs    []error
+		hdlrErr, err error
+	}{
+		{
+			name:      "all hashes fetched",
+			fetchErrs: []error{nil, nil},
+		},
+		{
+			name:      "some hashes failed",
+			fetchErrs: []error{nil, errUnknown},
+			err:       errUnknown,
+		},
+		{
+			name:      "handler failed",
+			fetchErrs: []error{nil, nil},
+			hdlrErr:   errUnknown,
+			err:       errUnknown,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := createFetch(t)
+			results := make(map[types.Hash32]HashDataPromiseResult, len(hashes))
+			for i, h := range hashes {
+				if tc.fetchErrs[i] == nil {
+					data, err := codec.Encode(types.NewMalfeasanceProof(types.MultipleBallots,
+						types.NewSignedMessage([]byte{byte(i)}, nil),
+						types.NewSignedMessage([]byte{byte(i + 1)}, nil),
+					))
+					require.NoError(t, err)
+					results[h] = HashDataPromiseResult{
+						Hash: h,
+						Data: data,
+					}
+					f.mMalH.EXPECT().HandleSyncedMalfeasanceProof(gomock.Any(), data).Return(tc.hdlrErr)
+				} else {
+					results[h] = HashDataPromiseResult{
+						Hash: h,
+						Err:  tc.fetchErrs[i],
+					}
+				}
+			}
+
+			var eg errgroup.Group
+			startTestLoop(f.Fetch, &eg, func(req *request) {
+				req.returnChan <- results[req.hash]
+			})
+
+			require.ErrorIs(t, f.GetMalfeasanceProofs(context.TODO(), nodeIDs), tc.err)
+			f.cancel()
+			require.NoError(t, eg.Wait())
+		})
+	}
+}
+
 func TestGetPoetProof(t *testing.T) {
 	f := createFetch(t)
 	proof := types.PoetProofMessage{}
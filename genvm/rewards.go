@@ -8,37 +8,57 @@ import (
 
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/common/util"
+	"github.com/spacemeshos/go-spacemesh/events"
 	"github.com/spacemeshos/go-spacemesh/genvm/core"
 	"github.com/spacemeshos/go-spacemesh/log"
 	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/atxs"
 	"github.com/spacemeshos/go-spacemesh/sql/rewards"
 )
 
-// ValidateRewards syntactically validates rewards.
-func ValidateRewards(rewards []types.AnyReward) error {
+// ValidateRewards syntactically validates rewards of the block in the layer.
+//
+// Starting from the reward atx layer every reward must reference an ATX, and the block may contain
+// at most one reward per ATX. Multiple rewards for the same coinbase are valid, as several smeshers
+// may share the coinbase and each of them is rewarded separately.
+// Rewards in the earlier layers don't reference ATXs, and the block may contain at most one reward per coinbase.
+func ValidateRewards(lid types.LayerID, rewards []types.AnyReward) error {
 	if len(rewards) == 0 {
 		return fmt.Errorf("empty rewards")
 	}
-	unique := map[core.Address]struct{}{}
+	legacy := lid.Before(types.GetRewardAtxLayer())
+	unique := map[types.ATXID]struct{}{}
+	coinbases := map[types.Address]struct{}{}
 	for _, reward := range rewards {
 		if reward.Weight.Num == 0 || reward.Weight.Denom == 0 {
 			return fmt.Errorf("reward with invalid (zeroed) weight (%d/%d) included into the block for %v", reward.Weight.Num, reward.Weight.Denom, reward.Coinbase)
 		}
-		if _, exists := unique[reward.Coinbase]; exists {
-			return fmt.Errorf("multiple rewards for the same coinbase %v", reward.Coinbase)
+		if legacy {
+			if _, exists := coinbases[reward.Coinbase]; exists {
+				return fmt.Errorf("multiple rewards for the same coinbase %v", reward.Coinbase)
+			}
+			coinbases[reward.Coinbase] = struct{}{}
+			continue
 		}
-		unique[reward.Coinbase] = struct{}{}
+		if reward.AtxID == *types.EmptyATXID {
+			return fmt.Errorf("reward without atx included into the block for %v", reward.Coinbase)
+		}
+		if _, exists := unique[reward.AtxID]; exists {
+			return fmt.Errorf("multiple rewards for the same atx %v", reward.AtxID)
+		}
+		unique[reward.AtxID] = struct{}{}
 	}
 	return nil
 }
 
-func (v *VM) addRewards(lctx ApplyContext, ss *core.StagedCache, tx *sql.Tx, fees uint64, blockRewards []types.AnyReward) error {
+func (v *VM) addRewards(lctx ApplyContext, ss *core.StagedCache, tx *sql.Tx, fees uint64, blockRewards []types.AnyReward) ([]*types.Reward, error) {
 	var (
 		layersAfterEffectiveGenesis = lctx.Layer.Difference(types.GetEffectiveGenesis())
 		subsidy                     = erewards.TotalSubsidyAtLayer(layersAfterEffectiveGenesis)
 		total                       = subsidy + fees
 		transferred                 uint64
 		totalWeight                 = util.WeightFromUint64(0)
+		added                       = make([]*types.Reward, 0, len(blockRewards))
 	)
 	for _, blockReward := range blockRewards {
 		totalWeight.Add(util.WeightFromNumDenom(blockReward.Weight.Num, blockReward.Weight.Denom))
@@ -51,7 +71,7 @@ func (v *VM) addRewards(lctx ApplyContext, ss *core.StagedCache, tx *sql.Tx, fee
 			Mul(totalReward, relative.Num()).
 			Quo(totalReward, relative.Denom())
 		if !totalReward.IsUint64() {
-			return fmt.Errorf("%w: total reward %v for %v overflows uint64",
+			return nil, fmt.Errorf("%w: total reward %v for %v overflows uint64",
 				core.ErrInternal, totalReward, blockReward.Coinbase)
 		}
 
@@ -60,7 +80,7 @@ func (v *VM) addRewards(lctx ApplyContext, ss *core.StagedCache, tx *sql.Tx, fee
 			Mul(subsidyReward, relative.Num()).
 			Quo(subsidyReward, relative.Denom())
 		if !subsidyReward.IsUint64() {
-			return fmt.Errorf("%w: subsidy reward %v for %v overflows uint64",
+			return nil, fmt.Errorf("%w: subsidy reward %v for %v overflows uint64",
 				core.ErrInternal, subsidyReward, blockReward.Coinbase)
 		}
 
@@ -72,24 +92,40 @@ func (v *VM) addRewards(lctx ApplyContext, ss *core.StagedCache, tx *sql.Tx, fee
 			log.Uint64("total", totalReward.Uint64()),
 		)
 
+		var (
+			smesher types.NodeID
+			err     error
+		)
+		// rewards in layers before the reward atx layer don't reference atxs.
+		// atxs of the other rewards are fetched together with the block, if the atx is not available
+		// the layer is not applied and will be retried.
+		if blockReward.AtxID != *types.EmptyATXID {
+			smesher, err = atxs.GetNodeID(tx, blockReward.AtxID)
+			if err != nil {
+				return nil, fmt.Errorf("%w: smesher for %v: %s", core.ErrInternal, blockReward.AtxID, err.Error())
+			}
+		}
 		reward := &types.Reward{
 			Layer:       lctx.Layer,
 			Coinbase:    blockReward.Coinbase,
+			SmesherID:   smesher,
+			AtxID:       blockReward.AtxID,
 			TotalReward: totalReward.Uint64(),
 			LayerReward: subsidyReward.Uint64(),
 		}
 		if err := rewards.Add(tx, reward); err != nil {
-			return fmt.Errorf("%w: %s", core.ErrInternal, err.Error())
+			return nil, fmt.Errorf("%w: %s", core.ErrInternal, err.Error())
 		}
 		account, err := ss.Get(blockReward.Coinbase)
 		if err != nil {
-			return fmt.Errorf("%w: %s", core.ErrInternal, err.Error())
+			return nil, fmt.Errorf("%w: %s", core.ErrInternal, err.Error())
 		}
 		account.Balance += reward.TotalReward
 		if err := ss.Update(account); err != nil {
-			return fmt.Errorf("%w: %s", core.ErrInternal, err.Error())
+			return nil, fmt.Errorf("%w: %s", core.ErrInternal, err.Error())
 		}
 		transferred += totalReward.Uint64()
+		added = append(added, reward)
 	}
 	v.logger.With().Debug("rewards for layer",
 		lctx.Layer,
@@ -104,5 +140,17 @@ func (v *VM) addRewards(lctx ApplyContext, ss *core.StagedCache, tx *sql.Tx, fee
 	subsidyCount.Add(float64(subsidy))
 	rewardsCount.Add(float64(transferred))
 	burntCount.Add(float64(total - transferred))
-	return nil
+	return added, nil
+}
+
+func reportRewards(added []*types.Reward) {
+	for _, reward := range added {
+		events.ReportRewardReceived(events.Reward{
+			Layer:       reward.Layer,
+			Total:       reward.TotalReward,
+			LayerReward: reward.LayerReward,
+			Coinbase:    reward.Coinbase,
+			Smesher:     reward.SmesherID,
+		})
+	}
 }
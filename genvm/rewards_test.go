@@ -7,11 +7,17 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/genvm/core"
+	"github.com/spacemeshos/go-spacemesh/sql/rewards"
 )
 
 func TestValidateRewards(t *testing.T) {
+	atxLayer := types.NewLayerID(10)
+	types.SetRewardAtxLayer(atxLayer)
+	t.Cleanup(func() { types.SetRewardAtxLayer(types.LayerID{}) })
 	for _, tc := range []struct {
 		desc    string
+		legacy  bool
 		rewards []types.AnyReward
 		err     bool
 	}{
@@ -20,14 +26,17 @@ func TestValidateRewards(t *testing.T) {
 			rewards: []types.AnyReward{
 				{
 					Coinbase: types.Address{1},
+					AtxID:    types.ATXID{1},
 					Weight:   types.RatNum{Num: 1, Denom: 3},
 				},
 				{
 					Coinbase: types.Address{2},
+					AtxID:    types.ATXID{2},
 					Weight:   types.RatNum{Num: 1, Denom: 3},
 				},
 				{
 					Coinbase: types.Address{3},
+					AtxID:    types.ATXID{3},
 					Weight:   types.RatNum{Num: 1, Denom: 3},
 				},
 			},
@@ -46,10 +55,12 @@ func TestValidateRewards(t *testing.T) {
 			rewards: []types.AnyReward{
 				{
 					Coinbase: types.Address{1},
+					AtxID:    types.ATXID{4},
 					Weight:   types.RatNum{Num: 1, Denom: 3},
 				},
 				{
 					Coinbase: types.Address{3},
+					AtxID:    types.ATXID{5},
 					Weight:   types.RatNum{Num: 0, Denom: 3},
 				},
 			},
@@ -60,24 +71,83 @@ func TestValidateRewards(t *testing.T) {
 			rewards: []types.AnyReward{
 				{
 					Coinbase: types.Address{1},
+					AtxID:    types.ATXID{6},
 					Weight:   types.RatNum{Num: 1, Denom: 3},
 				},
 				{
 					Coinbase: types.Address{3},
+					AtxID:    types.ATXID{7},
 					Weight:   types.RatNum{Num: 1, Denom: 0},
 				},
 			},
 			err: true,
 		},
 		{
-			desc: "multiple per coinbase",
+			desc: "multiple per coinbase are allowed",
 			rewards: []types.AnyReward{
 				{
 					Coinbase: types.Address{1},
+					AtxID:    types.ATXID{8},
 					Weight:   types.RatNum{Num: 1, Denom: 3},
 				},
 				{
 					Coinbase: types.Address{3},
+					AtxID:    types.ATXID{9},
+					Weight:   types.RatNum{Num: 1, Denom: 3},
+				},
+				{
+					Coinbase: types.Address{1},
+					AtxID:    types.ATXID{10},
+					Weight:   types.RatNum{Num: 1, Denom: 3},
+				},
+			},
+		},
+		{
+			desc: "multiple per atx",
+			rewards: []types.AnyReward{
+				{
+					Coinbase: types.Address{1},
+					AtxID:    types.ATXID{11},
+					Weight:   types.RatNum{Num: 1, Denom: 3},
+				},
+				{
+					Coinbase: types.Address{2},
+					AtxID:    types.ATXID{11},
+					Weight:   types.RatNum{Num: 1, Denom: 3},
+				},
+			},
+			err: true,
+		},
+		{
+			desc: "empty atx",
+			rewards: []types.AnyReward{
+				{
+					Coinbase: types.Address{1},
+					Weight:   types.RatNum{Num: 1, Denom: 3},
+				},
+			},
+			err: true,
+		},
+		{
+			desc:   "legacy without atx",
+			legacy: true,
+			rewards: []types.AnyReward{
+				{
+					Coinbase: types.Address{1},
+					Weight:   types.RatNum{Num: 1, Denom: 3},
+				},
+				{
+					Coinbase: types.Address{2},
+					Weight:   types.RatNum{Num: 1, Denom: 3},
+				},
+			},
+		},
+		{
+			desc:   "legacy multiple per coinbase",
+			legacy: true,
+			rewards: []types.AnyReward{
+				{
+					Coinbase: types.Address{1},
 					Weight:   types.RatNum{Num: 1, Denom: 3},
 				},
 				{
@@ -89,7 +159,11 @@ func TestValidateRewards(t *testing.T) {
 		},
 	} {
 		t.Run(tc.desc, func(t *testing.T) {
-			err := ValidateRewards(tc.rewards)
+			lid := atxLayer
+			if tc.legacy {
+				lid = atxLayer.Sub(1)
+			}
+			err := ValidateRewards(lid, tc.rewards)
 			if tc.err {
 				require.Error(t, err)
 			} else {
@@ -212,3 +286,32 @@ func TestRewards(t *testing.T) {
 	}
 	runTestCases(t, tcs, genTester)
 }
+
+func TestRewards_Atx(t *testing.T) {
+	tt := newTester(t).addSingleSig(2).applyGenesis()
+	lid := types.GetEffectiveGenesis().Add(1)
+	coinbase := tt.accounts[0].getAddress()
+
+	t.Run("unknown atx", func(t *testing.T) {
+		_, _, err := tt.Apply(ApplyContext{Layer: lid}, nil, []types.AnyReward{{
+			Coinbase: coinbase,
+			AtxID:    types.ATXID{1},
+			Weight:   types.RatNum{Num: 1, Denom: 1},
+		}})
+		require.ErrorIs(t, err, core.ErrInternal)
+	})
+	t.Run("legacy reward without atx", func(t *testing.T) {
+		types.SetRewardAtxLayer(lid.Add(1))
+		t.Cleanup(func() { types.SetRewardAtxLayer(types.LayerID{}) })
+
+		_, _, err := tt.Apply(ApplyContext{Layer: lid}, nil, []types.AnyReward{{
+			Coinbase: coinbase,
+			Weight:   types.RatNum{Num: 1, Denom: 1},
+		}})
+		require.NoError(t, err)
+		rst, err := rewards.List(tt.db, coinbase)
+		require.NoError(t, err)
+		require.Len(t, rst, 1)
+		require.Equal(t, types.NodeID{}, rst[0].SmesherID)
+	})
+}
@@ -212,7 +212,8 @@ func (v *VM) Apply(lctx ApplyContext, txs []types.Transaction, blockRewards []ty
 	t3 := time.Now()
 	blockDurationTxs.Observe(float64(time.Since(t2)))
 
-	if err := v.addRewards(lctx, ss, tx, fees, blockRewards); err != nil {
+	layerRewards, err := v.addRewards(lctx, ss, tx, fees, blockRewards)
+	if err != nil {
 		return nil, nil, err
 	}
 
@@ -247,6 +248,7 @@ func (v *VM) Apply(lctx ApplyContext, txs []types.Transaction, blockRewards []ty
 	if err := tx.Commit(); err != nil {
 		return nil, nil, fmt.Errorf("%w: %s", core.ErrInternal, err.Error())
 	}
+	reportRewards(layerRewards)
 	blockDurationPersist.Observe(float64(time.Since(t4)))
 	blockDuration.Observe(float64(time.Since(t1)))
 	transactionsPerBlock.Observe(float64(len(txs)))
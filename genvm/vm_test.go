@@ -30,6 +30,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/signing"
 	"github.com/spacemeshos/go-spacemesh/sql"
 	"github.com/spacemeshos/go-spacemesh/sql/accounts"
+	"github.com/spacemeshos/go-spacemesh/sql/atxs"
 	"github.com/spacemeshos/go-spacemesh/sql/layers"
 )
 
@@ -440,8 +441,10 @@ func (t *tester) rewards(all ...reward) []types.AnyReward {
 	var rst []types.AnyReward
 	for _, rew := range all {
 		rat := new(big.Rat).SetFloat64(rew.share)
+		coinbase := t.accounts[rew.address].getAddress()
 		rst = append(rst, types.AnyReward{
-			Coinbase: t.accounts[rew.address].getAddress(),
+			Coinbase: coinbase,
+			AtxID:    t.addAtx(coinbase),
 			Weight: types.RatNum{
 				Num:   rat.Num().Uint64(),
 				Denom: rat.Denom().Uint64(),
@@ -451,6 +454,24 @@ func (t *tester) rewards(all ...reward) []types.AnyReward {
 	return rst
 }
 
+// addAtx persists atx for the coinbase, it is required to attribute
+// rewards to the smesher.
+func (t *tester) addAtx(coinbase types.Address) types.ATXID {
+	var (
+		id     types.ATXID
+		nodeID types.NodeID
+	)
+	t.rng.Read(id[:])
+	t.rng.Read(nodeID[:])
+	atx := types.NewActivationTx(types.NIPostChallenge{}, coinbase, nil, 1, nil)
+	atx.SetID(&id)
+	atx.SetNodeID(&nodeID)
+	vatx, err := atx.Verify(0, 1)
+	require.NoError(t, err)
+	require.NoError(t, atxs.Add(t.VM.db, vatx, time.Now()))
+	return id
+}
+
 func (t *tester) estimateSpawnGas(principal int) int {
 	return t.accounts[principal].spawnGas() +
 		len(t.accounts[principal].selfSpawn(core.Nonce{}))*int(t.VM.cfg.StorageCostFactor)
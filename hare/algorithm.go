@@ -12,10 +12,13 @@ import (
 	"github.com/spacemeshos/go-spacemesh/codec"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/common/util"
+	"github.com/spacemeshos/go-spacemesh/events"
 	"github.com/spacemeshos/go-spacemesh/hare/config"
 	"github.com/spacemeshos/go-spacemesh/log"
 	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
 	"github.com/spacemeshos/go-spacemesh/signing"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/identities"
 )
 
 const (
@@ -33,7 +36,7 @@ const ( // constants of the different roles
 
 // Signer provides signing and public-key getter.
 type Signer interface {
-	Sign(m []byte) []byte
+	SignRequest(signing.Request) ([]byte, error)
 	PublicKey() *signing.PublicKey
 }
 
@@ -164,6 +167,7 @@ type consensusProcess struct {
 	oracle            Rolacle // the roles oracle provider
 	signing           Signer
 	nid               types.NodeID
+	db                *sql.Database
 	publisher         pubsub.Publisher
 	isStarted         bool
 	inbox             chan *Msg
@@ -178,14 +182,27 @@ type consensusProcess struct {
 	pending           map[string]*Msg // buffer for early messages that are pending process
 	notifySent        bool            // flag to set in case a notification had already been sent by this instance
 	mTracker          *msgsTracker    // tracks valid messages
+	eTracker          *equivocationTracker
+	trace             *tracer // nil if tracing is disabled
+	journal           journal // messages needed to resume the process after restart
+	initial           []types.ProposalID
+	resumed           bool // true if the process was resumed from the persisted state
 	terminating       bool
-	eligibilityCount  uint16
+	eligibility       map[types.NodeID]uint16 // eligibility count of every participant in the current round
 	clock             RoundClock
+	identities        []participant // additional identities that participate on behalf of this node
+}
+
+// participant is an identity of the node that takes part in the consensus process.
+type participant struct {
+	nid     types.NodeID
+	signing Signer
+	oracle  Rolacle
 }
 
 // newConsensusProcess creates a new consensus process instance.
 func newConsensusProcess(cfg config.Config, instanceID types.LayerID, s *Set, oracle Rolacle, stateQuerier stateQuerier,
-	layersPerEpoch uint16, signing Signer, nid types.NodeID, p2p pubsub.Publisher,
+	layersPerEpoch uint16, signing Signer, db *sql.Database, nid types.NodeID, p2p pubsub.Publisher,
 	terminationReport chan TerminationOutput,
 	ev roleValidator, clock RoundClock, logger log.Log,
 ) *consensusProcess {
@@ -197,6 +214,7 @@ func newConsensusProcess(cfg config.Config, instanceID types.LayerID, s *Set, or
 		oracle:            oracle,
 		signing:           signing,
 		nid:               nid,
+		db:                db,
 		publisher:         p2p,
 		preRoundTracker:   newPreRoundTracker(cfg.F+1, cfg.N, logger),
 		notifyTracker:     newNotifyTracker(cfg.N),
@@ -205,6 +223,10 @@ func newConsensusProcess(cfg config.Config, instanceID types.LayerID, s *Set, or
 		pending:           make(map[string]*Msg, cfg.N),
 		Log:               logger,
 		mTracker:          msgsTracker,
+		eTracker:          newEquivocationTracker(cfg.N),
+		trace:             newTracer(cfg, instanceID, s),
+		initial:           s.ToSlice(),
+		eligibility:       map[types.NodeID]uint16{},
 		clock:             clock,
 	}
 	proc.validator = newSyntaxContextValidator(signing, cfg.F+1, proc.statusValidator(), stateQuerier, layersPerEpoch, ev, msgsTracker, logger)
@@ -258,64 +280,23 @@ func (proc *consensusProcess) SetInbox(inbox chan *Msg) {
 // runs the main loop of the protocol.
 func (proc *consensusProcess) eventLoop(ctx context.Context) {
 	logger := proc.WithContext(ctx)
+	proc.trace.onStart()
+	defer func() {
+		proc.trace.onTerminated(proc.getK(), proc.s, notCompleted, "closed")
+		if err := proc.trace.flush(); err != nil {
+			logger.With().Error("failed to write consensus process trace", log.Err(err))
+		}
+	}()
 	logger.With().Info("consensus process started",
 		proc.instanceID,
 		log.String("current_set", proc.s.String()),
-		log.Int("set_size", proc.s.Size()))
-
-	// check participation and send message
-	go func() {
-		// check participation
-		if proc.shouldParticipate(ctx) {
-			// set pre-round InnerMsg and send
-			builder, err := proc.initDefaultBuilder(proc.s)
-			if err != nil {
-				logger.With().Error("init default builder failed", log.Err(err))
-				return
-			}
-			m := builder.SetType(pre).Sign(proc.signing).Build()
-			proc.sendMessage(ctx, m)
-		} else {
-			logger.With().Debug("should not participate",
-				log.Uint32("current_k", proc.getK()),
-				proc.instanceID)
-		}
-	}()
-
-	endOfRound := proc.clock.AwaitEndOfRound(preRound)
+		log.Int("set_size", proc.s.Size()),
+		log.Bool("resumed", proc.resumed))
 
-PreRound:
-	for {
-		select {
-		// listen to pre-round Messages
-		case msg := <-proc.inbox:
-			proc.handleMessage(ctx, msg)
-		case <-endOfRound:
-			break PreRound
-		case <-proc.CloseChannel():
-			logger.With().Info("terminating during preround: received termination signal",
-				log.Uint32("current_k", proc.getK()),
-				proc.instanceID)
-			return
-		}
-	}
-	logger.With().Debug("preround ended, filtering preliminary set",
-		proc.instanceID,
-		log.Int("set_size", proc.s.Size()))
-	proc.preRoundTracker.FilterSet(proc.s)
-	if proc.s.Size() == 0 {
-		logger.Event().Warning("preround ended with empty set",
-			proc.instanceID)
-	} else {
-		logger.With().Debug("preround ended",
-			log.Int("set_size", proc.s.Size()),
-			proc.instanceID)
+	if proc.getK() == preRound && !proc.runPreRound(ctx) {
+		return
 	}
-	proc.advanceToNextRound(ctx) // K was initialized to -1, K should be 0
-
-	// start first iteration
-	proc.onRoundBegin(ctx)
-	endOfRound = proc.clock.AwaitEndOfRound(proc.getK())
+	endOfRound := proc.clock.AwaitEndOfRound(proc.getK())
 
 	for {
 		select {
@@ -341,10 +322,12 @@ PreRound:
 					log.Int("limit", proc.cfg.LimitIterations),
 					log.Uint32("current_k", k),
 					proc.instanceID)
+				proc.trace.onTerminated(k, proc.s, notCompleted, "iterations limit")
 				proc.report(notCompleted)
 				proc.Close()
 			}
 			proc.onRoundBegin(ctx)
+			proc.persistState(ctx)
 			endOfRound = proc.clock.AwaitEndOfRound(k)
 
 		case <-proc.CloseChannel(): // close event
@@ -356,6 +339,57 @@ PreRound:
 	}
 }
 
+// runs the preround and begins the first iteration.
+// Returns false if the process was closed during the preround.
+func (proc *consensusProcess) runPreRound(ctx context.Context) bool {
+	logger := proc.WithContext(ctx)
+
+	if !proc.resumed {
+		proc.persistState(ctx)
+		// check participation and send message
+		go proc.participate(ctx, proc.s, func(b *messageBuilder) *messageBuilder {
+			return b.SetType(pre)
+		})
+	}
+
+	endOfRound := proc.clock.AwaitEndOfRound(preRound)
+
+PreRound:
+	for {
+		select {
+		// listen to pre-round Messages
+		case msg := <-proc.inbox:
+			proc.handleMessage(ctx, msg)
+		case <-endOfRound:
+			proc.trace.onRoundEnd(proc.getK())
+			break PreRound
+		case <-proc.CloseChannel():
+			logger.With().Info("terminating during preround: received termination signal",
+				log.Uint32("current_k", proc.getK()),
+				proc.instanceID)
+			return false
+		}
+	}
+	logger.With().Debug("preround ended, filtering preliminary set",
+		proc.instanceID,
+		log.Int("set_size", proc.s.Size()))
+	proc.preRoundTracker.FilterSet(proc.s)
+	if proc.s.Size() == 0 {
+		logger.Event().Warning("preround ended with empty set",
+			proc.instanceID)
+	} else {
+		logger.With().Debug("preround ended",
+			log.Int("set_size", proc.s.Size()),
+			proc.instanceID)
+	}
+	proc.advanceToNextRound(ctx) // K was initialized to -1, K should be 0
+
+	// start first iteration
+	proc.onRoundBegin(ctx)
+	proc.persistState(ctx)
+	return true
+}
+
 // handles a message that has arrived early.
 func (proc *consensusProcess) onEarlyMessage(ctx context.Context, m *Msg) {
 	logger := proc.WithContext(ctx)
@@ -417,6 +451,10 @@ func (proc *consensusProcess) handleMessage(ctx context.Context, m *Msg) {
 				return
 			}
 
+			if !proc.checkEquivocation(ctx, logger, m) {
+				return
+			}
+
 			proc.onEarlyMessage(ctx, m)
 			return
 		}
@@ -432,6 +470,10 @@ func (proc *consensusProcess) handleMessage(ctx context.Context, m *Msg) {
 		return
 	}
 
+	if !proc.checkEquivocation(ctx, logger, m) {
+		return
+	}
+
 	// warn on late pre-round msgs
 	if m.InnerMsg.Type == pre && proc.getK() != preRound {
 		logger.Warning("encountered late preround message")
@@ -441,11 +483,55 @@ func (proc *consensusProcess) handleMessage(ctx context.Context, m *Msg) {
 	proc.processMsg(ctx, m)
 }
 
+// checkEquivocation tracks the message and reports the sender if it sent conflicting messages in the same round.
+// Returns false if the message should be discarded.
+func (proc *consensusProcess) checkEquivocation(ctx context.Context, logger log.Log, m *Msg) bool {
+	if proc.eTracker.IsMalicious(m.PubKey.String()) {
+		logger.Debug("sender equivocated in this instance, discarding")
+		return false
+	}
+	proof := proc.eTracker.Track(m)
+	if proof == nil {
+		return true
+	}
+	proc.reportEquivocation(ctx, logger, m.PubKey, proof)
+	// conflicting proposal is still passed to the proposal tracker, so that it can invalidate the leader's proposal
+	return m.InnerMsg.Type == proposal
+}
+
+// reportEquivocation stores proof of equivocation and gossips it to the network.
+func (proc *consensusProcess) reportEquivocation(ctx context.Context, logger log.Log, pub *signing.PublicKey, proof *types.MalfeasanceProof) {
+	logger.With().Warning("sender sent conflicting messages in the same round", log.Inline(proof))
+	// identity may be recorded as malicious without the proof, then the proof is still stored and gossiped
+	if _, err := identities.GetProof(proc.db, pub.Bytes()); err == nil {
+		return
+	} else if !errors.Is(err, sql.ErrNotFound) {
+		logger.With().Error("failed to check if sender is malicious", log.Err(err))
+		return
+	}
+	encoded, err := codec.Encode(proof)
+	if err != nil {
+		logger.With().Panic("failed to encode malfeasance proof", log.Err(err))
+	}
+	if err := proc.db.WithTx(ctx, func(tx *sql.Tx) error {
+		return identities.AddProof(tx, pub.Bytes(), encoded, time.Now())
+	}); err != nil {
+		logger.With().Error("failed to store malfeasance proof", log.Err(err))
+		return
+	}
+	events.ReportMalfeasance(types.BytesToNodeID(pub.Bytes()), proof)
+	if err := proc.publisher.Publish(ctx, pubsub.MalfeasanceProtocol, encoded); err != nil {
+		logger.With().Error("failed to broadcast malfeasance proof", log.Err(err))
+	}
+}
+
 // process the message by its type.
 func (proc *consensusProcess) processMsg(ctx context.Context, m *Msg) {
 	proc.WithContext(ctx).With().Debug("processing message",
 		log.String("msg_type", m.InnerMsg.Type.String()),
 		log.Int("num_values", len(m.InnerMsg.Values)))
+	proc.trace.onMessage(proc.getK(), m)
+	proc.journal.onMessage(proc.getK(), m)
 
 	switch m.InnerMsg.Type {
 	case pre:
@@ -500,6 +586,7 @@ func (proc *consensusProcess) onRoundEnd(ctx context.Context) {
 		log.Uint32("current_k", proc.getK()),
 		proc.instanceID)
 	logger.Debug("end of round")
+	proc.trace.onRoundEnd(proc.getK())
 
 	// reset trackers
 	switch proc.currentRound() {
@@ -507,6 +594,7 @@ func (proc *consensusProcess) onRoundEnd(ctx context.Context) {
 		proc.endOfStatusRound()
 	case proposalRound:
 		s := proc.proposalTracker.ProposedSet()
+		proc.trace.onSet(TraceProposed, proc.getK(), s)
 		sStr := "nil"
 		if s != nil {
 			sStr = s.String()
@@ -534,18 +622,9 @@ func (proc *consensusProcess) beginStatusRound(ctx context.Context) {
 	proc.statusesTracker = newStatusTracker(proc.cfg.F+1, proc.cfg.N)
 	proc.statusesTracker.Log = proc.Log
 
-	// check participation
-	if !proc.shouldParticipate(ctx) {
-		return
-	}
-
-	b, err := proc.initDefaultBuilder(proc.s)
-	if err != nil {
-		proc.With().Error("init default builder failed", log.Err(err))
-		return
-	}
-	statusMsg := b.SetType(status).Sign(proc.signing).Build()
-	proc.sendMessage(ctx, statusMsg)
+	proc.participate(ctx, proc.s, func(b *messageBuilder) *messageBuilder {
+		return b.SetType(status)
+	})
 }
 
 func (proc *consensusProcess) beginProposalRound(ctx context.Context) {
@@ -554,20 +633,17 @@ func (proc *consensusProcess) beginProposalRound(ctx context.Context) {
 	// done with building proposal, reset statuses tracking
 	defer func() { proc.statusesTracker = nil }()
 
-	if proc.statusesTracker.IsSVPReady() && proc.shouldParticipate(ctx) {
-		builder, err := proc.initDefaultBuilder(proc.statusesTracker.ProposalSet(defaultSetSize))
-		if err != nil {
-			proc.With().Error("init default builder failed", log.Err(err))
-			return
-		}
-		svp := proc.statusesTracker.BuildSVP()
-		if svp != nil {
-			proposalMsg := builder.SetType(proposal).SetSVP(svp).Sign(proc.signing).Build()
-			proc.sendMessage(ctx, proposalMsg)
-		} else {
-			proc.Error("failed to build SVP (nil) after verifying SVP is ready")
-		}
+	if !proc.statusesTracker.IsSVPReady() {
+		return
+	}
+	svp := proc.statusesTracker.BuildSVP()
+	if svp == nil {
+		proc.Error("failed to build SVP (nil) after verifying SVP is ready")
+		return
 	}
+	proc.participate(ctx, proc.statusesTracker.ProposalSet(defaultSetSize), func(b *messageBuilder) *messageBuilder {
+		return b.SetType(proposal).SetSVP(svp)
+	})
 }
 
 func (proc *consensusProcess) beginCommitRound(ctx context.Context) {
@@ -579,20 +655,9 @@ func (proc *consensusProcess) beginCommitRound(ctx context.Context) {
 	if proposedSet == nil {
 		return
 	}
-
-	// check participation
-	if !proc.shouldParticipate(ctx) {
-		return
-	}
-
-	builder, err := proc.initDefaultBuilder(proposedSet)
-	if err != nil {
-		proc.WithContext(ctx).With().Error("init default builder failed", log.Err(err))
-		return
-	}
-	builder = builder.SetType(commit).Sign(proc.signing)
-	commitMsg := builder.Build()
-	proc.sendMessage(ctx, commitMsg)
+	proc.participate(ctx, proposedSet, func(b *messageBuilder) *messageBuilder {
+		return b.SetType(commit)
+	})
 }
 
 func (proc *consensusProcess) beginNotifyRound(ctx context.Context) {
@@ -637,23 +702,12 @@ func (proc *consensusProcess) beginNotifyRound(ctx context.Context) {
 	// update set & matching certificate
 	proc.s = s
 	proc.certificate = cert
+	proc.trace.onSet(TraceCommitted, proc.getK(), s)
 
-	// check participation
-	if !proc.shouldParticipate(ctx) {
-		return
-	}
-
-	// build & send notify message
-	builder, err := proc.initDefaultBuilder(proc.s)
-	if err != nil {
-		logger.With().Error("init default builder failed", log.Err(err))
-		return
-	}
-
-	builder = builder.SetType(notify).SetCertificate(proc.certificate).Sign(proc.signing)
-	notifyMsg := builder.Build()
-	logger.With().Debug("sending notify message", notifyMsg)
-	if proc.sendMessage(ctx, notifyMsg) { // on success, mark sent
+	// build & send notify message, on success mark sent
+	if proc.participate(ctx, proc.s, func(b *messageBuilder) *messageBuilder {
+		return b.SetType(notify).SetCertificate(proc.certificate)
+	}) {
 		proc.notifySent = true
 	}
 }
@@ -693,18 +747,18 @@ func (proc *consensusProcess) onRoundBegin(ctx context.Context) {
 }
 
 // init a new message builder with the current state (s, k, ki) for this instance.
-func (proc *consensusProcess) initDefaultBuilder(s *Set) (*messageBuilder, error) {
+func (proc *consensusProcess) initDefaultBuilder(p participant, s *Set) (*messageBuilder, error) {
 	builder := newMessageBuilder().SetInstanceID(proc.instanceID)
 	builder = builder.SetRoundCounter(proc.getK()).SetKi(proc.ki).SetValues(s)
-	proof, err := proc.oracle.Proof(context.TODO(), proc.instanceID, proc.getK())
+	proof, err := p.oracle.Proof(context.TODO(), proc.instanceID, proc.getK())
 	if err != nil {
-		proc.With().Error("could not initialize default builder", log.Err(err))
+		proc.With().Error("could not initialize default builder", p.nid, log.Err(err))
 		return nil, fmt.Errorf("init default builder:: %w", err)
 	}
 	builder.SetRoleProof(proof)
 
 	proc.mu.RLock()
-	builder.SetEligibilityCount(proc.eligibilityCount)
+	builder.SetEligibilityCount(proc.eligibility[p.nid])
 	proc.mu.RUnlock()
 
 	return builder, nil
@@ -773,6 +827,7 @@ func (proc *consensusProcess) processNotifyMsg(ctx context.Context, msg *Msg) {
 		log.Uint32("current_k", proc.getK()),
 		proc.instanceID,
 		log.Int("set_size", proc.s.Size()))
+	proc.trace.onTerminated(proc.getK(), proc.s, completed, "enough notifications")
 	proc.report(completed)
 	numIterations.Observe(float64(proc.getK()))
 	proc.terminating = true
@@ -823,16 +878,53 @@ func (proc *consensusProcess) endOfStatusRound() {
 		log.String("analyze_duration", time.Since(before).String()))
 }
 
-// checks if we should participate in the current round
-// returns true if we should participate, false otherwise.
-func (proc *consensusProcess) shouldParticipate(ctx context.Context) bool {
+// participants returns the primary identity of the process followed by the additional identities.
+func (proc *consensusProcess) participants() []participant {
+	return append([]participant{{nid: proc.nid, signing: proc.signing, oracle: proc.oracle}}, proc.identities...)
+}
+
+// participate sends the message prepared by build on behalf of every identity of the node
+// that should participate in the current round.
+// Returns true if a message was sent for at least one identity.
+func (proc *consensusProcess) participate(ctx context.Context, s *Set, build func(*messageBuilder) *messageBuilder) bool {
+	if s == nil {
+		return false
+	}
+	logger := proc.WithContext(ctx).WithFields(log.Uint32("current_k", proc.getK()), proc.instanceID)
+	sent := false
+	for _, p := range proc.participants() {
+		if !proc.shouldParticipate(ctx, p) {
+			continue
+		}
+		builder, err := proc.initDefaultBuilder(p, s)
+		if err != nil {
+			logger.With().Error("init default builder failed", p.nid, log.Err(err))
+			continue
+		}
+		builder, err = build(builder).Sign(p.signing)
+		if err != nil {
+			logger.With().Error("failed to sign message", p.nid, log.Err(err))
+			continue
+		}
+		msg := builder.Build()
+		logger.With().Debug("sending message", p.nid, msg)
+		if proc.sendMessage(ctx, msg) {
+			sent = true
+		}
+	}
+	return sent
+}
+
+// checks if the identity should participate in the current round
+// returns true if it should participate, false otherwise.
+func (proc *consensusProcess) shouldParticipate(ctx context.Context, p participant) bool {
 	logger := proc.WithContext(ctx).WithFields(
 		log.Uint32("current_k", proc.getK()),
-		proc.instanceID)
+		proc.instanceID,
+		p.nid)
 
 	// query if identity is active
-	nid := types.BytesToNodeID(proc.signing.PublicKey().Bytes())
-	res, err := proc.oracle.IsIdentityActiveOnConsensusView(ctx, nid, proc.instanceID)
+	res, err := p.oracle.IsIdentityActiveOnConsensusView(ctx, p.nid, proc.instanceID)
 	if err != nil {
 		logger.With().Error("should not participate: error checking our identity for activeness", log.Err(err))
 		return false
@@ -843,14 +935,14 @@ func (proc *consensusProcess) shouldParticipate(ctx context.Context) bool {
 		return false
 	}
 
-	currentRole := proc.currentRole(ctx)
+	currentRole := proc.currentRole(ctx, p)
 	if currentRole == passive {
 		logger.Debug("should not participate: passive")
 		return false
 	}
 
 	proc.mu.RLock()
-	eligibilityCount := proc.eligibilityCount
+	eligibilityCount := proc.eligibility[p.nid]
 	proc.mu.RUnlock()
 
 	// should participate
@@ -861,10 +953,10 @@ func (proc *consensusProcess) shouldParticipate(ctx context.Context) bool {
 	return true
 }
 
-// Returns the role matching the current round if eligible for this round, false otherwise.
-func (proc *consensusProcess) currentRole(ctx context.Context) role {
-	logger := proc.WithContext(ctx).WithFields(proc.instanceID)
-	proof, err := proc.oracle.Proof(ctx, proc.instanceID, proc.getK())
+// Returns the role of the identity matching the current round if eligible for this round, false otherwise.
+func (proc *consensusProcess) currentRole(ctx context.Context, p participant) role {
+	logger := proc.WithContext(ctx).WithFields(proc.instanceID, p.nid)
+	proof, err := p.oracle.Proof(ctx, proc.instanceID, proc.getK())
 	if err != nil {
 		logger.With().Error("could not retrieve eligibility proof from oracle", log.Err(err))
 		return passive
@@ -872,15 +964,15 @@ func (proc *consensusProcess) currentRole(ctx context.Context) role {
 
 	k := proc.getK()
 
-	eligibilityCount, err := proc.oracle.CalcEligibility(ctx, proc.instanceID,
-		k, expectedCommitteeSize(k, proc.cfg.N, proc.cfg.ExpectedLeaders), proc.nid, proof)
+	eligibilityCount, err := p.oracle.CalcEligibility(ctx, proc.instanceID,
+		k, expectedCommitteeSize(k, proc.cfg.N, proc.cfg.ExpectedLeaders), p.nid, proof)
 	if err != nil {
 		logger.With().Error("failed to check eligibility", log.Err(err))
 		return passive
 	}
 
 	proc.mu.Lock()
-	proc.eligibilityCount = eligibilityCount
+	proc.eligibility[p.nid] = eligibilityCount
 	proc.mu.Unlock()
 
 	if eligibilityCount > 0 { // eligible
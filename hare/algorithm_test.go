@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/spacemeshos/go-spacemesh/codec"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/common/util"
 	"github.com/spacemeshos/go-spacemesh/eligibility"
@@ -20,6 +21,8 @@ import (
 	"github.com/spacemeshos/go-spacemesh/log"
 	"github.com/spacemeshos/go-spacemesh/log/logtest"
 	"github.com/spacemeshos/go-spacemesh/signing"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/identities"
 	smocks "github.com/spacemeshos/go-spacemesh/system/mocks"
 )
 
@@ -287,6 +290,60 @@ func TestConsensusProcess_handleMessage(t *testing.T) {
 	r.Equal(1, len(proc.pending))
 }
 
+func TestConsensusProcess_handleMessage_Equivocation(t *testing.T) {
+	broker := buildBroker(t, t.Name())
+	broker.mockSyncS.EXPECT().IsSynced(gomock.Any()).Return(true).AnyTimes()
+	require.NoError(t, broker.Start(context.TODO()))
+	proc := generateConsensusProcess(t)
+	net := &mockP2p{}
+	proc.publisher = net
+	proc.validator = &mockMessageValidator{syntaxValid: true}
+	proc.inbox, _ = broker.Register(context.TODO(), proc.ID())
+
+	signer := signing.NewEdSigner()
+	first := BuildPreRoundMsg(signer, NewSetFromValues(value1), []byte{1})
+	second := BuildPreRoundMsg(signer, NewSetFromValues(value2), []byte{1})
+	proc.handleMessage(context.TODO(), first)
+	require.Equal(t, 0, net.getCount())
+	proc.handleMessage(context.TODO(), second)
+	require.Equal(t, 1, net.getCount())
+	require.False(t, proc.preRoundTracker.preRound[signer.PublicKey().String()].Contains(value2))
+
+	encoded, err := identities.GetProof(proc.db, signer.PublicKey().Bytes())
+	require.NoError(t, err)
+	var proof types.MalfeasanceProof
+	require.NoError(t, codec.Decode(encoded, &proof))
+	require.Equal(t, types.HareEquivocation, proof.MalfeasanceType())
+	require.Equal(t, first.InnerMsg.Bytes(), proof.Messages[0].Data)
+	require.Equal(t, second.InnerMsg.Bytes(), proof.Messages[1].Data)
+
+	// sender is excluded for the rest of the instance
+	proc.handleMessage(context.TODO(), BuildPreRoundMsg(signer, NewSetFromValues(value3), []byte{1}))
+	require.Equal(t, 1, net.getCount())
+	require.False(t, proc.preRoundTracker.preRound[signer.PublicKey().String()].Contains(value3))
+}
+
+func TestConsensusProcess_handleMessage_EquivocationMaliciousWithoutProof(t *testing.T) {
+	broker := buildBroker(t, t.Name())
+	broker.mockSyncS.EXPECT().IsSynced(gomock.Any()).Return(true).AnyTimes()
+	require.NoError(t, broker.Start(context.TODO()))
+	proc := generateConsensusProcess(t)
+	net := &mockP2p{}
+	proc.publisher = net
+	proc.validator = &mockMessageValidator{syntaxValid: true}
+	proc.inbox, _ = broker.Register(context.TODO(), proc.ID())
+
+	// identity was recorded as malicious before proofs were stored
+	signer := signing.NewEdSigner()
+	require.NoError(t, identities.SetMalicious(proc.db, signer.PublicKey().Bytes()))
+
+	proc.handleMessage(context.TODO(), BuildPreRoundMsg(signer, NewSetFromValues(value1), []byte{1}))
+	proc.handleMessage(context.TODO(), BuildPreRoundMsg(signer, NewSetFromValues(value2), []byte{1}))
+	require.Equal(t, 1, net.getCount())
+	_, err := identities.GetProof(proc.db, signer.PublicKey().Bytes())
+	require.NoError(t, err)
+}
+
 func TestConsensusProcess_nextRound(t *testing.T) {
 	broker := buildBroker(t, t.Name())
 	broker.mockSyncS.EXPECT().IsSynced(gomock.Any()).Return(true).AnyTimes()
@@ -319,7 +376,7 @@ func generateConsensusProcessWithConfig(tb testing.TB, cfg config.Config) *conse
 	sq := mocks.NewMockstateQuerier(ctrl)
 	sq.EXPECT().IsIdentityActiveOnConsensusView(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
 	return newConsensusProcess(cfg, instanceID1, s, oracle, sq,
-		4, edSigner, types.BytesToNodeID(edPubkey.Bytes()),
+		4, edSigner, sql.InMemory(), types.BytesToNodeID(edPubkey.Bytes()),
 		noopPubSub(tb), output, truer{}, newRoundClockFromCfg(logger, cfg),
 		logtest.New(tb).WithName(edPubkey.String()))
 }
@@ -349,7 +406,7 @@ func TestConsensusProcess_InitDefaultBuilder(t *testing.T) {
 	proc := generateConsensusProcess(t)
 	s := NewEmptySet(defaultSetSize)
 	s.Add(value1)
-	builder, err := proc.initDefaultBuilder(s)
+	builder, err := proc.initDefaultBuilder(proc.participants()[0], s)
 	assert.Nil(t, err)
 	assert.True(t, NewSet(builder.inner.Values).Equals(s))
 	verifier := builder.msg.PubKey
@@ -370,7 +427,7 @@ func TestConsensusProcess_isEligible_NotEligible(t *testing.T) {
 	mo.EXPECT().Proof(gomock.Any(), proc.instanceID, proc.getK()).Return(nil, nil).Times(1)
 	mo.EXPECT().IsIdentityActiveOnConsensusView(gomock.Any(), gomock.Any(), proc.instanceID).Return(true, nil).Times(1)
 	mo.EXPECT().CalcEligibility(gomock.Any(), proc.instanceID, proc.getK(), gomock.Any(), proc.nid, gomock.Any()).Return(uint16(0), nil).Times(1)
-	assert.False(t, proc.shouldParticipate(context.TODO()))
+	assert.False(t, proc.shouldParticipate(context.TODO(), proc.participants()[0]))
 }
 
 func TestConsensusProcess_isEligible_Eligible(t *testing.T) {
@@ -384,7 +441,7 @@ func TestConsensusProcess_isEligible_Eligible(t *testing.T) {
 	mo.EXPECT().Proof(gomock.Any(), proc.instanceID, proc.getK()).Return(nil, nil).Times(1)
 	mo.EXPECT().IsIdentityActiveOnConsensusView(gomock.Any(), gomock.Any(), proc.instanceID).Return(true, nil).Times(1)
 	mo.EXPECT().CalcEligibility(gomock.Any(), proc.instanceID, proc.getK(), gomock.Any(), proc.nid, gomock.Any()).Return(uint16(1), nil).Times(1)
-	assert.True(t, proc.shouldParticipate(context.TODO()))
+	assert.True(t, proc.shouldParticipate(context.TODO(), proc.participants()[0]))
 }
 
 func TestConsensusProcess_isEligible_ActiveSetFailed(t *testing.T) {
@@ -396,7 +453,7 @@ func TestConsensusProcess_isEligible_ActiveSetFailed(t *testing.T) {
 	proc.oracle = mo
 
 	mo.EXPECT().IsIdentityActiveOnConsensusView(gomock.Any(), gomock.Any(), proc.instanceID).Return(false, errors.New("some err")).Times(1)
-	assert.False(t, proc.shouldParticipate(context.TODO()))
+	assert.False(t, proc.shouldParticipate(context.TODO(), proc.participants()[0]))
 }
 
 func TestConsensusProcess_isEligible_NotActive(t *testing.T) {
@@ -408,7 +465,7 @@ func TestConsensusProcess_isEligible_NotActive(t *testing.T) {
 	proc.oracle = mo
 
 	mo.EXPECT().IsIdentityActiveOnConsensusView(gomock.Any(), gomock.Any(), proc.instanceID).Return(false, nil).Times(1)
-	assert.False(t, proc.shouldParticipate(context.TODO()))
+	assert.False(t, proc.shouldParticipate(context.TODO(), proc.participants()[0]))
 }
 
 func TestConsensusProcess_sendMessage(t *testing.T) {
@@ -591,6 +648,48 @@ func TestConsensusProcess_beginStatusRound(t *testing.T) {
 	assert.NotEqual(t, preStatusTracker, proc.statusesTracker)
 }
 
+func TestConsensusProcess_beginStatusRound_Identities(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	proc := generateConsensusProcess(t)
+	proc.advanceToNextRound(context.TODO())
+	network := &mockP2p{}
+	proc.publisher = network
+
+	mo := mocks.NewMockRolacle(ctrl)
+	mo.EXPECT().IsIdentityActiveOnConsensusView(gomock.Any(), proc.nid, proc.instanceID).Return(true, nil).Times(1)
+	mo.EXPECT().Proof(gomock.Any(), proc.instanceID, proc.getK()).Return(nil, nil).Times(1)
+	mo.EXPECT().CalcEligibility(gomock.Any(), proc.instanceID, proc.getK(), gomock.Any(), proc.nid, gomock.Any()).Return(uint16(0), nil).Times(1)
+	proc.oracle = mo
+
+	eligible := signing.NewEdSigner()
+	notEligible := signing.NewEdSigner()
+	inactive := signing.NewEdSigner()
+	for _, signer := range []*signing.EdSigner{eligible, notEligible, inactive} {
+		nid := types.BytesToNodeID(signer.PublicKey().Bytes())
+		io := mocks.NewMockRolacle(ctrl)
+		io.EXPECT().IsIdentityActiveOnConsensusView(gomock.Any(), nid, proc.instanceID).Return(signer != inactive, nil).Times(1)
+		if signer != inactive {
+			count := uint16(0)
+			if signer == eligible {
+				count = 2
+			}
+			proofs := 1
+			if signer == eligible {
+				// proof is computed again for the message
+				proofs = 2
+			}
+			io.EXPECT().Proof(gomock.Any(), proc.instanceID, proc.getK()).Return(nid[:], nil).Times(proofs)
+			io.EXPECT().CalcEligibility(gomock.Any(), proc.instanceID, proc.getK(), gomock.Any(), nid, nid[:]).Return(count, nil).Times(1)
+		}
+		proc.identities = append(proc.identities, participant{nid: nid, signing: signer, oracle: io})
+	}
+
+	proc.beginStatusRound(context.TODO())
+	require.Equal(t, 1, network.getCount())
+}
+
 func TestConsensusProcess_beginProposalRound(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
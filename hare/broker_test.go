@@ -45,7 +45,9 @@ func createMessage(tb testing.TB, instanceID types.LayerID) []byte {
 
 	sr := signing.NewEdSigner()
 	b := newMessageBuilder()
-	msg := b.SetPubKey(sr.PublicKey()).SetInstanceID(instanceID).Sign(sr).Build()
+	b, err := b.SetPubKey(sr.PublicKey()).SetInstanceID(instanceID).Sign(sr)
+	require.NoError(tb, err)
+	msg := b.Build()
 	return mustEncode(tb, msg.Message)
 }
 
@@ -109,7 +111,9 @@ func TestBroker_Priority(t *testing.T) {
 	createMessageWithRoleProof := func(roleProof []byte) []byte {
 		sr := signing.NewEdSigner()
 		b := newMessageBuilder()
-		msg := b.SetPubKey(sr.PublicKey()).SetInstanceID(instanceID1).SetRoleProof(roleProof).Sign(sr).Build()
+		b, err := b.SetPubKey(sr.PublicKey()).SetInstanceID(instanceID1).SetRoleProof(roleProof).Sign(sr)
+		require.NoError(t, err)
+		msg := b.Build()
 		return mustEncode(t, msg.Message)
 	}
 	roleProofInbound := []byte{1, 2, 3}
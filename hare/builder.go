@@ -112,10 +112,18 @@ func (builder *messageBuilder) SetCertificate(certificate *Certificate) *message
 }
 
 // Sign calls the provided signer to calculate the signature and then set it accordingly.
-func (builder *messageBuilder) Sign(signing Signer) *messageBuilder {
-	builder.msg.Sig = signing.Sign(builder.inner.Bytes())
-
-	return builder
+func (builder *messageBuilder) Sign(signer Signer) (*messageBuilder, error) {
+	sig, err := signer.SignRequest(signing.Request{
+		Domain:  signing.HARE,
+		Layer:   builder.inner.InstanceID.Value,
+		Round:   builder.inner.K,
+		Message: builder.inner.Bytes(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sign hare message: %w", err)
+	}
+	builder.msg.Sig = sig
+	return builder, nil
 }
 
 // SetPubKey sets the public key of the message.
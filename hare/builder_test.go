@@ -10,6 +10,15 @@ import (
 	"github.com/spacemeshos/go-spacemesh/signing"
 )
 
+// mustSign signs the message of the builder and panics if signing fails.
+func mustSign(builder *messageBuilder, signer Signer) *messageBuilder {
+	builder, err := builder.Sign(signer)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
 func marshallUnmarshall(t *testing.T, msg *Message) *Message {
 	buf, err := codec.Encode(msg)
 	require.NoError(t, err)
@@ -22,7 +31,9 @@ func marshallUnmarshall(t *testing.T, msg *Message) *Message {
 func TestBuilder_TestBuild(t *testing.T) {
 	b := newMessageBuilder()
 	sgn := signing.NewEdSigner()
-	msg := b.SetPubKey(sgn.PublicKey()).SetInstanceID(instanceID1).Sign(sgn).Build()
+	b, err := b.SetPubKey(sgn.PublicKey()).SetInstanceID(instanceID1).Sign(sgn)
+	require.NoError(t, err)
+	msg := b.Build()
 
 	m := marshallUnmarshall(t, &msg.Message)
 	assert.Equal(t, m, &msg.Message)
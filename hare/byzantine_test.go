@@ -0,0 +1,110 @@
+package hare
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/hare/config"
+	"github.com/spacemeshos/go-spacemesh/sql/identities"
+)
+
+func TestHare_Byzantine(t *testing.T) {
+	const (
+		parties       = 7
+		roundDuration = 300 * time.Millisecond
+	)
+	cfg := config.Config{N: parties, F: parties / 2, ExpectedLeaders: 3, LimitIterations: 10, LimitConcurrent: 10}
+	seeds := 3
+	if testing.Short() {
+		seeds = 1
+	}
+	outsider := NewSetFromValues(value9, value10)
+	for _, tc := range []struct {
+		desc      string
+		byzantine []strategy
+		layers    int
+		malicious bool // byzantine parties are expected to be proven malicious
+	}{
+		{desc: "honest", layers: 1},
+		{
+			desc:      "equivocate",
+			byzantine: []strategy{equivocate(), equivocate()},
+			layers:    1,
+			malicious: true,
+		},
+		{desc: "invalid proof", byzantine: []strategy{invalidProof(), invalidProof()}, layers: 1},
+		{
+			desc:      "delay",
+			byzantine: []strategy{delay(2 * roundDuration), delay(2 * roundDuration)},
+			layers:    1,
+		},
+		{desc: "minority", byzantine: []strategy{minority(outsider), minority(outsider)}, layers: 1},
+		{desc: "replay old", byzantine: []strategy{replayOld(), replayOld()}, layers: 3},
+		{desc: "mixed", byzantine: []strategy{equivocate(), minority(outsider)}, layers: 2},
+	} {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			for seed := int64(1); seed <= int64(seeds); seed++ {
+				seed := seed
+				t.Run(fmt.Sprintf("seed=%d", seed), func(t *testing.T) {
+					rng := rand.New(rand.NewSource(seed))
+					strategies := make([]strategy, parties-len(tc.byzantine), parties)
+					strategies = append(strategies, tc.byzantine...)
+					rng.Shuffle(len(strategies), func(i, j int) {
+						strategies[i], strategies[j] = strategies[j], strategies[i]
+					})
+					h := newHareHarness(t, seed, cfg, roundDuration, strategies)
+					for i := 0; i < tc.layers; i++ {
+						inputs := randomInputs(rng, parties)
+						outputs := h.run(instanceID1.Add(uint32(i)), inputs)
+						requireHareProperties(t, h, inputs, outputs)
+					}
+					if tc.malicious {
+						requireMalicious(t, h)
+					}
+				})
+			}
+		})
+	}
+}
+
+// randomInputs generates input sets that share a common value, and may include other values.
+func randomInputs(rng *rand.Rand, n int) []*Set {
+	optional := []*Set{
+		NewSetFromValues(value2), NewSetFromValues(value3),
+		NewSetFromValues(value4), NewSetFromValues(value5),
+	}
+	inputs := make([]*Set, n)
+	for i := range inputs {
+		s := NewSetFromValues(value1)
+		for _, o := range optional {
+			if rng.Intn(4) > 0 {
+				s = s.Union(o)
+			}
+		}
+		inputs[i] = s
+	}
+	return inputs
+}
+
+// requireMalicious checks that every honest party stored proofs of malfeasance for every byzantine party.
+func requireMalicious(tb testing.TB, h *hareHarness) {
+	tb.Helper()
+	for i, p := range h.parties {
+		if !p.honest() {
+			continue
+		}
+		for j, byz := range h.parties {
+			if byz.honest() {
+				continue
+			}
+			_, err := identities.GetProof(p.db, byz.nodeID.ToBytes())
+			require.NoError(tb, err, "party %d doesn't have proof for %d", i, j)
+		}
+	}
+}
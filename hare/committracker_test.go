@@ -12,7 +12,7 @@ func BuildCommitMsg(signing Signer, s *Set) *Msg {
 	builder := newMessageBuilder()
 	builder.SetType(commit).SetInstanceID(instanceID1).SetRoundCounter(commitRound).SetKi(ki).SetValues(s)
 	builder.SetEligibilityCount(1)
-	builder = builder.SetPubKey(signing.PublicKey()).Sign(signing)
+	builder = mustSign(builder.SetPubKey(signing.PublicKey()), signing)
 
 	return builder.Build()
 }
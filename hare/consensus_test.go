@@ -19,6 +19,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/p2p"
 	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
 	signing2 "github.com/spacemeshos/go-spacemesh/signing"
+	"github.com/spacemeshos/go-spacemesh/sql"
 )
 
 // Test the consensus process as a whole
@@ -158,7 +159,7 @@ func createConsensusProcess(tb testing.TB, isHonest bool, cfg config.Config, ora
 	nid := types.BytesToNodeID(signing.PublicKey().Bytes())
 	oracle.Register(isHonest, nid)
 	proc := newConsensusProcess(cfg, layer, initialSet, oracle, broker.mockStateQ, 10, signing,
-		nid, network, output, truer{},
+		sql.InMemory(), nid, network, output, truer{},
 		newRoundClockFromCfg(logtest.New(tb), cfg), logtest.New(tb).WithName(signing.PublicKey().ShortString()))
 	c, _ := broker.Register(context.TODO(), proc.ID())
 	proc.SetInbox(c)
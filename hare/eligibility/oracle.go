@@ -67,7 +67,7 @@ type Oracle struct {
 	lock           sync.Mutex
 	beacons        system.BeaconGetter
 	cdb            *datastore.CachedDB
-	vrfSigner      *signing.VRFSigner
+	vrfSigner      signing.RequestSigner
 	vrfVerifier    verifierFunc
 	layersPerEpoch uint32
 	vrfMsgCache    cache
@@ -118,7 +118,7 @@ func New(
 	beacons system.BeaconGetter,
 	db *datastore.CachedDB,
 	vrfVerifier verifierFunc,
-	vrfSigner *signing.VRFSigner,
+	vrfSigner signing.RequestSigner,
 	layersPerEpoch uint32,
 	cfg config.Config,
 	logger log.Log,
@@ -409,7 +409,39 @@ func (o *Oracle) Proof(ctx context.Context, layer types.LayerID, round uint32) (
 		return nil, err
 	}
 
-	return o.vrfSigner.Sign(msg), nil
+	return o.vrfSigner.SignRequest(signing.Request{
+		Domain:  signing.HARE_ELIGIBILITY,
+		Layer:   layer.Value,
+		Round:   round,
+		Message: msg,
+	})
+}
+
+// IdentityOracle is an Oracle that computes eligibility proofs for an additional identity of the node.
+type IdentityOracle struct {
+	*Oracle
+	vrfSigner signing.RequestSigner
+}
+
+// ForIdentity returns an oracle that shares state with o, but proves eligibility with the provided vrf signer.
+func (o *Oracle) ForIdentity(vrfSigner signing.RequestSigner) *IdentityOracle {
+	return &IdentityOracle{Oracle: o, vrfSigner: vrfSigner}
+}
+
+// Proof returns the role proof of the identity for the current Layer & Round.
+func (o *IdentityOracle) Proof(ctx context.Context, layer types.LayerID, round uint32) ([]byte, error) {
+	msg, err := o.buildVRFMessage(ctx, layer, round)
+	if err != nil {
+		o.WithContext(ctx).With().Error("proof: could not build vrf message", log.Err(err))
+		return nil, err
+	}
+
+	return o.vrfSigner.SignRequest(signing.Request{
+		Domain:  signing.HARE_ELIGIBILITY,
+		Layer:   layer.Value,
+		Round:   round,
+		Message: msg,
+	})
 }
 
 // Returns a map of all active node IDs in the specified layer id.
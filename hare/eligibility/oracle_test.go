@@ -432,6 +432,22 @@ func Test_Proof(t *testing.T) {
 	require.NotNil(t, sig)
 }
 
+func Test_Proof_ForIdentity(t *testing.T) {
+	o := defaultOracle(t)
+	layer := types.NewLayerID(2)
+	o.mBeacon.EXPECT().GetBeacon(layer.GetEpoch()).Return(beaconWithValOne(), nil).Times(1)
+
+	o.vrfSigner = signing.NewEdSigner().VRFSigner()
+	primary, err := o.Proof(context.TODO(), layer, 3)
+	require.NoError(t, err)
+
+	identity := o.ForIdentity(signing.NewEdSigner().VRFSigner())
+	sig, err := identity.Proof(context.TODO(), layer, 3)
+	require.NoError(t, err)
+	require.NotNil(t, sig)
+	require.NotEqual(t, primary, sig)
+}
+
 func TestOracle_IsIdentityActive(t *testing.T) {
 	o := defaultOracle(t)
 	layer := types.NewLayerID(40)
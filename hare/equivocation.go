@@ -0,0 +1,59 @@
+package hare
+
+import (
+	"bytes"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+)
+
+type equivocationKey struct {
+	pub  string
+	k    uint32
+	kind MessageType
+}
+
+// equivocationTracker keeps the first message from every sender per round and detects
+// senders that sent conflicting messages in the same round.
+type equivocationTracker struct {
+	first     map[equivocationKey]*Msg
+	malicious map[string]*types.MalfeasanceProof
+}
+
+func newEquivocationTracker(expectedSize int) *equivocationTracker {
+	return &equivocationTracker{
+		first:     make(map[equivocationKey]*Msg, expectedSize),
+		malicious: make(map[string]*types.MalfeasanceProof),
+	}
+}
+
+// Track records the message and returns a proof if the sender already sent a different
+// message for the same round. The proof is returned only once per sender.
+func (et *equivocationTracker) Track(m *Msg) *types.MalfeasanceProof {
+	pub := m.PubKey.String()
+	if _, exist := et.malicious[pub]; exist {
+		return nil
+	}
+	key := equivocationKey{pub: pub, k: m.InnerMsg.K, kind: m.InnerMsg.Type}
+	prev, exist := et.first[key]
+	if !exist {
+		et.first[key] = m
+		return nil
+	}
+	prevBytes := prev.InnerMsg.Bytes()
+	currBytes := m.InnerMsg.Bytes()
+	if bytes.Equal(prevBytes, currBytes) {
+		return nil
+	}
+	proof := types.NewMalfeasanceProof(types.HareEquivocation,
+		types.NewSignedMessage(prevBytes, prev.Sig),
+		types.NewSignedMessage(currBytes, m.Sig),
+	)
+	et.malicious[pub] = proof
+	return proof
+}
+
+// IsMalicious returns true if the sender was caught equivocating in this instance.
+func (et *equivocationTracker) IsMalicious(pub string) bool {
+	_, exist := et.malicious[pub]
+	return exist
+}
@@ -0,0 +1,54 @@
+package hare
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/signing"
+)
+
+func TestEquivocationTracker_Track(t *testing.T) {
+	et := newEquivocationTracker(lowDefaultSize)
+	signer := signing.NewEdSigner()
+	first := BuildPreRoundMsg(signer, NewSetFromValues(value1), []byte{1})
+	second := BuildPreRoundMsg(signer, NewSetFromValues(value2), []byte{1})
+
+	require.Nil(t, et.Track(first))
+	require.Nil(t, et.Track(first), "same message is not an equivocation")
+	require.False(t, et.IsMalicious(signer.PublicKey().String()))
+
+	other := BuildPreRoundMsg(signing.NewEdSigner(), NewSetFromValues(value2), []byte{1})
+	require.Nil(t, et.Track(other), "different sender is not an equivocation")
+
+	proof := et.Track(second)
+	require.NotNil(t, proof)
+	require.Equal(t, types.HareEquivocation, proof.MalfeasanceType())
+	require.Len(t, proof.Messages, 2)
+	require.Equal(t, first.InnerMsg.Bytes(), proof.Messages[0].Data)
+	require.Equal(t, first.Sig, proof.Messages[0].Signature)
+	require.Equal(t, second.InnerMsg.Bytes(), proof.Messages[1].Data)
+	require.Equal(t, second.Sig, proof.Messages[1].Signature)
+	for _, msg := range proof.Messages {
+		var inner InnerMessage
+		require.NoError(t, codec.Decode(msg.Data, &inner))
+		pub, err := signing.ExtractPublicKey(msg.Data, msg.Signature)
+		require.NoError(t, err)
+		require.Equal(t, signer.PublicKey().Bytes(), []byte(pub))
+	}
+	require.True(t, et.IsMalicious(signer.PublicKey().String()))
+	require.False(t, et.IsMalicious(other.PubKey.String()))
+
+	require.Nil(t, et.Track(BuildPreRoundMsg(signer, NewSetFromValues(value3), []byte{1})),
+		"proof is returned only once")
+}
+
+func TestEquivocationTracker_DifferentRounds(t *testing.T) {
+	et := newEquivocationTracker(lowDefaultSize)
+	signer := signing.NewEdSigner()
+	require.Nil(t, et.Track(BuildPreRoundMsg(signer, NewSetFromValues(value1), []byte{1})))
+	require.Nil(t, et.Track(BuildStatusMsg(signer, NewSetFromValues(value2))))
+	require.False(t, et.IsMalicious(signer.PublicKey().String()))
+}
@@ -8,6 +8,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/spacemeshos/go-spacemesh/codec"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/common/util"
 	"github.com/spacemeshos/go-spacemesh/hare/config"
@@ -16,6 +17,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
 	"github.com/spacemeshos/go-spacemesh/sql"
 	"github.com/spacemeshos/go-spacemesh/sql/ballots"
+	"github.com/spacemeshos/go-spacemesh/sql/hareinstances"
 	"github.com/spacemeshos/go-spacemesh/sql/layers"
 	"github.com/spacemeshos/go-spacemesh/sql/proposals"
 	"github.com/spacemeshos/go-spacemesh/system"
@@ -94,6 +96,8 @@ type Hare struct {
 	factory consensusFactory
 
 	nid types.NodeID
+	// identities are additional identities of the node that participate in every consensus process.
+	identities []participant
 
 	totalCPs int32
 	wg       sync.WaitGroup
@@ -152,7 +156,9 @@ func New(
 	h.outputChan = make(chan TerminationOutput, h.bufferSize)
 	h.outputs = make(map[types.LayerID][]types.ProposalID, h.bufferSize) // we keep results about LayerBuffer past layers
 	h.factory = func(conf config.Config, instanceId types.LayerID, s *Set, oracle Rolacle, signing Signer, p2p pubsub.Publisher, clock RoundClock, terminationReport chan TerminationOutput) Consensus {
-		return newConsensusProcess(conf, instanceId, s, oracle, stateQ, layersPerEpoch, signing, nid, p2p, terminationReport, ev, clock, logger)
+		proc := newConsensusProcess(conf, instanceId, s, oracle, stateQ, layersPerEpoch, signing, db, nid, p2p, terminationReport, ev, clock, logger)
+		proc.identities = h.identities
+		return proc
 	}
 
 	h.nid = nid
@@ -160,6 +166,17 @@ func New(
 	return h
 }
 
+// AddIdentity adds an identity that participates in consensus together with the primary identity.
+// oracle must compute eligibility proofs with the vrf key of the identity.
+// Must be called before Start.
+func (h *Hare) AddIdentity(sign Signer, oracle Rolacle) {
+	h.identities = append(h.identities, participant{
+		nid:     types.BytesToNodeID(sign.PublicKey().Bytes()),
+		signing: sign,
+		oracle:  oracle,
+	})
+}
+
 // GetHareMsgHandler returns the gossip handler for hare protocol message.
 func (h *Hare) GetHareMsgHandler() pubsub.GossipHandler {
 	return h.broker.HandleMessage
@@ -225,19 +242,18 @@ func (h *Hare) collectOutput(ctx context.Context, output TerminationOutput) erro
 		postNumProposals.Add(float64(set.len()))
 		pids = make([]types.ProposalID, 0, set.len())
 		pids = append(pids, set.elements()...)
-		select {
-		case h.blockGenCh <- LayerOutput{
-			Ctx:       ctx,
-			Layer:     layerID,
-			Proposals: pids,
-		}:
-		case <-ctx.Done():
-		}
 	} else {
 		consensusFailCnt.Inc()
 		h.WithContext(ctx).With().Warning("hare terminated with failure", layerID)
 	}
 
+	h.persistOutput(ctx, output, pids)
+	if output.Completed() {
+		h.deliver(ctx, layerID, pids)
+	} else if err := hareinstances.SetDelivered(h.db, layerID); err != nil {
+		h.WithContext(ctx).With().Error("failed to persist hare output position", layerID, log.Err(err))
+	}
+
 	if h.outOfBufferRange(layerID) {
 		return ErrTooLate
 	}
@@ -251,6 +267,37 @@ func (h *Hare) collectOutput(ctx context.Context, output TerminationOutput) erro
 	return nil
 }
 
+// persistOutput saves the output, so that it is not lost if the node restarts before it is consumed.
+func (h *Hare) persistOutput(ctx context.Context, output TerminationOutput, pids []types.ProposalID) {
+	buf, err := codec.Encode(&InstanceOutput{
+		Completed: output.Completed(),
+		Coinflip:  output.Coinflip(),
+		Set:       pids,
+	})
+	if err != nil {
+		h.WithContext(ctx).With().Panic("failed to encode hare output", log.Err(err))
+	}
+	if err := hareinstances.SetOutput(h.db, output.ID(), buf); err != nil {
+		h.WithContext(ctx).With().Error("failed to persist hare output", output.ID(), log.Err(err))
+	}
+}
+
+// deliver sends the output to the block generator and records that it was consumed.
+func (h *Hare) deliver(ctx context.Context, lid types.LayerID, pids []types.ProposalID) {
+	select {
+	case h.blockGenCh <- LayerOutput{
+		Ctx:       ctx,
+		Layer:     lid,
+		Proposals: pids,
+	}:
+	case <-ctx.Done():
+		return
+	}
+	if err := hareinstances.SetDelivered(h.db, lid); err != nil {
+		h.WithContext(ctx).With().Error("failed to persist hare output position", lid, log.Err(err))
+	}
+}
+
 // the logic that happens when a new layer arrives.
 // this function triggers the start of new consensus processes.
 func (h *Hare) onTick(ctx context.Context, id types.LayerID) (bool, error) {
@@ -422,6 +469,11 @@ func (h *Hare) outputCollectionLoop(ctx context.Context) {
 				logger.With().Warning("error collecting output from hare", log.Err(err))
 			}
 			h.broker.Unregister(ctx, out.ID())
+			if layerID.After(types.NewLayerID(h.bufferSize)) {
+				if err := hareinstances.PruneBefore(h.db, layerID.Sub(h.bufferSize)); err != nil {
+					logger.With().Error("failed to prune hare instances", log.Err(err))
+				}
+			}
 			logger.With().Debug("number of consensus processes (after unregister)",
 				log.Int32("count", atomic.AddInt32(&h.totalCPs, -1)))
 		case <-h.CloseChannel():
@@ -468,6 +520,10 @@ func (h *Hare) Start(ctx context.Context) error {
 		return fmt.Errorf("start broker: %w", err)
 	}
 
+	if err := h.recover(ctxOutputLoop); err != nil {
+		return fmt.Errorf("recover hare instances: %w", err)
+	}
+
 	h.wg.Add(2)
 	go h.tickLoop(ctxTickLoop)
 	go h.outputCollectionLoop(ctxOutputLoop)
@@ -0,0 +1,419 @@
+package hare
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/common/util"
+	"github.com/spacemeshos/go-spacemesh/hare/config"
+	"github.com/spacemeshos/go-spacemesh/hash"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/p2p"
+	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
+	"github.com/spacemeshos/go-spacemesh/signing"
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+// memNetwork is a fully connected in-memory gossip network. Every published message is delivered
+// to every registered handler, including handlers of the publisher, in a separate goroutine.
+type memNetwork struct {
+	ctx   context.Context
+	mu    sync.RWMutex
+	peers []*memPubSub
+}
+
+func newMemNetwork(ctx context.Context) *memNetwork {
+	return &memNetwork{ctx: ctx}
+}
+
+func (n *memNetwork) join(id p2p.Peer) *memPubSub {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	ps := &memPubSub{net: n, id: id, handlers: map[string][]pubsub.GossipHandler{}}
+	n.peers = append(n.peers, ps)
+	return ps
+}
+
+type memPubSub struct {
+	net *memNetwork
+	id  p2p.Peer
+
+	mu       sync.RWMutex
+	handlers map[string][]pubsub.GossipHandler
+}
+
+func (ps *memPubSub) Register(protocol string, handler pubsub.GossipHandler) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	ps.handlers[protocol] = append(ps.handlers[protocol], handler)
+}
+
+func (ps *memPubSub) Publish(_ context.Context, protocol string, msg []byte) error {
+	ps.net.mu.RLock()
+	defer ps.net.mu.RUnlock()
+	for _, peer := range ps.net.peers {
+		peer.mu.RLock()
+		for _, handler := range peer.handlers[protocol] {
+			go handler(ps.net.ctx, ps.id, msg)
+		}
+		peer.mu.RUnlock()
+	}
+	return nil
+}
+
+// harnessOracle makes eligibility a deterministic function of the seed. Role proof is the hash that is used
+// to rank participants, so the proof can't be claimed by another identity and can be validated by anyone.
+type harnessOracle struct {
+	seed int64
+
+	mu    sync.Mutex
+	ids   []types.NodeID
+	cache map[string]map[types.NodeID]struct{}
+}
+
+func newHarnessOracle(seed int64) *harnessOracle {
+	return &harnessOracle{seed: seed, cache: map[string]map[types.NodeID]struct{}{}}
+}
+
+func (o *harnessOracle) register(id types.NodeID) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.ids = append(o.ids, id)
+}
+
+func (o *harnessOracle) proof(layer types.LayerID, round uint32, id types.NodeID) []byte {
+	buf := make([]byte, 16)
+	binary.LittleEndian.PutUint64(buf, uint64(o.seed))
+	binary.LittleEndian.PutUint32(buf[8:], layer.Uint32())
+	binary.LittleEndian.PutUint32(buf[12:], round)
+	sum := hash.Sum(buf, id.ToBytes())
+	return sum[:]
+}
+
+func (o *harnessOracle) eligible(layer types.LayerID, round uint32, size int, id types.NodeID) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	key := fmt.Sprintf("%d/%d/%d", layer.Uint32(), round, size)
+	committee, exist := o.cache[key]
+	if !exist {
+		ids := append([]types.NodeID{}, o.ids...)
+		sort.Slice(ids, func(i, j int) bool {
+			return bytes.Compare(o.proof(layer, round, ids[i]), o.proof(layer, round, ids[j])) < 0
+		})
+		if size < len(ids) {
+			ids = ids[:size]
+		}
+		committee = make(map[types.NodeID]struct{}, len(ids))
+		for _, id := range ids {
+			committee[id] = struct{}{}
+		}
+		o.cache[key] = committee
+	}
+	_, exist = committee[id]
+	return exist
+}
+
+func (o *harnessOracle) Validate(_ context.Context, layer types.LayerID, round uint32, size int, id types.NodeID, proof []byte, count uint16) (bool, error) {
+	if !bytes.Equal(proof, o.proof(layer, round, id)) {
+		return false, nil
+	}
+	return count == 1 && o.eligible(layer, round, size, id), nil
+}
+
+func (o *harnessOracle) CalcEligibility(_ context.Context, layer types.LayerID, round uint32, size int, id types.NodeID, proof []byte) (uint16, error) {
+	if !bytes.Equal(proof, o.proof(layer, round, id)) || !o.eligible(layer, round, size, id) {
+		return 0, nil
+	}
+	return 1, nil
+}
+
+func (o *harnessOracle) IsIdentityActiveOnConsensusView(context.Context, types.NodeID, types.LayerID) (bool, error) {
+	return true, nil
+}
+
+// partyOracle binds the harness oracle to the identity of the party, so that it can generate proofs.
+type partyOracle struct {
+	*harnessOracle
+	id types.NodeID
+}
+
+func (o partyOracle) Proof(_ context.Context, layer types.LayerID, round uint32) ([]byte, error) {
+	return o.proof(layer, round, o.id), nil
+}
+
+type alwaysSynced struct{}
+
+func (alwaysSynced) IsSynced(context.Context) bool {
+	return true
+}
+
+// strategy describes behaviour of the byzantine party. Zero value is an honest party.
+type strategy struct {
+	name string
+	// delay is the maximal random delay before every outgoing hare message is published.
+	delay time.Duration
+	// transform replaces outgoing hare message with the returned messages.
+	transform func(p *party, msg Message) []Message
+	// input overwrites input set of the party.
+	input *Set
+}
+
+func (s strategy) honest() bool {
+	return s.delay == 0 && s.transform == nil && s.input == nil
+}
+
+// resign copies the message, applies modification to the copy and signs it with the key of the party.
+func (p *party) resign(msg Message, modify func(*InnerMessage)) Message {
+	inner := *msg.InnerMsg
+	modify(&inner)
+	return Message{Sig: p.signer.Sign(inner.Bytes()), InnerMsg: &inner}
+}
+
+// equivocate sends a conflicting message together with every message.
+func equivocate() strategy {
+	return strategy{
+		name: "equivocate",
+		transform: func(p *party, msg Message) []Message {
+			return []Message{msg, p.resign(msg, func(inner *InnerMessage) {
+				inner.Values = append(append([]types.ProposalID{}, inner.Values...), types.RandomProposalID())
+			})}
+		},
+	}
+}
+
+// invalidProof sends messages with eligibility proofs that don't belong to the party.
+func invalidProof() strategy {
+	return strategy{
+		name: "invalid proof",
+		transform: func(p *party, msg Message) []Message {
+			return []Message{p.resign(msg, func(inner *InnerMessage) {
+				inner.RoleProof = types.RandomBytes(len(inner.RoleProof))
+			})}
+		},
+	}
+}
+
+// delay publishes messages with random delay up to the specified duration.
+func delay(max time.Duration) strategy {
+	return strategy{name: "delay", delay: max}
+}
+
+// minority votes for the set that is not supported by honest parties.
+func minority(set *Set) strategy {
+	return strategy{
+		name:  "minority",
+		input: set,
+		transform: func(p *party, msg Message) []Message {
+			if msg.InnerMsg.Type == notify {
+				return []Message{msg}
+			}
+			return []Message{p.resign(msg, func(inner *InnerMessage) {
+				inner.Values = set.ToSlice()
+			})}
+		},
+	}
+}
+
+// replayOld sends messages from the previous layers for the same round together with every message.
+func replayOld() strategy {
+	return strategy{
+		name: "replay old",
+		transform: func(p *party, msg Message) []Message {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			rst := []Message{msg}
+			for _, old := range p.history {
+				if old.InnerMsg.InstanceID.Before(msg.InnerMsg.InstanceID) &&
+					old.InnerMsg.K == msg.InnerMsg.K && old.InnerMsg.Type == msg.InnerMsg.Type {
+					rst = append(rst, old)
+				}
+			}
+			p.history = append(p.history, msg)
+			return rst
+		},
+	}
+}
+
+type party struct {
+	strategy
+	signer *signing.EdSigner
+	nodeID types.NodeID
+	oracle partyOracle
+	broker *Broker
+	ps     *memPubSub
+	db     *sql.Database
+
+	mu      sync.Mutex
+	history []Message
+}
+
+// Publish applies strategy of the party to the outgoing hare messages.
+func (p *party) Publish(ctx context.Context, protocol string, data []byte) error {
+	if protocol != pubsub.HareProtocol || p.honest() {
+		return p.ps.Publish(ctx, protocol, data)
+	}
+	msg, err := MessageFromBuffer(data)
+	if err != nil {
+		return err
+	}
+	msgs := []Message{msg}
+	if p.transform != nil {
+		msgs = p.transform(p, msg)
+	}
+	encoded := make([][]byte, 0, len(msgs))
+	for i := range msgs {
+		buf, err := codec.Encode(&msgs[i])
+		if err != nil {
+			return err
+		}
+		encoded = append(encoded, buf)
+	}
+	publish := func() {
+		for _, buf := range encoded {
+			_ = p.ps.Publish(ctx, protocol, buf)
+		}
+	}
+	if p.delay == 0 {
+		publish()
+		return nil
+	}
+	go func() {
+		time.Sleep(time.Duration(rand.Int63n(int64(p.delay))))
+		publish()
+	}()
+	return nil
+}
+
+// hareHarness runs consensus processes of all parties for the sequence of layers over in-memory network.
+type hareHarness struct {
+	tb            testing.TB
+	cfg           config.Config
+	roundDuration time.Duration
+	oracle        *harnessOracle
+	parties       []*party
+}
+
+func newHareHarness(tb testing.TB, seed int64, cfg config.Config, roundDuration time.Duration, strategies []strategy) *hareHarness {
+	tb.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	tb.Cleanup(cancel)
+	net := newMemNetwork(ctx)
+	h := &hareHarness{
+		tb:            tb,
+		cfg:           cfg,
+		roundDuration: roundDuration,
+		oracle:        newHarnessOracle(seed),
+	}
+	for i, s := range strategies {
+		signer := signing.NewEdSigner()
+		nodeID := types.BytesToNodeID(signer.PublicKey().Bytes())
+		logger := logtest.New(tb).Named(fmt.Sprintf("party-%d", i))
+		p := &party{
+			strategy: s,
+			signer:   signer,
+			nodeID:   nodeID,
+			oracle:   partyOracle{harnessOracle: h.oracle, id: nodeID},
+			ps:       net.join(p2p.Peer(nodeID.String())),
+			db:       sql.InMemory(),
+		}
+		ev := newEligibilityValidator(p.oracle, 4, cfg.N, cfg.ExpectedLeaders, logger)
+		p.broker = newBroker(p.ps.id, ev, h.oracle, alwaysSynced{}, 4, cfg.LimitConcurrent, util.NewCloser(), logger)
+		require.NoError(tb, p.broker.Start(ctx))
+		tb.Cleanup(p.broker.Close)
+		p.ps.Register(pubsub.HareProtocol, p.broker.HandleMessage)
+		h.oracle.register(nodeID)
+		h.parties = append(h.parties, p)
+	}
+	return h
+}
+
+// run starts consensus processes of all parties for the layer, and returns outputs of honest parties.
+// Output is nil if the party didn't complete the protocol within the limit of iterations.
+func (h *hareHarness) run(lid types.LayerID, inputs []*Set) []*Set {
+	h.tb.Helper()
+	clock := NewSimpleRoundClock(time.Now().Add(h.roundDuration), 0, h.roundDuration)
+	procs := make([]*consensusProcess, len(h.parties))
+	outputs := make([]chan TerminationOutput, len(h.parties))
+	for i, p := range h.parties {
+		input := inputs[i]
+		if p.input != nil {
+			input = p.input
+		}
+		outputs[i] = make(chan TerminationOutput, 1)
+		logger := logtest.New(h.tb).Named(fmt.Sprintf("party-%d", i))
+		ev := newEligibilityValidator(p.oracle, 4, h.cfg.N, h.cfg.ExpectedLeaders, logger)
+		procs[i] = newConsensusProcess(h.cfg, lid, input, p.oracle, h.oracle, 4, p.signer, p.db, p.nodeID, p,
+			outputs[i], ev, clock, logger)
+		inbox, err := p.broker.Register(context.Background(), lid)
+		require.NoError(h.tb, err)
+		procs[i].SetInbox(inbox)
+	}
+	for _, proc := range procs {
+		require.NoError(h.tb, proc.Start(context.Background()))
+	}
+	// time required to complete all iterations with the margin for the preround and termination
+	timeout := time.After(h.roundDuration * time.Duration(h.cfg.LimitIterations*RoundsPerIteration+4))
+	var rst []*Set
+	for i, p := range h.parties {
+		if !p.honest() {
+			continue
+		}
+		select {
+		case out := <-outputs[i]:
+			if out.Completed() {
+				rst = append(rst, out.Set())
+			} else {
+				rst = append(rst, nil)
+			}
+		case <-timeout:
+			h.tb.Fatalf("consensus process of party %d didn't terminate in layer %s", i, lid)
+		}
+	}
+	for i, p := range h.parties {
+		select {
+		case <-procs[i].CloseChannel():
+		case <-timeout:
+			if p.honest() {
+				h.tb.Fatalf("consensus process of party %d didn't close in layer %s", i, lid)
+			}
+		}
+		p.broker.Unregister(context.Background(), lid)
+	}
+	return rst
+}
+
+// requireHareProperties checks that all honest parties completed the protocol with the same output,
+// which contains values that all honest parties agree on, and only values that some honest party proposed.
+func requireHareProperties(tb testing.TB, h *hareHarness, inputs, outputs []*Set) {
+	tb.Helper()
+	var intersection, union *Set
+	for i, p := range h.parties {
+		if !p.honest() {
+			continue
+		}
+		if intersection == nil {
+			intersection, union = inputs[i], inputs[i]
+			continue
+		}
+		intersection = intersection.Intersection(inputs[i])
+		union = union.Union(inputs[i])
+	}
+	require.NotEmpty(tb, outputs)
+	for i, out := range outputs {
+		require.NotNil(tb, out, "honest party %d didn't complete", i)
+		require.True(tb, outputs[0].Equals(out), "agreement: %s != %s", outputs[0], out)
+	}
+	require.True(tb, intersection.IsSubSetOf(outputs[0]), "validity: %s not in %s", intersection, outputs[0])
+	require.True(tb, outputs[0].IsSubSetOf(union), "validity: %s not in %s", outputs[0], union)
+}
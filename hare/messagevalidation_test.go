@@ -89,8 +89,7 @@ func TestEligibilityValidator_validateRole_Genesis(t *testing.T) {
 		SetValues(NewDefaultEmptySet()).
 		SetPubKey(sig.PublicKey()).
 		SetEligibilityCount(1)
-	builder.Sign(sig)
-	m := builder.Build()
+	m := mustSign(builder, sig).Build()
 
 	res, err := ev.validateRole(context.TODO(), m)
 	assert.NoError(t, err)
@@ -283,16 +282,20 @@ func TestMessageValidator_ValidateMessage(t *testing.T) {
 	proc := generateConsensusProcess(t)
 	proc.advanceToNextRound(context.TODO())
 	v := proc.validator
-	b, err := proc.initDefaultBuilder(proc.s)
+	b, err := proc.initDefaultBuilder(proc.participants()[0], proc.s)
 	assert.Nil(t, err)
-	preround := b.SetType(pre).Sign(proc.signing).Build()
+	b, err = b.SetType(pre).Sign(proc.signing)
+	require.NoError(t, err)
+	preround := b.Build()
 	preround.PubKey = proc.signing.PublicKey()
 	assert.True(t, v.SyntacticallyValidateMessage(context.TODO(), preround))
 	e := v.ContextuallyValidateMessage(context.TODO(), preround, 0)
 	assert.Nil(t, e)
-	b, err = proc.initDefaultBuilder(proc.s)
+	b, err = proc.initDefaultBuilder(proc.participants()[0], proc.s)
 	assert.Nil(t, err)
-	status := b.SetType(status).Sign(proc.signing).Build()
+	b, err = b.SetType(status).Sign(proc.signing)
+	require.NoError(t, err)
+	status := b.Build()
 	status.PubKey = proc.signing.PublicKey()
 	e = v.ContextuallyValidateMessage(context.TODO(), status, 0)
 	assert.Nil(t, e)
@@ -11,7 +11,7 @@ import (
 func BuildNotifyMsg(signing Signer, s *Set) *Msg {
 	builder := newMessageBuilder()
 	builder.SetType(notify).SetInstanceID(instanceID1).SetRoundCounter(notifyRound).SetKi(ki).SetValues(s)
-	builder = builder.SetPubKey(signing.PublicKey()).Sign(signing)
+	builder = mustSign(builder.SetPubKey(signing.PublicKey()), signing)
 	cert := &Certificate{}
 	cert.Values = NewSetFromValues(value1).ToSlice()
 	cert.AggMsgs = &AggregatedMessages{}
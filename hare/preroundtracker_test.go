@@ -45,7 +45,7 @@ func BuildPreRoundMsg(signing Signer, s *Set, roleProof []byte) *Msg {
 	builder.SetPubKey(signing.PublicKey())
 	builder.SetEligibilityCount(1)
 
-	return builder.Sign(signing).Build()
+	return mustSign(builder, signing).Build()
 }
 
 func TestPreRoundTracker_OnPreRound(t *testing.T) {
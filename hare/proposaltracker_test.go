@@ -14,7 +14,7 @@ func buildProposalMsg(signing Signer, s *Set, signature []byte) *Msg {
 	builder := newMessageBuilder().SetRoleProof(signature)
 	builder.SetType(proposal).SetInstanceID(instanceID1).SetRoundCounter(proposalRound).SetKi(ki).SetValues(s).SetSVP(buildSVP(ki, NewSetFromValues(value1)))
 	builder.SetEligibilityCount(1)
-	builder = builder.SetPubKey(signing.PublicKey()).Sign(signing)
+	builder = mustSign(builder.SetPubKey(signing.PublicKey()), signing)
 
 	return builder.Build()
 }
@@ -0,0 +1,285 @@
+package hare
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/signing"
+	"github.com/spacemeshos/go-spacemesh/sql/hareinstances"
+)
+
+//go:generate scalegen -types InstanceState,InstanceMessage,InstanceOutput
+
+// InstanceMessage is a message that was processed by the consensus process.
+type InstanceMessage struct {
+	// K is the round counter when the message was processed.
+	K       uint32
+	Sender  []byte
+	Message Message
+}
+
+func (im *InstanceMessage) msg() *Msg {
+	return &Msg{Message: im.Message, PubKey: signing.NewPublicKey(im.Sender), RequestID: "recovered"}
+}
+
+// InstanceState is a snapshot of the consensus process that is persisted at the beginning of every round,
+// so that the node can rejoin the instance after restart.
+type InstanceState struct {
+	Layer types.LayerID
+	// K is the round counter when the snapshot was taken.
+	K  uint32
+	Ki uint32
+	// Initial is the set the consensus process was started with.
+	Initial     []types.ProposalID
+	Values      []types.ProposalID
+	Certificate *Certificate
+	NotifySent  bool
+	// Messages are preround and notify messages from all iterations, and other messages from
+	// the current iteration. They are used to rebuild trackers.
+	Messages []InstanceMessage
+}
+
+// InstanceOutput is the persisted output of the consensus process.
+type InstanceOutput struct {
+	Completed bool
+	Coinflip  bool
+	Set       []types.ProposalID
+}
+
+// resumable is implemented by consensus processes that can rejoin a running instance.
+type resumable interface {
+	resume(ctx context.Context, state *InstanceState, k uint32)
+}
+
+// journal keeps messages that are needed to rebuild the state of the consensus process.
+type journal struct {
+	messages []InstanceMessage
+}
+
+func (j *journal) onMessage(k uint32, m *Msg) {
+	j.messages = append(j.messages, InstanceMessage{K: k, Sender: m.PubKey.Bytes(), Message: m.Message})
+}
+
+// prune drops status, proposal and commit messages from iterations before the one with round counter k.
+func (j *journal) prune(k uint32) {
+	if k == preRound {
+		return
+	}
+	iteration := iterationFromCounter(k)
+	rst := j.messages[:0]
+	for _, im := range j.messages {
+		switch im.Message.InnerMsg.Type {
+		case pre, notify:
+		default:
+			if im.K == preRound || iterationFromCounter(im.K) < iteration {
+				continue
+			}
+		}
+		rst = append(rst, im)
+	}
+	j.messages = rst
+}
+
+// persistState saves the snapshot of the consensus process.
+func (proc *consensusProcess) persistState(ctx context.Context) {
+	if proc.db == nil {
+		return
+	}
+	k := proc.getK()
+	proc.journal.prune(k)
+	state := &InstanceState{
+		Layer:       proc.instanceID,
+		K:           k,
+		Ki:          proc.ki,
+		Initial:     proc.initial,
+		Values:      proc.s.ToSlice(),
+		Certificate: proc.certificate,
+		NotifySent:  proc.notifySent,
+		Messages:    proc.journal.messages,
+	}
+	buf, err := codec.Encode(state)
+	if err != nil {
+		proc.WithContext(ctx).With().Panic("failed to encode hare state", log.Err(err))
+	}
+	if err := hareinstances.SetState(proc.db, proc.instanceID, buf); err != nil {
+		proc.WithContext(ctx).With().Error("failed to persist hare state", log.Err(err))
+	}
+}
+
+// resume restores the consensus process from the snapshot, so that it continues from the round k.
+// Process doesn't send messages in the round it was resumed in, as it might have already sent them
+// before the restart, and sending them with a different content would be an equivocation.
+// Must be called before Start.
+func (proc *consensusProcess) resume(ctx context.Context, state *InstanceState, k uint32) {
+	proc.resumed = true
+	proc.setK(k)
+	proc.ki = state.Ki
+	proc.initial = state.Initial
+	proc.s = NewSet(state.Values)
+	proc.certificate = state.Certificate
+	proc.notifySent = state.NotifySent
+	var previous, current []*Msg
+	for i := range state.Messages {
+		im := &state.Messages[i]
+		m := im.msg()
+		proc.eTracker.Track(m)
+		switch m.InnerMsg.Type {
+		case pre:
+			proc.preRoundTracker.OnPreRound(ctx, m)
+		case notify:
+			proc.notifyTracker.OnNotify(m)
+		default:
+			if k == preRound {
+				continue
+			}
+			if im.K == k {
+				current = append(current, m)
+			} else if im.K+1 == k {
+				previous = append(previous, m)
+			}
+		}
+	}
+	proc.journal.messages = state.Messages
+	proc.journal.prune(k)
+	if k == preRound {
+		return
+	}
+
+	switch proc.currentRound() {
+	case statusRound:
+		proc.statusesTracker = newStatusTracker(proc.cfg.F+1, proc.cfg.N)
+		proc.statusesTracker.Log = proc.Log
+		for _, m := range current {
+			if m.InnerMsg.Type == status {
+				proc.statusesTracker.RecordStatus(ctx, m)
+			}
+		}
+	case proposalRound:
+		proc.proposalTracker = newProposalTracker(proc.Log)
+		for _, m := range current {
+			if m.InnerMsg.Type == proposal {
+				proc.proposalTracker.OnProposal(ctx, m)
+			}
+		}
+	case commitRound:
+		proc.proposalTracker = newProposalTracker(proc.Log)
+		for _, m := range previous {
+			if m.InnerMsg.Type == proposal {
+				proc.proposalTracker.OnProposal(ctx, m)
+			}
+		}
+		proc.commitTracker = newCommitTracker(proc.cfg.F+1, proc.cfg.N, proc.proposalTracker.ProposedSet())
+		for _, m := range current {
+			switch m.InnerMsg.Type {
+			case proposal:
+				proc.proposalTracker.OnLateProposal(ctx, m)
+			case commit:
+				proc.mTracker.Track(m)
+				proc.commitTracker.OnCommit(m)
+			}
+		}
+	}
+}
+
+// roundAt returns the round counter of the instance for the layer at the given time.
+// Returns false if the instance is not running at that time.
+func (h *Hare) roundAt(lid types.LayerID, now time.Time) (uint32, bool) {
+	wakeup := h.layerClock.LayerToTime(lid).Add(time.Duration(h.config.WakeupDelta) * time.Second)
+	roundDuration := time.Duration(h.config.RoundDuration) * time.Second
+	if now.Before(wakeup) || roundDuration == 0 {
+		return 0, false
+	}
+	// preround is the first round after wakeup, it wraps to preRound counter
+	k := uint32(now.Sub(wakeup)/roundDuration) - 1
+	if k != preRound && k >= uint32(h.config.LimitIterations)*RoundsPerIteration {
+		return 0, false
+	}
+	return k, true
+}
+
+// recover loads instances persisted before the restart. Outputs that were not consumed by the block generator
+// are delivered again, and instances that are still running are resumed.
+func (h *Hare) recover(ctx context.Context) error {
+	last := h.layerClock.GetCurrentLayer()
+	from := types.NewLayerID(0)
+	if last.After(types.NewLayerID(h.bufferSize)) {
+		from = last.Sub(h.bufferSize)
+	}
+	instances, err := hareinstances.Since(h.db, from)
+	if err != nil {
+		return err
+	}
+	for _, inst := range instances {
+		logger := h.WithContext(ctx).WithFields(inst.Layer)
+		if inst.Output != nil {
+			var out InstanceOutput
+			if err := codec.Decode(inst.Output, &out); err != nil {
+				return fmt.Errorf("decode hare output %s: %w", inst.Layer, err)
+			}
+			h.mu.Lock()
+			h.outputs[inst.Layer] = out.Set
+			h.mu.Unlock()
+			if !inst.Delivered && out.Completed {
+				logger.With().Info("delivering hare output recovered after restart", log.Int("num_proposals", len(out.Set)))
+				h.wg.Add(1)
+				go func(lid types.LayerID, pids []types.ProposalID) {
+					defer h.wg.Done()
+					h.deliver(ctx, lid, pids)
+				}(inst.Layer, out.Set)
+			}
+			continue
+		}
+		if inst.State == nil {
+			continue
+		}
+		var state InstanceState
+		if err := codec.Decode(inst.State, &state); err != nil {
+			return fmt.Errorf("decode hare state %s: %w", inst.Layer, err)
+		}
+		if _, err := h.resume(ctx, &state); err != nil {
+			logger.With().Warning("failed to resume hare instance", log.Err(err))
+		}
+	}
+	return nil
+}
+
+// resume rejoins the instance from the persisted state if it is still running.
+func (h *Hare) resume(ctx context.Context, state *InstanceState) (bool, error) {
+	logger := h.WithContext(ctx).WithFields(state.Layer)
+	k, running := h.roundAt(state.Layer, time.Now())
+	if !running {
+		logger.Debug("not resuming hare: instance is not running")
+		return false, nil
+	}
+	if k != preRound && state.K != preRound && k < state.K {
+		k = state.K
+	}
+	c, err := h.broker.Register(ctx, state.Layer)
+	if err != nil {
+		return false, fmt.Errorf("broker register: %w", err)
+	}
+	cp := h.factory(h.config, state.Layer, NewSet(state.Initial), h.rolacle, h.sign, h.publisher, h.newRoundClock(state.Layer), h.outputChan)
+	r, ok := cp.(resumable)
+	if !ok {
+		h.broker.Unregister(ctx, state.Layer)
+		return false, nil
+	}
+	r.resume(ctx, state, k)
+	cp.SetInbox(c)
+	if err := cp.Start(ctx); err != nil {
+		h.broker.Unregister(ctx, state.Layer)
+		return false, fmt.Errorf("start consensus: %w", err)
+	}
+	h.patrol.SetHareInCharge(state.Layer)
+	logger.With().Info("resumed hare instance after restart",
+		log.Uint32("snapshot_k", state.K),
+		log.Uint32("current_k", k))
+	logger.With().Debug("number of consensus processes (after register)",
+		log.Int32("count", atomic.AddInt32(&h.totalCPs, 1)))
+	return true, nil
+}
@@ -0,0 +1,213 @@
+package hare
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/hare/config"
+	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
+	"github.com/spacemeshos/go-spacemesh/signing"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/hareinstances"
+)
+
+func loadInstanceState(tb testing.TB, db sql.Executor, lid types.LayerID) *InstanceState {
+	tb.Helper()
+	inst, err := hareinstances.Get(db, lid)
+	require.NoError(tb, err)
+	var state InstanceState
+	require.NoError(tb, codec.Decode(inst.State, &state))
+	return &state
+}
+
+func TestConsensusProcess_PersistResume(t *testing.T) {
+	ctx := context.TODO()
+	proc := generateConsensusProcessWithConfig(t, config.Config{N: 10, F: 0, RoundDuration: 2, ExpectedLeaders: 5, LimitIterations: 10})
+	s := NewSetFromValues(value1)
+
+	proc.processMsg(ctx, BuildPreRoundMsg(signing.NewEdSigner(), s, nil))
+	proc.processMsg(ctx, BuildPreRoundMsg(signing.NewEdSigner(), s, nil))
+
+	proc.setK(proposalRound)
+	proc.proposalTracker = newProposalTracker(proc.Log)
+	proc.processMsg(ctx, BuildProposalMsg(signing.NewEdSigner(), s))
+
+	proc.setK(commitRound)
+	proc.commitTracker = newCommitTracker(proc.cfg.F+1, proc.cfg.N, proc.proposalTracker.ProposedSet())
+	proc.processMsg(ctx, BuildCommitMsg(signing.NewEdSigner(), s))
+	proc.persistState(ctx)
+
+	state := loadInstanceState(t, proc.db, proc.instanceID)
+	require.Equal(t, proc.instanceID, state.Layer)
+	require.EqualValues(t, commitRound, state.K)
+	require.Equal(t, []types.ProposalID{value1}, state.Initial)
+	require.Len(t, state.Messages, 4)
+
+	t.Run("same round", func(t *testing.T) {
+		resumed := generateConsensusProcessWithConfig(t, proc.cfg)
+		resumed.resume(ctx, state, commitRound)
+		require.True(t, resumed.resumed)
+		require.EqualValues(t, commitRound, resumed.getK())
+		require.Len(t, resumed.preRoundTracker.preRound, 2)
+		require.True(t, s.Equals(resumed.proposalTracker.ProposedSet()))
+		require.True(t, resumed.commitTracker.HasEnoughCommits())
+		require.Equal(t, 1, resumed.commitTracker.CommitCount())
+	})
+	t.Run("later round", func(t *testing.T) {
+		resumed := generateConsensusProcessWithConfig(t, proc.cfg)
+		resumed.resume(ctx, state, notifyRound)
+		require.EqualValues(t, notifyRound, resumed.getK())
+		require.Len(t, resumed.preRoundTracker.preRound, 2)
+		require.Nil(t, resumed.commitTracker)
+	})
+	t.Run("next iteration", func(t *testing.T) {
+		resumed := generateConsensusProcessWithConfig(t, proc.cfg)
+		resumed.resume(ctx, state, RoundsPerIteration+commitRound)
+		require.Len(t, resumed.preRoundTracker.preRound, 2)
+		require.Nil(t, resumed.commitTracker.(*commitTracker).proposedSet)
+
+		resumed.persistState(ctx)
+		state := loadInstanceState(t, resumed.db, resumed.instanceID)
+		require.Len(t, state.Messages, 2)
+		for _, im := range state.Messages {
+			require.Equal(t, pre, im.Message.InnerMsg.Type)
+		}
+	})
+}
+
+func TestHare_roundAt(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.WakeupDelta = 10
+	cfg.RoundDuration = 10
+	cfg.LimitIterations = 2
+	clock := newMockClock()
+	h := createTestHare(t, sql.InMemory(), cfg, clock, "test", noopPubSub(t), t.Name())
+
+	lid := types.GetEffectiveGenesis()
+	start := clock.LayerToTime(lid)
+	for _, tc := range []struct {
+		desc    string
+		elapsed time.Duration
+		k       uint32
+		running bool
+	}{
+		{desc: "before wakeup", elapsed: 5 * time.Second},
+		{desc: "preround", elapsed: 15 * time.Second, k: preRound, running: true},
+		{desc: "status", elapsed: 25 * time.Second, k: statusRound, running: true},
+		{desc: "second iteration", elapsed: 75 * time.Second, k: RoundsPerIteration + proposalRound, running: true},
+		{desc: "iterations limit", elapsed: 105 * time.Second},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			k, running := h.roundAt(lid, start.Add(tc.elapsed))
+			require.Equal(t, tc.running, running)
+			require.Equal(t, tc.k, k)
+		})
+	}
+}
+
+func TestHare_RecoverOutputs(t *testing.T) {
+	db := sql.InMemory()
+	undelivered := types.GetEffectiveGenesis().Add(1)
+	delivered := types.GetEffectiveGenesis()
+	for _, out := range []struct {
+		lid       types.LayerID
+		delivered bool
+		pids      []types.ProposalID
+	}{
+		{lid: undelivered, pids: []types.ProposalID{value1, value2}},
+		{lid: delivered, delivered: true, pids: []types.ProposalID{value3}},
+	} {
+		buf, err := codec.Encode(&InstanceOutput{Completed: true, Set: out.pids})
+		require.NoError(t, err)
+		require.NoError(t, hareinstances.SetOutput(db, out.lid, buf))
+		if out.delivered {
+			require.NoError(t, hareinstances.SetDelivered(db, out.lid))
+		}
+	}
+
+	h := createTestHare(t, db, config.DefaultConfig(), newMockClock(), "test", noopPubSub(t), t.Name())
+	require.NoError(t, h.Start(context.TODO()))
+	t.Cleanup(h.Close)
+
+	select {
+	case out := <-h.blockGenCh:
+		require.Equal(t, undelivered, out.Layer)
+		require.Equal(t, []types.ProposalID{value1, value2}, out.Proposals)
+	case <-time.After(time.Second):
+		require.Fail(t, "timed out waiting for recovered output")
+	}
+	require.Eventually(t, func() bool {
+		inst, err := hareinstances.Get(db, undelivered)
+		require.NoError(t, err)
+		return inst.Delivered
+	}, time.Second, 10*time.Millisecond)
+	select {
+	case out := <-h.blockGenCh:
+		require.Failf(t, "output delivered twice", "layer %s", out.Layer)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	pids, err := h.getResult(delivered)
+	require.NoError(t, err)
+	require.Equal(t, []types.ProposalID{value3}, pids)
+}
+
+func TestHare_ResumeInstance(t *testing.T) {
+	db := sql.InMemory()
+	cfg := config.DefaultConfig()
+	cfg.N = 10
+	cfg.F = 5
+	cfg.WakeupDelta = 1
+	cfg.RoundDuration = 1
+	cfg.LimitIterations = 4
+
+	lid := types.GetEffectiveGenesis().Add(1)
+	state := &InstanceState{
+		Layer:   lid,
+		K:       preRound,
+		Ki:      preRound,
+		Initial: []types.ProposalID{value1, value2},
+		Values:  []types.ProposalID{value1},
+		Messages: []InstanceMessage{{
+			K:       preRound,
+			Sender:  signing.NewEdSigner().PublicKey().Bytes(),
+			Message: BuildPreRoundMsg(signing.NewEdSigner(), NewSetFromValues(value1), nil).Message,
+		}},
+	}
+	buf, err := codec.Encode(state)
+	require.NoError(t, err)
+	require.NoError(t, hareinstances.SetState(db, lid, buf))
+
+	clock := newMockClock()
+	// instance is in the middle of the status round
+	clock.layerTime[lid] = time.Now().Add(-2500 * time.Millisecond)
+	h := createTestHare(t, db, cfg, clock, "test", noopPubSub(t), t.Name())
+	h.mockRoracle.EXPECT().IsIdentityActiveOnConsensusView(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
+
+	created := make(chan *consensusProcess, 1)
+	factory := h.factory
+	h.factory = func(cfg config.Config, instanceId types.LayerID, s *Set, oracle Rolacle, signing Signer, p2p pubsub.Publisher, clock RoundClock, outputChan chan TerminationOutput) Consensus {
+		cp := factory(cfg, instanceId, s, oracle, signing, p2p, clock, outputChan)
+		created <- cp.(*consensusProcess)
+		return cp
+	}
+	require.NoError(t, h.Start(context.TODO()))
+	t.Cleanup(h.Close)
+
+	select {
+	case proc := <-created:
+		require.Equal(t, lid, proc.ID())
+		require.True(t, proc.resumed)
+		require.Equal(t, []types.ProposalID{value1, value2}, proc.initial)
+		require.GreaterOrEqual(t, proc.getK(), uint32(statusRound))
+		require.NotEqual(t, uint32(preRound), proc.getK())
+	default:
+		require.Fail(t, "instance wasn't resumed")
+	}
+}
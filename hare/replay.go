@@ -0,0 +1,210 @@
+package hare
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/hare/config"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/signing"
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+// ReplayResult is the output of the consensus process that replayed a trace.
+type ReplayResult struct {
+	Layer      types.LayerID
+	Terminated bool
+	Completed  bool
+	Coinflip   bool
+	K          uint32
+	Set        []types.ProposalID
+}
+
+// Replay feeds events from the recorded trace into a new consensus process and returns its output.
+//
+// Recorded messages already passed validation, therefore replayed process accepts them without validation.
+// Replayed process is passive and doesn't send messages, messages that were sent by the recording node
+// are part of the trace as they were received through gossip. Rounds are driven by the fake clock
+// that ends the round only after all messages recorded before the end of the round were processed.
+func Replay(ctx context.Context, logger log.Log, trace *Trace) (*ReplayResult, error) {
+	cfg := config.Config{
+		N:               int(trace.N),
+		F:               int(trace.F),
+		ExpectedLeaders: int(trace.ExpectedLeaders),
+		LimitIterations: int(trace.LimitIterations),
+	}
+	clock := newReplayClock()
+	output := make(chan TerminationOutput, 2)
+	inbox := make(chan *Msg)
+	proc := newConsensusProcess(cfg, trace.Layer, NewSet(trace.Initial), replayOracle{}, nil,
+		0, signing.NewEdSigner(), sql.InMemory(), types.NodeID{}, replayPublisher{},
+		output, nil, clock, logger)
+	barrier := &Msg{
+		Message: Message{InnerMsg: &InnerMessage{Type: pre, InstanceID: trace.Layer}},
+		PubKey:  signing.NewEdSigner().PublicKey(),
+	}
+	proc.validator = replayValidator{barrier: barrier}
+	proc.SetInbox(inbox)
+	if err := proc.Start(ctx); err != nil {
+		return nil, err
+	}
+
+	// wait until preround started
+	if err := clock.waitAwait(ctx, proc); err != nil {
+		return nil, err
+	}
+	for i, ev := range trace.Events {
+		switch ev.Type {
+		case TraceMessage:
+			var msg Message
+			if err := codec.Decode(ev.Message, &msg); err != nil {
+				return nil, fmt.Errorf("decode message %d: %w", i, err)
+			}
+			m := &Msg{Message: msg, PubKey: signing.NewPublicKey(ev.Sender), RequestID: "replay"}
+			select {
+			case inbox <- m:
+			case <-proc.CloseChannel():
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		case TraceRoundEnd:
+			clock.end(ev.K)
+			if err := clock.waitAwait(ctx, proc); err != nil {
+				return nil, err
+			}
+		}
+	}
+	// the barrier is discarded by the validator. once the second barrier is received the process
+	// handled all events, and it is safe to close it unless it already closed itself.
+	for i := 0; i < 2; i++ {
+		select {
+		case inbox <- barrier:
+		case <-proc.CloseChannel():
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if !proc.IsClosed() {
+		proc.Close()
+	}
+
+	rst := &ReplayResult{Layer: trace.Layer, K: proc.getK()}
+	select {
+	case out := <-output:
+		rst.Terminated = true
+		rst.Completed = out.Completed()
+		rst.Coinflip = out.Coinflip()
+		if out.Set() != nil {
+			rst.Set = out.Set().ToSlice()
+		}
+	default:
+	}
+	return rst, nil
+}
+
+// replayClock ends rounds only when asked to. Every call to AwaitEndOfRound is signaled, so that replay
+// can wait until the consensus process is ready to receive messages for the next round.
+type replayClock struct {
+	mu      sync.Mutex
+	rounds  map[uint32]chan struct{}
+	awaited chan struct{}
+}
+
+func newReplayClock() *replayClock {
+	return &replayClock{
+		rounds:  make(map[uint32]chan struct{}),
+		awaited: make(chan struct{}, 1),
+	}
+}
+
+func (c *replayClock) round(k uint32) chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch, exist := c.rounds[k]
+	if !exist {
+		ch = make(chan struct{})
+		c.rounds[k] = ch
+	}
+	return ch
+}
+
+// AwaitWakeup returns a channel that gets closed when the wakeup round ends.
+func (c *replayClock) AwaitWakeup() <-chan struct{} {
+	return c.AwaitEndOfRound(Wakeup)
+}
+
+// AwaitEndOfRound returns a channel that gets closed when the round is ended by replay.
+func (c *replayClock) AwaitEndOfRound(round uint32) <-chan struct{} {
+	ch := c.round(round)
+	select {
+	case c.awaited <- struct{}{}:
+	default:
+	}
+	return ch
+}
+
+func (c *replayClock) end(round uint32) {
+	ch := c.round(round)
+	select {
+	case <-ch:
+	default:
+		close(ch)
+	}
+}
+
+func (c *replayClock) waitAwait(ctx context.Context, proc *consensusProcess) error {
+	select {
+	case <-c.awaited:
+	case <-proc.CloseChannel():
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return nil
+}
+
+var errReplayBarrier = errors.New("replay barrier")
+
+// replayValidator accepts all messages, they were already validated when the trace was recorded.
+type replayValidator struct {
+	barrier *Msg
+}
+
+func (replayValidator) SyntacticallyValidateMessage(context.Context, *Msg) bool {
+	return true
+}
+
+func (v replayValidator) ContextuallyValidateMessage(_ context.Context, m *Msg, _ uint32) error {
+	if m == v.barrier {
+		return errReplayBarrier
+	}
+	return nil
+}
+
+// replayOracle makes the replayed process passive.
+type replayOracle struct{}
+
+func (replayOracle) Validate(context.Context, types.LayerID, uint32, int, types.NodeID, []byte, uint16) (bool, error) {
+	return false, nil
+}
+
+func (replayOracle) CalcEligibility(context.Context, types.LayerID, uint32, int, types.NodeID, []byte) (uint16, error) {
+	return 0, nil
+}
+
+func (replayOracle) Proof(context.Context, types.LayerID, uint32) ([]byte, error) {
+	return nil, nil
+}
+
+func (replayOracle) IsIdentityActiveOnConsensusView(context.Context, types.NodeID, types.LayerID) (bool, error) {
+	return false, nil
+}
+
+type replayPublisher struct{}
+
+func (replayPublisher) Publish(context.Context, string, []byte) error {
+	return nil
+}
@@ -13,7 +13,7 @@ func buildStatusMsg(signing Signer, s *Set, ki uint32) *Msg {
 	builder := newMessageBuilder()
 	builder.SetType(status).SetInstanceID(instanceID1).SetRoundCounter(statusRound).SetKi(ki).SetValues(s)
 	builder.SetEligibilityCount(1)
-	builder = builder.SetPubKey(signing.PublicKey()).Sign(signing)
+	builder = mustSign(builder.SetPubKey(signing.PublicKey()), signing)
 
 	return builder.Build()
 }
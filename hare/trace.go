@@ -0,0 +1,209 @@
+package hare
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/hare/config"
+)
+
+//go:generate scalegen -types Trace,TraceEvent
+
+// TraceEventType is a type of the event recorded in the Trace.
+type TraceEventType uint8
+
+const (
+	// TraceRoundEnd is recorded when the round K ends.
+	TraceRoundEnd TraceEventType = iota + 1
+	// TraceMessage is recorded for every valid message processed by the consensus process.
+	TraceMessage
+	// TraceProposed is recorded at the end of the proposal round with the proposed set.
+	TraceProposed
+	// TraceCommitted is recorded when enough commits were collected for the proposed set.
+	TraceCommitted
+	// TraceTerminated is recorded when the consensus process terminates.
+	TraceTerminated
+)
+
+func (t TraceEventType) String() string {
+	switch t {
+	case TraceRoundEnd:
+		return "round_end"
+	case TraceMessage:
+		return "message"
+	case TraceProposed:
+		return "proposed"
+	case TraceCommitted:
+		return "committed"
+	case TraceTerminated:
+		return "terminated"
+	}
+	return fmt.Sprintf("unknown(%d)", uint8(t))
+}
+
+// TraceEvent is a single event in the consensus process trace.
+type TraceEvent struct {
+	Type TraceEventType
+	// Elapsed is the number of nanoseconds since the start of the consensus process.
+	Elapsed uint64
+	// K is the round counter when the event was recorded.
+	K uint32
+
+	// Sender, EligibilityCount and Message are set for TraceMessage.
+	Sender           []byte
+	EligibilityCount uint16
+	Message          []byte
+
+	// Values is set for TraceProposed, TraceCommitted and TraceTerminated.
+	Values []types.ProposalID
+
+	// Completed and Reason are set for TraceTerminated.
+	Completed bool
+	Reason    string
+}
+
+// Trace is a record of everything that affected decisions of the consensus process for a single layer.
+type Trace struct {
+	Layer types.LayerID
+	// Start is the time when the consensus process was started in unix nanoseconds.
+	Start uint64
+
+	N               uint32
+	F               uint32
+	ExpectedLeaders uint32
+	LimitIterations uint32
+
+	Initial []types.ProposalID
+	Events  []TraceEvent
+}
+
+// Termination returns the termination event of the trace, or nil if it wasn't recorded.
+func (t *Trace) Termination() *TraceEvent {
+	for i := len(t.Events) - 1; i >= 0; i-- {
+		if t.Events[i].Type == TraceTerminated {
+			return &t.Events[i]
+		}
+	}
+	return nil
+}
+
+// TracePath returns the path of the trace file for the layer.
+func TracePath(dir string, lid types.LayerID) string {
+	return filepath.Join(dir, fmt.Sprintf("%d.trace", lid.Uint32()))
+}
+
+// WriteTrace writes trace to the file.
+func WriteTrace(path string, trace *Trace) error {
+	buf, err := codec.Encode(trace)
+	if err != nil {
+		return fmt.Errorf("encode trace: %w", err)
+	}
+	if err := os.WriteFile(path, buf, 0o600); err != nil {
+		return fmt.Errorf("write trace %s: %w", path, err)
+	}
+	return nil
+}
+
+// ReadTrace reads trace from the file.
+func ReadTrace(path string) (*Trace, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read trace %s: %w", path, err)
+	}
+	var trace Trace
+	if err := codec.Decode(buf, &trace); err != nil {
+		return nil, fmt.Errorf("decode trace %s: %w", path, err)
+	}
+	return &trace, nil
+}
+
+// tracer records events of the consensus process. All methods are safe to call on nil tracer,
+// in which case nothing is recorded.
+type tracer struct {
+	dir   string
+	start time.Time
+	trace Trace
+}
+
+func newTracer(cfg config.Config, lid types.LayerID, s *Set) *tracer {
+	if cfg.TraceDir == "" {
+		return nil
+	}
+	return &tracer{
+		dir: cfg.TraceDir,
+		trace: Trace{
+			Layer:           lid,
+			N:               uint32(cfg.N),
+			F:               uint32(cfg.F),
+			ExpectedLeaders: uint32(cfg.ExpectedLeaders),
+			LimitIterations: uint32(cfg.LimitIterations),
+			Initial:         s.ToSlice(),
+		},
+	}
+}
+
+func (t *tracer) onStart() {
+	if t == nil {
+		return
+	}
+	t.start = time.Now()
+	t.trace.Start = uint64(t.start.UnixNano())
+}
+
+func (t *tracer) add(ev TraceEvent) {
+	ev.Elapsed = uint64(time.Since(t.start))
+	t.trace.Events = append(t.trace.Events, ev)
+}
+
+func (t *tracer) onMessage(k uint32, m *Msg) {
+	if t == nil {
+		return
+	}
+	t.add(TraceEvent{
+		Type:             TraceMessage,
+		K:                k,
+		Sender:           m.PubKey.Bytes(),
+		EligibilityCount: m.InnerMsg.EligibilityCount,
+		Message:          m.Bytes(),
+	})
+}
+
+func (t *tracer) onRoundEnd(k uint32) {
+	if t == nil {
+		return
+	}
+	t.add(TraceEvent{Type: TraceRoundEnd, K: k})
+}
+
+func (t *tracer) onSet(typ TraceEventType, k uint32, s *Set) {
+	if t == nil {
+		return
+	}
+	ev := TraceEvent{Type: typ, K: k}
+	if s != nil {
+		ev.Values = s.ToSlice()
+	}
+	t.add(ev)
+}
+
+func (t *tracer) onTerminated(k uint32, s *Set, completed bool, reason string) {
+	if t == nil || t.trace.Termination() != nil {
+		return
+	}
+	ev := TraceEvent{Type: TraceTerminated, K: k, Completed: completed, Reason: reason}
+	if s != nil {
+		ev.Values = s.ToSlice()
+	}
+	t.add(ev)
+}
+
+func (t *tracer) flush() error {
+	if t == nil {
+		return nil
+	}
+	return WriteTrace(TracePath(t.dir, t.trace.Layer), &t.trace)
+}
@@ -0,0 +1,97 @@
+package hare
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/eligibility"
+	"github.com/spacemeshos/go-spacemesh/hare/config"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
+	"github.com/spacemeshos/go-spacemesh/signing"
+)
+
+func TestTrace_WriteRead(t *testing.T) {
+	msg := BuildPreRoundMsg(signing.NewEdSigner(), NewSetFromValues(value1, value2), []byte{1})
+	trace := &Trace{
+		Layer:           instanceID1,
+		Start:           uint64(time.Now().UnixNano()),
+		N:               10,
+		F:               5,
+		ExpectedLeaders: 5,
+		LimitIterations: 4,
+		Initial:         []types.ProposalID{value1, value2},
+		Events: []TraceEvent{
+			{Type: TraceMessage, K: preRound, Sender: msg.PubKey.Bytes(), EligibilityCount: 1, Message: msg.Bytes()},
+			{Type: TraceRoundEnd, Elapsed: 10, K: preRound},
+			{Type: TraceTerminated, Elapsed: 20, K: 7, Values: []types.ProposalID{value1}, Completed: true, Reason: "test"},
+		},
+	}
+	path := TracePath(t.TempDir(), trace.Layer)
+	require.NoError(t, WriteTrace(path, trace))
+	got, err := ReadTrace(path)
+	require.NoError(t, err)
+	require.Equal(t, trace, got)
+	require.Equal(t, &trace.Events[2], got.Termination())
+
+	_, err = ReadTrace(filepath.Join(t.TempDir(), "missing.trace"))
+	require.Error(t, err)
+}
+
+func TestTrace_RecordReplay(t *testing.T) {
+	test := newConsensusTest()
+	cfg := config.Config{N: 10, F: 5, RoundDuration: 2, ExpectedLeaders: 5, LimitIterations: 1000}
+	totalNodes := 10
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mesh, err := mocknet.FullMeshLinked(totalNodes)
+	require.NoError(t, err)
+
+	test.initialSets = make([]*Set, totalNodes)
+	test.fill(NewSetFromValues(value1, value2), 0, totalNodes/2)
+	test.fill(NewSetFromValues(value1), totalNodes/2+1, totalNodes-1)
+	test.honestSets = test.initialSets
+	oracle := eligibility.New(logtest.New(t))
+	dirs := make([]string, totalNodes)
+	for i := 0; i < totalNodes; i++ {
+		ps, err := pubsub.New(ctx, logtest.New(t), mesh.Hosts()[i], pubsub.DefaultConfig())
+		require.NoError(t, err)
+		dirs[i] = t.TempDir()
+		pcfg := cfg
+		pcfg.TraceDir = dirs[i]
+		proc, broker := createConsensusProcess(t, true, pcfg, oracle, ps, test.initialSets[i], instanceID1, t.Name())
+		test.procs = append(test.procs, proc)
+		test.brokers = append(test.brokers, broker)
+	}
+	require.NoError(t, mesh.ConnectAllButSelf())
+	test.Start()
+	test.WaitForTimedTermination(t, 30*time.Second)
+
+	for i, dir := range dirs {
+		var trace *Trace
+		require.Eventually(t, func() bool {
+			trace, err = ReadTrace(TracePath(dir, instanceID1))
+			return err == nil
+		}, time.Second, 10*time.Millisecond)
+		require.Equal(t, instanceID1, trace.Layer)
+		require.Equal(t, test.initialSets[i].ToSlice(), trace.Initial)
+		recorded := trace.Termination()
+		require.NotNil(t, recorded)
+		require.True(t, recorded.Completed)
+		require.Equal(t, test.outputs[i].ToSlice(), recorded.Values)
+
+		rst, err := Replay(ctx, logtest.New(t), trace)
+		require.NoError(t, err)
+		require.True(t, rst.Terminated)
+		require.True(t, rst.Completed)
+		require.Equal(t, recorded.Values, rst.Set)
+		require.Equal(t, recorded.K, rst.K)
+	}
+}
@@ -0,0 +1,219 @@
+// Package keystore stores private keys encrypted with a key derived from a passphrase.
+package keystore
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	version    = 1
+	kdfScrypt  = "scrypt"
+	cipherName = "aes-256-gcm"
+	keyLength  = 32
+	saltLength = 32
+)
+
+var (
+	// ErrWrongPassphrase is returned when the key can't be decrypted with the passphrase.
+	ErrWrongPassphrase = errors.New("keystore: wrong passphrase")
+	// ErrPassphraseRequired is returned when an encrypted key is read without a passphrase.
+	ErrPassphraseRequired = errors.New("keystore: key is encrypted, passphrase required")
+	// ErrNotEncrypted is returned when the public key is requested from a plaintext key file.
+	ErrNotEncrypted = errors.New("keystore: key is not encrypted")
+)
+
+// ScryptParams are the cost parameters of the scrypt key derivation.
+type ScryptParams struct {
+	N    int    `json:"n"`
+	R    int    `json:"r"`
+	P    int    `json:"p"`
+	Salt []byte `json:"salt"`
+}
+
+// DefaultScryptParams returns the cost parameters used for new keys.
+func DefaultScryptParams() ScryptParams {
+	return ScryptParams{N: 1 << 18, R: 8, P: 1}
+}
+
+// Opt modifies encryption of a key.
+type Opt func(*ScryptParams)
+
+// WithScryptParams sets the cost parameters of the key derivation.
+func WithScryptParams(n, r, p int) Opt {
+	return func(params *ScryptParams) {
+		params.N = n
+		params.R = r
+		params.P = p
+	}
+}
+
+// file is the format of an encrypted key file.
+type file struct {
+	Version    int          `json:"version"`
+	Public     []byte       `json:"public,omitempty"`
+	KDF        string       `json:"kdf"`
+	KDFParams  ScryptParams `json:"kdfparams"`
+	Cipher     string       `json:"cipher"`
+	Nonce      []byte       `json:"nonce"`
+	Ciphertext []byte       `json:"ciphertext"`
+}
+
+func newAEAD(passphrase []byte, params ScryptParams) (cipher.AEAD, error) {
+	key, err := scrypt.Key(passphrase, params.Salt, params.N, params.R, params.P, keyLength)
+	if err != nil {
+		return nil, fmt.Errorf("derive key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("new cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// Encrypt encrypts the secret with a key derived from the passphrase.
+// The public part is stored in plaintext, so that it can be read without the passphrase.
+func Encrypt(secret, public, passphrase []byte, opts ...Opt) ([]byte, error) {
+	params := DefaultScryptParams()
+	for _, opt := range opts {
+		opt(&params)
+	}
+	params.Salt = make([]byte, saltLength)
+	if _, err := rand.Read(params.Salt); err != nil {
+		return nil, fmt.Errorf("read salt: %w", err)
+	}
+	aead, err := newAEAD(passphrase, params)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("read nonce: %w", err)
+	}
+	f := file{
+		Version:   version,
+		Public:    public,
+		KDF:       kdfScrypt,
+		KDFParams: params,
+		Cipher:    cipherName,
+		Nonce:     nonce,
+	}
+	// the public part is authenticated, so that it can't be swapped
+	f.Ciphertext = aead.Seal(nil, nonce, secret, public)
+	return json.MarshalIndent(f, "", "  ")
+}
+
+func decode(data []byte) (*file, bool) {
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return nil, false
+	}
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil || f.KDF == "" || f.Ciphertext == nil {
+		return nil, false
+	}
+	return &f, true
+}
+
+// IsEncrypted returns true if data is an encrypted key.
+func IsEncrypted(data []byte) bool {
+	_, ok := decode(data)
+	return ok
+}
+
+// Decrypt decrypts the encrypted key with the passphrase.
+func Decrypt(data, passphrase []byte) ([]byte, error) {
+	f, ok := decode(data)
+	if !ok {
+		return nil, ErrNotEncrypted
+	}
+	if f.Version != version || f.KDF != kdfScrypt || f.Cipher != cipherName {
+		return nil, fmt.Errorf("keystore: unsupported format version=%d kdf=%s cipher=%s", f.Version, f.KDF, f.Cipher)
+	}
+	aead, err := newAEAD(passphrase, f.KDFParams)
+	if err != nil {
+		return nil, err
+	}
+	if len(f.Nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("keystore: invalid nonce size %d", len(f.Nonce))
+	}
+	secret, err := aead.Open(nil, f.Nonce, f.Ciphertext, f.Public)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	return secret, nil
+}
+
+// PublicKey returns the public part stored with the encrypted key.
+func PublicKey(data []byte) ([]byte, error) {
+	f, ok := decode(data)
+	if !ok {
+		return nil, ErrNotEncrypted
+	}
+	return f.Public, nil
+}
+
+// ReadFile reads the key file at path. Encrypted keys are decrypted with the passphrase,
+// plaintext keys are returned as is. The second return value reports whether the file was encrypted.
+func ReadFile(path string, passphrase []byte) ([]byte, bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false, err
+	}
+	if !IsEncrypted(data) {
+		return data, false, nil
+	}
+	if len(passphrase) == 0 {
+		return nil, true, ErrPassphraseRequired
+	}
+	secret, err := Decrypt(data, passphrase)
+	if err != nil {
+		return nil, true, fmt.Errorf("decrypt %s: %w", path, err)
+	}
+	return secret, true, nil
+}
+
+// WriteFile atomically writes the key to path with mode 0600. The key is encrypted
+// if the passphrase is not empty, otherwise the secret is written in plaintext.
+func WriteFile(path string, secret, public, passphrase []byte, opts ...Opt) error {
+	data := secret
+	if len(passphrase) > 0 {
+		var err error
+		data, err = Encrypt(secret, public, passphrase, opts...)
+		if err != nil {
+			return err
+		}
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return fmt.Errorf("chmod %s: %w", tmp.Name(), err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write %s: %w", tmp.Name(), err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("sync %s: %w", tmp.Name(), err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close %s: %w", tmp.Name(), err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("rename %s: %w", tmp.Name(), err)
+	}
+	return nil
+}
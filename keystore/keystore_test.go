@@ -0,0 +1,115 @@
+package keystore
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// light parameters to keep tests fast.
+var testParams = WithScryptParams(1<<10, 8, 1)
+
+func TestEncryptDecrypt(t *testing.T) {
+	secret := []byte("secret key")
+	public := []byte("public key")
+	passphrase := []byte("passphrase")
+
+	data, err := Encrypt(secret, public, passphrase, testParams)
+	require.NoError(t, err)
+	require.True(t, IsEncrypted(data))
+	require.NotContains(t, string(data), string(secret))
+
+	got, err := Decrypt(data, passphrase)
+	require.NoError(t, err)
+	require.Equal(t, secret, got)
+
+	_, err = Decrypt(data, []byte("wrong"))
+	require.ErrorIs(t, err, ErrWrongPassphrase)
+
+	pub, err := PublicKey(data)
+	require.NoError(t, err)
+	require.Equal(t, public, pub)
+
+	require.False(t, IsEncrypted(secret))
+	_, err = Decrypt(secret, passphrase)
+	require.ErrorIs(t, err, ErrNotEncrypted)
+}
+
+func TestPublicKeyAuthenticated(t *testing.T) {
+	passphrase := []byte("passphrase")
+	data, err := Encrypt([]byte("secret"), []byte("public"), passphrase, testParams)
+	require.NoError(t, err)
+
+	f, ok := decode(data)
+	require.True(t, ok)
+	f.Public = []byte("other public")
+	tampered, err := json.Marshal(f)
+	require.NoError(t, err)
+
+	_, err = Decrypt(tampered, passphrase)
+	require.ErrorIs(t, err, ErrWrongPassphrase)
+}
+
+func TestReadWriteFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "key.bin")
+	secret := []byte("secret key")
+	passphrase := []byte("passphrase")
+
+	require.NoError(t, WriteFile(path, secret, nil, nil))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	got, encrypted, err := ReadFile(path, passphrase)
+	require.NoError(t, err)
+	require.False(t, encrypted)
+	require.Equal(t, secret, got)
+
+	require.NoError(t, WriteFile(path, secret, []byte("public"), passphrase, testParams))
+	got, encrypted, err = ReadFile(path, passphrase)
+	require.NoError(t, err)
+	require.True(t, encrypted)
+	require.Equal(t, secret, got)
+
+	_, _, err = ReadFile(path, nil)
+	require.ErrorIs(t, err, ErrPassphraseRequired)
+	_, _, err = ReadFile(path, []byte("wrong"))
+	require.ErrorIs(t, err, ErrWrongPassphrase)
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "temporary files are removed")
+}
+
+func TestPassphrase(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "passphrase")
+	require.NoError(t, os.WriteFile(file, []byte("from file\n"), 0o600))
+	t.Setenv("TEST_KEYSTORE_PASSPHRASE", "from env")
+
+	for _, tc := range []struct {
+		desc   string
+		cfg    Config
+		expect []byte
+		err    bool
+	}{
+		{desc: "file", cfg: Config{PassphraseFile: file, PassphraseEnv: "TEST_KEYSTORE_PASSPHRASE"}, expect: []byte("from file")},
+		{desc: "env", cfg: Config{PassphraseEnv: "TEST_KEYSTORE_PASSPHRASE"}, expect: []byte("from env")},
+		{desc: "empty env", cfg: Config{PassphraseEnv: "TEST_KEYSTORE_UNSET"}},
+		{desc: "missing file", cfg: Config{PassphraseFile: filepath.Join(dir, "missing")}, err: true},
+	} {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			passphrase, err := tc.cfg.Passphrase("")
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, passphrase)
+		})
+	}
+}
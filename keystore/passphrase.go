@@ -0,0 +1,72 @@
+package keystore
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// DefaultPassphraseEnv is the environment variable the passphrase is read from by default.
+const DefaultPassphraseEnv = "SPACEMESH_KEYSTORE_PASSPHRASE"
+
+// ErrNoTerminal is returned when the passphrase prompt is requested without a terminal.
+var ErrNoTerminal = errors.New("keystore: stdin is not a terminal, can't prompt for passphrase")
+
+// Config configures the source of the passphrase. Sources are checked in order:
+// file, environment variable and prompt. Keys are stored in plaintext if none of them provides a passphrase.
+type Config struct {
+	PassphraseFile string `mapstructure:"passphrase-file"`
+	PassphraseEnv  string `mapstructure:"passphrase-env"`
+	Prompt         bool   `mapstructure:"prompt"`
+}
+
+// DefaultConfig returns the default Config.
+func DefaultConfig() Config {
+	return Config{PassphraseEnv: DefaultPassphraseEnv}
+}
+
+// Passphrase returns the passphrase from the first source that provides it.
+// It returns nil if the passphrase is not configured.
+func (c Config) Passphrase(prompt string) ([]byte, error) {
+	if c.PassphraseFile != "" {
+		data, err := os.ReadFile(c.PassphraseFile)
+		if err != nil {
+			return nil, fmt.Errorf("read passphrase file: %w", err)
+		}
+		passphrase := bytes.TrimRight(data, "\r\n")
+		if len(passphrase) == 0 {
+			return nil, fmt.Errorf("passphrase file %s is empty", c.PassphraseFile)
+		}
+		return passphrase, nil
+	}
+	if c.PassphraseEnv != "" {
+		if passphrase := os.Getenv(c.PassphraseEnv); passphrase != "" {
+			return []byte(passphrase), nil
+		}
+	}
+	if c.Prompt {
+		return Prompt(prompt)
+	}
+	return nil, nil
+}
+
+// Prompt reads a passphrase from the terminal without echoing it.
+func Prompt(prompt string) ([]byte, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return nil, ErrNoTerminal
+	}
+	fmt.Fprint(os.Stderr, prompt)
+	defer fmt.Fprintln(os.Stderr)
+	passphrase, err := term.ReadPassword(fd)
+	if err != nil {
+		return nil, fmt.Errorf("read passphrase: %w", err)
+	}
+	if len(passphrase) == 0 {
+		return nil, errors.New("empty passphrase")
+	}
+	return passphrase, nil
+}
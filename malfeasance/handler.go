@@ -0,0 +1,173 @@
+// Package malfeasance validates, stores and propagates proofs that an identity is malicious.
+package malfeasance
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/events"
+	"github.com/spacemeshos/go-spacemesh/hare"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/p2p"
+	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
+	"github.com/spacemeshos/go-spacemesh/signing"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/identities"
+)
+
+var (
+	errMalformedData = errors.New("malformed data")
+	errInvalidProof  = errors.New("invalid proof")
+	errUnknownType   = errors.New("unknown malfeasance type")
+)
+
+// Handler processes MalfeasanceProof from gossip and, if deems it valid, propagates it to peers.
+type Handler struct {
+	logger log.Log
+	db     *sql.Database
+	self   p2p.Peer
+}
+
+// NewHandler creates new Handler. Proofs published by self are accepted even if they are already known,
+// as they are stored before they are published.
+func NewHandler(db *sql.Database, lg log.Log, self p2p.Peer) *Handler {
+	return &Handler{
+		logger: lg,
+		db:     db,
+		self:   self,
+	}
+}
+
+// HandleSyncedMalfeasanceProof is the sync validator for MalfeasanceProof.
+func (h *Handler) HandleSyncedMalfeasanceProof(ctx context.Context, data []byte) error {
+	_, err := h.handleProof(ctx, data)
+	return err
+}
+
+// HandleMalfeasanceProof is the gossip receiver for MalfeasanceProof.
+func (h *Handler) HandleMalfeasanceProof(ctx context.Context, peer p2p.Peer, data []byte) pubsub.ValidationResult {
+	stored, err := h.handleProof(ctx, data)
+	switch {
+	case err != nil:
+		h.logger.WithContext(ctx).With().Warning("failed to process malfeasance proof",
+			log.Stringer("sender", peer),
+			log.Err(err),
+		)
+		return pubsub.ValidationReject
+	case !stored && peer != h.self:
+		return pubsub.ValidationIgnore
+	}
+	return pubsub.ValidationAccept
+}
+
+// handleProof validates proof and stores it. Returns false if proof for the identity was already stored.
+func (h *Handler) handleProof(ctx context.Context, data []byte) (bool, error) {
+	var proof types.MalfeasanceProof
+	if err := codec.Decode(data, &proof); err != nil {
+		return false, fmt.Errorf("%w: %v", errMalformedData, err)
+	}
+	nodeID, err := Validate(&proof)
+	if err != nil {
+		return false, err
+	}
+	logger := h.logger.WithContext(ctx).WithFields(nodeID, log.Stringer("type", proof.MalfeasanceType()))
+	if _, err := identities.GetProof(h.db, nodeID.ToBytes()); err == nil {
+		logger.Debug("known malfeasance proof")
+		return false, nil
+	} else if !errors.Is(err, sql.ErrNotFound) {
+		return false, err
+	}
+	if err := h.db.WithTx(ctx, func(tx *sql.Tx) error {
+		return identities.AddProof(tx, nodeID.ToBytes(), data, time.Now())
+	}); err != nil {
+		return false, err
+	}
+	logger.With().Info("new malfeasance proof", log.Inline(&proof))
+	events.ReportMalfeasance(nodeID, &proof)
+	return true, nil
+}
+
+// Validate checks that proof consists of two different messages that are signed by the same identity
+// and are in conflict according to the type of the proof. Returns the identity that is proven malicious.
+func Validate(proof *types.MalfeasanceProof) (types.NodeID, error) {
+	if len(proof.Messages) != 2 {
+		return types.NodeID{}, fmt.Errorf("%w: expected 2 messages, got %d", errInvalidProof, len(proof.Messages))
+	}
+	first, second := proof.Messages[0], proof.Messages[1]
+	if bytes.Equal(first.Data, second.Data) {
+		return types.NodeID{}, fmt.Errorf("%w: messages are the same", errInvalidProof)
+	}
+	pub1, err := signing.ExtractPublicKey(first.Data, first.Signature)
+	if err != nil {
+		return types.NodeID{}, fmt.Errorf("%w: extract key: %v", errInvalidProof, err)
+	}
+	pub2, err := signing.ExtractPublicKey(second.Data, second.Signature)
+	if err != nil {
+		return types.NodeID{}, fmt.Errorf("%w: extract key: %v", errInvalidProof, err)
+	}
+	if !bytes.Equal(pub1, pub2) {
+		return types.NodeID{}, fmt.Errorf("%w: messages are signed by different identities", errInvalidProof)
+	}
+	if err := validateConflict(proof.MalfeasanceType(), first.Data, second.Data); err != nil {
+		return types.NodeID{}, err
+	}
+	return types.BytesToNodeID(pub1), nil
+}
+
+func validateConflict(typ types.MalfeasanceType, first, second []byte) error {
+	switch typ {
+	case types.MultipleATXs:
+		var atx1, atx2 types.InnerActivationTx
+		if err := decodePair(first, second, &atx1, &atx2); err != nil {
+			return err
+		}
+		if atx1.PublishEpoch() != atx2.PublishEpoch() {
+			return fmt.Errorf("%w: atxs are published in different epochs", errInvalidProof)
+		}
+	case types.MultipleBallots:
+		var ballot1, ballot2 types.InnerBallot
+		if err := decodePair(first, second, &ballot1, &ballot2); err != nil {
+			return err
+		}
+		if ballot1.LayerIndex != ballot2.LayerIndex {
+			return fmt.Errorf("%w: ballots are in different layers", errInvalidProof)
+		}
+	case types.HareEquivocation:
+		var msg1, msg2 hare.InnerMessage
+		if err := decodePair(first, second, &msg1, &msg2); err != nil {
+			return err
+		}
+		if msg1.InstanceID != msg2.InstanceID || msg1.K != msg2.K || msg1.Type != msg2.Type {
+			return fmt.Errorf("%w: hare messages are from different rounds", errInvalidProof)
+		}
+	case types.CertifyEquivocation:
+		var cert1, cert2 types.CertifyContent
+		if err := decodePair(first, second, &cert1, &cert2); err != nil {
+			return err
+		}
+		if cert1.LayerID != cert2.LayerID {
+			return fmt.Errorf("%w: certificates are for different layers", errInvalidProof)
+		}
+		if cert1.BlockID == cert2.BlockID {
+			return fmt.Errorf("%w: certificates are for the same block", errInvalidProof)
+		}
+	default:
+		return fmt.Errorf("%w: %s", errUnknownType, typ)
+	}
+	return nil
+}
+
+func decodePair(first, second []byte, v1, v2 codec.Decodable) error {
+	if err := codec.Decode(first, v1); err != nil {
+		return fmt.Errorf("%w: %v", errMalformedData, err)
+	}
+	if err := codec.Decode(second, v2); err != nil {
+		return fmt.Errorf("%w: %v", errMalformedData, err)
+	}
+	return nil
+}
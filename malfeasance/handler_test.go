@@ -0,0 +1,200 @@
+package malfeasance
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/hare"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/p2p"
+	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
+	"github.com/spacemeshos/go-spacemesh/signing"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/identities"
+)
+
+const layersPerEpoch = 4
+
+func TestMain(m *testing.M) {
+	types.SetLayersPerEpoch(layersPerEpoch)
+
+	res := m.Run()
+	os.Exit(res)
+}
+
+func signed(t *testing.T, signer *signing.EdSigner, msg codec.Encodable) types.SignedMessage {
+	t.Helper()
+	data, err := codec.Encode(msg)
+	require.NoError(t, err)
+	return types.NewSignedMessage(data, signer.Sign(data))
+}
+
+func TestValidate(t *testing.T) {
+	signer := signing.NewEdSigner()
+	other := signing.NewEdSigner()
+	lid := types.NewLayerID(11)
+	atx := func(lid types.LayerID, seq uint64) *types.InnerActivationTx {
+		return &types.InnerActivationTx{NIPostChallenge: types.NIPostChallenge{PubLayerID: lid, Sequence: seq}}
+	}
+	ballot := func(lid types.LayerID, atxid types.ATXID) *types.InnerBallot {
+		return &types.InnerBallot{LayerIndex: lid, AtxID: atxid}
+	}
+	hareMsg := func(lid types.LayerID, k uint32, values ...types.ProposalID) *hare.InnerMessage {
+		return &hare.InnerMessage{InstanceID: lid, K: k, Values: values}
+	}
+	certify := func(lid types.LayerID, bid types.BlockID) *types.CertifyContent {
+		return &types.CertifyContent{LayerID: lid, BlockID: bid}
+	}
+	for _, tc := range []struct {
+		desc          string
+		typ           types.MalfeasanceType
+		first, second types.SignedMessage
+		err           error
+	}{
+		{
+			desc:   "multiple atxs",
+			typ:    types.MultipleATXs,
+			first:  signed(t, signer, atx(lid, 1)),
+			second: signed(t, signer, atx(lid, 2)),
+		},
+		{
+			desc:   "atxs in different epochs",
+			typ:    types.MultipleATXs,
+			first:  signed(t, signer, atx(lid, 1)),
+			second: signed(t, signer, atx(lid.Add(100), 2)),
+			err:    errInvalidProof,
+		},
+		{
+			desc:   "multiple ballots",
+			typ:    types.MultipleBallots,
+			first:  signed(t, signer, ballot(lid, types.ATXID{1})),
+			second: signed(t, signer, ballot(lid, types.ATXID{2})),
+		},
+		{
+			desc:   "ballots in different layers",
+			typ:    types.MultipleBallots,
+			first:  signed(t, signer, ballot(lid, types.ATXID{1})),
+			second: signed(t, signer, ballot(lid.Add(1), types.ATXID{1})),
+			err:    errInvalidProof,
+		},
+		{
+			desc:   "hare equivocation",
+			typ:    types.HareEquivocation,
+			first:  signed(t, signer, hareMsg(lid, 1, types.ProposalID{1})),
+			second: signed(t, signer, hareMsg(lid, 1, types.ProposalID{2})),
+		},
+		{
+			desc:   "hare messages in different rounds",
+			typ:    types.HareEquivocation,
+			first:  signed(t, signer, hareMsg(lid, 1, types.ProposalID{1})),
+			second: signed(t, signer, hareMsg(lid, 2, types.ProposalID{2})),
+			err:    errInvalidProof,
+		},
+		{
+			desc:   "certify equivocation",
+			typ:    types.CertifyEquivocation,
+			first:  signed(t, signer, certify(lid, types.BlockID{1})),
+			second: signed(t, signer, certify(lid, types.BlockID{2})),
+		},
+		{
+			desc:   "certify same block",
+			typ:    types.CertifyEquivocation,
+			first:  signed(t, signer, &types.CertifyContent{LayerID: lid, BlockID: types.BlockID{1}, EligibilityCnt: 1}),
+			second: signed(t, signer, &types.CertifyContent{LayerID: lid, BlockID: types.BlockID{1}, EligibilityCnt: 2}),
+			err:    errInvalidProof,
+		},
+		{
+			desc:   "same message",
+			typ:    types.MultipleBallots,
+			first:  signed(t, signer, ballot(lid, types.ATXID{1})),
+			second: signed(t, signer, ballot(lid, types.ATXID{1})),
+			err:    errInvalidProof,
+		},
+		{
+			desc:   "different signers",
+			typ:    types.MultipleBallots,
+			first:  signed(t, signer, ballot(lid, types.ATXID{1})),
+			second: signed(t, other, ballot(lid, types.ATXID{2})),
+			err:    errInvalidProof,
+		},
+		{
+			desc:   "malformed message",
+			typ:    types.MultipleATXs,
+			first:  types.NewSignedMessage([]byte{1}, signer.Sign([]byte{1})),
+			second: types.NewSignedMessage([]byte{2}, signer.Sign([]byte{2})),
+			err:    errMalformedData,
+		},
+		{
+			desc:   "unknown type",
+			typ:    types.MalfeasanceType(100),
+			first:  signed(t, signer, ballot(lid, types.ATXID{1})),
+			second: signed(t, signer, ballot(lid, types.ATXID{2})),
+			err:    errUnknownType,
+		},
+	} {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			nodeID, err := Validate(types.NewMalfeasanceProof(tc.typ, tc.first, tc.second))
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, types.BytesToNodeID(signer.PublicKey().Bytes()), nodeID)
+		})
+	}
+}
+
+func TestValidate_MessagesCount(t *testing.T) {
+	signer := signing.NewEdSigner()
+	proof := types.NewMalfeasanceProof(types.MultipleBallots,
+		signed(t, signer, &types.InnerBallot{LayerIndex: types.NewLayerID(1)}),
+		signed(t, signer, &types.InnerBallot{LayerIndex: types.NewLayerID(1), AtxID: types.ATXID{1}}),
+	)
+	proof.Messages = proof.Messages[:1]
+	_, err := Validate(proof)
+	require.ErrorIs(t, err, errInvalidProof)
+}
+
+func TestHandler_HandleMalfeasanceProof(t *testing.T) {
+	var (
+		self   = p2p.Peer("self")
+		remote = p2p.Peer("remote")
+		signer = signing.NewEdSigner()
+		lid    = types.NewLayerID(11)
+	)
+	proof := types.NewMalfeasanceProof(types.MultipleBallots,
+		signed(t, signer, &types.InnerBallot{LayerIndex: lid, AtxID: types.ATXID{1}}),
+		signed(t, signer, &types.InnerBallot{LayerIndex: lid, AtxID: types.ATXID{2}}),
+	)
+	data, err := codec.Encode(proof)
+	require.NoError(t, err)
+
+	db := sql.InMemory()
+	h := NewHandler(db, logtest.New(t), self)
+	require.Equal(t, pubsub.ValidationReject, h.HandleMalfeasanceProof(context.TODO(), remote, []byte("malformed")))
+
+	require.Equal(t, pubsub.ValidationAccept, h.HandleMalfeasanceProof(context.TODO(), remote, data))
+	malicious, err := identities.IsMalicious(db, signer.PublicKey().Bytes())
+	require.NoError(t, err)
+	require.True(t, malicious)
+	stored, err := identities.GetProof(db, signer.PublicKey().Bytes())
+	require.NoError(t, err)
+	require.Equal(t, data, stored)
+
+	require.Equal(t, pubsub.ValidationIgnore, h.HandleMalfeasanceProof(context.TODO(), remote, data))
+	require.Equal(t, pubsub.ValidationAccept, h.HandleMalfeasanceProof(context.TODO(), self, data))
+	require.NoError(t, h.HandleSyncedMalfeasanceProof(context.TODO(), data))
+
+	proof.Messages[1] = signed(t, signing.NewEdSigner(), &types.InnerBallot{LayerIndex: lid})
+	invalid, err := codec.Encode(proof)
+	require.NoError(t, err)
+	require.Equal(t, pubsub.ValidationReject, h.HandleMalfeasanceProof(context.TODO(), remote, invalid))
+	require.ErrorIs(t, h.HandleSyncedMalfeasanceProof(context.TODO(), invalid), errInvalidProof)
+}
@@ -8,9 +8,11 @@ import (
 	"fmt"
 	"sort"
 	"sync"
+	"time"
 
 	"go.uber.org/atomic"
 
+	"github.com/spacemeshos/go-spacemesh/codec"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/datastore"
 	"github.com/spacemeshos/go-spacemesh/events"
@@ -619,39 +621,64 @@ func (msh *Mesh) AddTXsFromProposal(ctx context.Context, layerID types.LayerID,
 	return nil
 }
 
-// AddBallot to the mesh.
-func (msh *Mesh) AddBallot(ballot *types.Ballot) error {
+// AddBallot to the mesh. If the smesher already published a different ballot in the same layer
+// the smesher is marked as malicious and the proof of malfeasance is returned.
+func (msh *Mesh) AddBallot(ballot *types.Ballot) (*types.MalfeasanceProof, error) {
 	malicious, err := identities.IsMalicious(msh.cdb, ballot.SmesherID().Bytes())
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if malicious {
 		ballot.SetMalicious()
 	}
+	var proof *types.MalfeasanceProof
 	// ballots.Add and ballots.Count should be atomic
 	// otherwise concurrent ballots.Add from the same smesher may not be noticed
-	return msh.cdb.WithTx(context.Background(), func(tx *sql.Tx) error {
+	if err := msh.cdb.WithTx(context.Background(), func(tx *sql.Tx) error {
 		if err := ballots.Add(tx, ballot); err != nil && !errors.Is(err, sql.ErrObjectExists) {
 			return err
 		}
-		if !malicious {
-			count, err := ballots.CountByPubkeyLayer(tx, ballot.LayerIndex, ballot.SmesherID().Bytes())
+		// identity may be recorded as malicious without the proof, then the proof is still stored
+		if _, err := identities.GetProof(tx, ballot.SmesherID().Bytes()); err == nil {
+			return nil
+		} else if !errors.Is(err, sql.ErrNotFound) {
+			return err
+		}
+		prev, err := ballots.ByPubkeyLayer(tx, ballot.LayerIndex, ballot.SmesherID().Bytes())
+		if err != nil {
+			return err
+		}
+		for _, other := range prev {
+			if other.ID() == ballot.ID() {
+				continue
+			}
+			proof = types.NewMalfeasanceProof(types.MultipleBallots,
+				types.NewSignedMessage(other.SignedBytes(), other.Signature),
+				types.NewSignedMessage(ballot.SignedBytes(), ballot.Signature),
+			)
+			encoded, err := codec.Encode(proof)
 			if err != nil {
-				return err
+				msh.logger.With().Panic("failed to encode malfeasance proof", log.Err(err))
 			}
-			if count > 1 {
-				if err := identities.SetMalicious(tx, ballot.SmesherID().Bytes()); err != nil {
-					return err
-				}
-				ballot.SetMalicious()
-				msh.logger.With().Warning("smesher produced more than one ballot in the same layer",
-					log.Stringer("smesher", ballot.SmesherID()),
-					log.Inline(ballot),
-				)
+			if err := identities.AddProof(tx, ballot.SmesherID().Bytes(), encoded, time.Now()); err != nil {
+				return err
 			}
+			ballot.SetMalicious()
+			msh.logger.With().Warning("smesher produced more than one ballot in the same layer",
+				log.Stringer("smesher", ballot.SmesherID()),
+				log.Inline(ballot),
+				log.Stringer("other", other.ID()),
+			)
+			return nil
 		}
 		return nil
-	})
+	}); err != nil {
+		return nil, err
+	}
+	if proof != nil {
+		events.ReportMalfeasance(types.BytesToNodeID(ballot.SmesherID().Bytes()), proof)
+	}
+	return proof, nil
 }
 
 // AddBlockWithTXs adds the block and its TXs in into the database.
@@ -692,6 +719,11 @@ func (msh *Mesh) GetRewards(coinbase types.Address) ([]*types.Reward, error) {
 	return rewards.List(msh.cdb, coinbase)
 }
 
+// GetRewardsBySmesher retrieves rewards earned by the smesher identity.
+func (msh *Mesh) GetRewardsBySmesher(smesherID types.NodeID) ([]*types.Reward, error) {
+	return rewards.ListBySmesher(msh.cdb, smesherID)
+}
+
 // sortBlocks sort blocks tick height, if height is equal by lexicographic order.
 func sortBlocks(blks []*types.Block) []*types.Block {
 	sort.Slice(blks, func(i, j int) bool {
@@ -8,6 +8,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/spacemeshos/go-spacemesh/codec"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/common/util"
 	"github.com/spacemeshos/go-spacemesh/datastore"
@@ -17,6 +18,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/sql"
 	"github.com/spacemeshos/go-spacemesh/sql/ballots"
 	"github.com/spacemeshos/go-spacemesh/sql/blocks"
+	"github.com/spacemeshos/go-spacemesh/sql/identities"
 	"github.com/spacemeshos/go-spacemesh/sql/layers"
 	smocks "github.com/spacemeshos/go-spacemesh/system/mocks"
 	"github.com/spacemeshos/go-spacemesh/tortoise/opinionhash"
@@ -91,7 +93,9 @@ func createLayerBallots(t *testing.T, mesh *Mesh, lyrID types.LayerID) []*types.
 	for i := 0; i < numBallots; i++ {
 		ballot := types.GenLayerBallot(lyrID)
 		blts = append(blts, ballot)
-		require.NoError(t, mesh.AddBallot(ballot))
+		malProof, err := mesh.AddBallot(ballot)
+		require.NoError(t, err)
+		require.Nil(t, malProof)
 	}
 	return blts
 }
@@ -722,14 +726,56 @@ func TestMesh_MaliciousBallots(t *testing.T) {
 	pub := []byte{1, 1, 1}
 
 	blts := []types.Ballot{
-		types.NewExistingBallot(types.BallotID{1}, nil, pub, types.InnerBallot{LayerIndex: lid}),
-		types.NewExistingBallot(types.BallotID{2}, nil, pub, types.InnerBallot{LayerIndex: lid}),
-		types.NewExistingBallot(types.BallotID{3}, nil, pub, types.InnerBallot{LayerIndex: lid}),
+		types.NewExistingBallot(types.BallotID{1}, []byte{1}, pub, types.InnerBallot{LayerIndex: lid}),
+		types.NewExistingBallot(types.BallotID{2}, []byte{2}, pub, types.InnerBallot{LayerIndex: lid}),
+		types.NewExistingBallot(types.BallotID{3}, []byte{3}, pub, types.InnerBallot{LayerIndex: lid}),
 	}
-	require.NoError(t, tm.AddBallot(&blts[0]))
+	malProof, err := tm.AddBallot(&blts[0])
+	require.NoError(t, err)
+	require.Nil(t, malProof)
 	require.False(t, blts[0].IsMalicious())
-	for _, ballot := range blts[1:] {
-		require.NoError(t, tm.AddBallot(&ballot))
-		require.True(t, ballot.IsMalicious())
+
+	malProof, err = tm.AddBallot(&blts[1])
+	require.NoError(t, err)
+	require.NotNil(t, malProof)
+	require.True(t, blts[1].IsMalicious())
+	require.Equal(t, types.MultipleBallots, malProof.MalfeasanceType())
+	require.Equal(t, []types.SignedMessage{
+		types.NewSignedMessage(blts[0].SignedBytes(), blts[0].Signature),
+		types.NewSignedMessage(blts[1].SignedBytes(), blts[1].Signature),
+	}, malProof.Messages)
+	stored, err := identities.GetProof(tm.cdb, pub)
+	require.NoError(t, err)
+	encoded, err := codec.Encode(malProof)
+	require.NoError(t, err)
+	require.Equal(t, encoded, stored)
+
+	// proof is not generated again for the identity that is already known to be malicious
+	malProof, err = tm.AddBallot(&blts[2])
+	require.NoError(t, err)
+	require.Nil(t, malProof)
+	require.True(t, blts[2].IsMalicious())
+}
+
+func TestMesh_MaliciousBallots_WithoutProof(t *testing.T) {
+	tm := createTestMesh(t)
+	lid := types.NewLayerID(1)
+	pub := []byte{1, 1, 1}
+	// identity was recorded as malicious before proofs were stored
+	require.NoError(t, identities.SetMalicious(tm.cdb, pub))
+
+	blts := []types.Ballot{
+		types.NewExistingBallot(types.BallotID{1}, []byte{1}, pub, types.InnerBallot{LayerIndex: lid}),
+		types.NewExistingBallot(types.BallotID{2}, []byte{2}, pub, types.InnerBallot{LayerIndex: lid}),
 	}
+	malProof, err := tm.AddBallot(&blts[0])
+	require.NoError(t, err)
+	require.Nil(t, malProof)
+	require.True(t, blts[0].IsMalicious())
+
+	malProof, err = tm.AddBallot(&blts[1])
+	require.NoError(t, err)
+	require.NotNil(t, malProof)
+	_, err = identities.GetProof(tm.cdb, pub)
+	require.NoError(t, err)
 }
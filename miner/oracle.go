@@ -34,7 +34,7 @@ type Oracle struct {
 	layersPerEpoch uint32
 	cdb            *datastore.CachedDB
 
-	vrfSigner *signing.VRFSigner
+	vrfSigner signing.RequestSigner
 	nodeID    types.NodeID
 	log       log.Log
 
@@ -42,7 +42,7 @@ type Oracle struct {
 	cache oracleCache
 }
 
-func newMinerOracle(layerSize, layersPerEpoch uint32, cdb *datastore.CachedDB, vrfSigner *signing.VRFSigner, nodeID types.NodeID, log log.Log) *Oracle {
+func newMinerOracle(layerSize, layersPerEpoch uint32, cdb *datastore.CachedDB, vrfSigner signing.RequestSigner, nodeID types.NodeID, log log.Log) *Oracle {
 	return &Oracle{
 		avgLayerSize:   layerSize,
 		layersPerEpoch: layersPerEpoch,
@@ -159,7 +159,15 @@ func (o *Oracle) calcEligibilityProofs(weight uint64, epoch types.EpochID, beaco
 		if err != nil {
 			logger.With().Panic("failed to serialize VRF msg", log.Err(err))
 		}
-		vrfSig := o.vrfSigner.Sign(message)
+		vrfSig, err := o.vrfSigner.SignRequest(signing.Request{
+			Domain:  signing.PROPOSAL_ELIGIBILITY,
+			Epoch:   uint32(epoch),
+			Round:   counter,
+			Message: message,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("sign vrf message: %w", err)
+		}
 		eligibleLayer := proposals.CalcEligibleLayer(epoch, o.layersPerEpoch, vrfSig)
 		eligibilityProofs[eligibleLayer] = append(eligibilityProofs[eligibleLayer], types.VotingEligibilityProof{
 			J:   counter,
@@ -49,7 +49,7 @@ type ProposalBuilder struct {
 	layerTimer chan types.LayerID
 
 	publisher          pubsub.Publisher
-	signer             *signing.EdSigner
+	signer             signing.RequestSigner
 	conState           conservativeState
 	baseBallotProvider votesEncoder
 	proposalOracle     proposalOracle
@@ -120,8 +120,8 @@ func withOracle(o proposalOracle) Opt {
 func NewProposalBuilder(
 	ctx context.Context,
 	layerTimer timesync.LayerTimer,
-	signer *signing.EdSigner,
-	vrfSigner *signing.VRFSigner,
+	signer signing.RequestSigner,
+	vrfSigner signing.RequestSigner,
 	cdb *datastore.CachedDB,
 	publisher pubsub.Publisher,
 	bbp votesEncoder,
@@ -242,8 +242,24 @@ func (pb *ProposalBuilder) createProposal(
 			MeshHash: mesh,
 		},
 	}
-	p.Ballot.Signature = pb.signer.Sign(p.Ballot.SignedBytes())
-	p.Signature = pb.signer.Sign(p.Bytes())
+	p.Ballot.Signature, err = pb.signer.SignRequest(signing.Request{
+		Domain:  signing.BALLOT,
+		Layer:   layerID.Value,
+		Message: p.Ballot.SignedBytes(),
+	})
+	if err != nil {
+		logger.With().Error("failed to sign ballot", log.Err(err))
+		return nil, fmt.Errorf("sign ballot: %w", err)
+	}
+	p.Signature, err = pb.signer.SignRequest(signing.Request{
+		Domain:  signing.PROPOSAL,
+		Layer:   layerID.Value,
+		Message: p.Bytes(),
+	})
+	if err != nil {
+		logger.With().Error("failed to sign proposal", log.Err(err))
+		return nil, fmt.Errorf("sign proposal: %w", err)
+	}
 	if err := p.Initialize(); err != nil {
 		logger.Panic("proposal failed to initialize", log.Err(err))
 	}
@@ -0,0 +1,330 @@
+package p2p
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/control"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+	atomicfile "github.com/natefinch/atomic"
+
+	"github.com/spacemeshos/go-spacemesh/log"
+)
+
+const accessListFilename = "access.json"
+
+var (
+	// ErrInvalidAccessEntry is returned if the entry is neither a peer ID, nor an IP address or CIDR range.
+	ErrInvalidAccessEntry = errors.New("invalid access entry")
+	// ErrAccessEntryNotFound is returned when removing an entry that is not in the list.
+	ErrAccessEntryNotFound = errors.New("access entry not found")
+	// ErrAccessListsDisabled is returned if the host was created without the connection gater.
+	ErrAccessListsDisabled = errors.New("access lists are not enabled")
+)
+
+// AccessList is one of the lists that are used to gate connections.
+type AccessList uint8
+
+const (
+	// Allowlist is a list of peers that are always allowed to connect,
+	// and in the allowlist-only mode the only peers that are allowed to connect.
+	Allowlist AccessList = iota + 1
+	// Denylist is a list of peers that are never allowed to connect. It takes precedence over the Allowlist.
+	Denylist
+)
+
+func (l AccessList) String() string {
+	switch l {
+	case Allowlist:
+		return "allow"
+	case Denylist:
+		return "deny"
+	default:
+		return "unknown"
+	}
+}
+
+// accessEntry is either a peer ID or a range of IP addresses.
+type accessEntry struct {
+	peer peer.ID
+	net  *net.IPNet
+}
+
+// parseAccessEntry parses peer ID, IP address or CIDR range and returns the entry with its canonical form.
+func parseAccessEntry(raw string) (accessEntry, string, error) {
+	raw = strings.TrimSpace(raw)
+	if strings.Contains(raw, "/") {
+		_, ipnet, err := net.ParseCIDR(raw)
+		if err != nil {
+			return accessEntry{}, "", fmt.Errorf("%w: %s", ErrInvalidAccessEntry, raw)
+		}
+		return accessEntry{net: ipnet}, ipnet.String(), nil
+	}
+	if ip := net.ParseIP(raw); ip != nil {
+		bits := 8 * net.IPv6len
+		if ip4 := ip.To4(); ip4 != nil {
+			ip = ip4
+			bits = 8 * net.IPv4len
+		}
+		ipnet := &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}
+		return accessEntry{net: ipnet}, ipnet.String(), nil
+	}
+	pid, err := peer.Decode(raw)
+	if err != nil {
+		return accessEntry{}, "", fmt.Errorf("%w: %s", ErrInvalidAccessEntry, raw)
+	}
+	return accessEntry{peer: pid}, pid.String(), nil
+}
+
+type accessEntries map[string]accessEntry
+
+func (e accessEntries) hasPeer(pid peer.ID) bool {
+	_, exist := e[pid.String()]
+	return exist
+}
+
+func (e accessEntries) hasIP(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	for _, entry := range e {
+		if entry.net != nil && entry.net.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e accessEntries) hasNets() bool {
+	for _, entry := range e {
+		if entry.net != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (e accessEntries) sorted() []string {
+	rst := make([]string, 0, len(e))
+	for raw := range e {
+		rst = append(rst, raw)
+	}
+	sort.Strings(rst)
+	return rst
+}
+
+type serializedAccessLists struct {
+	Allow []string `json:"allow"`
+	Deny  []string `json:"deny"`
+}
+
+// Gater gates connections using persistent allowlist and denylist of peer IDs and CIDR ranges.
+// Lists are stored in the data directory and can be edited when the node is not running.
+type Gater struct {
+	logger        log.Log
+	path          string
+	allowlistOnly bool
+
+	mu    sync.RWMutex
+	lists map[AccessList]accessEntries
+}
+
+// NewGater loads access lists from the directory. Lists are not persisted if the directory is empty.
+// In allowlist-only mode connections are allowed only with peers that are in the allowlist.
+func NewGater(logger log.Log, dir string, allowlistOnly bool) (*Gater, error) {
+	g := &Gater{
+		logger:        logger,
+		allowlistOnly: allowlistOnly,
+		lists: map[AccessList]accessEntries{
+			Allowlist: {},
+			Denylist:  {},
+		},
+	}
+	if len(dir) == 0 {
+		return g, nil
+	}
+	g.path = filepath.Join(dir, accessListFilename)
+	data, err := os.ReadFile(g.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return g, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("read access lists from %s: %w", g.path, err)
+	}
+	var serialized serializedAccessLists
+	if err := json.Unmarshal(data, &serialized); err != nil {
+		return nil, fmt.Errorf("unmarshal access lists from %s: %w", g.path, err)
+	}
+	for list, entries := range map[AccessList][]string{
+		Allowlist: serialized.Allow,
+		Denylist:  serialized.Deny,
+	} {
+		for _, raw := range entries {
+			entry, canonical, err := parseAccessEntry(raw)
+			if err != nil {
+				return nil, fmt.Errorf("load %s list from %s: %w", list, g.path, err)
+			}
+			g.lists[list][canonical] = entry
+		}
+	}
+	logger.With().Info("loaded access lists",
+		log.String("path", g.path),
+		log.Int("allowlist", len(g.lists[Allowlist])),
+		log.Int("denylist", len(g.lists[Denylist])),
+		log.Bool("allowlist_only", allowlistOnly),
+	)
+	return g, nil
+}
+
+// AllowlistOnly returns true if only peers from the allowlist are allowed to connect.
+func (g *Gater) AllowlistOnly() bool {
+	return g.allowlistOnly
+}
+
+// Add adds the entry to the list and persists the lists. Adding an existing entry has no effect.
+func (g *Gater) Add(list AccessList, raw string) error {
+	entry, canonical, err := parseAccessEntry(raw)
+	if err != nil {
+		return err
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	entries, exist := g.lists[list]
+	if !exist {
+		return fmt.Errorf("unknown access list %d", list)
+	}
+	if _, exist := entries[canonical]; exist {
+		return nil
+	}
+	entries[canonical] = entry
+	if err := g.persist(); err != nil {
+		delete(entries, canonical)
+		return err
+	}
+	return nil
+}
+
+// Remove removes the entry from the list and persists the lists.
+func (g *Gater) Remove(list AccessList, raw string) error {
+	_, canonical, err := parseAccessEntry(raw)
+	if err != nil {
+		return err
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	entries, exist := g.lists[list]
+	if !exist {
+		return fmt.Errorf("unknown access list %d", list)
+	}
+	entry, exist := entries[canonical]
+	if !exist {
+		return fmt.Errorf("%w: %s in %s list", ErrAccessEntryNotFound, canonical, list)
+	}
+	delete(entries, canonical)
+	if err := g.persist(); err != nil {
+		entries[canonical] = entry
+		return err
+	}
+	return nil
+}
+
+// Entries returns sorted entries of the list.
+func (g *Gater) Entries(list AccessList) []string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.lists[list].sorted()
+}
+
+// Allowed returns true if the connection with the peer on the address is allowed.
+func (g *Gater) Allowed(pid peer.ID, addr ma.Multiaddr) bool {
+	ip := toIP(addr)
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	deny := g.lists[Denylist]
+	if deny.hasPeer(pid) || deny.hasIP(ip) {
+		return false
+	}
+	if !g.allowlistOnly {
+		return true
+	}
+	allow := g.lists[Allowlist]
+	return allow.hasPeer(pid) || allow.hasIP(ip)
+}
+
+// InterceptPeerDial checks that dialing the peer is allowed.
+// In allowlist-only mode the peer can be allowed by address, which is checked by InterceptAddrDial.
+func (g *Gater) InterceptPeerDial(pid peer.ID) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if g.lists[Denylist].hasPeer(pid) {
+		return false
+	}
+	if !g.allowlistOnly {
+		return true
+	}
+	allow := g.lists[Allowlist]
+	return allow.hasPeer(pid) || allow.hasNets()
+}
+
+// InterceptAddrDial checks that dialing the peer on the address is allowed.
+func (g *Gater) InterceptAddrDial(pid peer.ID, addr ma.Multiaddr) bool {
+	return g.Allowed(pid, addr)
+}
+
+// InterceptAccept checks that the remote address of the inbound connection is not denied.
+// The peer ID is not known at this stage, and is checked by InterceptSecured.
+func (g *Gater) InterceptAccept(addrs network.ConnMultiaddrs) bool {
+	ip := toIP(addrs.RemoteMultiaddr())
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return !g.lists[Denylist].hasIP(ip)
+}
+
+// InterceptSecured checks that the connection with the authenticated peer is allowed.
+func (g *Gater) InterceptSecured(_ network.Direction, pid peer.ID, addrs network.ConnMultiaddrs) bool {
+	return g.Allowed(pid, addrs.RemoteMultiaddr())
+}
+
+// InterceptUpgraded allows all upgraded connections, as they were already checked.
+func (g *Gater) InterceptUpgraded(network.Conn) (bool, control.DisconnectReason) {
+	return true, 0
+}
+
+// persist writes lists to the file. Must be called with the lock held.
+func (g *Gater) persist() error {
+	if len(g.path) == 0 {
+		return nil
+	}
+	data, err := json.MarshalIndent(serializedAccessLists{
+		Allow: g.lists[Allowlist].sorted(),
+		Deny:  g.lists[Denylist].sorted(),
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal access lists: %w", err)
+	}
+	if err := atomicfile.WriteFile(g.path, bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("write access lists to %s: %w", g.path, err)
+	}
+	return nil
+}
+
+func toIP(addr ma.Multiaddr) net.IP {
+	if addr == nil {
+		return nil
+	}
+	ip, err := manet.ToIP(addr)
+	if err != nil {
+		return nil
+	}
+	return ip
+}
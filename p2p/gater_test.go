@@ -0,0 +1,128 @@
+package p2p
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+)
+
+const testPeer = "12D3KooWDS4mbE2Cqysjf6GBMtWnhcaoBYC6M3FNkTeZqCNFCNkf"
+
+func TestParseAccessEntry(t *testing.T) {
+	for _, tc := range []struct {
+		desc, raw, canonical string
+		err                  error
+	}{
+		{desc: "peer", raw: testPeer, canonical: testPeer},
+		{desc: "ipv4", raw: "10.0.0.1", canonical: "10.0.0.1/32"},
+		{desc: "ipv6", raw: "::1", canonical: "::1/128"},
+		{desc: "cidr", raw: " 10.1.2.3/16", canonical: "10.1.0.0/16"},
+		{desc: "invalid cidr", raw: "10.0.0.1/33", err: ErrInvalidAccessEntry},
+		{desc: "invalid", raw: "peer", err: ErrInvalidAccessEntry},
+	} {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			_, canonical, err := parseAccessEntry(tc.raw)
+			require.ErrorIs(t, err, tc.err)
+			require.Equal(t, tc.canonical, canonical)
+		})
+	}
+}
+
+func TestGater(t *testing.T) {
+	pid, err := peer.Decode(testPeer)
+	require.NoError(t, err)
+	other := peer.ID("other")
+	addr := ma.StringCast("/ip4/10.0.0.1/tcp/7513")
+	otherAddr := ma.StringCast("/ip4/192.168.0.1/tcp/7513")
+
+	t.Run("denylist", func(t *testing.T) {
+		g, err := NewGater(logtest.New(t), "", false)
+		require.NoError(t, err)
+		require.True(t, g.Allowed(pid, addr))
+
+		require.NoError(t, g.Add(Denylist, testPeer))
+		require.False(t, g.Allowed(pid, otherAddr))
+		require.False(t, g.InterceptPeerDial(pid))
+		require.True(t, g.Allowed(other, addr))
+
+		require.NoError(t, g.Add(Denylist, "10.0.0.0/8"))
+		require.False(t, g.Allowed(other, addr))
+		require.True(t, g.Allowed(other, otherAddr))
+
+		// denylist takes precedence
+		require.NoError(t, g.Add(Allowlist, testPeer))
+		require.False(t, g.Allowed(pid, otherAddr))
+
+		require.NoError(t, g.Remove(Denylist, testPeer))
+		require.True(t, g.Allowed(pid, otherAddr))
+		require.ErrorIs(t, g.Remove(Denylist, testPeer), ErrAccessEntryNotFound)
+	})
+	t.Run("allowlist only", func(t *testing.T) {
+		g, err := NewGater(logtest.New(t), "", true)
+		require.NoError(t, err)
+		require.False(t, g.Allowed(pid, addr))
+		require.False(t, g.InterceptPeerDial(pid))
+
+		require.NoError(t, g.Add(Allowlist, testPeer))
+		require.True(t, g.Allowed(pid, otherAddr))
+		require.True(t, g.InterceptPeerDial(pid))
+		require.False(t, g.InterceptPeerDial(other))
+
+		require.NoError(t, g.Add(Allowlist, "10.0.0.1"))
+		require.True(t, g.InterceptPeerDial(other))
+		require.True(t, g.Allowed(other, addr))
+		require.False(t, g.Allowed(other, otherAddr))
+	})
+	t.Run("persisted", func(t *testing.T) {
+		dir := t.TempDir()
+		g, err := NewGater(logtest.New(t), dir, false)
+		require.NoError(t, err)
+		require.NoError(t, g.Add(Allowlist, "10.0.0.1"))
+		require.NoError(t, g.Add(Denylist, testPeer))
+		require.NoError(t, g.Add(Denylist, "192.168.0.0/24"))
+		require.NoError(t, g.Remove(Denylist, "192.168.0.0/24"))
+
+		g, err = NewGater(logtest.New(t), dir, false)
+		require.NoError(t, err)
+		require.Equal(t, []string{"10.0.0.1/32"}, g.Entries(Allowlist))
+		require.Equal(t, []string{testPeer}, g.Entries(Denylist))
+	})
+	t.Run("corrupted", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, accessListFilename), []byte(`{"deny": ["peer"]}`), 0o600))
+		_, err := NewGater(logtest.New(t), dir, false)
+		require.ErrorIs(t, err, ErrInvalidAccessEntry)
+	})
+}
+
+func TestHost_AccessEntries(t *testing.T) {
+	mesh, err := mocknet.FullMeshConnected(3)
+	require.NoError(t, err)
+	hosts := mesh.Hosts()
+	g, err := NewGater(logtest.New(t), "", false)
+	require.NoError(t, err)
+	fh := &Host{Host: hosts[0], logger: logtest.New(t), gater: g}
+
+	require.NoError(t, fh.AddAccessEntry(Denylist, hosts[1].ID().String()))
+	require.Equal(t, network.NotConnected, fh.Network().Connectedness(hosts[1].ID()))
+	require.Equal(t, network.Connected, fh.Network().Connectedness(hosts[2].ID()))
+	entries, err := fh.AccessEntries(Denylist)
+	require.NoError(t, err)
+	require.Equal(t, []string{hosts[1].ID().String()}, entries)
+
+	require.ErrorIs(t, fh.AddAccessEntry(Denylist, "peer"), ErrInvalidAccessEntry)
+	require.NoError(t, fh.RemoveAccessEntry(Denylist, hosts[1].ID().String()))
+
+	disabled := &Host{Host: hosts[0], logger: logtest.New(t)}
+	require.ErrorIs(t, disabled.AddAccessEntry(Denylist, hosts[1].ID().String()), ErrAccessListsDisabled)
+	require.False(t, disabled.AllowlistOnly())
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/log"
 	p2pmetrics "github.com/spacemeshos/go-spacemesh/p2p/metrics"
 	"github.com/spacemeshos/go-spacemesh/p2p/peerexchange"
+	"github.com/spacemeshos/go-spacemesh/p2p/reputation"
 )
 
 // DefaultConfig config.
@@ -36,6 +37,7 @@ func DefaultConfig() Config {
 		CheckPeersNumber:     10,
 		CheckPeersUsedBefore: 30 * time.Minute,
 		peerExchange:         peerexchange.DefaultPeerExchangeConfig(),
+		Reputation:           reputation.DefaultConfig(),
 	}
 }
 
@@ -62,12 +64,21 @@ type Config struct {
 	CheckPeersUsedBefore time.Duration
 
 	peerExchange peerexchange.PeerExchangeConfig `mapstructure:"peer-exchange"`
+
+	Reputation reputation.Config `mapstructure:"reputation"`
+
+	// AllowlistOnly allows connections only with peers from the allowlist, for example on a node behind sentries.
+	AllowlistOnly bool `mapstructure:"allowlist-only"`
 }
 
 // New initializes libp2p host configured for spacemesh.
 func New(_ context.Context, logger log.Log, cfg Config, genesisID types.Hash20, opts ...Opt) (*Host, error) {
 	logger.Info("starting libp2p host with config %+v", cfg)
-	key, err := EnsureIdentity(cfg.DataDir)
+	scratch := &Host{}
+	for _, opt := range opts {
+		opt(scratch)
+	}
+	key, err := EnsureIdentity(cfg.DataDir, scratch.identityOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -88,6 +99,10 @@ func New(_ context.Context, logger log.Log, cfg Config, genesisID types.Hash20,
 		}
 		cm.Protect(addr.ID, peerexchange.BootNodeTag)
 	}
+	gater, err := NewGater(logger, cfg.DataDir, cfg.AllowlistOnly)
+	if err != nil {
+		return nil, err
+	}
 	streamer := *yamux.DefaultTransport
 	ps, err := pstoremem.NewPeerstore()
 	if err != nil {
@@ -104,6 +119,7 @@ func New(_ context.Context, logger log.Log, cfg Config, genesisID types.Hash20,
 		libp2p.Muxer("/yamux/1.0.0", &streamer),
 
 		libp2p.ConnectionManager(cm),
+		libp2p.ConnectionGater(gater),
 		libp2p.Peerstore(ps),
 		libp2p.BandwidthReporter(p2pmetrics.NewBandwidthCollector()),
 	}
@@ -121,6 +137,6 @@ func New(_ context.Context, logger log.Log, cfg Config, genesisID types.Hash20,
 	)
 	// TODO(dshulyak) this is small mess. refactor to avoid this patching
 	// both New and Upgrade should use options.
-	opts = append(opts, WithConfig(cfg), WithLog(logger))
+	opts = append(opts, WithConfig(cfg), WithLog(logger), withGater(gater))
 	return Upgrade(h, genesisID, opts...)
 }
@@ -10,6 +10,8 @@ import (
 
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/spacemeshos/go-spacemesh/keystore"
 )
 
 const keyFilename = "p2p.key"
@@ -27,38 +29,85 @@ func genIdentity() (crypto.PrivKey, error) {
 	return pk, nil
 }
 
-func identityInfoFromDir(dir string) (*identityInfo, error) {
+// IdentityOpt configures storage of the identity key.
+type IdentityOpt func(*identityOpts)
+
+type identityOpts struct {
+	passphrase []byte
+	keystore   []keystore.Opt
+}
+
+// WithPassphrase encrypts the identity key with the passphrase.
+// Identity keys that are stored in plaintext are encrypted when loaded.
+func WithPassphrase(passphrase []byte, opts ...keystore.Opt) IdentityOpt {
+	return func(o *identityOpts) {
+		o.passphrase = passphrase
+		o.keystore = opts
+	}
+}
+
+func identityInfoFromDir(dir string, passphrase []byte) (*identityInfo, bool, error) {
 	path := filepath.Join(dir, keyFilename)
-	data, err := os.ReadFile(path)
+	data, encrypted, err := keystore.ReadFile(path, passphrase)
 	if err != nil {
-		return nil, fmt.Errorf("read file %s: %w", path, err)
+		return nil, encrypted, fmt.Errorf("read file %s: %w", path, err)
 	}
 	var info identityInfo
 	err = json.Unmarshal(data, &info)
 	if err != nil {
-		return nil, fmt.Errorf("unmarshal file content from %s into %+v: %w", path, info, err)
+		return nil, encrypted, fmt.Errorf("unmarshal file content from %s: %w", path, err)
 	}
-	return &info, nil
+	return &info, encrypted, nil
 }
 
 // PrettyIdentityInfoFromDir returns a printable ID from a given identity directory.
+// The ID of an encrypted identity is read without the passphrase.
 func PrettyIdentityInfoFromDir(dir string) (string, error) {
-	identityInfo, err := identityInfoFromDir(dir)
-	return identityInfo.ID.Pretty(), err
+	path := filepath.Join(dir, keyFilename)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("read file %s: %w", path, err)
+	}
+	if keystore.IsEncrypted(data) {
+		public, err := keystore.PublicKey(data)
+		if err != nil {
+			return "", err
+		}
+		id, err := peer.IDFromBytes(public)
+		if err != nil {
+			return "", fmt.Errorf("parse identity from %s: %w", path, err)
+		}
+		return id.Pretty(), nil
+	}
+	var info identityInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return "", fmt.Errorf("unmarshal file content from %s: %w", path, err)
+	}
+	return info.ID.Pretty(), nil
 }
 
 // EnsureIdentity generates an identity key file in given directory.
-func EnsureIdentity(dir string) (crypto.PrivKey, error) {
+func EnsureIdentity(dir string, opts ...IdentityOpt) (crypto.PrivKey, error) {
+	options := &identityOpts{}
+	for _, opt := range opts {
+		opt(options)
+	}
 	// TODO add crc check
 	if err := os.MkdirAll(dir, 0o755); err != nil {
 		return nil, fmt.Errorf("ensure that directory %s exist: %w", dir, err)
 	}
-	info, err := identityInfoFromDir(dir)
+	path := filepath.Join(dir, keyFilename)
+	info, encrypted, err := identityInfoFromDir(dir, options.passphrase)
 	if err == nil {
 		pk, err := crypto.UnmarshalPrivateKey(info.Key)
 		if err != nil {
 			return nil, fmt.Errorf("unmarshal privkey: %w", err)
 		}
+		if !encrypted && len(options.passphrase) > 0 {
+			if err := writeIdentity(path, info, options); err != nil {
+				return nil, fmt.Errorf("encrypt identity: %w", err)
+			}
+		}
 		return pk, nil
 	}
 	if errors.Is(err, os.ErrNotExist) {
@@ -74,17 +123,18 @@ func EnsureIdentity(dir string) (crypto.PrivKey, error) {
 		if err != nil {
 			panic("generated key can't be marshaled to bytes")
 		}
-		data, err := json.Marshal(identityInfo{
-			Key: raw,
-			ID:  id,
-		})
-		if err != nil {
-			return nil, err
-		}
-		if err := os.WriteFile(filepath.Join(dir, keyFilename), data, 0o644); err != nil {
+		if err := writeIdentity(path, &identityInfo{Key: raw, ID: id}, options); err != nil {
 			return nil, fmt.Errorf("write identity data: %w", err)
 		}
 		return key, nil
 	}
 	return nil, fmt.Errorf("read key from disk: %w", err)
 }
+
+func writeIdentity(path string, info *identityInfo, options *identityOpts) error {
+	data, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	return keystore.WriteFile(path, data, []byte(info.ID), options.passphrase, options.keystore...)
+}
@@ -1,11 +1,15 @@
 package p2p
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/keystore"
 )
 
 func TestPersistedIdentity(t *testing.T) {
@@ -13,7 +17,7 @@ func TestPersistedIdentity(t *testing.T) {
 	_, err := EnsureIdentity(dir)
 	require.NoError(t, err)
 
-	info, err := identityInfoFromDir(dir)
+	info, _, err := identityInfoFromDir(dir, nil)
 	require.NoError(t, err)
 
 	key, err := crypto.UnmarshalPrivateKey(info.Key)
@@ -22,3 +26,35 @@ func TestPersistedIdentity(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, id, info.ID)
 }
+
+func TestEncryptedIdentity(t *testing.T) {
+	dir := t.TempDir()
+	plain, err := EnsureIdentity(dir)
+	require.NoError(t, err)
+	id, err := PrettyIdentityInfoFromDir(dir)
+	require.NoError(t, err)
+
+	passphrase := []byte("passphrase")
+	opt := WithPassphrase(passphrase, keystore.WithScryptParams(1<<10, 8, 1))
+
+	// plaintext identity is encrypted when loaded with a passphrase
+	key, err := EnsureIdentity(dir, opt)
+	require.NoError(t, err)
+	require.True(t, plain.Equals(key))
+	data, err := os.ReadFile(filepath.Join(dir, keyFilename))
+	require.NoError(t, err)
+	require.True(t, keystore.IsEncrypted(data))
+
+	_, err = EnsureIdentity(dir)
+	require.ErrorIs(t, err, keystore.ErrPassphraseRequired)
+	_, err = EnsureIdentity(dir, WithPassphrase([]byte("wrong")))
+	require.ErrorIs(t, err, keystore.ErrWrongPassphrase)
+
+	key, err = EnsureIdentity(dir, opt)
+	require.NoError(t, err)
+	require.True(t, plain.Equals(key))
+
+	encryptedID, err := PrettyIdentityInfoFromDir(dir)
+	require.NoError(t, err)
+	require.Equal(t, id, encryptedID)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/hash"
 	"github.com/spacemeshos/go-spacemesh/log"
 	p2pmetrics "github.com/spacemeshos/go-spacemesh/p2p/metrics"
+	"github.com/spacemeshos/go-spacemesh/p2p/reputation"
 )
 
 func init() {
@@ -50,6 +51,10 @@ const (
 	// may select more peers with score above the median to opportunistically graft on the mesh.
 	OpportunisticGraftScoreThreshold = 3.5
 
+	// appSpecificWeight scales the score of the peer reputation. With the default reputation config
+	// a banned peer is below the publish threshold, and is not a candidate for the mesh.
+	appSpecificWeight = 10
+
 	// AtxProtocol is the protocol id for ATXs.
 	AtxProtocol = "ax1"
 	// PoetProofProtocol is the protocol id for PoetProof.
@@ -65,6 +70,9 @@ const (
 	// BlockCertify is the protocol id for block certification.
 	BlockCertify = "bc1"
 
+	// MalfeasanceProtocol is the protocol id for proofs of malfeasance.
+	MalfeasanceProtocol = "mp1"
+
 	// BeaconWeakCoinProtocol is the protocol id for beacon weak coin.
 	BeaconWeakCoinProtocol = "bw1"
 	// BeaconProposalProtocol is the protocol id for beacon proposals.
@@ -86,6 +94,8 @@ type Config struct {
 	Flood          bool
 	IsBootnode     bool
 	MaxMessageSize int
+	// Reputation of peers that is used as an application-specific score. Optional.
+	Reputation *reputation.Reputation
 }
 
 // New creates PubSub instance.
@@ -97,9 +107,10 @@ func New(ctx context.Context, logger log.Log, h host.Host, cfg Config) (*PubSub,
 		return nil, fmt.Errorf("failed to initialize gossipsub instance: %w", err)
 	}
 	return &PubSub{
-		logger: logger,
-		pubsub: ps,
-		topics: map[string]*pubsub.Topic{},
+		logger:     logger,
+		pubsub:     ps,
+		reputation: cfg.Reputation,
+		topics:     map[string]*pubsub.Topic{},
 	}, nil
 }
 
@@ -171,12 +182,8 @@ func getOptions(cfg Config) []pubsub.Option {
 		pubsub.WithRawTracer(p2pmetrics.NewGoSIPCollector()),
 		pubsub.WithPeerScore(
 			&pubsub.PeerScoreParams{
-				AppSpecificScore: func(p peer.ID) float64 {
-					// TODO: add application specific score to provide feedback to the pubsub system
-					//       based on observed behavior
-					return 0
-				},
-				AppSpecificWeight: 1,
+				AppSpecificScore:  cfg.Reputation.AppSpecificScore,
+				AppSpecificWeight: appSpecificWeight,
 
 				// TODO: consider setting IP co-location threshold before applying penalties
 
@@ -192,11 +199,18 @@ func getOptions(cfg Config) []pubsub.Option {
 				RetainScore: 6 * time.Hour,
 
 				Topics: map[string]*pubsub.TopicScoreParams{
-					AtxProtocol:       defaultTopicParam(),
-					PoetProofProtocol: defaultTopicParam(),
-					ProposalProtocol:  defaultTopicParam(),
+					AtxProtocol:                  defaultTopicParam(),
+					PoetProofProtocol:            defaultTopicParam(),
+					ProposalProtocol:             defaultTopicParam(),
+					MalfeasanceProtocol:          defaultTopicParam(),
+					BeaconProposalProtocol:       defaultTopicParam(),
+					TxProtocol:                   highVolumeTopicParam(),
+					HareProtocol:                 highVolumeTopicParam(),
+					BlockCertify:                 highVolumeTopicParam(),
+					BeaconWeakCoinProtocol:       highVolumeTopicParam(),
+					BeaconFirstVotesProtocol:     highVolumeTopicParam(),
+					BeaconFollowingVotesProtocol: highVolumeTopicParam(),
 				},
-				// TODO: add TopicScoreParams
 			},
 			&pubsub.PeerScoreThresholds{
 				GossipThreshold:             GossipScoreThreshold,
@@ -252,6 +266,17 @@ func defaultTopicParam() *pubsub.TopicScoreParams {
 	}
 }
 
+// highVolumeTopicParam is for topics with many messages from every peer, such as transactions
+// and votes. Rewards for deliveries are lower and decay faster, so that a single topic doesn't
+// dominate the score, but invalid messages are penalized the same as on other topics.
+func highVolumeTopicParam() *pubsub.TopicScoreParams {
+	params := defaultTopicParam()
+	params.FirstMessageDeliveriesWeight = 0.5
+	params.FirstMessageDeliveriesDecay = pubsub.ScoreParameterDecay(10 * time.Minute)
+	params.FirstMessageDeliveriesCap = 1000
+	return params
+}
+
 func castResult(rst ValidationResult) string {
 	switch rst {
 	case ValidationAccept:
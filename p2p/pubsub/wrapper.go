@@ -11,12 +11,16 @@ import (
 
 	"github.com/spacemeshos/go-spacemesh/log"
 	"github.com/spacemeshos/go-spacemesh/p2p/metrics"
+	"github.com/spacemeshos/go-spacemesh/p2p/reputation"
 )
 
 // PubSub is a spacemesh-specific wrapper around gossip protocol.
 type PubSub struct {
 	logger log.Log
 	pubsub *pubsub.PubSub
+	// reputation is updated with the results of validation. Ignored messages are not reported,
+	// as they are usually valid but outdated.
+	reputation *reputation.Reputation
 
 	mu     sync.RWMutex
 	topics map[string]*pubsub.Topic
@@ -34,6 +38,12 @@ func (ps *PubSub) Register(topic string, handler GossipHandler) {
 		rst := handler(log.WithNewRequestID(ctx), pid, msg.Data)
 		metrics.ProcessedMessagesDuration.WithLabelValues(topic, castResult(rst)).
 			Observe(float64(time.Since(start)))
+		switch rst {
+		case ValidationAccept:
+			ps.reputation.Report(pid, reputation.Valid)
+		case ValidationReject:
+			ps.reputation.Report(pid, reputation.Invalid)
+		}
 		return rst
 	})
 	topich, err := ps.pubsub.Join(topic)
@@ -0,0 +1,22 @@
+package reputation
+
+import (
+	"github.com/spacemeshos/go-spacemesh/metrics"
+)
+
+const subsystem = "reputation"
+
+var (
+	reportsCounter = metrics.NewCounter(
+		"reports",
+		subsystem,
+		"Number of reported peer behaviours",
+		[]string{"behaviour"},
+	)
+	bansCounter = metrics.NewCounter(
+		"bans",
+		subsystem,
+		"Number of banned peers",
+		[]string{},
+	).WithLabelValues()
+)
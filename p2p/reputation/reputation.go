@@ -0,0 +1,250 @@
+// Package reputation keeps application-level scores of peers. Components that validate data
+// received from peers report good and bad behaviour, scores are fed into gossipsub peer scoring
+// and peers with a score below the ban threshold are banned temporarily.
+package reputation
+
+import (
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/spacemeshos/go-spacemesh/log"
+)
+
+// Behaviour is a behaviour of a peer that is observed by the node.
+type Behaviour uint8
+
+const (
+	// Valid is reported when a peer delivers a valid message or response.
+	Valid Behaviour = iota + 1
+	// Invalid is reported when a message of a peer fails validation.
+	Invalid
+	// Malformed is reported when a message of a peer can't be decoded.
+	Malformed
+	// WrongHash is reported when a peer responds with data that doesn't match the requested hash.
+	WrongHash
+)
+
+func (b Behaviour) String() string {
+	switch b {
+	case Valid:
+		return "valid"
+	case Invalid:
+		return "invalid"
+	case Malformed:
+		return "malformed"
+	case WrongHash:
+		return "wrong_hash"
+	default:
+		return "unknown"
+	}
+}
+
+// Config for the Reputation.
+type Config struct {
+	// ValidReward is added to the score for every Valid report.
+	ValidReward float64 `mapstructure:"valid-reward"`
+	// InvalidPenalty is subtracted from the score for every Invalid report.
+	InvalidPenalty float64 `mapstructure:"invalid-penalty"`
+	// MalformedPenalty is subtracted from the score for every Malformed or WrongHash report.
+	MalformedPenalty float64 `mapstructure:"malformed-penalty"`
+	// MaxScore caps the score, so that good behaviour in the past doesn't outweigh bad behaviour.
+	MaxScore float64 `mapstructure:"max-score"`
+	// BanThreshold is the score at or below which the peer is banned.
+	BanThreshold float64 `mapstructure:"ban-threshold"`
+	// BanDuration is for how long the peer is banned.
+	BanDuration time.Duration `mapstructure:"ban-duration"`
+	// HalfLife is the time for the score to decay by half towards zero.
+	HalfLife time.Duration `mapstructure:"half-life"`
+}
+
+// DefaultConfig returns the default Config.
+func DefaultConfig() Config {
+	return Config{
+		ValidReward:      1,
+		InvalidPenalty:   20,
+		MalformedPenalty: 50,
+		MaxScore:         100,
+		BanThreshold:     -100,
+		BanDuration:      time.Hour,
+		HalfLife:         10 * time.Minute,
+	}
+}
+
+// Opt configures Reputation.
+type Opt func(*Reputation)
+
+// WithLog configures logger for Reputation.
+func WithLog(logger log.Log) Opt {
+	return func(r *Reputation) {
+		r.logger = logger
+	}
+}
+
+// WithBanHandler sets a function that is called, without locks held, when a peer is banned.
+func WithBanHandler(handler func(peer.ID)) Opt {
+	return func(r *Reputation) {
+		r.onBan = handler
+	}
+}
+
+func withClock(now func() time.Time) Opt {
+	return func(r *Reputation) {
+		r.now = now
+	}
+}
+
+type record struct {
+	score       float64
+	updated     time.Time
+	bannedUntil time.Time
+}
+
+// Reputation keeps scores of peers. Scores decay towards zero, so that peers recover from past behaviour.
+// A nil Reputation ignores reports.
+type Reputation struct {
+	cfg    Config
+	logger log.Log
+	onBan  func(peer.ID)
+	now    func() time.Time
+
+	mu          sync.Mutex
+	peers       map[peer.ID]*record
+	lastCleanup time.Time
+}
+
+// New creates Reputation.
+func New(cfg Config, opts ...Opt) *Reputation {
+	r := &Reputation{
+		cfg:    cfg,
+		logger: log.NewNop(),
+		now:    time.Now,
+		peers:  map[peer.ID]*record{},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	r.lastCleanup = r.now()
+	return r
+}
+
+// Report updates the score of the peer with the observed behaviour.
+func (r *Reputation) Report(pid peer.ID, behaviour Behaviour) {
+	if r == nil || pid == "" {
+		return
+	}
+	reportsCounter.WithLabelValues(behaviour.String()).Inc()
+	var delta float64
+	switch behaviour {
+	case Valid:
+		delta = r.cfg.ValidReward
+	case Invalid:
+		delta = -r.cfg.InvalidPenalty
+	case Malformed, WrongHash:
+		delta = -r.cfg.MalformedPenalty
+	default:
+		return
+	}
+
+	r.mu.Lock()
+	now := r.now()
+	r.cleanup(now)
+	rec := r.peers[pid]
+	if rec == nil {
+		rec = &record{updated: now}
+		r.peers[pid] = rec
+	}
+	r.decay(rec, now)
+	rec.score = math.Min(rec.score+delta, r.cfg.MaxScore)
+	banned := rec.score <= r.cfg.BanThreshold && !now.Before(rec.bannedUntil)
+	if banned {
+		rec.bannedUntil = now.Add(r.cfg.BanDuration)
+	}
+	score := rec.score
+	r.mu.Unlock()
+
+	if banned {
+		bansCounter.Inc()
+		r.logger.With().Warning("banned peer",
+			log.String("peer", pid.String()),
+			log.Stringer("behaviour", behaviour),
+			log.String("score", strconv.FormatFloat(score, 'f', 2, 64)),
+			log.Duration("duration", r.cfg.BanDuration),
+		)
+		if r.onBan != nil {
+			r.onBan(pid)
+		}
+	}
+}
+
+// Score returns the current score of the peer.
+func (r *Reputation) Score(pid peer.ID) float64 {
+	if r == nil {
+		return 0
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rec := r.peers[pid]
+	if rec == nil {
+		return 0
+	}
+	r.decay(rec, r.now())
+	return rec.score
+}
+
+// Banned returns true if the peer is banned.
+func (r *Reputation) Banned(pid peer.ID) bool {
+	if r == nil {
+		return false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rec := r.peers[pid]
+	return rec != nil && r.now().Before(rec.bannedUntil)
+}
+
+// AppSpecificScore is the application-specific score of the peer for gossipsub.
+// Banned peers keep the score at the ban threshold until the ban expires.
+func (r *Reputation) AppSpecificScore(pid peer.ID) float64 {
+	if r == nil {
+		return 0
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rec := r.peers[pid]
+	if rec == nil {
+		return 0
+	}
+	now := r.now()
+	r.decay(rec, now)
+	if now.Before(rec.bannedUntil) {
+		return math.Min(rec.score, r.cfg.BanThreshold)
+	}
+	return rec.score
+}
+
+func (r *Reputation) decay(rec *record, now time.Time) {
+	elapsed := now.Sub(rec.updated)
+	rec.updated = now
+	if elapsed <= 0 || r.cfg.HalfLife <= 0 {
+		return
+	}
+	rec.score *= math.Pow(0.5, float64(elapsed)/float64(r.cfg.HalfLife))
+}
+
+// cleanup drops records of peers that recovered their score and are not banned.
+func (r *Reputation) cleanup(now time.Time) {
+	if r.cfg.HalfLife <= 0 || now.Sub(r.lastCleanup) < r.cfg.HalfLife {
+		return
+	}
+	r.lastCleanup = now
+	for pid, rec := range r.peers {
+		r.decay(rec, now)
+		if math.Abs(rec.score) < 1 && !now.Before(rec.bannedUntil) {
+			delete(r.peers, pid)
+		}
+	}
+}
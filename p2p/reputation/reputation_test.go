@@ -0,0 +1,154 @@
+package reputation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+)
+
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func TestReputation(t *testing.T) {
+	const pid = peer.ID("peer")
+	cfg := DefaultConfig()
+	for _, tc := range []struct {
+		desc    string
+		reports []Behaviour
+		score   float64
+		banned  bool
+	}{
+		{
+			desc:    "valid",
+			reports: []Behaviour{Valid, Valid, Valid},
+			score:   3 * cfg.ValidReward,
+		},
+		{
+			desc:    "invalid",
+			reports: []Behaviour{Valid, Invalid},
+			score:   cfg.ValidReward - cfg.InvalidPenalty,
+		},
+		{
+			desc:    "malformed",
+			reports: []Behaviour{Malformed},
+			score:   -cfg.MalformedPenalty,
+		},
+		{
+			desc:    "wrong hash",
+			reports: []Behaviour{WrongHash, WrongHash},
+			score:   -2 * cfg.MalformedPenalty,
+			banned:  true,
+		},
+		{
+			desc:    "unknown",
+			reports: []Behaviour{0},
+		},
+	} {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			clock := &testClock{now: time.Now()}
+			var bans []peer.ID
+			r := New(cfg,
+				WithLog(logtest.New(t)),
+				WithBanHandler(func(p peer.ID) { bans = append(bans, p) }),
+				withClock(clock.Now),
+			)
+			for _, behaviour := range tc.reports {
+				r.Report(pid, behaviour)
+			}
+			require.Equal(t, tc.score, r.Score(pid))
+			require.Equal(t, tc.banned, r.Banned(pid))
+			if tc.banned {
+				require.Equal(t, []peer.ID{pid}, bans)
+			} else {
+				require.Empty(t, bans)
+			}
+		})
+	}
+}
+
+func TestReputation_MaxScore(t *testing.T) {
+	const pid = peer.ID("peer")
+	cfg := DefaultConfig()
+	cfg.MaxScore = 2
+	r := New(cfg, withClock((&testClock{now: time.Now()}).Now))
+	for i := 0; i < 10; i++ {
+		r.Report(pid, Valid)
+	}
+	require.Equal(t, cfg.MaxScore, r.Score(pid))
+}
+
+func TestReputation_Decay(t *testing.T) {
+	const pid = peer.ID("peer")
+	cfg := DefaultConfig()
+	clock := &testClock{now: time.Now()}
+	r := New(cfg, withClock(clock.Now))
+
+	r.Report(pid, Invalid)
+	require.Equal(t, -cfg.InvalidPenalty, r.Score(pid))
+	clock.now = clock.now.Add(cfg.HalfLife)
+	require.InDelta(t, -cfg.InvalidPenalty/2, r.Score(pid), 1e-9)
+	clock.now = clock.now.Add(cfg.HalfLife)
+	require.InDelta(t, -cfg.InvalidPenalty/4, r.Score(pid), 1e-9)
+}
+
+func TestReputation_Ban(t *testing.T) {
+	const pid = peer.ID("peer")
+	cfg := DefaultConfig()
+	clock := &testClock{now: time.Now()}
+	var bans int
+	r := New(cfg,
+		WithBanHandler(func(peer.ID) { bans++ }),
+		withClock(clock.Now),
+	)
+
+	r.Report(pid, Malformed)
+	r.Report(pid, Malformed)
+	require.True(t, r.Banned(pid))
+	require.Equal(t, 1, bans)
+
+	// the score of a banned peer stays at the threshold even when it decays
+	clock.now = clock.now.Add(cfg.BanDuration / 2)
+	require.Greater(t, r.Score(pid), cfg.BanThreshold)
+	require.Equal(t, cfg.BanThreshold, r.AppSpecificScore(pid))
+
+	// bad behaviour while banned doesn't extend the ban
+	r.Report(pid, Malformed)
+	r.Report(pid, Malformed)
+	require.Equal(t, 1, bans)
+
+	clock.now = clock.now.Add(cfg.BanDuration / 2)
+	require.False(t, r.Banned(pid))
+	require.Greater(t, r.AppSpecificScore(pid), cfg.BanThreshold)
+}
+
+func TestReputation_Cleanup(t *testing.T) {
+	cfg := DefaultConfig()
+	clock := &testClock{now: time.Now()}
+	r := New(cfg, withClock(clock.Now))
+
+	r.Report("first", Invalid)
+	require.Len(t, r.peers, 1)
+	clock.now = clock.now.Add(10 * cfg.HalfLife)
+	r.Report("second", Valid)
+	require.Len(t, r.peers, 1)
+	require.Zero(t, r.Score("first"))
+}
+
+func TestReputation_Nil(t *testing.T) {
+	var r *Reputation
+	r.Report("peer", Invalid)
+	require.Zero(t, r.Score("peer"))
+	require.Zero(t, r.AppSpecificScore("peer"))
+	require.False(t, r.Banned("peer"))
+}
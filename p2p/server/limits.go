@@ -0,0 +1,209 @@
+package server
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// peerIdleTimeout is the time after which the state of a peer without active streams is dropped.
+	peerIdleTimeout = time.Minute
+
+	reasonConcurrent     = "concurrent"
+	reasonPeerConcurrent = "peer_concurrent"
+	reasonRequests       = "requests"
+	reasonPeerRequests   = "peer_requests"
+	reasonBandwidth      = "bandwidth"
+)
+
+// Limit for streams of the protocol. Zero value of the field disables the limit.
+type Limit struct {
+	// Concurrent is the maximal number of streams that are handled concurrently.
+	Concurrent int `mapstructure:"concurrent"`
+	// Requests is the maximal number of requests per second. Bursts are allowed up to one second of requests.
+	Requests float64 `mapstructure:"requests"`
+	// Bytes is the maximal number of bytes per second in responses. Bursts are allowed up to one second of bytes.
+	Bytes int `mapstructure:"bytes"`
+}
+
+// Limits for the protocol that are enforced by the server.
+type Limits struct {
+	// Protocol limits all streams of the protocol.
+	Protocol Limit `mapstructure:"protocol"`
+	// Peer limits streams of every peer separately.
+	Peer Limit `mapstructure:"peer"`
+}
+
+// NewBytesLimiter creates a limiter of bytes per second that can be shared by servers to shape the egress.
+// Returns nil if bytesPerSecond is not positive.
+func NewBytesLimiter(bytesPerSecond int) *rate.Limiter {
+	if bytesPerSecond <= 0 {
+		return nil
+	}
+	return rate.NewLimiter(rate.Limit(bytesPerSecond), bytesPerSecond)
+}
+
+type limiter struct {
+	concurrent int
+	inflight   int
+	requests   *rate.Limiter
+	bytes      *rate.Limiter
+	lastUsed   time.Time
+}
+
+func newLimiter(limit Limit) *limiter {
+	l := &limiter{
+		concurrent: limit.Concurrent,
+		bytes:      NewBytesLimiter(limit.Bytes),
+	}
+	if limit.Requests > 0 {
+		l.requests = rate.NewLimiter(rate.Limit(limit.Requests), int(math.Max(1, math.Ceil(limit.Requests))))
+	}
+	return l
+}
+
+func (l *limiter) overConcurrent() bool {
+	return l.concurrent > 0 && l.inflight >= l.concurrent
+}
+
+func (l *limiter) allowRequest(now time.Time) bool {
+	return l.requests == nil || l.requests.AllowN(now, 1)
+}
+
+// limiters tracks streams of the protocol and of every peer.
+type limiters struct {
+	limits Limits
+	egress *rate.Limiter
+
+	mu          sync.Mutex
+	protocol    *limiter
+	peers       map[peer.ID]*limiter
+	lastCleanup time.Time
+}
+
+func newLimiters(limits Limits, egress *rate.Limiter) *limiters {
+	return &limiters{
+		limits:   limits,
+		egress:   egress,
+		protocol: newLimiter(limits.Protocol),
+		peers:    map[peer.ID]*limiter{},
+	}
+}
+
+// acquire reserves a stream for the peer. If the stream is over the limit the reason is returned,
+// otherwise release must be called when the stream is handled.
+func (l *limiters) acquire(pid peer.ID, now time.Time) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.cleanup(now)
+	pl := l.peers[pid]
+	if pl == nil {
+		pl = newLimiter(l.limits.Peer)
+		l.peers[pid] = pl
+	}
+	pl.lastUsed = now
+	switch {
+	case pl.overConcurrent():
+		return reasonPeerConcurrent
+	case l.protocol.overConcurrent():
+		return reasonConcurrent
+	case !pl.allowRequest(now):
+		return reasonPeerRequests
+	case !l.protocol.allowRequest(now):
+		return reasonRequests
+	}
+	pl.inflight++
+	l.protocol.inflight++
+	return ""
+}
+
+func (l *limiters) release(pid peer.ID, now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.protocol.inflight--
+	if pl := l.peers[pid]; pl != nil {
+		pl.inflight--
+		pl.lastUsed = now
+	}
+}
+
+// bytesLimiters returns the limiters of bytes that apply to responses to the peer.
+func (l *limiters) bytesLimiters(pid peer.ID) []*rate.Limiter {
+	l.mu.Lock()
+	var peerBytes *rate.Limiter
+	if pl := l.peers[pid]; pl != nil {
+		peerBytes = pl.bytes
+	}
+	l.mu.Unlock()
+	return []*rate.Limiter{peerBytes, l.protocol.bytes, l.egress}
+}
+
+// allowBytes returns false if the bandwidth that is available to the peer is exhausted by the previous responses,
+// so that the request can be rejected before it is handled.
+func (l *limiters) allowBytes(pid peer.ID, now time.Time) bool {
+	for _, lim := range l.bytesLimiters(pid) {
+		if lim == nil {
+			continue
+		}
+		r := lim.ReserveN(now, 1)
+		available := r.OK() && r.DelayFrom(now) == 0
+		r.CancelAt(now)
+		if !available {
+			return false
+		}
+	}
+	return true
+}
+
+// reserveBytes reserves bandwidth for the response to the peer and returns the delay before it can be sent.
+// The reservation is cancelled if the delay exceeds the deadline, and false is returned.
+func (l *limiters) reserveBytes(pid peer.ID, size int, now, deadline time.Time) (time.Duration, bool) {
+	var (
+		delay        time.Duration
+		ok           = true
+		reservations []*rate.Reservation
+	)
+	for _, lim := range l.bytesLimiters(pid) {
+		if lim == nil {
+			continue
+		}
+		// responses that are larger than the burst are reserved in chunks,
+		// delay of the last chunk accounts for the previous ones
+		for left := size; left > 0 && ok; left -= lim.Burst() {
+			chunk := left
+			if chunk > lim.Burst() {
+				chunk = lim.Burst()
+			}
+			r := lim.ReserveN(now, chunk)
+			reservations = append(reservations, r)
+			ok = r.OK()
+			if d := r.DelayFrom(now); ok && d > delay {
+				delay = d
+			}
+		}
+	}
+	if !ok || (!deadline.IsZero() && now.Add(delay).After(deadline)) {
+		for _, r := range reservations {
+			r.CancelAt(now)
+		}
+		return 0, false
+	}
+	return delay, true
+}
+
+// cleanup drops state of idle peers. Must be called with the lock held.
+func (l *limiters) cleanup(now time.Time) {
+	if now.Sub(l.lastCleanup) < peerIdleTimeout {
+		return
+	}
+	l.lastCleanup = now
+	for pid, pl := range l.peers {
+		if pl.inflight == 0 && now.Sub(pl.lastUsed) >= peerIdleTimeout {
+			delete(l.peers, pid)
+		}
+	}
+}
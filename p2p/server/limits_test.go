@@ -0,0 +1,70 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiters_ReserveBytes(t *testing.T) {
+	const pid = peer.ID("peer")
+	l := newLimiters(Limits{
+		Protocol: Limit{Bytes: 100},
+		Peer:     Limit{Bytes: 10},
+	}, NewBytesLimiter(1000))
+	now := time.Now()
+	require.Empty(t, l.acquire(pid, now))
+
+	delay, ok := l.reserveBytes(pid, 10, now, time.Time{})
+	require.True(t, ok)
+	require.Zero(t, delay)
+
+	// larger than the burst of the peer limiter, reserved in chunks
+	delay, ok = l.reserveBytes(pid, 25, now, time.Time{})
+	require.True(t, ok)
+	require.Equal(t, 2500*time.Millisecond, delay)
+
+	// cancelled reservation doesn't consume tokens
+	_, ok = l.reserveBytes(pid, 10, now, now.Add(time.Second))
+	require.False(t, ok)
+	delay, ok = l.reserveBytes(pid, 10, now, time.Time{})
+	require.True(t, ok)
+	require.Equal(t, 3500*time.Millisecond, delay)
+}
+
+func TestLimiters_AllowBytes(t *testing.T) {
+	const pid = peer.ID("peer")
+	l := newLimiters(Limits{Peer: Limit{Bytes: 10}}, NewBytesLimiter(1000))
+	now := time.Now()
+	require.Empty(t, l.acquire(pid, now))
+	require.True(t, l.allowBytes(pid, now))
+	require.True(t, l.allowBytes("other", now))
+
+	delay, ok := l.reserveBytes(pid, 15, now, time.Time{})
+	require.True(t, ok)
+	require.Equal(t, 500*time.Millisecond, delay)
+	require.False(t, l.allowBytes(pid, now))
+	require.False(t, l.allowBytes(pid, now.Add(500*time.Millisecond)))
+	require.True(t, l.allowBytes(pid, now.Add(600*time.Millisecond)))
+	// check doesn't consume tokens
+	delay, ok = l.reserveBytes(pid, 1, now.Add(600*time.Millisecond), time.Time{})
+	require.True(t, ok)
+	require.Zero(t, delay)
+}
+
+func TestLimiters_Cleanup(t *testing.T) {
+	l := newLimiters(Limits{Peer: Limit{Concurrent: 1}}, nil)
+	now := time.Now()
+	require.Empty(t, l.acquire("active", now))
+	require.Empty(t, l.acquire("idle", now))
+	l.release("idle", now)
+	require.Len(t, l.peers, 2)
+
+	now = now.Add(peerIdleTimeout)
+	require.Empty(t, l.acquire("other", now))
+	require.Len(t, l.peers, 2)
+	require.Contains(t, l.peers, peer.ID("active"))
+	require.Equal(t, reasonPeerConcurrent, l.acquire("active", now))
+}
@@ -0,0 +1,29 @@
+package server
+
+import (
+	"github.com/spacemeshos/go-spacemesh/metrics"
+)
+
+const subsystem = "server"
+
+var (
+	rejectedRequests = metrics.NewCounter(
+		"rejected_requests",
+		subsystem,
+		"Number of requests that were rejected because of the limits",
+		[]string{"protocol", "reason"},
+	)
+	inflightStreams = metrics.NewGauge(
+		"inflight_streams",
+		subsystem,
+		"Number of streams that are handled concurrently",
+		[]string{"protocol"},
+	)
+	throttleDelay = metrics.NewHistogramWithBuckets(
+		"throttle_delay",
+		subsystem,
+		"Delay of responses to shape the bandwidth (seconds)",
+		[]string{"protocol"},
+		[]float64{0.01, 0.05, 0.1, 0.5, 1, 2, 5, 10},
+	)
+)
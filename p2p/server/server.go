@@ -12,13 +12,18 @@ import (
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
+	"golang.org/x/time/rate"
 
 	"github.com/spacemeshos/go-spacemesh/codec"
 	"github.com/spacemeshos/go-spacemesh/log"
 )
 
-// ErrNotConnected is returned when peer is not connected.
-var ErrNotConnected = errors.New("peer is not connected")
+var (
+	// ErrNotConnected is returned when peer is not connected.
+	ErrNotConnected = errors.New("peer is not connected")
+	// ErrRateLimited is returned when the peer rejected the request because of its limits.
+	ErrRateLimited = errors.New("rate limited")
+)
 
 // Opt is a type to configure a server.
 type Opt func(s *Server)
@@ -44,6 +49,20 @@ func WithContext(ctx context.Context) Opt {
 	}
 }
 
+// WithLimits configures limits for the streams of the protocol.
+func WithLimits(limits Limits) Opt {
+	return func(s *Server) {
+		s.limits = limits
+	}
+}
+
+// WithEgress configures limiter of bytes per second that is shared with other servers.
+func WithEgress(limiter *rate.Limiter) Opt {
+	return func(s *Server) {
+		s.egress = limiter
+	}
+}
+
 // Handler is the handler to be defined by the application.
 type Handler func(context.Context, []byte) ([]byte, error)
 
@@ -70,6 +89,9 @@ type Server struct {
 	protocol string
 	handler  Handler
 	timeout  time.Duration
+	limits   Limits
+	egress   *rate.Limiter
+	limiters *limiters
 
 	h Host
 
@@ -89,13 +111,15 @@ func New(h Host, proto string, handler Handler, opts ...Opt) *Server {
 	for _, opt := range opts {
 		opt(srv)
 	}
+	srv.limiters = newLimiters(srv.limits, srv.egress)
 	h.SetStreamHandler(protocol.ID(proto), srv.streamHandler)
 	return srv
 }
 
 func (s *Server) streamHandler(stream network.Stream) {
 	defer stream.Close()
-	_ = stream.SetDeadline(time.Now().Add(s.timeout))
+	deadline := time.Now().Add(s.timeout)
+	_ = stream.SetDeadline(deadline)
 	defer stream.SetDeadline(time.Time{})
 	rd := bufio.NewReader(stream)
 	size, err := binary.ReadUvarint(rd)
@@ -107,6 +131,22 @@ func (s *Server) streamHandler(stream network.Stream) {
 	if err != nil {
 		return
 	}
+	pid := stream.Conn().RemotePeer()
+	if reason := s.limiters.acquire(pid, time.Now()); reason != "" {
+		s.reject(stream, pid, reason)
+		return
+	}
+	defer s.limiters.release(pid, time.Now())
+	// size of the response is not known before it is handled, but the request is not handled
+	// if the bandwidth is already exhausted by the previous responses
+	if !s.limiters.allowBytes(pid, time.Now()) {
+		s.reject(stream, pid, reasonBandwidth)
+		return
+	}
+	inflight := inflightStreams.WithLabelValues(s.protocol)
+	inflight.Inc()
+	defer inflight.Dec()
+
 	start := time.Now()
 	buf, err = s.handler(log.WithNewRequestID(s.ctx), buf)
 	s.logger.With().Debug("protocol handler execution time",
@@ -119,14 +159,39 @@ func (s *Server) streamHandler(stream network.Stream) {
 	} else {
 		resp.Data = buf
 	}
-
-	wr := bufio.NewWriter(stream)
-	if _, err := codec.EncodeTo(wr, &resp); err != nil {
-		s.logger.With().Warning("failed to write response", log.Err(err))
+	encoded, err := codec.Encode(&resp)
+	if err != nil {
+		s.logger.With().Warning("failed to encode response", log.Err(err))
 		return
 	}
-	if err := wr.Flush(); err != nil {
-		s.logger.With().Warning("failed to flush stream", log.Err(err))
+	delay, ok := s.limiters.reserveBytes(pid, len(encoded), time.Now(), deadline)
+	if !ok {
+		s.reject(stream, pid, reasonBandwidth)
+		return
+	}
+	if delay > 0 {
+		throttleDelay.WithLabelValues(s.protocol).Observe(delay.Seconds())
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+	if _, err := stream.Write(encoded); err != nil {
+		s.logger.With().Warning("failed to write response", log.Err(err))
+	}
+}
+
+// reject responds with ErrRateLimited to the request that is over the limits.
+func (s *Server) reject(stream network.Stream, pid peer.ID, reason string) {
+	rejectedRequests.WithLabelValues(s.protocol, reason).Inc()
+	s.logger.With().Debug("rejected request over the limit",
+		log.String("protocol", s.protocol),
+		log.String("peer", pid.String()),
+		log.String("reason", reason),
+	)
+	if _, err := codec.EncodeTo(stream, &Response{Error: ErrRateLimited.Error()}); err != nil {
+		s.logger.With().Debug("failed to write response", log.Err(err))
 	}
 }
 
@@ -179,7 +244,9 @@ func (s *Server) Request(ctx context.Context, pid peer.ID, req []byte, resp func
 			failure(err)
 			return
 		}
-		if len(r.Error) > 0 {
+		if r.Error == ErrRateLimited.Error() {
+			failure(ErrRateLimited)
+		} else if len(r.Error) > 0 {
 			failure(errors.New(r.Error))
 		} else {
 			resp(r.Data)
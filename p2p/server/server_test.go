@@ -3,9 +3,11 @@ package server
 import (
 	"context"
 	"errors"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/libp2p/go-libp2p/core/peer"
 	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
 	"github.com/spacemeshos/go-scale/tester"
 	"github.com/stretchr/testify/require"
@@ -81,6 +83,121 @@ func TestServer(t *testing.T) {
 	})
 }
 
+func TestServer_Limits(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	request := func(t *testing.T, client *Server, pid peer.ID) error {
+		errch := make(chan error, 1)
+		require.NoError(t, client.Request(ctx, pid, []byte("test request"),
+			func([]byte) { errch <- nil },
+			func(err error) { errch <- err },
+		))
+		select {
+		case <-time.After(time.Second):
+			require.FailNow(t, "timed out while waiting for response")
+		case err := <-errch:
+			return err
+		}
+		return nil
+	}
+	handler := func(_ context.Context, msg []byte) ([]byte, error) {
+		return msg, nil
+	}
+
+	t.Run("Concurrent", func(t *testing.T) {
+		mesh, err := mocknet.FullMeshConnected(2)
+		require.NoError(t, err)
+		proto := "test"
+		started := make(chan struct{}, 1)
+		unblock := make(chan struct{})
+		blocking := func(_ context.Context, msg []byte) ([]byte, error) {
+			select {
+			case started <- struct{}{}:
+			default:
+			}
+			<-unblock
+			return msg, nil
+		}
+		client := New(mesh.Hosts()[0], proto, handler, WithContext(ctx))
+		_ = New(mesh.Hosts()[1], proto, blocking,
+			WithContext(ctx),
+			WithLimits(Limits{Peer: Limit{Concurrent: 1}}),
+		)
+		first := make(chan error, 1)
+		go func() {
+			first <- request(t, client, mesh.Hosts()[1].ID())
+		}()
+		<-started
+		require.ErrorIs(t, request(t, client, mesh.Hosts()[1].ID()), ErrRateLimited)
+		close(unblock)
+		require.NoError(t, <-first)
+		require.NoError(t, request(t, client, mesh.Hosts()[1].ID()))
+	})
+	t.Run("Requests", func(t *testing.T) {
+		mesh, err := mocknet.FullMeshConnected(4)
+		require.NoError(t, err)
+		proto := "test"
+		var clients []*Server
+		for _, h := range mesh.Hosts()[:3] {
+			clients = append(clients, New(h, proto, handler, WithContext(ctx)))
+		}
+		srv := mesh.Hosts()[3].ID()
+		_ = New(mesh.Hosts()[3], proto, handler,
+			WithContext(ctx),
+			WithLimits(Limits{
+				Protocol: Limit{Requests: 2},
+				Peer:     Limit{Requests: 0.001},
+			}),
+		)
+		require.NoError(t, request(t, clients[0], srv))
+		// rejected by the peer limit, and not counted against the protocol limit
+		require.ErrorIs(t, request(t, clients[0], srv), ErrRateLimited)
+		require.NoError(t, request(t, clients[1], srv))
+		// rejected by the protocol limit
+		require.ErrorIs(t, request(t, clients[2], srv), ErrRateLimited)
+	})
+	t.Run("Bandwidth", func(t *testing.T) {
+		mesh, err := mocknet.FullMeshConnected(2)
+		require.NoError(t, err)
+		proto := "test"
+		client := New(mesh.Hosts()[0], proto, handler, WithContext(ctx))
+		_ = New(mesh.Hosts()[1], proto, handler,
+			WithContext(ctx),
+			WithTimeout(100*time.Millisecond),
+			WithEgress(NewBytesLimiter(20)),
+		)
+		require.NoError(t, request(t, client, mesh.Hosts()[1].ID()))
+		// tokens are exhausted, the response can't be sent before the deadline
+		require.ErrorIs(t, request(t, client, mesh.Hosts()[1].ID()), ErrRateLimited)
+	})
+	t.Run("Bandwidth exhausted before handler", func(t *testing.T) {
+		mesh, err := mocknet.FullMeshConnected(2)
+		require.NoError(t, err)
+		proto := "test"
+		var handled atomic.Int32
+		counting := func(_ context.Context, msg []byte) ([]byte, error) {
+			handled.Add(1)
+			return msg, nil
+		}
+		client := New(mesh.Hosts()[0], proto, handler, WithContext(ctx))
+		_ = New(mesh.Hosts()[1], proto, counting,
+			WithContext(ctx),
+			WithEgress(NewBytesLimiter(20)),
+		)
+		require.NoError(t, request(t, client, mesh.Hosts()[1].ID()))
+		// response is delayed until tokens are available, the limiter is in debt until it is sent
+		delayed := make(chan error, 1)
+		go func() {
+			delayed <- request(t, client, mesh.Hosts()[1].ID())
+		}()
+		require.Eventually(t, func() bool { return handled.Load() == 2 }, time.Second, time.Millisecond)
+		require.ErrorIs(t, request(t, client, mesh.Hosts()[1].ID()), ErrRateLimited)
+		require.NoError(t, <-delayed)
+		require.EqualValues(t, 2, handled.Load())
+	})
+}
+
 func FuzzResponseConsistency(f *testing.F) {
 	tester.FuzzConsistency[Response](f)
 }
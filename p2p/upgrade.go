@@ -5,6 +5,8 @@ import (
 	"fmt"
 
 	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
 
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/log"
@@ -13,6 +15,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/p2p/handshake"
 	"github.com/spacemeshos/go-spacemesh/p2p/peerexchange"
 	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
+	"github.com/spacemeshos/go-spacemesh/p2p/reputation"
 )
 
 // Opt is for configuring Host.
@@ -47,6 +50,19 @@ func WithNodeReporter(reporter func()) Opt {
 	}
 }
 
+// WithIdentityOpts configures storage of the identity key. It has effect only in New.
+func WithIdentityOpts(opts ...IdentityOpt) Opt {
+	return func(fh *Host) {
+		fh.identityOpts = opts
+	}
+}
+
+func withGater(g *Gater) Opt {
+	return func(fh *Host) {
+		fh.gater = g
+	}
+}
+
 // Host is a conveniency wrapper for all p2p related functionality required to run
 // a full spacemesh node.
 type Host struct {
@@ -58,11 +74,16 @@ type Host struct {
 	*pubsub.PubSub
 
 	nodeReporter func()
+	identityOpts []IdentityOpt
 	*bootstrap.Peers
 
-	discovery *peerexchange.Discovery
-	hs        *handshake.Handshake
-	bootstrap *bootstrap.Bootstrap
+	discovery  *peerexchange.Discovery
+	hs         *handshake.Handshake
+	bootstrap  *bootstrap.Bootstrap
+	reputation *reputation.Reputation
+	notifee    *network.NotifyBundle
+	// gater is nil if the host wasn't created with New.
+	gater *Gater
 }
 
 func isBootnode(h host.Host, bootnodes []string) (bool, error) {
@@ -94,10 +115,24 @@ func Upgrade(h host.Host, genesisID types.Hash20, opts ...Opt) (*Host, error) {
 	if err != nil {
 		return nil, fmt.Errorf("check node as bootnode: %w", err)
 	}
+	fh.reputation = reputation.New(cfg.Reputation,
+		reputation.WithLog(fh.logger),
+		reputation.WithBanHandler(fh.disconnect),
+	)
+	// banned peers may reconnect using addresses from discovery, their connections are closed right away
+	fh.notifee = &network.NotifyBundle{
+		ConnectedF: func(_ network.Network, conn network.Conn) {
+			if fh.reputation.Banned(conn.RemotePeer()) {
+				go conn.Close()
+			}
+		},
+	}
+	h.Network().Notify(fh.notifee)
 	if fh.PubSub, err = pubsub.New(fh.ctx, fh.logger, h, pubsub.Config{
 		Flood:          cfg.Flood,
 		IsBootnode:     bootnode,
 		MaxMessageSize: cfg.MaxMessageSize,
+		Reputation:     fh.reputation,
 	}); err != nil {
 		return nil, fmt.Errorf("failed to initialize pubsub: %w", err)
 	}
@@ -127,8 +162,86 @@ func Upgrade(h host.Host, genesisID types.Hash20, opts ...Opt) (*Host, error) {
 	return fh, nil
 }
 
+// Reputation returns the reputation of the peers that is shared by the p2p components.
+func (fh *Host) Reputation() *reputation.Reputation {
+	return fh.reputation
+}
+
+// AllowlistOnly returns true if only peers from the allowlist are allowed to connect.
+func (fh *Host) AllowlistOnly() bool {
+	return fh.gater != nil && fh.gater.AllowlistOnly()
+}
+
+// AccessEntries returns entries of the access list.
+func (fh *Host) AccessEntries(list AccessList) ([]string, error) {
+	if fh.gater == nil {
+		return nil, ErrAccessListsDisabled
+	}
+	return fh.gater.Entries(list), nil
+}
+
+// AddAccessEntry adds peer ID, IP address or CIDR range to the access list.
+// Connections that are not allowed anymore are closed.
+func (fh *Host) AddAccessEntry(list AccessList, entry string) error {
+	if fh.gater == nil {
+		return ErrAccessListsDisabled
+	}
+	if err := fh.gater.Add(list, entry); err != nil {
+		return err
+	}
+	fh.logger.With().Info("added access entry",
+		log.Stringer("list", list),
+		log.String("entry", entry),
+	)
+	fh.enforceAccess()
+	return nil
+}
+
+// RemoveAccessEntry removes the entry from the access list.
+// Connections that are not allowed anymore are closed.
+func (fh *Host) RemoveAccessEntry(list AccessList, entry string) error {
+	if fh.gater == nil {
+		return ErrAccessListsDisabled
+	}
+	if err := fh.gater.Remove(list, entry); err != nil {
+		return err
+	}
+	fh.logger.With().Info("removed access entry",
+		log.Stringer("list", list),
+		log.String("entry", entry),
+	)
+	fh.enforceAccess()
+	return nil
+}
+
+// enforceAccess closes connections that are not allowed by the access lists.
+func (fh *Host) enforceAccess() {
+	for _, conn := range fh.Network().Conns() {
+		if fh.gater.Allowed(conn.RemotePeer(), conn.RemoteMultiaddr()) {
+			continue
+		}
+		fh.logger.With().Info("closing connection that is not allowed by access lists",
+			log.String("peer", conn.RemotePeer().String()),
+			log.String("address", conn.RemoteMultiaddr().String()),
+		)
+		if err := conn.Close(); err != nil {
+			fh.logger.With().Debug("failed to close connection", log.Err(err))
+		}
+	}
+}
+
+func (fh *Host) disconnect(pid peer.ID) {
+	if err := fh.Network().ClosePeer(pid); err != nil {
+		fh.logger.With().Debug("failed to close connections of banned peer",
+			log.String("peer", pid.String()),
+			log.Err(err),
+		)
+	}
+}
+
 // Stop background workers and release external resources.
 func (fh *Host) Stop() error {
+	fh.Network().StopNotify(fh.notifee)
 	fh.discovery.Stop()
 	fh.bootstrap.Stop()
 	fh.Peers.Stop()
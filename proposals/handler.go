@@ -40,6 +40,7 @@ var (
 	errKnownProposal         = errors.New("known proposal")
 	errKnownBallot           = errors.New("known ballot")
 	errInvalidVote           = errors.New("invalid layer/height in the vote")
+	errInvalidCheckpoint     = errors.New("invalid opinion checkpoint")
 )
 
 // Handler processes Proposal from gossip and, if deems it valid, propagates it to peers.
@@ -49,6 +50,7 @@ type Handler struct {
 
 	cdb       *datastore.CachedDB
 	fetcher   system.Fetcher
+	publisher pubsub.Publisher
 	mesh      meshProvider
 	validator eligibilityValidator
 	decoder   ballotDecoder
@@ -95,14 +97,15 @@ func WithConfig(cfg Config) Opt {
 }
 
 // NewHandler creates new Handler.
-func NewHandler(cdb *datastore.CachedDB, f system.Fetcher, bc system.BeaconCollector, m meshProvider, decoder ballotDecoder, opts ...Opt) *Handler {
+func NewHandler(cdb *datastore.CachedDB, f system.Fetcher, p pubsub.Publisher, bc system.BeaconCollector, m meshProvider, decoder ballotDecoder, opts ...Opt) *Handler {
 	b := &Handler{
-		logger:  log.NewNop(),
-		cfg:     defaultConfig(),
-		cdb:     cdb,
-		fetcher: f,
-		mesh:    m,
-		decoder: decoder,
+		logger:    log.NewNop(),
+		cfg:       defaultConfig(),
+		cdb:       cdb,
+		fetcher:   f,
+		publisher: p,
+		mesh:      m,
+		decoder:   decoder,
 	}
 	for _, opt := range opts {
 		opt(b)
@@ -278,13 +281,17 @@ func (h *Handler) processBallot(ctx context.Context, logger log.Log, b *types.Ba
 	}
 
 	t1 := time.Now()
-	if err := h.mesh.AddBallot(b); err != nil {
+	proof, err := h.mesh.AddBallot(b)
+	if err != nil {
 		if errors.Is(err, sql.ErrObjectExists) {
 			return fmt.Errorf("%w: ballot %s", errKnownBallot, b.ID())
 		}
 		return fmt.Errorf("save ballot: %w", err)
 	}
 	ballotDuration.WithLabelValues(dbSave).Observe(float64(time.Since(t1)))
+	if proof != nil {
+		h.publishProof(ctx, logger, proof)
+	}
 	if err := h.decoder.StoreBallot(decoded); err != nil {
 		return fmt.Errorf("store decoded ballot %s: %w", decoded.ID(), err)
 	}
@@ -292,6 +299,18 @@ func (h *Handler) processBallot(ctx context.Context, logger log.Log, b *types.Ba
 	return nil
 }
 
+// publishProof gossips proof of malfeasance, so that peers can verify it
+// instead of relying on which ballots they happened to receive.
+func (h *Handler) publishProof(ctx context.Context, logger log.Log, proof *types.MalfeasanceProof) {
+	encoded, err := codec.Encode(proof)
+	if err != nil {
+		logger.With().Panic("failed to encode malfeasance proof", log.Err(err))
+	}
+	if err := h.publisher.Publish(ctx, pubsub.MalfeasanceProtocol, encoded); err != nil {
+		logger.With().Error("failed to broadcast malfeasance proof", log.Err(err))
+	}
+}
+
 func (h *Handler) checkBallotSyntacticValidity(ctx context.Context, logger log.Log, b *types.Ballot) (*tortoise.DecodedBallot, error) {
 	logger.With().Debug("checking proposal syntactic validity")
 
@@ -385,6 +404,9 @@ func (h *Handler) setBallotMalicious(ctx context.Context, b *types.Ballot) error
 }
 
 func (h *Handler) checkVotesConsistency(ctx context.Context, b *types.Ballot) error {
+	if err := checkOpinionCheckpoint(b); err != nil {
+		return err
+	}
 	exceptions := map[types.BlockID]struct{}{}
 	layers := make(map[types.LayerID]types.BlockID)
 	// a ballot should not vote for multiple blocks in the same layer within hdist,
@@ -465,6 +487,35 @@ func (h *Handler) checkVotesConsistency(ctx context.Context, b *types.Ballot) er
 	return nil
 }
 
+// checkOpinionCheckpoint verifies that opinion checkpoint replaces base ballot and that
+// exceptions are encoded only for layers after the checkpoint layer.
+func checkOpinionCheckpoint(b *types.Ballot) error {
+	cp := b.Votes.Checkpoint
+	if cp == nil {
+		return nil
+	}
+	if b.Votes.Base != types.EmptyBallotID {
+		return fmt.Errorf("%w: ballot %s references both base ballot and checkpoint", errInvalidCheckpoint, b.ID())
+	}
+	if !cp.Layer.Before(b.LayerIndex) {
+		return fmt.Errorf("%w: checkpoint layer %s is not before ballot layer %s", errInvalidCheckpoint, cp.Layer, b.LayerIndex)
+	}
+	for _, votes := range [][]types.Vote{b.Votes.Support, b.Votes.Against} {
+		for _, vote := range votes {
+			if !vote.LayerID.After(cp.Layer) {
+				return fmt.Errorf("%w: vote for block %s in layer %s is covered by checkpoint %s",
+					errInvalidCheckpoint, vote.ID, vote.LayerID, cp.Layer)
+			}
+		}
+	}
+	for _, lid := range b.Votes.Abstain {
+		if !lid.After(cp.Layer) {
+			return fmt.Errorf("%w: abstain in layer %s is covered by checkpoint %s", errInvalidCheckpoint, lid, cp.Layer)
+		}
+	}
+	return nil
+}
+
 func ballotBlockView(b *types.Ballot) []types.BlockID {
 	combined := make([]types.BlockID, 0, len(b.Votes.Support)+len(b.Votes.Against))
 	for _, vote := range b.Votes.Support {
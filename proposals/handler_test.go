@@ -17,6 +17,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/log/logtest"
 	"github.com/spacemeshos/go-spacemesh/p2p"
 	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
+	pubsubmocks "github.com/spacemeshos/go-spacemesh/p2p/pubsub/mocks"
 	"github.com/spacemeshos/go-spacemesh/proposals/mocks"
 	"github.com/spacemeshos/go-spacemesh/signing"
 	"github.com/spacemeshos/go-spacemesh/sql"
@@ -43,6 +44,7 @@ type mockSet struct {
 	mm  *mocks.MockmeshProvider
 	mv  *mocks.MockeligibilityValidator
 	md  *mocks.MockballotDecoder
+	mp  *pubsubmocks.MockPublisher
 }
 
 func (ms *mockSet) decodeAnyBallots() *mockSet {
@@ -62,6 +64,7 @@ func fullMockSet(tb testing.TB) *mockSet {
 		mf:  smocks.NewMockFetcher(ctrl),
 		mbc: smocks.NewMockBeaconCollector(ctrl),
 		mm:  mocks.NewMockmeshProvider(ctrl),
+		mp:  pubsubmocks.NewMockPublisher(ctrl),
 		mv:  mocks.NewMockeligibilityValidator(ctrl),
 		md:  mocks.NewMockballotDecoder(ctrl),
 	}
@@ -71,7 +74,7 @@ func createTestHandler(t *testing.T) *testHandler {
 	types.SetLayersPerEpoch(layersPerEpoch)
 	ms := fullMockSet(t)
 	return &testHandler{
-		Handler: NewHandler(datastore.NewCachedDB(sql.InMemory(), logtest.New(t)), ms.mf, ms.mbc, ms.mm, ms.md,
+		Handler: NewHandler(datastore.NewCachedDB(sql.InMemory(), logtest.New(t)), ms.mf, ms.mp, ms.mbc, ms.mm, ms.md,
 			WithLogger(logtest.New(t)),
 			WithConfig(Config{
 				LayerSize:      layerAvgSize,
@@ -117,6 +120,13 @@ func withAbstain(layers ...types.LayerID) createBallotOpt {
 	}
 }
 
+func withCheckpoint(lid types.LayerID) createBallotOpt {
+	return func(b *types.Ballot) {
+		b.Votes.Base = types.EmptyBallotID
+		b.Votes.Checkpoint = &types.OpinionCheckpoint{Layer: lid, Opinion: types.RandomHash()}
+	}
+}
+
 func withLayer(lid types.LayerID) createBallotOpt {
 	return func(b *types.Ballot) {
 		b.LayerIndex = lid
@@ -394,7 +404,7 @@ func TestBallot_BallotDoubleVotedOutsideHdist(t *testing.T) {
 			require.Equal(t, b.ID(), ballot.ID())
 			return true, nil
 		})
-	th.mm.EXPECT().AddBallot(b).Return(nil)
+	th.mm.EXPECT().AddBallot(b).Return(nil, nil)
 	require.NoError(t, th.HandleSyncedBallot(context.TODO(), data))
 	checkIdentity(t, th.cdb, b, false)
 }
@@ -645,6 +655,83 @@ func TestBallot_InvalidVote(t *testing.T) {
 	}
 }
 
+func TestBallot_OpinionCheckpoint(t *testing.T) {
+	lid := types.NewLayerID(100)
+	blks := []*types.Block{
+		types.NewExistingBlock(types.BlockID{1}, types.InnerBlock{LayerIndex: lid.Sub(1)}),
+		types.NewExistingBlock(types.BlockID{2}, types.InnerBlock{LayerIndex: lid.Sub(2)}),
+	}
+	for _, tc := range []struct {
+		desc string
+		opts []createBallotOpt
+		err  error
+	}{
+		{
+			desc: "valid",
+			opts: []createBallotOpt{
+				withLayer(lid),
+				withCheckpoint(lid.Sub(3)),
+				withSupportBlocks(blks...),
+			},
+		},
+		{
+			desc: "with base ballot",
+			opts: []createBallotOpt{
+				withLayer(lid),
+				withCheckpoint(lid.Sub(3)),
+				func(b *types.Ballot) { b.Votes.Base = types.RandomBallotID() },
+			},
+			err: errInvalidCheckpoint,
+		},
+		{
+			desc: "checkpoint not before ballot",
+			opts: []createBallotOpt{
+				withLayer(lid),
+				withCheckpoint(lid),
+				withSupportBlocks(),
+			},
+			err: errInvalidCheckpoint,
+		},
+		{
+			desc: "support covered by checkpoint",
+			opts: []createBallotOpt{
+				withLayer(lid),
+				withCheckpoint(lid.Sub(2)),
+				withSupportBlocks(blks...),
+			},
+			err: errInvalidCheckpoint,
+		},
+		{
+			desc: "abstain covered by checkpoint",
+			opts: []createBallotOpt{
+				withLayer(lid),
+				withCheckpoint(lid.Sub(2)),
+				withSupportBlocks(),
+				withAbstain(lid.Sub(2)),
+			},
+			err: errInvalidCheckpoint,
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			th := createTestHandlerNoopDecoder(t)
+			for _, blk := range blks {
+				require.NoError(t, blocks.Add(th.cdb, blk))
+			}
+			b := createBallot(t, tc.opts...)
+			data := encodeBallot(t, b)
+			th.mf.EXPECT().AddPeersFromHash(b.ID().AsHash32(), collectHashes(*b))
+			th.mf.EXPECT().GetBallots(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			th.mf.EXPECT().GetAtxs(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			th.mf.EXPECT().GetBlocks(gomock.Any(), gomock.Any()).Return(nil).MaxTimes(1)
+			if tc.err == nil {
+				th.mv.EXPECT().CheckEligibility(gomock.Any(), gomock.Any()).Return(true, nil)
+				th.mm.EXPECT().AddBallot(b).Return(nil, nil)
+			}
+			require.ErrorIs(t, th.HandleSyncedBallot(context.TODO(), data), tc.err)
+		})
+	}
+}
+
 func TestBallot_Success(t *testing.T) {
 	th := createTestHandler(t)
 	lid := types.NewLayerID(100)
@@ -669,7 +756,7 @@ func TestBallot_Success(t *testing.T) {
 			require.Equal(t, b.ID(), ballot.ID())
 			return true, nil
 		})
-	th.mm.EXPECT().AddBallot(b).Return(nil)
+	th.mm.EXPECT().AddBallot(b).Return(nil, nil)
 	decoded := &tortoise.DecodedBallot{Ballot: b}
 	th.md.EXPECT().DecodeBallot(b).Return(decoded, nil)
 	th.md.EXPECT().StoreBallot(decoded).Return(nil)
@@ -677,6 +764,39 @@ func TestBallot_Success(t *testing.T) {
 	checkIdentity(t, th.cdb, b, false)
 }
 
+func TestBallot_MalfeasanceProof(t *testing.T) {
+	th := createTestHandler(t)
+	lid := types.NewLayerID(100)
+	supported := []*types.Block{
+		types.NewExistingBlock(types.BlockID{1}, types.InnerBlock{LayerIndex: lid.Sub(1)}),
+	}
+	b := createBallot(t,
+		withLayer(lid),
+		withSupportBlocks(supported...),
+	)
+	for _, blk := range supported {
+		require.NoError(t, blocks.Add(th.cdb, blk))
+	}
+	data := encodeBallot(t, b)
+	th.mf.EXPECT().AddPeersFromHash(b.ID().AsHash32(), collectHashes(*b))
+	th.mf.EXPECT().GetBallots(gomock.Any(), []types.BallotID{b.Votes.Base, b.RefBallot}).Return(nil).Times(1)
+	th.mf.EXPECT().GetAtxs(gomock.Any(), types.ATXIDList{b.AtxID}).Return(nil).Times(1)
+	th.mf.EXPECT().GetBlocks(gomock.Any(), toIds(b.Votes.Support)).Return(nil).Times(1)
+	th.mv.EXPECT().CheckEligibility(gomock.Any(), gomock.Any()).Return(true, nil)
+	proof := types.NewMalfeasanceProof(types.MultipleBallots,
+		types.NewSignedMessage([]byte{1}, []byte{1}),
+		types.NewSignedMessage(b.SignedBytes(), b.Signature),
+	)
+	th.mm.EXPECT().AddBallot(b).Return(proof, nil)
+	encoded, err := codec.Encode(proof)
+	require.NoError(t, err)
+	th.mp.EXPECT().Publish(gomock.Any(), pubsub.MalfeasanceProtocol, encoded).Return(nil)
+	decoded := &tortoise.DecodedBallot{Ballot: b}
+	th.md.EXPECT().DecodeBallot(b).Return(decoded, nil)
+	th.md.EXPECT().StoreBallot(decoded).Return(nil)
+	require.NoError(t, th.HandleSyncedBallot(context.TODO(), data))
+}
+
 func TestBallot_RefBallot(t *testing.T) {
 	th := createTestHandlerNoopDecoder(t)
 	lid := types.NewLayerID(100)
@@ -704,7 +824,7 @@ func TestBallot_RefBallot(t *testing.T) {
 			require.Equal(t, b.ID(), ballot.ID())
 			return true, nil
 		})
-	th.mm.EXPECT().AddBallot(b).Return(nil)
+	th.mm.EXPECT().AddBallot(b).Return(nil, nil)
 	require.NoError(t, th.HandleSyncedBallot(context.TODO(), data))
 	checkIdentity(t, th.cdb, b, false)
 }
@@ -744,7 +864,7 @@ func TestBallot_DecodedStoreFailure(t *testing.T) {
 
 	decoded := &tortoise.DecodedBallot{Ballot: b}
 	th.md.EXPECT().DecodeBallot(b).Return(decoded, nil)
-	th.mm.EXPECT().AddBallot(b).Return(nil)
+	th.mm.EXPECT().AddBallot(b).Return(nil, nil)
 	th.md.EXPECT().StoreBallot(decoded).Return(expected)
 	require.ErrorIs(t, th.HandleSyncedBallot(context.TODO(), data), expected)
 }
@@ -805,10 +925,10 @@ func TestProposal_DuplicateTXs(t *testing.T) {
 			require.Equal(t, p.Ballot.ID(), ballot.ID())
 			return true, nil
 		})
-	th.mm.EXPECT().AddBallot(&p.Ballot).Return(nil).DoAndReturn(
-		func(got *types.Ballot) error {
+	th.mm.EXPECT().AddBallot(&p.Ballot).DoAndReturn(
+		func(got *types.Ballot) (*types.MalfeasanceProof, error) {
 			require.NoError(t, ballots.Add(th.cdb, got))
-			return nil
+			return nil, nil
 		})
 	th.mf.EXPECT().RegisterPeerHashes(p2p.NoPeer, collectHashes(*p))
 	require.ErrorIs(t, th.HandleSyncedProposal(context.TODO(), data), errDuplicateTX)
@@ -839,10 +959,10 @@ func TestProposal_TXsNotAvailable(t *testing.T) {
 			require.Equal(t, p.Ballot.ID(), ballot.ID())
 			return true, nil
 		})
-	th.mm.EXPECT().AddBallot(&p.Ballot).Return(nil).DoAndReturn(
-		func(got *types.Ballot) error {
+	th.mm.EXPECT().AddBallot(&p.Ballot).DoAndReturn(
+		func(got *types.Ballot) (*types.MalfeasanceProof, error) {
 			require.NoError(t, ballots.Add(th.cdb, got))
-			return nil
+			return nil, nil
 		})
 
 	errUnknown := errors.New("unknown")
@@ -874,10 +994,10 @@ func TestProposal_FailedToAddProposalTXs(t *testing.T) {
 			require.Equal(t, p.Ballot.ID(), ballot.ID())
 			return true, nil
 		})
-	th.mm.EXPECT().AddBallot(&p.Ballot).Return(nil).DoAndReturn(
-		func(got *types.Ballot) error {
+	th.mm.EXPECT().AddBallot(&p.Ballot).DoAndReturn(
+		func(got *types.Ballot) (*types.MalfeasanceProof, error) {
 			require.NoError(t, ballots.Add(th.cdb, got))
-			return nil
+			return nil, nil
 		})
 	th.mf.EXPECT().GetProposalTxs(gomock.Any(), p.TxIDs).Return(nil).Times(1)
 	th.mf.EXPECT().RegisterPeerHashes(p2p.NoPeer, collectHashes(*p))
@@ -912,10 +1032,10 @@ func TestProposal_ProposalGossip_Concurrent(t *testing.T) {
 			require.Equal(t, p.Ballot.ID(), ballot.ID())
 			return true, nil
 		}).MinTimes(1).MaxTimes(2)
-	th.mm.EXPECT().AddBallot(&p.Ballot).Return(nil).DoAndReturn(
-		func(got *types.Ballot) error {
+	th.mm.EXPECT().AddBallot(&p.Ballot).DoAndReturn(
+		func(got *types.Ballot) (*types.MalfeasanceProof, error) {
 			_ = ballots.Add(th.cdb, got)
-			return nil
+			return nil, nil
 		}).MinTimes(1).MaxTimes(2)
 	th.mf.EXPECT().GetProposalTxs(gomock.Any(), p.TxIDs).Return(nil).MinTimes(1).MaxTimes(2)
 	th.mm.EXPECT().AddTXsFromProposal(gomock.Any(), p.LayerIndex, p.ID(), p.TxIDs).Return(nil).Times(1)
@@ -989,7 +1109,7 @@ func TestProposal_ProposalGossip_Fetched(t *testing.T) {
 					}
 					return true, nil
 				})
-			th.mm.EXPECT().AddBallot(&p.Ballot).Return(nil).Return(nil)
+			th.mm.EXPECT().AddBallot(&p.Ballot).Return(nil, nil)
 			th.mf.EXPECT().GetProposalTxs(gomock.Any(), p.TxIDs).Return(nil)
 			if tc.propFetched {
 				require.Equal(t, pubsub.ValidationIgnore, th.HandleProposal(context.TODO(), p2p.NoPeer, data))
@@ -1026,10 +1146,10 @@ func TestProposal_ValidProposal(t *testing.T) {
 			require.Equal(t, p.Ballot.ID(), ballot.ID())
 			return true, nil
 		})
-	th.mm.EXPECT().AddBallot(&p.Ballot).Return(nil).DoAndReturn(
-		func(got *types.Ballot) error {
+	th.mm.EXPECT().AddBallot(&p.Ballot).DoAndReturn(
+		func(got *types.Ballot) (*types.MalfeasanceProof, error) {
 			require.NoError(t, ballots.Add(th.cdb, got))
-			return nil
+			return nil, nil
 		})
 	th.mf.EXPECT().GetProposalTxs(gomock.Any(), p.TxIDs).Return(nil).Times(1)
 	th.mf.EXPECT().RegisterPeerHashes(p2p.NoPeer, collectHashes(*p))
@@ -1061,10 +1181,10 @@ func TestMetrics(t *testing.T) {
 			require.Equal(t, p.Ballot.ID(), ballot.ID())
 			return true, nil
 		})
-	th.mm.EXPECT().AddBallot(&p.Ballot).Return(nil).DoAndReturn(
-		func(got *types.Ballot) error {
+	th.mm.EXPECT().AddBallot(&p.Ballot).DoAndReturn(
+		func(got *types.Ballot) (*types.MalfeasanceProof, error) {
 			require.NoError(t, ballots.Add(th.cdb, got))
-			return nil
+			return nil, nil
 		})
 	th.mf.EXPECT().GetProposalTxs(gomock.Any(), p.TxIDs).Return(nil).Times(1)
 	th.mf.EXPECT().RegisterPeerHashes(p2p.NoPeer, collectHashes(*p))
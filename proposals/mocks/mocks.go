@@ -37,11 +37,12 @@ func (m *MockmeshProvider) EXPECT() *MockmeshProviderMockRecorder {
 }
 
 // AddBallot mocks base method.
-func (m *MockmeshProvider) AddBallot(arg0 *types.Ballot) error {
+func (m *MockmeshProvider) AddBallot(arg0 *types.Ballot) (*types.MalfeasanceProof, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "AddBallot", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(*types.MalfeasanceProof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // AddBallot indicates an expected call of AddBallot.
@@ -1,6 +1,6 @@
 package signing
 
-//go:generate mockgen -package=mocks -destination=./mocks/mocks.go -source=./interfaces.go Signer,Verifier,VerifyExtractor
+//go:generate mockgen -package=mocks -destination=./mocks/mocks.go -source=./interfaces.go Signer,RequestSigner,Verifier,VerifyExtractor
 
 // Signer is a common interface for signature generation.
 type Signer interface {
@@ -9,6 +9,14 @@ type Signer interface {
 	LittleEndian() bool
 }
 
+// RequestSigner signs typed requests. It is implemented by local signers
+// and by signers that keep the keys in a separate process.
+type RequestSigner interface {
+	SignRequest(Request) ([]byte, error)
+	PublicKey() *PublicKey
+	LittleEndian() bool
+}
+
 // Verifier is a common interface for signature verification.
 type Verifier interface {
 	Verify(pub *PublicKey, msg, sig []byte) bool
@@ -76,6 +76,72 @@ func (mr *MockSignerMockRecorder) Sign(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sign", reflect.TypeOf((*MockSigner)(nil).Sign), arg0)
 }
 
+// MockRequestSigner is a mock of RequestSigner interface.
+type MockRequestSigner struct {
+	ctrl     *gomock.Controller
+	recorder *MockRequestSignerMockRecorder
+}
+
+// MockRequestSignerMockRecorder is the mock recorder for MockRequestSigner.
+type MockRequestSignerMockRecorder struct {
+	mock *MockRequestSigner
+}
+
+// NewMockRequestSigner creates a new mock instance.
+func NewMockRequestSigner(ctrl *gomock.Controller) *MockRequestSigner {
+	mock := &MockRequestSigner{ctrl: ctrl}
+	mock.recorder = &MockRequestSignerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRequestSigner) EXPECT() *MockRequestSignerMockRecorder {
+	return m.recorder
+}
+
+// LittleEndian mocks base method.
+func (m *MockRequestSigner) LittleEndian() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LittleEndian")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// LittleEndian indicates an expected call of LittleEndian.
+func (mr *MockRequestSignerMockRecorder) LittleEndian() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LittleEndian", reflect.TypeOf((*MockRequestSigner)(nil).LittleEndian))
+}
+
+// PublicKey mocks base method.
+func (m *MockRequestSigner) PublicKey() *signing.PublicKey {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublicKey")
+	ret0, _ := ret[0].(*signing.PublicKey)
+	return ret0
+}
+
+// PublicKey indicates an expected call of PublicKey.
+func (mr *MockRequestSignerMockRecorder) PublicKey() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublicKey", reflect.TypeOf((*MockRequestSigner)(nil).PublicKey))
+}
+
+// SignRequest mocks base method.
+func (m *MockRequestSigner) SignRequest(arg0 signing.Request) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignRequest", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignRequest indicates an expected call of SignRequest.
+func (mr *MockRequestSignerMockRecorder) SignRequest(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignRequest", reflect.TypeOf((*MockRequestSigner)(nil).SignRequest), arg0)
+}
+
 // MockVerifier is a mock of Verifier interface.
 type MockVerifier struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,97 @@
+package remote
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	pb "github.com/spacemeshos/go-spacemesh/api/nodepb"
+	"github.com/spacemeshos/go-spacemesh/signing"
+)
+
+// Config configures the connection to the signer.
+type Config struct {
+	// Socket is the path to the unix socket of the signer. Keys are stored by the node when empty.
+	Socket string `mapstructure:"socket"`
+	// Timeout bounds every request to the signer.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// DefaultConfig returns the default Config.
+func DefaultConfig() Config {
+	return Config{
+		Timeout: 5 * time.Second,
+	}
+}
+
+// Client signs requests on the signer. It serves both regular and vrf domains,
+// so it can be used in place of signing.EdSigner and signing.VRFSigner.
+type Client struct {
+	cfg    Config
+	conn   *grpc.ClientConn
+	client pb.SignerServiceClient
+	pub    *signing.PublicKey
+}
+
+var _ signing.RequestSigner = (*Client)(nil)
+
+// NewClient connects to the signer and fetches the public key of the identity.
+func NewClient(ctx context.Context, cfg Config) (*Client, error) {
+	conn, err := grpc.DialContext(ctx, "unix://"+cfg.Socket, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("dial %s: %w", cfg.Socket, err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+	client := pb.NewSignerServiceClient(conn)
+	resp, err := client.PublicKey(ctx, &emptypb.Empty{})
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("remote public key: %w", err)
+	}
+	return &Client{
+		cfg:    cfg,
+		conn:   conn,
+		client: client,
+		pub:    signing.NewPublicKey(resp.PublicKey),
+	}, nil
+}
+
+// Close closes the connection to the signer.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// PublicKey returns the public key of the identity.
+func (c *Client) PublicKey() *signing.PublicKey {
+	return c.pub
+}
+
+// LittleEndian indicates whether byte order in a signature is little-endian.
+func (c *Client) LittleEndian() bool {
+	return true
+}
+
+// SignRequest signs the request on the signer.
+// Errors wrap ErrEquivocation or ErrBelowHighWaterMark if the signer refused to sign.
+func (c *Client) SignRequest(req signing.Request) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.cfg.Timeout)
+	defer cancel()
+	resp, err := c.client.Sign(ctx, toPb(req))
+	if err != nil {
+		switch status.Code(err) {
+		case codes.FailedPrecondition:
+			return nil, fmt.Errorf("%w: %s", ErrEquivocation, status.Convert(err).Message())
+		case codes.OutOfRange:
+			return nil, fmt.Errorf("%w: %s", ErrBelowHighWaterMark, status.Convert(err).Message())
+		}
+		return nil, fmt.Errorf("remote sign %s: %w", req.Domain, err)
+	}
+	return resp.Signature, nil
+}
@@ -0,0 +1,39 @@
+package remote
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+)
+
+// Listen listens on the unix socket. The socket is created in a directory that is only accessible
+// by the owner, so that other users can't connect to the socket before its permissions are restricted.
+// The directory is created if it doesn't exist. A stale socket left by a previous run is removed.
+func Listen(socket string) (net.Listener, error) {
+	dir := filepath.Dir(socket)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create socket directory: %w", err)
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("stat socket directory: %w", err)
+	}
+	if perm := info.Mode().Perm(); perm&0o077 != 0 {
+		return nil, fmt.Errorf("socket directory %s is accessible by other users (%s), expected %s",
+			dir, perm, os.FileMode(0o700))
+	}
+	if err := os.Remove(socket); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("remove stale socket: %w", err)
+	}
+	lis, err := net.Listen("unix", socket)
+	if err != nil {
+		return nil, fmt.Errorf("listen: %w", err)
+	}
+	if err := os.Chmod(socket, 0o600); err != nil {
+		lis.Close()
+		return nil, fmt.Errorf("restrict socket permissions: %w", err)
+	}
+	return lis, nil
+}
@@ -0,0 +1,67 @@
+package remote
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/hare"
+	"github.com/spacemeshos/go-spacemesh/signing"
+)
+
+var (
+	// ErrMalformedMessage is returned when the message of a request can't be decoded for its domain.
+	ErrMalformedMessage = errors.New("malformed message")
+	// ErrHeightMismatch is returned when the layer (or epoch) or the round stated in a request
+	// doesn't match the message.
+	ErrHeightMismatch = errors.New("request doesn't match the message")
+)
+
+// decodeHeight decodes the message of a protected request and returns the layer (or epoch)
+// and the round the message was created for. The values stated in the request are not trusted,
+// otherwise an equivocating message could be signed by stating another height.
+func decodeHeight(req signing.Request) (height, round uint32, err error) {
+	switch req.Domain {
+	case signing.ATX:
+		var atx types.InnerActivationTx
+		if err := codec.Decode(req.Message, &atx); err != nil {
+			return 0, 0, fmt.Errorf("%w: %s: %v", ErrMalformedMessage, req.Domain, err)
+		}
+		if types.GetLayersPerEpoch() == 0 {
+			return 0, 0, errors.New("layers per epoch are not set")
+		}
+		height = uint32(atx.PubLayerID.GetEpoch())
+	case signing.BALLOT:
+		var ballot types.InnerBallot
+		if err := codec.Decode(req.Message, &ballot); err != nil {
+			return 0, 0, fmt.Errorf("%w: %s: %v", ErrMalformedMessage, req.Domain, err)
+		}
+		height = ballot.LayerIndex.Value
+	case signing.PROPOSAL:
+		var proposal types.InnerProposal
+		if err := codec.Decode(req.Message, &proposal); err != nil {
+			return 0, 0, fmt.Errorf("%w: %s: %v", ErrMalformedMessage, req.Domain, err)
+		}
+		height = proposal.LayerIndex.Value
+	case signing.HARE:
+		var msg hare.InnerMessage
+		if err := codec.Decode(req.Message, &msg); err != nil {
+			return 0, 0, fmt.Errorf("%w: %s: %v", ErrMalformedMessage, req.Domain, err)
+		}
+		height, round = msg.InstanceID.Value, msg.K
+	case signing.CERTIFICATE:
+		var content types.CertifyContent
+		if err := codec.Decode(req.Message, &content); err != nil {
+			return 0, 0, fmt.Errorf("%w: %s: %v", ErrMalformedMessage, req.Domain, err)
+		}
+		height = content.LayerID.Value
+	default:
+		return 0, 0, fmt.Errorf("%w: %s", signing.ErrUnsupportedDomain, req.Domain)
+	}
+	if height != req.Height() || round != req.Round {
+		return 0, 0, fmt.Errorf("%w: %s stated at %d round %d, message at %d round %d",
+			ErrHeightMismatch, req.Domain, req.Height(), req.Round, height, round)
+	}
+	return height, round, nil
+}
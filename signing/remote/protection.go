@@ -0,0 +1,340 @@
+package remote
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/spacemeshos/go-spacemesh/hash"
+	"github.com/spacemeshos/go-spacemesh/signing"
+)
+
+const (
+	protectionVersion = 2
+
+	// a record is the domain, the height, the round and the hash of a signed message.
+	recordSize = 1 + 4 + 4 + 32
+	// log is compacted when it has this many more records than the ones that are retained.
+	compactThreshold = 4096
+)
+
+var (
+	// ErrEquivocation is returned when a request conflicts with a message that was signed before.
+	ErrEquivocation = errors.New("refusing to sign equivocating message")
+	// ErrBelowHighWaterMark is returned when a request is too old to be checked against the signed messages.
+	ErrBelowHighWaterMark = errors.New("request is below the high-water mark")
+)
+
+// DefaultWindow is the default number of layers (or epochs, for epoch scoped domains)
+// below the high-water mark for which signed messages are retained.
+const DefaultWindow = 1000
+
+// Protected returns true if equivocating messages of the domain are refused.
+// Vrf signatures are deterministic and can't equivocate.
+func Protected(domain signing.Domain) bool {
+	switch domain {
+	case signing.ATX, signing.BALLOT, signing.PROPOSAL, signing.HARE, signing.CERTIFICATE:
+		return true
+	default:
+		return false
+	}
+}
+
+type signedMessage struct {
+	Height uint32
+	Round  uint32
+	Hash   [32]byte
+}
+
+type domainRecord struct {
+	HighWaterMark uint32
+	Signed        []signedMessage
+}
+
+// Protection is a slashing protection database.
+// For every protected domain it tracks the highest layer (or epoch) it signed a message for
+// and the hashes of the messages signed within the window below that high-water mark.
+// A request is refused if a different message was signed for the same layer and round,
+// or if it is too far below the high-water mark to be checked.
+//
+// Signed messages are appended to a log that is synced before a request is allowed,
+// so the database survives restarts of the signer. The log is replayed on start and
+// compacted to the retained messages once it grows large enough.
+type Protection struct {
+	path   string
+	window uint32
+
+	mu      sync.Mutex
+	log     *os.File
+	logged  int
+	domains map[signing.Domain]*domainRecord
+}
+
+// ProtectionOpt modifies Protection.
+type ProtectionOpt func(*Protection)
+
+// WithWindow sets the number of layers below the high-water mark for which signed messages are retained.
+func WithWindow(window uint32) ProtectionOpt {
+	return func(p *Protection) {
+		p.window = window
+	}
+}
+
+// NewProtection loads the slashing protection database from path. The database is created if it doesn't exist.
+func NewProtection(path string, opts ...ProtectionOpt) (*Protection, error) {
+	p := &Protection{
+		path:    path,
+		window:  DefaultWindow,
+		domains: map[signing.Domain]*domainRecord{},
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open slashing protection database: %w", err)
+	}
+	if err := p.replay(f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("replay slashing protection database %s: %w", path, err)
+	}
+	p.log = f
+	if err := p.compact(); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return p, nil
+}
+
+// Close closes the database.
+func (p *Protection) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.log.Close()
+}
+
+// HighWaterMark returns the highest layer (or epoch) a message of the domain was signed for.
+func (p *Protection) HighWaterMark(domain signing.Domain) uint32 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if record, ok := p.domains[domain]; ok {
+		return record.HighWaterMark
+	}
+	return 0
+}
+
+// Check returns nil if the request can be signed without equivocating and records it.
+// The layer (or epoch) and the round are decoded from the message and must match the ones stated in the request.
+// Requests of domains that are not protected are always allowed.
+func (p *Protection) Check(req signing.Request) error {
+	if !Protected(req.Domain) {
+		return nil
+	}
+	height, round, err := decodeHeight(req)
+	if err != nil {
+		return err
+	}
+	msg := signedMessage{Height: height, Round: round, Hash: hash.Sum(req.Message)}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	record, ok := p.domains[req.Domain]
+	if !ok {
+		record = &domainRecord{}
+		p.domains[req.Domain] = record
+	}
+	if record.HighWaterMark >= p.window && height <= record.HighWaterMark-p.window {
+		return fmt.Errorf("%w: %s at %d, high-water mark %d", ErrBelowHighWaterMark, req.Domain, height, record.HighWaterMark)
+	}
+	for _, signed := range record.Signed {
+		if signed.Height != height || signed.Round != round {
+			continue
+		}
+		if signed.Hash == msg.Hash {
+			// same message can be signed again, e.g. after a restart of the node
+			return nil
+		}
+		return fmt.Errorf("%w: %s at %d round %d", ErrEquivocation, req.Domain, height, round)
+	}
+	if err := p.append(req.Domain, msg); err != nil {
+		return err
+	}
+	p.logged++
+	record.add(msg, p.window)
+	if p.logged > p.retained()+compactThreshold {
+		return p.compact()
+	}
+	return nil
+}
+
+func (r *domainRecord) add(msg signedMessage, window uint32) {
+	r.Signed = append(r.Signed, msg)
+	if msg.Height > r.HighWaterMark {
+		r.HighWaterMark = msg.Height
+		r.prune(window)
+	}
+}
+
+func (r *domainRecord) prune(window uint32) {
+	if r.HighWaterMark < window {
+		return
+	}
+	retained := r.Signed[:0]
+	for _, signed := range r.Signed {
+		if signed.Height > r.HighWaterMark-window {
+			retained = append(retained, signed)
+		}
+	}
+	r.Signed = retained
+	sort.Slice(r.Signed, func(i, j int) bool {
+		if r.Signed[i].Height != r.Signed[j].Height {
+			return r.Signed[i].Height < r.Signed[j].Height
+		}
+		return r.Signed[i].Round < r.Signed[j].Round
+	})
+}
+
+func (p *Protection) retained() int {
+	n := 0
+	for _, record := range p.domains {
+		n += len(record.Signed)
+	}
+	return n
+}
+
+func encodeRecord(buf []byte, domain signing.Domain, msg signedMessage) {
+	buf[0] = byte(domain)
+	binary.BigEndian.PutUint32(buf[1:], msg.Height)
+	binary.BigEndian.PutUint32(buf[5:], msg.Round)
+	copy(buf[9:], msg.Hash[:])
+}
+
+// append writes the record to the end of the log and syncs it.
+func (p *Protection) append(domain signing.Domain, msg signedMessage) error {
+	var buf [recordSize]byte
+	encodeRecord(buf[:], domain, msg)
+	if _, err := p.log.Write(buf[:]); err != nil {
+		return fmt.Errorf("write slashing protection database: %w", err)
+	}
+	if err := p.log.Sync(); err != nil {
+		return fmt.Errorf("sync slashing protection database: %w", err)
+	}
+	return nil
+}
+
+// replay loads the records of the log. A partially written record at the end of the log,
+// left by a crash, is truncated, as it was never allowed.
+func (p *Protection) replay(f *os.File) error {
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size() == 0 {
+		// header is written by compact
+		return nil
+	}
+	rd := bufio.NewReader(f)
+	version, err := rd.ReadByte()
+	if err != nil {
+		return err
+	}
+	if version != protectionVersion {
+		return fmt.Errorf("unsupported version %d", version)
+	}
+	var (
+		buf   [recordSize]byte
+		valid = int64(1)
+	)
+	for {
+		if _, err := io.ReadFull(rd, buf[:]); errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		} else if err != nil {
+			return err
+		}
+		domain := signing.Domain(buf[0])
+		if !Protected(domain) {
+			return fmt.Errorf("record of unprotected domain %s at offset %d", domain, valid)
+		}
+		msg := signedMessage{
+			Height: binary.BigEndian.Uint32(buf[1:]),
+			Round:  binary.BigEndian.Uint32(buf[5:]),
+		}
+		copy(msg.Hash[:], buf[9:])
+		record, ok := p.domains[domain]
+		if !ok {
+			record = &domainRecord{}
+			p.domains[domain] = record
+		}
+		record.add(msg, p.window)
+		p.logged++
+		valid += recordSize
+	}
+	if valid != info.Size() {
+		if err := f.Truncate(valid); err != nil {
+			return err
+		}
+	}
+	_, err = f.Seek(valid, io.SeekStart)
+	return err
+}
+
+// compact atomically replaces the log with the retained records.
+func (p *Protection) compact() error {
+	if p.logged != 0 && p.logged <= p.retained()+compactThreshold {
+		return nil
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(p.path), filepath.Base(p.path)+".tmp")
+	if err != nil {
+		return fmt.Errorf("create slashing protection database: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	wr := bufio.NewWriter(tmp)
+	wr.WriteByte(protectionVersion)
+	var (
+		buf    [recordSize]byte
+		logged int
+	)
+	for domain, record := range p.domains {
+		for _, msg := range record.Signed {
+			encodeRecord(buf[:], domain, msg)
+			wr.Write(buf[:])
+			logged++
+		}
+	}
+	if err := wr.Flush(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write slashing protection database: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("sync slashing protection database: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), p.path); err != nil {
+		tmp.Close()
+		return fmt.Errorf("replace slashing protection database: %w", err)
+	}
+	if err := syncDir(filepath.Dir(p.path)); err != nil {
+		tmp.Close()
+		return fmt.Errorf("sync slashing protection database directory: %w", err)
+	}
+	p.log.Close()
+	p.log = tmp
+	p.logged = logged
+	return nil
+}
+
+func syncDir(path string) error {
+	dir, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+	return dir.Sync()
+}
@@ -0,0 +1,107 @@
+// Package remote keeps the identity key of a node in a separate signing process.
+// The signer serves typed signing requests over a local unix socket and refuses
+// to sign messages that equivocate with messages it signed before (see Protection).
+package remote
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	pb "github.com/spacemeshos/go-spacemesh/api/nodepb"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/signing"
+)
+
+func toPb(req signing.Request) *pb.SignRequest {
+	return &pb.SignRequest{
+		Domain:  uint32(req.Domain),
+		Layer:   req.Layer,
+		Epoch:   req.Epoch,
+		Round:   req.Round,
+		Message: req.Message,
+	}
+}
+
+func fromPb(req *pb.SignRequest) (signing.Request, error) {
+	if req.Domain > math.MaxUint8 || !signing.Domain(req.Domain).Valid() {
+		return signing.Request{}, status.Errorf(codes.InvalidArgument, "%v: %d", signing.ErrUnsupportedDomain, req.Domain)
+	}
+	return signing.Request{
+		Domain:  signing.Domain(req.Domain),
+		Layer:   req.Layer,
+		Epoch:   req.Epoch,
+		Round:   req.Round,
+		Message: req.Message,
+	}, nil
+}
+
+// Server signs requests with the identity key after checking them against the slashing protection database.
+type Server struct {
+	logger     log.Log
+	signer     *signing.EdSigner
+	vrfSigner  *signing.VRFSigner
+	protection *Protection
+}
+
+// NewServer creates a Server. Requests of vrf domains are signed with the vrf signer derived from signer.
+func NewServer(signer *signing.EdSigner, protection *Protection, logger log.Log) *Server {
+	return &Server{
+		logger:     logger,
+		signer:     signer,
+		vrfSigner:  signer.VRFSigner(),
+		protection: protection,
+	}
+}
+
+// Register registers the signer service on the grpc server.
+func (s *Server) Register(server *grpc.Server) {
+	pb.RegisterSignerServiceServer(server, s)
+}
+
+// PublicKey returns the public key of the identity.
+func (s *Server) PublicKey(context.Context, *emptypb.Empty) (*pb.SignerPublicKey, error) {
+	return &pb.SignerPublicKey{PublicKey: s.signer.PublicKey().Bytes()}, nil
+}
+
+// Sign signs the request and returns the signature.
+func (s *Server) Sign(ctx context.Context, in *pb.SignRequest) (*pb.SignResponse, error) {
+	req, err := fromPb(in)
+	if err != nil {
+		return nil, err
+	}
+	logger := s.logger.WithContext(ctx).WithFields(
+		log.Stringer("domain", req.Domain),
+		log.Uint32("height", req.Height()),
+		log.Uint32("round", req.Round),
+	)
+	if err := s.protection.Check(req); err != nil {
+		logger.With().Warning("refused to sign", log.Err(err))
+		switch {
+		case errors.Is(err, ErrEquivocation):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, ErrBelowHighWaterMark):
+			return nil, status.Error(codes.OutOfRange, err.Error())
+		case errors.Is(err, ErrMalformedMessage), errors.Is(err, ErrHeightMismatch):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+	var sig []byte
+	if req.Domain.VRF() {
+		sig, err = s.vrfSigner.SignRequest(req)
+	} else {
+		sig, err = s.signer.SignRequest(req)
+	}
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	logger.Debug("signed request")
+	return &pb.SignResponse{Signature: sig}, nil
+}
@@ -0,0 +1,384 @@
+package remote
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/spacemeshos/ed25519"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/spacemeshos/go-spacemesh/api/nodepb"
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/hare"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/signing"
+)
+
+const layersPerEpoch = 4
+
+func TestMain(m *testing.M) {
+	types.SetLayersPerEpoch(layersPerEpoch)
+
+	res := m.Run()
+	os.Exit(res)
+}
+
+func encode(tb testing.TB, v codec.Encodable) []byte {
+	tb.Helper()
+	buf, err := codec.Encode(v)
+	require.NoError(tb, err)
+	return buf
+}
+
+// requests for messages of protected domains. messages with different tags at the same height are equivocating.
+
+func ballotRequest(tb testing.TB, layer uint32, tag byte) signing.Request {
+	ballot := &types.InnerBallot{AtxID: types.ATXID{tag}, LayerIndex: types.NewLayerID(layer)}
+	return signing.Request{Domain: signing.BALLOT, Layer: layer, Message: encode(tb, ballot)}
+}
+
+func proposalRequest(tb testing.TB, layer uint32, tag byte) signing.Request {
+	proposal := &types.InnerProposal{
+		Ballot:   types.Ballot{InnerBallot: types.InnerBallot{LayerIndex: types.NewLayerID(layer)}},
+		MeshHash: types.Hash32{tag},
+	}
+	return signing.Request{Domain: signing.PROPOSAL, Layer: layer, Message: encode(tb, proposal)}
+}
+
+func hareRequest(tb testing.TB, layer, round uint32, tag byte) signing.Request {
+	msg := &hare.InnerMessage{InstanceID: types.NewLayerID(layer), K: round, Ki: uint32(tag)}
+	return signing.Request{Domain: signing.HARE, Layer: layer, Round: round, Message: encode(tb, msg)}
+}
+
+func atxRequest(tb testing.TB, epoch uint32, tag byte) signing.Request {
+	atx := &types.InnerActivationTx{
+		NIPostChallenge: types.NIPostChallenge{PubLayerID: types.NewLayerID(epoch * layersPerEpoch)},
+		NumUnits:        uint32(tag),
+	}
+	return signing.Request{Domain: signing.ATX, Epoch: epoch, Message: encode(tb, atx)}
+}
+
+func certificateRequest(tb testing.TB, layer uint32, tag byte) signing.Request {
+	content := &types.CertifyContent{LayerID: types.NewLayerID(layer), BlockID: types.BlockID{tag}}
+	return signing.Request{Domain: signing.CERTIFICATE, Layer: layer, Message: encode(tb, content)}
+}
+
+func TestProtection(t *testing.T) {
+	ballot := func(layer uint32, tag byte) signing.Request {
+		return ballotRequest(t, layer, tag)
+	}
+	hare := func(layer, round uint32, tag byte) signing.Request {
+		return hareRequest(t, layer, round, tag)
+	}
+	for _, tc := range []struct {
+		desc     string
+		requests []signing.Request
+		err      error
+	}{
+		{
+			desc:     "same message",
+			requests: []signing.Request{ballot(10, 1), ballot(10, 1)},
+		},
+		{
+			desc:     "equivocating ballot",
+			requests: []signing.Request{ballot(10, 1), ballot(10, 2)},
+			err:      ErrEquivocation,
+		},
+		{
+			desc:     "next layer",
+			requests: []signing.Request{ballot(10, 1), ballot(11, 2)},
+		},
+		{
+			desc:     "previous layer within window",
+			requests: []signing.Request{ballot(11, 1), ballot(10, 2)},
+		},
+		{
+			desc:     "equivocating previous layer",
+			requests: []signing.Request{ballot(10, 1), ballot(11, 2), ballot(10, 3)},
+			err:      ErrEquivocation,
+		},
+		{
+			desc:     "below high-water mark",
+			requests: []signing.Request{ballot(10, 1), ballot(30, 2), ballot(20, 3)},
+			err:      ErrBelowHighWaterMark,
+		},
+		{
+			desc:     "hare rounds",
+			requests: []signing.Request{hare(10, 0, 1), hare(10, 1, 2), hare(11, 0, 3), hare(10, 2, 4)},
+		},
+		{
+			desc:     "equivocating hare round",
+			requests: []signing.Request{hare(10, 0, 1), hare(10, 1, 2), hare(10, 0, 3)},
+			err:      ErrEquivocation,
+		},
+		{
+			desc:     "equivocating proposal",
+			requests: []signing.Request{proposalRequest(t, 10, 1), proposalRequest(t, 10, 2)},
+			err:      ErrEquivocation,
+		},
+		{
+			desc:     "equivocating atx",
+			requests: []signing.Request{atxRequest(t, 3, 1), atxRequest(t, 3, 2)},
+			err:      ErrEquivocation,
+		},
+		{
+			desc:     "equivocating certificate",
+			requests: []signing.Request{certificateRequest(t, 10, 1), certificateRequest(t, 10, 2)},
+			err:      ErrEquivocation,
+		},
+		{
+			desc:     "domains are independent",
+			requests: []signing.Request{ballot(10, 1), proposalRequest(t, 10, 2)},
+		},
+		{
+			desc: "vrf domains are not protected",
+			requests: []signing.Request{
+				{Domain: signing.HARE_ELIGIBILITY, Layer: 10, Message: []byte("a")},
+				{Domain: signing.HARE_ELIGIBILITY, Layer: 10, Message: []byte("b")},
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			protection, err := NewProtection(filepath.Join(t.TempDir(), "protection.log"), WithWindow(10))
+			require.NoError(t, err)
+			t.Cleanup(func() { require.NoError(t, protection.Close()) })
+			last := len(tc.requests) - 1
+			for _, req := range tc.requests[:last] {
+				require.NoError(t, protection.Check(req))
+			}
+			require.ErrorIs(t, protection.Check(tc.requests[last]), tc.err)
+		})
+	}
+}
+
+func TestProtectionHeightFromMessage(t *testing.T) {
+	stated := func(req signing.Request, layer, epoch, round uint32) signing.Request {
+		req.Layer, req.Epoch, req.Round = layer, epoch, round
+		return req
+	}
+	for _, tc := range []struct {
+		desc string
+		req  signing.Request
+		err  error
+	}{
+		{
+			desc: "ballot layer",
+			req:  stated(ballotRequest(t, 10, 1), 11, 0, 0),
+			err:  ErrHeightMismatch,
+		},
+		{
+			desc: "proposal layer",
+			req:  stated(proposalRequest(t, 10, 1), 9, 0, 0),
+			err:  ErrHeightMismatch,
+		},
+		{
+			desc: "hare layer",
+			req:  stated(hareRequest(t, 10, 2, 1), 11, 0, 2),
+			err:  ErrHeightMismatch,
+		},
+		{
+			desc: "hare round",
+			req:  stated(hareRequest(t, 10, 2, 1), 10, 0, 3),
+			err:  ErrHeightMismatch,
+		},
+		{
+			desc: "atx epoch",
+			req:  stated(atxRequest(t, 3, 1), 0, 4, 0),
+			err:  ErrHeightMismatch,
+		},
+		{
+			desc: "certificate layer",
+			req:  stated(certificateRequest(t, 10, 1), 12, 0, 0),
+			err:  ErrHeightMismatch,
+		},
+		{
+			desc: "malformed",
+			req:  signing.Request{Domain: signing.BALLOT, Layer: 10, Message: []byte("ballot")},
+			err:  ErrMalformedMessage,
+		},
+	} {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			protection, err := NewProtection(filepath.Join(t.TempDir(), "protection.log"))
+			require.NoError(t, err)
+			t.Cleanup(func() { require.NoError(t, protection.Close()) })
+			require.ErrorIs(t, protection.Check(tc.req), tc.err)
+			require.Zero(t, protection.HighWaterMark(tc.req.Domain))
+		})
+	}
+}
+
+func TestProtectionPersisted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "protection.log")
+	protection, err := NewProtection(path, WithWindow(10))
+	require.NoError(t, err)
+	req := atxRequest(t, 3, 1)
+	require.NoError(t, protection.Check(req))
+	for layer := uint32(1); layer <= 30; layer++ {
+		require.NoError(t, protection.Check(certificateRequest(t, layer, byte(layer))))
+	}
+	require.NoError(t, protection.Close())
+
+	reopened, err := NewProtection(path, WithWindow(10))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, reopened.Close()) })
+	require.EqualValues(t, 3, reopened.HighWaterMark(signing.ATX))
+	require.EqualValues(t, 30, reopened.HighWaterMark(signing.CERTIFICATE))
+	require.Len(t, reopened.domains[signing.CERTIFICATE].Signed, 10)
+
+	require.NoError(t, reopened.Check(req))
+	require.ErrorIs(t, reopened.Check(atxRequest(t, 3, 2)), ErrEquivocation)
+}
+
+func TestProtectionLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "protection.log")
+	protection, err := NewProtection(path, WithWindow(10))
+	require.NoError(t, err)
+	for layer := uint32(1); layer <= 5; layer++ {
+		require.NoError(t, protection.Check(ballotRequest(t, layer, 1)))
+	}
+	require.NoError(t, protection.Close())
+
+	t.Run("records are appended", func(t *testing.T) {
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		require.EqualValues(t, 1+5*recordSize, info.Size())
+	})
+
+	t.Run("partial record is truncated", func(t *testing.T) {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+		require.NoError(t, err)
+		_, err = f.Write(make([]byte, recordSize/2))
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+
+		reopened, err := NewProtection(path, WithWindow(10))
+		require.NoError(t, err)
+		require.EqualValues(t, 5, reopened.HighWaterMark(signing.BALLOT))
+		require.NoError(t, reopened.Check(ballotRequest(t, 6, 1)))
+		require.NoError(t, reopened.Close())
+
+		reopened, err = NewProtection(path, WithWindow(10))
+		require.NoError(t, err)
+		require.EqualValues(t, 6, reopened.HighWaterMark(signing.BALLOT))
+		require.ErrorIs(t, reopened.Check(ballotRequest(t, 6, 2)), ErrEquivocation)
+		require.NoError(t, reopened.Close())
+	})
+
+	t.Run("compacted", func(t *testing.T) {
+		reopened, err := NewProtection(path, WithWindow(10))
+		require.NoError(t, err)
+		layers := uint32(compactThreshold + 100)
+		for layer := uint32(7); layer <= layers; layer++ {
+			require.NoError(t, reopened.Check(ballotRequest(t, layer, 1)))
+		}
+		require.Less(t, reopened.logged, 2*compactThreshold)
+		require.NoError(t, reopened.Close())
+
+		reopened, err = NewProtection(path, WithWindow(10))
+		require.NoError(t, err)
+		defer reopened.Close()
+		require.Equal(t, layers, reopened.HighWaterMark(signing.BALLOT))
+		require.Len(t, reopened.domains[signing.BALLOT].Signed, 10)
+		require.ErrorIs(t, reopened.Check(ballotRequest(t, layers, 2)), ErrEquivocation)
+	})
+}
+
+func TestListen(t *testing.T) {
+	dir := t.TempDir()
+	socket := filepath.Join(dir, "signer", "signer.sock")
+	lis, err := Listen(socket)
+	require.NoError(t, err)
+	require.NoError(t, lis.Close())
+
+	info, err := os.Stat(filepath.Dir(socket))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o700), info.Mode().Perm())
+
+	shared := filepath.Join(dir, "shared")
+	require.NoError(t, os.Mkdir(shared, 0o700))
+	require.NoError(t, os.Chmod(shared, 0o755))
+	_, err = Listen(filepath.Join(shared, "signer.sock"))
+	require.Error(t, err)
+}
+
+func TestClientServer(t *testing.T) {
+	signer := signing.NewEdSigner(signing.WithSignerPrefix([]byte("genesis")))
+	protection, err := NewProtection(filepath.Join(t.TempDir(), "protection.log"))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, protection.Close()) })
+
+	socket := filepath.Join(t.TempDir(), "signer", "signer.sock")
+	lis, err := Listen(socket)
+	require.NoError(t, err)
+	var (
+		mu          sync.Mutex
+		intercepted []string
+	)
+	server := grpc.NewServer(grpc.UnaryInterceptor(
+		func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			mu.Lock()
+			intercepted = append(intercepted, info.FullMethod)
+			mu.Unlock()
+			return handler(ctx, req)
+		}))
+	NewServer(signer, protection, logtest.New(t)).Register(server)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	cfg := DefaultConfig()
+	cfg.Socket = socket
+	client, err := NewClient(context.Background(), cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, client.Close()) })
+	require.Equal(t, signer.PublicKey().Bytes(), client.PublicKey().Bytes())
+
+	req := ballotRequest(t, 7, 1)
+	sig, err := client.SignRequest(req)
+	require.NoError(t, err)
+	local, err := signer.SignRequest(req)
+	require.NoError(t, err)
+	require.Equal(t, local, sig)
+	require.True(t, ed25519.Verify2(ed25519.PublicKey(signer.PublicKey().Bytes()), append([]byte("genesis"), req.Message...), sig))
+
+	vrfReq := signing.Request{Domain: signing.PROPOSAL_ELIGIBILITY, Epoch: 2, Message: []byte("eligibility")}
+	sig, err = client.SignRequest(vrfReq)
+	require.NoError(t, err)
+	require.True(t, signing.VRFVerify(signer.PublicKey().Bytes(), vrfReq.Message, sig))
+
+	_, err = client.SignRequest(ballotRequest(t, 7, 2))
+	require.ErrorIs(t, err, ErrEquivocation)
+	mismatch := ballotRequest(t, 8, 1)
+	mismatch.Layer = 9
+	_, err = client.SignRequest(mismatch)
+	require.ErrorContains(t, err, ErrHeightMismatch.Error())
+	require.EqualValues(t, 7, protection.HighWaterMark(signing.BALLOT))
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Contains(t, intercepted, "/spacemesh.node.v1.SignerService/PublicKey")
+	require.Contains(t, intercepted, "/spacemesh.node.v1.SignerService/Sign")
+}
+
+func TestServerRejectsUnknownDomain(t *testing.T) {
+	signer := signing.NewEdSigner()
+	protection, err := NewProtection(filepath.Join(t.TempDir(), "protection.log"))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, protection.Close()) })
+	server := NewServer(signer, protection, logtest.New(t))
+
+	for _, domain := range []uint32{255, 256 + uint32(signing.BALLOT)} {
+		_, err := server.Sign(context.Background(), &pb.SignRequest{Domain: domain, Message: []byte("msg")})
+		require.Equal(t, codes.InvalidArgument, status.Code(err), "domain %d", domain)
+	}
+}
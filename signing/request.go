@@ -0,0 +1,114 @@
+package signing
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnsupportedDomain is returned when a signer is asked to sign a request of a domain it doesn't serve.
+var ErrUnsupportedDomain = errors.New("unsupported signing domain")
+
+// Domain identifies the kind of message that is signed.
+type Domain uint8
+
+const (
+	// ATX is the domain of activation transactions. Requests specify the publication epoch.
+	ATX Domain = iota + 1
+	// BALLOT is the domain of ballots. Requests specify the layer.
+	BALLOT
+	// PROPOSAL is the domain of proposals. Requests specify the layer.
+	PROPOSAL
+	// HARE is the domain of hare messages. Requests specify the layer and the round.
+	HARE
+	// CERTIFICATE is the domain of block certificates. Requests specify the layer.
+	CERTIFICATE
+	// BEACON is the domain of beacon votes. Requests specify the epoch and the round.
+	BEACON
+
+	// BEACON_PROPOSAL is the vrf domain of beacon proposals. Requests specify the epoch.
+	BEACON_PROPOSAL
+	// WEAK_COIN is the vrf domain of weak coin proposals. Requests specify the epoch and the round.
+	WEAK_COIN
+	// HARE_ELIGIBILITY is the vrf domain of hare eligibility proofs. Requests specify the layer and the round.
+	HARE_ELIGIBILITY
+	// PROPOSAL_ELIGIBILITY is the vrf domain of proposal eligibility proofs. Requests specify the epoch.
+	PROPOSAL_ELIGIBILITY
+)
+
+// String returns the name of the domain.
+func (d Domain) String() string {
+	switch d {
+	case ATX:
+		return "atx"
+	case BALLOT:
+		return "ballot"
+	case PROPOSAL:
+		return "proposal"
+	case HARE:
+		return "hare"
+	case CERTIFICATE:
+		return "certificate"
+	case BEACON:
+		return "beacon"
+	case BEACON_PROPOSAL:
+		return "beacon_proposal"
+	case WEAK_COIN:
+		return "weak_coin"
+	case HARE_ELIGIBILITY:
+		return "hare_eligibility"
+	case PROPOSAL_ELIGIBILITY:
+		return "proposal_eligibility"
+	default:
+		return fmt.Sprintf("domain(%d)", uint8(d))
+	}
+}
+
+// VRF returns true if messages of the domain are signed with the vrf signer.
+func (d Domain) VRF() bool {
+	return d >= BEACON_PROPOSAL && d <= PROPOSAL_ELIGIBILITY
+}
+
+// Valid returns true if the domain is known.
+func (d Domain) Valid() bool {
+	return d >= ATX && d <= PROPOSAL_ELIGIBILITY
+}
+
+// Request is a typed signing request.
+// Layer and epoch are stated separately from the message. A signer that refuses to sign
+// equivocating messages must check them against the decoded message.
+type Request struct {
+	Domain Domain
+	// Layer is set for layer scoped domains.
+	Layer uint32
+	// Epoch is set for epoch scoped domains.
+	Epoch uint32
+	// Round is set for domains with several messages per layer or epoch.
+	Round   uint32
+	Message []byte
+}
+
+// Height returns the layer or the epoch of the request, depending on the domain.
+func (r *Request) Height() uint32 {
+	switch r.Domain {
+	case ATX, BEACON, BEACON_PROPOSAL, WEAK_COIN, PROPOSAL_ELIGIBILITY:
+		return r.Epoch
+	default:
+		return r.Layer
+	}
+}
+
+// SignRequest signs the message of the request. Requests of vrf domains are not supported, use VRFSigner instead.
+func (es *EdSigner) SignRequest(req Request) ([]byte, error) {
+	if !req.Domain.Valid() || req.Domain.VRF() {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedDomain, req.Domain)
+	}
+	return es.Sign(req.Message), nil
+}
+
+// SignRequest computes the vrf signature of the message of the request. Only requests of vrf domains are supported.
+func (s VRFSigner) SignRequest(req Request) ([]byte, error) {
+	if !req.Domain.VRF() {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedDomain, req.Domain)
+	}
+	return s.Sign(req.Message), nil
+}
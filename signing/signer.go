@@ -65,7 +65,10 @@ func (p *PublicKey) Equals(o *PublicKey) bool {
 	return bytes.Equal(p.Bytes(), o.Bytes())
 }
 
-var _ Signer = (*EdSigner)(nil)
+var (
+	_ Signer        = (*EdSigner)(nil)
+	_ RequestSigner = (*EdSigner)(nil)
+)
 
 // EdSigner represents an ED25519 signer.
 type EdSigner struct {
@@ -74,3 +74,21 @@ func TestPrefix(t *testing.T) {
 		require.Equal(t, pub.Bytes(), signer.PublicKey().Bytes())
 	})
 }
+
+func TestSignRequest(t *testing.T) {
+	ed := NewEdSigner()
+	vrf := ed.VRFSigner()
+	m := []byte("message")
+
+	sig, err := ed.SignRequest(Request{Domain: BALLOT, Layer: 1, Message: m})
+	require.NoError(t, err)
+	require.Equal(t, ed.Sign(m), sig)
+	_, err = ed.SignRequest(Request{Domain: WEAK_COIN, Epoch: 1, Message: m})
+	require.ErrorIs(t, err, ErrUnsupportedDomain)
+
+	sig, err = vrf.SignRequest(Request{Domain: WEAK_COIN, Epoch: 1, Message: m})
+	require.NoError(t, err)
+	require.True(t, VRFVerify(vrf.PublicKey().Bytes(), m, sig))
+	_, err = vrf.SignRequest(Request{Domain: BALLOT, Layer: 1, Message: m})
+	require.ErrorIs(t, err, ErrUnsupportedDomain)
+}
@@ -5,7 +5,10 @@ import (
 	"github.com/oasisprotocol/curve25519-voi/primitives/ed25519/extra/ecvrf"
 )
 
-var _ Signer = VRFSigner{}
+var (
+	_ Signer        = VRFSigner{}
+	_ RequestSigner = VRFSigner{}
+)
 
 // VRFSigner is a signer for VRF purposes.
 type VRFSigner struct {
@@ -72,6 +72,25 @@ func GetTimestamp(db sql.Executor, id types.ATXID) (timestamp time.Time, err err
 	return timestamp, err
 }
 
+// GetNodeID gets the node ID of the smesher that published the ATX.
+func GetNodeID(db sql.Executor, id types.ATXID) (nodeID types.NodeID, err error) {
+	enc := func(stmt *sql.Statement) {
+		stmt.BindBytes(1, id.Bytes())
+	}
+	dec := func(stmt *sql.Statement) bool {
+		stmt.ColumnBytes(0, nodeID[:])
+		return true
+	}
+
+	if rows, err := db.Exec("select smesher from atxs where id = ?1;", enc, dec); err != nil {
+		return types.NodeID{}, fmt.Errorf("exec id %v: %w", id, err)
+	} else if rows == 0 {
+		return types.NodeID{}, fmt.Errorf("exec id %s: %w", id, sql.ErrNotFound)
+	}
+
+	return nodeID, err
+}
+
 // GetFirstIDByNodeID gets the initial ATX ID for a given node ID.
 func GetFirstIDByNodeID(db sql.Executor, nodeID types.NodeID) (id types.ATXID, err error) {
 	enc := func(stmt *sql.Statement) {
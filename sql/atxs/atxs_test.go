@@ -91,6 +91,22 @@ func TestGetTimestampByID(t *testing.T) {
 	require.ErrorIs(t, err, sql.ErrNotFound)
 }
 
+func TestGetNodeIDByID(t *testing.T) {
+	db := sql.InMemory()
+
+	sig := signing.NewEdSigner()
+	atx, err := newAtx(sig, types.NewLayerID(uint32(0)))
+	require.NoError(t, err)
+	require.NoError(t, Add(db, atx, time.Now()))
+
+	nodeID, err := GetNodeID(db, atx.ID())
+	require.NoError(t, err)
+	require.Equal(t, types.BytesToNodeID(sig.PublicKey().Bytes()), nodeID)
+
+	_, err = GetNodeID(db, types.ATXID(types.CalcHash32([]byte("0"))))
+	require.ErrorIs(t, err, sql.ErrNotFound)
+}
+
 func TestGetFirstIDByNodeID(t *testing.T) {
 	db := sql.InMemory()
 
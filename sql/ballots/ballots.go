@@ -141,6 +141,33 @@ func CountByPubkeyLayer(db sql.Executor, lid types.LayerID, pubkey []byte) (int,
 	return rows, nil
 }
 
+// ByPubkeyLayer returns ballots in the layer that were created by the pubkey.
+func ByPubkeyLayer(db sql.Executor, lid types.LayerID, pubkey []byte) (rst []*types.Ballot, err error) {
+	if _, err = db.Exec(`select id, pubkey, ballot, identities.malicious
+		from ballots left join identities using(pubkey)
+		where layer = ?1 and pubkey = ?2;`, func(stmt *sql.Statement) {
+		stmt.BindInt64(1, int64(lid.Value))
+		stmt.BindBytes(2, pubkey)
+	}, func(stmt *sql.Statement) bool {
+		id := types.BallotID{}
+		stmt.ColumnBytes(0, id[:])
+		var ballot *types.Ballot
+		ballot, err = decodeBallot(id,
+			stmt.ColumnReader(1),
+			stmt.ColumnReader(2),
+			stmt.ColumnInt(3) > 0,
+		)
+		if err != nil {
+			return false
+		}
+		rst = append(rst, ballot)
+		return true
+	}); err != nil {
+		return nil, fmt.Errorf("ballots for layer %s and pubkey 0x%x: %w", lid, pubkey, err)
+	}
+	return rst, err
+}
+
 // GetRefBallot gets a ref ballot for a layer and a pubkey.
 func GetRefBallot(db sql.Executor, epochID types.EpochID, pubkey []byte) (ballotID types.BallotID, err error) {
 	firstLayer := epochID.FirstLayer()
@@ -179,3 +206,18 @@ func LatestLayer(db sql.Executor) (types.LayerID, error) {
 	}
 	return lid, nil
 }
+
+// LayerOf returns the layer of the ballot.
+func LayerOf(db sql.Executor, id types.BallotID) (lid types.LayerID, err error) {
+	if rows, err := db.Exec("select layer from ballots where id = ?1;", func(stmt *sql.Statement) {
+		stmt.BindBytes(1, id.Bytes())
+	}, func(stmt *sql.Statement) bool {
+		lid = types.NewLayerID(uint32(stmt.ColumnInt64(0)))
+		return false
+	}); err != nil {
+		return types.LayerID{}, fmt.Errorf("layer of %s: %w", id, err)
+	} else if rows == 0 {
+		return types.LayerID{}, fmt.Errorf("%w ballot %s", sql.ErrNotFound, id)
+	}
+	return lid, nil
+}
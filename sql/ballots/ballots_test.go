@@ -42,6 +42,29 @@ func TestLayer(t *testing.T) {
 	}
 }
 
+func TestByPubkeyLayer(t *testing.T) {
+	db := sql.InMemory()
+	lid := types.NewLayerID(1)
+	pub1, pub2 := []byte{1, 1, 1}, []byte{2, 2, 2}
+	ballots := []types.Ballot{
+		types.NewExistingBallot(types.BallotID{1}, nil, pub1, types.InnerBallot{LayerIndex: lid}),
+		types.NewExistingBallot(types.BallotID{2}, nil, pub1, types.InnerBallot{LayerIndex: lid}),
+		types.NewExistingBallot(types.BallotID{3}, nil, pub2, types.InnerBallot{LayerIndex: lid}),
+		types.NewExistingBallot(types.BallotID{4}, nil, pub1, types.InnerBallot{LayerIndex: lid.Add(1)}),
+	}
+	for _, ballot := range ballots {
+		require.NoError(t, Add(db, &ballot))
+	}
+
+	rst, err := ByPubkeyLayer(db, lid, pub1)
+	require.NoError(t, err)
+	require.Equal(t, []*types.Ballot{&ballots[0], &ballots[1]}, rst)
+
+	rst, err = ByPubkeyLayer(db, lid.Add(2), pub1)
+	require.NoError(t, err)
+	require.Empty(t, rst)
+}
+
 func TestAdd(t *testing.T) {
 	db := sql.InMemory()
 	pub := []byte{1, 1}
@@ -78,6 +101,21 @@ func TestHas(t *testing.T) {
 	require.True(t, exists)
 }
 
+func TestLayerOf(t *testing.T) {
+	db := sql.InMemory()
+	lid := types.NewLayerID(10)
+	ballot := types.NewExistingBallot(types.BallotID{1}, []byte{}, []byte{},
+		types.InnerBallot{LayerIndex: lid})
+
+	_, err := LayerOf(db, ballot.ID())
+	require.ErrorIs(t, err, sql.ErrNotFound)
+
+	require.NoError(t, Add(db, &ballot))
+	got, err := LayerOf(db, ballot.ID())
+	require.NoError(t, err)
+	require.Equal(t, lid, got)
+}
+
 func TestLatest(t *testing.T) {
 	db := sql.InMemory()
 	latest, err := LatestLayer(db)
@@ -41,3 +41,98 @@ func Add(db sql.Executor, epoch types.EpochID, beacon types.Beacon) error {
 
 	return nil
 }
+
+// SetManual sets a beacon for a given epoch, that was provided by the operator instead of the protocol.
+// Beacon that was already set for the epoch is replaced.
+func SetManual(db sql.Executor, epoch types.EpochID, beacon types.Beacon) error {
+	enc := func(stmt *sql.Statement) {
+		stmt.BindInt64(1, int64(epoch))
+		stmt.BindBytes(2, beacon.Bytes())
+	}
+	if _, err := db.Exec(`insert into beacons (epoch, beacon, manual) values (?1, ?2, 1)
+		on conflict(epoch) do update set beacon = ?2, manual = 1;`, enc, nil); err != nil {
+		return fmt.Errorf("set manual epoch %v, beacon %v: %w", epoch, beacon, err)
+	}
+	return nil
+}
+
+// IsManual returns true if the beacon for a given epoch was set by the operator.
+func IsManual(db sql.Executor, epoch types.EpochID) (manual bool, err error) {
+	enc := func(stmt *sql.Statement) {
+		stmt.BindInt64(1, int64(epoch))
+	}
+	dec := func(stmt *sql.Statement) bool {
+		manual = stmt.ColumnInt(0) != 0
+		return false
+	}
+	rows, err := db.Exec("select manual from beacons where epoch = ?1;", enc, dec)
+	if err != nil {
+		return false, fmt.Errorf("is manual epoch %v: %w", epoch, err)
+	}
+	if rows == 0 {
+		return false, fmt.Errorf("is manual epoch %v: %w", epoch, sql.ErrNotFound)
+	}
+	return manual, nil
+}
+
+// SetState saves the checkpoint of the beacon protocol state for the epoch.
+func SetState(db sql.Executor, epoch types.EpochID, state []byte) error {
+	enc := func(stmt *sql.Statement) {
+		stmt.BindInt64(1, int64(epoch))
+		stmt.BindBytes(2, state)
+	}
+	if _, err := db.Exec(`insert into beacon_states (epoch, state) values (?1, ?2)
+		on conflict(epoch) do update set state = ?2;`, enc, nil); err != nil {
+		return fmt.Errorf("set state epoch %v: %w", epoch, err)
+	}
+	return nil
+}
+
+// GetState returns the checkpoint of the beacon protocol state for the epoch.
+func GetState(db sql.Executor, epoch types.EpochID) (state []byte, err error) {
+	enc := func(stmt *sql.Statement) {
+		stmt.BindInt64(1, int64(epoch))
+	}
+	dec := func(stmt *sql.Statement) bool {
+		state = make([]byte, stmt.ColumnLen(0))
+		stmt.ColumnBytes(0, state)
+		return false
+	}
+	rows, err := db.Exec("select state from beacon_states where epoch = ?1;", enc, dec)
+	if err != nil {
+		return nil, fmt.Errorf("get state epoch %v: %w", epoch, err)
+	}
+	if rows == 0 {
+		return nil, fmt.Errorf("get state epoch %v: %w", epoch, sql.ErrNotFound)
+	}
+	return state, nil
+}
+
+// LatestState returns the checkpoint of the beacon protocol state for the latest epoch.
+func LatestState(db sql.Executor) (epoch types.EpochID, state []byte, err error) {
+	dec := func(stmt *sql.Statement) bool {
+		epoch = types.EpochID(uint32(stmt.ColumnInt64(0)))
+		state = make([]byte, stmt.ColumnLen(1))
+		stmt.ColumnBytes(1, state)
+		return false
+	}
+	rows, err := db.Exec("select epoch, state from beacon_states order by epoch desc limit 1;", nil, dec)
+	if err != nil {
+		return 0, nil, fmt.Errorf("latest state: %w", err)
+	}
+	if rows == 0 {
+		return 0, nil, fmt.Errorf("latest state: %w", sql.ErrNotFound)
+	}
+	return epoch, state, nil
+}
+
+// DeleteStatesBefore deletes checkpoints of the beacon protocol state before the epoch.
+func DeleteStatesBefore(db sql.Executor, epoch types.EpochID) error {
+	enc := func(stmt *sql.Statement) {
+		stmt.BindInt64(1, int64(epoch))
+	}
+	if _, err := db.Exec("delete from beacon_states where epoch < ?1;", enc, nil); err != nil {
+		return fmt.Errorf("delete states before epoch %v: %w", epoch, err)
+	}
+	return nil
+}
@@ -48,3 +48,55 @@ func TestAdd(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, beacon, got)
 }
+
+func TestSetManual(t *testing.T) {
+	db := sql.InMemory()
+
+	_, err := IsManual(db, baseEpoch)
+	require.ErrorIs(t, err, sql.ErrNotFound)
+
+	require.NoError(t, Add(db, baseEpoch, types.HexToBeacon("0x1")))
+	manual, err := IsManual(db, baseEpoch)
+	require.NoError(t, err)
+	require.False(t, manual)
+
+	beacon := types.HexToBeacon("0x2")
+	require.NoError(t, SetManual(db, baseEpoch, beacon))
+	require.NoError(t, SetManual(db, baseEpoch+1, beacon))
+	for _, epoch := range []types.EpochID{baseEpoch, baseEpoch + 1} {
+		got, err := Get(db, epoch)
+		require.NoError(t, err)
+		require.Equal(t, beacon, got)
+		manual, err := IsManual(db, epoch)
+		require.NoError(t, err)
+		require.True(t, manual)
+	}
+}
+
+func TestState(t *testing.T) {
+	db := sql.InMemory()
+
+	_, err := GetState(db, baseEpoch)
+	require.ErrorIs(t, err, sql.ErrNotFound)
+	_, _, err = LatestState(db)
+	require.ErrorIs(t, err, sql.ErrNotFound)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, SetState(db, types.EpochID(baseEpoch+i), []byte{byte(i)}))
+	}
+	require.NoError(t, SetState(db, baseEpoch, []byte{1, 2, 3}))
+	state, err := GetState(db, baseEpoch)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 2, 3}, state)
+	epoch, state, err := LatestState(db)
+	require.NoError(t, err)
+	require.Equal(t, types.EpochID(baseEpoch+2), epoch)
+	require.Equal(t, []byte{2}, state)
+
+	require.NoError(t, DeleteStatesBefore(db, baseEpoch+2))
+	_, err = GetState(db, baseEpoch+1)
+	require.ErrorIs(t, err, sql.ErrNotFound)
+	state, err = GetState(db, baseEpoch+2)
+	require.NoError(t, err)
+	require.Equal(t, []byte{2}, state)
+}
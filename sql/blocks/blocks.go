@@ -68,6 +68,24 @@ func Get(db sql.Executor, id types.BlockID) (rst *types.Block, err error) {
 	return rst, err
 }
 
+// GetBlob loads the encoded block with id from database.
+// The block is returned as it was stored, so that it has the same id when it is decoded.
+func GetBlob(db sql.Executor, id []byte) (blk []byte, err error) {
+	if rows, err := db.Exec("select block from blocks where id = ?1;",
+		func(stmt *sql.Statement) {
+			stmt.BindBytes(1, id)
+		}, func(stmt *sql.Statement) bool {
+			blk = make([]byte, stmt.ColumnLen(0))
+			stmt.ColumnBytes(0, blk)
+			return true
+		}); err != nil {
+		return nil, fmt.Errorf("get blob %x: %w", id, err)
+	} else if rows == 0 {
+		return nil, fmt.Errorf("%w block %x", sql.ErrNotFound, id)
+	}
+	return blk, nil
+}
+
 // SetValid updates verified status for a block.
 func SetValid(db sql.Executor, id types.BlockID) error {
 	return setValidity(db, id, valid)
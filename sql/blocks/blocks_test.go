@@ -1,11 +1,14 @@
 package blocks
 
 import (
+	"bytes"
 	"sort"
 	"testing"
 
+	"github.com/spacemeshos/go-scale"
 	"github.com/stretchr/testify/require"
 
+	"github.com/spacemeshos/go-spacemesh/codec"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/sql"
 )
@@ -223,3 +226,85 @@ func TestGetLayer(t *testing.T) {
 		require.Equal(t, b.LayerIndex, lid)
 	}
 }
+
+func TestLegacyEncoding(t *testing.T) {
+	types.SetRewardAtxLayer(types.NewLayerID(2))
+	t.Cleanup(func() { types.SetRewardAtxLayer(types.LayerID{}) })
+
+	db := sql.InMemory()
+	legacy := struct {
+		LayerIndex types.LayerID
+		TickHeight uint64
+		Rewards    []types.AnyRewardV0
+		TxIDs      []types.TransactionID
+	}{
+		LayerIndex: types.NewLayerID(1),
+		TickHeight: 10,
+		Rewards: []types.AnyRewardV0{
+			{Coinbase: types.Address{1}, Weight: types.RatNum{Num: 1, Denom: 2}},
+			{Coinbase: types.Address{2}, Weight: types.RatNum{Num: 1, Denom: 2}},
+		},
+		TxIDs: []types.TransactionID{{1}},
+	}
+	// encoding of the block before rewards referenced atxs
+	var buf bytes.Buffer
+	enc := scale.NewEncoder(&buf)
+	_, err := legacy.LayerIndex.EncodeScale(enc)
+	require.NoError(t, err)
+	_, err = scale.EncodeCompact64(enc, legacy.TickHeight)
+	require.NoError(t, err)
+	_, err = scale.EncodeStructSlice(enc, legacy.Rewards)
+	require.NoError(t, err)
+	_, err = scale.EncodeStructSlice(enc, legacy.TxIDs)
+	require.NoError(t, err)
+	data := buf.Bytes()
+	id := types.BlockID(types.CalcHash32(data).ToHash20())
+	_, err = db.Exec("insert into blocks (id, layer, block) values (?1, ?2, ?3);",
+		func(stmt *sql.Statement) {
+			stmt.BindBytes(1, id.Bytes())
+			stmt.BindInt64(2, int64(legacy.LayerIndex.Uint32()))
+			stmt.BindBytes(3, data)
+		}, nil)
+	require.NoError(t, err)
+
+	expected := types.NewExistingBlock(id, types.InnerBlock{
+		LayerIndex: legacy.LayerIndex,
+		TickHeight: legacy.TickHeight,
+		Rewards: []types.AnyReward{
+			{Coinbase: types.Address{1}, Weight: types.RatNum{Num: 1, Denom: 2}},
+			{Coinbase: types.Address{2}, Weight: types.RatNum{Num: 1, Denom: 2}},
+		},
+		TxIDs: legacy.TxIDs,
+	})
+	got, err := Get(db, id)
+	require.NoError(t, err)
+	require.Equal(t, expected, got)
+
+	blocks, err := Layer(db, legacy.LayerIndex)
+	require.NoError(t, err)
+	require.Equal(t, []*types.Block{expected}, blocks)
+
+	// block is served to peers as it was stored, and has the same id when it is decoded
+	blob, err := GetBlob(db, id.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, data, blob)
+	var decoded types.Block
+	require.NoError(t, codec.Decode(blob, &decoded))
+	decoded.Initialize()
+	require.Equal(t, id, decoded.ID())
+}
+
+func TestGetBlob(t *testing.T) {
+	db := sql.InMemory()
+	block := types.NewExistingBlock(types.BlockID{1}, types.InnerBlock{
+		LayerIndex: types.NewLayerID(1),
+		Rewards:    []types.AnyReward{{Coinbase: types.Address{1}, AtxID: types.ATXID{1}, Weight: types.RatNum{Num: 1, Denom: 1}}},
+	})
+	require.NoError(t, Add(db, block))
+	blob, err := GetBlob(db, block.ID().Bytes())
+	require.NoError(t, err)
+	require.Equal(t, block.Bytes(), blob)
+
+	_, err = GetBlob(db, types.BlockID{2}.Bytes())
+	require.ErrorIs(t, err, sql.ErrNotFound)
+}
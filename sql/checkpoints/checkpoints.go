@@ -0,0 +1,73 @@
+package checkpoints
+
+import (
+	"fmt"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+// Checkpoint is a serialized tortoise state taken after the layer was processed.
+type Checkpoint struct {
+	Layer types.LayerID
+	// Hash of the State, used to detect corrupted checkpoints.
+	Hash  types.Hash32
+	State []byte
+}
+
+// Add checkpoint for the layer. Checkpoint for the same layer is overwritten.
+func Add(db sql.Executor, checkpoint *Checkpoint) error {
+	if _, err := db.Exec(`insert into tortoise_checkpoints (layer, hash, state) values (?1, ?2, ?3)
+		on conflict(layer) do update set hash = ?2, state = ?3;`,
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(checkpoint.Layer.Uint32()))
+			stmt.BindBytes(2, checkpoint.Hash[:])
+			stmt.BindBytes(3, checkpoint.State)
+		}, nil); err != nil {
+		return fmt.Errorf("insert checkpoint %s: %w", checkpoint.Layer, err)
+	}
+	return nil
+}
+
+// Last returns checkpoint for the highest layer.
+func Last(db sql.Executor) (*Checkpoint, error) {
+	var checkpoint *Checkpoint
+	if _, err := db.Exec("select layer, hash, state from tortoise_checkpoints order by layer desc limit 1;", nil,
+		func(stmt *sql.Statement) bool {
+			checkpoint = &Checkpoint{
+				Layer: types.NewLayerID(uint32(stmt.ColumnInt64(0))),
+				State: make([]byte, stmt.ColumnLen(2)),
+			}
+			stmt.ColumnBytes(1, checkpoint.Hash[:])
+			stmt.ColumnBytes(2, checkpoint.State)
+			return false
+		}); err != nil {
+		return nil, fmt.Errorf("last checkpoint: %w", err)
+	}
+	if checkpoint == nil {
+		return nil, fmt.Errorf("last checkpoint: %w", sql.ErrNotFound)
+	}
+	return checkpoint, nil
+}
+
+// PruneBefore deletes checkpoints for layers before the specified layer.
+func PruneBefore(db sql.Executor, lid types.LayerID) error {
+	if _, err := db.Exec("delete from tortoise_checkpoints where layer < ?1;",
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(lid.Uint32()))
+		}, nil); err != nil {
+		return fmt.Errorf("prune checkpoints before %s: %w", lid, err)
+	}
+	return nil
+}
+
+// DeleteAfter deletes checkpoints for layers after the specified layer.
+func DeleteAfter(db sql.Executor, lid types.LayerID) error {
+	if _, err := db.Exec("delete from tortoise_checkpoints where layer > ?1;",
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(lid.Uint32()))
+		}, nil); err != nil {
+		return fmt.Errorf("delete checkpoints after %s: %w", lid, err)
+	}
+	return nil
+}
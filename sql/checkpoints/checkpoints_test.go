@@ -0,0 +1,136 @@
+package checkpoints
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+func TestLast(t *testing.T) {
+	db := sql.InMemory()
+
+	_, err := Last(db)
+	require.ErrorIs(t, err, sql.ErrNotFound)
+
+	checkpoints := []*Checkpoint{
+		{Layer: types.NewLayerID(10), Hash: types.Hash32{1}, State: []byte{1, 1}},
+		{Layer: types.NewLayerID(30), Hash: types.Hash32{3}, State: []byte{3, 3}},
+		{Layer: types.NewLayerID(20), Hash: types.Hash32{2}, State: []byte{2, 2}},
+	}
+	for _, checkpoint := range checkpoints {
+		require.NoError(t, Add(db, checkpoint))
+	}
+	last, err := Last(db)
+	require.NoError(t, err)
+	require.Equal(t, checkpoints[1], last)
+
+	updated := &Checkpoint{Layer: types.NewLayerID(30), Hash: types.Hash32{4}, State: []byte{4}}
+	require.NoError(t, Add(db, updated))
+	last, err = Last(db)
+	require.NoError(t, err)
+	require.Equal(t, updated, last)
+}
+
+func TestPrune(t *testing.T) {
+	db := sql.InMemory()
+
+	for i := 1; i <= 5; i++ {
+		require.NoError(t, Add(db, &Checkpoint{Layer: types.NewLayerID(uint32(i)), State: []byte{byte(i)}}))
+	}
+	require.NoError(t, DeleteAfter(db, types.NewLayerID(3)))
+	last, err := Last(db)
+	require.NoError(t, err)
+	require.Equal(t, types.NewLayerID(3), last.Layer)
+
+	require.NoError(t, PruneBefore(db, types.NewLayerID(4)))
+	_, err = Last(db)
+	require.ErrorIs(t, err, sql.ErrNotFound)
+}
+
+func TestSpilled(t *testing.T) {
+	db := sql.InMemory()
+
+	layer := func(lid types.LayerID) []*Spilled {
+		var rst []*Spilled
+		require.NoError(t, IterateSpilled(db, lid, func(spilled *Spilled) bool {
+			rst = append(rst, spilled)
+			return true
+		}))
+		return rst
+	}
+
+	batches := []*Spilled{
+		{Layer: types.NewLayerID(10), Checkpoint: types.NewLayerID(100), Ballots: []byte{1}},
+		{Layer: types.NewLayerID(10), Checkpoint: types.NewLayerID(200), Ballots: []byte{2}},
+		{Layer: types.NewLayerID(11), Checkpoint: types.NewLayerID(100), Ballots: []byte{3}},
+		{Layer: types.NewLayerID(12), Checkpoint: types.NewLayerID(300), Ballots: []byte{4}},
+	}
+	for _, spilled := range batches {
+		require.NoError(t, AddSpilled(db, spilled))
+	}
+	require.Equal(t, batches[:2], layer(types.NewLayerID(10)))
+	require.Equal(t, batches[2:3], layer(types.NewLayerID(11)))
+
+	require.NoError(t, DeleteSpilledSince(db, types.NewLayerID(200)))
+	require.Equal(t, batches[:1], layer(types.NewLayerID(10)))
+	require.Empty(t, layer(types.NewLayerID(12)))
+
+	require.NoError(t, PruneSpilledBefore(db, types.NewLayerID(11)))
+	require.Empty(t, layer(types.NewLayerID(10)))
+	require.Equal(t, batches[2:3], layer(types.NewLayerID(11)))
+
+	require.NoError(t, DeleteSpilled(db))
+	require.Empty(t, layer(types.NewLayerID(11)))
+}
+
+func TestSpilledLayers(t *testing.T) {
+	db := sql.InMemory()
+
+	get := func(lid uint32) []byte {
+		layer, err := GetSpilledLayer(db, types.NewLayerID(lid))
+		if errors.Is(err, sql.ErrNotFound) {
+			return nil
+		}
+		require.NoError(t, err)
+		return layer.State
+	}
+	add := func(lid, checkpoint uint32, state byte) {
+		require.NoError(t, AddSpilledLayer(db, &SpilledLayer{
+			Layer:      types.NewLayerID(lid),
+			Checkpoint: types.NewLayerID(checkpoint),
+			State:      []byte{state},
+		}))
+	}
+
+	add(10, 100, 1)
+	add(10, 100, 2)
+	add(11, 100, 3)
+	add(10, 200, 4)
+	add(12, 300, 5)
+	require.Equal(t, []byte{4}, get(10))
+	require.Equal(t, []byte{3}, get(11))
+	require.Equal(t, []byte{5}, get(12))
+
+	require.NoError(t, DeleteSpilledSince(db, types.NewLayerID(200)))
+	require.Equal(t, []byte{2}, get(10), "version consistent with the checkpoint is not overwritten")
+	require.Nil(t, get(12))
+
+	add(10, 200, 6)
+	add(10, 300, 7)
+	require.NoError(t, CompactSpilledLayers(db, types.NewLayerID(300)))
+	require.NoError(t, DeleteSpilledSince(db, types.NewLayerID(300)))
+	require.Equal(t, []byte{6}, get(10))
+	require.NoError(t, DeleteSpilledSince(db, types.NewLayerID(200)))
+	require.Nil(t, get(10), "older version is compacted")
+
+	require.NoError(t, PruneSpilledBefore(db, types.NewLayerID(12)))
+	require.Nil(t, get(11))
+
+	add(13, 100, 8)
+	require.NoError(t, DeleteSpilled(db))
+	require.Nil(t, get(13))
+}
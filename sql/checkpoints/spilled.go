@@ -0,0 +1,158 @@
+package checkpoints
+
+import (
+	"fmt"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+// Spilled is a serialized batch of ballots that tortoise moved out of memory.
+type Spilled struct {
+	Layer types.LayerID
+	// Checkpoint is the layer of the last checkpoint when ballots were spilled.
+	// Batches spilled after the checkpoint are not consistent with it.
+	Checkpoint types.LayerID
+	Ballots    []byte
+}
+
+// AddSpilled appends a batch of ballots for the layer.
+func AddSpilled(db sql.Executor, spilled *Spilled) error {
+	if _, err := db.Exec(`insert into tortoise_spilled_ballots (layer, checkpoint, ballots) values (?1, ?2, ?3);`,
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(spilled.Layer.Uint32()))
+			stmt.BindInt64(2, int64(spilled.Checkpoint.Uint32()))
+			stmt.BindBytes(3, spilled.Ballots)
+		}, nil); err != nil {
+		return fmt.Errorf("insert spilled ballots %s: %w", spilled.Layer, err)
+	}
+	return nil
+}
+
+// IterateSpilled calls fn for every batch of ballots spilled for the layer.
+func IterateSpilled(db sql.Executor, lid types.LayerID, fn func(*Spilled) bool) error {
+	if _, err := db.Exec("select checkpoint, ballots from tortoise_spilled_ballots where layer = ?1;",
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(lid.Uint32()))
+		}, func(stmt *sql.Statement) bool {
+			spilled := &Spilled{
+				Layer:      lid,
+				Checkpoint: types.NewLayerID(uint32(stmt.ColumnInt64(0))),
+				Ballots:    make([]byte, stmt.ColumnLen(1)),
+			}
+			stmt.ColumnBytes(1, spilled.Ballots)
+			return fn(spilled)
+		}); err != nil {
+		return fmt.Errorf("spilled ballots %s: %w", lid, err)
+	}
+	return nil
+}
+
+// SpilledLayer is a serialized layer that tortoise moved out of memory.
+type SpilledLayer struct {
+	Layer types.LayerID
+	// Checkpoint is the layer of the last checkpoint when the layer was written.
+	// Every write after the checkpoint adds a new version of the layer, so that
+	// the version that is consistent with the checkpoint is not overwritten.
+	Checkpoint types.LayerID
+	State      []byte
+}
+
+// AddSpilledLayer writes a version of the layer. Version written after the same
+// checkpoint is overwritten.
+func AddSpilledLayer(db sql.Executor, layer *SpilledLayer) error {
+	if _, err := db.Exec(`insert into tortoise_spilled_layers (layer, checkpoint, state) values (?1, ?2, ?3)
+		on conflict(layer, checkpoint) do update set state = ?3;`,
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(layer.Layer.Uint32()))
+			stmt.BindInt64(2, int64(layer.Checkpoint.Uint32()))
+			stmt.BindBytes(3, layer.State)
+		}, nil); err != nil {
+		return fmt.Errorf("insert spilled layer %s: %w", layer.Layer, err)
+	}
+	return nil
+}
+
+// GetSpilledLayer returns the latest version of the layer.
+func GetSpilledLayer(db sql.Executor, lid types.LayerID) (*SpilledLayer, error) {
+	var layer *SpilledLayer
+	if _, err := db.Exec(`select checkpoint, state from tortoise_spilled_layers where layer = ?1
+		order by checkpoint desc limit 1;`,
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(lid.Uint32()))
+		}, func(stmt *sql.Statement) bool {
+			layer = &SpilledLayer{
+				Layer:      lid,
+				Checkpoint: types.NewLayerID(uint32(stmt.ColumnInt64(0))),
+				State:      make([]byte, stmt.ColumnLen(1)),
+			}
+			stmt.ColumnBytes(1, layer.State)
+			return false
+		}); err != nil {
+		return nil, fmt.Errorf("spilled layer %s: %w", lid, err)
+	}
+	if layer == nil {
+		return nil, fmt.Errorf("spilled layer %s: %w", lid, sql.ErrNotFound)
+	}
+	return layer, nil
+}
+
+// CompactSpilledLayers deletes versions of the layers that were written before the checkpoint
+// and are not the latest version written before it.
+func CompactSpilledLayers(db sql.Executor, checkpoint types.LayerID) error {
+	if _, err := db.Exec(`delete from tortoise_spilled_layers where checkpoint < ?1 and checkpoint < (
+		select max(latest.checkpoint) from tortoise_spilled_layers as latest
+		where latest.layer = tortoise_spilled_layers.layer and latest.checkpoint < ?1);`,
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(checkpoint.Uint32()))
+		}, nil); err != nil {
+		return fmt.Errorf("compact spilled layers before %s: %w", checkpoint, err)
+	}
+	return nil
+}
+
+// PruneSpilledBefore deletes layers and ballots spilled for layers before the specified layer.
+func PruneSpilledBefore(db sql.Executor, lid types.LayerID) error {
+	for _, query := range []string{
+		"delete from tortoise_spilled_ballots where layer < ?1;",
+		"delete from tortoise_spilled_layers where layer < ?1;",
+	} {
+		if _, err := db.Exec(query,
+			func(stmt *sql.Statement) {
+				stmt.BindInt64(1, int64(lid.Uint32()))
+			}, nil); err != nil {
+			return fmt.Errorf("prune spilled before %s: %w", lid, err)
+		}
+	}
+	return nil
+}
+
+// DeleteSpilledSince deletes layers and ballots that were spilled when the specified checkpoint,
+// or a later one, was the last.
+func DeleteSpilledSince(db sql.Executor, checkpoint types.LayerID) error {
+	for _, query := range []string{
+		"delete from tortoise_spilled_ballots where checkpoint >= ?1;",
+		"delete from tortoise_spilled_layers where checkpoint >= ?1;",
+	} {
+		if _, err := db.Exec(query,
+			func(stmt *sql.Statement) {
+				stmt.BindInt64(1, int64(checkpoint.Uint32()))
+			}, nil); err != nil {
+			return fmt.Errorf("delete spilled since %s: %w", checkpoint, err)
+		}
+	}
+	return nil
+}
+
+// DeleteSpilled deletes all spilled layers and ballots.
+func DeleteSpilled(db sql.Executor) error {
+	for _, query := range []string{
+		"delete from tortoise_spilled_ballots;",
+		"delete from tortoise_spilled_layers;",
+	} {
+		if _, err := db.Exec(query, nil, nil); err != nil {
+			return fmt.Errorf("delete spilled: %w", err)
+		}
+	}
+	return nil
+}
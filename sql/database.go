@@ -8,6 +8,8 @@ import (
 
 	"crawshaw.io/sqlite"
 	"crawshaw.io/sqlite/sqlitex"
+
+	"github.com/spacemeshos/go-spacemesh/log"
 )
 
 var (
@@ -49,6 +51,7 @@ func defaultConf() *conf {
 	return &conf{
 		connections: 16,
 		migrations:  embeddedMigrations,
+		logger:      log.NewNop(),
 	}
 }
 
@@ -56,6 +59,8 @@ type conf struct {
 	flags       sqlite.OpenFlags
 	connections int
 	migrations  Migrations
+	logger      log.Log
+	slowQuery   time.Duration
 }
 
 // WithConnections overwrites number of pooled connections.
@@ -72,6 +77,22 @@ func WithMigrations(migrations Migrations) Opt {
 	}
 }
 
+// WithLogger overwrites logger that is used to report slow queries.
+func WithLogger(logger log.Log) Opt {
+	return func(c *conf) {
+		c.logger = logger
+	}
+}
+
+// WithSlowQueryThreshold enables logging for queries that took longer than threshold.
+// Slow query log includes normalized query and the package that executed it.
+// Zero disables slow query log.
+func WithSlowQueryThreshold(threshold time.Duration) Opt {
+	return func(c *conf) {
+		c.slowQuery = threshold
+	}
+}
+
 // Opt for configuring database.
 type Opt func(c *conf)
 
@@ -99,7 +120,10 @@ func Open(uri string, opts ...Opt) (*Database, error) {
 	if err != nil {
 		return nil, fmt.Errorf("open db %s: %w", uri, err)
 	}
-	db := &Database{pool: pool}
+	db := &Database{
+		pool:    pool,
+		queries: newQueryLogger(config.logger, config.slowQuery),
+	}
 	if config.migrations != nil {
 		tx, err := db.Tx(context.Background())
 		if err != nil {
@@ -126,18 +150,22 @@ func Open(uri string, opts ...Opt) (*Database, error) {
 
 // Database is an instance of sqlite database.
 type Database struct {
-	pool *sqlitex.Pool
+	pool    *sqlitex.Pool
+	queries *queryLogger
 }
 
 func (db *Database) getTx(ctx context.Context, initstmt string) (*Tx, error) {
+	start := time.Now()
 	conn := db.pool.Get(ctx)
 	if conn == nil {
 		return nil, ErrNoConnection
 	}
 	tx := &Tx{db: db, conn: conn}
 	if err := tx.begin(initstmt); err != nil {
+		db.pool.Put(conn)
 		return nil, err
 	}
+	lockWait.WithLabelValues(lockKind(initstmt)).Observe(float64(time.Since(start)))
 	return tx, nil
 }
 
@@ -194,12 +222,14 @@ func (db *Database) WithTxImmediate(ctx context.Context, exec func(*Tx) error) e
 // Note that Exec will block until database is closed or statement has finished.
 // If application needs to control statement execution lifetime use one of the transaction.
 func (db *Database) Exec(query string, encoder Encoder, decoder Decoder) (int, error) {
+	start := time.Now()
 	conn := db.pool.Get(context.Background())
 	if conn == nil {
 		return 0, ErrNoConnection
 	}
 	defer db.pool.Put(conn)
-	return exec(conn, query, encoder, decoder)
+	lockWait.WithLabelValues(lockExec).Observe(float64(time.Since(start)))
+	return db.exec(conn, query, encoder, decoder)
 }
 
 // Close closes all pooled connections.
@@ -210,10 +240,10 @@ func (db *Database) Close() error {
 	return nil
 }
 
-func exec(conn *sqlite.Conn, query string, encoder Encoder, decoder Decoder) (int, error) {
+func (db *Database) exec(conn *sqlite.Conn, query string, encoder Encoder, decoder Decoder) (rows int, err error) {
 	start := time.Now()
 	defer func() {
-		queryDuration.WithLabelValues(query).Observe(float64(time.Since(start)))
+		db.queries.observe(query, start, rows)
 	}()
 
 	stmt, err := conn.Prepare(query)
@@ -225,7 +255,6 @@ func exec(conn *sqlite.Conn, query string, encoder Encoder, decoder Decoder) (in
 	}
 	defer stmt.ClearBindings()
 
-	rows := 0
 	for {
 		row, err := stmt.Step()
 		if err != nil {
@@ -293,5 +322,5 @@ func (tx *Tx) Release() error {
 
 // Exec query.
 func (tx *Tx) Exec(query string, encoder Encoder, decoder Decoder) (int, error) {
-	return exec(tx.conn, query, encoder, decoder)
+	return tx.db.exec(tx.conn, query, encoder, decoder)
 }
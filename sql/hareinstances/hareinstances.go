@@ -0,0 +1,119 @@
+package hareinstances
+
+import (
+	"fmt"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+// Instance is a persisted state of the hare instance for a layer.
+type Instance struct {
+	Layer types.LayerID
+	// State is the encoded state of the consensus process, nil if it was never saved.
+	State []byte
+	// Output is the encoded output of the consensus process, nil if it didn't terminate.
+	Output []byte
+	// Delivered is true if the output was consumed by the block generator.
+	Delivered bool
+}
+
+// SetState saves the state of the consensus process for the layer.
+func SetState(db sql.Executor, lid types.LayerID, state []byte) error {
+	if _, err := db.Exec(`insert into hare_instances (layer, state) values (?1, ?2)
+		on conflict(layer) do update set state = ?2;`,
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(lid.Uint32()))
+			stmt.BindBytes(2, state)
+		}, nil); err != nil {
+		return fmt.Errorf("set hare state %s: %w", lid, err)
+	}
+	return nil
+}
+
+// SetOutput saves the output of the consensus process for the layer.
+func SetOutput(db sql.Executor, lid types.LayerID, output []byte) error {
+	if _, err := db.Exec(`insert into hare_instances (layer, output) values (?1, ?2)
+		on conflict(layer) do update set output = ?2;`,
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(lid.Uint32()))
+			stmt.BindBytes(2, output)
+		}, nil); err != nil {
+		return fmt.Errorf("set hare output %s: %w", lid, err)
+	}
+	return nil
+}
+
+// SetDelivered records that the output for the layer was consumed.
+func SetDelivered(db sql.Executor, lid types.LayerID) error {
+	if _, err := db.Exec(`insert into hare_instances (layer, delivered) values (?1, 1)
+		on conflict(layer) do update set delivered = 1;`,
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(lid.Uint32()))
+		}, nil); err != nil {
+		return fmt.Errorf("set hare output delivered %s: %w", lid, err)
+	}
+	return nil
+}
+
+func decodeInstance(stmt *sql.Statement) *Instance {
+	inst := &Instance{
+		Layer:     types.NewLayerID(uint32(stmt.ColumnInt64(0))),
+		Delivered: stmt.ColumnInt(3) == 1,
+	}
+	if n := stmt.ColumnLen(1); n > 0 {
+		inst.State = make([]byte, n)
+		stmt.ColumnBytes(1, inst.State)
+	}
+	if n := stmt.ColumnLen(2); n > 0 {
+		inst.Output = make([]byte, n)
+		stmt.ColumnBytes(2, inst.Output)
+	}
+	return inst
+}
+
+// Get returns the instance for the layer.
+func Get(db sql.Executor, lid types.LayerID) (*Instance, error) {
+	var inst *Instance
+	if _, err := db.Exec("select layer, state, output, delivered from hare_instances where layer = ?1;",
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(lid.Uint32()))
+		},
+		func(stmt *sql.Statement) bool {
+			inst = decodeInstance(stmt)
+			return false
+		}); err != nil {
+		return nil, fmt.Errorf("get hare instance %s: %w", lid, err)
+	}
+	if inst == nil {
+		return nil, fmt.Errorf("get hare instance %s: %w", lid, sql.ErrNotFound)
+	}
+	return inst, nil
+}
+
+// Since returns instances for layers starting from the specified layer, ordered by layer.
+func Since(db sql.Executor, lid types.LayerID) ([]*Instance, error) {
+	var rst []*Instance
+	if _, err := db.Exec("select layer, state, output, delivered from hare_instances where layer >= ?1 order by layer asc;",
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(lid.Uint32()))
+		},
+		func(stmt *sql.Statement) bool {
+			rst = append(rst, decodeInstance(stmt))
+			return true
+		}); err != nil {
+		return nil, fmt.Errorf("hare instances since %s: %w", lid, err)
+	}
+	return rst, nil
+}
+
+// PruneBefore deletes instances for layers before the specified layer.
+func PruneBefore(db sql.Executor, lid types.LayerID) error {
+	if _, err := db.Exec("delete from hare_instances where layer < ?1;",
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(lid.Uint32()))
+		}, nil); err != nil {
+		return fmt.Errorf("prune hare instances before %s: %w", lid, err)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package hareinstances
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+func TestInstance(t *testing.T) {
+	db := sql.InMemory()
+	lid := types.NewLayerID(10)
+
+	_, err := Get(db, lid)
+	require.ErrorIs(t, err, sql.ErrNotFound)
+
+	require.NoError(t, SetState(db, lid, []byte{1}))
+	inst, err := Get(db, lid)
+	require.NoError(t, err)
+	require.Equal(t, &Instance{Layer: lid, State: []byte{1}}, inst)
+
+	require.NoError(t, SetState(db, lid, []byte{2, 2}))
+	require.NoError(t, SetOutput(db, lid, []byte{3}))
+	inst, err = Get(db, lid)
+	require.NoError(t, err)
+	require.Equal(t, &Instance{Layer: lid, State: []byte{2, 2}, Output: []byte{3}}, inst)
+
+	require.NoError(t, SetDelivered(db, lid))
+	inst, err = Get(db, lid)
+	require.NoError(t, err)
+	require.True(t, inst.Delivered)
+	require.Equal(t, []byte{3}, inst.Output)
+
+	other := lid.Add(1)
+	require.NoError(t, SetOutput(db, other, []byte{4}))
+	inst, err = Get(db, other)
+	require.NoError(t, err)
+	require.Equal(t, &Instance{Layer: other, Output: []byte{4}}, inst)
+}
+
+func TestSinceAndPrune(t *testing.T) {
+	db := sql.InMemory()
+	for i := 1; i <= 5; i++ {
+		require.NoError(t, SetState(db, types.NewLayerID(uint32(i)), []byte{byte(i)}))
+	}
+	instances, err := Since(db, types.NewLayerID(3))
+	require.NoError(t, err)
+	require.Len(t, instances, 3)
+	for i, inst := range instances {
+		require.Equal(t, types.NewLayerID(uint32(i+3)), inst.Layer)
+		require.Equal(t, []byte{byte(i + 3)}, inst.State)
+	}
+
+	require.NoError(t, PruneBefore(db, types.NewLayerID(4)))
+	instances, err = Since(db, types.NewLayerID(0))
+	require.NoError(t, err)
+	require.Len(t, instances, 2)
+	require.Equal(t, types.NewLayerID(4), instances[0].Layer)
+}
@@ -2,6 +2,7 @@ package identities
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/spacemeshos/go-spacemesh/sql"
 )
@@ -32,3 +33,62 @@ func IsMalicious(db sql.Executor, pubkey []byte) (bool, error) {
 	}
 	return rows > 0, nil
 }
+
+// AddProof stores proof of malfeasance and records identity as malicious.
+// If proof for the identity is already stored it is not replaced.
+// Both are written in the transaction, so that identity is never recorded as malicious without the proof.
+func AddProof(db *sql.Tx, pubkey, proof []byte, received time.Time) error {
+	if err := SetMalicious(db, pubkey); err != nil {
+		return err
+	}
+	if _, err := db.Exec(`insert into malfeasance_proofs (pubkey, proof, received) 
+	values (?1, ?2, ?3) 
+	on conflict do nothing;`,
+		func(stmt *sql.Statement) {
+			stmt.BindBytes(1, pubkey)
+			stmt.BindBytes(2, proof)
+			stmt.BindInt64(3, received.UnixNano())
+		}, nil,
+	); err != nil {
+		return fmt.Errorf("add proof 0x%x: %w", pubkey, err)
+	}
+	return nil
+}
+
+// GetProof returns encoded proof of malfeasance for the identity.
+func GetProof(db sql.Executor, pubkey []byte) (proof []byte, err error) {
+	rows, err := db.Exec("select proof from malfeasance_proofs where pubkey = ?1;",
+		func(stmt *sql.Statement) {
+			stmt.BindBytes(1, pubkey)
+		}, func(stmt *sql.Statement) bool {
+			proof = make([]byte, stmt.ColumnLen(0))
+			stmt.ColumnBytes(0, proof)
+			return false
+		})
+	if err != nil {
+		return nil, fmt.Errorf("get proof 0x%x: %w", pubkey, err)
+	}
+	if rows == 0 {
+		return nil, fmt.Errorf("get proof 0x%x: %w", pubkey, sql.ErrNotFound)
+	}
+	return proof, nil
+}
+
+// IterateProofs calls fn for every proof of malfeasance received after the specified time,
+// in the order they were received.
+func IterateProofs(db sql.Executor, after time.Time, fn func(pubkey, proof []byte, received time.Time) bool) error {
+	if _, err := db.Exec(`select pubkey, proof, received from malfeasance_proofs 
+	where received > ?1 order by received asc;`,
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, after.UnixNano())
+		}, func(stmt *sql.Statement) bool {
+			pubkey := make([]byte, stmt.ColumnLen(0))
+			stmt.ColumnBytes(0, pubkey)
+			proof := make([]byte, stmt.ColumnLen(1))
+			stmt.ColumnBytes(1, proof)
+			return fn(pubkey, proof, time.Unix(0, stmt.ColumnInt64(2)))
+		}); err != nil {
+		return fmt.Errorf("iterate proofs: %w", err)
+	}
+	return nil
+}
@@ -1,7 +1,10 @@
 package identities
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -21,3 +24,63 @@ func TestMalicious(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, mal)
 }
+
+func addProof(db *sql.Database, pubkey, proof []byte, received time.Time) error {
+	return db.WithTx(context.Background(), func(tx *sql.Tx) error {
+		return AddProof(tx, pubkey, proof, received)
+	})
+}
+
+func TestProofs(t *testing.T) {
+	db := sql.InMemory()
+
+	first := []byte{1, 1, 1, 1}
+	_, err := GetProof(db, first)
+	require.ErrorIs(t, err, sql.ErrNotFound)
+
+	now := time.Now()
+	require.NoError(t, addProof(db, first, []byte("first"), now))
+	require.NoError(t, addProof(db, first, []byte("replaced"), now.Add(time.Second)))
+	proof, err := GetProof(db, first)
+	require.NoError(t, err)
+	require.Equal(t, []byte("first"), proof)
+	mal, err := IsMalicious(db, first)
+	require.NoError(t, err)
+	require.True(t, mal)
+
+	second := []byte{2, 2, 2, 2}
+	require.NoError(t, addProof(db, second, []byte("second"), now.Add(2*time.Second)))
+
+	var pubkeys [][]byte
+	require.NoError(t, IterateProofs(db, time.Time{}, func(pubkey, _ []byte, _ time.Time) bool {
+		pubkeys = append(pubkeys, pubkey)
+		return true
+	}))
+	require.Equal(t, [][]byte{first, second}, pubkeys)
+
+	pubkeys = nil
+	require.NoError(t, IterateProofs(db, now, func(pubkey, proof []byte, received time.Time) bool {
+		pubkeys = append(pubkeys, pubkey)
+		require.Equal(t, []byte("second"), proof)
+		require.Equal(t, now.Add(2*time.Second).UnixNano(), received.UnixNano())
+		return true
+	}))
+	require.Equal(t, [][]byte{second}, pubkeys)
+}
+
+func TestProofs_Rollback(t *testing.T) {
+	db := sql.InMemory()
+
+	pub := []byte{1, 1, 1, 1}
+	errAbort := errors.New("abort")
+	require.ErrorIs(t, db.WithTx(context.Background(), func(tx *sql.Tx) error {
+		require.NoError(t, AddProof(tx, pub, []byte("proof"), time.Now()))
+		return errAbort
+	}), errAbort)
+
+	mal, err := IsMalicious(db, pub)
+	require.NoError(t, err)
+	require.False(t, mal)
+	_, err = GetProof(db, pub)
+	require.ErrorIs(t, err, sql.ErrNotFound)
+}
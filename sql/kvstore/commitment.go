@@ -11,7 +11,7 @@ import (
 const commitmentATXKey = "commitmentATX"
 
 func getKeyForNode(nodeId types.NodeID) string {
-	return fmt.Sprintf("%s-%s", commitmentATXKey, nodeId)
+	return nodeKey(commitmentATXKey, nodeId)
 }
 
 // AddCommitmentATXForNode adds the id for the commitment atx to the key-value store.
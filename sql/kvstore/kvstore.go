@@ -6,9 +6,15 @@ import (
 	"github.com/spacemeshos/go-scale"
 
 	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/sql"
 )
 
+// nodeKey returns the key for the value that is stored separately for every identity of the node.
+func nodeKey(key string, nodeID types.NodeID) string {
+	return fmt.Sprintf("%s-%s", key, nodeID)
+}
+
 func addKeyValue(db sql.Executor, key string, value scale.Encodable) error {
 	bytes, err := codec.Encode(value)
 	if err != nil {
@@ -57,3 +63,24 @@ func clearKeyValue(db sql.Executor, key string) error {
 	}
 	return nil
 }
+
+// MigrateLegacyKeys moves values that were stored before identities were added to the keys
+// to the keys of the specified identity. It is expected to be called for the primary identity
+// of the node. Legacy value is dropped if the value for the identity already exists.
+func MigrateLegacyKeys(db sql.Executor, nodeID types.NodeID) error {
+	for _, key := range []string{nipostBuilderStateKey, nipostChallengeKey} {
+		if _, err := db.Exec(`
+			update kvstore set id = ?2 where id = ?1
+			and not exists (select 1 from kvstore where id = ?2);`,
+			func(stmt *sql.Statement) {
+				stmt.BindBytes(1, []byte(key))
+				stmt.BindBytes(2, []byte(nodeKey(key, nodeID)))
+			}, nil); err != nil {
+			return fmt.Errorf("failed to migrate %s: %w", key, err)
+		}
+		if err := clearKeyValue(db, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
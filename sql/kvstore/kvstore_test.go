@@ -0,0 +1,47 @@
+package kvstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+func TestMigrateLegacyKeys(t *testing.T) {
+	db := sql.InMemory()
+	nodeID := types.NodeID{0x0, 0x1}
+	state := &types.NIPostBuilderState{
+		PoetRequests: []types.PoetRequest{
+			{PoetRound: &types.PoetRound{ID: "asdf"}},
+		},
+	}
+	challenge := &types.NIPostChallenge{
+		Sequence:       1,
+		PositioningATX: types.RandomATXID(),
+	}
+	require.NoError(t, addKeyValue(db, nipostBuilderStateKey, state))
+	require.NoError(t, addKeyValue(db, nipostChallengeKey, challenge))
+
+	require.NoError(t, MigrateLegacyKeys(db, nodeID))
+	gotState, err := GetNIPostBuilderState(db, nodeID)
+	require.NoError(t, err)
+	require.Equal(t, state, gotState)
+	gotChallenge, err := GetNIPostChallenge(db, nodeID)
+	require.NoError(t, err)
+	require.Equal(t, challenge, gotChallenge)
+	require.ErrorIs(t, getKeyValue(db, nipostBuilderStateKey, &types.NIPostBuilderState{}), sql.ErrNotFound)
+	require.ErrorIs(t, getKeyValue(db, nipostChallengeKey, &types.NIPostChallenge{}), sql.ErrNotFound)
+
+	// value for the identity is not overwritten by the stale legacy value
+	require.NoError(t, addKeyValue(db, nipostChallengeKey, &types.NIPostChallenge{Sequence: 0}))
+	require.NoError(t, MigrateLegacyKeys(db, nodeID))
+	gotChallenge, err = GetNIPostChallenge(db, nodeID)
+	require.NoError(t, err)
+	require.Equal(t, challenge, gotChallenge)
+	require.ErrorIs(t, getKeyValue(db, nipostChallengeKey, &types.NIPostChallenge{}), sql.ErrNotFound)
+
+	// no legacy values
+	require.NoError(t, MigrateLegacyKeys(db, nodeID))
+}
@@ -7,21 +7,21 @@ import (
 
 const nipostBuilderStateKey = "NIPostBuilderState"
 
-// AddNIPostBuilderState adds the data for nipost builder state to the key-value store.
-func AddNIPostBuilderState(db sql.Executor, state *types.NIPostBuilderState) error {
-	return addKeyValue(db, nipostBuilderStateKey, state)
+// AddNIPostBuilderState adds the data for nipost builder state of the node to the key-value store.
+func AddNIPostBuilderState(db sql.Executor, nodeID types.NodeID, state *types.NIPostBuilderState) error {
+	return addKeyValue(db, nodeKey(nipostBuilderStateKey, nodeID), state)
 }
 
-// GetNIPostBuilderState returns the data for nipost builder state from the key-value store.
-func GetNIPostBuilderState(db sql.Executor) (*types.NIPostBuilderState, error) {
+// GetNIPostBuilderState returns the data for nipost builder state of the node from the key-value store.
+func GetNIPostBuilderState(db sql.Executor, nodeID types.NodeID) (*types.NIPostBuilderState, error) {
 	res := &types.NIPostBuilderState{}
-	if err := getKeyValue(db, nipostBuilderStateKey, res); err != nil {
+	if err := getKeyValue(db, nodeKey(nipostBuilderStateKey, nodeID), res); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-// ClearNIPostBuilderState clears the data for nipost builder state from the key-value store.
-func ClearNIPostBuilderState(db sql.Executor) error {
-	return clearKeyValue(db, nipostBuilderStateKey)
+// ClearNIPostBuilderState clears the data for nipost builder state of the node from the key-value store.
+func ClearNIPostBuilderState(db sql.Executor, nodeID types.NodeID) error {
+	return clearKeyValue(db, nodeKey(nipostBuilderStateKey, nodeID))
 }
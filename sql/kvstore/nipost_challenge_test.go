@@ -12,16 +12,17 @@ import (
 func TestAddNIPostChallenge(t *testing.T) {
 	// Arrange
 	db := sql.InMemory()
+	nodeID := types.NodeID{0x0, 0x1}
 	nipost := &types.NIPostChallenge{
 		Sequence:       0,
 		PositioningATX: types.RandomATXID(),
 	}
 
 	// Act
-	require.NoError(t, AddNIPostChallenge(db, nipost))
+	require.NoError(t, AddNIPostChallenge(db, nodeID, nipost))
 
 	// Assert
-	got, err := GetNIPostChallenge(db)
+	got, err := GetNIPostChallenge(db, nodeID)
 	require.NoError(t, err)
 	require.Equal(t, nipost, got)
 }
@@ -29,6 +30,7 @@ func TestAddNIPostChallenge(t *testing.T) {
 func TestOverwriteNIPostChallenge(t *testing.T) {
 	// Arrange
 	db := sql.InMemory()
+	nodeID := types.NodeID{0x0, 0x1}
 	nipost := &types.NIPostChallenge{
 		Sequence:       0,
 		PositioningATX: types.RandomATXID(),
@@ -40,11 +42,11 @@ func TestOverwriteNIPostChallenge(t *testing.T) {
 	}
 
 	// Act
-	require.NoError(t, AddNIPostChallenge(db, nipost))
-	require.NoError(t, AddNIPostChallenge(db, newNipost))
+	require.NoError(t, AddNIPostChallenge(db, nodeID, nipost))
+	require.NoError(t, AddNIPostChallenge(db, nodeID, newNipost))
 
 	// Assert
-	got, err := GetNIPostChallenge(db)
+	got, err := GetNIPostChallenge(db, nodeID)
 	require.NoError(t, err)
 	require.Equal(t, newNipost, got)
 }
@@ -52,17 +54,38 @@ func TestOverwriteNIPostChallenge(t *testing.T) {
 func TestClearNIPostChallenge(t *testing.T) {
 	// Arrange
 	db := sql.InMemory()
+	nodeID := types.NodeID{0x0, 0x1}
 	nipost := &types.NIPostChallenge{
 		Sequence:       0,
 		PositioningATX: types.RandomATXID(),
 	}
-	require.NoError(t, AddNIPostChallenge(db, nipost))
+	require.NoError(t, AddNIPostChallenge(db, nodeID, nipost))
 
 	// Act
-	require.NoError(t, ClearNIPostChallenge(db))
+	require.NoError(t, ClearNIPostChallenge(db, nodeID))
 
 	// Assert
-	got, err := GetNIPostChallenge(db)
+	got, err := GetNIPostChallenge(db, nodeID)
 	require.ErrorIs(t, err, sql.ErrNotFound)
 	require.Nil(t, got)
 }
+
+func TestNIPostChallengeForOtherNodeID(t *testing.T) {
+	db := sql.InMemory()
+	nodeID := types.NodeID{0x0, 0x1}
+	nodeID2 := types.NodeID{0x0, 0x2}
+	nipost := &types.NIPostChallenge{
+		Sequence:       0,
+		PositioningATX: types.RandomATXID(),
+	}
+	require.NoError(t, AddNIPostChallenge(db, nodeID, nipost))
+
+	got, err := GetNIPostChallenge(db, nodeID2)
+	require.ErrorIs(t, err, sql.ErrNotFound)
+	require.Nil(t, got)
+
+	require.NoError(t, ClearNIPostChallenge(db, nodeID2))
+	got, err = GetNIPostChallenge(db, nodeID)
+	require.NoError(t, err)
+	require.Equal(t, nipost, got)
+}
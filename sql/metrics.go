@@ -8,11 +8,50 @@ import (
 
 const namespace = "database"
 
-// QueryDuration in nanoseconds.
-var queryDuration = metrics.NewHistogramWithBuckets(
-	"query_duration",
-	namespace,
-	"Duration of the query in nanoseconds",
-	[]string{"query"},
-	prometheus.ExponentialBuckets(100_000, 2, 20),
+var (
+	// queryDuration in nanoseconds, labeled by normalized query.
+	queryDuration = metrics.NewHistogramWithBuckets(
+		"query_duration",
+		namespace,
+		"Duration of the query in nanoseconds",
+		[]string{"query"},
+		prometheus.ExponentialBuckets(100_000, 2, 20),
+	)
+	// queryRows is a number of rows returned by the query, labeled by normalized query.
+	queryRows = metrics.NewHistogramWithBuckets(
+		"query_rows",
+		namespace,
+		"Number of rows returned by the query",
+		[]string{"query"},
+		prometheus.ExponentialBuckets(1, 4, 10),
+	)
+	// lockWait in nanoseconds is a time spent waiting for a pooled connection
+	// and for the lock required by transaction to start.
+	lockWait = metrics.NewHistogramWithBuckets(
+		"lock_wait",
+		namespace,
+		"Time spent waiting for connection and transaction lock in nanoseconds",
+		[]string{"kind"},
+		prometheus.ExponentialBuckets(10_000, 2, 24),
+	)
+	// slowQueries is a number of queries that took longer than configured threshold.
+	slowQueries = metrics.NewCounter(
+		"slow_queries",
+		namespace,
+		"Number of queries that exceeded slow query threshold",
+		[]string{"query", "caller"},
+	)
 )
+
+const (
+	lockExec        = "exec"
+	lockTx          = "tx"
+	lockTxImmediate = "tx_immediate"
+)
+
+func lockKind(initstmt string) string {
+	if initstmt == beginImmediate {
+		return lockTxImmediate
+	}
+	return lockTx
+}
@@ -0,0 +1,17 @@
+ALTER TABLE rewards RENAME TO rewards_old;
+CREATE TABLE rewards
+(
+    coinbase     CHAR(20),
+    layer        INT NOT NULL,
+    total_reward UNSIGNED LONG INT,
+    layer_reward UNSIGNED LONG INT,
+    smesher      CHAR(32),
+    atx          CHAR(32),
+    PRIMARY KEY (coinbase, layer, atx)
+) WITHOUT ROWID;
+INSERT INTO rewards (coinbase, layer, total_reward, layer_reward, smesher, atx)
+    SELECT coinbase, layer, total_reward, layer_reward, zeroblob(32), zeroblob(32) FROM rewards_old;
+DROP TABLE rewards_old;
+CREATE INDEX rewards_by_coinbase ON rewards (coinbase, layer);
+CREATE INDEX rewards_by_layer ON rewards (layer asc);
+CREATE INDEX rewards_by_smesher ON rewards (smesher, layer);
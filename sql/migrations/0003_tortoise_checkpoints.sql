@@ -0,0 +1,6 @@
+CREATE TABLE tortoise_checkpoints
+(
+    layer INT PRIMARY KEY DESC,
+    hash  CHAR(32) NOT NULL,
+    state BLOB NOT NULL
+) WITHOUT ROWID;
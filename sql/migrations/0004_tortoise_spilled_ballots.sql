@@ -0,0 +1,7 @@
+CREATE TABLE tortoise_spilled_ballots
+(
+    layer      INT NOT NULL,
+    checkpoint INT NOT NULL,
+    ballots    BLOB NOT NULL
+);
+CREATE INDEX tortoise_spilled_ballots_by_layer ON tortoise_spilled_ballots (layer asc);
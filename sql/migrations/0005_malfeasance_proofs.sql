@@ -0,0 +1,7 @@
+CREATE TABLE malfeasance_proofs
+(
+    pubkey   CHAR(32) PRIMARY KEY,
+    proof    BLOB NOT NULL,
+    received INT NOT NULL
+) WITHOUT ROWID;
+CREATE INDEX malfeasance_proofs_by_received ON malfeasance_proofs (received);
@@ -0,0 +1,7 @@
+CREATE TABLE hare_instances
+(
+    layer     INT PRIMARY KEY,
+    state     BLOB,
+    output    BLOB,
+    delivered BOOL NOT NULL DEFAULT 0
+);
@@ -0,0 +1,5 @@
+CREATE TABLE beacon_states
+(
+    epoch INT NOT NULL PRIMARY KEY,
+    state BLOB NOT NULL
+) WITHOUT ROWID;
@@ -0,0 +1 @@
+ALTER TABLE beacons ADD COLUMN manual BOOL NOT NULL DEFAULT 0;
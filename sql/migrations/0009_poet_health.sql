@@ -0,0 +1,7 @@
+CREATE TABLE poet_health
+(
+    address              VARCHAR NOT NULL PRIMARY KEY,
+    successes            INT NOT NULL DEFAULT 0,
+    failures             INT NOT NULL DEFAULT 0,
+    consecutive_failures INT NOT NULL DEFAULT 0
+) WITHOUT ROWID;
@@ -0,0 +1 @@
+ALTER TABLE blocks ADD COLUMN encoding INT NOT NULL DEFAULT 0;
@@ -0,0 +1,7 @@
+CREATE TABLE tortoise_spilled_layers
+(
+    layer      INT NOT NULL,
+    checkpoint INT NOT NULL,
+    state      BLOB NOT NULL,
+    PRIMARY KEY (layer, checkpoint)
+) WITHOUT ROWID;
@@ -82,3 +82,57 @@ func GetRef(db sql.Executor, poetID []byte, roundID string) (ref []byte, err err
 
 	return ref, nil
 }
+
+// Health is a submission record of a PoET service.
+type Health struct {
+	Address             string
+	Successes           uint64
+	Failures            uint64
+	ConsecutiveFailures uint64
+}
+
+// Score returns the share of successful submissions, smoothed so that
+// services without history are ranked between good and bad ones.
+func (h Health) Score() float64 {
+	return float64(h.Successes+1) / float64(h.Successes+h.Failures+2)
+}
+
+// RecordSubmission records the outcome of a submission to the PoET service at address.
+func RecordSubmission(db sql.Executor, address string, success bool) error {
+	enc := func(stmt *sql.Statement) {
+		stmt.BindText(1, address)
+		stmt.BindBool(2, success)
+	}
+	_, err := db.Exec(`
+		insert into poet_health (address, successes, failures, consecutive_failures)
+		values (?1, ?2, not ?2, not ?2)
+		on conflict(address) do update set
+			successes = successes + ?2,
+			failures = failures + not ?2,
+			consecutive_failures = case when ?2 then 0 else consecutive_failures + 1 end;`, enc, nil)
+	if err != nil {
+		return fmt.Errorf("record submission %s: %w", address, err)
+	}
+	return nil
+}
+
+// GetHealth returns the submission record of the PoET service at address.
+// Services without any submissions have an empty record.
+func GetHealth(db sql.Executor, address string) (Health, error) {
+	health := Health{Address: address}
+	enc := func(stmt *sql.Statement) {
+		stmt.BindText(1, address)
+	}
+	dec := func(stmt *sql.Statement) bool {
+		health.Successes = uint64(stmt.ColumnInt64(0))
+		health.Failures = uint64(stmt.ColumnInt64(1))
+		health.ConsecutiveFailures = uint64(stmt.ColumnInt64(2))
+		return true
+	}
+	if _, err := db.Exec(`
+		select successes, failures, consecutive_failures from poet_health
+		where address = ?1;`, enc, dec); err != nil {
+		return Health{}, fmt.Errorf("get health %s: %w", address, err)
+	}
+	return health, nil
+}
@@ -149,3 +149,37 @@ func TestGetRef(t *testing.T) {
 	_, err := GetRef(db, []byte("sid0"), "rid0")
 	require.ErrorIs(t, err, sql.ErrNotFound)
 }
+
+func TestHealth(t *testing.T) {
+	db := sql.InMemory()
+
+	health, err := GetHealth(db, "poet1")
+	require.NoError(t, err)
+	require.Equal(t, Health{Address: "poet1"}, health)
+	require.Equal(t, 0.5, health.Score())
+
+	for _, success := range []bool{true, false, true, false, false} {
+		require.NoError(t, RecordSubmission(db, "poet1", success))
+	}
+	require.NoError(t, RecordSubmission(db, "poet2", true))
+
+	health, err = GetHealth(db, "poet1")
+	require.NoError(t, err)
+	require.Equal(t, Health{
+		Address:             "poet1",
+		Successes:           2,
+		Failures:            3,
+		ConsecutiveFailures: 2,
+	}, health)
+
+	require.NoError(t, RecordSubmission(db, "poet1", true))
+	health, err = GetHealth(db, "poet1")
+	require.NoError(t, err)
+	require.Zero(t, health.ConsecutiveFailures)
+	require.EqualValues(t, 3, health.Successes)
+
+	health, err = GetHealth(db, "poet2")
+	require.NoError(t, err)
+	require.Equal(t, Health{Address: "poet2", Successes: 1}, health)
+	require.Greater(t, health.Score(), 0.5)
+}
@@ -0,0 +1,207 @@
+package sql
+
+import (
+	"runtime"
+	"strings"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/spacemeshos/go-spacemesh/log"
+)
+
+const (
+	// maxQueryLength is the maximal length of the normalized query.
+	// Normalized query is used as a metric label, therefore its size should be bounded.
+	maxQueryLength = 256
+	// normalizedCacheSize is the number of distinct queries for which normalized form is cached.
+	// Most queries are constants, but some are built dynamically, therefore the cache is bounded.
+	normalizedCacheSize = 1024
+)
+
+// normalizeQuery returns an identifier of the query that doesn't depend on the
+// parameters and formatting.
+//
+// Whitespace is collapsed, identifiers and keywords are lowercased,
+// literals and parameters (?1, @id, :id, $id) are replaced with a single ?,
+// and lists of parameters such as (?1, ?2, ?3) are collapsed into (?).
+func normalizeQuery(query string) string {
+	var (
+		b     strings.Builder
+		space bool
+		// if the last written token is a ? or list of ?
+		param bool
+		// if the last written token is a ? followed by a comma
+		paramList bool
+	)
+	b.Grow(len(query))
+	writeParam := func() {
+		if paramList {
+			paramList = false
+			param = true
+			return
+		}
+		if space && b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		space = false
+		b.WriteByte('?')
+		param = true
+	}
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			space = true
+		case c == '\'':
+			// skip string literal, '' is an escaped quote
+			for i++; i < len(query); i++ {
+				if query[i] == '\'' {
+					if i+1 < len(query) && query[i+1] == '\'' {
+						i++
+						continue
+					}
+					break
+				}
+			}
+			writeParam()
+		case c == '?' || c == '@' || c == ':' || c == '$':
+			for i+1 < len(query) && isIdentChar(query[i+1]) {
+				i++
+			}
+			writeParam()
+		case c >= '0' && c <= '9':
+			for i+1 < len(query) && (isIdentChar(query[i+1]) || query[i+1] == '.') {
+				i++
+			}
+			writeParam()
+		case c == ',' && param:
+			paramList = true
+			space = false
+		case isIdentChar(c):
+			start := i
+			for i+1 < len(query) && isIdentChar(query[i+1]) {
+				i++
+			}
+			if paramList {
+				b.WriteByte(',')
+				paramList = false
+				space = true
+			}
+			if space && b.Len() > 0 {
+				b.WriteByte(' ')
+			}
+			space = false
+			param = false
+			b.WriteString(strings.ToLower(query[start : i+1]))
+		default:
+			if paramList {
+				b.WriteByte(',')
+				paramList = false
+			}
+			if space && b.Len() > 0 && c != ')' && c != ';' && c != ',' {
+				b.WriteByte(' ')
+			}
+			space = false
+			param = false
+			b.WriteByte(c)
+		}
+		if b.Len() >= maxQueryLength {
+			break
+		}
+	}
+	rst := strings.TrimSuffix(b.String(), ";")
+	if len(rst) > maxQueryLength {
+		rst = rst[:maxQueryLength]
+	}
+	return rst
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// sqlPackage is a prefix of the functions defined in this package.
+var sqlPackage = func() string {
+	pc, _, _, _ := runtime.Caller(0)
+	name := runtime.FuncForPC(pc).Name()
+	return funcPackage(name)
+}()
+
+// funcPackage returns the package path of the fully qualified function name.
+// For example github.com/spacemeshos/go-spacemesh/sql/atxs.Get returns
+// github.com/spacemeshos/go-spacemesh/sql/atxs.
+func funcPackage(name string) string {
+	slash := strings.LastIndexByte(name, '/')
+	if slash < 0 {
+		slash = 0
+	}
+	if dot := strings.IndexByte(name[slash:], '.'); dot >= 0 {
+		return name[:slash+dot]
+	}
+	return name
+}
+
+// callerPackage returns the first package up in the stack that is not
+// the sql package itself.
+func callerPackage() string {
+	pcs := make([]uintptr, 16)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if pkg := funcPackage(frame.Function); pkg != sqlPackage {
+			return pkg
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
+
+// queryLogger records metrics for executed queries and reports slow queries.
+type queryLogger struct {
+	logger    log.Log
+	threshold time.Duration
+	// normalized caches normalized form of the query string.
+	normalized *lru.Cache
+}
+
+func newQueryLogger(logger log.Log, threshold time.Duration) *queryLogger {
+	cache, err := lru.New(normalizedCacheSize)
+	if err != nil {
+		log.Panic("could not initialize cache ", err)
+	}
+	return &queryLogger{
+		logger:     logger,
+		threshold:  threshold,
+		normalized: cache,
+	}
+}
+
+func (q *queryLogger) normalize(query string) string {
+	if normalized, exist := q.normalized.Get(query); exist {
+		return normalized.(string)
+	}
+	normalized := normalizeQuery(query)
+	q.normalized.Add(query, normalized)
+	return normalized
+}
+
+func (q *queryLogger) observe(query string, start time.Time, rows int) {
+	elapsed := time.Since(start)
+	normalized := q.normalize(query)
+	queryDuration.WithLabelValues(normalized).Observe(float64(elapsed))
+	queryRows.WithLabelValues(normalized).Observe(float64(rows))
+	if q.threshold == 0 || elapsed < q.threshold {
+		return
+	}
+	caller := callerPackage()
+	slowQueries.WithLabelValues(normalized, caller).Inc()
+	q.logger.With().Warning("slow query",
+		log.String("query", normalized),
+		log.String("caller", caller),
+		log.Duration("duration", elapsed),
+		log.Int("rows", rows),
+	)
+}
@@ -0,0 +1,93 @@
+package sql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/spacemeshos/go-spacemesh/log"
+)
+
+func TestNormalizeQuery(t *testing.T) {
+	for _, tc := range []struct {
+		desc, query, expected string
+	}{
+		{
+			desc:     "whitespace",
+			query:    "select  id\n\tfrom blocks  where layer = ?1;",
+			expected: "select id from blocks where layer = ?",
+		},
+		{
+			desc:     "named parameters",
+			query:    "update layers set hash = @hash where id = @id",
+			expected: "update layers set hash = ? where id = ?",
+		},
+		{
+			desc:     "parameters list",
+			query:    "insert into ballots (id, layer, pubkey, ballot) values (?1, ?2, ?3, ?4)",
+			expected: "insert into ballots (id, layer, pubkey, ballot) values (?)",
+		},
+		{
+			desc:     "literals",
+			query:    "SELECT name FROM sqlite_master WHERE type='table' and rowid > 10",
+			expected: "select name from sqlite_master where type=? and rowid > ?",
+		},
+		{
+			desc:     "escaped quote",
+			query:    "select 1 from kvstore where id = 'it''s'",
+			expected: "select ? from kvstore where id = ?",
+		},
+		{
+			desc:     "parameter before column",
+			query:    "select ?1, id from blocks",
+			expected: "select ?, id from blocks",
+		},
+	} {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			require.Equal(t, tc.expected, normalizeQuery(tc.query))
+		})
+	}
+}
+
+func TestCallerPackage(t *testing.T) {
+	require.Equal(t, "github.com/spacemeshos/go-spacemesh/sql", sqlPackage)
+	require.Equal(t, "github.com/spacemeshos/go-spacemesh/sql/atxs",
+		funcPackage("github.com/spacemeshos/go-spacemesh/sql/atxs.Get"))
+	require.Equal(t, "github.com/spacemeshos/go-spacemesh/sql",
+		funcPackage("github.com/spacemeshos/go-spacemesh/sql.(*Database).Exec"))
+	require.Equal(t, "testing", callerPackage())
+}
+
+func TestQueryLogger_Normalize(t *testing.T) {
+	q := newQueryLogger(log.NewNop(), 0)
+	query := "select  id from blocks where layer = ?1"
+	require.Equal(t, "select id from blocks where layer = ?", q.normalize(query))
+	cached, exist := q.normalized.Get(query)
+	require.True(t, exist)
+	require.Equal(t, "select id from blocks where layer = ?", cached)
+}
+
+func TestSlowQueryLog(t *testing.T) {
+	core, logs := observer.New(zapcore.WarnLevel)
+	db := InMemory(
+		WithLogger(log.NewFromLog(zap.New(core))),
+		WithSlowQueryThreshold(time.Nanosecond),
+	)
+	t.Cleanup(func() { require.NoError(t, db.Close()) })
+
+	const normalized = "select ?"
+	before := testutil.ToFloat64(slowQueries.WithLabelValues(normalized, "testing"))
+	_, err := db.Exec("select 1", nil, nil)
+	require.NoError(t, err)
+
+	entries := logs.FilterMessage("slow query").FilterField(zap.String("query", normalized)).All()
+	require.Len(t, entries, 1)
+	require.Equal(t, "testing", entries[0].ContextMap()["caller"])
+	require.Equal(t, before+1, testutil.ToFloat64(slowQueries.WithLabelValues(normalized, "testing")))
+}
@@ -2,6 +2,7 @@ package rewards
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/sql"
@@ -10,8 +11,8 @@ import (
 // Add reward to the database.
 func Add(db sql.Executor, reward *types.Reward) error {
 	if _, err := db.Exec(`
-		insert into rewards (coinbase, layer, total_reward, layer_reward) values (?1, ?2, ?3, ?4)
-		on conflict(coinbase, layer)
+		insert into rewards (coinbase, layer, total_reward, layer_reward, smesher, atx) values (?1, ?2, ?3, ?4, ?5, ?6)
+		on conflict(coinbase, layer, atx)
 			do update set
 				total_reward=add_uint64(total_reward, ?3),
 				layer_reward=add_uint64(layer_reward, ?4);`,
@@ -20,6 +21,8 @@ func Add(db sql.Executor, reward *types.Reward) error {
 			stmt.BindInt64(2, int64(reward.Layer.Uint32()))
 			stmt.BindInt64(3, int64(reward.TotalReward))
 			stmt.BindInt64(4, int64(reward.LayerReward))
+			stmt.BindBytes(5, reward.SmesherID[:])
+			stmt.BindBytes(6, reward.AtxID[:])
 		}, nil); err != nil {
 		return fmt.Errorf("insert %+x: %w", reward, err)
 	}
@@ -38,6 +41,7 @@ func Revert(db sql.Executor, revertTo types.LayerID) error {
 }
 
 // List rewards from all layers for the coinbase address.
+// Rewards earned by different smeshers for the same coinbase in the same layer are summed up.
 func List(db sql.Executor, coinbase types.Address) (rst []*types.Reward, err error) {
 	_, err = db.Exec("select layer, total_reward, layer_reward from rewards where coinbase = ?1 order by layer;",
 		func(stmt *sql.Statement) {
@@ -49,8 +53,56 @@ func List(db sql.Executor, coinbase types.Address) (rst []*types.Reward, err err
 				TotalReward: uint64(stmt.ColumnInt64(1)),
 				LayerReward: uint64(stmt.ColumnInt64(2)),
 			}
+			if last := len(rst) - 1; last >= 0 && rst[last].Layer == reward.Layer {
+				rst[last].TotalReward += reward.TotalReward
+				rst[last].LayerReward += reward.LayerReward
+				return true
+			}
+			rst = append(rst, reward)
+			return true
+		})
+	if err != nil {
+		return nil, fmt.Errorf("list coinbase %v: %w", coinbase, err)
+	}
+	return rst, nil
+}
+
+// ListBySmesher rewards from all layers earned by the smesher.
+func ListBySmesher(db sql.Executor, smesherID types.NodeID) ([]*types.Reward, error) {
+	rst, err := listBySmesher(db, smesherID, types.NewLayerID(0), types.NewLayerID(math.MaxUint32))
+	if err != nil {
+		return nil, fmt.Errorf("list smesher %v: %w", smesherID, err)
+	}
+	return rst, nil
+}
+
+// ListBySmesherAndEpoch rewards earned by the smesher in the layers of the epoch.
+func ListBySmesherAndEpoch(db sql.Executor, smesherID types.NodeID, epoch types.EpochID) ([]*types.Reward, error) {
+	rst, err := listBySmesher(db, smesherID, epoch.FirstLayer(), (epoch + 1).FirstLayer().Sub(1))
+	if err != nil {
+		return nil, fmt.Errorf("list smesher %v in epoch %v: %w", smesherID, epoch, err)
+	}
+	return rst, nil
+}
+
+func listBySmesher(db sql.Executor, smesherID types.NodeID, from, to types.LayerID) (rst []*types.Reward, err error) {
+	_, err = db.Exec(`select coinbase, layer, total_reward, layer_reward, atx from rewards
+		where smesher = ?1 and layer between ?2 and ?3 order by layer;`,
+		func(stmt *sql.Statement) {
+			stmt.BindBytes(1, smesherID[:])
+			stmt.BindInt64(2, int64(from.Uint32()))
+			stmt.BindInt64(3, int64(to.Uint32()))
+		}, func(stmt *sql.Statement) bool {
+			reward := &types.Reward{
+				SmesherID:   smesherID,
+				Layer:       types.NewLayerID(uint32(stmt.ColumnInt64(1))),
+				TotalReward: uint64(stmt.ColumnInt64(2)),
+				LayerReward: uint64(stmt.ColumnInt64(3)),
+			}
+			stmt.ColumnBytes(0, reward.Coinbase[:])
+			stmt.ColumnBytes(4, reward.AtxID[:])
 			rst = append(rst, reward)
 			return true
 		})
-	return
+	return rst, err
 }
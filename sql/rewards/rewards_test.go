@@ -94,3 +94,71 @@ func TestRewards(t *testing.T) {
 	require.Equal(t, part, got[0].TotalReward)
 	require.Equal(t, lyrReward, got[0].LayerReward)
 }
+
+func TestRewardsBySmesher(t *testing.T) {
+	types.SetLayersPerEpoch(4)
+	db := sql.InMemory()
+
+	coinbase := types.Address{1}
+	smesher1 := types.NodeID{1}
+	smesher2 := types.NodeID{2}
+	all := []types.Reward{
+		{
+			Layer:       types.NewLayerID(4),
+			Coinbase:    coinbase,
+			SmesherID:   smesher1,
+			AtxID:       types.ATXID{1},
+			TotalReward: 10,
+			LayerReward: 5,
+		},
+		{
+			Layer:       types.NewLayerID(4),
+			Coinbase:    coinbase,
+			SmesherID:   smesher2,
+			AtxID:       types.ATXID{2},
+			TotalReward: 20,
+			LayerReward: 10,
+		},
+		{
+			Layer:       types.NewLayerID(8),
+			Coinbase:    coinbase,
+			SmesherID:   smesher1,
+			AtxID:       types.ATXID{3},
+			TotalReward: 30,
+			LayerReward: 15,
+		},
+	}
+	for _, reward := range all {
+		require.NoError(t, Add(db, &reward))
+	}
+
+	got, err := List(db, coinbase)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	require.Equal(t, types.NewLayerID(4), got[0].Layer)
+	require.Equal(t, uint64(30), got[0].TotalReward)
+	require.Equal(t, uint64(15), got[0].LayerReward)
+	require.Equal(t, types.NewLayerID(8), got[1].Layer)
+	require.Equal(t, uint64(30), got[1].TotalReward)
+
+	got, err = ListBySmesher(db, smesher1)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	require.Equal(t, all[0], *got[0])
+	require.Equal(t, all[2], *got[1])
+
+	got, err = ListBySmesherAndEpoch(db, smesher1, 2)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Equal(t, all[2], *got[0])
+
+	got, err = ListBySmesherAndEpoch(db, smesher2, 2)
+	require.NoError(t, err)
+	require.Empty(t, got)
+
+	require.NoError(t, Revert(db, types.NewLayerID(4)))
+	got, err = ListBySmesher(db, smesher1)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Equal(t, all[0], *got[0])
+}
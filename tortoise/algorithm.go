@@ -2,6 +2,7 @@ package tortoise
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 
@@ -10,6 +11,8 @@ import (
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/datastore"
 	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/checkpoints"
 	"github.com/spacemeshos/go-spacemesh/system"
 )
 
@@ -20,6 +23,13 @@ type Config struct {
 	// how long we are waiting for a switch from verifying to full. relevant during rerun.
 	WindowSize    uint32 `mapstructure:"tortoise-window-size"`    // size of the tortoise sliding window (in layers)
 	MaxExceptions int    `mapstructure:"tortoise-max-exceptions"` // if candidate for base block has more than max exceptions it will be ignored
+	// state is stored in the database after every CheckpointInterval processed layers.
+	// on restart tortoise is recovered from the checkpoint instead of full rerun. zero disables checkpoints.
+	CheckpointInterval uint32 `mapstructure:"tortoise-checkpoint-interval"`
+	// layers that are older than MaxResidentLayers (relative to the last processed layer) are moved
+	// to the database together with blocks, ballots and votes, and loaded when needed.
+	// it must not be less than the window size. zero disables it.
+	MaxResidentLayers uint32 `mapstructure:"tortoise-max-resident-layers"`
 
 	LayerSize                uint32
 	BadBeaconVoteDelayLayers uint32 // number of layers to delay votes for blocks with bad beacon values during self-healing
@@ -35,9 +45,22 @@ func DefaultConfig() Config {
 		WindowSize:               1000,
 		BadBeaconVoteDelayLayers: 6,
 		MaxExceptions:            30 * 100, // 100 layers of average size
+		CheckpointInterval:       100,
+		MaxResidentLayers:        2000,
 	}
 }
 
+// Validate returns an error if parameters are not consistent.
+func (c *Config) Validate() error {
+	if c.Hdist < c.Zdist {
+		return fmt.Errorf("hdist (%d) must be >= zdist (%d)", c.Hdist, c.Zdist)
+	}
+	if c.MaxResidentLayers != 0 && c.MaxResidentLayers < c.WindowSize {
+		return fmt.Errorf("max resident layers (%d) must be >= window size (%d)", c.MaxResidentLayers, c.WindowSize)
+	}
+	return nil
+}
+
 // Tortoise is a thread safe verifying tortoise wrapper, it just locks all actions.
 type Tortoise struct {
 	logger log.Log
@@ -93,11 +116,8 @@ func New(cdb *datastore.CachedDB, beacons system.BeaconGetter, updater blockVali
 		opt(t)
 	}
 
-	if t.cfg.Hdist < t.cfg.Zdist {
-		t.logger.With().Panic("hdist must be >= zdist",
-			log.Uint32("hdist", t.cfg.Hdist),
-			log.Uint32("zdist", t.cfg.Zdist),
-		)
+	if err := t.cfg.Validate(); err != nil {
+		t.logger.With().Panic("invalid tortoise config", log.Err(err))
 	}
 
 	ctx, cancel := context.WithCancel(t.ctx)
@@ -117,12 +137,14 @@ func New(cdb *datastore.CachedDB, beacons system.BeaconGetter, updater blockVali
 			log.Stringer("last layer", t.cfg.MeshProcessed),
 		)
 		t.eg.Go(func() error {
-			for lid := types.GetEffectiveGenesis().Add(1); !lid.After(t.cfg.MeshProcessed); lid = lid.Add(1) {
+			start := t.recoverFromCheckpoint(cdb, beacons, updater)
+			for lid := start; !lid.After(t.cfg.MeshProcessed); lid = lid.Add(1) {
 				err := t.trtl.onLayer(ctx, lid)
 				if err != nil {
 					t.ready <- err
 					return err
 				}
+				t.trtl.maybeCheckpoint()
 			}
 			close(t.ready)
 			return nil
@@ -134,6 +156,43 @@ func New(cdb *datastore.CachedDB, beacons system.BeaconGetter, updater blockVali
 	return t
 }
 
+// recoverFromCheckpoint loads the last checkpoint and returns the first layer that needs to be replayed.
+// If checkpoint can't be used state is recovered from genesis.
+func (t *Tortoise) recoverFromCheckpoint(cdb *datastore.CachedDB, beacons system.BeaconGetter, updater blockValidityUpdater) types.LayerID {
+	genesis := types.GetEffectiveGenesis().Add(1)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.cfg.CheckpointInterval == 0 {
+		t.deleteSpilled(cdb)
+		return genesis
+	}
+	err := t.trtl.loadCheckpoint()
+	if err == nil {
+		t.logger.With().Info("recovered state from checkpoint",
+			log.Stringer("processed", t.trtl.processed),
+			log.Stringer("verified", t.trtl.verified),
+			log.Bool("full", t.trtl.isFull),
+		)
+		return t.trtl.processed.Add(1)
+	}
+	if errors.Is(err, sql.ErrNotFound) {
+		t.logger.Info("no tortoise checkpoint. recovering from genesis")
+	} else {
+		t.logger.With().Warning("can't recover from checkpoint. recovering from genesis", log.Err(err))
+	}
+	// checkpoint may be partially loaded into the state
+	t.trtl = newTurtle(t.logger, cdb, beacons, updater, t.cfg)
+	t.deleteSpilled(cdb)
+	return genesis
+}
+
+// deleteSpilled ballots that are not consistent with the state recovered from genesis.
+func (t *Tortoise) deleteSpilled(cdb *datastore.CachedDB) {
+	if err := checkpoints.DeleteSpilled(cdb); err != nil {
+		t.logger.With().Error("failed to delete spilled ballots", log.Err(err))
+	}
+}
+
 // LatestComplete returns the latest verified layer.
 func (t *Tortoise) LatestComplete() types.LayerID {
 	t.mu.Lock()
@@ -160,6 +219,20 @@ func EncodeVotesWithCurrent(current types.LayerID) EncodeVotesOpts {
 	}
 }
 
+// ExplainLayer returns inputs that were used to decide the layer validity.
+func (t *Tortoise) ExplainLayer(lid types.LayerID) (*LayerExplanation, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.trtl.explainLayer(lid)
+}
+
+// ExplainBlock returns votes for the block, grouped by ballots and atxs.
+func (t *Tortoise) ExplainBlock(bid types.BlockID) (*BlockExplanation, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.trtl.explainBlock(bid)
+}
+
 // EncodeVotes chooses a base ballot and creates a differences list. needs the hare results for latest layers.
 func (t *Tortoise) EncodeVotes(ctx context.Context, opts ...EncodeVotesOpts) (*types.Opinion, error) {
 	t.mu.Lock()
@@ -177,7 +250,9 @@ func (t *Tortoise) TallyVotes(ctx context.Context, lid types.LayerID) {
 	defer t.mu.Unlock()
 	if err := t.trtl.onLayer(ctx, lid); err != nil {
 		t.logger.With().Error("failed on layer", lid, log.Err(err))
+		return
 	}
+	t.trtl.maybeCheckpoint()
 }
 
 // OnAtx is expected to be called before ballots that use this atx.
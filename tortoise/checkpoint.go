@@ -0,0 +1,678 @@
+package tortoise
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/ballots"
+	"github.com/spacemeshos/go-spacemesh/sql/blocks"
+	"github.com/spacemeshos/go-spacemesh/sql/checkpoints"
+	"github.com/spacemeshos/go-spacemesh/sql/layers"
+	"github.com/spacemeshos/go-spacemesh/tortoise/checkpoint"
+	"github.com/spacemeshos/go-spacemesh/tortoise/metrics"
+)
+
+var (
+	errStaleCheckpoint   = errors.New("checkpoint is stale")
+	errCorruptCheckpoint = errors.New("checkpoint is corrupt")
+)
+
+// maybeCheckpoint stores the state in the database if at least CheckpointInterval
+// layers were processed since the last checkpoint.
+func (t *turtle) maybeCheckpoint() {
+	if t.CheckpointInterval == 0 || !t.processed.After(t.checkpointed) ||
+		t.processed.Difference(t.checkpointed) < t.CheckpointInterval {
+		return
+	}
+	if err := t.checkpoint(); err != nil {
+		t.logger.With().Error("failed to checkpoint tortoise state", t.processed, log.Err(err))
+	}
+}
+
+// checkpoint serializes the state and stores it in the database.
+func (t *turtle) checkpoint() error {
+	start := time.Now()
+	// spilled layers are not a part of the encoded state, they must be consistent with it
+	if err := t.flushSpilled(); err != nil {
+		return err
+	}
+	if err := t.unloadLayers(); err != nil {
+		return err
+	}
+	state, err := t.encodeState()
+	if err != nil {
+		return err
+	}
+	buf, err := codec.Encode(state)
+	if err != nil {
+		return fmt.Errorf("encode checkpoint: %w", err)
+	}
+	if err := checkpoints.Add(t.cdb, &checkpoints.Checkpoint{
+		Layer: t.processed,
+		Hash:  types.CalcHash32(buf),
+		State: buf,
+	}); err != nil {
+		return err
+	}
+	if err := checkpoints.PruneBefore(t.cdb, t.processed); err != nil {
+		return err
+	}
+	if err := checkpoints.CompactSpilledLayers(t.cdb, t.processed); err != nil {
+		return err
+	}
+	if err := checkpoints.PruneSpilledBefore(t.cdb, t.evicted.Add(1)); err != nil {
+		return err
+	}
+	t.checkpointed = t.processed
+	metrics.CheckpointDuration.Observe(time.Since(start).Seconds())
+	metrics.CheckpointSize.Set(float64(len(buf)))
+	t.logger.With().Info("checkpointed tortoise state",
+		t.processed,
+		log.Stringer("verified", t.verified),
+		log.Int("size", len(buf)),
+		log.Duration("duration", time.Since(start)),
+	)
+	return nil
+}
+
+func (t *turtle) encodeState() (*checkpoint.State, error) {
+	state := &checkpoint.State{
+		Version:           checkpoint.Version,
+		Genesis:           types.GetEffectiveGenesis(),
+		Hdist:             t.Hdist,
+		Zdist:             t.Zdist,
+		WindowSize:        t.WindowSize,
+		Last:              t.last,
+		Verified:          t.verified,
+		Processed:         t.processed,
+		Evicted:           t.evicted,
+		Spilled:           t.spilled,
+		ChangedOpinionMin: t.changedOpinion.min,
+		ChangedOpinionMax: t.changedOpinion.max,
+		IsFull:            t.isFull,
+		Counted:           t.full.counted,
+	}
+	var err error
+	if state.LocalThreshold, err = encodeWeight(t.localThreshold); err != nil {
+		return nil, err
+	}
+	if state.TotalGoodWeight, err = encodeWeight(t.verifying.totalGoodWeight); err != nil {
+		return nil, err
+	}
+
+	epochs := make([]types.EpochID, 0, len(t.epochs))
+	for eid := range t.epochs {
+		epochs = append(epochs, eid)
+	}
+	sort.Slice(epochs, func(i, j int) bool { return epochs[i] < epochs[j] })
+	for _, eid := range epochs {
+		einfo := t.epochs[eid]
+		epoch := checkpoint.Epoch{
+			Epoch:  uint32(eid),
+			Weight: einfo.weight,
+			Height: einfo.height,
+			Atxs:   make([]checkpoint.ATX, 0, len(einfo.atxs)),
+		}
+		for id, weight := range einfo.atxs {
+			epoch.Atxs = append(epoch.Atxs, checkpoint.ATX{ID: id, Weight: weight})
+		}
+		sort.Slice(epoch.Atxs, func(i, j int) bool {
+			return bytes.Compare(epoch.Atxs[i].ID[:], epoch.Atxs[j].ID[:]) < 0
+		})
+		state.Epochs = append(state.Epochs, epoch)
+	}
+
+	lids := make([]types.LayerID, 0, len(t.layers))
+	for lid := range t.layers {
+		lids = append(lids, lid)
+	}
+	sort.Slice(lids, func(i, j int) bool { return lids[i].Before(lids[j]) })
+	for _, lid := range lids {
+		layer, err := encodeCheckpointLayer(t.layers[lid])
+		if err != nil {
+			return nil, err
+		}
+		state.Layers = append(state.Layers, layer)
+	}
+
+	// votes are shared between ballots, every vote is stored only once
+	votes := map[*layerVote]uint32{}
+	for _, lid := range lids {
+		for _, binfo := range t.layers[lid].ballots {
+			ballot, err := encodeCheckpointBallot(binfo, t.encodeCheckpointVotes(&state.Votes, votes, binfo.votes.tail))
+			if err != nil {
+				return nil, err
+			}
+			state.Ballots = append(state.Ballots, ballot)
+		}
+	}
+
+	delayed := make([]types.LayerID, 0, len(t.full.delayed))
+	for lid := range t.full.delayed {
+		delayed = append(delayed, lid)
+	}
+	sort.Slice(delayed, func(i, j int) bool { return delayed[i].Before(delayed[j]) })
+	for _, lid := range delayed {
+		layer := checkpoint.Delayed{Layer: lid}
+		for _, binfo := range t.full.delayed[lid] {
+			layer.Ballots = append(layer.Ballots, binfo.id)
+		}
+		state.Delayed = append(state.Delayed, layer)
+	}
+	return state, nil
+}
+
+func encodeCheckpointLayer(linfo *layerInfo) (checkpoint.Layer, error) {
+	layer := checkpoint.Layer{
+		ID:              linfo.lid,
+		HareTerminated:  linfo.hareTerminated,
+		Opinion:         linfo.opinion,
+		ReferenceHeight: linfo.verifying.referenceHeight,
+	}
+	if linfo.prevOpinion != nil {
+		layer.HasPrevOpinion = true
+		layer.PrevOpinion = *linfo.prevOpinion
+	}
+	var err error
+	if layer.Empty, err = encodeWeight(linfo.empty); err != nil {
+		return layer, err
+	}
+	if layer.GoodUncounted, err = encodeWeight(linfo.verifying.goodUncounted); err != nil {
+		return layer, err
+	}
+	for _, binfo := range linfo.blocks {
+		block := checkpoint.Block{
+			ID:        binfo.id,
+			Height:    binfo.height,
+			Hare:      encodeSign(binfo.hare),
+			Validity:  encodeSign(binfo.validity),
+			Persisted: encodeSign(binfo.persisted),
+		}
+		if block.Margin, err = encodeWeight(binfo.margin); err != nil {
+			return layer, err
+		}
+		layer.Blocks = append(layer.Blocks, block)
+	}
+	return layer, nil
+}
+
+// decodeCheckpointLayer decodes the layer together with blocks. Previous opinion
+// is decoded as a copy and needs to be linked with the previous layer by the caller.
+func decodeCheckpointLayer(layer *checkpoint.Layer) (*layerInfo, error) {
+	linfo := &layerInfo{
+		lid:            layer.ID,
+		hareTerminated: layer.HareTerminated,
+		opinion:        layer.Opinion,
+	}
+	linfo.verifying.referenceHeight = layer.ReferenceHeight
+	if layer.HasPrevOpinion {
+		prevOpinion := layer.PrevOpinion
+		linfo.prevOpinion = &prevOpinion
+	}
+	var err error
+	if linfo.empty, err = decodeWeight(layer.Empty); err != nil {
+		return nil, err
+	}
+	if linfo.verifying.goodUncounted, err = decodeWeight(layer.GoodUncounted); err != nil {
+		return nil, err
+	}
+	for _, block := range layer.Blocks {
+		binfo := &blockInfo{
+			id:     block.ID,
+			layer:  layer.ID,
+			height: block.Height,
+		}
+		if binfo.hare, err = decodeSign(block.Hare); err != nil {
+			return nil, err
+		}
+		if binfo.validity, err = decodeSign(block.Validity); err != nil {
+			return nil, err
+		}
+		if binfo.persisted, err = decodeSign(block.Persisted); err != nil {
+			return nil, err
+		}
+		if binfo.margin, err = decodeWeight(block.Margin); err != nil {
+			return nil, err
+		}
+		linfo.blocks = append(linfo.blocks, binfo)
+	}
+	return linfo, nil
+}
+
+func encodeCheckpointBallot(binfo *ballotInfo, votes uint32) (checkpoint.Ballot, error) {
+	ballot := checkpoint.Ballot{
+		ID:        binfo.id,
+		ATX:       binfo.atxid,
+		Layer:     binfo.layer,
+		BaseID:    binfo.base.id,
+		BaseLayer: binfo.base.layer,
+		BadBeacon: binfo.conditions.badBeacon,
+		Votes:     votes,
+	}
+	if votes == 0 {
+		ballot.SpilledVotes = encodeSpilledRef(binfo.votes.spilled)
+	}
+	var err error
+	if ballot.Weight, err = encodeWeight(binfo.weight); err != nil {
+		return ballot, err
+	}
+	if binfo.reference != nil {
+		ballot.RefHeight = binfo.reference.height
+		ballot.RefBeacon = binfo.reference.beacon
+		if ballot.RefWeight, err = encodeWeight(binfo.reference.weight); err != nil {
+			return ballot, err
+		}
+	}
+	return ballot, nil
+}
+
+// encodeCheckpointVotes appends votes that are not yet encoded and returns
+// the index of the tail increased by one.
+func (t *turtle) encodeCheckpointVotes(votes *[]checkpoint.Vote, encoded map[*layerVote]uint32, tail *layerVote) uint32 {
+	var chain []*layerVote
+	for current := tail; current != nil && current.lid.After(t.evicted); current = current.prev {
+		if _, exist := encoded[current]; exist {
+			break
+		}
+		chain = append(chain, current)
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		lvote := chain[i]
+		vote := checkpoint.Vote{
+			Layer:   lvote.lid,
+			Vote:    encodeSign(lvote.vote),
+			Opinion: lvote.opinion,
+		}
+		if lvote.prev != nil {
+			vote.Prev = encoded[lvote.prev]
+		} else {
+			vote.SpilledPrev = encodeSpilledRef(lvote.spilled)
+		}
+		for _, block := range lvote.supported {
+			vote.Supported = append(vote.Supported, block.id)
+		}
+		*votes = append(*votes, vote)
+		encoded[lvote] = uint32(len(*votes))
+	}
+	if tail == nil {
+		return 0
+	}
+	return encoded[tail]
+}
+
+// decodeCheckpointVotes decodes votes in the same order as they were encoded.
+// If getLayer returns nil layer the vote is dropped, and votes that reference it
+// are decoded without previous votes.
+func decodeCheckpointVotes(
+	encoded []checkpoint.Vote,
+	blockRefs map[types.BlockID]*blockInfo,
+	getLayer func(types.LayerID) (*layerInfo, error),
+) ([]*layerVote, error) {
+	votes := make([]*layerVote, 0, len(encoded))
+	for i, vote := range encoded {
+		linfo, err := getLayer(vote.Layer)
+		if err != nil {
+			return nil, err
+		}
+		if linfo == nil {
+			votes = append(votes, nil)
+			continue
+		}
+		lvote := &layerVote{
+			layerInfo: linfo,
+			opinion:   vote.Opinion,
+		}
+		if lvote.vote, err = decodeSign(vote.Vote); err != nil {
+			return nil, err
+		}
+		if vote.Prev > uint32(i) {
+			return nil, fmt.Errorf("%w: vote references unknown vote %d", errCorruptCheckpoint, vote.Prev)
+		} else if vote.Prev > 0 {
+			lvote.prev = votes[vote.Prev-1]
+		} else {
+			lvote.spilled = decodeSpilledRef(vote.SpilledPrev)
+		}
+		for _, bid := range vote.Supported {
+			binfo, exist := blockRefs[bid]
+			if !exist {
+				return nil, fmt.Errorf("%w: vote for unknown block %s", errCorruptCheckpoint, bid)
+			}
+			lvote.supported = append(lvote.supported, binfo)
+		}
+		votes = append(votes, lvote)
+	}
+	return votes, nil
+}
+
+func decodeCheckpointBallot(ballot *checkpoint.Ballot, votes []*layerVote) (*ballotInfo, error) {
+	binfo := &ballotInfo{
+		id:    ballot.ID,
+		atxid: ballot.ATX,
+		layer: ballot.Layer,
+		base: baseInfo{
+			id:    ballot.BaseID,
+			layer: ballot.BaseLayer,
+		},
+		reference: &referenceInfo{
+			height: ballot.RefHeight,
+			beacon: ballot.RefBeacon,
+		},
+		conditions: conditions{badBeacon: ballot.BadBeacon},
+	}
+	var err error
+	if binfo.weight, err = decodeWeight(ballot.Weight); err != nil {
+		return nil, err
+	}
+	if binfo.reference.weight, err = decodeWeight(ballot.RefWeight); err != nil {
+		return nil, err
+	}
+	if ballot.Votes > uint32(len(votes)) {
+		return nil, fmt.Errorf("%w: ballot %s references unknown vote %d", errCorruptCheckpoint, ballot.ID, ballot.Votes)
+	} else if ballot.Votes > 0 && votes[ballot.Votes-1] != nil {
+		binfo.votes.tail = votes[ballot.Votes-1]
+	} else if ballot.Votes == 0 {
+		binfo.votes.spilled = decodeSpilledRef(ballot.SpilledVotes)
+	}
+	return binfo, nil
+}
+
+// restore state from the checkpoint. turtle is expected to be freshly created.
+func (t *turtle) restore(state *checkpoint.State) error {
+	if state.Version != checkpoint.Version {
+		return fmt.Errorf("%w: version %d", errStaleCheckpoint, state.Version)
+	}
+	if state.Genesis != types.GetEffectiveGenesis() {
+		return fmt.Errorf("%w: genesis %s", errStaleCheckpoint, state.Genesis)
+	}
+	if state.Hdist != t.Hdist || state.Zdist != t.Zdist || state.WindowSize != t.WindowSize {
+		return fmt.Errorf("%w: parameters hdist=%d zdist=%d window=%d",
+			errStaleCheckpoint, state.Hdist, state.Zdist, state.WindowSize)
+	}
+	restored := newState()
+	restored.last = state.Last
+	restored.verified = state.Verified
+	restored.processed = state.Processed
+	restored.evicted = state.Evicted
+	restored.spilled = state.Spilled
+	restored.changedOpinion.min = state.ChangedOpinionMin
+	restored.changedOpinion.max = state.ChangedOpinionMax
+	var err error
+	if restored.localThreshold, err = decodeWeight(state.LocalThreshold); err != nil {
+		return err
+	}
+	totalGoodWeight, err := decodeWeight(state.TotalGoodWeight)
+	if err != nil {
+		return err
+	}
+
+	for _, epoch := range state.Epochs {
+		einfo := &epochInfo{
+			atxs:   make(map[types.ATXID]uint64, len(epoch.Atxs)),
+			weight: epoch.Weight,
+			height: epoch.Height,
+		}
+		for _, atx := range epoch.Atxs {
+			einfo.atxs[atx.ID] = atx.Weight
+		}
+		restored.epochs[types.EpochID(epoch.Epoch)] = einfo
+	}
+
+	for i := range state.Layers {
+		linfo, err := decodeCheckpointLayer(&state.Layers[i])
+		if err != nil {
+			return err
+		}
+		for _, binfo := range linfo.blocks {
+			restored.blockRefs[binfo.id] = binfo
+		}
+		restored.layers[linfo.lid] = linfo
+	}
+	for _, linfo := range restored.layers {
+		if linfo.prevOpinion == nil {
+			continue
+		}
+		if prev, exist := restored.layers[linfo.lid.Sub(1)]; exist {
+			if prev.opinion != *linfo.prevOpinion {
+				return fmt.Errorf("%w: previous opinion for layer %s doesn't match", errCorruptCheckpoint, linfo.lid)
+			}
+			linfo.prevOpinion = &prev.opinion
+		}
+	}
+
+	votes, err := decodeCheckpointVotes(state.Votes, restored.blockRefs, func(lid types.LayerID) (*layerInfo, error) {
+		linfo, exist := restored.layers[lid]
+		if !exist {
+			return nil, fmt.Errorf("%w: vote for unknown layer %s", errCorruptCheckpoint, lid)
+		}
+		return linfo, nil
+	})
+	if err != nil {
+		return err
+	}
+	for i := range state.Ballots {
+		binfo, err := decodeCheckpointBallot(&state.Ballots[i], votes)
+		if err != nil {
+			return err
+		}
+		linfo, exist := restored.layers[binfo.layer]
+		if !exist {
+			return fmt.Errorf("%w: ballot %s in unknown layer %s", errCorruptCheckpoint, binfo.id, binfo.layer)
+		}
+		linfo.ballots = append(linfo.ballots, binfo)
+		restored.ballotRefs[binfo.id] = binfo
+	}
+
+	delayed := map[types.LayerID][]*ballotInfo{}
+	for _, layer := range state.Delayed {
+		for _, id := range layer.Ballots {
+			binfo, exist := restored.ballotRefs[id]
+			if !exist {
+				return fmt.Errorf("%w: unknown delayed ballot %s", errCorruptCheckpoint, id)
+			}
+			delayed[layer.Layer] = append(delayed[layer.Layer], binfo)
+		}
+	}
+
+	restored.store = t.store
+	// verifying and full tortoise share a pointer to the state
+	*t.state = *restored
+	t.verifying.totalGoodWeight = totalGoodWeight
+	t.full.delayed = delayed
+	t.full.counted = state.Counted
+	t.isFull = state.IsFull
+	t.checkpointed = state.Processed
+	return nil
+}
+
+// loadCheckpoint restores the last checkpoint from the database and updates state
+// with data that was persisted after the checkpoint was taken.
+//
+// It returns an error if checkpoint can't be used and tortoise needs to be rerun
+// from genesis.
+func (t *turtle) loadCheckpoint() error {
+	cp, err := checkpoints.Last(t.cdb)
+	if err != nil {
+		return err
+	}
+	if cp.Layer.After(t.MeshProcessed) {
+		// database was reverted to a layer before the checkpoint. such checkpoint
+		// will never be usable, therefore it is safe to delete it.
+		if err := checkpoints.DeleteAfter(t.cdb, t.MeshProcessed); err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: checkpoint %s is after processed layer %s", errStaleCheckpoint, cp.Layer, t.MeshProcessed)
+	}
+	if hash := types.CalcHash32(cp.State); hash != cp.Hash {
+		return fmt.Errorf("%w: hash %s doesn't match %s", errCorruptCheckpoint, hash.ShortString(), cp.Hash.ShortString())
+	}
+	var state checkpoint.State
+	if err := codec.Decode(cp.State, &state); err != nil {
+		return fmt.Errorf("%w: %s", errCorruptCheckpoint, err)
+	}
+	if state.Processed != cp.Layer {
+		return fmt.Errorf("%w: checkpoint for %s stores state for %s", errCorruptCheckpoint, cp.Layer, state.Processed)
+	}
+	if err := t.restore(&state); err != nil {
+		return err
+	}
+	// ballots that were spilled after the checkpoint are either in the checkpoint,
+	// or will be loaded from the database together with other ballots received after it.
+	if err := checkpoints.DeleteSpilledSince(t.cdb, cp.Layer); err != nil {
+		return err
+	}
+	if err := checkpoints.PruneSpilledBefore(t.cdb, t.evicted.Add(1)); err != nil {
+		return err
+	}
+	if err := t.checkPersistedValidity(); err != nil {
+		return err
+	}
+	return t.loadAfterCheckpoint()
+}
+
+// checkPersistedValidity makes sure that validity persisted by tortoise is consistent with database.
+func (t *turtle) checkPersistedValidity() error {
+	for lid := t.evicted.Add(1); !lid.After(t.verified); lid = lid.Add(1) {
+		persisted, err := blocks.ContextualValidity(t.cdb, lid)
+		if err != nil {
+			return err
+		}
+		validity := make(map[types.BlockID]bool, len(persisted))
+		for _, block := range persisted {
+			validity[block.ID] = block.Validity
+		}
+		for _, block := range t.peekLayer(lid).blocks {
+			if block.persisted == abstain {
+				continue
+			}
+			valid, exist := validity[block.id]
+			if !exist {
+				return fmt.Errorf("%w: block %s in layer %s is not in the database", errStaleCheckpoint, block.id, lid)
+			}
+			if valid != (block.persisted == support) {
+				return fmt.Errorf("%w: validity for block %s in layer %s was changed", errStaleCheckpoint, block.id, lid)
+			}
+		}
+	}
+	return nil
+}
+
+// loadAfterCheckpoint loads data for processed layers that was persisted after checkpoint was taken.
+func (t *turtle) loadAfterCheckpoint() error {
+	for epoch := t.processed.GetEpoch(); epoch <= t.last.GetEpoch(); epoch++ {
+		if err := t.cdb.IterateEpochATXHeaders(epoch, func(header *types.ActivationTxHeader) bool {
+			t.onAtx(header)
+			return true
+		}); err != nil {
+			return fmt.Errorf("load atxs for epoch %d: %w", epoch, err)
+		}
+	}
+	for lid := t.evicted.Add(1); !lid.After(t.processed); lid = lid.Add(1) {
+		blockIDs, err := blocks.IDsInLayer(t.cdb, lid)
+		if err != nil {
+			return fmt.Errorf("read blocks for layer %s: %w", lid, err)
+		}
+		known := map[types.BlockID]struct{}{}
+		for _, block := range t.peekLayer(lid).blocks {
+			known[block.id] = struct{}{}
+		}
+		for _, bid := range blockIDs {
+			if _, exist := known[bid]; exist {
+				continue
+			}
+			block, err := blocks.Get(t.cdb, bid)
+			if err != nil {
+				return fmt.Errorf("read block %s: %w", bid, err)
+			}
+			if err := t.onBlock(lid, block); err != nil {
+				return err
+			}
+		}
+		output, err := layers.GetHareOutput(t.cdb, lid)
+		if err != nil && !errors.Is(err, sql.ErrNotFound) {
+			return fmt.Errorf("get hare output %s: %w", lid, err)
+		}
+		if err == nil && !t.hareOutputMatches(lid, output) {
+			t.onHareOutput(lid, output)
+		}
+		ballotIDs, err := ballots.IDsInLayer(t.cdb, lid)
+		if err != nil {
+			return fmt.Errorf("read ballots for layer %s: %w", lid, err)
+		}
+		knownBallots := map[types.BallotID]struct{}{}
+		if err := t.withBallots(lid, func(ballots []*ballotInfo) {
+			for _, ballot := range ballots {
+				knownBallots[ballot.id] = struct{}{}
+			}
+		}); err != nil {
+			return err
+		}
+		for _, id := range ballotIDs {
+			if _, exist := knownBallots[id]; exist {
+				continue
+			}
+			ballot, err := ballots.Get(t.cdb, id)
+			if err != nil {
+				return fmt.Errorf("read ballot %s: %w", id, err)
+			}
+			if err := t.onBallot(ballot); err != nil {
+				t.logger.With().Error("failed to add ballot to the state", log.Err(err), log.Inline(ballot))
+			}
+		}
+	}
+	return nil
+}
+
+func (t *turtle) hareOutputMatches(lid types.LayerID, output types.BlockID) bool {
+	layer := t.peekLayer(lid)
+	if !layer.hareTerminated {
+		return false
+	}
+	supported := types.EmptyBlockID
+	for _, block := range layer.blocks {
+		if block.hare == support {
+			supported = block.id
+		}
+	}
+	return supported == output
+}
+
+func encodeWeight(w weight) ([]byte, error) {
+	if w.IsNil() {
+		return nil, nil
+	}
+	buf, err := w.Rat.GobEncode()
+	if err != nil {
+		return nil, fmt.Errorf("encode weight %s: %w", w, err)
+	}
+	return buf, nil
+}
+
+func decodeWeight(buf []byte) (weight, error) {
+	if len(buf) == 0 {
+		return weight{}, nil
+	}
+	rat := new(big.Rat)
+	if err := rat.GobDecode(buf); err != nil {
+		return weight{}, fmt.Errorf("%w: decode weight: %s", errCorruptCheckpoint, err)
+	}
+	return weight{Rat: rat}, nil
+}
+
+func encodeSign(s sign) uint8 {
+	return uint8(s + 1)
+}
+
+func decodeSign(value uint8) (sign, error) {
+	if value > checkpoint.Support {
+		return 0, fmt.Errorf("%w: invalid vote %d", errCorruptCheckpoint, value)
+	}
+	return sign(value) - 1, nil
+}
@@ -0,0 +1,161 @@
+// Package checkpoint defines the persisted representation of the tortoise state.
+package checkpoint
+
+import (
+	"github.com/spacemeshos/go-spacemesh/common/types"
+)
+
+//go:generate scalegen
+
+// Version of the checkpoint encoding. Checkpoints with a different version are ignored.
+const Version = 4
+
+// Votes are encoded as a sign shifted by one, so that they fit into unsigned byte.
+const (
+	Against uint8 = iota
+	Abstain
+	Support
+)
+
+// State is a snapshot of the tortoise state after the layer was processed.
+//
+// Weights are encoded using big.Rat gob encoding, empty weight is decoded as nil weight.
+type State struct {
+	Version uint32
+	Genesis types.LayerID
+
+	// protocol parameters that affect state that is stored in the checkpoint.
+	Hdist      uint32
+	Zdist      uint32
+	WindowSize uint32
+
+	Last      types.LayerID
+	Verified  types.LayerID
+	Processed types.LayerID
+	Evicted   types.LayerID
+	// Spilled is the last layer that was moved out of memory.
+	Spilled types.LayerID
+
+	ChangedOpinionMin types.LayerID
+	ChangedOpinionMax types.LayerID
+
+	LocalThreshold  []byte
+	TotalGoodWeight []byte
+
+	// IsFull is true if tortoise was in full mode when checkpoint was taken.
+	IsFull bool
+	// Counted is the last layer counted by full tortoise.
+	Counted types.LayerID
+
+	Epochs []Epoch
+	Layers []Layer
+	// Votes are ordered so that the previous vote is always stored before the vote
+	// that references it.
+	Votes []Vote
+	// Ballots are ordered by layer in the same order as they were added to the state.
+	Ballots []Ballot
+	Delayed []Delayed
+}
+
+// Epoch contains atxs that target the epoch.
+type Epoch struct {
+	Epoch  uint32
+	Weight uint64
+	Height uint64
+	Atxs   []ATX
+}
+
+// ATX is a weight of the atx.
+type ATX struct {
+	ID     types.ATXID
+	Weight uint64
+}
+
+// Layer contains blocks and computed opinion for the layer.
+type Layer struct {
+	ID             types.LayerID
+	Empty          []byte
+	HareTerminated bool
+	Opinion        types.Hash32
+	// HasPrevOpinion is true if opinion of the previous layer was used to compute
+	// Opinion.
+	HasPrevOpinion  bool
+	PrevOpinion     types.Hash32
+	GoodUncounted   []byte
+	ReferenceHeight uint64
+	Blocks          []Block
+}
+
+// Block is a block with computed votes.
+type Block struct {
+	ID        types.BlockID
+	Height    uint64
+	Hare      uint8
+	Validity  uint8
+	Persisted uint8
+	Margin    []byte
+}
+
+// Vote is a ballot opinion about a single layer.
+type Vote struct {
+	Layer     types.LayerID
+	Vote      uint8
+	Opinion   types.Hash32
+	Supported []types.BlockID
+	// Prev is an index of the previous vote in the State.Votes increased by one.
+	// Zero is used if vote doesn't have previous votes.
+	Prev uint32
+	// SpilledPrev references the previous vote if it was moved out of memory
+	// together with the layer. It is used only if Prev is zero.
+	// Votes in the SpilledLayer always use SpilledPrev.
+	SpilledPrev SpilledRef
+}
+
+// Ballot is a decoded ballot.
+type Ballot struct {
+	ID        types.BallotID
+	ATX       types.ATXID
+	Layer     types.LayerID
+	BaseID    types.BallotID
+	BaseLayer types.LayerID
+	Weight    []byte
+	RefWeight []byte
+	RefHeight uint64
+	RefBeacon types.Beacon
+	BadBeacon bool
+	// Votes is an index of the last vote in the State.Votes increased by one.
+	// Zero is used if ballot doesn't have votes.
+	Votes uint32
+	// SpilledVotes references the last vote of the ballot if it was moved out of memory
+	// together with the layer. It is used only if Votes is zero.
+	SpilledVotes SpilledRef
+}
+
+// SpilledRef references a vote in the SpilledLayer. Zero value is used if there is no reference.
+type SpilledRef struct {
+	Layer types.LayerID
+	// Index of the vote in the SpilledLayer.Votes increased by one.
+	// Zero is used if the vote is not stored and only its opinion is known.
+	Index uint32
+	// Opinion of the referenced vote.
+	Opinion types.Hash32
+}
+
+// SpilledLayer is a layer that was moved out of memory together with votes for it.
+// Votes are only appended, so that references to them remain valid.
+type SpilledLayer struct {
+	Layer Layer
+	Votes []Vote
+}
+
+// SpilledBallots is a batch of ballots from a single layer that were moved out of memory.
+// Votes of the spilled ballots are always referenced by SpilledVotes.
+type SpilledBallots struct {
+	Ballots []Ballot
+}
+
+// Delayed ballots that will be counted by full tortoise after the layer.
+type Delayed struct {
+	Layer   types.LayerID
+	Ballots []types.BallotID
+}
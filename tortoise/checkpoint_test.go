@@ -0,0 +1,155 @@
+package tortoise
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/sql/checkpoints"
+	"github.com/spacemeshos/go-spacemesh/tortoise/checkpoint"
+	"github.com/spacemeshos/go-spacemesh/tortoise/sim"
+)
+
+func recoverTortoise(tb testing.TB, state sim.State, cfg Config, last types.LayerID) *Tortoise {
+	tb.Helper()
+	cfg.MeshProcessed = last
+	tortoise := tortoiseFromSimState(state, WithLogger(logtest.New(tb)), WithConfig(cfg))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(tb, tortoise.WaitReady(ctx))
+	return tortoise
+}
+
+func encodedState(tb testing.TB, tortoise *Tortoise) *checkpoint.State {
+	tb.Helper()
+	tortoise.mu.Lock()
+	defer tortoise.mu.Unlock()
+	state, err := tortoise.trtl.encodeState()
+	require.NoError(tb, err)
+	return state
+}
+
+func TestCheckpointRecovery(t *testing.T) {
+	ctx := context.Background()
+	const size = 10
+	s := sim.New(sim.WithLayerSize(size))
+	s.Setup()
+
+	cfg := defaultTestConfig()
+	cfg.LayerSize = size
+	cfg.CheckpointInterval = 10
+	tortoise := tortoiseFromSimState(s.GetState(0), WithLogger(logtest.New(t)), WithConfig(cfg))
+	var last types.LayerID
+	for i := 0; i < 45; i++ {
+		last = s.Next()
+		tortoise.TallyVotes(ctx, last)
+	}
+	require.Equal(t, last.Sub(1), tortoise.LatestComplete())
+
+	cp, err := checkpoints.Last(s.GetState(0).DB)
+	require.NoError(t, err)
+	require.Equal(t, types.GetEffectiveGenesis().Add(40), cp.Layer)
+
+	// with a larger interval checkpoints won't be created if state is rerun from genesis
+	rcfg := cfg
+	rcfg.CheckpointInterval = 100
+	recovered := recoverTortoise(t, s.GetState(0), rcfg, last)
+	require.Equal(t, cp.Layer, recovered.trtl.checkpointed, "state must be loaded from checkpoint")
+	require.Equal(t, last.Sub(1), recovered.LatestComplete())
+	require.Equal(t, encodedState(t, tortoise), encodedState(t, recovered))
+
+	expected, err := tortoise.EncodeVotes(ctx)
+	require.NoError(t, err)
+	votes, err := recovered.EncodeVotes(ctx)
+	require.NoError(t, err)
+	require.Equal(t, expected, votes)
+
+	for i := 0; i < 10; i++ {
+		last = s.Next()
+		tortoise.TallyVotes(ctx, last)
+		recovered.TallyVotes(ctx, last)
+	}
+	require.Equal(t, last.Sub(1), recovered.LatestComplete())
+	require.Equal(t, encodedState(t, tortoise), encodedState(t, recovered))
+}
+
+func TestCheckpointFallback(t *testing.T) {
+	ctx := context.Background()
+	const size = 10
+	s := sim.New(sim.WithLayerSize(size))
+	s.Setup()
+
+	cfg := defaultTestConfig()
+	cfg.LayerSize = size
+	cfg.CheckpointInterval = 10
+	tortoise := tortoiseFromSimState(s.GetState(0), WithLogger(logtest.New(t)), WithConfig(cfg))
+	var last types.LayerID
+	for i := 0; i < 25; i++ {
+		last = s.Next()
+		tortoise.TallyVotes(ctx, last)
+	}
+	db := s.GetState(0).DB
+	cp, err := checkpoints.Last(db)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		desc   string
+		update func(cp checkpoints.Checkpoint, cfg *Config, last *types.LayerID) *checkpoints.Checkpoint
+	}{
+		{
+			desc: "corrupt hash",
+			update: func(cp checkpoints.Checkpoint, _ *Config, _ *types.LayerID) *checkpoints.Checkpoint {
+				cp.Hash = types.Hash32{1}
+				return &cp
+			},
+		},
+		{
+			desc: "corrupt state",
+			update: func(cp checkpoints.Checkpoint, _ *Config, _ *types.LayerID) *checkpoints.Checkpoint {
+				cp.State = cp.State[:len(cp.State)/2]
+				cp.Hash = types.CalcHash32(cp.State)
+				return &cp
+			},
+		},
+		{
+			desc: "changed parameters",
+			update: func(_ checkpoints.Checkpoint, cfg *Config, _ *types.LayerID) *checkpoints.Checkpoint {
+				cfg.WindowSize++
+				return nil
+			},
+		},
+		{
+			desc: "after mesh processed",
+			update: func(cp checkpoints.Checkpoint, _ *Config, last *types.LayerID) *checkpoints.Checkpoint {
+				cp.Layer = last.Add(10)
+				return &cp
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := cfg
+			last := last
+			updated := tc.update(*cp, &cfg, &last)
+			if updated != nil {
+				require.NoError(t, checkpoints.Add(db, updated))
+				defer func() {
+					require.NoError(t, checkpoints.DeleteAfter(db, cp.Layer))
+					require.NoError(t, checkpoints.Add(db, cp))
+				}()
+			}
+			recovered := recoverTortoise(t, s.GetState(0), cfg, last)
+			require.Equal(t, last.Sub(1), recovered.LatestComplete())
+			if updated != nil && updated.Layer.After(last) {
+				// checkpoint that can't be used is deleted
+				stored, err := checkpoints.Last(db)
+				require.NoError(t, err)
+				require.Equal(t, cp.Layer, stored.Layer)
+			}
+		})
+	}
+}
@@ -0,0 +1,340 @@
+package tortoise
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+)
+
+// ErrNotInWindow is returned if layer or block is outside of the tortoise sliding window.
+var ErrNotInWindow = errors.New("not in the tortoise window")
+
+const (
+	verifyingMode = "verifying"
+	fullMode      = "full"
+
+	reasonHareNotTerminated = "hare is not terminated"
+	reasonBelowThreshold    = "margin doesn't cross global threshold"
+	reasonUndecidedBlock    = "block is undecided"
+
+	// maxModeSwitches is the number of mode switches that are kept in memory.
+	maxModeSwitches = 100
+)
+
+// decisionInfo contains inputs that were used on the last attempt to verify a layer.
+type decisionInfo struct {
+	mode     string
+	verified bool
+	reason   string
+
+	threshold weight
+	// margin is a good weight for verifying tortoise, and a weight that votes
+	// for empty layer for full tortoise.
+	margin weight
+	// uncounted, totalGoodWeight and goodUncounted are recorded only by verifying tortoise.
+	uncounted       weight
+	totalGoodWeight weight
+	goodUncounted   weight
+
+	blocks map[types.BlockID]sign
+}
+
+func (d *decisionInfo) recordBlock(getDecision func(*blockInfo) sign) func(*blockInfo) sign {
+	d.blocks = map[types.BlockID]sign{}
+	return func(block *blockInfo) sign {
+		decision := getDecision(block)
+		d.blocks[block.id] = decision
+		return decision
+	}
+}
+
+func (d *decisionInfo) record(verified bool) bool {
+	d.verified = verified
+	if !verified {
+		d.reason = reasonUndecidedBlock
+	}
+	return verified
+}
+
+type modeSwitch struct {
+	target    types.LayerID
+	processed types.LayerID
+	verified  types.LayerID
+	full      bool
+}
+
+func (t *turtle) recordModeSwitch(target types.LayerID) {
+	if len(t.modeSwitches) == maxModeSwitches {
+		copy(t.modeSwitches, t.modeSwitches[1:])
+		t.modeSwitches = t.modeSwitches[:maxModeSwitches-1]
+	}
+	t.modeSwitches = append(t.modeSwitches, modeSwitch{
+		target:    target,
+		processed: t.processed,
+		verified:  t.verified,
+		full:      t.isFull,
+	})
+}
+
+func modeName(full bool) string {
+	if full {
+		return fullMode
+	}
+	return verifyingMode
+}
+
+func weightFloat(w weight) float64 {
+	if w.IsNil() {
+		return 0
+	}
+	value, _ := w.Float64()
+	return value
+}
+
+// LayerExplanation describes why the layer is or isn't verified.
+type LayerExplanation struct {
+	Layer          types.LayerID
+	Verified       bool
+	HareTerminated bool
+	Opinion        types.Hash32
+
+	// state of the tortoise when explanation was created.
+	Mode      string
+	Last      types.LayerID
+	Processed types.LayerID
+	Latest    types.LayerID
+
+	// current thresholds for the layer.
+	GlobalThreshold float64
+	LocalThreshold  float64
+	ExpectedWeight  float64
+
+	// Decision made on the last attempt to verify the layer. Nil if layer wasn't a candidate yet.
+	Decision *Decision
+	Blocks   []BlockExplanation
+	// ModeSwitches that happened when the layer was a candidate for verification.
+	ModeSwitches []ModeSwitch
+}
+
+// Decision contains inputs that were used on the last attempt to verify the layer.
+type Decision struct {
+	Mode     string
+	Verified bool
+	// Reason why layer wasn't verified.
+	Reason          string
+	GlobalThreshold float64
+	// Margin is the good weight in verifying mode, and the weight of votes for empty layer in full mode.
+	Margin float64
+	// Uncounted, TotalGoodWeight and GoodUncounted are set only in verifying mode.
+	Uncounted       float64
+	TotalGoodWeight float64
+	GoodUncounted   float64
+	Blocks          []BlockDecision
+}
+
+// BlockDecision is a decision made for a block.
+type BlockDecision struct {
+	Block    types.BlockID
+	Decision string
+}
+
+// ModeSwitch is recorded every time tortoise switches between verifying and full modes.
+type ModeSwitch struct {
+	Target    types.LayerID
+	Processed types.LayerID
+	Verified  types.LayerID
+	Mode      string
+}
+
+// BlockExplanation describes votes for the block.
+type BlockExplanation struct {
+	ID        types.BlockID
+	Layer     types.LayerID
+	Height    uint64
+	Hare      string
+	Validity  string
+	Persisted string
+
+	// LocalVote and LocalVoteReason are used when the block is encoded in ballots created by this node.
+	LocalVote       string
+	LocalVoteReason string
+
+	// Margin is counted by full tortoise.
+	Margin float64
+	// Support, Against and Abstain sum weight of ballots in the window.
+	Support float64
+	Against float64
+	Abstain float64
+
+	Ballots []BallotVote
+	Atxs    []AtxVote
+}
+
+// BallotVote is a vote of a single ballot for the block.
+type BallotVote struct {
+	Ballot types.BallotID
+	ATX    types.ATXID
+	Layer  types.LayerID
+	Weight float64
+	Vote   string
+	// Good is true if ballot is consistent with local opinion and is counted by verifying tortoise.
+	Good bool
+	// Counted is true if ballot vote for the block is counted by full tortoise.
+	Counted bool
+}
+
+// AtxVote sums votes from all ballots of the atx.
+type AtxVote struct {
+	ATX     types.ATXID
+	Support float64
+	Against float64
+	Abstain float64
+}
+
+func (t *turtle) explainLayer(lid types.LayerID) (*LayerExplanation, error) {
+	layer, exist := t.layers[lid]
+	if !exist || !lid.After(t.spilledBoundary()) {
+		return nil, fmt.Errorf("%w: layer %s", ErrNotInWindow, lid)
+	}
+	explanation := &LayerExplanation{
+		Layer:          lid,
+		Verified:       !lid.After(t.verified),
+		HareTerminated: layer.hareTerminated,
+		Opinion:        layer.opinion,
+		Mode:           modeName(t.isFull),
+		Last:           t.last,
+		Processed:      t.processed,
+		Latest:         t.verified,
+		LocalThreshold: weightFloat(t.localThreshold),
+	}
+	if lid.Before(t.last) {
+		explanation.GlobalThreshold = weightFloat(t.globalThreshold(t.Config, lid))
+		explanation.ExpectedWeight = weightFloat(t.expectedWeight(t.Config, lid))
+	}
+	if decision := layer.decision; decision != nil {
+		explanation.Decision = &Decision{
+			Mode:            decision.mode,
+			Verified:        decision.verified,
+			Reason:          decision.reason,
+			GlobalThreshold: weightFloat(decision.threshold),
+			Margin:          weightFloat(decision.margin),
+			Uncounted:       weightFloat(decision.uncounted),
+			TotalGoodWeight: weightFloat(decision.totalGoodWeight),
+			GoodUncounted:   weightFloat(decision.goodUncounted),
+		}
+		for _, block := range layer.blocks {
+			if vote, exist := decision.blocks[block.id]; exist {
+				explanation.Decision.Blocks = append(explanation.Decision.Blocks, BlockDecision{
+					Block:    block.id,
+					Decision: vote.String(),
+				})
+			}
+		}
+	}
+	for _, block := range layer.blocks {
+		explanation.Blocks = append(explanation.Blocks, *t.explainBlockInfo(block))
+	}
+	for _, switched := range t.modeSwitches {
+		if switched.target == lid {
+			explanation.ModeSwitches = append(explanation.ModeSwitches, ModeSwitch{
+				Target:    switched.target,
+				Processed: switched.processed,
+				Verified:  switched.verified,
+				Mode:      modeName(switched.full),
+			})
+		}
+	}
+	return explanation, nil
+}
+
+func (t *turtle) explainBlock(id types.BlockID) (*BlockExplanation, error) {
+	block, exist := t.blockRefs[id]
+	if !exist || !block.layer.After(t.spilledBoundary()) {
+		return nil, fmt.Errorf("%w: block %s", ErrNotInWindow, id)
+	}
+	return t.explainBlockInfo(block), nil
+}
+
+func (t *turtle) explainBlockInfo(block *blockInfo) *BlockExplanation {
+	explanation := &BlockExplanation{
+		ID:        block.id,
+		Layer:     block.layer,
+		Height:    block.height,
+		Hare:      block.hare.String(),
+		Validity:  block.validity.String(),
+		Persisted: block.persisted.String(),
+		Margin:    weightFloat(block.margin),
+	}
+	vote, reason, err := t.getFullVote(t.verified, t.last.Add(1), block)
+	if err != nil {
+		// coinflip is not available, fallback to the vote that doesn't depend on it
+		vote, reason = getLocalVote(t.Config, t.verified, t.last.Add(1), block)
+	}
+	explanation.LocalVote = vote.String()
+	explanation.LocalVoteReason = reason.String()
+
+	atxs := map[types.ATXID]*AtxVote{}
+	for lid := block.layer.Add(1); !lid.After(t.processed); lid = lid.Add(1) {
+		prev := t.layer(lid.Sub(1))
+		for _, ballot := range t.layer(lid).ballots {
+			if ballot.weight.IsNil() {
+				continue
+			}
+			vote := ballotVote(ballot, block)
+			weight := weightFloat(ballot.weight)
+			explanation.Ballots = append(explanation.Ballots, BallotVote{
+				Ballot: ballot.id,
+				ATX:    ballot.atxid,
+				Layer:  ballot.layer,
+				Weight: weight,
+				Vote:   vote.String(),
+				Good: !(ballot.conditions.badBeacon ||
+					prev.opinion != ballot.opinion() ||
+					prev.verifying.referenceHeight > ballot.reference.height),
+				Counted: block.height <= ballot.reference.height,
+			})
+			atx, exist := atxs[ballot.atxid]
+			if !exist {
+				atx = &AtxVote{ATX: ballot.atxid}
+				atxs[ballot.atxid] = atx
+			}
+			switch vote {
+			case support:
+				explanation.Support += weight
+				atx.Support += weight
+			case against:
+				explanation.Against += weight
+				atx.Against += weight
+			case abstain:
+				explanation.Abstain += weight
+				atx.Abstain += weight
+			}
+		}
+	}
+	for _, atx := range atxs {
+		explanation.Atxs = append(explanation.Atxs, *atx)
+	}
+	sort.Slice(explanation.Atxs, func(i, j int) bool {
+		return bytes.Compare(explanation.Atxs[i].ATX[:], explanation.Atxs[j].ATX[:]) < 0
+	})
+	return explanation
+}
+
+// ballotVote returns the vote of the ballot for the block.
+func ballotVote(ballot *ballotInfo, block *blockInfo) sign {
+	for lvote := ballot.votes.tail; lvote != nil; lvote = lvote.prev {
+		if lvote.lid.Before(block.layer) {
+			break
+		}
+		if lvote.lid == block.layer {
+			if lvote.vote == abstain {
+				return abstain
+			}
+			return lvote.getVote(block.id)
+		}
+	}
+	return abstain
+}
@@ -0,0 +1,130 @@
+package tortoise
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/tortoise/sim"
+)
+
+func TestExplainLayer(t *testing.T) {
+	const size = 10
+	t.Run("Verified", func(t *testing.T) {
+		s := sim.New(sim.WithLayerSize(size))
+		s.Setup()
+
+		ctx := context.Background()
+		cfg := defaultTestConfig()
+		cfg.LayerSize = size
+		tortoise := tortoiseFromSimState(s.GetState(0), WithConfig(cfg), WithLogger(logtest.New(t)))
+
+		var last types.LayerID
+		for _, last = range sim.GenLayers(s, sim.WithSequence(3)) {
+			tortoise.TallyVotes(ctx, last)
+		}
+		target := last.Sub(1)
+		explanation, err := tortoise.ExplainLayer(target)
+		require.NoError(t, err)
+		require.True(t, explanation.Verified)
+		require.True(t, explanation.HareTerminated)
+		require.Equal(t, verifyingMode, explanation.Mode)
+		require.Equal(t, last, explanation.Processed)
+		require.NotNil(t, explanation.Decision)
+		require.True(t, explanation.Decision.Verified)
+		require.Empty(t, explanation.Decision.Reason)
+		require.Equal(t, verifyingMode, explanation.Decision.Mode)
+		require.Greater(t, explanation.Decision.Margin, explanation.Decision.GlobalThreshold)
+		require.NotEmpty(t, explanation.Decision.Blocks)
+		require.Len(t, explanation.Blocks, len(explanation.Decision.Blocks))
+		var block BlockExplanation
+		for i, decision := range explanation.Decision.Blocks {
+			require.Equal(t, explanation.Blocks[i].Hare, decision.Decision)
+			if decision.Decision == support.String() {
+				block = explanation.Blocks[i]
+			}
+		}
+		require.Equal(t, target, block.Layer)
+		require.Equal(t, support.String(), block.Hare)
+		require.Equal(t, support.String(), block.Validity)
+		require.Equal(t, support.String(), block.LocalVote)
+		require.Equal(t, reasonHareOutput.String(), block.LocalVoteReason)
+		require.NotEmpty(t, block.Ballots)
+		require.NotEmpty(t, block.Atxs)
+		var total float64
+		for _, ballot := range block.Ballots {
+			require.Equal(t, last, ballot.Layer)
+			require.Equal(t, support.String(), ballot.Vote)
+			require.True(t, ballot.Good)
+			require.True(t, ballot.Counted)
+			total += ballot.Weight
+		}
+		require.Equal(t, total, block.Support)
+		require.Zero(t, block.Against)
+		require.Zero(t, block.Abstain)
+
+		explained, err := tortoise.ExplainBlock(block.ID)
+		require.NoError(t, err)
+		require.Equal(t, block, *explained)
+	})
+	t.Run("NotVerified", func(t *testing.T) {
+		s := sim.New(sim.WithLayerSize(size))
+		s.Setup()
+
+		ctx := context.Background()
+		cfg := defaultTestConfig()
+		cfg.LayerSize = size
+		tortoise := tortoiseFromSimState(s.GetState(0), WithConfig(cfg), WithLogger(logtest.New(t)))
+
+		var last types.LayerID
+		for _, last = range sim.GenLayers(s,
+			sim.WithSequence(3),
+			sim.WithSequence(2, sim.WithVoteGenerator(abstainVoting)),
+		) {
+			tortoise.TallyVotes(ctx, last)
+		}
+		target := tortoise.LatestComplete().Add(1)
+		require.True(t, target.Before(last))
+		explanation, err := tortoise.ExplainLayer(target)
+		require.NoError(t, err)
+		require.False(t, explanation.Verified)
+		require.NotNil(t, explanation.Decision)
+		require.False(t, explanation.Decision.Verified)
+		require.Equal(t, reasonBelowThreshold, explanation.Decision.Reason)
+		require.Less(t, explanation.Decision.Margin, explanation.Decision.GlobalThreshold)
+		for _, block := range explanation.Blocks {
+			for _, ballot := range block.Ballots {
+				// ballots from the last two layers abstain, and are not consistent with local opinion
+				require.Equal(t, ballot.Layer.Before(last.Sub(1)), ballot.Good)
+			}
+		}
+
+		abstained, err := tortoise.ExplainLayer(last.Sub(2))
+		require.NoError(t, err)
+		for _, block := range abstained.Blocks {
+			for _, ballot := range block.Ballots {
+				if ballot.Layer == last.Sub(1) {
+					require.Equal(t, abstain.String(), ballot.Vote)
+				}
+			}
+			require.NotZero(t, block.Abstain)
+		}
+	})
+	t.Run("NotInWindow", func(t *testing.T) {
+		s := sim.New(sim.WithLayerSize(size))
+		s.Setup()
+
+		cfg := defaultTestConfig()
+		cfg.LayerSize = size
+		tortoise := tortoiseFromSimState(s.GetState(0), WithConfig(cfg), WithLogger(logtest.New(t)))
+
+		_, err := tortoise.ExplainLayer(types.NewLayerID(100))
+		require.True(t, errors.Is(err, ErrNotInWindow))
+		_, err = tortoise.ExplainBlock(types.BlockID{1})
+		require.True(t, errors.Is(err, ErrNotInWindow))
+	})
+}
@@ -38,10 +38,13 @@ func (f *full) countBallot(logger log.Log, ballot *ballotInfo) {
 		log.Stringer("id", ballot.id),
 		log.Uint32("lid", ballot.layer.Value),
 	)
+	ref := ballot.votes.spilled
 	for lvote := ballot.votes.tail; lvote != nil; lvote = lvote.prev {
 		if !lvote.lid.After(f.evicted) {
+			ref = nil
 			break
 		}
+		ref = lvote.spilled
 		if lvote.vote == abstain {
 			continue
 		}
@@ -62,23 +65,20 @@ func (f *full) countBallot(logger log.Log, ballot *ballotInfo) {
 			lvote.empty = lvote.empty.Add(ballot.weight)
 		}
 	}
+	// votes that were moved out of memory are counted when pending weight is flushed
+	f.countSpilled(ref, ballot.reference.height, ballot.weight)
 }
 
-func (f *full) countForLateBlock(block *blockInfo) {
-	// ballots are always added to the state after blocks that are
-	// explicitly referenced in the ballot.
-	// therefore if block is added later - all previous ballots vote
-	// for it negatively.
-	//
+// countForLateBlock counts votes against the block from ballots that were counted
+// before the block was added.
+func (f *full) countForLateBlock(block *blockInfo, ballots []*ballotInfo) {
 	// we could store all negative weight in a single variable and avoid
 	// this computation if there would be no height
-	for lid := block.layer.Add(1); !lid.After(f.counted); lid = lid.Add(1) {
-		for _, ballot := range f.layer(lid).ballots {
-			if block.height > ballot.reference.height {
-				continue
-			}
-			block.margin = block.margin.Sub(ballot.weight)
+	for _, ballot := range ballots {
+		if block.height > ballot.reference.height {
+			continue
 		}
+		block.margin = block.margin.Sub(ballot.weight)
 	}
 }
 
@@ -112,6 +112,12 @@ func (f *full) verify(logger log.Log, lid types.LayerID) bool {
 		log.Stringer("global_threshold", threshold),
 	)
 	layer := f.state.layer(lid)
+	decision := &decisionInfo{
+		mode:      fullMode,
+		threshold: threshold,
+		margin:    layer.empty.Copy(),
+	}
+	layer.decision = decision
 	empty := layer.empty.Cmp(threshold) > 0
 	if len(layer.blocks) == 0 {
 		if empty {
@@ -121,20 +127,22 @@ func (f *full) verify(logger log.Log, lid types.LayerID) bool {
 				lid,
 				log.Stringer("margin", layer.empty),
 			)
+			decision.reason = reasonBelowThreshold
 		}
+		decision.verified = empty
 		return empty
 	}
-	return verifyLayer(
+	return decision.record(verifyLayer(
 		logger,
 		layer.blocks,
-		func(block *blockInfo) sign {
+		decision.recordBlock(func(block *blockInfo) sign {
 			decision := sign(block.margin.Cmp(threshold))
 			if decision == neutral && empty {
 				return against
 			}
 			return decision
-		},
-	)
+		}),
+	))
 }
 
 func (f *full) shouldBeDelayed(logger log.Log, ballot *ballotInfo) bool {
@@ -19,3 +19,77 @@ var LayerDistanceToBaseBallot = metrics.NewHistogramWithBuckets(
 	[]string{},
 	prometheus.ExponentialBuckets(1, 2, 16),
 )
+
+// CheckpointDuration is the time it takes to store tortoise state in the database.
+var CheckpointDuration = metrics.NewHistogramWithBuckets(
+	"checkpoint_duration",
+	Subsystem,
+	"Duration in seconds of storing tortoise checkpoint",
+	[]string{},
+	prometheus.ExponentialBuckets(0.01, 2, 12),
+).WithLabelValues()
+
+// CheckpointSize is the size of the last stored tortoise checkpoint.
+var CheckpointSize = metrics.NewGauge(
+	"checkpoint_size",
+	Subsystem,
+	"Size in bytes of the last tortoise checkpoint",
+	[]string{},
+).WithLabelValues()
+
+// ResidentLayers is the number of layers in memory.
+var ResidentLayers = metrics.NewGauge(
+	"resident_layers",
+	Subsystem,
+	"Number of layers in memory",
+	[]string{},
+).WithLabelValues()
+
+// ResidentBallots is the number of ballots in memory.
+var ResidentBallots = metrics.NewGauge(
+	"resident_ballots",
+	Subsystem,
+	"Number of ballots in memory",
+	[]string{},
+).WithLabelValues()
+
+// ResidentBlocks is the number of blocks in memory.
+var ResidentBlocks = metrics.NewGauge(
+	"resident_blocks",
+	Subsystem,
+	"Number of blocks in memory",
+	[]string{},
+).WithLabelValues()
+
+// SpilledLayers is the number of layers in the tortoise window that are stored in the database.
+var SpilledLayers = metrics.NewGauge(
+	"spilled_layers",
+	Subsystem,
+	"Number of layers moved to the database",
+	[]string{},
+).WithLabelValues()
+
+// SpilledBallots counts ballots that were moved to the database.
+var SpilledBallots = metrics.NewCounter(
+	"spilled_ballots",
+	Subsystem,
+	"Number of ballots moved to the database",
+	[]string{},
+).WithLabelValues()
+
+// SpillReloads counts loads of spilled layers and ballots from the database.
+var SpillReloads = metrics.NewCounter(
+	"spill_reloads",
+	Subsystem,
+	"Number of times spilled layers or ballots were loaded from the database",
+	[]string{},
+).WithLabelValues()
+
+// UnknownOpinionCheckpoints counts ballots rejected because their opinion checkpoint
+// is not consistent with the local state.
+var UnknownOpinionCheckpoints = metrics.NewCounter(
+	"unknown_opinion_checkpoints",
+	Subsystem,
+	"Number of ballots with opinion checkpoint that is not consistent with the state",
+	[]string{},
+).WithLabelValues()
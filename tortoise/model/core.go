@@ -56,7 +56,7 @@ type core struct {
 
 	// generated on setup
 	units  uint32
-	signer signing.Signer
+	signer *signing.EdSigner
 
 	// set in the first layer of each epoch
 	refBallot     *types.BallotID
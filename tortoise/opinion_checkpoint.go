@@ -0,0 +1,280 @@
+package tortoise
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/tortoise/checkpoint"
+)
+
+// encodeOpinionCheckpoint encodes votes relative to the local opinion on the checkpoint layer,
+// instead of the base ballot. It is used when none of the ballots can be used as a base ballot
+// without exceeding the limit on exceptions, for example after long period without consistent voting.
+//
+// Votes up to and including checkpoint layer are represented by the opinion hash,
+// and votes after it are encoded as exceptions. Checkpoint layer is the verified layer,
+// unless exceptions after it exceed the limit, in such case it is moved forward.
+func (t *turtle) encodeOpinionCheckpoint(ctx context.Context, current types.LayerID) (*ballotInfo, *types.Opinion, error) {
+	lid := current.Sub(1)
+	exceptions := 0
+	for ; lid.After(t.verified) && lid.After(t.evicted.Add(1)); lid = lid.Sub(1) {
+		layer := t.peekLayer(lid)
+		if !layer.hareTerminated {
+			continue
+		}
+		supported := 0
+		for _, block := range layer.blocks {
+			vote, _, err := t.getFullVote(t.verified, current, block)
+			if err != nil {
+				return nil, nil, err
+			}
+			if vote == support {
+				supported++
+			}
+		}
+		if exceptions+supported > t.MaxExceptions {
+			break
+		}
+		exceptions += supported
+	}
+	if !lid.After(t.evicted) {
+		return nil, nil, fmt.Errorf("checkpoint layer %s is evicted", lid)
+	}
+	local, err := t.localVotes(lid, current, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	base := &ballotInfo{layer: lid.Add(1), votes: local}
+	opinion, err := t.encodeVotes(ctx, base, base.layer, current)
+	if err != nil {
+		return nil, nil, err
+	}
+	opinion.Votes.Checkpoint = &types.OpinionCheckpoint{
+		Layer:   lid,
+		Opinion: local.opinion(),
+	}
+	return base, opinion, nil
+}
+
+// decodeOpinionCheckpoint returns base for the ballot that votes with opinion checkpoint.
+// Returns nil if opinion on the checkpoint layer is not known.
+//
+// Opinion is consistent with local opinion if the ballot agrees with this node, otherwise
+// it may be consistent with votes from other ballots.
+func (t *turtle) decodeOpinionCheckpoint(current types.LayerID, cp *types.OpinionCheckpoint) *ballotInfo {
+	if !cp.Layer.After(t.evicted) || !cp.Layer.Before(current) {
+		return nil
+	}
+	base := &ballotInfo{layer: cp.Layer.Add(1)}
+	local, err := t.localVotes(cp.Layer, current, false)
+	if err != nil {
+		t.logger.With().Debug("failed to compute local votes for opinion checkpoint",
+			cp.Layer, log.Err(err),
+		)
+	} else if local.opinion() == cp.Opinion {
+		if t.spilled.After(t.evicted) {
+			// votes for the layers that are not in memory are stored only when they are used
+			if local, err = t.localVotes(cp.Layer, current, true); err != nil {
+				t.logger.With().Error("failed to store local votes for opinion checkpoint",
+					cp.Layer, log.Err(err),
+				)
+				return nil
+			}
+		}
+		base.votes = local
+		return base
+	}
+	var (
+		found *votes
+		refs  = map[types.LayerID]map[uint32]*spilledVote{}
+	)
+	for lid := cp.Layer.Add(1); !lid.After(t.last) && found == nil; lid = lid.Add(1) {
+		if err := t.withBallots(lid, func(ballots []*ballotInfo) {
+			for _, ballot := range ballots {
+				ref := ballot.votes.spilled
+				for lvote := ballot.votes.tail; lvote != nil; lvote = lvote.prev {
+					ref = nil
+					if lvote.lid == cp.Layer {
+						if lvote.opinion == cp.Opinion {
+							found = &votes{tail: lvote}
+							return
+						}
+						break
+					}
+					ref = lvote.spilled
+				}
+				if ref != nil && ref.index > 0 && !ref.lid.Before(cp.Layer) {
+					addSpilledRef(refs, ref)
+				}
+			}
+		}); err != nil {
+			t.logger.With().Error("failed to load ballots for opinion checkpoint", lid, log.Err(err))
+			return nil
+		}
+	}
+	if found == nil && len(refs) > 0 {
+		// votes for the checkpoint layer may be stored together with the layer
+		var err error
+		if found, err = t.findSpilledOpinion(cp, refs); err != nil {
+			t.logger.With().Error("failed to search spilled votes for opinion checkpoint", cp.Layer, log.Err(err))
+			return nil
+		}
+	}
+	if found != nil {
+		base.votes = *found
+		return base
+	}
+	return nil
+}
+
+func addSpilledRef(refs map[types.LayerID]map[uint32]*spilledVote, ref *spilledVote) {
+	layer, exist := refs[ref.lid]
+	if !exist {
+		layer = map[uint32]*spilledVote{}
+		refs[ref.lid] = layer
+	}
+	layer[ref.index] = ref
+}
+
+// findSpilledOpinion follows referenced spilled votes down to the checkpoint layer,
+// and returns votes with the opinion from the checkpoint.
+func (t *turtle) findSpilledOpinion(
+	cp *types.OpinionCheckpoint,
+	refs map[types.LayerID]map[uint32]*spilledVote,
+) (*votes, error) {
+	var top types.LayerID
+	for lid := range refs {
+		top = maxLayer(top, lid)
+	}
+	if err := t.flushSpilled(); err != nil {
+		return nil, err
+	}
+	for lid := top; !lid.Before(cp.Layer) && len(refs) > 0; lid = lid.Sub(1) {
+		layer, exist := refs[lid]
+		if !exist {
+			continue
+		}
+		delete(refs, lid)
+		if lid == cp.Layer {
+			for _, ref := range layer {
+				if ref.opinion == cp.Opinion {
+					return &votes{spilled: ref}, nil
+				}
+			}
+			return nil, nil
+		}
+		spilled, err := t.readSpilledLayer(lid)
+		if err != nil {
+			return nil, err
+		}
+		for index := range layer {
+			if index > uint32(len(spilled.Votes)) {
+				return nil, fmt.Errorf("%w: unknown vote %d in layer %s", errCorruptCheckpoint, index, lid)
+			}
+			prev := decodeSpilledRef(spilled.Votes[index-1].SpilledPrev)
+			if prev != nil && prev.index > 0 && !prev.lid.Before(cp.Layer) {
+				addSpilledRef(refs, prev)
+			}
+		}
+	}
+	return nil, nil
+}
+
+// localVotes returns votes for layers in (evicted, lid] according to the local opinion.
+// Opinion hash for returned votes matches opinion of the ballot that votes consistently
+// with local opinion.
+//
+// Votes for the layers that are not in memory are stored together with the layers if persist is true.
+func (t *turtle) localVotes(lid, current types.LayerID, persist bool) (votes, error) {
+	var local votes
+	vlid := t.evicted.Add(1)
+	if top := minLayer(t.spilledBoundary(), lid); !top.Before(vlid) {
+		spilled, err := t.spillLocalVotes(vlid, top, current, persist)
+		if err != nil {
+			return votes{}, err
+		}
+		local.spilled = spilled
+		vlid = top.Add(1)
+	}
+	for ; !vlid.After(lid); vlid = vlid.Add(1) {
+		layer := t.layer(vlid)
+		lvote, err := t.localVote(layer, current)
+		if err != nil {
+			return votes{}, err
+		}
+		if local.tail == nil && local.spilled == nil && layer.prevOpinion != nil {
+			// opinion for evicted layers is accumulated in the opinion of the previous layer
+			local.tail = &layerVote{
+				layerInfo: &layerInfo{lid: vlid.Sub(1)},
+				opinion:   *layer.prevOpinion,
+			}
+		}
+		local.append(lvote)
+	}
+	local.cutBefore(t.evicted.Add(1))
+	return local, nil
+}
+
+func (t *turtle) localVote(layer *layerInfo, current types.LayerID) (*layerVote, error) {
+	lvote := &layerVote{layerInfo: layer, vote: against}
+	if !layer.hareTerminated {
+		lvote.vote = abstain
+		return lvote, nil
+	}
+	for _, block := range layer.blocks {
+		vote, _, err := t.getFullVote(t.verified, current, block)
+		if err != nil {
+			return nil, err
+		}
+		if vote == support {
+			lvote.supported = append(lvote.supported, block)
+		}
+	}
+	return lvote, nil
+}
+
+// spillLocalVotes computes local votes for the layers in [from, to] that are not in memory.
+// Returns reference to the last computed vote.
+func (t *turtle) spillLocalVotes(from, to, current types.LayerID, persist bool) (*spilledVote, error) {
+	var last *spilledVote
+	for lid := from; !lid.After(to); lid = lid.Add(1) {
+		layer := t.peekLayer(lid)
+		lvote, err := t.localVote(layer, current)
+		if err != nil {
+			return nil, err
+		}
+		if last != nil {
+			lvote.spilled = last
+		} else if layer.prevOpinion != nil {
+			// opinion for evicted layers is accumulated in the opinion of the previous layer,
+			// it is used in the opinion but the vote is not referenced
+			lvote.spilled = &spilledVote{lid: lid.Sub(1), opinion: *layer.prevOpinion}
+		}
+		lvote.computeOpinion()
+		vote := checkpoint.Vote{
+			Layer:       lid,
+			Vote:        encodeSign(lvote.vote),
+			Opinion:     lvote.opinion,
+			SpilledPrev: encodeSpilledRef(last),
+		}
+		for _, block := range lvote.supported {
+			vote.Supported = append(vote.Supported, block.id)
+		}
+		ref := &spilledVote{lid: lid, opinion: lvote.opinion}
+		if persist {
+			spilled, err := t.readSpilledLayer(lid)
+			if err != nil {
+				return nil, err
+			}
+			spilled.Votes = append(spilled.Votes, vote)
+			ref.index = uint32(len(spilled.Votes))
+			if err := t.writeSpilledLayer(spilled); err != nil {
+				return nil, err
+			}
+		}
+		last = ref
+	}
+	return last, nil
+}
@@ -0,0 +1,164 @@
+package tortoise
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/sql/ballots"
+	"github.com/spacemeshos/go-spacemesh/sql/blocks"
+	"github.com/spacemeshos/go-spacemesh/tortoise/sim"
+)
+
+func TestOpinionCheckpointAfterAbstain(t *testing.T) {
+	ctx := context.Background()
+	const size = 10
+	s := sim.New(sim.WithLayerSize(size), sim.WithStates(2))
+	s.Setup(sim.WithSetupMinerRange(size, size))
+
+	cfg := defaultTestConfig()
+	cfg.LayerSize = size
+	cfg.Hdist = 4
+	cfg.Zdist = 4
+
+	limited := cfg
+	limited.MaxExceptions = 5
+	var (
+		voter     = tortoiseFromSimState(s.GetState(0), WithLogger(logtest.New(t).Named("limited")), WithConfig(limited))
+		unlimited = tortoiseFromSimState(s.GetState(1), WithLogger(logtest.New(t).Named("unlimited")), WithConfig(cfg))
+		last      types.LayerID
+	)
+	tally := func(lid types.LayerID) {
+		voter.TallyVotes(ctx, lid)
+		unlimited.TallyVotes(ctx, lid)
+	}
+	for _, last = range sim.GenLayers(s,
+		sim.WithSequence(5),
+		sim.WithSequence(20, sim.WithVoteGenerator(abstainVoting)),
+	) {
+		tally(last)
+	}
+	verified := voter.LatestComplete()
+	require.True(t, verified.Before(last.Sub(10)))
+
+	votes, err := voter.EncodeVotes(ctx, EncodeVotesWithCurrent(last.Add(1)))
+	require.NoError(t, err)
+	require.NotNil(t, votes.Votes.Checkpoint)
+	require.Equal(t, types.EmptyBallotID, votes.Votes.Base)
+	require.True(t, votes.Votes.Checkpoint.Layer.After(verified))
+	require.LessOrEqual(t, len(votes.Votes.Support)+len(votes.Votes.Against), limited.MaxExceptions)
+
+	for i := 0; i < 20; i++ {
+		last = s.Next(sim.WithVoteGenerator(tortoiseVotingWithCurrent(voter)))
+		tally(last)
+	}
+	require.Equal(t, last.Sub(1), voter.LatestComplete())
+	require.Equal(t, last.Sub(1), unlimited.LatestComplete())
+	for lid := types.GetEffectiveGenesis().Add(1); lid.Before(last); lid = lid.Add(1) {
+		expected, err := blocks.ContextualValidity(s.GetState(1).DB, lid)
+		require.NoError(t, err)
+		validity, err := blocks.ContextualValidity(s.GetState(0).DB, lid)
+		require.NoError(t, err)
+		require.Equal(t, expected, validity, "layer %s", lid)
+	}
+}
+
+func TestOpinionCheckpointDecode(t *testing.T) {
+	ctx := context.Background()
+	const size = 10
+	s := sim.New(sim.WithLayerSize(size))
+	s.Setup()
+
+	cfg := defaultTestConfig()
+	cfg.LayerSize = size
+	tortoise := tortoiseFromSimState(s.GetState(0), WithLogger(logtest.New(t)), WithConfig(cfg))
+	var last types.LayerID
+	for _, last = range sim.GenLayers(s, sim.WithSequence(10)) {
+		tortoise.TallyVotes(ctx, last)
+	}
+	require.Equal(t, last.Sub(1), tortoise.LatestComplete())
+
+	blts, err := ballots.Layer(s.GetState(0).DB, last)
+	require.NoError(t, err)
+	require.NotEmpty(t, blts)
+	template := *blts[0]
+
+	// ballot that disagrees with local opinion by abstaining on the previous layer
+	prev, err := ballots.Layer(s.GetState(0).DB, last.Sub(1))
+	require.NoError(t, err)
+	disagreeing := template
+	disagreeing.Votes = types.Votes{Base: prev[0].ID(), Abstain: []types.LayerID{last.Sub(1)}}
+	disagreeing.SetID(types.RandomBallotID())
+	decoded, err := tortoise.trtl.decodeBallot(&disagreeing)
+	require.NoError(t, err)
+	require.NotNil(t, decoded)
+	require.NoError(t, tortoise.trtl.storeBallot(decoded))
+
+	current := last.Add(1)
+	base, opinion, err := tortoise.trtl.encodeOpinionCheckpoint(ctx, current)
+	require.NoError(t, err)
+	require.Equal(t, tortoise.LatestComplete(), opinion.Votes.Checkpoint.Layer)
+	require.Equal(t, opinion.Votes.Checkpoint.Layer.Add(1), base.layer)
+
+	for _, tc := range []struct {
+		desc     string
+		mutate   func(*types.OpinionCheckpoint)
+		decoded  bool
+		expected types.Hash32
+	}{
+		{
+			desc:     "local opinion",
+			mutate:   func(*types.OpinionCheckpoint) {},
+			decoded:  true,
+			expected: opinion.Hash,
+		},
+		{
+			desc: "opinion from ballot",
+			mutate: func(cp *types.OpinionCheckpoint) {
+				cp.Layer = last.Sub(1)
+				cp.Opinion = decoded.opinion()
+			},
+			decoded: true,
+		},
+		{
+			desc: "unknown opinion",
+			mutate: func(cp *types.OpinionCheckpoint) {
+				cp.Opinion = types.RandomHash()
+			},
+		},
+		{
+			desc: "evicted layer",
+			mutate: func(cp *types.OpinionCheckpoint) {
+				cp.Layer = tortoise.trtl.evicted
+			},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			ballot := template
+			ballot.LayerIndex = current
+			ballot.Votes = opinion.Votes
+			cp := *opinion.Votes.Checkpoint
+			tc.mutate(&cp)
+			ballot.Votes.Checkpoint = &cp
+			ballot.OpinionHash = opinion.Hash
+			ballot.SetID(types.RandomBallotID())
+
+			decoded, err := tortoise.trtl.decodeBallot(&ballot)
+			if !tc.decoded {
+				require.ErrorIs(t, err, errUnknownOpinionCheckpoint)
+				require.Nil(t, decoded)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, decoded)
+			require.Equal(t, types.EmptyBallotID, decoded.base.id)
+			require.Equal(t, cp.Layer.Add(1), decoded.base.layer)
+			if tc.expected != (types.Hash32{}) {
+				require.Equal(t, tc.expected, decoded.opinion())
+			}
+		})
+	}
+}
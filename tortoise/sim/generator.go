@@ -170,6 +170,11 @@ func (g *Generator) GetState(i int) State {
 	return g.states[i]
 }
 
+// States returns all states that are attached to the Generator.
+func (g *Generator) States() []State {
+	return g.states
+}
+
 func (g *Generator) addState(state State) {
 	g.states = append(g.states, state)
 }
@@ -26,6 +26,7 @@ type nextConf struct {
 	FailHare         bool
 	EmptyHare        bool
 	HareOutputIndex  int
+	BadBeacon        Fraction
 	Coinflip         bool
 	LayerSize        int
 	NumBlocks        int
@@ -103,6 +104,14 @@ func WithHareOutputIndex(i int) NextOpt {
 	}
 }
 
+// WithBadBeacon configures fraction of miners that will use a beacon that is different
+// from the beacon stored in the state.
+func WithBadBeacon(frac Fraction) NextOpt {
+	return func(c *nextConf) {
+		c.BadBeacon = frac
+	}
+}
+
 // Next generates the next layer.
 func (g *Generator) Next(opts ...NextOpt) types.LayerID {
 	cfg := nextConfDefaults()
@@ -161,6 +170,10 @@ func (g *Generator) genLayer(cfg nextConf) types.LayerID {
 		miner := i % len(g.activations)
 		miners[miner]++
 	}
+	badBeacons := 0
+	if cfg.BadBeacon.Denominator != 0 {
+		badBeacons = len(miners) * cfg.BadBeacon.Nominator / cfg.BadBeacon.Denominator
+	}
 	for miner, maxj := range miners {
 		voting := cfg.VoteGen(g.rng, g.layers, miner)
 		atxid := g.activations[miner]
@@ -173,6 +186,9 @@ func (g *Generator) genLayer(cfg nextConf) types.LayerID {
 		if err != nil {
 			g.logger.With().Panic("failed to get a beacon", log.Err(err))
 		}
+		if miner < badBeacons {
+			beacon[0] ^= 0xff
+		}
 		ballot := &types.Ballot{
 			InnerBallot: types.InnerBallot{
 				AtxID:             atxid,
@@ -84,12 +84,16 @@ func (g *Generator) Split(opts ...SplitOpt) []*Generator {
 		part := conf.Partitions[i]
 		share := total * part.Nominator / part.Denominator
 
+		// partition uses only the state that is popped from the original generator
+		conf := g.conf
+		conf.StateInstances = 0
 		gens[i] = New(
 			withRng(g.rng),
-			withConf(g.conf),
+			withConf(conf),
 			WithLogger(g.logger),
 		)
-		gens[i].addState(g.popState(i + 1))
+		// states are shifted after every pop, next state is always at index 1
+		gens[i].addState(g.popState(1))
 		gens[i].mergeLayers(g)
 		gens[i].Setup(
 			WithSetupMinerRange(share, share),
@@ -161,12 +165,13 @@ func (g *Generator) mergeLayers(other *Generator) {
 				}
 			}
 			if !exists {
+				// state may already store the ballot if it was shared with other generator,
+				// ballot is still added to the layer
 				rst, _ := ballots.Get(g.GetState(0).DB, ballot.ID())
-				if rst != nil {
-					continue
-				}
-				for _, state := range g.states {
-					state.OnBallot(ballot)
+				if rst == nil {
+					for _, state := range g.states {
+						state.OnBallot(ballot)
+					}
 				}
 				g.layers[i].AddBallot(ballot)
 			}
@@ -181,11 +186,10 @@ func (g *Generator) mergeLayers(other *Generator) {
 			}
 			if !exists {
 				rst, _ := blocks.Get(g.GetState(0).DB, block.ID())
-				if rst != nil {
-					continue
-				}
-				for _, state := range g.states {
-					state.OnBlock(block)
+				if rst == nil {
+					for _, state := range g.states {
+						state.OnBlock(block)
+					}
 				}
 				g.layers[i].AddBlock(block)
 			}
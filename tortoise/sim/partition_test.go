@@ -0,0 +1,72 @@
+package sim
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/sql/ballots"
+)
+
+func TestMain(m *testing.M) {
+	types.SetLayersPerEpoch(4)
+
+	res := m.Run()
+	os.Exit(res)
+}
+
+func layerBallots(layers []*types.Layer) [][]types.BallotID {
+	rst := make([][]types.BallotID, 0, len(layers))
+	for _, layer := range layers {
+		rst = append(rst, layer.BallotIDs())
+	}
+	return rst
+}
+
+func TestSplit(t *testing.T) {
+	gen := New(WithStates(3))
+	gen.Setup(WithSetupMinerRange(6, 6))
+	for i := 0; i < 3; i++ {
+		gen.Next()
+	}
+	before := layerBallots(gen.layers)
+	states := gen.States()
+	first, second, third := states[0], states[1], states[2]
+
+	gens := gen.Split(WithPartitions(Frac(1, 3), Frac(1, 3)))
+	require.Len(t, gens, 3)
+	for i, expected := range []State{first, second, third} {
+		require.Len(t, gens[i].States(), 1, "partition %d", i)
+		require.Equal(t, expected.DB, gens[i].GetState(0).DB, "partition %d", i)
+	}
+	for i, partition := range gens {
+		require.Equal(t, before, layerBallots(partition.layers[:len(before)]), "partition %d", i)
+	}
+}
+
+func TestSplitMerge(t *testing.T) {
+	gen := New(WithStates(2))
+	gen.Setup(WithSetupMinerRange(4, 4))
+	gen.Next()
+
+	gens := gen.Split()
+	require.Len(t, gens, 2)
+	var last types.LayerID
+	for _, partition := range gens {
+		last = partition.Next()
+	}
+	other := gens[1].layers[len(gens[1].layers)-1].BallotIDs()
+	require.NotEmpty(t, other)
+
+	gens[0].Merge(gens[1])
+	require.Len(t, gens[0].States(), 2)
+	merged := gens[0].layers[len(gens[0].layers)-1].BallotIDs()
+	require.Subset(t, merged, other)
+	for _, state := range gens[0].States() {
+		stored, err := ballots.IDsInLayer(state.DB, last)
+		require.NoError(t, err)
+		require.ElementsMatch(t, merged, stored)
+	}
+}
@@ -0,0 +1,373 @@
+package scenario
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/datastore"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/sql/ballots"
+	"github.com/spacemeshos/go-spacemesh/sql/blocks"
+	"github.com/spacemeshos/go-spacemesh/tortoise"
+	"github.com/spacemeshos/go-spacemesh/tortoise/sim"
+)
+
+// fractionPrecision is used to convert float shares into sim.Fraction.
+const fractionPrecision = 1000
+
+// Report is a summary of the scenario execution.
+type Report struct {
+	Name string `json:"name"`
+	Seed int64  `json:"seed"`
+	// Layers is a number of generated layers.
+	Layers int    `json:"layers"`
+	Last   uint32 `json:"last"`
+	// Converged is true if all instances verified the layer before last at the end of the run.
+	Converged bool              `json:"converged"`
+	Duration  float64           `json:"duration_seconds"`
+	Instances []*InstanceReport `json:"instances"`
+	Heals     []*HealReport     `json:"heals,omitempty"`
+}
+
+// InstanceReport is a progress of a single tortoise instance.
+type InstanceReport struct {
+	ID       int    `json:"id"`
+	Verified uint32 `json:"verified"`
+	// Reverts is a number of times when validity of a previously updated block was changed.
+	Reverts  int        `json:"reverts"`
+	Progress []Progress `json:"progress"`
+}
+
+// Progress is recorded after every layer processed by the instance.
+type Progress struct {
+	Layer    uint32 `json:"layer"`
+	Verified uint32 `json:"verified"`
+}
+
+// HealReport describes how fast instances converged after partition was healed.
+type HealReport struct {
+	// Layer is the last layer of the partition.
+	Layer     uint32 `json:"layer"`
+	Converged bool   `json:"converged"`
+	// ConvergedLayer is the first layer when all instances verified previous layer.
+	ConvergedLayer uint32  `json:"converged_layer,omitempty"`
+	Layers         int     `json:"layers"`
+	Duration       float64 `json:"duration_seconds"`
+
+	healed time.Time
+}
+
+func (h *HealReport) converge(lid types.LayerID) {
+	h.Converged = true
+	h.ConvergedLayer = lid.Uint32()
+	h.Duration = time.Since(h.healed).Seconds()
+}
+
+// Run executes scenario and returns a report.
+func Run(ctx context.Context, logger log.Log, sc *Scenario) (*Report, error) {
+	started := time.Now()
+	r := newRunner(logger, sc)
+	for i, step := range sc.Steps {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		logger.With().Info("executing step", log.Int("step", i), log.Int("layers", step.Layers))
+		if len(step.Partition) == 0 {
+			r.sequence(ctx, step)
+		} else {
+			r.partition(ctx, step)
+		}
+	}
+	report := &Report{
+		Name:      sc.Name,
+		Seed:      sc.Seed,
+		Layers:    r.layers,
+		Last:      r.last.Uint32(),
+		Converged: r.converged(),
+		Duration:  time.Since(started).Seconds(),
+		Heals:     r.heals,
+	}
+	for _, inst := range r.instances {
+		report.Instances = append(report.Instances, inst.report)
+	}
+	return report, nil
+}
+
+func newRunner(logger log.Log, sc *Scenario) *runner {
+	gen := sim.New(
+		sim.WithSeed(sc.Seed),
+		sim.WithLayerSize(sc.LayerSize),
+		sim.WithStates(sc.Instances),
+		sim.WithLogger(logger.Named("sim")),
+	)
+	gen.Setup(sim.WithSetupMinerRange(sc.Smeshers, sc.Smeshers))
+
+	cfg := tortoise.DefaultConfig()
+	cfg.LayerSize = sc.LayerSize
+	cfg.Hdist = sc.Tortoise.Hdist
+	cfg.Zdist = sc.Tortoise.Zdist
+	cfg.WindowSize = sc.Tortoise.WindowSize
+	cfg.BadBeaconVoteDelayLayers = sc.Tortoise.BadBeaconVoteDelayLayers
+	if sc.Tortoise.MaxResidentLayers > 0 {
+		cfg.MaxResidentLayers = sc.Tortoise.MaxResidentLayers
+	}
+	cfg.MaxExceptions = int(cfg.Hdist) * int(cfg.LayerSize) * 100
+	cfg.CheckpointInterval = 0
+
+	r := &runner{
+		logger: logger,
+		sc:     sc,
+		rng:    sc.rng(),
+		gen:    gen,
+	}
+	for i, state := range gen.States() {
+		updater := &updater{db: state.DB, validity: map[types.BlockID]bool{}}
+		inst := &instance{
+			state:   state,
+			updater: updater,
+			report:  &InstanceReport{ID: i},
+			trtl: tortoise.New(state.DB, state.Beacons, updater,
+				tortoise.WithConfig(cfg),
+				tortoise.WithLogger(logger.Named(fmt.Sprintf("tortoise-%d", i))),
+			),
+		}
+		r.instances = append(r.instances, inst)
+	}
+	return r
+}
+
+type runner struct {
+	logger log.Log
+	sc     *Scenario
+	rng    *rand.Rand
+
+	gen       *sim.Generator
+	instances []*instance
+
+	layers int
+	last   types.LayerID
+	heals  []*HealReport
+}
+
+func (r *runner) sequence(ctx context.Context, step Step) {
+	for i := 0; i < step.Layers; i++ {
+		opts := r.options(step, r.instances)
+		if r.hareFailed(step) {
+			opts = append(opts, sim.WithoutHareOutput())
+		}
+		lid := r.gen.Next(opts...)
+		for _, inst := range r.instances {
+			inst.tally(ctx, lid)
+		}
+		r.onLayer(lid)
+	}
+}
+
+func (r *runner) partition(ctx context.Context, step Step) {
+	parts := make([]sim.Fraction, 0, len(step.Partition))
+	for _, share := range step.Partition {
+		parts = append(parts, sim.Frac(int(share*fractionPrecision), fractionPrecision))
+	}
+	gens := r.gen.Split(sim.WithPartitions(parts...))
+	members := make([][]*instance, len(gens))
+	for i, gen := range gens {
+		for _, state := range gen.States() {
+			members[i] = append(members[i], r.instance(state.DB))
+		}
+	}
+
+	start := r.last
+	for i := 0; i < step.Layers; i++ {
+		hareFailed := r.hareFailed(step)
+		var lid types.LayerID
+		for j, gen := range gens {
+			opts := r.options(step, members[j])
+			if hareFailed {
+				opts = append(opts, sim.WithoutHareOutput())
+			}
+			lid = gen.Next(opts...)
+			for _, inst := range members[j] {
+				inst.tally(ctx, lid)
+			}
+		}
+		r.onLayer(lid)
+	}
+
+	for _, gen := range gens[1:] {
+		gens[0].Merge(gen)
+	}
+	r.gen = gens[0]
+	r.heal(ctx, start)
+}
+
+// heal delivers data that was generated in the partitions to every instance.
+func (r *runner) heal(ctx context.Context, start types.LayerID) {
+	db := r.gen.GetState(0).DB
+	for epoch := start.GetEpoch(); epoch <= r.last.GetEpoch(); epoch++ {
+		if err := db.IterateEpochATXHeaders(epoch, func(header *types.ActivationTxHeader) bool {
+			for _, inst := range r.instances {
+				inst.trtl.OnAtx(header)
+			}
+			return true
+		}); err != nil {
+			r.logger.With().Panic("failed to iterate atxs", epoch, log.Err(err))
+		}
+	}
+	for lid := start.Add(1); !lid.After(r.last); lid = lid.Add(1) {
+		merged, err := blocks.Layer(db, lid)
+		if err != nil {
+			r.logger.With().Panic("failed to load blocks", lid, log.Err(err))
+		}
+		for _, block := range merged {
+			for _, inst := range r.instances {
+				inst.trtl.OnBlock(block)
+			}
+		}
+		mergedBallots, err := ballots.Layer(db, lid)
+		if err != nil {
+			r.logger.With().Panic("failed to load ballots", lid, log.Err(err))
+		}
+		for _, ballot := range mergedBallots {
+			for _, inst := range r.instances {
+				inst.trtl.OnBallot(ballot)
+			}
+		}
+	}
+	for _, inst := range r.instances {
+		inst.tally(ctx, r.last)
+	}
+	heal := &HealReport{Layer: r.last.Uint32(), healed: time.Now()}
+	r.heals = append(r.heals, heal)
+	if r.converged() {
+		heal.converge(r.last)
+	}
+}
+
+func (r *runner) onLayer(lid types.LayerID) {
+	r.layers++
+	if lid.After(r.last) {
+		r.last = lid
+	}
+	converged := r.converged()
+	for _, heal := range r.heals {
+		if heal.Converged {
+			continue
+		}
+		heal.Layers++
+		if converged {
+			heal.converge(r.last)
+		}
+	}
+}
+
+func (r *runner) converged() bool {
+	for _, inst := range r.instances {
+		if inst.trtl.LatestComplete() != r.last.Sub(1) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *runner) instance(db *datastore.CachedDB) *instance {
+	for _, inst := range r.instances {
+		if inst.state.DB == db {
+			return inst
+		}
+	}
+	r.logger.Panic("state is not attached to any instance")
+	return nil
+}
+
+func (r *runner) hareFailed(step Step) bool {
+	return step.HareFailure > 0 && r.rng.Float64() < step.HareFailure
+}
+
+// options for the next layer. honest smeshers vote according to the opinion of the instances
+// that observe the same partition.
+func (r *runner) options(step Step, members []*instance) []sim.NextOpt {
+	honest := membersVoting(members)
+	opts := []sim.NextOpt{sim.WithVoteGenerator(honest)}
+	if step.LayerSize > 0 {
+		opts = append(opts, sim.WithLayerSizeOverwrite(step.LayerSize))
+	}
+	if step.Blocks > 0 {
+		opts = append(opts,
+			sim.WithNumBlocks(step.Blocks),
+			sim.WithBlockTickHeights(make([]uint64, step.Blocks)...),
+		)
+	}
+	if step.EmptyHare {
+		opts = append(opts, sim.WithEmptyHareOutput())
+	}
+	if step.BadBeacon > 0 {
+		opts = append(opts, sim.WithBadBeacon(sim.Frac(int(step.BadBeacon*fractionPrecision), fractionPrecision)))
+	}
+	if step.Byzantine > 0 {
+		byzantine := int(step.Byzantine * float64(r.sc.Smeshers))
+		opts = append(opts, sim.WithVoteGenerator(sim.VaryingVoting(byzantine, againstHareVoting, honest)))
+	}
+	return opts
+}
+
+func membersVoting(members []*instance) sim.VotesGenerator {
+	return func(_ *rand.Rand, layers []*types.Layer, i int) sim.Voting {
+		current := layers[len(layers)-1].Index().Add(1)
+		votes, err := members[i%len(members)].trtl.EncodeVotes(context.Background(), tortoise.EncodeVotesWithCurrent(current))
+		if err != nil {
+			panic(fmt.Sprintf("encode votes: %v", err))
+		}
+		return votes.Votes
+	}
+}
+
+// againstHareVoting supports block that is different from the hare output in the previous layer.
+func againstHareVoting(rng *rand.Rand, layers []*types.Layer, _ int) sim.Voting {
+	baseLayer := layers[len(layers)-1]
+	ballots := baseLayer.Ballots()
+	votes := sim.Voting{Base: ballots[rng.Intn(len(ballots))].ID()}
+	// sim uses first block as a hare output
+	if blocks := baseLayer.Blocks(); len(blocks) > 1 {
+		votes.Support = append(votes.Support, types.Vote{
+			ID:      blocks[1].ID(),
+			LayerID: blocks[1].LayerIndex,
+			Height:  blocks[1].TickHeight,
+		})
+	}
+	return votes
+}
+
+type instance struct {
+	state   sim.State
+	updater *updater
+	trtl    *tortoise.Tortoise
+	report  *InstanceReport
+}
+
+func (i *instance) tally(ctx context.Context, lid types.LayerID) {
+	i.trtl.TallyVotes(ctx, lid)
+	verified := i.trtl.LatestComplete()
+	i.report.Verified = verified.Uint32()
+	i.report.Reverts = i.updater.reverts
+	i.report.Progress = append(i.report.Progress, Progress{Layer: lid.Uint32(), Verified: verified.Uint32()})
+}
+
+type updater struct {
+	db       *datastore.CachedDB
+	validity map[types.BlockID]bool
+	reverts  int
+}
+
+func (u *updater) UpdateBlockValidity(bid types.BlockID, _ types.LayerID, valid bool) error {
+	if prev, exist := u.validity[bid]; exist && prev != valid {
+		u.reverts++
+	}
+	u.validity[bid] = valid
+	if valid {
+		return blocks.SetValid(u.db, bid)
+	}
+	return blocks.SetInvalid(u.db, bid)
+}
@@ -0,0 +1,154 @@
+// Package scenario runs tortoise instances against the layers generated by sim.Generator,
+// using declarative description of the network conditions.
+package scenario
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Scenario describes network and a sequence of steps that are executed one after another.
+// Scenario can be decoded from YAML or JSON.
+type Scenario struct {
+	Name string `yaml:"name"`
+	Seed int64  `yaml:"seed"`
+	// LayersPerEpoch is a global parameter, and should be applied by the caller before running scenario.
+	LayersPerEpoch uint32 `yaml:"layers_per_epoch"`
+	// Smeshers is a number of smeshers that publish ballots in every layer.
+	Smeshers int `yaml:"smeshers"`
+	// LayerSize is an expected number of ballots in the layer.
+	LayerSize uint32 `yaml:"layer_size"`
+	// Instances is a number of tortoise instances, each instance has its own state.
+	// Partition with N parts requires at least N instances.
+	Instances int      `yaml:"instances"`
+	Tortoise  Tortoise `yaml:"tortoise"`
+	Steps     []Step   `yaml:"steps"`
+}
+
+// Tortoise parameters. Zero values are replaced with defaults.
+type Tortoise struct {
+	Hdist                    uint32 `yaml:"hdist"`
+	Zdist                    uint32 `yaml:"zdist"`
+	WindowSize               uint32 `yaml:"window_size"`
+	BadBeaconVoteDelayLayers uint32 `yaml:"bad_beacon_vote_delay_layers"`
+	// MaxResidentLayers limits the number of layers in memory.
+	MaxResidentLayers uint32 `yaml:"max_resident_layers"`
+}
+
+// Step generates a number of layers with the same network conditions.
+type Step struct {
+	Layers int `yaml:"layers"`
+	// LayerSize overwrites layer size for the step.
+	LayerSize int `yaml:"layer_size"`
+	// Blocks is a number of blocks in every layer. Default is sim.DefaultNumBlocks.
+	Blocks int `yaml:"blocks"`
+	// HareFailure is a probability that hare will not output anything for the layer.
+	HareFailure float64 `yaml:"hare_failure"`
+	// EmptyHare if true hare will output empty layer.
+	EmptyHare bool `yaml:"empty_hare"`
+	// BadBeacon is a fraction of smeshers that use a beacon that is different from the local beacon.
+	BadBeacon float64 `yaml:"bad_beacon"`
+	// Byzantine is a fraction of smeshers that vote against hare output.
+	Byzantine float64 `yaml:"byzantine"`
+	// Partition splits the network for the duration of the step.
+	// Every value is a share of smeshers in a separate partition, the rest of the smeshers
+	// remain in the first partition. Partition heals at the end of the step.
+	Partition []float64 `yaml:"partition"`
+}
+
+// Decode scenario from YAML or JSON.
+func Decode(r io.Reader) (*Scenario, error) {
+	var sc Scenario
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err := dec.Decode(&sc); err != nil {
+		return nil, fmt.Errorf("decode scenario: %w", err)
+	}
+	sc.defaults()
+	if err := sc.validate(); err != nil {
+		return nil, err
+	}
+	return &sc, nil
+}
+
+func (sc *Scenario) defaults() {
+	if sc.LayersPerEpoch == 0 {
+		sc.LayersPerEpoch = 4
+	}
+	if sc.Smeshers == 0 {
+		sc.Smeshers = 30
+	}
+	if sc.LayerSize == 0 {
+		sc.LayerSize = uint32(sc.Smeshers)
+	}
+	partitions := 0
+	for _, step := range sc.Steps {
+		if len(step.Partition) > partitions {
+			partitions = len(step.Partition)
+		}
+	}
+	if sc.Instances < partitions+1 {
+		sc.Instances = partitions + 1
+	}
+	if sc.Tortoise.Hdist == 0 {
+		sc.Tortoise.Hdist = 10
+	}
+	if sc.Tortoise.Zdist == 0 {
+		sc.Tortoise.Zdist = 8
+	}
+	if sc.Tortoise.WindowSize == 0 {
+		sc.Tortoise.WindowSize = 1000
+	}
+	if sc.Tortoise.BadBeaconVoteDelayLayers == 0 {
+		sc.Tortoise.BadBeaconVoteDelayLayers = sc.LayersPerEpoch
+	}
+}
+
+func validFraction(value float64) bool {
+	return value >= 0 && value <= 1
+}
+
+func (sc *Scenario) validate() error {
+	if len(sc.Steps) == 0 {
+		return errors.New("scenario without steps")
+	}
+	if sc.Tortoise.Zdist > sc.Tortoise.Hdist {
+		return fmt.Errorf("zdist %d must not exceed hdist %d", sc.Tortoise.Zdist, sc.Tortoise.Hdist)
+	}
+	if sc.Tortoise.MaxResidentLayers > 0 && sc.Tortoise.MaxResidentLayers < sc.Tortoise.WindowSize {
+		return fmt.Errorf("max resident layers %d must not be less than window size %d",
+			sc.Tortoise.MaxResidentLayers, sc.Tortoise.WindowSize)
+	}
+	for i, step := range sc.Steps {
+		if step.Layers <= 0 {
+			return fmt.Errorf("step %d: number of layers must be positive", i)
+		}
+		if step.Blocks < 0 || step.LayerSize < 0 {
+			return fmt.Errorf("step %d: blocks and layer size must not be negative", i)
+		}
+		for _, value := range []float64{step.HareFailure, step.BadBeacon, step.Byzantine} {
+			if !validFraction(value) {
+				return fmt.Errorf("step %d: probabilities and fractions must be within [0, 1]", i)
+			}
+		}
+		var total float64
+		for _, share := range step.Partition {
+			if share <= 0 {
+				return fmt.Errorf("step %d: partition share must be positive", i)
+			}
+			total += share
+		}
+		if total >= 1 {
+			return fmt.Errorf("step %d: partitions must leave smeshers in the first partition", i)
+		}
+	}
+	return nil
+}
+
+func (sc *Scenario) rng() *rand.Rand {
+	return rand.New(rand.NewSource(sc.Seed))
+}
@@ -0,0 +1,128 @@
+package scenario
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+)
+
+func TestMain(m *testing.M) {
+	types.SetLayersPerEpoch(4)
+
+	res := m.Run()
+	os.Exit(res)
+}
+
+func decodeFile(tb testing.TB, name string) *Scenario {
+	tb.Helper()
+	f, err := os.Open(filepath.Join("testdata", name))
+	require.NoError(tb, err)
+	defer f.Close()
+	sc, err := Decode(f)
+	require.NoError(tb, err)
+	require.Equal(tb, types.GetLayersPerEpoch(), sc.LayersPerEpoch)
+	return sc
+}
+
+func TestDecode(t *testing.T) {
+	for _, tc := range []struct {
+		desc string
+		data string
+		err  string
+	}{
+		{
+			desc: "defaults",
+			data: "steps: [{layers: 1}]",
+		},
+		{
+			desc: "json",
+			data: `{"smeshers": 5, "steps": [{"layers": 1, "partition": [0.2, 0.3]}]}`,
+		},
+		{
+			desc: "no steps",
+			data: "smeshers: 10",
+			err:  "scenario without steps",
+		},
+		{
+			desc: "unknown field",
+			data: "steps: [{layers: 1, unknown: 1}]",
+			err:  "field unknown not found",
+		},
+		{
+			desc: "invalid fraction",
+			data: "steps: [{layers: 1, byzantine: 2}]",
+			err:  "within [0, 1]",
+		},
+		{
+			desc: "partition without majority",
+			data: "steps: [{layers: 1, partition: [0.5, 0.5]}]",
+			err:  "must leave smeshers",
+		},
+		{
+			desc: "zdist larger than hdist",
+			data: "tortoise: {hdist: 2, zdist: 4}\nsteps: [{layers: 1}]",
+			err:  "must not exceed hdist",
+		},
+	} {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			sc, err := Decode(strings.NewReader(tc.data))
+			if len(tc.err) > 0 {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.NotZero(t, sc.Smeshers)
+			require.Equal(t, uint32(sc.Smeshers), sc.LayerSize)
+			require.NotZero(t, sc.Tortoise.Hdist)
+			require.Greater(t, sc.Instances, len(sc.Steps[0].Partition))
+		})
+	}
+}
+
+func TestRunPartition(t *testing.T) {
+	sc := decodeFile(t, "partition.yaml")
+	require.Equal(t, 2, sc.Instances)
+
+	report, err := Run(context.Background(), logtest.New(t), sc)
+	require.NoError(t, err)
+	require.Equal(t, "partition", report.Name)
+	require.Equal(t, 32, report.Layers)
+	require.True(t, report.Converged)
+	require.Len(t, report.Instances, sc.Instances)
+	for _, inst := range report.Instances {
+		require.Equal(t, report.Last-1, inst.Verified)
+		require.NotEmpty(t, inst.Progress)
+	}
+	require.Len(t, report.Heals, 1)
+	heal := report.Heals[0]
+	require.True(t, heal.Converged)
+	require.GreaterOrEqual(t, heal.ConvergedLayer, heal.Layer)
+	require.Equal(t, int(heal.ConvergedLayer-heal.Layer), heal.Layers)
+}
+
+func TestRunFaults(t *testing.T) {
+	sc := decodeFile(t, "faults.json")
+
+	report, err := Run(context.Background(), logtest.New(t), sc)
+	require.NoError(t, err)
+	require.True(t, report.Converged)
+	require.Empty(t, report.Heals)
+	require.Len(t, report.Instances, 1)
+	inst := report.Instances[0]
+	require.Equal(t, report.Last-1, inst.Verified)
+	require.Len(t, inst.Progress, report.Layers)
+
+	// deterministic for the same seed
+	again, err := Run(context.Background(), logtest.New(t), decodeFile(t, "faults.json"))
+	require.NoError(t, err)
+	require.Equal(t, inst.Progress, again.Instances[0].Progress)
+	require.Equal(t, inst.Reverts, again.Instances[0].Reverts)
+}
@@ -0,0 +1,902 @@
+package tortoise
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/ballots"
+	"github.com/spacemeshos/go-spacemesh/sql/blocks"
+	"github.com/spacemeshos/go-spacemesh/sql/checkpoints"
+	"github.com/spacemeshos/go-spacemesh/tortoise/checkpoint"
+	"github.com/spacemeshos/go-spacemesh/tortoise/metrics"
+	"github.com/spacemeshos/go-spacemesh/tortoise/opinionhash"
+)
+
+// spillStore keeps layers that were moved out of memory in the database.
+//
+// Every spilled layer is stored together with blocks and votes for it. Votes
+// reference previous votes by the index in the previous spilled layer, so that
+// the chain of votes can be followed without loading ballots. Ballots are stored
+// separately, in batches for every layer.
+//
+// Weight counted by tortoise for the spilled layers is accumulated in memory and
+// applied to the stored layers in a single pass, before layers are read.
+type spillStore struct {
+	db     sql.Executor
+	logger log.Log
+
+	// votes counted for the spilled votes that are not yet applied to the stored layers.
+	// weight is grouped by the layer and the index of the vote, and by the reference
+	// height of the counted ballot.
+	votes map[types.LayerID]map[uint32]map[uint64]weight
+	// good weight that is not yet added to the stored layers. weight is added to every
+	// spilled layer starting from the key.
+	good map[types.LayerID]weight
+	// peeked is the last layer that was decoded without moving it back to memory.
+	peeked *layerInfo
+}
+
+func newSpillStore(db sql.Executor, logger log.Log) *spillStore {
+	return &spillStore{
+		db:     db,
+		logger: logger,
+		votes:  map[types.LayerID]map[uint32]map[uint64]weight{},
+		good:   map[types.LayerID]weight{},
+	}
+}
+
+// isSpilled returns true if the layer was moved out of memory.
+// Spilled layer may be loaded back to memory until the next spill pass.
+func (s *state) isSpilled(lid types.LayerID) bool {
+	return s.store != nil && lid.After(s.evicted) && !lid.After(s.spilled)
+}
+
+// spilledBoundary is the last layer that doesn't keep votes in memory.
+func (s *state) spilledBoundary() types.LayerID {
+	return maxLayer(s.spilled, s.evicted)
+}
+
+func (s *state) readSpilledLayer(lid types.LayerID) (*checkpoint.SpilledLayer, error) {
+	stored, err := checkpoints.GetSpilledLayer(s.store.db, lid)
+	if err != nil {
+		return nil, err
+	}
+	var layer checkpoint.SpilledLayer
+	if err := codec.Decode(stored.State, &layer); err != nil {
+		return nil, fmt.Errorf("%w: decode spilled layer %s: %s", errCorruptCheckpoint, lid, err)
+	}
+	return &layer, nil
+}
+
+func (s *state) writeSpilledLayer(layer *checkpoint.SpilledLayer) error {
+	buf, err := codec.Encode(layer)
+	if err != nil {
+		return fmt.Errorf("encode spilled layer %s: %w", layer.Layer.ID, err)
+	}
+	s.store.peeked = nil
+	return checkpoints.AddSpilledLayer(s.store.db, &checkpoints.SpilledLayer{
+		Layer:      layer.Layer.ID,
+		Checkpoint: s.checkpointed,
+		State:      buf,
+	})
+}
+
+// decodeSpilledLayer applies pending weight and decodes the stored layer.
+func (s *state) decodeSpilledLayer(lid types.LayerID) (*layerInfo, error) {
+	if err := s.flushSpilled(); err != nil {
+		return nil, err
+	}
+	spilled, err := s.readSpilledLayer(lid)
+	if err != nil {
+		return nil, err
+	}
+	return decodeCheckpointLayer(&spilled.Layer)
+}
+
+// loadLayer moves spilled layer back to memory. It will be moved out
+// on the next spill pass.
+func (s *state) loadLayer(lid types.LayerID) *layerInfo {
+	layer, err := s.decodeSpilledLayer(lid)
+	if err != nil {
+		s.store.logger.With().Fatal("failed to load spilled layer", lid, log.Err(err))
+	}
+	for _, block := range layer.blocks {
+		s.blockRefs[block.id] = block
+	}
+	if prev, exist := s.layers[lid.Sub(1)]; exist && layer.prevOpinion != nil {
+		layer.prevOpinion = &prev.opinion
+	}
+	if next, exist := s.layers[lid.Add(1)]; exist && next.prevOpinion != nil {
+		next.prevOpinion = &layer.opinion
+	}
+	s.layers[lid] = layer
+	metrics.SpillReloads.Inc()
+	return layer
+}
+
+// peekLayer returns the layer without moving it back to memory if it was spilled.
+// Layer that wasn't moved back to memory must not be modified.
+func (s *state) peekLayer(lid types.LayerID) *layerInfo {
+	if layer, exist := s.layers[lid]; exist {
+		return layer
+	}
+	if !s.isSpilled(lid) {
+		return s.layer(lid)
+	}
+	if peeked := s.store.peeked; peeked != nil && peeked.lid == lid {
+		return peeked
+	}
+	layer, err := s.decodeSpilledLayer(lid)
+	if err != nil {
+		s.store.logger.With().Fatal("failed to read spilled layer", lid, log.Err(err))
+	}
+	s.store.peeked = layer
+	return layer
+}
+
+// updateLayer calls fn with the layer. If the layer was spilled it is updated
+// in the database without moving it back to memory.
+func (s *state) updateLayer(lid types.LayerID, fn func(*layerInfo)) {
+	if _, exist := s.layers[lid]; exist || !s.isSpilled(lid) {
+		fn(s.layer(lid))
+		return
+	}
+	if err := s.flushSpilled(); err != nil {
+		s.store.logger.With().Fatal("failed to update spilled layer", lid, log.Err(err))
+	}
+	if err := s.updateSpilledLayer(lid, fn); err != nil {
+		s.store.logger.With().Fatal("failed to update spilled layer", lid, log.Err(err))
+	}
+}
+
+func (s *state) updateSpilledLayer(lid types.LayerID, fn func(*layerInfo)) error {
+	spilled, err := s.readSpilledLayer(lid)
+	if err != nil {
+		return err
+	}
+	layer, err := decodeCheckpointLayer(&spilled.Layer)
+	if err != nil {
+		return err
+	}
+	fn(layer)
+	if spilled.Layer, err = encodeCheckpointLayer(layer); err != nil {
+		return err
+	}
+	return s.writeSpilledLayer(spilled)
+}
+
+// countSpilled records weight of the ballot that votes with the spilled vote.
+func (s *state) countSpilled(ref *spilledVote, height uint64, w weight) {
+	if s.store == nil || ref == nil || ref.index == 0 || !ref.lid.After(s.evicted) {
+		return
+	}
+	addPendingVotes(s.store.votes, ref.lid, ref.index, map[uint64]weight{height: w})
+}
+
+// countSpilledGood records weight of the good ballot from the spilled layer.
+func (s *state) countSpilledGood(lid types.LayerID, w weight) {
+	s.store.good[lid] = s.store.good[lid].Add(w)
+}
+
+func addPendingVotes(
+	pending map[types.LayerID]map[uint32]map[uint64]weight,
+	lid types.LayerID,
+	index uint32,
+	heights map[uint64]weight,
+) {
+	layer, exist := pending[lid]
+	if !exist {
+		layer = map[uint32]map[uint64]weight{}
+		pending[lid] = layer
+	}
+	counted, exist := layer[index]
+	if !exist {
+		counted = map[uint64]weight{}
+		layer[index] = counted
+	}
+	for height, w := range heights {
+		counted[height] = counted[height].Add(w)
+	}
+}
+
+// flushSpilled applies weight that was counted for the spilled layers.
+func (s *state) flushSpilled() error {
+	if s.store == nil {
+		return nil
+	}
+	if err := s.flushGood(); err != nil {
+		return err
+	}
+	return s.flushVotes()
+}
+
+func (s *state) flushGood() error {
+	if len(s.store.good) == 0 {
+		return nil
+	}
+	good := s.store.good
+	s.store.good = map[types.LayerID]weight{}
+	from := s.spilled
+	for lid := range good {
+		if lid.Before(from) {
+			from = lid
+		}
+	}
+	var total weight
+	for lid := from; !lid.After(s.spilled); lid = lid.Add(1) {
+		if w, exist := good[lid]; exist {
+			total = total.Add(w)
+		}
+		if !lid.After(s.evicted) {
+			continue
+		}
+		if layer, exist := s.layers[lid]; exist {
+			layer.verifying.goodUncounted = layer.verifying.goodUncounted.Add(total)
+		} else if err := s.updateSpilledLayer(lid, func(layer *layerInfo) {
+			layer.verifying.goodUncounted = layer.verifying.goodUncounted.Add(total)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flushVotes counts pending votes starting from the highest layer, weight of the vote
+// is carried to the previous vote in the chain.
+func (s *state) flushVotes() error {
+	if len(s.store.votes) == 0 {
+		return nil
+	}
+	pending := s.store.votes
+	s.store.votes = map[types.LayerID]map[uint32]map[uint64]weight{}
+	var top types.LayerID
+	for lid := range pending {
+		top = maxLayer(top, lid)
+	}
+	for lid := top; lid.After(s.evicted) && len(pending) > 0; lid = lid.Sub(1) {
+		counted, exist := pending[lid]
+		if !exist {
+			continue
+		}
+		delete(pending, lid)
+		spilled, err := s.readSpilledLayer(lid)
+		if err != nil {
+			return err
+		}
+		layer, resident := s.layers[lid]
+		if !resident {
+			if layer, err = decodeCheckpointLayer(&spilled.Layer); err != nil {
+				return err
+			}
+		}
+		for index, heights := range counted {
+			if index > uint32(len(spilled.Votes)) {
+				return fmt.Errorf("%w: unknown vote %d in layer %s", errCorruptCheckpoint, index, lid)
+			}
+			vote := &spilled.Votes[index-1]
+			if err := countSpilledVote(layer, vote, heights); err != nil {
+				return err
+			}
+			if prev := vote.SpilledPrev; prev.Index > 0 {
+				addPendingVotes(pending, prev.Layer, prev.Index, heights)
+			}
+		}
+		if !resident {
+			if spilled.Layer, err = encodeCheckpointLayer(layer); err != nil {
+				return err
+			}
+			if err := s.writeSpilledLayer(spilled); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// countSpilledVote counts weight of the vote in the same way as it is counted by full tortoise.
+func countSpilledVote(layer *layerInfo, vote *checkpoint.Vote, heights map[uint64]weight) error {
+	decoded, err := decodeSign(vote.Vote)
+	if err != nil {
+		return err
+	}
+	if decoded == abstain {
+		return nil
+	}
+	for height, w := range heights {
+		empty := true
+		for _, block := range layer.blocks {
+			if block.height > height {
+				continue
+			}
+			if supportsBlock(vote.Supported, block.id) {
+				empty = false
+				block.margin = block.margin.Add(w)
+			} else {
+				block.margin = block.margin.Sub(w)
+			}
+		}
+		if empty {
+			layer.empty = layer.empty.Add(w)
+		}
+	}
+	return nil
+}
+
+func supportsBlock(supported []types.BlockID, id types.BlockID) bool {
+	for _, bid := range supported {
+		if bid == id {
+			return true
+		}
+	}
+	return false
+}
+
+// prunePending drops pending weight for the evicted layers.
+func (s *state) prunePending(windowStart types.LayerID) {
+	if s.store == nil {
+		return
+	}
+	for lid := range s.store.votes {
+		if lid.Before(windowStart) {
+			delete(s.store.votes, lid)
+		}
+	}
+	for lid, w := range s.store.good {
+		if !lid.Before(windowStart) {
+			continue
+		}
+		delete(s.store.good, lid)
+		if !windowStart.After(s.spilled) {
+			s.store.good[windowStart] = s.store.good[windowStart].Add(w)
+		}
+	}
+}
+
+func decodeSpilledRef(ref checkpoint.SpilledRef) *spilledVote {
+	if ref == (checkpoint.SpilledRef{}) {
+		return nil
+	}
+	return &spilledVote{lid: ref.Layer, index: ref.Index, opinion: ref.Opinion}
+}
+
+func encodeSpilledRef(ref *spilledVote) checkpoint.SpilledRef {
+	if ref == nil {
+		return checkpoint.SpilledRef{}
+	}
+	return checkpoint.SpilledRef{Layer: ref.lid, Index: ref.index, Opinion: ref.opinion}
+}
+
+// residentLayers is the number of processed layers that are kept in memory.
+// Layers within hdist are always resident, as they are used to select base ballot
+// and to recount votes when hare output changes.
+func (t *turtle) residentLayers() uint32 {
+	if t.MaxResidentLayers < t.Hdist+1 {
+		return t.Hdist + 1
+	}
+	return t.MaxResidentLayers
+}
+
+// spill moves layers that are outside of the resident window to the database,
+// together with blocks, ballots and votes for them.
+//
+// Eviction bounds memory only when layers are verified, spill bounds memory when
+// tortoise keeps receiving layers that it can't verify (for example during long partition).
+func (t *turtle) spill() {
+	defer t.updateResidentMetrics()
+	if t.MaxResidentLayers == 0 {
+		return
+	}
+	if err := t.flushSpilled(); err != nil {
+		t.logger.With().Error("failed to count votes for spilled layers", log.Err(err))
+		return
+	}
+	if err := t.unloadLayers(); err != nil {
+		t.logger.With().Error("failed to unload spilled layers", log.Err(err))
+		return
+	}
+	if !t.processed.After(types.NewLayerID(t.residentLayers())) {
+		return
+	}
+	target := t.processed.Sub(t.residentLayers())
+	// ballots that are delayed by full tortoise are referenced by pointers
+	// and remain in memory together with their layers until they are counted
+	for _, ballots := range t.full.delayed {
+		for _, ballot := range ballots {
+			if !ballot.layer.After(target) {
+				target = ballot.layer.Sub(1)
+			}
+		}
+	}
+	from := t.spilledBoundary().Add(1)
+	if target.Before(from) {
+		return
+	}
+	if err := t.spillLayers(from, target); err != nil {
+		t.logger.With().Error("failed to spill layers", log.Err(err))
+		return
+	}
+	t.spilled = target
+	t.logger.With().Debug("spilled layers",
+		log.Stringer("spilled", t.spilled),
+		log.Stringer("processed", t.processed),
+		log.Int("resident_layers", len(t.layers)),
+		log.Int("resident_ballots", len(t.ballotRefs)),
+	)
+}
+
+// unloadLayers moves spilled layers that were loaded back to memory to the database.
+func (t *turtle) unloadLayers() error {
+	var lids []types.LayerID
+	for lid := range t.layers {
+		if t.isSpilled(lid) {
+			lids = append(lids, lid)
+		}
+	}
+	sort.Slice(lids, func(i, j int) bool { return lids[i].Before(lids[j]) })
+	for _, lid := range lids {
+		spilled, err := t.readSpilledLayer(lid)
+		if err != nil {
+			return err
+		}
+		if spilled.Layer, err = encodeCheckpointLayer(t.layers[lid]); err != nil {
+			return err
+		}
+		if err := t.writeSpilledLayer(spilled); err != nil {
+			return err
+		}
+		t.dropLayer(lid)
+	}
+	return nil
+}
+
+// dropLayer removes the layer together with blocks and ballots from memory.
+func (t *turtle) dropLayer(lid types.LayerID) {
+	layer := t.layers[lid]
+	for _, block := range layer.blocks {
+		delete(t.blockRefs, block.id)
+	}
+	for _, ballot := range layer.ballots {
+		delete(t.ballotRefs, ballot.id)
+	}
+	if next, exist := t.layers[lid.Add(1)]; exist && next.prevOpinion != nil {
+		opinion := layer.opinion
+		next.prevOpinion = &opinion
+	}
+	delete(t.layers, lid)
+}
+
+// spillLayers writes layers in [from, to] to the database and removes them from memory.
+// Votes that remain in memory are updated to reference spilled votes.
+func (t *turtle) spillLayers(from, to types.LayerID) error {
+	lids := make([]types.LayerID, 0, len(t.layers))
+	for lid := range t.layers {
+		if !lid.Before(from) {
+			lids = append(lids, lid)
+		}
+	}
+	sort.Slice(lids, func(i, j int) bool { return lids[i].Before(lids[j]) })
+
+	// votes are shared between ballots, every vote is spilled only once
+	var (
+		seen    = map[*layerVote]struct{}{}
+		byLayer = map[types.LayerID][]*layerVote{}
+		linked  []*layerVote
+		tails   []*ballotInfo
+	)
+	for _, lid := range lids {
+		for _, ballot := range t.layers[lid].ballots {
+			if lid.After(to) && ballot.votes.tail != nil && !ballot.votes.tail.lid.After(to) {
+				tails = append(tails, ballot)
+			}
+			for lvote := ballot.votes.tail; lvote != nil && !lvote.lid.Before(from); lvote = lvote.prev {
+				if _, exist := seen[lvote]; exist {
+					break
+				}
+				seen[lvote] = struct{}{}
+				if !lvote.lid.After(to) {
+					byLayer[lvote.lid] = append(byLayer[lvote.lid], lvote)
+				} else if lvote.prev != nil && !lvote.prev.lid.After(to) {
+					linked = append(linked, lvote)
+				}
+			}
+		}
+	}
+
+	refs := map[*layerVote]*spilledVote{}
+	refOf := func(lvote *layerVote) *spilledVote {
+		if ref, exist := refs[lvote]; exist {
+			return ref
+		}
+		return &spilledVote{lid: lvote.lid, opinion: lvote.opinion}
+	}
+	var (
+		layers  = make([]*checkpoint.SpilledLayer, 0, to.Difference(from)+1)
+		batches = map[types.LayerID]*checkpoint.SpilledBallots{}
+	)
+	for lid := from; !lid.After(to); lid = lid.Add(1) {
+		record := &checkpoint.SpilledLayer{}
+		for _, lvote := range byLayer[lid] {
+			vote := checkpoint.Vote{
+				Layer:   lid,
+				Vote:    encodeSign(lvote.vote),
+				Opinion: lvote.opinion,
+			}
+			if lvote.prev != nil {
+				vote.SpilledPrev = encodeSpilledRef(refOf(lvote.prev))
+			} else {
+				vote.SpilledPrev = encodeSpilledRef(lvote.spilled)
+			}
+			for _, block := range lvote.supported {
+				vote.Supported = append(vote.Supported, block.id)
+			}
+			record.Votes = append(record.Votes, vote)
+			refs[lvote] = &spilledVote{lid: lid, index: uint32(len(record.Votes)), opinion: lvote.opinion}
+		}
+		layer := t.layer(lid)
+		var err error
+		if record.Layer, err = encodeCheckpointLayer(layer); err != nil {
+			return err
+		}
+		layers = append(layers, record)
+		if len(layer.ballots) == 0 {
+			continue
+		}
+		batch := &checkpoint.SpilledBallots{}
+		for _, binfo := range layer.ballots {
+			ballot, err := encodeCheckpointBallot(binfo, 0)
+			if err != nil {
+				return err
+			}
+			if binfo.votes.tail != nil {
+				ballot.SpilledVotes = encodeSpilledRef(refOf(binfo.votes.tail))
+			}
+			batch.Ballots = append(batch.Ballots, ballot)
+		}
+		batches[lid] = batch
+	}
+
+	for _, record := range layers {
+		if err := t.writeSpilledLayer(record); err != nil {
+			return err
+		}
+		if batch, exist := batches[record.Layer.ID]; exist {
+			if err := t.writeSpilledBallots(record.Layer.ID, batch); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, lvote := range linked {
+		lvote.spilled = refOf(lvote.prev)
+		lvote.prev = nil
+	}
+	for _, ballot := range tails {
+		ballot.votes = votes{spilled: refOf(ballot.votes.tail)}
+	}
+	for lid := from; !lid.After(to); lid = lid.Add(1) {
+		t.dropLayer(lid)
+	}
+	return nil
+}
+
+func (t *turtle) writeSpilledBallots(lid types.LayerID, batch *checkpoint.SpilledBallots) error {
+	buf, err := codec.Encode(batch)
+	if err != nil {
+		return fmt.Errorf("encode spilled ballots: %w", err)
+	}
+	if err := checkpoints.AddSpilled(t.cdb, &checkpoints.Spilled{
+		Layer:      lid,
+		Checkpoint: t.checkpointed,
+		Ballots:    buf,
+	}); err != nil {
+		return err
+	}
+	metrics.SpilledBallots.Add(float64(len(batch.Ballots)))
+	return nil
+}
+
+// spillBallot stores ballot from the spilled layer without adding it to memory.
+func (t *turtle) spillBallot(binfo *ballotInfo) error {
+	ballot, err := encodeCheckpointBallot(binfo, 0)
+	if err != nil {
+		return err
+	}
+	return t.writeSpilledBallots(binfo.layer, &checkpoint.SpilledBallots{Ballots: []checkpoint.Ballot{ballot}})
+}
+
+// loadSpilledBallots decodes ballots that were spilled from the layer.
+func (t *turtle) loadSpilledBallots(lid types.LayerID) ([]*ballotInfo, error) {
+	var (
+		rst    []*ballotInfo
+		seen   = map[types.BallotID]struct{}{}
+		reterr error
+	)
+	if err := checkpoints.IterateSpilled(t.cdb, lid, func(spilled *checkpoints.Spilled) bool {
+		var batch checkpoint.SpilledBallots
+		if err := codec.Decode(spilled.Ballots, &batch); err != nil {
+			reterr = fmt.Errorf("%w: decode spilled ballots: %s", errCorruptCheckpoint, err)
+			return false
+		}
+		for i := range batch.Ballots {
+			id := batch.Ballots[i].ID
+			if _, exist := seen[id]; exist {
+				continue
+			}
+			binfo, err := decodeCheckpointBallot(&batch.Ballots[i], nil)
+			if err != nil {
+				reterr = err
+				return false
+			}
+			seen[id] = struct{}{}
+			rst = append(rst, binfo)
+		}
+		return true
+	}); err != nil {
+		return nil, err
+	}
+	if reterr != nil {
+		return nil, fmt.Errorf("load spilled ballots %s: %w", lid, reterr)
+	}
+	metrics.SpillReloads.Inc()
+	return rst, nil
+}
+
+// withBallots calls fn with all ballots from the layer. If the layer was spilled, ballots
+// are loaded from the database only for the duration of the call.
+func (t *turtle) withBallots(lid types.LayerID, fn func([]*ballotInfo)) error {
+	if !t.isSpilled(lid) {
+		fn(t.layer(lid).ballots)
+		return nil
+	}
+	spilled, err := t.loadSpilledBallots(lid)
+	if err != nil {
+		return err
+	}
+	fn(spilled)
+	return nil
+}
+
+// spilledBallot returns the ballot if it was spilled together with the layer.
+// Returns nil if ballot is not known.
+func (t *turtle) spilledBallot(lid types.LayerID, id types.BallotID) (*ballotInfo, error) {
+	if !t.isSpilled(lid) {
+		return nil, nil
+	}
+	var rst *ballotInfo
+	if err := t.withBallots(lid, func(ballots []*ballotInfo) {
+		for _, ballot := range ballots {
+			if ballot.id == id {
+				rst = ballot
+				return
+			}
+		}
+	}); err != nil {
+		return nil, err
+	}
+	return rst, nil
+}
+
+// findSpilledBallot is the same as spilledBallot, but ballot layer is read from the database.
+func (t *turtle) findSpilledBallot(id types.BallotID) (*ballotInfo, error) {
+	if !t.spilled.After(t.evicted) {
+		return nil, nil
+	}
+	lid, err := ballots.LayerOf(t.cdb, id)
+	if errors.Is(err, sql.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return t.spilledBallot(lid, id)
+}
+
+// blockLayer returns the layer of the known block.
+func (t *turtle) blockLayer(id types.BlockID) (types.LayerID, bool, error) {
+	if block, exist := t.blockRefs[id]; exist {
+		return block.layer, true, nil
+	}
+	if !t.spilled.After(t.evicted) {
+		return types.LayerID{}, false, nil
+	}
+	lid, err := blocks.GetLayer(t.cdb, id)
+	if errors.Is(err, sql.ErrNotFound) {
+		return types.LayerID{}, false, nil
+	}
+	if err != nil {
+		return types.LayerID{}, false, err
+	}
+	if !t.isSpilled(lid) {
+		return types.LayerID{}, false, nil
+	}
+	for _, block := range t.peekLayer(lid).blocks {
+		if block.id == id {
+			return lid, true, nil
+		}
+	}
+	return types.LayerID{}, false, nil
+}
+
+// walkSpilled calls fn for every spilled vote in the chain, starting from the referenced one.
+func (t *turtle) walkSpilled(
+	ref *spilledVote,
+	fn func(*spilledVote, *checkpoint.SpilledLayer, *checkpoint.Vote) (bool, error),
+) error {
+	if err := t.flushSpilled(); err != nil {
+		return err
+	}
+	for ref != nil && ref.index > 0 && ref.lid.After(t.evicted) {
+		spilled, err := t.readSpilledLayer(ref.lid)
+		if err != nil {
+			return err
+		}
+		if ref.index > uint32(len(spilled.Votes)) {
+			return fmt.Errorf("%w: unknown vote %d in layer %s", errCorruptCheckpoint, ref.index, ref.lid)
+		}
+		vote := &spilled.Votes[ref.index-1]
+		if cont, err := fn(ref, spilled, vote); err != nil || !cont {
+			return err
+		}
+		ref = decodeSpilledRef(vote.SpilledPrev)
+	}
+	return nil
+}
+
+// walkVotes calls fn for every vote starting from the last one, including votes
+// that were moved out of memory. Spilled votes are decoded only for the duration of the call.
+func (t *turtle) walkVotes(v votes, fn func(*layerVote) bool) error {
+	ref := v.spilled
+	for lvote := v.tail; lvote != nil; lvote = lvote.prev {
+		if !fn(lvote) {
+			return nil
+		}
+		ref = lvote.spilled
+	}
+	return t.walkSpilled(ref, func(_ *spilledVote, spilled *checkpoint.SpilledLayer, vote *checkpoint.Vote) (bool, error) {
+		layer, exist := t.layers[spilled.Layer.ID]
+		if !exist {
+			var err error
+			if layer, err = decodeCheckpointLayer(&spilled.Layer); err != nil {
+				return false, err
+			}
+		}
+		lvote := &layerVote{
+			layerInfo: layer,
+			opinion:   vote.Opinion,
+			spilled:   decodeSpilledRef(vote.SpilledPrev),
+		}
+		var err error
+		if lvote.vote, err = decodeSign(vote.Vote); err != nil {
+			return false, err
+		}
+		for _, block := range layer.blocks {
+			if supportsBlock(vote.Supported, block.id) {
+				lvote.supported = append(lvote.supported, block)
+			}
+		}
+		return fn(lvote), nil
+	})
+}
+
+// spillVotes computes votes of the ballot for the spilled layers in [from, to] by applying diff
+// to the votes of the base ballot, in the same way as votes.update does for votes in memory.
+// Votes are appended to the stored layers if persist is true.
+//
+// Returns reference to the last computed vote.
+func (t *turtle) spillVotes(
+	base *ballotInfo,
+	from, to types.LayerID,
+	diff map[types.LayerID]map[types.BlockID]sign,
+	persist bool,
+) (*spilledVote, error) {
+	var (
+		based = map[types.LayerID]checkpoint.Vote{}
+		prev  *spilledVote
+	)
+	if base.layer.After(from) {
+		if err := t.walkSpilled(base.votes.bottom(), func(
+			ref *spilledVote, _ *checkpoint.SpilledLayer, vote *checkpoint.Vote,
+		) (bool, error) {
+			if !ref.lid.After(to) {
+				based[ref.lid] = *vote
+			}
+			if ref.lid == from {
+				prev = decodeSpilledRef(vote.SpilledPrev)
+				return false, nil
+			}
+			return true, nil
+		}); err != nil {
+			return nil, err
+		}
+	} else if base.layer == from {
+		prev = base.votes.bottom()
+	}
+
+	last := prev
+	for lid := from; !lid.After(to); lid = lid.Add(1) {
+		spilled, err := t.readSpilledLayer(lid)
+		if err != nil {
+			return nil, err
+		}
+		vote := checkpoint.Vote{Layer: lid, Vote: encodeSign(against)}
+		if lid.Before(base.layer) {
+			old, exist := based[lid]
+			if !exist {
+				continue
+			}
+			vote.Vote = old.Vote
+			vote.Supported = old.Supported
+		}
+		layerdiff, exist := diff[lid]
+		if exist && len(layerdiff) == 0 {
+			vote.Vote = encodeSign(abstain)
+			vote.Supported = nil
+		} else if exist {
+			var supported []types.BlockID
+			for _, block := range spilled.Layer.Blocks {
+				decoded, exist := layerdiff[block.ID]
+				if exist && decoded == against {
+					continue
+				}
+				if (exist && decoded == support) || supportsBlock(vote.Supported, block.ID) {
+					supported = append(supported, block.ID)
+				}
+			}
+			vote.Supported = supported
+		}
+
+		hasher := opinionhash.New()
+		if last != nil {
+			hasher.WritePrevious(last.opinion)
+		}
+		if len(vote.Supported) > 0 {
+			for _, id := range vote.Supported {
+				height, exist := spilledBlockHeight(spilled, id)
+				if !exist {
+					return nil, fmt.Errorf("%w: vote for unknown block %s", errCorruptCheckpoint, id)
+				}
+				hasher.WriteSupport(id, height)
+			}
+		} else if vote.Vote == encodeSign(abstain) {
+			hasher.WriteAbstain()
+		}
+		hasher.Sum(vote.Opinion[:0])
+		vote.SpilledPrev = encodeSpilledRef(last)
+
+		ref := &spilledVote{lid: lid, opinion: vote.Opinion}
+		if persist {
+			spilled.Votes = append(spilled.Votes, vote)
+			ref.index = uint32(len(spilled.Votes))
+			if err := t.writeSpilledLayer(spilled); err != nil {
+				return nil, err
+			}
+		}
+		last = ref
+	}
+	return last, nil
+}
+
+func spilledBlockHeight(spilled *checkpoint.SpilledLayer, id types.BlockID) (uint64, bool) {
+	for _, block := range spilled.Layer.Blocks {
+		if block.ID == id {
+			return block.Height, true
+		}
+	}
+	return 0, false
+}
+
+func (t *turtle) updateResidentMetrics() {
+	var spilled uint32
+	if t.spilled.After(t.evicted) {
+		spilled = t.spilled.Difference(t.evicted)
+	}
+	metrics.ResidentLayers.Set(float64(len(t.layers)))
+	metrics.ResidentBallots.Set(float64(len(t.ballotRefs)))
+	metrics.ResidentBlocks.Set(float64(len(t.blockRefs)))
+	metrics.SpilledLayers.Set(float64(spilled))
+}
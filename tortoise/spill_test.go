@@ -0,0 +1,237 @@
+package tortoise
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/common/util"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/sql/ballots"
+	"github.com/spacemeshos/go-spacemesh/sql/blocks"
+	"github.com/spacemeshos/go-spacemesh/sql/checkpoints"
+	"github.com/spacemeshos/go-spacemesh/tortoise/sim"
+)
+
+func TestSpillUnverifiedLayers(t *testing.T) {
+	ctx := context.Background()
+	const (
+		size     = 10
+		resident = 8
+	)
+	s := sim.New(sim.WithLayerSize(size), sim.WithStates(2))
+	s.Setup(sim.WithSetupMinerRange(size, size))
+
+	cfg := defaultTestConfig()
+	cfg.LayerSize = size
+	cfg.Hdist = 4
+	cfg.Zdist = 4
+	cfg.WindowSize = resident
+
+	spilling := cfg
+	spilling.MaxResidentLayers = resident
+	var (
+		bounded   = tortoiseFromSimState(s.GetState(0), WithLogger(logtest.New(t).Named("bounded")), WithConfig(spilling))
+		unbounded = tortoiseFromSimState(s.GetState(1), WithLogger(logtest.New(t).Named("unbounded")), WithConfig(cfg))
+		last      types.LayerID
+	)
+	tally := func(lid types.LayerID) {
+		bounded.TallyVotes(ctx, lid)
+		unbounded.TallyVotes(ctx, lid)
+	}
+	for _, last = range sim.GenLayers(s, sim.WithSequence(5)) {
+		tally(last)
+	}
+	require.Equal(t, last.Sub(1), bounded.LatestComplete())
+
+	verified := bounded.LatestComplete()
+	for _, last = range sim.GenLayers(s,
+		sim.WithSequence(30, sim.WithVoteGenerator(abstainVoting)),
+	) {
+		tally(last)
+		require.LessOrEqual(t, len(bounded.trtl.ballotRefs), (resident+1)*size)
+	}
+	require.Equal(t, verified, bounded.LatestComplete())
+	require.Equal(t, verified, unbounded.LatestComplete())
+	require.Equal(t, last.Sub(resident), bounded.trtl.spilled)
+	require.Greater(t, len(unbounded.trtl.ballotRefs), 2*len(bounded.trtl.ballotRefs))
+
+	for i := 0; i < 20; i++ {
+		last = s.Next(sim.WithVoteGenerator(tortoiseVotingWithCurrent(bounded)))
+		tally(last)
+	}
+	require.Equal(t, last.Sub(1), bounded.LatestComplete())
+	require.Equal(t, last.Sub(1), unbounded.LatestComplete())
+	for lid := types.GetEffectiveGenesis().Add(1); lid.Before(last); lid = lid.Add(1) {
+		expected, err := blocks.ContextualValidity(s.GetState(1).DB, lid)
+		require.NoError(t, err)
+		validity, err := blocks.ContextualValidity(s.GetState(0).DB, lid)
+		require.NoError(t, err)
+		require.Equal(t, expected, validity, "layer %s", lid)
+	}
+}
+
+func TestSpillBoundedMemory(t *testing.T) {
+	ctx := context.Background()
+	const (
+		size     = 10
+		resident = 10
+	)
+	s := sim.New(sim.WithLayerSize(size), sim.WithStates(2))
+	s.Setup(sim.WithSetupMinerRange(size, size))
+
+	cfg := defaultTestConfig()
+	cfg.LayerSize = size
+	cfg.Hdist = 4
+	cfg.Zdist = 4
+	cfg.WindowSize = resident
+
+	spilling := cfg
+	spilling.MaxResidentLayers = resident
+	var (
+		bounded   = tortoiseFromSimState(s.GetState(0), WithLogger(logtest.New(t).Named("bounded")), WithConfig(spilling))
+		unbounded = tortoiseFromSimState(s.GetState(1), WithLogger(logtest.New(t).Named("unbounded")), WithConfig(cfg))
+		last      types.LayerID
+	)
+	tally := func(lid types.LayerID) {
+		bounded.TallyVotes(ctx, lid)
+		unbounded.TallyVotes(ctx, lid)
+	}
+	for _, last = range sim.GenLayers(s, sim.WithSequence(5)) {
+		tally(last)
+	}
+	verified := bounded.LatestComplete()
+
+	trtl := bounded.trtl
+	for _, last = range sim.GenLayers(s,
+		sim.WithSequence(50, sim.WithVoteGenerator(abstainVoting)),
+	) {
+		tally(last)
+		require.LessOrEqual(t, len(trtl.layers), resident+1, "layer %s", last)
+		require.LessOrEqual(t, len(trtl.ballotRefs), (resident+1)*size, "layer %s", last)
+		for _, block := range trtl.blockRefs {
+			require.True(t, block.layer.After(trtl.spilledBoundary()), "block %s in layer %s", block.id, block.layer)
+		}
+	}
+	require.Equal(t, verified, bounded.LatestComplete())
+	require.Greater(t, len(unbounded.trtl.layers), 4*len(trtl.layers))
+
+	for i := 0; i < 40; i++ {
+		last = s.Next(sim.WithVoteGenerator(tortoiseVotingWithCurrent(bounded)))
+		tally(last)
+	}
+	require.Equal(t, last.Sub(1), bounded.LatestComplete())
+	require.Equal(t, last.Sub(1), unbounded.LatestComplete())
+	for lid := types.GetEffectiveGenesis().Add(1); lid.Before(last); lid = lid.Add(1) {
+		expected, err := blocks.ContextualValidity(s.GetState(1).DB, lid)
+		require.NoError(t, err)
+		validity, err := blocks.ContextualValidity(s.GetState(0).DB, lid)
+		require.NoError(t, err)
+		require.Equal(t, expected, validity, "layer %s", lid)
+	}
+}
+
+func TestSpillLateBallots(t *testing.T) {
+	ctx := context.Background()
+	const size = 10
+	s := sim.New(sim.WithLayerSize(size))
+	s.Setup()
+
+	cfg := defaultTestConfig()
+	cfg.LayerSize = size
+	cfg.Hdist = 4
+	cfg.Zdist = 4
+	cfg.WindowSize = 5
+	cfg.MaxResidentLayers = 5
+	tortoise := tortoiseFromSimState(s.GetState(0), WithLogger(logtest.New(t)), WithConfig(cfg))
+
+	var last types.LayerID
+	for _, last = range sim.GenLayers(s, sim.WithSequence(20)) {
+		tortoise.TallyVotes(ctx, last)
+	}
+	require.Equal(t, last.Sub(1), tortoise.LatestComplete())
+	trtl := tortoise.trtl
+	spilled := trtl.spilled
+	require.True(t, spilled.After(trtl.evicted))
+	require.True(t, trtl.isSpilled(spilled))
+	require.NotContains(t, trtl.layers, spilled)
+
+	total := util.WeightFromUint64(0)
+	require.NoError(t, trtl.withBallots(spilled, func(ballots []*ballotInfo) {
+		require.NotEmpty(t, ballots)
+		for _, ballot := range ballots {
+			require.NotContains(t, trtl.ballotRefs, ballot.id, "ballots are not kept in memory")
+			total = total.Add(ballot.weight)
+		}
+	}))
+	require.NotContains(t, trtl.layers, spilled)
+
+	// ballot that was already counted is not counted twice
+	blts, err := ballots.Layer(s.GetState(0).DB, spilled)
+	require.NoError(t, err)
+	tortoise.OnBallot(blts[0])
+	require.NotContains(t, trtl.ballotRefs, blts[0].ID())
+	reloaded := util.WeightFromUint64(0)
+	require.NoError(t, trtl.withBallots(spilled, func(ballots []*ballotInfo) {
+		require.Len(t, ballots, len(blts))
+		for _, ballot := range ballots {
+			reloaded = reloaded.Add(ballot.weight)
+		}
+	}))
+	require.Zero(t, total.Cmp(reloaded))
+
+	// layer that is loaded back to memory is spilled again on the next pass
+	require.NotNil(t, trtl.layer(spilled))
+	require.Contains(t, trtl.layers, spilled)
+	last = s.Next()
+	tortoise.TallyVotes(ctx, last)
+	require.Equal(t, last.Sub(1), tortoise.LatestComplete())
+	require.NotContains(t, trtl.layers, spilled)
+}
+
+func TestSpillCheckpointRecovery(t *testing.T) {
+	ctx := context.Background()
+	const size = 10
+	s := sim.New(sim.WithLayerSize(size), sim.WithStates(2))
+	s.Setup()
+
+	cfg := defaultTestConfig()
+	cfg.LayerSize = size
+	cfg.CheckpointInterval = 10
+	cfg.WindowSize = 15
+	cfg.MaxResidentLayers = 15
+	var (
+		tortoise  = tortoiseFromSimState(s.GetState(0), WithLogger(logtest.New(t)), WithConfig(cfg))
+		reference = tortoiseFromSimState(s.GetState(1), WithLogger(logtest.New(t)), WithConfig(cfg))
+		last      types.LayerID
+	)
+	for i := 0; i < 45; i++ {
+		last = s.Next()
+		tortoise.TallyVotes(ctx, last)
+		reference.TallyVotes(ctx, last)
+	}
+	require.Equal(t, last.Sub(1), tortoise.LatestComplete())
+	require.True(t, tortoise.trtl.spilled.After(tortoise.trtl.evicted))
+
+	// some ballots were spilled after the checkpoint, they must be recovered from the checkpoint
+	cp, err := checkpoints.Last(s.GetState(0).DB)
+	require.NoError(t, err)
+	require.True(t, tortoise.trtl.spilled.After(cp.Layer.Sub(cfg.MaxResidentLayers)))
+
+	recovered := recoverTortoise(t, s.GetState(0), cfg, last)
+	require.Equal(t, cp.Layer, recovered.trtl.checkpointed, "state must be loaded from checkpoint")
+	require.Equal(t, last.Sub(1), recovered.LatestComplete())
+	require.Equal(t, encodedState(t, tortoise), encodedState(t, recovered))
+
+	// recovered tortoise shares the database with the original one, it is compared with the reference
+	// that received the same layers
+	for i := 0; i < 10; i++ {
+		last = s.Next()
+		reference.TallyVotes(ctx, last)
+		recovered.TallyVotes(ctx, last)
+	}
+	require.Equal(t, last.Sub(1), recovered.LatestComplete())
+	require.Equal(t, encodedState(t, reference), encodedState(t, recovered))
+}
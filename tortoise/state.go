@@ -56,6 +56,12 @@ type (
 		processed types.LayerID
 		// last evicted layer
 		evicted types.LayerID
+		// last layer that was moved out of memory
+		spilled types.LayerID
+		// last layer when state was stored in the database
+		checkpointed types.LayerID
+		// layers in the range (evicted, spilled] are stored in the database, see spill.go
+		store *spillStore
 
 		changedOpinion struct {
 			// sector of layers where opinion is different from previously computed opinion
@@ -92,6 +98,9 @@ func (s *state) expectedWeight(cfg Config, target types.LayerID) weight {
 
 func (s *state) layer(lid types.LayerID) *layerInfo {
 	layer, exist := s.layers[lid]
+	if !exist && s.isSpilled(lid) {
+		return s.loadLayer(lid)
+	}
 	if !exist {
 		layer = &layerInfo{lid: lid, empty: util.WeightFromUint64(0)}
 		s.layers[lid] = layer
@@ -125,7 +134,7 @@ func (s *state) addBlock(block *blockInfo) {
 
 func (s *state) findRefHeightBelow(lid types.LayerID) uint64 {
 	for lid = lid.Sub(1); lid.After(s.evicted); lid = lid.Sub(1) {
-		layer := s.layer(lid)
+		layer := s.peekLayer(lid)
 		if len(layer.blocks) == 0 {
 			continue
 		}
@@ -162,6 +171,9 @@ type layerInfo struct {
 	// it is stored as a pointer so that when previous layerInfo is evicted
 	// we still have access in case we need to recompute opinion for this layer
 	prevOpinion *types.Hash32
+
+	// decision is recorded on the last attempt to verify the layer.
+	decision *decisionInfo
 }
 
 func (l *layerInfo) computeOpinion(hdist uint32, last types.LayerID) {
@@ -206,6 +218,7 @@ type (
 
 	ballotInfo struct {
 		id         types.BallotID
+		atxid      types.ATXID
 		layer      types.LayerID
 		base       baseInfo
 		weight     weight
@@ -221,10 +234,15 @@ func (b *ballotInfo) opinion() types.Hash32 {
 
 type votes struct {
 	tail *layerVote
+	// spilled references the last vote if it was moved out of memory,
+	// used only if tail is nil.
+	spilled *spilledVote
 }
 
 func (v *votes) append(lv *layerVote) {
 	if v.tail == nil {
+		lv.spilled = v.spilled
+		v.spilled = nil
 		v.tail = lv
 	} else {
 		if v.tail.lid.Add(1) != lv.lid {
@@ -236,21 +254,33 @@ func (v *votes) append(lv *layerVote) {
 	v.tail.computeOpinion()
 }
 
-func (v *votes) update(from types.LayerID, diff map[types.LayerID]map[types.BlockID]sign) votes {
+// update copies votes starting from the layer and applies diff to them.
+// If spilled is not nil it replaces reference to the votes that were moved out of memory.
+func (v *votes) update(from types.LayerID, diff map[types.LayerID]map[types.BlockID]sign, spilled *spilledVote) votes {
 	if v.tail == nil {
-		return votes{}
+		if spilled != nil {
+			return votes{spilled: spilled}
+		}
+		return votes{spilled: v.spilled}
 	}
-	return votes{tail: v.tail.update(from, diff)}
+	return votes{tail: v.tail.update(from, diff, spilled)}
 }
 
 // cutBefore cuts all pointers to votes before the specified layer.
 func (v *votes) cutBefore(lid types.LayerID) {
+	if v.tail == nil && v.spilled != nil && v.spilled.lid.Before(lid) {
+		v.spilled = nil
+	}
 	for current := v.tail; current != nil; current = current.prev {
 		prev := current.prev
 		if prev != nil && prev.lid.Before(lid) {
 			current.prev = nil
 			return
 		}
+		if prev == nil && current.spilled != nil && current.spilled.lid.Before(lid) {
+			current.spilled = nil
+			return
+		}
 		if current.lid.Before(lid) {
 			v.tail = nil
 			return
@@ -273,10 +303,34 @@ func (v *votes) find(lid types.LayerID, bid types.BlockID) sign {
 }
 
 func (v *votes) opinion() types.Hash32 {
+	if v.tail != nil {
+		return v.tail.opinion
+	}
+	if v.spilled != nil {
+		return v.spilled.opinion
+	}
+	return types.Hash32{}
+}
+
+// bottom returns reference to the last vote that was moved out of memory.
+func (v *votes) bottom() *spilledVote {
 	if v.tail == nil {
-		return types.Hash32{}
+		return v.spilled
+	}
+	current := v.tail
+	for current.prev != nil {
+		current = current.prev
 	}
-	return v.tail.opinion
+	return current.spilled
+}
+
+// spilledVote references a vote that was moved out of memory together with the layer.
+type spilledVote struct {
+	lid types.LayerID
+	// index of the vote in the spilled layer, starting from 1.
+	// zero if the vote is not stored and only its opinion is known.
+	index   uint32
+	opinion types.Hash32
 }
 
 type layerVote struct {
@@ -286,6 +340,9 @@ type layerVote struct {
 	supported []*blockInfo
 
 	prev *layerVote
+	// spilled references the previous vote if it was moved out of memory,
+	// used only if prev is nil.
+	spilled *spilledVote
 }
 
 func (l *layerVote) getVote(bid types.BlockID) sign {
@@ -303,6 +360,7 @@ func (l *layerVote) copy() *layerVote {
 		vote:      l.vote,
 		supported: l.supported,
 		prev:      l.prev,
+		spilled:   l.spilled,
 	}
 }
 
@@ -311,13 +369,19 @@ func (l *layerVote) append(lv *layerVote) *layerVote {
 	return lv
 }
 
-func (l *layerVote) update(from types.LayerID, diff map[types.LayerID]map[types.BlockID]sign) *layerVote {
+func (l *layerVote) update(
+	from types.LayerID,
+	diff map[types.LayerID]map[types.BlockID]sign,
+	spilled *spilledVote,
+) *layerVote {
 	if l.lid.Before(from) {
 		return l
 	}
 	copied := l.copy()
 	if copied.prev != nil {
-		copied.prev = copied.prev.update(from, diff)
+		copied.prev = copied.prev.update(from, diff, spilled)
+	} else if spilled != nil {
+		copied.spilled = spilled
 	}
 	layerdiff, exist := diff[copied.lid]
 	if exist && len(layerdiff) == 0 {
@@ -349,6 +413,8 @@ func (l *layerVote) computeOpinion() {
 	hasher := opinionhash.New()
 	if l.prev != nil {
 		hasher.WritePrevious(l.prev.opinion)
+	} else if l.spilled != nil {
+		hasher.WritePrevious(l.spilled.opinion)
 	}
 	if len(l.supported) > 0 {
 		for _, block := range l.supported {
@@ -16,7 +16,7 @@ func TestVotesUpdate(t *testing.T) {
 		for i := 0; i < last; i++ {
 			original.append(&layerVote{layerInfo: &layerInfo{lid: types.NewLayerID(uint32(i))}})
 		}
-		cp := original.update(types.NewLayerID(last), nil)
+		cp := original.update(types.NewLayerID(last), nil, nil)
 		c1 := original.tail
 		c2 := cp.tail
 		for c1 != nil || c2 != nil {
@@ -32,7 +32,7 @@ func TestVotesUpdate(t *testing.T) {
 			original.append(&layerVote{layerInfo: &layerInfo{lid: types.NewLayerID(uint32(i))}})
 		}
 		const modified = last - 2
-		cp := original.update(types.NewLayerID(modified), nil)
+		cp := original.update(types.NewLayerID(modified), nil, nil)
 		c1 := original.tail
 		c2 := cp.tail
 		for c1 != nil || c2 != nil {
@@ -56,7 +56,7 @@ func TestVotesUpdate(t *testing.T) {
 			})
 			update[types.NewLayerID(uint32(i))] = map[types.BlockID]sign{}
 		}
-		cp := original.update(types.NewLayerID(0), update)
+		cp := original.update(types.NewLayerID(0), update, nil)
 		for c := original.tail; c != nil; c = c.prev {
 			require.Equal(t, against, c.vote)
 		}
@@ -81,7 +81,7 @@ func TestVotesUpdate(t *testing.T) {
 				{byte(i)}: support,
 			}
 		}
-		cp := original.update(types.NewLayerID(0), update)
+		cp := original.update(types.NewLayerID(0), update, nil)
 		for c := original.tail; c != nil; c = c.prev {
 			require.Len(t, c.supported, 0)
 		}
@@ -181,7 +181,7 @@ func TestComputeOpinion(t *testing.T) {
 		})
 		v := original.update(updated, map[types.LayerID]map[types.BlockID]sign{
 			updated: {blocks[0].id: against},
-		})
+		}, nil)
 		hh := opinionhash.New()
 		rst := types.Hash32{}
 		hh.Sum(rst[:0])
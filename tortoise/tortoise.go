@@ -13,14 +13,16 @@ import (
 	"github.com/spacemeshos/go-spacemesh/sql"
 	"github.com/spacemeshos/go-spacemesh/sql/ballots"
 	"github.com/spacemeshos/go-spacemesh/sql/blocks"
+	"github.com/spacemeshos/go-spacemesh/sql/checkpoints"
 	"github.com/spacemeshos/go-spacemesh/sql/layers"
 	"github.com/spacemeshos/go-spacemesh/system"
 	"github.com/spacemeshos/go-spacemesh/tortoise/metrics"
 )
 
 var (
-	errNoBaseBallotFound    = errors.New("no good base ballot within exception vector limit")
-	errstrTooManyExceptions = "too many exceptions to base ballot vote"
+	errNoBaseBallotFound        = errors.New("no good base ballot within exception vector limit")
+	errUnknownOpinionCheckpoint = errors.New("opinion checkpoint not consistent with state")
+	errstrTooManyExceptions     = "too many exceptions to base ballot vote"
 )
 
 type turtle struct {
@@ -37,6 +39,9 @@ type turtle struct {
 
 	isFull bool
 	full   *full
+
+	// recent mode switches, bounded by maxModeSwitches.
+	modeSwitches []modeSwitch
 }
 
 // newTurtle creates a new verifying tortoise algorithm instance.
@@ -61,6 +66,8 @@ func newTurtle(
 	t.processed = genesis
 	t.verified = genesis
 	t.evicted = genesis.Sub(1)
+	t.checkpointed = genesis
+	t.store = newSpillStore(cdb, logger)
 
 	t.epochs[genesis.GetEpoch()] = &epochInfo{}
 	t.layers[genesis] = &layerInfo{
@@ -86,13 +93,14 @@ func (t *turtle) lookbackWindowStart() (types.LayerID, bool) {
 }
 
 // evict makes sure we only keep a window of the last hdist layers.
+//
+// Eviction depends on the verified layer, if tortoise can't verify layers
+// memory is bounded by spilling ballots to the database (see spill).
 func (t *turtle) evict(ctx context.Context) {
 	// Don't evict before we've verified at least hdist layers
 	if !t.verified.After(types.GetEffectiveGenesis().Add(t.Hdist)) {
 		return
 	}
-	// TODO: fix potential leak when we can't verify but keep receiving layers
-	//    see https://github.com/spacemeshos/go-spacemesh/issues/2671
 
 	windowStart, ok := t.lookbackWindowStart()
 	if !ok {
@@ -108,19 +116,31 @@ func (t *turtle) evict(ctx context.Context) {
 	)
 
 	for lid := t.evicted.Add(1); lid.Before(windowStart); lid = lid.Add(1) {
-		for _, ballot := range t.layer(lid).ballots {
-			delete(t.ballotRefs, ballot.id)
-		}
-		for _, block := range t.layers[lid].blocks {
-			delete(t.blockRefs, block.id)
+		if layer, exist := t.layers[lid]; exist {
+			for _, ballot := range layer.ballots {
+				delete(t.ballotRefs, ballot.id)
+			}
+			for _, block := range layer.blocks {
+				delete(t.blockRefs, block.id)
+			}
+			delete(t.layers, lid)
 		}
-		delete(t.layers, lid)
 		if lid.OrdinalInEpoch() == types.GetLayersPerEpoch()-1 {
 			delete(t.epochs, lid.GetEpoch())
 		}
 	}
-	for _, ballot := range t.layer(windowStart).ballots {
-		ballot.votes.cutBefore(windowStart)
+	if layer, exist := t.layers[windowStart]; exist {
+		for _, ballot := range layer.ballots {
+			ballot.votes.cutBefore(windowStart)
+		}
+	}
+	t.prunePending(windowStart)
+	// with checkpoints enabled spilled layers are pruned when checkpoint is taken,
+	// as they are needed to recover from the last checkpoint
+	if t.CheckpointInterval == 0 && t.spilled.After(t.evicted) {
+		if err := checkpoints.PruneSpilledBefore(t.cdb, windowStart); err != nil {
+			t.logger.With().Error("failed to prune spilled layers", log.Err(err))
+		}
 	}
 	t.evicted = windowStart.Sub(1)
 }
@@ -141,8 +161,8 @@ func (t *turtle) EncodeVotes(ctx context.Context, conf *encodeConf) (*types.Opin
 		current = *conf.current
 	}
 
-	for lid := t.evicted.Add(1); !lid.After(t.processed); lid = lid.Add(1) {
-		for _, ballot := range t.layer(lid).ballots {
+	collect := func(ballots []*ballotInfo) {
+		for _, ballot := range ballots {
 			if ballot.weight.IsNil() {
 				continue
 			}
@@ -155,26 +175,54 @@ func (t *turtle) EncodeVotes(ctx context.Context, conf *encodeConf) (*types.Opin
 			choices = append(choices, ballot)
 		}
 	}
-
-	prioritizeBallots(choices, disagreements)
-	if len(choices) == 0 {
-		choices = append(choices, &ballotInfo{layer: types.GetEffectiveGenesis()})
+	choose := func() {
+		prioritizeBallots(choices, disagreements)
+		if len(choices) == 0 {
+			choices = append(choices, &ballotInfo{layer: types.GetEffectiveGenesis()})
+		}
+		for _, base = range choices {
+			opinion, err = t.encodeVotes(ctx, base, t.evicted.Add(1), current)
+			if err == nil {
+				break
+			}
+			logger.With().Warning("error calculating vote exceptions for ballot",
+				base.id,
+				log.Err(err),
+				log.Stringer("current layer", current),
+			)
+		}
 	}
-	for _, base = range choices {
-		opinion, err = t.encodeVotes(ctx, base, t.evicted.Add(1), current)
-		if err == nil {
-			break
+
+	if err := t.flushSpilled(); err != nil {
+		return nil, err
+	}
+	for lid := t.spilledBoundary().Add(1); !lid.After(t.processed); lid = lid.Add(1) {
+		collect(t.layer(lid).ballots)
+	}
+	choose()
+	if opinion == nil && t.spilled.After(t.evicted) {
+		// none of the ballots in memory can be used as a base ballot, try ballots that were spilled
+		choices = choices[:0]
+		for lid := t.evicted.Add(1); !lid.After(t.spilled); lid = lid.Add(1) {
+			if err := t.withBallots(lid, collect); err != nil {
+				return nil, err
+			}
+		}
+		if len(choices) > 0 {
+			choose()
 		}
-		logger.With().Warning("error calculating vote exceptions for ballot",
-			base.id,
-			log.Err(err),
-			log.Stringer("current layer", current),
-		)
 	}
 
 	if opinion == nil {
-		// TODO: special error encoding when exceeding exception list size
-		return nil, errNoBaseBallotFound
+		// none of the ballots can be used as a base ballot, votes are encoded relative to the local opinion
+		base, opinion, err = t.encodeOpinionCheckpoint(ctx, current)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", errNoBaseBallotFound, err)
+		}
+		logger.With().Info("encoded votes with opinion checkpoint",
+			log.Stringer("checkpoint layer", opinion.Votes.Checkpoint.Layer),
+			log.Stringer("voting layer", current),
+		)
 	}
 	logger.With().Info("choose base ballot",
 		log.Stringer("base layer", base.layer),
@@ -195,21 +243,27 @@ func (t *turtle) firstDisagreement(ctx context.Context, current types.LayerID, b
 		return basedis, nil
 	}
 
-	for lvote := ballot.votes.tail; lvote != nil; lvote = lvote.prev {
+	var (
+		rst    = consistent
+		reterr error
+	)
+	if err := t.walkVotes(ballot.votes, func(lvote *layerVote) bool {
 		if lvote.lid.Before(ballot.base.layer) {
-			break
+			return false
 		}
 		if lvote.vote == abstain && lvote.hareTerminated {
 			t.logger.With().Debug("ballot votes abstain on a terminated layer. can't use as a base ballot",
 				ballot.id,
 				lvote.lid,
 			)
-			return types.LayerID{}, nil
+			rst = types.LayerID{}
+			return false
 		}
 		for _, block := range lvote.blocks {
 			vote, _, err := t.getFullVote(t.verified, current, block)
 			if err != nil {
-				return types.LayerID{}, err
+				reterr = err
+				return false
 			}
 			if bvote := lvote.getVote(block.id); vote != bvote {
 				t.logger.With().Debug("found disagreement on a block",
@@ -220,11 +274,18 @@ func (t *turtle) firstDisagreement(ctx context.Context, current types.LayerID, b
 					log.Stringer("local_vote", vote),
 					log.Stringer("vote", bvote),
 				)
-				return lvote.lid, nil
+				rst = lvote.lid
+				return false
 			}
 		}
+		return true
+	}); err != nil {
+		return types.LayerID{}, err
 	}
-	return consistent, nil
+	if reterr != nil {
+		return types.LayerID{}, reterr
+	}
+	return rst, nil
 }
 
 // encode differences between selected base ballot and local votes.
@@ -242,22 +303,25 @@ func (t *turtle) encodeVotes(
 		Base: base.id,
 	}
 	// encode difference with local opinion between [start, base.layer)
-	for lvote := base.votes.tail; lvote != nil; lvote = lvote.prev {
+	var reterr error
+	if err := t.walkVotes(base.votes, func(lvote *layerVote) bool {
 		if lvote.lid.Before(start) {
-			break
+			return false
 		}
 		if lvote.vote == abstain && lvote.hareTerminated {
-			return nil, fmt.Errorf("ballot %s can't be used as a base ballot", base.id)
+			reterr = fmt.Errorf("ballot %s can't be used as a base ballot", base.id)
+			return false
 		}
 		if lvote.vote != abstain && !lvote.hareTerminated {
 			logger.With().Debug("voting abstain on the layer", lvote.lid)
 			votes.Abstain = append(votes.Abstain, lvote.lid)
-			continue
+			return true
 		}
 		for _, block := range lvote.blocks {
 			vote, reason, err := t.getFullVote(t.verified, current, block)
 			if err != nil {
-				return nil, err
+				reterr = err
+				return false
 			}
 			// ballot vote is consistent with local opinion, exception is not necessary
 			bvote := lvote.getVote(block.id)
@@ -285,10 +349,16 @@ func (t *turtle) encodeVotes(
 				)
 			}
 		}
+		return true
+	}); err != nil {
+		return nil, err
+	}
+	if reterr != nil {
+		return nil, reterr
 	}
 	// encode votes after base ballot votes [base layer, last)
 	for lid := base.layer; lid.Before(current); lid = lid.Add(1) {
-		layer := t.layer(lid)
+		layer := t.peekLayer(lid)
 		if !layer.hareTerminated {
 			logger.With().Debug("voting abstain on the layer", lid)
 			votes.Abstain = append(votes.Abstain, lid)
@@ -320,7 +390,10 @@ func (t *turtle) encodeVotes(
 	if explen := len(votes.Support) + len(votes.Against); explen > t.MaxExceptions {
 		return nil, fmt.Errorf("%s (%v)", errstrTooManyExceptions, explen)
 	}
-	decoded := t.decodeExceptions(current, base, &conditions{}, votes)
+	decoded, err := t.decodeExceptions(current, base, &conditions{}, votes, false)
+	if err != nil {
+		return nil, err
+	}
 	return &types.Opinion{
 		Hash:  decoded.opinion(),
 		Votes: votes,
@@ -352,7 +425,10 @@ func (t *turtle) getFullVote(verified, current types.LayerID, block *blockInfo)
 
 func (t *turtle) onLayer(ctx context.Context, last types.LayerID) error {
 	t.logger.With().Debug("on layer", last)
-	defer t.evict(ctx)
+	defer func() {
+		t.evict(ctx)
+		t.spill()
+	}()
 	if last.After(t.last) {
 		t.last = last
 	}
@@ -404,8 +480,9 @@ func (t *turtle) onLayer(ctx context.Context, last types.LayerID) error {
 	return t.verifyLayers()
 }
 
-func (t *turtle) switchModes(logger log.Log) {
+func (t *turtle) switchModes(logger log.Log, target types.LayerID) {
 	t.isFull = !t.isFull
+	t.recordModeSwitch(target)
 	logger.With().Debug("switching tortoise mode",
 		log.Uint32("hdist", t.Hdist),
 		log.Stringer("processed_layer", t.processed),
@@ -434,10 +511,15 @@ func (t *turtle) verifyLayers() error {
 		)
 		verified = maxLayer(t.evicted, types.GetEffectiveGenesis())
 	)
+	if err := t.flushSpilled(); err != nil {
+		return err
+	}
 
 	if t.changedOpinion.min.Value != 0 && !withinDistance(t.Hdist, t.changedOpinion.max, t.last) {
 		logger.With().Debug("changed opinion outside hdist", log.Stringer("from", t.changedOpinion.min), log.Stringer("to", t.changedOpinion.max))
-		t.onOpinionChange(t.changedOpinion.min)
+		if err := t.onOpinionChange(t.changedOpinion.min); err != nil {
+			return err
+		}
 		t.changedOpinion.min = types.LayerID{}
 		t.changedOpinion.max = types.LayerID{}
 	}
@@ -445,18 +527,25 @@ func (t *turtle) verifyLayers() error {
 	for target := t.evicted.Add(1); target.Before(t.processed); target = target.Add(1) {
 		success := t.verifying.verify(logger, target)
 		if success && t.isFull {
-			t.switchModes(logger)
+			t.switchModes(logger, target)
 		}
 		if !success && (t.isFull || !withinDistance(t.Hdist, target, t.last)) {
 			if !t.isFull {
-				t.switchModes(logger)
+				t.switchModes(logger, target)
 				for counted := maxLayer(t.full.counted.Add(1), t.evicted.Add(1)); !counted.After(t.processed); counted = counted.Add(1) {
-					for _, ballot := range t.layer(counted).ballots {
-						t.full.countBallot(logger, ballot)
+					if err := t.withBallots(counted, func(ballots []*ballotInfo) {
+						for _, ballot := range ballots {
+							t.full.countBallot(logger, ballot)
+						}
+					}); err != nil {
+						return err
 					}
 					t.full.countDelayed(logger, counted)
 					t.full.counted = counted
 				}
+				if err := t.flushSpilled(); err != nil {
+					return err
+				}
 			}
 			success = t.full.verify(logger, target)
 		}
@@ -583,6 +672,13 @@ func (t *turtle) onBlock(lid types.LayerID, block *types.Block) error {
 	if !lid.After(t.evicted) {
 		return nil
 	}
+	if t.isSpilled(lid) {
+		// votes counted before the block is added must not be counted against it
+		if err := t.flushSpilled(); err != nil {
+			return err
+		}
+		t.layer(lid)
+	}
 	if _, exist := t.state.blockRefs[block.ID()]; exist {
 		return nil
 	}
@@ -598,7 +694,17 @@ func (t *turtle) onBlock(lid types.LayerID, block *types.Block) error {
 		binfo.hare = against
 	}
 	t.addBlock(binfo)
-	t.full.countForLateBlock(binfo)
+	// ballots are always added to the state after blocks that are
+	// explicitly referenced in the ballot.
+	// therefore if block is added later - all previous ballots vote
+	// for it negatively.
+	for lid := binfo.layer.Add(1); !lid.After(t.full.counted); lid = lid.Add(1) {
+		if err := t.withBallots(lid, func(ballots []*ballotInfo) {
+			t.full.countForLateBlock(binfo, ballots)
+		}); err != nil {
+			return err
+		}
+	}
 	if !binfo.layer.After(t.processed) {
 		if err := t.updateRefHeight(t.layer(binfo.layer), binfo); err != nil {
 			return err
@@ -640,22 +746,41 @@ func (t *turtle) onHareOutput(lid types.LayerID, bid types.BlockID) {
 			log.Stringer("previous", previous),
 			log.Stringer("new", bid),
 		)
-		t.onOpinionChange(lid)
+		if err := t.onOpinionChange(lid); err != nil {
+			t.logger.With().Error("failed to recount votes after opinion change", lid, log.Err(err))
+		}
 	}
 }
 
-func (t *turtle) onOpinionChange(lid types.LayerID) {
+func (t *turtle) onOpinionChange(lid types.LayerID) error {
+	if err := t.flushSpilled(); err != nil {
+		return err
+	}
+	var prev *types.Hash32
 	for recompute := lid; !recompute.After(t.processed); recompute = recompute.Add(1) {
-		layer := t.layer(recompute)
-		layer.computeOpinion(t.Hdist, t.last)
-		t.logger.With().Debug("computed local opinion",
-			layer.lid,
-			log.Stringer("local opinion", layer.opinion))
+		t.updateLayer(recompute, func(layer *layerInfo) {
+			if _, exist := t.layers[recompute.Sub(1)]; !exist && prev != nil && layer.prevOpinion != nil {
+				// previous layer is not in memory, opinion is updated by value
+				opinion := *prev
+				layer.prevOpinion = &opinion
+			}
+			layer.computeOpinion(t.Hdist, t.last)
+			opinion := layer.opinion
+			prev = &opinion
+			t.logger.With().Debug("computed local opinion",
+				layer.lid,
+				log.Stringer("local opinion", layer.opinion))
+		})
 	}
 	t.verifying.resetWeights(lid)
 	for target := lid.Add(1); !target.After(t.processed); target = target.Add(1) {
-		t.verifying.countVotes(t.logger, t.layer(target).ballots)
+		if err := t.withBallots(target, func(ballots []*ballotInfo) {
+			t.verifying.countVotes(t.logger, ballots)
+		}); err != nil {
+			return err
+		}
 	}
+	return nil
 }
 
 func (t *turtle) onAtx(atx *types.ActivationTxHeader) {
@@ -683,6 +808,12 @@ func (t *turtle) decodeBallot(ballot *types.Ballot) (*ballotInfo, error) {
 	if _, exist := t.state.ballotRefs[ballot.ID()]; exist {
 		return nil, nil
 	}
+	// ballot may be already counted and moved out of memory together with the layer
+	if spilled, err := t.spilledBallot(ballot.LayerIndex, ballot.ID()); err != nil {
+		return nil, err
+	} else if spilled != nil {
+		return nil, nil
+	}
 	t.logger.With().Debug("on ballot",
 		log.Inline(ballot),
 		log.Uint32("processed", t.processed.Value),
@@ -694,10 +825,24 @@ func (t *turtle) decodeBallot(ballot *types.Ballot) (*ballotInfo, error) {
 		refinfo *referenceInfo
 	)
 
-	if ballot.Votes.Base == types.EmptyBallotID {
+	if cp := ballot.Votes.Checkpoint; cp != nil {
+		base = t.decodeOpinionCheckpoint(ballot.LayerIndex, cp)
+		if base == nil {
+			metrics.UnknownOpinionCheckpoints.Inc()
+			return nil, fmt.Errorf("%w: ballot %s checkpoint %s/%s",
+				errUnknownOpinionCheckpoint, ballot.ID(), cp.Layer, cp.Opinion.ShortString(),
+			)
+		}
+	} else if ballot.Votes.Base == types.EmptyBallotID {
 		base = &ballotInfo{layer: types.GetEffectiveGenesis()}
 	} else {
 		base = t.state.ballotRefs[ballot.Votes.Base]
+		if base == nil {
+			var err error
+			if base, err = t.findSpilledBallot(ballot.Votes.Base); err != nil {
+				return nil, err
+			}
+		}
 		if base == nil {
 			t.logger.With().Warning("base ballot not in state",
 				log.Stringer("base", ballot.Votes.Base),
@@ -723,6 +868,13 @@ func (t *turtle) decodeBallot(ballot *types.Ballot) (*ballotInfo, error) {
 		}
 	} else {
 		ref, exists := t.state.ballotRefs[ballot.RefBallot]
+		if !exists {
+			var err error
+			if ref, err = t.findSpilledBallot(ballot.RefBallot); err != nil {
+				return nil, err
+			}
+			exists = ref != nil
+		}
 		if !exists {
 			t.logger.With().Warning("ref ballot not in state",
 				log.Stringer("ref", ballot.RefBallot),
@@ -749,7 +901,8 @@ func (t *turtle) decodeBallot(ballot *types.Ballot) (*ballotInfo, error) {
 		log.Uint32("lid", ballot.LayerIndex.Value),
 	)
 	binfo := &ballotInfo{
-		id: ballot.ID(),
+		id:    ballot.ID(),
+		atxid: ballot.AtxID,
 		base: baseInfo{
 			id:    base.id,
 			layer: base.layer,
@@ -758,7 +911,11 @@ func (t *turtle) decodeBallot(ballot *types.Ballot) (*ballotInfo, error) {
 		layer:     ballot.LayerIndex,
 		weight:    weight,
 	}
-	binfo.votes = t.decodeExceptions(binfo.layer, base, &binfo.conditions, ballot.Votes)
+	var err error
+	binfo.votes, err = t.decodeExceptions(binfo.layer, base, &binfo.conditions, ballot.Votes, true)
+	if err != nil {
+		return nil, err
+	}
 	t.logger.With().Debug("decoded exceptions",
 		binfo.id, binfo.layer,
 		log.Stringer("opinion", binfo.opinion()),
@@ -772,6 +929,10 @@ func (t *turtle) storeBallot(ballot *ballotInfo) error {
 			return err
 		}
 	}
+	if t.isSpilled(ballot.layer) {
+		// ballot is counted, but its layer is not in memory
+		return t.spillBallot(ballot)
+	}
 	t.state.addBallot(ballot)
 	return nil
 }
@@ -800,38 +961,44 @@ func (t *turtle) compareBeacons(logger log.Log, bid types.BallotID, layerID type
 	return false, nil
 }
 
-func (t *turtle) decodeExceptions(blid types.LayerID, base *ballotInfo, cond *conditions, exceptions types.Votes) votes {
+// decodeExceptions applies exceptions to the votes of the base ballot.
+//
+// Votes for the layers that were moved out of memory are computed from the stored votes,
+// and appended to the stored layers if persist is true.
+func (t *turtle) decodeExceptions(
+	blid types.LayerID,
+	base *ballotInfo,
+	cond *conditions,
+	exceptions types.Votes,
+	persist bool,
+) (votes, error) {
 	from := base.layer
 	diff := map[types.LayerID]map[types.BlockID]sign{}
-	for _, svote := range exceptions.Support {
-		block, exist := t.blockRefs[svote.ID]
-		if !exist {
-			continue
+	addDiff := func(vote types.Vote, s sign) error {
+		lid, exist, err := t.blockLayer(vote.ID)
+		if err != nil || !exist {
+			return err
 		}
-		if block.layer.Before(from) {
-			from = block.layer
+		if lid.Before(from) {
+			from = lid
 		}
-		layerdiff, exist := diff[block.layer]
+		layerdiff, exist := diff[lid]
 		if !exist {
 			layerdiff = map[types.BlockID]sign{}
-			diff[block.layer] = layerdiff
+			diff[lid] = layerdiff
 		}
-		layerdiff[block.id] = support
+		layerdiff[vote.ID] = s
+		return nil
 	}
-	for _, avote := range exceptions.Against {
-		block, exist := t.blockRefs[avote.ID]
-		if !exist {
-			continue
-		}
-		if block.layer.Before(from) {
-			from = block.layer
+	for _, svote := range exceptions.Support {
+		if err := addDiff(svote, support); err != nil {
+			return votes{}, err
 		}
-		layerdiff, exist := diff[block.layer]
-		if !exist {
-			layerdiff = map[types.BlockID]sign{}
-			diff[block.layer] = layerdiff
+	}
+	for _, avote := range exceptions.Against {
+		if err := addDiff(avote, against); err != nil {
+			return votes{}, err
 		}
-		layerdiff[block.id] = against
 	}
 	for _, lid := range exceptions.Abstain {
 		if lid.Before(from) {
@@ -843,10 +1010,34 @@ func (t *turtle) decodeExceptions(blid types.LayerID, base *ballotInfo, cond *co
 		}
 	}
 
-	// inherit opinion from the base ballot by copying votes
-	decoded := base.votes.update(from, diff)
+	var (
+		decoded votes
+		next    = base.layer
+		top     = t.spilledBoundary()
+		low     = maxLayer(from, t.evicted.Add(1))
+	)
+	switch {
+	case t.spilled.After(t.evicted) && !blid.After(top.Add(1)) && low.Before(blid):
+		// all votes are for the layers that are not in memory
+		spilled, err := t.spillVotes(base, low, blid.Sub(1), diff, persist)
+		if err != nil {
+			return votes{}, err
+		}
+		return votes{spilled: spilled}, nil
+	case t.spilled.After(t.evicted) && !low.After(top):
+		// inherit opinion for the layers that are not in memory from the stored votes
+		spilled, err := t.spillVotes(base, low, top, diff, persist)
+		if err != nil {
+			return votes{}, err
+		}
+		decoded = base.votes.update(top.Add(1), diff, spilled)
+		next = maxLayer(next, top.Add(1))
+	default:
+		// inherit opinion from the base ballot by copying votes
+		decoded = base.votes.update(from, diff, nil)
+	}
 	// add new opinions after the base layer
-	for lid := base.layer; lid.Before(blid); lid = lid.Add(1) {
+	for lid := next; lid.Before(blid); lid = lid.Add(1) {
 		layer := t.layer(lid)
 		lvote := layerVote{
 			layerInfo: layer,
@@ -865,7 +1056,7 @@ func (t *turtle) decodeExceptions(blid types.LayerID, base *ballotInfo, cond *co
 		}
 		decoded.append(&lvote)
 	}
-	return decoded
+	return decoded, nil
 }
 
 func withinDistance(dist uint32, lid, last types.LayerID) bool {
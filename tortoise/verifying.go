@@ -22,17 +22,19 @@ type verifying struct {
 }
 
 // reset all weight that can vote on a voted layer.
+// weight counted for the spilled layers must be flushed before reset.
 func (v *verifying) resetWeights(voted types.LayerID) {
-	vlayer := v.layer(voted)
-	v.totalGoodWeight = vlayer.verifying.goodUncounted.Copy()
+	goodUncounted := v.peekLayer(voted).verifying.goodUncounted.Copy()
+	v.totalGoodWeight = goodUncounted.Copy()
 	for lid := voted.Add(1); !lid.After(v.processed); lid = lid.Add(1) {
-		layer := v.layer(lid)
-		layer.verifying.goodUncounted = vlayer.verifying.goodUncounted.Copy()
+		v.updateLayer(lid, func(layer *layerInfo) {
+			layer.verifying.goodUncounted = goodUncounted.Copy()
+		})
 	}
 }
 
 func (v *verifying) countBallot(logger log.Log, ballot *ballotInfo) {
-	prev := v.layer(ballot.layer.Sub(1))
+	prev := v.peekLayer(ballot.layer.Sub(1))
 	counted := !(ballot.conditions.badBeacon ||
 		prev.opinion != ballot.opinion() ||
 		prev.verifying.referenceHeight > ballot.reference.height)
@@ -52,9 +54,16 @@ func (v *verifying) countBallot(logger log.Log, ballot *ballotInfo) {
 	}
 
 	for lid := ballot.layer; !lid.After(v.processed); lid = lid.Add(1) {
+		if v.isSpilled(lid) {
+			continue
+		}
 		layer := v.layer(lid)
 		layer.verifying.goodUncounted = layer.verifying.goodUncounted.Add(ballot.weight)
 	}
+	if v.isSpilled(ballot.layer) {
+		// weight is added to the spilled layers when pending weight is flushed
+		v.countSpilledGood(ballot.layer, ballot.weight)
+	}
 	v.totalGoodWeight = v.totalGoodWeight.Add(ballot.weight)
 }
 
@@ -66,8 +75,11 @@ func (v *verifying) countVotes(logger log.Log, ballots []*ballotInfo) {
 
 func (v *verifying) verify(logger log.Log, lid types.LayerID) bool {
 	layer := v.layer(lid)
+	decision := &decisionInfo{mode: verifyingMode}
+	layer.decision = decision
 	if !layer.hareTerminated {
 		logger.With().Debug("hare is not terminated")
+		decision.reason = reasonHareNotTerminated
 		return false
 	}
 
@@ -81,6 +93,11 @@ func (v *verifying) verify(logger log.Log, lid types.LayerID) bool {
 	}
 
 	threshold := v.globalThreshold(v.Config, lid)
+	decision.threshold = threshold
+	decision.margin = margin
+	decision.uncounted = uncounted
+	decision.totalGoodWeight = v.totalGoodWeight.Copy()
+	decision.goodUncounted = layer.verifying.goodUncounted.Copy()
 	logger = logger.WithFields(
 		log.String("verifier", "verifying"),
 		log.Stringer("candidate layer", lid),
@@ -92,19 +109,20 @@ func (v *verifying) verify(logger log.Log, lid types.LayerID) bool {
 	)
 	if sign(margin.Cmp(threshold)) != support {
 		logger.With().Debug("doesn't cross global threshold")
+		decision.reason = reasonBelowThreshold
 		return false
 	} else {
 		logger.With().Debug("crosses global threshold")
 	}
-	return verifyLayer(
+	return decision.record(verifyLayer(
 		logger,
 		layer.blocks,
-		func(block *blockInfo) sign {
+		decision.recordBlock(func(block *blockInfo) sign {
 			if block.height > layer.verifying.referenceHeight {
 				return neutral
 			}
 			decision, _ := getLocalVote(v.Config, v.state.verified, v.state.last, block)
 			return decision
-		},
-	)
+		}),
+	))
 }